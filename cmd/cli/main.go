@@ -0,0 +1,16 @@
+package main
+
+import (
+	_ "github.com/edumes/golang-api-rest/docs"
+	"github.com/edumes/golang-api-rest/internal/cli"
+)
+
+// @title Golang API REST
+// @version 1.0
+// @description API REST in Go with Clean Architecture
+// @host localhost:8080
+// @BasePath /
+
+func main() {
+	cli.Execute()
+}
@@ -0,0 +1,289 @@
+package api
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// productImageFileField is the multipart field that carries an image.
+const productImageFileField = "file"
+
+type AttachmentHandler struct {
+	service AttachmentService
+	logger  *logrus.Logger
+}
+
+func NewAttachmentHandler(service AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+// RegisterPublicRoutes registers the file downloads. Their links are handed
+// out to be used from img tags and browsers, which send no Authorization
+// header, so they are authenticated by the signature in their URL.
+func (h *AttachmentHandler) RegisterPublicRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering public file routes")
+	r.GET(FilesEndpoint, h.DownloadFile)
+}
+
+func (h *AttachmentHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering product image routes")
+	write := RequirePermission(domain.ScopeActionWrite, "products")
+	r.POST(ProductImages, write, h.UploadProductImage)
+	r.GET(ProductImages, h.ListProductImages)
+	r.DELETE(ProductImageByID, write, h.DeleteProductImage)
+}
+
+type downloadFileQuery struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
+
+// @Summary Upload product image
+// @Description Upload an image of a product in the multipart field "file" (admin only). JPEG, PNG, GIF and WebP are accepted, told apart by their content rather than by the file name, up to STORAGE_MAX_IMAGE_SIZE bytes (5 MiB by default). The answer carries a signed download URL that works until url_expires_at.
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param file formData file true "Image file"
+// @Success 201 {object} domain.Attachment
+// @Header 201 {string} Location "URL of the product's images"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 413 {object} map[string]interface{} "Image too large"
+// @Failure 415 {object} map[string]interface{} "Not a JPEG, PNG, GIF or WebP image"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/products/{id}/images [post]
+func (h *AttachmentHandler) UploadProductImage(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid product ID format for image upload")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"product_id": productID,
+		"user_id":    userID,
+		"ip":         c.ClientIP(),
+	}).Info("Uploading product image")
+
+	file, err := multipartFile(c, productImageFileField)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid product image upload")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	defer file.Close()
+
+	image, err := h.service.UploadProductImage(c.Request.Context(), productID, userID, file.FileName(), file)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Warn("Failed to upload product image")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+	image.URL = absoluteURL(c, image.URL)
+
+	h.logger.WithFields(logrus.Fields{
+		"image_id":   image.ID,
+		"product_id": productID,
+		"size":       image.Size,
+	}).Info("Product image uploaded successfully")
+
+	c.Header("Location", resourcePath(ProductImages, productID.String()))
+	c.JSON(StatusCreated, image)
+}
+
+// @Summary List product images
+// @Description List the images of a product, oldest first, each with a freshly signed download URL that works until url_expires_at.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Success 200 {array} domain.Attachment
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/products/{id}/images [get]
+func (h *AttachmentHandler) ListProductImages(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid product ID format for image list")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"product_id": productID,
+		"ip":         c.ClientIP(),
+	}).Info("Listing product images")
+
+	images, err := h.service.ListProductImages(c.Request.Context(), productID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Error("Failed to list product images")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+	for i := range images {
+		images[i].URL = absoluteURL(c, images[i].URL)
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+		"count":      len(images),
+	}).Info("Product images listed successfully")
+
+	c.JSON(StatusOK, images)
+}
+
+// @Summary Delete product image
+// @Description Delete an image of a product and its file (admin only). Download URLs already handed out stop working.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param imageId path string true "Image ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/products/{id}/images/{imageId} [delete]
+func (h *AttachmentHandler) DeleteProductImage(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+	imageID, err := uuid.Parse(c.Param("imageId"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("imageId"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid image ID format for deletion")
+		abortWithMessage(c, StatusBadRequest, "invalid image id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"product_id": productID,
+		"image_id":   imageID,
+		"ip":         c.ClientIP(),
+	}).Info("Deleting product image")
+
+	if err := h.service.DeleteProductImage(c.Request.Context(), productID, imageID); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+			"image_id":   imageID,
+		}).Error("Failed to delete product image")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+		"image_id":   imageID,
+	}).Info("Product image deleted successfully")
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary Download file
+// @Description Download an uploaded file through a signed URL handed out by the API, such as the url of a product image. The expires and signature parameters stand in for the Authorization header. Only used when files are kept on the API's own disk; with S3 storage the URLs point at the bucket instead.
+// @Tags files
+// @Produce octet-stream
+// @Param key path string true "File key"
+// @Param expires query int true "Unix time the URL expires at"
+// @Param signature query string true "URL signature"
+// @Success 200 {file} file "File content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 403 {object} map[string]interface{} "Invalid or expired signature"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/files/{key} [get]
+func (h *AttachmentHandler) DownloadFile(c *gin.Context) {
+	var query downloadFileQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	expiresAt := time.Unix(query.Expires, 0)
+
+	attachment, file, err := h.service.OpenFile(c.Request.Context(), key, expiresAt, query.Signature)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"key":       key,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to open file for download")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+	defer file.Close()
+
+	maxAge := int(time.Until(expiresAt) / time.Second)
+	c.DataFromReader(StatusOK, attachment.Size, attachment.ContentType, file, map[string]string{
+		"Cache-Control":          fmt.Sprintf("private, max-age=%d", max(maxAge, 0)),
+		"Content-Disposition":    mime.FormatMediaType("inline", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// absoluteURL resolves a download link relative to the API, as local
+// storage hands out when no public URL is configured, against the origin
+// the client reached the API at.
+func absoluteURL(c *gin.Context, u string) string {
+	if strings.HasPrefix(u, "/") {
+		return requestOrigin(c) + u
+	}
+	return u
+}
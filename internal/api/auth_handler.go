@@ -1,50 +1,213 @@
 package api
 
 import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/edumes/golang-api-rest/internal/application"
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 	"github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
 )
 
 type AuthHandler struct {
-	service *application.UserService
-	logger  *logrus.Logger
+	service      UserService
+	tokenService TokenService
+	logger       *logrus.Logger
 }
 
-func NewAuthHandler(service *application.UserService) *AuthHandler {
+func NewAuthHandler(service UserService, tokenService TokenService) *AuthHandler {
 	return &AuthHandler{
-		service: service,
-		logger:  logrus.New(),
+		service:      service,
+		tokenService: tokenService,
+		logger:       infrastructure.WithRedaction(logrus.New()),
 	}
 }
 
 func (h *AuthHandler) RegisterRoutes(r *gin.RouterGroup) {
 	h.logger.Info("Registering auth routes")
+	r.POST(AuthRegister, h.Register)
 	r.POST(AuthLogin, h.Login)
+	r.POST(AuthChangePassword, h.ChangePassword)
+	r.POST(AuthRestore, h.RestoreAccount)
+	r.POST(AuthRefresh, h.Refresh)
+	r.POST(AuthLogout, h.Logout)
+	r.POST(AuthForgotPassword, h.ForgotPassword)
+	r.POST(AuthResetPassword, h.ResetPassword)
+	r.GET(AuthOAuthLogin, h.OAuthLogin)
+	r.GET(AuthOAuthCallback, h.OAuthCallback)
+	r.POST(AuthMFAVerify, h.VerifyMFA)
 }
 
+const (
+	// oauthStateCookie keeps the state of an OAuth sign-in in the browser
+	// that started it, for the callback to compare with the one the
+	// provider brings back.
+	oauthStateCookie = "oauth_state"
+	// oauthStateMaxAge is how long a user has to sign in at the provider.
+	oauthStateMaxAge = 10 * time.Minute
+)
+
+// errInvalidCredentials answers wrong emails and passwords alike, so
+// neither tells which accounts exist.
+var errInvalidCredentials = errors.New("invalid credentials")
+
 type loginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
 }
 
+// loginResponse carries a refresh token unless refresh tokens are
+// disabled.
 type loginResponse struct {
-	Token string `json:"token"`
+	Token            string     `json:"token"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	RefreshToken     string     `json:"refresh_token,omitempty"`
+	RefreshExpiresAt *time.Time `json:"refresh_expires_at,omitempty"`
+}
+
+// registerResponse logs the new account in right away.
+type registerResponse struct {
+	loginResponse
+	User *domain.User `json:"user"`
+}
+
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type logoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type changePasswordRequest struct {
+	Email           string `json:"email" binding:"required,email"`
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+type forgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type resetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+type oauthCallbackQuery struct {
+	Code  string `form:"code" binding:"required"`
+	State string `form:"state" binding:"required"`
+}
+
+type mfaVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// mfaChallengeResponse is what signing in returns instead of tokens when
+// the account has two-factor authentication on. The challenge token and a
+// code from the authenticator app are then sent to Verify.
+type mfaChallengeResponse struct {
+	MFARequired    bool      `json:"mfa_required"`
+	ChallengeToken string    `json:"challenge_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Verify         string    `json:"verify"`
+}
+
+// accountLockedResponse documents the answer to logins of a locked
+// account. Retry-After gives the seconds left too.
+type accountLockedResponse struct {
+	Error       string    `json:"error"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// passwordExpiredResponse documents the challenge returned by login when
+// the password has to be changed before a token is issued.
+type passwordExpiredResponse struct {
+	Error           string `json:"error"`
+	PasswordExpired bool   `json:"password_expired"`
+	ChangePassword  string `json:"change_password"`
+}
+
+// @Summary Register
+// @Description Create your own account with the user role and return tokens for it, so there is no separate login. Emails are unique, deleted accounts included.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body createUserRequest true "Account data"
+// @Success 201 {object} registerResponse
+// @Header 201 {string} Location "URL of the new user"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 409 {object} map[string]interface{} "Email already registered"
+// @Router /v1/auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Registration attempt")
+
+	var req createUserRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid registration request body")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.service.CreateUser(c.Request.Context(), req.Name, req.Email, req.Password)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"email": req.Email,
+			"ip":    c.ClientIP(),
+		}).Warn("Registration failed")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.RecordLogin(c.Request.Context(), user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Warn("Failed to record login, continuing")
+	}
+
+	tokens, ok := h.issueTokens(c, user)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+		"email":   user.Email,
+		"ip":      c.ClientIP(),
+	}).Info("User registered successfully")
+
+	c.Header("Location", resourcePath(UserByID, user.ID.String()))
+	c.JSON(StatusCreated, registerResponse{loginResponse: tokens, User: user})
 }
 
 // @Summary Login user
-// @Description Authenticate user and return JWT token
+// @Description Authenticate user and return a JWT access token valid for APP_JWT_TTL, along with a refresh token that gets new ones from /v1/auth/refresh. Accounts with two-factor authentication get a challenge instead, to be sent with a code to /v1/auth/mfa/verify.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body loginRequest true "Login credentials"
 // @Success 200 {object} loginResponse
+// @Success 202 {object} mfaChallengeResponse "Two-factor code required"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} passwordExpiredResponse "Account deactivated or suspended, or password expired"
+// @Failure 423 {object} accountLockedResponse "Account locked after too many failed logins"
+// @Failure 429 {object} map[string]interface{} "Too many failed logins from this address"
 // @Router /v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	h.logger.WithFields(logrus.Fields{
@@ -54,12 +217,16 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}).Info("Login attempt")
 
 	var req loginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 			"ip":    c.ClientIP(),
 		}).Warn("Invalid login request body")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if !h.checkThrottle(c) {
 		return
 	}
 
@@ -75,7 +242,11 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			"email": req.Email,
 			"ip":    c.ClientIP(),
 		}).Warn("Login failed - user not found")
-		c.JSON(StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		h.loginFailed(c, req.Email, nil, errInvalidCredentials)
+		return
+	}
+
+	if !h.checkLockout(c, user) {
 		return
 	}
 
@@ -85,7 +256,35 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			"email":   req.Email,
 			"ip":      c.ClientIP(),
 		}).Warn("Login failed - invalid password")
-		c.JSON(StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		h.loginFailed(c, req.Email, user, errInvalidCredentials)
+		return
+	}
+
+	if err := h.service.CheckSignIn(user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"email":   req.Email,
+			"ip":      c.ClientIP(),
+		}).Warn("Login refused - account inactive")
+		abortWithError(c, StatusForbidden, err)
+		return
+	}
+
+	if err := h.service.CheckPasswordAge(user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"email":   req.Email,
+			"ip":      c.ClientIP(),
+		}).Warn("Login refused - password expired")
+		abortWithDetails(c, StatusForbidden, err, gin.H{
+			"password_expired": true,
+			"change_password":  "/v1" + AuthChangePassword,
+		})
+		return
+	}
+
+	if user.MFAEnabled {
+		h.challengeMFA(c, user)
 		return
 	}
 
@@ -95,29 +294,701 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		"ip":      c.ClientIP(),
 	}).Info("User authenticated successfully")
 
-	secret := viper.GetString("APP_JWT_SECRET")
-	claims := jwt.MapClaims{
-		"sub":   user.ID.String(),
-		"email": user.Email,
-		"exp":   time.Now().Add(time.Hour * 24).Unix(),
+	if err := h.service.RecordLogin(c.Request.Context(), user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Warn("Failed to record login, continuing")
+	}
+
+	tokens, ok := h.issueTokens(c, user)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+		"email":   user.Email,
+		"ip":      c.ClientIP(),
+	}).Info("JWT token generated successfully")
+
+	c.JSON(StatusOK, tokens)
+}
+
+// @Summary Change password
+// @Description Replace the password of an account after re-checking its current credentials and return new tokens. Refresh tokens issued before are revoked, signing the account out of other clients. This is how an expired password is renewed; the new password must differ from the current one. Accounts with two-factor authentication get a challenge instead of tokens, like at login.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body changePasswordRequest true "Current credentials and new password"
+// @Success 200 {object} loginResponse
+// @Success 202 {object} mfaChallengeResponse "Two-factor code required"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Account deactivated or suspended"
+// @Failure 423 {object} accountLockedResponse "Account locked after too many failed logins"
+// @Failure 429 {object} map[string]interface{} "Too many failed logins from this address"
+// @Router /v1/auth/password [post]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Password change attempt")
+
+	var req changePasswordRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid password change request body")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if !h.checkThrottle(c) {
+		return
+	}
+
+	user, err := h.service.GetUserByEmail(c.Request.Context(), req.Email)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"email": req.Email,
+			"ip":    c.ClientIP(),
+		}).Warn("Password change failed - user not found")
+		h.loginFailed(c, req.Email, nil, errInvalidCredentials)
+		return
+	}
+
+	if !h.checkLockout(c, user) {
+		return
+	}
+
+	if !h.service.CheckPassword(user, req.CurrentPassword) {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"ip":      c.ClientIP(),
+		}).Warn("Password change failed - invalid password")
+		h.loginFailed(c, req.Email, user, errInvalidCredentials)
+		return
+	}
+
+	if err := h.service.CheckSignIn(user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"ip":      c.ClientIP(),
+		}).Warn("Password change refused - account inactive")
+		abortWithError(c, StatusForbidden, err)
+		return
+	}
+
+	if err := h.service.ChangePassword(c.Request.Context(), user, req.NewPassword); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Warn("Failed to change password")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if err := h.tokenService.RevokeUserRefreshTokens(c.Request.Context(), user.ID); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Error("Failed to revoke refresh tokens after password change, continuing")
+	}
+
+	if user.MFAEnabled {
+		h.challengeMFA(c, user)
+		return
+	}
+
+	if err := h.service.RecordLogin(c.Request.Context(), user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Warn("Failed to record login, continuing")
+	}
+
+	tokens, ok := h.issueTokens(c, user)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+		"ip":      c.ClientIP(),
+	}).Info("Password changed and token issued")
+
+	c.JSON(StatusOK, tokens)
+}
+
+// @Summary Restore deleted account
+// @Description Undo the deletion of your own account before its grace period ends, using the credentials it had. Sign in again afterwards.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body loginRequest true "Credentials of the deleted account"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "Account is past its grace period"
+// @Failure 429 {object} map[string]interface{} "Too many failed logins from this address"
+// @Router /v1/auth/restore [post]
+func (h *AuthHandler) RestoreAccount(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Account restore attempt")
+
+	var req loginRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid account restore request body")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if !h.checkThrottle(c) {
+		return
+	}
+
+	// Deleted accounts cannot be locked, so failures here only count
+	// towards the address.
+	user, err := h.service.GetDeletedAccountByEmail(c.Request.Context(), req.Email)
+	if err != nil || !h.service.CheckPassword(user, req.Password) {
+		h.logger.WithFields(logrus.Fields{
+			"email": req.Email,
+			"ip":    c.ClientIP(),
+		}).Warn("Account restore failed - invalid credentials")
+		h.loginFailed(c, req.Email, nil, errInvalidCredentials)
+		return
+	}
+
+	restored, err := h.service.RestoreAccount(c.Request.Context(), user.ID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+			"ip":      c.ClientIP(),
+		}).Warn("Account restore refused")
+		abortWithError(c, StatusConflict, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": restored.ID,
+		"ip":      c.ClientIP(),
+	}).Info("Account restored successfully")
+
+	c.JSON(StatusOK, restored)
+}
+
+// @Summary Refresh tokens
+// @Description Trade a refresh token for a new access token and a new refresh token. The refresh token sent is revoked; sending it again revokes every refresh token of the account. Accounts that can no longer sign in are refused like at login.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body refreshTokenRequest true "Refresh token"
+// @Success 200 {object} loginResponse
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} passwordExpiredResponse "Account deactivated or suspended, or password expired"
+// @Failure 503 {object} map[string]interface{} "Refresh tokens are disabled"
+// @Router /v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req refreshTokenRequest
+	if err := bindJSON(c, &req); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	userID, err := h.tokenService.RedeemRefreshToken(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Token refresh failed")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	user, err := h.service.GetAccount(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+			"ip":      c.ClientIP(),
+		}).Warn("Token refresh failed - account not found")
+		abortWithError(c, StatusUnauthorized, domain.ErrRefreshTokenInvalid)
+		return
+	}
+
+	if err := h.service.CheckSignIn(user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"ip":      c.ClientIP(),
+		}).Warn("Token refresh refused - account inactive")
+		abortWithError(c, StatusForbidden, err)
+		return
+	}
+
+	if err := h.service.CheckPasswordAge(user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"ip":      c.ClientIP(),
+		}).Warn("Token refresh refused - password expired")
+		abortWithDetails(c, StatusForbidden, err, gin.H{
+			"password_expired": true,
+			"change_password":  "/v1" + AuthChangePassword,
+		})
+		return
+	}
+
+	tokens, ok := h.issueTokens(c, user)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+		"ip":      c.ClientIP(),
+	}).Info("Tokens refreshed successfully")
+
+	c.JSON(StatusOK, tokens)
+}
+
+// @Summary Logout
+// @Description Sign out: the access token sent in the Authorization header is rejected from now on, and the refresh token in the body, if any, can no longer get new tokens. Send at least one of them. Unknown, expired or already revoked tokens are accepted too.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string false "Bearer access token to revoke"
+// @Param request body logoutRequest false "Refresh token to revoke"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Router /v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req logoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := bindJSON(c, &req); err != nil {
+			abortWithError(c, StatusBadRequest, err)
+			return
+		}
+	}
+
+	accessToken, hasAccessToken := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !hasAccessToken && req.RefreshToken == "" {
+		abortWithMessage(c, StatusBadRequest, "send the access token in the Authorization header or a refresh_token")
+		return
+	}
+
+	if hasAccessToken {
+		if err := h.tokenService.RevokeAccessToken(c.Request.Context(), accessToken); err != nil {
+			abortWithError(c, StatusInternalServerError, err)
+			return
+		}
+	}
+	if req.RefreshToken != "" {
+		if err := h.tokenService.RevokeRefreshToken(c.Request.Context(), req.RefreshToken); err != nil {
+			abortWithError(c, StatusInternalServerError, err)
+			return
+		}
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"ip": c.ClientIP(),
+	}).Info("Logged out")
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary Forgot password
+// @Description Email a password reset token to the account with this email, to be sent to /v1/auth/reset-password. The answer is the same whether or not the account exists, and no new email is sent within a minute of the last one.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body forgotPasswordRequest true "Account email"
+// @Success 202 {object} map[string]interface{} "Accepted"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 503 {object} map[string]interface{} "Password reset is not available"
+// @Router /v1/auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req forgotPasswordRequest
+	if err := bindJSON(c, &req); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Error("Failed to request password reset")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusAccepted, gin.H{
+		"message": "if an account uses this email, a password reset token was sent to it",
+	})
+}
+
+// @Summary Reset password
+// @Description Choose a new password with a token from /v1/auth/forgot-password. A token works once and only until it expires; refresh tokens issued before are revoked. Sign in with the new password afterwards.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body resetPasswordRequest true "Reset token and new password"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Invalid or expired token, or password rejected"
+// @Failure 403 {object} map[string]interface{} "Account deactivated or suspended"
+// @Failure 503 {object} map[string]interface{} "Password reset is not available"
+// @Router /v1/auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req resetPasswordRequest
+	if err := bindJSON(c, &req); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.service.ResetPassword(c.Request.Context(), req.Token, req.NewPassword)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Password reset failed")
+		status := StatusBadRequest
+		if errors.Is(err, domain.ErrUserInactive) || errors.Is(err, domain.ErrAccountDeleted) {
+			status = StatusForbidden
+		}
+		abortWithError(c, status, err)
+		return
+	}
+
+	if err := h.tokenService.RevokeUserRefreshTokens(c.Request.Context(), user.ID); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Error("Failed to revoke refresh tokens after password reset, continuing")
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+		"ip":      c.ClientIP(),
+	}).Info("Password reset")
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary Sign in with a provider
+// @Description Redirect to Google or GitHub to sign in. The provider sends the user back to /v1/auth/oauth/{provider}/callback, which must be registered with the app there. Open it in the browser rather than through an API call, since the state of the sign-in is kept in a cookie.
+// @Tags auth
+// @Param provider path string true "Provider: google or github"
+// @Success 302 "Redirect to the provider"
+// @Failure 404 {object} map[string]interface{} "Provider not enabled"
+// @Router /v1/auth/oauth/{provider}/login [get]
+func (h *AuthHandler) OAuthLogin(c *gin.Context) {
+	provider := domain.OAuthProvider(c.Param("provider"))
+	loginURL, state, err := h.service.OAuthLoginURL(provider)
+	if err != nil {
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, state, int(oauthStateMaxAge.Seconds()), OAuthCallbackPath(provider), "", isHTTPS(c), true)
+	c.Redirect(StatusFound, loginURL)
+}
+
+// @Summary Provider sign-in callback
+// @Description Where the provider sends the user back after signing in. The provider account is linked to the user with the same verified email, and a user with the user role is created when there is none; later sign-ins find the user through the link. Returns our tokens, or a two-factor challenge, like login. Password expiry does not apply, since no password is used.
+// @Tags auth
+// @Produce json
+// @Param provider path string true "Provider: google or github"
+// @Param code query string true "Authorization code from the provider"
+// @Param state query string true "State from the login redirect"
+// @Success 200 {object} loginResponse
+// @Success 202 {object} mfaChallengeResponse "Two-factor code required"
+// @Failure 400 {object} map[string]interface{} "Bad Request or state mismatch"
+// @Failure 401 {object} map[string]interface{} "Sign-in refused by the provider"
+// @Failure 403 {object} map[string]interface{} "No verified email, or account deactivated or suspended"
+// @Failure 404 {object} map[string]interface{} "Provider not enabled"
+// @Failure 409 {object} map[string]interface{} "Email belongs to a deleted account"
+// @Failure 502 {object} map[string]interface{} "Provider unavailable"
+// @Router /v1/auth/oauth/{provider}/callback [get]
+func (h *AuthHandler) OAuthCallback(c *gin.Context) {
+	provider := domain.OAuthProvider(c.Param("provider"))
+	if refusal := c.Query("error"); refusal != "" {
+		h.logger.WithFields(logrus.Fields{
+			"provider": provider,
+			"error":    refusal,
+			"ip":       c.ClientIP(),
+		}).Warn("OAuth sign-in refused at the provider")
+		abortWithMessage(c, StatusUnauthorized, "sign-in was refused at the provider: "+refusal)
+		return
+	}
+
+	var query oauthCallbackQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	expectedState, _ := c.Cookie(oauthStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, "", -1, OAuthCallbackPath(provider), "", isHTTPS(c), true)
+
+	user, err := h.service.SignInWithOAuth(c.Request.Context(), provider, query.Code, query.State, expectedState)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"provider": provider,
+			"ip":       c.ClientIP(),
+		}).Warn("OAuth sign-in failed")
+		abortWithError(c, StatusBadGateway, err)
+		return
+	}
+
+	if err := h.service.CheckSignIn(user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"ip":      c.ClientIP(),
+		}).Warn("OAuth sign-in refused - account inactive")
+		abortWithError(c, StatusForbidden, err)
+		return
+	}
+
+	if user.MFAEnabled {
+		h.challengeMFA(c, user)
+		return
+	}
+
+	if err := h.service.RecordLogin(c.Request.Context(), user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Warn("Failed to record login, continuing")
+	}
+
+	tokens, ok := h.issueTokens(c, user)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id":  user.ID,
+		"provider": provider,
+		"ip":       c.ClientIP(),
+	}).Info("User signed in with OAuth")
+
+	c.JSON(StatusOK, tokens)
+}
+
+// @Summary Verify two-factor code
+// @Description Finish signing in to an account with two-factor authentication: send the challenge token from login with the current code of the authenticator app to get the tokens login would have returned. Challenges expire after five minutes.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body mfaVerifyRequest true "Challenge token and code"
+// @Success 200 {object} loginResponse
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Invalid or expired challenge, or wrong code"
+// @Failure 403 {object} map[string]interface{} "Account deactivated or suspended"
+// @Failure 423 {object} accountLockedResponse "Account locked after too many failed logins"
+// @Failure 429 {object} map[string]interface{} "Too many failed logins from this address"
+// @Router /v1/auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req mfaVerifyRequest
+	if err := bindJSON(c, &req); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if !h.checkThrottle(c) {
+		return
+	}
+
+	userID, err := h.tokenService.RedeemMFAChallenge(req.ChallengeToken)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"ip": c.ClientIP(),
+		}).Warn("Two-factor verification failed - invalid challenge")
+		abortWithError(c, StatusUnauthorized, err)
+		return
+	}
+
+	user, err := h.service.GetAccount(c.Request.Context(), userID)
+	if err != nil {
+		abortWithError(c, StatusUnauthorized, domain.ErrMFAChallengeInvalid)
+		return
+	}
+
+	if err := h.service.CheckSignIn(user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"ip":      c.ClientIP(),
+		}).Warn("Two-factor verification refused - account inactive")
+		abortWithError(c, StatusForbidden, err)
+		return
+	}
+
+	if !h.checkLockout(c, user) {
+		return
+	}
+
+	if err := h.service.CheckMFACode(user, req.Code); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+			"ip":      c.ClientIP(),
+		}).Warn("Two-factor verification failed - invalid code")
+		h.loginFailed(c, string(user.Email), user, err)
+		return
+	}
+
+	if err := h.service.RecordLogin(c.Request.Context(), user); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Warn("Failed to record login, continuing")
+	}
+
+	tokens, ok := h.issueTokens(c, user)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+		"ip":      c.ClientIP(),
+	}).Info("User authenticated with two-factor code")
+
+	c.JSON(StatusOK, tokens)
+}
+
+// checkThrottle answers the request itself, with 429, when its address made
+// too many failed logins. The address is the one gin resolves, taken from
+// X-Forwarded-For only behind the proxies of WithTrustedProxies, so a
+// client cannot pick a fresh one for each attempt.
+func (h *AuthHandler) checkThrottle(c *gin.Context) bool {
+	if err := h.service.CheckLoginThrottle(c.Request.Context(), c.ClientIP()); err != nil {
+		abortWithError(c, StatusInternalServerError, err)
+		return false
+	}
+	return true
+}
+
+// checkLockout answers the request itself, with 423, when failed logins
+// locked the account of user.
+func (h *AuthHandler) checkLockout(c *gin.Context, user *domain.User) bool {
+	if err := h.service.CheckLockout(user); err != nil {
+		h.abortLocked(c, user)
+		return false
+	}
+	return true
+}
+
+// loginFailed records a wrong password or code for email and answers 401
+// with err, or 423 when the failure locked the account. user is nil when
+// no account uses email.
+func (h *AuthHandler) loginFailed(c *gin.Context, email string, user *domain.User, err error) {
+	recordErr := h.service.RecordLoginFailure(c.Request.Context(), email, user, c.ClientIP())
+	if errors.Is(recordErr, domain.ErrAccountLocked) {
+		h.abortLocked(c, user)
+		return
+	}
+	if recordErr != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": recordErr.Error(),
+			"ip":    c.ClientIP(),
+		}).Error("Failed to record failed login, continuing")
+	}
+	abortWithError(c, StatusUnauthorized, err)
+}
+
+func (h *AuthHandler) abortLocked(c *gin.Context, user *domain.User) {
+	if user.LockedUntil != nil {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(*user.LockedUntil).Seconds()))))
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenStr, err := token.SignedString([]byte(secret))
+	abortWithDetails(c, StatusLocked, domain.ErrAccountLocked, gin.H{
+		"locked_until": user.LockedUntil,
+	})
+}
+
+// challengeMFA answers a sign-in of user, whose first factor was checked,
+// with a challenge for the second one instead of tokens.
+func (h *AuthHandler) challengeMFA(c *gin.Context, user *domain.User) {
+	challenge, expiresAt, err := h.tokenService.IssueMFAChallenge(user)
 	if err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error":     err.Error(),
 			"user_id":   user.ID,
 			"client_ip": c.ClientIP(),
-		}).Error("Failed to generate JWT token")
-		c.JSON(StatusInternalServerError, gin.H{"error": "could not generate token"})
+		}).Error("Failed to generate MFA challenge")
+		abortWithMessage(c, StatusInternalServerError, "could not generate token")
 		return
 	}
 
 	h.logger.WithFields(logrus.Fields{
 		"user_id": user.ID,
-		"email":   user.Email,
 		"ip":      c.ClientIP(),
-	}).Info("JWT token generated successfully")
+	}).Info("Two-factor code requested")
+
+	c.JSON(StatusAccepted, mfaChallengeResponse{
+		MFARequired:    true,
+		ChallengeToken: challenge,
+		ExpiresAt:      expiresAt,
+		Verify:         APIVersion + AuthMFAVerify,
+	})
+}
+
+// OAuthCallbackPath is where provider sends users back after they sign in.
+// Prefixed with the public URL of the API, it is the redirect URL to
+// register with the provider.
+func OAuthCallbackPath(provider domain.OAuthProvider) string {
+	return resourcePath(AuthOAuthCallback, string(provider))
+}
+
+// isHTTPS reports whether the client reached the API over HTTPS, directly
+// or through a proxy.
+func isHTTPS(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
+
+// issueTokens signs a new access token for user and, when refresh tokens
+// are enabled, issues a refresh token with it. It answers the request
+// itself when that fails.
+func (h *AuthHandler) issueTokens(c *gin.Context, user *domain.User) (loginResponse, bool) {
+	tokenStr, expiresAt, err := h.tokenService.IssueAccessToken(user)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   user.ID,
+			"client_ip": c.ClientIP(),
+		}).Error("Failed to generate JWT token")
+		abortWithMessage(c, StatusInternalServerError, "could not generate token")
+		return loginResponse{}, false
+	}
+	tokens := loginResponse{Token: tokenStr, ExpiresAt: expiresAt}
+
+	refresh, err := h.tokenService.IssueRefreshToken(c.Request.Context(), user.ID)
+	switch {
+	case errors.Is(err, domain.ErrRefreshTokensDisabled):
+	case err != nil:
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   user.ID,
+			"client_ip": c.ClientIP(),
+		}).Error("Failed to generate refresh token")
+		abortWithMessage(c, StatusInternalServerError, "could not generate token")
+		return loginResponse{}, false
+	default:
+		tokens.RefreshToken = refresh.Token
+		tokens.RefreshExpiresAt = &refresh.ExpiresAt
+	}
 
-	c.JSON(StatusOK, loginResponse{Token: tokenStr})
+	return tokens, true
 }
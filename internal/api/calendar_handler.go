@@ -0,0 +1,176 @@
+package api
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type CalendarHandler struct {
+	service CalendarService
+	logger  *logrus.Logger
+}
+
+func NewCalendarHandler(service CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+// RegisterPublicRoutes registers the feed itself. Calendar apps cannot send
+// an Authorization header, so it is authenticated by the token in its URL.
+func (h *CalendarHandler) RegisterPublicRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering public calendar routes")
+	r.GET(UserCalendarICS, h.GetCalendar)
+}
+
+func (h *CalendarHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering calendar routes")
+	r.POST(UserCalendarFeed, h.CreateCalendarFeed)
+	r.GET(UserCalendarFeed, h.GetCalendarFeed)
+	r.DELETE(UserCalendarFeed, h.DeleteCalendarFeed)
+}
+
+type calendarQuery struct {
+	Token string `form:"token" binding:"required"`
+}
+
+// @Summary Calendar feed
+// @Description iCalendar feed of the due dates of the open items assigned to the feed's owner and of the start and end dates of the projects they own or work on, from 90 days ago onwards. Subscribe to it from Google Calendar, Outlook or any other calendar app with the URL returned by POST /v1/users/me/calendar-feed; the token in it stands in for the Authorization header. Dates without a time of day are all-day events.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token query string true "Calendar feed token"
+// @Success 200 {file} file "iCalendar feed"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/calendar.ics [get]
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	var query calendarQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	feed, err := h.service.RenderCalendarFeed(c.Request.Context(), query.Token)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Failed to render calendar feed")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("Content-Disposition", `inline; filename="calendar.ics"`)
+	c.Data(StatusOK, "text/calendar; charset=utf-8", feed)
+}
+
+// @Summary Create calendar feed
+// @Description Issue the URL of the authenticated user's calendar feed. The URL carries a secret token and is only shown now; calling this again issues a new one and stops the previous URL from working.
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.CalendarFeedToken
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/calendar-feed [post]
+func (h *CalendarHandler) CreateCalendarFeed(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": userID,
+		"ip":      c.ClientIP(),
+	}).Info("Creating calendar feed")
+
+	feed, err := h.service.CreateCalendarFeed(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to create calendar feed")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	feed.URL = requestOrigin(c) + resourcePath(UserCalendarICS) + "?" + url.Values{"token": {feed.Token}}.Encode()
+	c.JSON(StatusOK, feed)
+}
+
+// @Summary Get calendar feed
+// @Description Tell whether the authenticated user has a calendar feed, when it was issued and when a calendar app last fetched it. The token is not shown again.
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.CalendarFeed
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/users/me/calendar-feed [get]
+func (h *CalendarHandler) GetCalendarFeed(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	feed, err := h.service.GetCalendarFeed(c.Request.Context(), userID)
+	if err != nil {
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, feed)
+}
+
+// @Summary Revoke calendar feed
+// @Description Stop the authenticated user's calendar feed URL from working
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/calendar-feed [delete]
+func (h *CalendarHandler) DeleteCalendarFeed(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.service.DeleteCalendarFeed(c.Request.Context(), userID); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Warn("Failed to revoke calendar feed")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// requestOrigin is the scheme and host the client reached the API at,
+// honouring the X-Forwarded-Proto header set by TLS terminating proxies.
+func requestOrigin(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
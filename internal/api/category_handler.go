@@ -0,0 +1,337 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type CategoryHandler struct {
+	service CategoryService
+	logger  *logrus.Logger
+}
+
+func NewCategoryHandler(service CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *CategoryHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering category routes")
+	write := RequirePermission(domain.ScopeActionWrite, "categories")
+	r.POST(CategoriesEndpoint, write, h.CreateCategory)
+	r.GET(CategoriesEndpoint, h.ListCategories)
+	r.GET(CategoryByID, h.GetCategory)
+	r.PUT(CategoryByID, write, h.UpdateCategory)
+	r.DELETE(CategoryByID, write, h.DeleteCategory)
+}
+
+type categoryRequest struct {
+	Name     string     `json:"name" binding:"required"`
+	ParentID *uuid.UUID `json:"parent_id" binding:"omitempty,exists=category"`
+}
+
+// listCategoriesQuery is the query string of ListCategories.
+type listCategoriesQuery struct {
+	pageQuery
+	Name     string     `form:"name"`
+	ParentID *uuid.UUID `form:"parent_id"`
+	Roots    bool       `form:"roots"`
+}
+
+// @Summary Create category
+// @Description Create a product category (admin only). Give parent_id to nest it under another category. Names are unique among siblings, ignoring case.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body categoryRequest true "Category data"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Param Idempotency-Key header string false "Key that makes retries of this request replay its response instead of creating again"
+// @Success 201 {object} domain.Category
+// @Header 201 {string} Location "URL of the created category"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Name already used under the same parent, or the idempotency key is in use"
+// @Failure 422 {object} map[string]interface{} "Idempotency key was used for a different request"
+// @Router /v1/categories [post]
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Creating new category")
+
+	var req categoryRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for category creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	category, err := h.service.CreateCategory(c.Request.Context(), &domain.Category{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"name":  req.Name,
+		}).Error("Failed to create category")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"category_id": category.ID,
+		"name":        category.Name,
+	}).Info("Category created successfully")
+
+	respondCreated(c, category, CategoryByID, category.ID.String())
+}
+
+// @Summary List categories
+// @Description Get a list of categories with optional filtering and pagination. Use roots to walk the tree from the top and parent_id to list the children of a category.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name query string false "Filter by name"
+// @Param parent_id query string false "List the direct subcategories of this category"
+// @Param roots query bool false "List top-level categories only"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. name asc; fields: name, created_at, updated_at (default: name asc)"
+// @Success 200 {array} domain.Category
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/categories [get]
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Listing categories")
+
+	var query listCategoriesQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	sort, err := sortQuery(c, domain.CategorySort, "name asc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := query.pagination(sort)
+	filter := domain.CategoryParams{
+		Name:     query.Name,
+		ParentID: query.ParentID,
+		Roots:    query.Roots,
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"filter_name":      filter.Name,
+		"filter_parent_id": filter.ParentID,
+		"filter_roots":     filter.Roots,
+		"limit":            pagination.Limit,
+		"offset":           pagination.Offset,
+		"sort":             pagination.Sort,
+	}).Debug("List categories with filters and pagination")
+
+	categories, err := h.service.ListCategories(c.Request.Context(), filter, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list categories")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"count": len(categories),
+	}).Info("Categories listed successfully")
+
+	c.JSON(StatusOK, categories)
+}
+
+// @Summary Get category by ID
+// @Description Get a specific category by its ID
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Param If-None-Match header string false "ETag of a copy the client already has; answered with 304 when it is still current"
+// @Success 200 {object} domain.Category
+// @Success 304 "Not Modified"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/categories/{id} [get]
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid category ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":      c.Request.Method,
+		"path":        c.Request.URL.Path,
+		"category_id": id,
+		"ip":          c.ClientIP(),
+	}).Info("Getting category by ID")
+
+	category, err := h.service.GetCategoryByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"category_id": id,
+			"client_ip":   c.ClientIP(),
+		}).Warn("Category not found")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"category_id": category.ID,
+		"name":        category.Name,
+	}).Info("Category retrieved successfully")
+
+	setETag(c, category.UpdatedAt)
+	c.JSON(StatusOK, category)
+}
+
+// @Summary Update category
+// @Description Rename a category or move it under another parent (admin only). Omit parent_id to make it top-level. A category cannot be moved under itself or one of its descendants. Products in the category take the new name.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Param request body categoryRequest true "Category data"
+// @Success 200 {object} domain.Category
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Name already used under the same parent"
+// @Router /v1/categories/{id} [put]
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid category ID format for update")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":      c.Request.Method,
+		"path":        c.Request.URL.Path,
+		"category_id": id,
+		"ip":          c.ClientIP(),
+	}).Info("Updating category")
+
+	var req categoryRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"category_id": id,
+			"client_ip":   c.ClientIP(),
+		}).Warn("Invalid request body for category update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	category, err := h.service.UpdateCategory(c.Request.Context(), &domain.Category{
+		ID:       id,
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"category_id": id,
+			"client_ip":   c.ClientIP(),
+		}).Error("Failed to update category")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"category_id": category.ID,
+		"name":        category.Name,
+	}).Info("Category updated successfully")
+
+	c.JSON(StatusOK, category)
+}
+
+// @Summary Delete category
+// @Description Delete a category by ID (admin only). Categories that still have subcategories or products cannot be deleted.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Category still has subcategories or products"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/categories/{id} [delete]
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid category ID format for deletion")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":      c.Request.Method,
+		"path":        c.Request.URL.Path,
+		"category_id": id,
+		"ip":          c.ClientIP(),
+	}).Info("Deleting category")
+
+	if err := h.service.DeleteCategory(c.Request.Context(), id); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"category_id": id,
+			"client_ip":   c.ClientIP(),
+		}).Error("Failed to delete category")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"category_id": id,
+	}).Info("Category deleted successfully")
+
+	c.JSON(StatusNoContent, nil)
+}
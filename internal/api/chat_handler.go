@@ -0,0 +1,306 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ChatHandler struct {
+	service ChatService
+	logger  *logrus.Logger
+}
+
+func NewChatHandler(service ChatService) *ChatHandler {
+	return &ChatHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *ChatHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering chat connector routes")
+	admin := RequireRole(domain.RoleAdmin)
+	r.POST(AdminChatConnectors, admin, h.CreateChatConnector)
+	r.GET(AdminChatConnectors, admin, h.ListChatConnectors)
+	r.GET(AdminChatConnectorByID, admin, h.GetChatConnector)
+	r.PUT(AdminChatConnectorByID, admin, h.UpdateChatConnector)
+	r.DELETE(AdminChatConnectorByID, admin, h.DeleteChatConnector)
+	r.POST(AdminChatConnectorTest, admin, h.TestChatConnector)
+}
+
+// chatConnectorRequest is the body of create and update requests. Active
+// defaults to true; the provider cannot change once created.
+type chatConnectorRequest struct {
+	Name       string              `json:"name" binding:"required"`
+	Provider   domain.ChatProvider `json:"provider" binding:"omitempty,enum"`
+	WebhookURL string              `json:"webhook_url" binding:"required"`
+	Events     []string            `json:"events" binding:"required"`
+	ProjectID  *uuid.UUID          `json:"project_id"`
+	Active     *bool               `json:"active"`
+}
+
+func (r chatConnectorRequest) connector(id uuid.UUID) *domain.ChatConnector {
+	return &domain.ChatConnector{
+		ID:         id,
+		Name:       r.Name,
+		Provider:   r.Provider,
+		WebhookURL: r.WebhookURL,
+		Events:     r.Events,
+		ProjectID:  r.ProjectID,
+		Active:     r.Active == nil || *r.Active,
+	}
+}
+
+// @Summary Create chat connector
+// @Description Post events to a Slack or Microsoft Teams channel through its incoming webhook (admin only). Events are project_created, project_item_completed and product_low_stock. With a project_id the connector only posts that project's events, so each project can have its own channel; product_low_stock needs a connector without a project. Webhook URLs must be https URLs on hooks.slack.com for Slack, or on webhook.office.com, logic.azure.com or environment.api.powerplatform.com for Teams.
+// @Tags chat-connectors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body chatConnectorRequest true "Chat connector"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.ChatConnector
+// @Header 201 {string} Location "URL of the created chat connector"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/chat-connectors [post]
+func (h *ChatHandler) CreateChatConnector(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req chatConnectorRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for chat connector creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":   c.Request.Method,
+		"path":     c.Request.URL.Path,
+		"user_id":  userID,
+		"provider": req.Provider,
+		"ip":       c.ClientIP(),
+	}).Info("Creating chat connector")
+
+	connector, err := h.service.CreateChatConnector(c.Request.Context(), req.connector(uuid.Nil), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Warn("Failed to create chat connector")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	respondCreated(c, connector, AdminChatConnectorByID, connector.ID.String())
+}
+
+type listChatConnectorsQuery struct {
+	pageQuery
+	Provider  domain.ChatProvider `form:"provider" binding:"omitempty,enum"`
+	ProjectID *uuid.UUID          `form:"project_id"`
+}
+
+// @Summary List chat connectors
+// @Description List the chat connectors, ordered by name (admin only)
+// @Tags chat-connectors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider query string false "Filter by provider (slack, teams)"
+// @Param project_id query string false "Filter by mapped project"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.ChatConnector
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/admin/chat-connectors [get]
+func (h *ChatHandler) ListChatConnectors(c *gin.Context) {
+	var query listChatConnectorsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	params := domain.ChatConnectorParams{
+		Provider:  query.Provider,
+		ProjectID: query.ProjectID,
+	}
+
+	connectors, err := h.service.ListChatConnectors(c.Request.Context(), params, query.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list chat connectors")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, connectors)
+}
+
+// @Summary Get chat connector
+// @Description Get a chat connector by ID (admin only)
+// @Tags chat-connectors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Chat connector ID"
+// @Success 200 {object} domain.ChatConnector
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/chat-connectors/{id} [get]
+func (h *ChatHandler) GetChatConnector(c *gin.Context) {
+	id, ok := h.connectorID(c)
+	if !ok {
+		return
+	}
+
+	connector, err := h.service.GetChatConnector(c.Request.Context(), id)
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, connector)
+}
+
+// @Summary Update chat connector
+// @Description Replace the name, webhook URL, events, project and active flag of a chat connector (admin only). The provider is fixed.
+// @Tags chat-connectors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Chat connector ID"
+// @Param request body chatConnectorRequest true "Chat connector"
+// @Success 200 {object} domain.ChatConnector
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/chat-connectors/{id} [put]
+func (h *ChatHandler) UpdateChatConnector(c *gin.Context) {
+	id, ok := h.connectorID(c)
+	if !ok {
+		return
+	}
+
+	var req chatConnectorRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for chat connector update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	connector, err := h.service.UpdateChatConnector(c.Request.Context(), req.connector(id))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"connector_id": id,
+		}).Warn("Failed to update chat connector")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, connector)
+}
+
+// @Summary Delete chat connector
+// @Description Stop posting to a chat connector's channel and delete it (admin only)
+// @Tags chat-connectors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Chat connector ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/chat-connectors/{id} [delete]
+func (h *ChatHandler) DeleteChatConnector(c *gin.Context) {
+	id, ok := h.connectorID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteChatConnector(c.Request.Context(), id); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"connector_id": id,
+		}).Warn("Failed to delete chat connector")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary Test chat connector
+// @Description Post a test message through a chat connector, even an inactive one, and report whether the provider accepted it (admin only). A rejected message still answers 200 with delivered false and the provider's error.
+// @Tags chat-connectors
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Chat connector ID"
+// @Success 200 {object} domain.ChatTestResult
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/chat-connectors/{id}/test [post]
+func (h *ChatHandler) TestChatConnector(c *gin.Context) {
+	id, ok := h.connectorID(c)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":       c.Request.Method,
+		"path":         c.Request.URL.Path,
+		"connector_id": id,
+		"ip":           c.ClientIP(),
+	}).Info("Testing chat connector")
+
+	result, err := h.service.TestChatConnector(c.Request.Context(), id)
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, result)
+}
+
+func (h *ChatHandler) connectorID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid chat connector ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type CommentHandler struct {
+	service CommentService
+	logger  *logrus.Logger
+}
+
+func NewCommentHandler(service CommentService) *CommentHandler {
+	return &CommentHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *CommentHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering comment routes")
+	r.POST(ProjectItemComments, h.CreateComment)
+	r.GET(ProjectItemComments, h.ListComments)
+	r.DELETE(ProjectItemCommentByID, h.DeleteComment)
+}
+
+type commentRequest struct {
+	Body string `json:"body" binding:"required,max=10000"`
+}
+
+// parseCommentPath reads the item and, when withComment is set, the comment
+// IDs from the path, writing a 400 and returning false when either is invalid.
+func (h *CommentHandler) parseCommentPath(c *gin.Context, withComment bool) (uuid.UUID, uuid.UUID, bool) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project item ID format for comment")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	if !withComment {
+		return itemID, uuid.Nil, true
+	}
+
+	commentID, err := uuid.Parse(c.Param("commentId"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("commentId"),
+			"item_id":   itemID,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid comment ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return itemID, commentID, true
+}
+
+// @Summary Add comment
+// @Description Comment on a project item. The authenticated user is recorded as its author. Watchers of the item and of its project, and the item's assignee, are notified.
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project item ID"
+// @Param request body commentRequest true "Comment body (at most 10000 characters)"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.Comment
+// @Header 201 {string} Location "URL of the item's comments"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/project-items/{id}/comments [post]
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	itemID, _, ok := h.parseCommentPath(c, false)
+	if !ok {
+		return
+	}
+
+	authorID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"item_id":   itemID,
+			"client_ip": c.ClientIP(),
+		}).Warn("Comment creation without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":    c.Request.Method,
+		"path":      c.Request.URL.Path,
+		"item_id":   itemID,
+		"author_id": authorID,
+		"ip":        c.ClientIP(),
+	}).Info("Creating new comment")
+
+	var req commentRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"item_id":   itemID,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid request body for comment creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	comment, err := h.service.CreateComment(c.Request.Context(), itemID, authorID, req.Body)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": itemID,
+		}).Error("Failed to create comment")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"comment_id": comment.ID,
+		"item_id":    itemID,
+	}).Info("Comment created successfully")
+
+	respondCreated(c, comment, ProjectItemComments, itemID.String())
+}
+
+// @Summary List comments
+// @Description Get the comments of a project item, oldest first unless sorted otherwise. Deleted comments are left out.
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project item ID"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. created_at desc; fields: created_at (default: created_at asc)"
+// @Success 200 {object} pageResponse[domain.Comment]
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/project-items/{id}/comments [get]
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	itemID, _, ok := h.parseCommentPath(c, false)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"item_id": itemID,
+		"ip":      c.ClientIP(),
+	}).Info("Listing comments")
+
+	var query pageQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	sort, err := sortQuery(c, domain.CommentSort, "created_at asc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := query.pagination(sort)
+
+	comments, err := h.service.ListComments(c.Request.Context(), itemID, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"item_id":   itemID,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to list comments")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+	total, err := h.service.CountComments(c.Request.Context(), itemID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": itemID,
+		}).Error("Failed to count comments")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"item_id": itemID,
+		"count":   len(comments),
+		"total":   total,
+	}).Info("Comments listed successfully")
+
+	respondPage(c, comments, total, pagination)
+}
+
+// @Summary Delete comment
+// @Description Delete a comment of a project item. Only its author or an admin may delete it. The comment is kept in the database but no longer listed.
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project item ID"
+// @Param commentId path string true "Comment ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/project-items/{id}/comments/{commentId} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	itemID, commentID, ok := h.parseCommentPath(c, true)
+	if !ok {
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"comment_id": commentID,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Comment deletion without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"item_id":    itemID,
+		"comment_id": commentID,
+		"actor_id":   actorID,
+		"ip":         c.ClientIP(),
+	}).Info("Deleting comment")
+
+	if err := h.service.DeleteComment(c.Request.Context(), itemID, commentID, actorID, isAdmin(c)); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"comment_id": commentID,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Failed to delete comment")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"comment_id": commentID,
+	}).Info("Comment deleted successfully")
+
+	c.JSON(StatusNoContent, nil)
+}
@@ -10,26 +10,202 @@ const (
 	HealthReady = "/health/ready"
 
 	// Auth endpoints
-	AuthLogin = "/auth/login"
+	AuthRegister       = "/auth/register"
+	AuthLogin          = "/auth/login"
+	AuthChangePassword = "/auth/password"
+	AuthRestore        = "/auth/restore"
+	AuthRefresh        = "/auth/refresh"
+	AuthLogout         = "/auth/logout"
+	AuthForgotPassword = "/auth/forgot-password"
+	AuthResetPassword  = "/auth/reset-password"
+	AuthOAuthLogin     = "/auth/oauth/:provider/login"
+	AuthOAuthCallback  = "/auth/oauth/:provider/callback"
+	AuthMFAVerify      = "/auth/mfa/verify"
 
 	// User endpoints
 	UsersEndpoint = "/users"
 	UserByID      = "/users/:id"
+	UserHours     = "/users/:id/hours"
+	UserMe        = "/users/me"
+	UsersSuggest  = "/users/suggest"
+
+	// Two-factor authentication endpoints
+	UserMFAEnroll  = "/users/me/mfa/enroll"
+	UserMFAConfirm = "/users/me/mfa/confirm"
+	UserMFADisable = "/users/me/mfa/disable"
+
+	// User data export endpoints
+	UserExportEndpoint  = "/users/me/export"
+	UserExportsEndpoint = "/users/me/exports"
+	UserExportByID      = "/users/me/exports/:id"
+	UserExportDownload  = "/users/me/exports/:id/download"
+
+	// Admin endpoints
+	AdminUsers          = "/admin/users"
+	AdminStaleUsers     = "/admin/users/stale"
+	AdminUserDeactivate = "/admin/users/:id/deactivate"
+	AdminUserReactivate = "/admin/users/:id/reactivate"
+	AdminUserUnlock     = "/admin/users/:id/unlock"
+	AdminUserByID       = "/admin/users/:id"
+	AdminUserRestore    = "/admin/users/:id/restore"
+
+	// Retention endpoints (admin only)
+	AdminRetentionRules    = "/admin/retention-rules"
+	AdminRetentionRuleByID = "/admin/retention-rules/:id"
+	AdminRetentionRuns     = "/admin/retention-runs"
+	AdminRetentionMetrics  = "/admin/retention-runs/metrics"
+	AdminArchivedRecords   = "/admin/archived-records"
+	AdminArchivedRecord    = "/admin/archived-records/:entity/:id"
+	AdminArchivedRestore   = "/admin/archived-records/:entity/:id/restore"
+
+	// Exchange rate endpoints
+	ExchangeRates            = "/exchange-rates"
+	AdminExchangeRateRefresh = "/admin/exchange-rates/refresh"
+	AdminExchangeRateSyncs   = "/admin/exchange-rates/syncs"
+	AdminExchangeRateMetrics = "/admin/exchange-rates/metrics"
+
+	// Outbound HTTP endpoints (admin only)
+	AdminOutboundMetrics = "/admin/outbound/metrics"
+
+	// Fault injection endpoints (admin only, registered when enabled)
+	AdminFaults    = "/admin/faults"
+	AdminFaultByID = "/admin/faults/:id"
+
+	// Email template endpoints (admin only)
+	AdminEmailTemplates       = "/admin/email-templates"
+	AdminEmailTemplatePreview = "/admin/email-templates/:name/preview"
+
+	// Chat connector endpoints (admin only)
+	AdminChatConnectors    = "/admin/chat-connectors"
+	AdminChatConnectorByID = "/admin/chat-connectors/:id"
+	AdminChatConnectorTest = "/admin/chat-connectors/:id/test"
+
+	// Policy endpoints
+	PoliciesEndpoint       = "/policies"
+	PolicyByID             = "/policies/:id"
+	PolicyAccept           = "/policies/:id/accept"
+	UserPolicies           = "/users/me/policies"
+	AdminPolicies          = "/admin/policies"
+	AdminPolicyAcceptances = "/admin/policies/:id/acceptances"
 
 	// Product endpoints
-	ProductsEndpoint     = "/products"
-	ProductByID          = "/products/:id"
-	ProductStockEndpoint = "/products/:id/stock"
-	ProductBySKUEndpoint = "/products/sku/:sku"
+	ProductsEndpoint        = "/products"
+	ProductByID             = "/products/:id"
+	ProductStockAdjustments = "/products/:id/stock-adjustments"
+	ProductStockMovements   = "/products/:id/stock-movements"
+	ProductBySKUEndpoint    = "/products/sku/:sku"
+	ProductsSuggest         = "/products/suggest"
+	ProductsSearch          = "/products/search"
+	ProductsLowStock        = "/products/low-stock"
+	ProductRelated          = "/products/:id/related"
+	ProductByBarcode        = "/products/barcode/:code"
+	ProductArchive          = "/products/:id/archive"
+	ProductUnarchive        = "/products/:id/unarchive"
+	ProductsImport          = "/products/import"
+	ProductImages           = "/products/:id/images"
+	ProductImageByID        = "/products/:id/images/:imageId"
+
+	// Product import endpoints
+	ProductImportByID = "/product-imports/:id"
 
 	// Project endpoints
-	ProjectsEndpoint = "/projects"
-	ProjectByID      = "/projects/:id"
+	ProjectsEndpoint   = "/projects"
+	ProjectByID        = "/projects/:id"
+	ProjectExpenses    = "/projects/:id/expenses"
+	ProjectExpenseByID = "/projects/:id/expenses/:expenseId"
+	ProjectStats       = "/projects/:id/stats"
+	ProjectWatch       = "/projects/:id/watch"
+	ProjectWatchers    = "/projects/:id/watchers"
+	ProjectArchive     = "/projects/:id/archive"
+	ProjectUnarchive   = "/projects/:id/unarchive"
+	ProjectExport      = "/projects/:id/export"
+	ProjectHours       = "/projects/:id/hours"
+	ProjectBoard       = "/projects/:id/board"
+	ProjectBoardItem   = "/projects/:id/board/items/:itemId"
+
+	// Project export endpoints
+	ProjectExportByID     = "/project-exports/:id"
+	ProjectExportDownload = "/project-exports/:id/download"
 
 	// Project Item endpoints
-	ProjectItemsEndpoint  = "/project-items"
-	ProjectItemByID       = "/project-items/:id"
-	ProjectItemsByProject = "/project-items/project/:projectId"
+	ProjectItemsEndpoint   = "/project-items"
+	ProjectItemByID        = "/project-items/:id"
+	ProjectItemsByProject  = "/project-items/project/:projectId"
+	ProjectItemsOverdue    = "/project-items/overdue"
+	ProjectItemsUpcoming   = "/project-items/upcoming"
+	ProjectItemAssignments = "/project-items/:id/assignments"
+	ProjectItemTransition  = "/project-items/:id/transition"
+	ProjectItemTransitions = "/project-items/:id/transitions"
+	ProjectItemChildren    = "/project-items/:id/children"
+	ProjectItemWatch       = "/project-items/:id/watch"
+	ProjectItemWatchers    = "/project-items/:id/watchers"
+	ProjectItemComments    = "/project-items/:id/comments"
+	ProjectItemCommentByID = "/project-items/:id/comments/:commentId"
+
+	// Custom field endpoints
+	CustomFieldsEndpoint = "/custom-fields"
+	CustomFieldByID      = "/custom-fields/:id"
+
+	// Saved filter endpoints
+	SavedFiltersEndpoint = "/saved-filters"
+	SavedFilterByID      = "/saved-filters/:id"
+
+	// Report endpoints
+	ReportItemsByStatus   = "/reports/items-by-status"
+	ReportStockByCategory = "/reports/stock-by-category"
+	ReportProjectsBudget  = "/reports/projects-budget"
+
+	// Report subscription endpoints
+	ReportSubscriptionsEndpoint  = "/report-subscriptions"
+	ReportSubscriptionByID       = "/report-subscriptions/:id"
+	ReportSubscriptionDeliveries = "/report-subscriptions/:id/deliveries"
+
+	// Dashboard endpoints
+	DashboardEndpoint = "/dashboard"
+
+	// Notification endpoints
+	NotificationsEndpoint = "/notifications"
+	NotificationRead      = "/notifications/:id/read"
+
+	// Push notification endpoints
+	UserDevices                 = "/users/me/devices"
+	UserDeviceByID              = "/users/me/devices/:id"
+	UserNotificationPreferences = "/users/me/notification-preferences"
+
+	// Calendar feed endpoints
+	UserCalendarFeed = "/users/me/calendar-feed"
+	UserCalendarICS  = "/users/me/calendar.ics"
+
+	// Coupon endpoints
+	CouponsEndpoint        = "/coupons"
+	CouponByID             = "/coupons/:id"
+	CouponValidateEndpoint = "/coupons/validate"
+	CouponRedeemEndpoint   = "/coupons/redeem"
+
+	// Category endpoints
+	CategoriesEndpoint = "/categories"
+	CategoryByID       = "/categories/:id"
+
+	// Warehouse endpoints
+	WarehousesEndpoint     = "/warehouses"
+	WarehouseByID          = "/warehouses/:id"
+	WarehouseStockEndpoint = "/warehouses/:id/stock"
+	StockTransfersEndpoint = "/stock-transfers"
+
+	// Purchase order endpoints
+	PurchaseOrdersEndpoint = "/purchase-orders"
+	PurchaseOrderByID      = "/purchase-orders/:id"
+	PurchaseOrderSubmit    = "/purchase-orders/:id/submit"
+	PurchaseOrderReceive   = "/purchase-orders/:id/receive"
+
+	// Order endpoints
+	OrdersEndpoint = "/orders"
+	OrderByID      = "/orders/:id"
+	OrderStatus    = "/orders/:id/status"
+
+	// File download endpoints. FilesPrefix is followed by the file's key.
+	FilesPrefix   = "/files/"
+	FilesEndpoint = FilesPrefix + "*key"
 
 	// Swagger documentation
 	SwaggerEndpoint = "/swagger/*any"
@@ -37,11 +213,24 @@ const (
 
 // HTTP Status codes
 const (
-	StatusOK                  = 200
-	StatusCreated             = 201
-	StatusNoContent           = 204
-	StatusBadRequest          = 400
-	StatusUnauthorized        = 401
-	StatusNotFound            = 404
-	StatusInternalServerError = 500
+	StatusOK                    = 200
+	StatusCreated               = 201
+	StatusAccepted              = 202
+	StatusNoContent             = 204
+	StatusFound                 = 302
+	StatusBadRequest            = 400
+	StatusUnauthorized          = 401
+	StatusForbidden             = 403
+	StatusNotFound              = 404
+	StatusConflict              = 409
+	StatusGone                  = 410
+	StatusRequestEntityTooLarge = 413
+	StatusUnsupportedMediaType  = 415
+	StatusUnprocessableEntity   = 422
+	StatusLocked                = 423
+	StatusUpgradeRequired       = 426
+	StatusTooManyRequests       = 429
+	StatusInternalServerError   = 500
+	StatusBadGateway            = 502
+	StatusServiceUnavailable    = 503
 )
@@ -0,0 +1,457 @@
+package api
+
+import (
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type CouponHandler struct {
+	service CouponService
+	logger  *logrus.Logger
+}
+
+func NewCouponHandler(service CouponService) *CouponHandler {
+	return &CouponHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *CouponHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering coupon routes")
+	write := RequirePermission(domain.ScopeActionWrite, "coupons")
+	r.POST(CouponsEndpoint, write, h.CreateCoupon)
+	r.GET(CouponsEndpoint, h.ListCoupons)
+	r.GET(CouponByID, h.GetCoupon)
+	r.PUT(CouponByID, write, h.UpdateCoupon)
+	r.DELETE(CouponByID, write, h.DeleteCoupon)
+	r.POST(CouponValidateEndpoint, h.ValidateCoupon)
+	r.POST(CouponRedeemEndpoint, write, h.RedeemCoupon)
+}
+
+type createCouponRequest struct {
+	Code        string     `json:"code" binding:"required"`
+	Description string     `json:"description"`
+	Type        string     `json:"type" binding:"required"`
+	Value       float64    `json:"value" binding:"required,gt=0"`
+	Category    string     `json:"category"`
+	ProductID   *uuid.UUID `json:"product_id" binding:"omitempty,exists=product"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	MaxUses     *int       `json:"max_uses"`
+}
+
+type couponCartRequest struct {
+	Code  string            `json:"code"`
+	Items []domain.CartLine `json:"items" binding:"required,min=1,dive"`
+}
+
+// @Summary Create coupon
+// @Description Create a new percentage or fixed discount coupon (admin only)
+// @Tags coupons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body createCouponRequest true "Coupon data"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.Coupon
+// @Header 201 {string} Location "URL of the created coupon"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /v1/coupons [post]
+func (h *CouponHandler) CreateCoupon(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Creating new coupon")
+
+	var req createCouponRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for coupon creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"code":     req.Code,
+		"type":     req.Type,
+		"value":    req.Value,
+		"category": req.Category,
+	}).Debug("Processing coupon creation request")
+
+	coupon, err := h.service.CreateCoupon(c.Request.Context(), &domain.Coupon{
+		Code:        req.Code,
+		Description: req.Description,
+		Type:        req.Type,
+		Value:       req.Value,
+		Category:    req.Category,
+		ProductID:   req.ProductID,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		MaxUses:     req.MaxUses,
+	})
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"code":  req.Code,
+		}).Error("Failed to create coupon")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+	}).Info("Coupon created successfully")
+
+	respondCreated(c, coupon, CouponByID, coupon.ID.String())
+}
+
+type listCouponsQuery struct {
+	pageQuery
+	Code      string     `form:"code"`
+	Type      string     `form:"type"`
+	Category  string     `form:"category"`
+	ProductID *uuid.UUID `form:"product_id"`
+	ValidAt   *time.Time `form:"valid_at"`
+}
+
+// @Summary List coupons
+// @Description Get a list of coupons with optional filtering and pagination
+// @Tags coupons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code query string false "Filter by code"
+// @Param type query string false "Filter by type (percentage, fixed)"
+// @Param category query string false "Filter by category"
+// @Param product_id query string false "Filter by product ID"
+// @Param valid_at query string false "Only coupons valid at this RFC3339 time"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. created_at desc; fields: code, type, value, category, starts_at, ends_at, used_count, created_at, updated_at (default: created_at desc)"
+// @Param saved_filter query string false "Apply the query parameters and sort of a saved filter; parameters given here take precedence"
+// @Success 200 {array} domain.Coupon
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/coupons [get]
+func (h *CouponHandler) ListCoupons(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Listing coupons")
+
+	var query listCouponsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	filter := domain.CouponParams{
+		Code:      query.Code,
+		Type:      query.Type,
+		Category:  query.Category,
+		ProductID: query.ProductID,
+		ValidAt:   query.ValidAt,
+	}
+	sort, err := sortQuery(c, domain.CouponSort, "created_at desc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := query.pagination(sort)
+
+	h.logger.WithFields(logrus.Fields{
+		"filter_code":     filter.Code,
+		"filter_type":     filter.Type,
+		"filter_category": filter.Category,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
+		"sort":            pagination.Sort,
+	}).Debug("List coupons with filters and pagination")
+
+	coupons, err := h.service.ListCoupons(c.Request.Context(), filter, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list coupons")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"count": len(coupons),
+	}).Info("Coupons listed successfully")
+
+	c.JSON(StatusOK, coupons)
+}
+
+// @Summary Get coupon by ID
+// @Description Get a specific coupon by its ID
+// @Tags coupons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Coupon ID"
+// @Success 200 {object} domain.Coupon
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/coupons/{id} [get]
+func (h *CouponHandler) GetCoupon(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid coupon ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":    c.Request.Method,
+		"path":      c.Request.URL.Path,
+		"coupon_id": id,
+		"ip":        c.ClientIP(),
+	}).Info("Getting coupon by ID")
+
+	coupon, err := h.service.GetCouponByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Coupon not found")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+	}).Info("Coupon retrieved successfully")
+
+	c.JSON(StatusOK, coupon)
+}
+
+// @Summary Update coupon
+// @Description Update an existing coupon (admin only)
+// @Tags coupons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Coupon ID"
+// @Param coupon body domain.Coupon true "Coupon data"
+// @Success 200 {object} domain.Coupon
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /v1/coupons/{id} [put]
+func (h *CouponHandler) UpdateCoupon(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid coupon ID format for update")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":    c.Request.Method,
+		"path":      c.Request.URL.Path,
+		"coupon_id": id,
+		"ip":        c.ClientIP(),
+	}).Info("Updating coupon")
+
+	var coupon domain.Coupon
+	if err := bindJSON(c, &coupon); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid request body for coupon update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	coupon.ID = id
+	if err := h.service.UpdateCoupon(c.Request.Context(), &coupon); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": id,
+			"client_ip": c.ClientIP(),
+		}).Error("Failed to update coupon")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+	}).Info("Coupon updated successfully")
+
+	c.JSON(StatusOK, coupon)
+}
+
+// @Summary Delete coupon
+// @Description Delete a coupon by ID (admin only)
+// @Tags coupons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Coupon ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/coupons/{id} [delete]
+func (h *CouponHandler) DeleteCoupon(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid coupon ID format for deletion")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":    c.Request.Method,
+		"path":      c.Request.URL.Path,
+		"coupon_id": id,
+		"ip":        c.ClientIP(),
+	}).Info("Deleting coupon")
+
+	if err := h.service.DeleteCoupon(c.Request.Context(), id); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": id,
+			"client_ip": c.ClientIP(),
+		}).Error("Failed to delete coupon")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"coupon_id": id,
+	}).Info("Coupon deleted successfully")
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary Validate coupon against a cart
+// @Description Price the cart items and apply the coupon without consuming a use. Omit the code to get undiscounted totals.
+// @Tags coupons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body couponCartRequest true "Coupon code and cart items"
+// @Success 200 {object} domain.CartQuote
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /v1/coupons/validate [post]
+func (h *CouponHandler) ValidateCoupon(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Validating coupon")
+
+	var req couponCartRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for coupon validation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	quote, err := h.service.QuoteCart(c.Request.Context(), req.Code, req.Items)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"code":  req.Code,
+		}).Warn("Coupon validation failed")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"code":     quote.CouponCode,
+		"subtotal": quote.Subtotal,
+		"discount": quote.Discount,
+		"total":    quote.Total,
+	}).Info("Coupon validated successfully")
+
+	c.JSON(StatusOK, quote)
+}
+
+// @Summary Redeem coupon against a cart
+// @Description Price the cart items, apply the coupon and consume one of its uses (admin only)
+// @Tags coupons
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body couponCartRequest true "Coupon code and cart items"
+// @Success 200 {object} domain.CartQuote
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Usage limit reached"
+// @Router /v1/coupons/redeem [post]
+func (h *CouponHandler) RedeemCoupon(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Redeeming coupon")
+
+	var req couponCartRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for coupon redemption")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	quote, err := h.service.RedeemCoupon(c.Request.Context(), req.Code, req.Items)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"code":  req.Code,
+		}).Warn("Coupon redemption failed")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"code":     quote.CouponCode,
+		"discount": quote.Discount,
+		"total":    quote.Total,
+	}).Info("Coupon redeemed successfully")
+
+	c.JSON(StatusOK, quote)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondCreated answers a create request with 201 and a Location header
+// pointing at route, such as ProductByID, with its ":param" segments filled
+// in from params in order. Records without a URL of their own pass the
+// collection that lists them, or an empty route to send no Location. Clients
+// that send "Prefer: return=minimal" get an empty body instead of the created
+// entity, and Preference-Applied confirms it.
+func respondCreated(c *gin.Context, body any, route string, params ...string) {
+	if route != "" {
+		c.Header("Location", resourcePath(route, params...))
+	}
+	if prefersMinimal(c) {
+		c.Header("Preference-Applied", "return=minimal")
+		c.Status(StatusCreated)
+		return
+	}
+	c.JSON(StatusCreated, body)
+}
+
+// resourcePath is the absolute path of route with each ":param" segment
+// replaced by the next of params.
+func resourcePath(route string, params ...string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") && len(params) > 0 {
+			segments[i], params = params[0], params[1:]
+		}
+	}
+	return APIVersion + strings.Join(segments, "/")
+}
+
+// prefersMinimal reports whether the Prefer headers (RFC 7240) ask for
+// return=minimal. Preferences are comma separated and may carry parameters
+// after a semicolon.
+func prefersMinimal(c *gin.Context) bool {
+	for _, header := range c.Request.Header.Values("Prefer") {
+		for _, preference := range strings.Split(header, ",") {
+			preference, _, _ = strings.Cut(preference, ";")
+			name, value, _ := strings.Cut(strings.TrimSpace(preference), "=")
+			if strings.EqualFold(strings.TrimSpace(name), "return") && strings.EqualFold(strings.Trim(strings.TrimSpace(value), `"`), "minimal") {
+				return true
+			}
+		}
+	}
+	return false
+}
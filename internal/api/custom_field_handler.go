@@ -0,0 +1,284 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type CustomFieldHandler struct {
+	service CustomFieldService
+	logger  *logrus.Logger
+}
+
+func NewCustomFieldHandler(service CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *CustomFieldHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering custom field routes")
+	r.POST(CustomFieldsEndpoint, h.CreateCustomField)
+	r.GET(CustomFieldsEndpoint, h.ListCustomFields)
+	r.GET(CustomFieldByID, h.GetCustomField)
+	r.PUT(CustomFieldByID, h.UpdateCustomField)
+	r.DELETE(CustomFieldByID, h.DeleteCustomField)
+}
+
+type createCustomFieldRequest struct {
+	Entity    domain.CustomFieldEntity `json:"entity" binding:"required,enum"`
+	ProjectID *uuid.UUID               `json:"project_id" binding:"omitempty,exists=project"`
+	Key       string                   `json:"key" binding:"required"`
+	Label     string                   `json:"label"`
+	Type      domain.CustomFieldType   `json:"type" binding:"required,enum"`
+	Options   []string                 `json:"options"`
+	Required  bool                     `json:"required"`
+}
+
+type updateCustomFieldRequest struct {
+	Label    string   `json:"label"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+}
+
+// @Summary Create custom field
+// @Description Define a custom field for projects or project items. Type is text, number, date or select; select fields need options. Item fields may be scoped to one project with project_id. Global fields can only be defined by admins, project-scoped ones also by the project owner.
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body createCustomFieldRequest true "Custom field definition"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.CustomFieldDefinition
+// @Header 201 {string} Location "URL of the created custom field definition"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Key already in use"
+// @Router /v1/custom-fields [post]
+func (h *CustomFieldHandler) CreateCustomField(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req createCustomFieldRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for custom field creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"entity":     req.Entity,
+		"project_id": req.ProjectID,
+		"key":        req.Key,
+		"ip":         c.ClientIP(),
+	}).Info("Creating custom field")
+
+	definition, err := h.service.CreateCustomField(c.Request.Context(), &domain.CustomFieldDefinition{
+		Entity:    req.Entity,
+		ProjectID: req.ProjectID,
+		Key:       req.Key,
+		Label:     req.Label,
+		Type:      req.Type,
+		Options:   req.Options,
+		Required:  req.Required,
+	}, userID, isAdmin(c))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"key":   req.Key,
+		}).Warn("Failed to create custom field")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	respondCreated(c, definition, CustomFieldByID, definition.ID.String())
+}
+
+type listCustomFieldsQuery struct {
+	Entity     domain.CustomFieldEntity `form:"entity" binding:"omitempty,enum"`
+	ProjectID  *uuid.UUID               `form:"project_id"`
+	ExactScope bool                     `form:"exact_scope"`
+}
+
+// @Summary List custom fields
+// @Description List custom field definitions. With project_id the fields applying to that project's items are returned, global ones included, unless exact_scope is set.
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entity query string false "Filter by entity: project or item"
+// @Param project_id query string false "Project whose item fields to return"
+// @Param exact_scope query bool false "Only return fields defined at exactly this scope"
+// @Success 200 {array} domain.CustomFieldDefinition
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/custom-fields [get]
+func (h *CustomFieldHandler) ListCustomFields(c *gin.Context) {
+	var query listCustomFieldsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	filter := domain.CustomFieldParams{
+		Entity:     query.Entity,
+		ProjectID:  query.ProjectID,
+		ExactScope: query.ExactScope,
+	}
+
+	definitions, err := h.service.ListCustomFields(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list custom fields")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, definitions)
+}
+
+// @Summary Get custom field
+// @Description Get a custom field definition by its ID
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Custom field ID"
+// @Success 200 {object} domain.CustomFieldDefinition
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/custom-fields/{id} [get]
+func (h *CustomFieldHandler) GetCustomField(c *gin.Context) {
+	id, ok := h.customFieldID(c)
+	if !ok {
+		return
+	}
+
+	definition, err := h.service.GetCustomField(c.Request.Context(), id)
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, definition)
+}
+
+// @Summary Update custom field
+// @Description Change the label, options and required flag of a custom field. Its key, type and scope cannot change.
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Custom field ID"
+// @Param request body updateCustomFieldRequest true "Custom field changes"
+// @Success 200 {object} domain.CustomFieldDefinition
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/custom-fields/{id} [put]
+func (h *CustomFieldHandler) UpdateCustomField(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, ok := h.customFieldID(c)
+	if !ok {
+		return
+	}
+
+	var req updateCustomFieldRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for custom field update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	definition, err := h.service.UpdateCustomField(c.Request.Context(), &domain.CustomFieldDefinition{
+		ID:       id,
+		Label:    req.Label,
+		Options:  req.Options,
+		Required: req.Required,
+	}, userID, isAdmin(c))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"custom_field_id": id,
+		}).Warn("Failed to update custom field")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, definition)
+}
+
+// @Summary Delete custom field
+// @Description Delete a custom field definition and remove its values from every project or item
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Custom field ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/custom-fields/{id} [delete]
+func (h *CustomFieldHandler) DeleteCustomField(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, ok := h.customFieldID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteCustomField(c.Request.Context(), id, userID, isAdmin(c)); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"custom_field_id": id,
+		}).Warn("Failed to delete custom field")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
+
+func (h *CustomFieldHandler) customFieldID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid custom field ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, false
+	}
+	return id, true
+}
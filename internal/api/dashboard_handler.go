@@ -0,0 +1,66 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type DashboardHandler struct {
+	service DashboardService
+	logger  *logrus.Logger
+}
+
+func NewDashboardHandler(service DashboardService) *DashboardHandler {
+	return &DashboardHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *DashboardHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering dashboard routes")
+	r.GET(DashboardEndpoint, h.GetDashboard)
+}
+
+// @Summary Get dashboard
+// @Description Get the authenticated user's landing screen in one call: their open items soonest due first, open, overdue and due-today counts, latest notifications, owned projects counted by status and the products at or below the low stock threshold
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.Dashboard
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/dashboard [get]
+func (h *DashboardHandler) GetDashboard(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"path":      c.Request.URL.Path,
+			"client_ip": c.ClientIP(),
+		}).Warn("Dashboard request without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": userID,
+		"ip":      c.ClientIP(),
+	}).Info("Getting dashboard")
+
+	dashboard, err := h.service.GetDashboard(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   userID,
+			"client_ip": c.ClientIP(),
+		}).Error("Failed to get dashboard")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, dashboard)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type EmailTemplateHandler struct {
+	service EmailTemplateService
+	logger  *logrus.Logger
+}
+
+func NewEmailTemplateHandler(service EmailTemplateService) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *EmailTemplateHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering email template routes")
+	admin := RequireRole(domain.RoleAdmin)
+	r.GET(AdminEmailTemplates, admin, h.ListEmailTemplates)
+	r.GET(AdminEmailTemplatePreview, admin, h.PreviewEmailTemplate)
+}
+
+// @Summary List email templates
+// @Description List the templates notification emails are rendered from and the locales each is available in (admin only)
+// @Tags email-templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.EmailTemplateInfo
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /v1/admin/email-templates [get]
+func (h *EmailTemplateHandler) ListEmailTemplates(c *gin.Context) {
+	c.JSON(StatusOK, h.service.ListEmailTemplates())
+}
+
+// emailPreviewQuery picks the locale of a preview and whether it is
+// answered as JSON with every part, or as the bare HTML or text body.
+type emailPreviewQuery struct {
+	Locale domain.Locale `form:"locale,default=en" binding:"enum"`
+	Format string        `form:"format,default=json" binding:"oneof=json html text"`
+}
+
+// @Summary Preview email template
+// @Description Render an email template with sample data (admin only). format=html answers with the HTML body so it can be opened in a browser, format=text with the plain text body.
+// @Tags email-templates
+// @Accept json
+// @Produce json,html,plain
+// @Security BearerAuth
+// @Param name path string true "Template name, such as report_delivery"
+// @Param locale query string false "Locale: en or pt-BR (default: en)"
+// @Param format query string false "json, html or text (default: json)"
+// @Success 200 {object} domain.EmailContent
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/admin/email-templates/{name}/preview [get]
+func (h *EmailTemplateHandler) PreviewEmailTemplate(c *gin.Context) {
+	var query emailPreviewQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	template := domain.EmailTemplate(c.Param("name"))
+	content, err := h.service.PreviewEmailTemplate(template, query.Locale)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"template": template,
+			"locale":   query.Locale,
+		}).Warn("Failed to preview email template")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	switch query.Format {
+	case "html":
+		c.Data(StatusOK, "text/html; charset=utf-8", []byte(content.HTML))
+	case "text":
+		c.Data(StatusOK, "text/plain; charset=utf-8", []byte(content.Text))
+	default:
+		c.JSON(StatusOK, content)
+	}
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// AppError is what handlers push onto the context with abortWithError.
+// Status is used when Err is not one of the errorStatuses, and Details are
+// added to the response body next to "error".
+type AppError struct {
+	Status  int
+	Err     error
+	Details gin.H
+}
+
+func (e *AppError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// errorStatuses maps domain errors to the status they get wherever they are
+// returned, overriding the handler's fallback.
+var errorStatuses = []struct {
+	err    error
+	status int
+}{
+	{domain.ErrCustomFieldNotFound, StatusNotFound},
+	{domain.ErrPolicyDocumentNotFound, StatusNotFound},
+	{domain.ErrProjectExportNotFound, StatusNotFound},
+	{domain.ErrReportSubscriptionNotFound, StatusNotFound},
+	{domain.ErrRetentionRuleNotFound, StatusNotFound},
+	{domain.ErrArchivedRecordNotFound, StatusNotFound},
+	{domain.ErrFaultRuleNotFound, StatusNotFound},
+	{domain.ErrEmailTemplateNotFound, StatusNotFound},
+	{domain.ErrSavedFilterNotFound, StatusNotFound},
+	{domain.ErrUserExportNotFound, StatusNotFound},
+	{domain.ErrNotificationNotFound, StatusNotFound},
+	{domain.ErrDeviceNotFound, StatusNotFound},
+	{domain.ErrChatConnectorNotFound, StatusNotFound},
+	{domain.ErrOAuthProviderDisabled, StatusNotFound},
+	{domain.ErrCalendarFeedNotFound, StatusNotFound},
+	{domain.ErrProductImportNotFound, StatusNotFound},
+	{domain.ErrOrderNotFound, StatusNotFound},
+	{domain.ErrCategoryNotFound, StatusNotFound},
+	{domain.ErrAttachmentNotFound, StatusNotFound},
+	{domain.ErrFileNotFound, StatusNotFound},
+	{domain.ErrProjectItemNotFound, StatusNotFound},
+	{domain.ErrCommentNotFound, StatusNotFound},
+
+	{domain.ErrRefreshTokenInvalid, StatusUnauthorized},
+	{domain.ErrOAuthCodeInvalid, StatusUnauthorized},
+	{domain.ErrMFACodeInvalid, StatusUnauthorized},
+	{domain.ErrMFAChallengeInvalid, StatusUnauthorized},
+
+	{domain.ErrCustomFieldForbidden, StatusForbidden},
+	{domain.ErrSavedFilterForbidden, StatusForbidden},
+	{domain.ErrOrderForbidden, StatusForbidden},
+	{domain.ErrOAuthEmailUnverified, StatusForbidden},
+	{domain.ErrInvalidFileSignature, StatusForbidden},
+	{domain.ErrCommentForbidden, StatusForbidden},
+
+	{domain.ErrEmailTaken, StatusConflict},
+	{domain.ErrVersionConflict, StatusConflict},
+	{domain.ErrIdempotencyKeyInUse, StatusConflict},
+	{domain.ErrIdempotencyKeyReused, StatusUnprocessableEntity},
+	{domain.ErrCustomFieldKeyTaken, StatusConflict},
+	{domain.ErrPolicyVersionExists, StatusConflict},
+	{domain.ErrPolicyDocumentSuperseded, StatusConflict},
+	{domain.ErrRetentionRuleExists, StatusConflict},
+	{domain.ErrArchivedRecordOrphaned, StatusConflict},
+	{domain.ErrUserExportInProgress, StatusConflict},
+	{domain.ErrUserExportNotReady, StatusConflict},
+	{domain.ErrProjectExportNotReady, StatusConflict},
+	{domain.ErrProjectArchived, StatusConflict},
+	{domain.ErrProductArchived, StatusConflict},
+	{domain.ErrProductDeleted, StatusConflict},
+	{domain.ErrPurchaseOrderStatus, StatusConflict},
+	{domain.ErrOrderStatus, StatusConflict},
+	{domain.ErrProjectItemTransition, StatusConflict},
+	{domain.ErrCategoryNameTaken, StatusConflict},
+	{domain.ErrCategoryInUse, StatusConflict},
+	{domain.ErrInsufficientStock, StatusConflict},
+	{domain.ErrInsufficientWarehouseStock, StatusConflict},
+	{domain.ErrWarehouseNotEmpty, StatusConflict},
+	{domain.ErrCouponUsageLimitReached, StatusConflict},
+	{domain.ErrAccountNotRestorable, StatusConflict},
+	{domain.ErrMFAAlreadyEnabled, StatusConflict},
+	{domain.ErrMFANotEnrolled, StatusConflict},
+
+	{domain.ErrUserExportExpired, StatusGone},
+	{domain.ErrFileTooLarge, StatusRequestEntityTooLarge},
+	{domain.ErrUnsupportedFileType, StatusUnsupportedMediaType},
+	{domain.ErrAccountLocked, StatusLocked},
+	{domain.ErrPolicyAcceptanceRequired, StatusUpgradeRequired},
+	{domain.ErrTooManyLoginAttempts, StatusTooManyRequests},
+	{domain.ErrRateLimited, StatusTooManyRequests},
+	{domain.ErrExchangeRatesDisabled, StatusServiceUnavailable},
+	{domain.ErrRefreshTokensDisabled, StatusServiceUnavailable},
+	{domain.ErrPasswordResetDisabled, StatusServiceUnavailable},
+
+	{domain.ErrPasswordResetTokenInvalid, StatusBadRequest},
+	{domain.ErrOAuthStateInvalid, StatusBadRequest},
+	{domain.ErrUnknownFacet, StatusBadRequest},
+	{domain.ErrUnknownCategory, StatusBadRequest},
+	{domain.ErrAmbiguousCategory, StatusBadRequest},
+	{domain.ErrCategoryCycle, StatusBadRequest},
+	{domain.ErrInvalidBarcode, StatusBadRequest},
+	{domain.ErrInvalidEmail, StatusBadRequest},
+	{domain.ErrInvalidMoney, StatusBadRequest},
+	{domain.ErrInvalidImportFile, StatusBadRequest},
+	{domain.ErrInvalidPatch, StatusBadRequest},
+	{domain.ErrEmptyFile, StatusBadRequest},
+	{domain.ErrEmptyComment, StatusBadRequest},
+	{domain.ErrParentItemNotFound, StatusBadRequest},
+	{domain.ErrParentItemProject, StatusBadRequest},
+	{domain.ErrParentItemCycle, StatusBadRequest},
+
+	{errUnsupportedPatchType, StatusUnsupportedMediaType},
+	{errInvalidIfMatch, StatusBadRequest},
+	{domain.ErrInvalidIdempotencyKey, StatusBadRequest},
+}
+
+// abortWithError stops the handler chain and leaves err for
+// ErrorHandlerMiddleware, which answers with fallback unless err maps to a
+// status of its own.
+func abortWithError(c *gin.Context, fallback int, err error) {
+	_ = c.Error(&AppError{Status: fallback, Err: err})
+	c.Abort()
+}
+
+// abortWithMessage is abortWithError for failures that have no error value,
+// such as a malformed path parameter.
+func abortWithMessage(c *gin.Context, status int, message string) {
+	abortWithError(c, status, errors.New(message))
+}
+
+// abortWithDetails is abortWithError with extra fields in the body.
+func abortWithDetails(c *gin.Context, fallback int, err error, details gin.H) {
+	_ = c.Error(&AppError{Status: fallback, Err: err, Details: details})
+	c.Abort()
+}
+
+// errorStatus is the status the response to err gets.
+func errorStatus(err error) int {
+	for _, mapping := range errorStatuses {
+		if errors.Is(err, mapping.err) {
+			return mapping.status
+		}
+	}
+
+	var invalid *domain.InvalidValueError
+	var fields domain.ValidationErrors
+	if errors.As(err, &invalid) || errors.As(err, &fields) {
+		return StatusBadRequest
+	}
+
+	var appErr *AppError
+	if errors.As(err, &appErr) && appErr.Status != 0 {
+		return appErr.Status
+	}
+	return StatusInternalServerError
+}
+
+// ErrorHandlerMiddleware writes the response for the last error a handler
+// pushed with abortWithError. The body comes from errorResponse, except for
+// 5xx responses, whose cause is logged instead of returned to the client.
+func ErrorHandlerMiddleware() gin.HandlerFunc {
+	logger := infrastructure.WithRedaction(logrus.New())
+
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		status := errorStatus(err)
+
+		fields := logrus.Fields{
+			"error":  err.Error(),
+			"status": status,
+			"method": c.Request.Method,
+			"path":   c.Request.URL.Path,
+		}
+		if status >= StatusInternalServerError {
+			logger.WithFields(fields).Error("Request failed")
+			c.JSON(status, gin.H{"error": "Internal server error"})
+			return
+		}
+		logger.WithFields(fields).Debug("Request rejected")
+
+		body := errorResponse(err)
+		var appErr *AppError
+		if errors.As(err, &appErr) {
+			for key, value := range appErr.Details {
+				body[key] = value
+			}
+		}
+		c.JSON(status, body)
+	}
+}
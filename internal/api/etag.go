@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConditionalGetMiddleware answers a GET with 304 Not Modified and no body
+// when the handler set an ETag that the request's If-None-Match names, so
+// polling clients only download a resource again once it changed. Handlers
+// opt in with setETag; other responses pass through untouched. It must run
+// before ResponseCacheMiddleware so cached answers are checked too.
+func ConditionalGetMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			return
+		}
+		ifNoneMatch := c.GetHeader("If-None-Match")
+		if ifNoneMatch == "" {
+			return
+		}
+		c.Writer = &conditionalWriter{ResponseWriter: c.Writer, ifNoneMatch: ifNoneMatch}
+	}
+}
+
+// conditionalWriter swaps a 200 for a 304 when the first byte of the body
+// is written, once the handler has set its headers.
+type conditionalWriter struct {
+	gin.ResponseWriter
+	ifNoneMatch string
+	checked     bool
+	notModified bool
+}
+
+func (w *conditionalWriter) check() {
+	if w.checked {
+		return
+	}
+	w.checked = true
+
+	tag := w.Header().Get("ETag")
+	if w.Written() || w.Status() != http.StatusOK || tag == "" || !etagMatches(w.ifNoneMatch, tag) {
+		return
+	}
+	w.notModified = true
+	w.Header().Del("Content-Type")
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(http.StatusNotModified)
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *conditionalWriter) WriteHeaderNow() {
+	w.check()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *conditionalWriter) Write(data []byte) (int, error) {
+	w.check()
+	if w.notModified {
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *conditionalWriter) WriteString(s string) (int, error) {
+	w.check()
+	if w.notModified {
+		return len(s), nil
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// setETag tags the response with weakETag, letting ConditionalGetMiddleware
+// answer 304 to a client that already has it.
+func setETag(c *gin.Context, updatedAt time.Time, derived ...any) {
+	c.Header("ETag", weakETag(updatedAt, derived...))
+}
+
+// weakETag is the entity tag of a resource last changed at updatedAt. It is
+// weak because equal tags only promise the same content, not the same bytes.
+// derived are values shown with the resource that change without moving
+// updatedAt, such as a project's progress; they are hashed into the tag.
+func weakETag(updatedAt time.Time, derived ...any) string {
+	tag := strconv.FormatInt(updatedAt.UnixNano(), 36)
+	if len(derived) > 0 {
+		hash := fnv.New64a()
+		_ = json.NewEncoder(hash).Encode(derived)
+		tag += "-" + strconv.FormatUint(hash.Sum64(), 36)
+	}
+	return `W/"` + tag + `"`
+}
+
+// etagMatches reports whether the If-None-Match header, a list of entity
+// tags or *, names tag. The comparison is weak: W/ prefixes are ignored.
+func etagMatches(header, tag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	tag = strings.TrimPrefix(tag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type ExchangeRateHandler struct {
+	service ExchangeRateService
+	logger  *logrus.Logger
+}
+
+func NewExchangeRateHandler(service ExchangeRateService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *ExchangeRateHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering exchange rate routes")
+	admin := RequireRole(domain.RoleAdmin)
+	r.GET(ExchangeRates, h.ListExchangeRates)
+	r.POST(AdminExchangeRateRefresh, admin, h.RefreshExchangeRates)
+	r.GET(AdminExchangeRateSyncs, admin, h.ListExchangeRateSyncs)
+	r.GET(AdminExchangeRateMetrics, admin, h.ExchangeRateMetrics)
+}
+
+// @Summary List exchange rates
+// @Description List how many units of each currency one unit of the store currency buys, ordered by currency, as last synced from the configured provider. Check fetched_at before converting with a rate.
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.ExchangeRate
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/exchange-rates [get]
+func (h *ExchangeRateHandler) ListExchangeRates(c *gin.Context) {
+	rates, err := h.service.ListExchangeRates(c.Request.Context())
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list exchange rates")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, rates)
+}
+
+// @Summary Refresh exchange rates
+// @Description Sync the exchange rates from the configured provider now and return the recorded sync, whose error is set when the provider failed (admin only)
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.ExchangeRateSync
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 503 {object} map[string]interface{} "No provider configured"
+// @Router /v1/admin/exchange-rates/refresh [post]
+func (h *ExchangeRateHandler) RefreshExchangeRates(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": userID,
+		"ip":      c.ClientIP(),
+	}).Info("Refreshing exchange rates")
+
+	sync, err := h.service.RefreshExchangeRates(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Warn("Failed to refresh exchange rates")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, sync)
+}
+
+// @Summary List exchange rate syncs
+// @Description List the recorded exchange rate syncs, newest first (admin only). Scheduled syncs have no triggered_by.
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.ExchangeRateSync
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/admin/exchange-rates/syncs [get]
+func (h *ExchangeRateHandler) ListExchangeRateSyncs(c *gin.Context) {
+	var query pageQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	syncs, err := h.service.ListExchangeRateSyncs(c.Request.Context(), query.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list exchange rate syncs")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, syncs)
+}
+
+// @Summary Exchange rate metrics
+// @Description How old the stored exchange rates are and how the recent syncs went (admin only). age_seconds is the age of the oldest stored rate; stale is set once it exceeds EXCHANGE_RATE_STALE_AFTER or when no rates were synced yet.
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.ExchangeRateMetrics
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/admin/exchange-rates/metrics [get]
+func (h *ExchangeRateHandler) ExchangeRateMetrics(c *gin.Context) {
+	metrics, err := h.service.ExchangeRateMetrics(c.Request.Context())
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to compute exchange rate metrics")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, metrics)
+}
@@ -0,0 +1,417 @@
+package api
+
+import (
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ExpenseHandler struct {
+	service ExpenseService
+	logger  *logrus.Logger
+}
+
+func NewExpenseHandler(service ExpenseService) *ExpenseHandler {
+	return &ExpenseHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *ExpenseHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering expense routes")
+	r.POST(ProjectExpenses, h.CreateExpense)
+	r.GET(ProjectExpenses, h.ListExpenses)
+	r.GET(ProjectExpenseByID, h.GetExpense)
+	r.PUT(ProjectExpenseByID, h.UpdateExpense)
+	r.DELETE(ProjectExpenseByID, h.DeleteExpense)
+	r.GET(ProjectStats, h.GetProjectStats)
+}
+
+type expenseRequest struct {
+	Amount      domain.Money `json:"amount" binding:"required,gt=0"`
+	Category    string       `json:"category" binding:"required"`
+	Description string       `json:"description"`
+	Date        *time.Time   `json:"date"`
+	ReceiptURL  string       `json:"receipt_url" binding:"omitempty,url"`
+}
+
+func (req expenseRequest) expense(projectID uuid.UUID) *domain.Expense {
+	expense := &domain.Expense{
+		ProjectID:   projectID,
+		Amount:      req.Amount,
+		Category:    req.Category,
+		Description: req.Description,
+		ReceiptURL:  req.ReceiptURL,
+	}
+	if req.Date != nil {
+		expense.Date = *req.Date
+	}
+	return expense
+}
+
+// parseExpensePath reads the project and, when withExpense is set, the expense
+// IDs from the path, writing a 400 and returning false when either is invalid.
+func (h *ExpenseHandler) parseExpensePath(c *gin.Context, withExpense bool) (uuid.UUID, uuid.UUID, bool) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project ID format for expense")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	if !withExpense {
+		return projectID, uuid.Nil, true
+	}
+
+	expenseID, err := uuid.Parse(c.Param("expenseId"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"param_id":   c.Param("expenseId"),
+			"project_id": projectID,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Invalid expense ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return projectID, expenseID, true
+}
+
+// @Summary Create expense
+// @Description Record an expense against a project. The authenticated user is recorded as its author; date defaults to now.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param request body expenseRequest true "Expense data"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.Expense
+// @Header 201 {string} Location "URL of the created expense"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /v1/projects/{id}/expenses [post]
+func (h *ExpenseHandler) CreateExpense(c *gin.Context) {
+	projectID, _, ok := h.parseExpensePath(c, false)
+	if !ok {
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Expense creation without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"project_id": projectID,
+		"actor_id":   actorID,
+		"ip":         c.ClientIP(),
+	}).Info("Creating new expense")
+
+	var req expenseRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Invalid request body for expense creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	expense := req.expense(projectID)
+	expense.CreatedBy = actorID
+	expense, err := h.service.CreateExpense(c.Request.Context(), expense)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+		}).Error("Failed to create expense")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"expense_id": expense.ID,
+		"project_id": projectID,
+	}).Info("Expense created successfully")
+
+	respondCreated(c, expense, ProjectExpenseByID, expense.ProjectID.String(), expense.ID.String())
+}
+
+type listExpensesQuery struct {
+	pageQuery
+	Category string     `form:"category"`
+	DateFrom *time.Time `form:"date_from"`
+	DateTo   *time.Time `form:"date_to,end_of_day"`
+}
+
+// @Summary List expenses
+// @Description Get the expenses of a project with optional filtering and pagination
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param category query string false "Filter by category"
+// @Param date_from query string false "Expenses on or after this date (RFC3339 or YYYY-MM-DD)"
+// @Param date_to query string false "Expenses on or before this date (RFC3339 or YYYY-MM-DD)"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. date desc; fields: amount, category, date, created_at, updated_at (default: date desc)"
+// @Success 200 {array} domain.Expense
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/projects/{id}/expenses [get]
+func (h *ExpenseHandler) ListExpenses(c *gin.Context) {
+	projectID, _, ok := h.parseExpensePath(c, false)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"project_id": projectID,
+		"ip":         c.ClientIP(),
+	}).Info("Listing expenses")
+
+	var query listExpensesQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	filter := domain.ExpenseParams{
+		Category: query.Category,
+		DateFrom: query.DateFrom,
+		DateTo:   query.DateTo,
+	}
+	sort, err := sortQuery(c, domain.ExpenseSort, "date desc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := query.pagination(sort)
+
+	expenses, err := h.service.ListExpenses(c.Request.Context(), projectID, filter, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Failed to list expenses")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"count":      len(expenses),
+	}).Info("Expenses listed successfully")
+
+	c.JSON(StatusOK, expenses)
+}
+
+// @Summary Get expense by ID
+// @Description Get a specific expense of a project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param expenseId path string true "Expense ID"
+// @Success 200 {object} domain.Expense
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/projects/{id}/expenses/{expenseId} [get]
+func (h *ExpenseHandler) GetExpense(c *gin.Context) {
+	projectID, expenseID, ok := h.parseExpensePath(c, true)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"project_id": projectID,
+		"expense_id": expenseID,
+		"ip":         c.ClientIP(),
+	}).Info("Getting expense by ID")
+
+	expense, err := h.service.GetExpense(c.Request.Context(), projectID, expenseID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"expense_id": expenseID,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Expense not found")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	c.JSON(StatusOK, expense)
+}
+
+// @Summary Update expense
+// @Description Update an expense of a project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param expenseId path string true "Expense ID"
+// @Param request body expenseRequest true "Expense data"
+// @Success 200 {object} domain.Expense
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /v1/projects/{id}/expenses/{expenseId} [put]
+func (h *ExpenseHandler) UpdateExpense(c *gin.Context) {
+	projectID, expenseID, ok := h.parseExpensePath(c, true)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"project_id": projectID,
+		"expense_id": expenseID,
+		"ip":         c.ClientIP(),
+	}).Info("Updating expense")
+
+	var req expenseRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"expense_id": expenseID,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Invalid request body for expense update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	expense := req.expense(projectID)
+	expense.ID = expenseID
+	if err := h.service.UpdateExpense(c.Request.Context(), expense); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"expense_id": expenseID,
+			"client_ip":  c.ClientIP(),
+		}).Error("Failed to update expense")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"expense_id": expenseID,
+	}).Info("Expense updated successfully")
+
+	c.JSON(StatusOK, expense)
+}
+
+// @Summary Delete expense
+// @Description Delete an expense of a project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param expenseId path string true "Expense ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/projects/{id}/expenses/{expenseId} [delete]
+func (h *ExpenseHandler) DeleteExpense(c *gin.Context) {
+	projectID, expenseID, ok := h.parseExpensePath(c, true)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"project_id": projectID,
+		"expense_id": expenseID,
+		"ip":         c.ClientIP(),
+	}).Info("Deleting expense")
+
+	if err := h.service.DeleteExpense(c.Request.Context(), projectID, expenseID); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"expense_id": expenseID,
+			"client_ip":  c.ClientIP(),
+		}).Error("Failed to delete expense")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"expense_id": expenseID,
+	}).Info("Expense deleted successfully")
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary Project budget stats
+// @Description Report spending against the project budget, broken down by expense category. warnings flags budgets that are nearly consumed or exceeded.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} domain.ProjectBudgetStats
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/projects/{id}/stats [get]
+func (h *ExpenseHandler) GetProjectStats(c *gin.Context) {
+	projectID, _, ok := h.parseExpensePath(c, false)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"project_id": projectID,
+		"ip":         c.ClientIP(),
+	}).Info("Getting project stats")
+
+	stats, err := h.service.GetProjectStats(c.Request.Context(), projectID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Failed to get project stats")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"project_id":  projectID,
+		"spent":       stats.Spent,
+		"over_budget": stats.OverBudget,
+	}).Info("Project stats retrieved successfully")
+
+	c.JSON(StatusOK, stats)
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// faultHeader tells which rule a delayed or failed response came from, so a
+// client test can tell injected faults from real ones.
+const faultHeader = "X-Fault-Injected"
+
+type FaultHandler struct {
+	service FaultService
+	logger  *logrus.Logger
+}
+
+func NewFaultHandler(service FaultService) *FaultHandler {
+	return &FaultHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *FaultHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Warn("Registering fault injection routes")
+	admin := RequireRole(domain.RoleAdmin)
+	r.POST(AdminFaults, admin, h.CreateFaultRule)
+	r.GET(AdminFaults, admin, h.ListFaultRules)
+	r.DELETE(AdminFaults, admin, h.ClearFaultRules)
+	r.DELETE(AdminFaultByID, admin, h.DeleteFaultRule)
+}
+
+// InjectFaults delays, fails or drops the requests the fault rules match.
+// It runs before authentication, so rules apply to every caller. The fault
+// routes themselves are never affected, so a rule can always be removed.
+func (h *FaultHandler) InjectFaults(c *gin.Context) {
+	route := c.FullPath()
+	if route == "" || strings.HasPrefix(route, APIVersion+AdminFaults) {
+		return
+	}
+
+	fault, ok := h.service.Fault(c.Request.Method, route)
+	if !ok {
+		return
+	}
+	c.Header(faultHeader, fault.RuleID.String())
+
+	if fault.Latency > 0 {
+		timer := time.NewTimer(fault.Latency)
+		select {
+		case <-timer.C:
+		case <-c.Request.Context().Done():
+			timer.Stop()
+			c.Abort()
+			return
+		}
+	}
+
+	if fault.Drop {
+		h.logger.WithFields(logrus.Fields{
+			"rule_id": fault.RuleID,
+			"method":  c.Request.Method,
+			"path":    c.Request.URL.Path,
+		}).Debug("Dropping connection")
+		conn, _, err := c.Writer.Hijack()
+		if err == nil {
+			_ = conn.Close()
+			c.Abort()
+			return
+		}
+		// HTTP/2 connections cannot be taken over, so the request fails
+		// with an error instead.
+		fault.Status = domain.DefaultFaultErrorStatus
+	}
+
+	if fault.Status != 0 {
+		h.logger.WithFields(logrus.Fields{
+			"rule_id": fault.RuleID,
+			"method":  c.Request.Method,
+			"path":    c.Request.URL.Path,
+			"status":  fault.Status,
+		}).Debug("Injecting error response")
+		abortWithMessage(c, fault.Status, "injected fault")
+	}
+}
+
+// faultRuleRequest is the body of create requests. error_status defaults to
+// 503.
+type faultRuleRequest struct {
+	Method      string     `json:"method"`
+	Path        string     `json:"path"`
+	LatencyMS   int        `json:"latency_ms"`
+	JitterMS    int        `json:"jitter_ms"`
+	ErrorRate   float64    `json:"error_rate"`
+	ErrorStatus int        `json:"error_status"`
+	DropRate    float64    `json:"drop_rate"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+// @Summary Create fault rule
+// @Description Make the requests matching method and path misbehave, to test client timeouts, retries and circuit breakers (admin only; only available when SERVER_FAULT_INJECTION is on, never in production). Every matching request is delayed by latency_ms plus up to jitter_ms; then drop_rate of them have their connection closed without an answer and error_rate of them get error_status (default 503). path is a route as registered, e.g. /v1/products/:id, or a prefix ending in *; empty method or path match everything. The first matching rule applies. Rules live in the memory of the instance that received them and stop at expires_at.
+// @Tags faults
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body faultRuleRequest true "Fault rule"
+// @Success 201 {object} domain.FaultRule
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /v1/admin/faults [post]
+func (h *FaultHandler) CreateFaultRule(c *gin.Context) {
+	var req faultRuleRequest
+	if err := bindJSON(c, &req); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	rule, err := h.service.CreateFaultRule(c.Request.Context(), &domain.FaultRule{
+		Method:      req.Method,
+		Path:        req.Path,
+		LatencyMS:   req.LatencyMS,
+		JitterMS:    req.JitterMS,
+		ErrorRate:   req.ErrorRate,
+		ErrorStatus: req.ErrorStatus,
+		DropRate:    req.DropRate,
+		ExpiresAt:   req.ExpiresAt,
+	}, userID)
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusCreated, rule)
+}
+
+// @Summary List fault rules
+// @Description List the fault rules of this instance in the order they are tried, expired ones included (admin only)
+// @Tags faults
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.FaultRule
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /v1/admin/faults [get]
+func (h *FaultHandler) ListFaultRules(c *gin.Context) {
+	rules, err := h.service.ListFaultRules(c.Request.Context())
+	if err != nil {
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, rules)
+}
+
+// @Summary Clear fault rules
+// @Description Delete every fault rule of this instance (admin only)
+// @Tags faults
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /v1/admin/faults [delete]
+func (h *FaultHandler) ClearFaultRules(c *gin.Context) {
+	if _, err := h.service.ClearFaultRules(c.Request.Context()); err != nil {
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary Delete fault rule
+// @Description Delete a fault rule (admin only)
+// @Tags faults
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Fault rule ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/faults/{id} [delete]
+func (h *FaultHandler) DeleteFaultRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	if err := h.service.DeleteFaultRule(c.Request.Context(), id); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
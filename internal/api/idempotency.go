@@ -0,0 +1,148 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// IdempotencyKeyHeader names a POST so that retrying it, e.g. after a
+// timeout, cannot create the same record twice.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyMiddleware makes POSTs that carry an Idempotency-Key safe to
+// retry. The first request with a key runs and its response is kept for
+// ttl; a retry with the same key and the same method, path, query and body
+// gets that response again, marked with Idempotent-Replayed, without
+// running the handler. A retry while the first request still runs answers
+// 409, and the key reused for a different request answers 422.
+//
+// Keys are per user, so it must run after AuthMiddleware. Failed requests,
+// whether the handler reported an error or answered 500 and above, are not
+// kept, letting a retry run again. When the store fails the request goes
+// through as if it had no key.
+func IdempotencyMiddleware(store domain.IdempotencyStore, ttl time.Duration, clock domain.Clock) gin.HandlerFunc {
+	logger := infrastructure.WithRedaction(logrus.New())
+
+	return func(c *gin.Context) {
+		header := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || header == "" {
+			return
+		}
+		if len(header) > domain.MaxIdempotencyKeyLength {
+			abortWithError(c, StatusBadRequest, domain.ErrInvalidIdempotencyKey)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, domain.MaxIdempotentBodyBytes+1))
+		if err != nil {
+			abortWithError(c, StatusBadRequest, err)
+			return
+		}
+		if len(body) > domain.MaxIdempotentBodyBytes {
+			abortWithMessage(c, StatusRequestEntityTooLarge, fmt.Sprintf("requests with an %s take bodies of up to %d bytes", IdempotencyKeyHeader, domain.MaxIdempotentBodyBytes))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		key := c.GetString("user_id") + ":" + header
+		hash := idempotencyRequestHash(c, body)
+
+		// The client may be gone by the time the response is stored, which
+		// is exactly when it will retry.
+		ctx := context.WithoutCancel(c.Request.Context())
+		existing, claimed, err := store.Claim(ctx, key, hash, clock.Now())
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"path":  c.Request.URL.Path,
+			}).Warn("Idempotency store failed, running the request without its key")
+			return
+		}
+
+		if !claimed {
+			switch {
+			case existing.RequestHash != hash:
+				logger.WithFields(logrus.Fields{
+					"path": c.Request.URL.Path,
+				}).Warn("Idempotency key reused for a different request")
+				abortWithError(c, StatusUnprocessableEntity, domain.ErrIdempotencyKeyReused)
+			case !existing.Completed():
+				abortWithError(c, StatusConflict, domain.ErrIdempotencyKeyInUse)
+			default:
+				logger.WithFields(logrus.Fields{
+					"path":   c.Request.URL.Path,
+					"status": existing.Status,
+				}).Info("Replaying idempotent response")
+				if existing.Location != "" {
+					c.Header("Location", existing.Location)
+				}
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(existing.Status, existing.ContentType, existing.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := store.Release(ctx, key); err != nil {
+				logger.WithFields(logrus.Fields{
+					"error": err.Error(),
+				}).Warn("Failed to release idempotency key")
+			}
+		}()
+
+		writer := &cacheWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		// Handlers report errors through c.Error and leave the response to
+		// ErrorHandlerMiddleware, which writes it after this returns, so the
+		// status here would still read 200.
+		if len(c.Errors) > 0 || writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+		err = store.Complete(ctx, &domain.IdempotencyRecord{
+			Key:         key,
+			RequestHash: hash,
+			Status:      writer.Status(),
+			ContentType: writer.Header().Get("Content-Type"),
+			Location:    writer.Header().Get("Location"),
+			Body:        writer.body.Bytes(),
+			ExpiresAt:   clock.Now().Add(ttl),
+		})
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Warn("Failed to store idempotent response")
+			return
+		}
+		completed = true
+	}
+}
+
+// idempotencyRequestHash identifies a request by method, path, query and
+// body, so a key cannot be replayed for another request.
+func idempotencyRequestHash(c *gin.Context, body []byte) string {
+	hash := sha256.New()
+	for _, part := range []string{c.Request.Method, c.Request.URL.Path, c.Request.URL.Query().Encode()} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
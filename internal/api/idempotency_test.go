@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryIdempotencyStore keeps records in a map, ignoring expiry.
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+func (s *memoryIdempotencyStore) Claim(ctx context.Context, key, requestHash string, now time.Time) (*domain.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.records[key]; ok {
+		return existing, false, nil
+	}
+	s.records[key] = &domain.IdempotencyRecord{Key: key, RequestHash: requestHash, CreatedAt: now}
+	return nil, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[record.Key] = record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+func newIdempotencyTestEngine(store domain.IdempotencyStore, clock domain.Clock, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ErrorHandlerMiddleware())
+	engine.Use(func(c *gin.Context) {
+		c.Set("user_id", "11111111-1111-1111-1111-111111111111")
+	})
+	engine.Use(IdempotencyMiddleware(store, time.Hour, clock))
+	engine.POST("/things", handler)
+	return engine
+}
+
+func postWithKey(engine *gin.Engine, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(`{"name":"a"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, key)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyMiddlewareDoesNotKeepHandlerErrors(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	engine := newIdempotencyTestEngine(store, domain.SystemClock{}, func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			abortWithError(c, StatusConflict, domain.ErrInsufficientStock)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"id": "created"})
+	})
+
+	first := postWithKey(engine, "retry-me")
+	assert.Equal(t, http.StatusConflict, first.Code)
+	assert.Contains(t, first.Body.String(), domain.ErrInsufficientStock.Error())
+	assert.Empty(t, store.records, "a failed request must release its key")
+
+	second := postWithKey(engine, "retry-me")
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Empty(t, second.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyMiddlewareReplaysSuccess(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	calls := 0
+	engine := newIdempotencyTestEngine(store, domain.NewFixedClock(now), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"id": "created"})
+	})
+
+	first := postWithKey(engine, "once")
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	second := postWithKey(engine, "once")
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, 1, calls)
+
+	for _, record := range store.records {
+		assert.Equal(t, now.Add(time.Hour), record.ExpiresAt)
+	}
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	formatJSON         = "json"
+	formatNDJSON       = "ndjson"
+	formatCSV          = "csv"
+	formatXLSX         = "xlsx"
+	streamBatchSize    = 500
+	streamWriteTimeout = 30 * time.Second
+)
+
+// streamContentTypes are the formats a list endpoint streams instead of
+// answering with one page.
+var streamContentTypes = map[string]string{
+	formatNDJSON: "application/x-ndjson",
+	formatCSV:    "text/csv; charset=utf-8",
+	formatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// formatQuery selects how a list endpoint answers: one page of JSON, or
+// every matching row as newline-delimited JSON (ndjson), CSV or an Excel
+// spreadsheet (xlsx).
+type formatQuery struct {
+	Format string `form:"format,default=json" binding:"oneof=json ndjson csv xlsx"`
+}
+
+func (q formatQuery) streaming() bool {
+	return q.Format != formatJSON
+}
+
+// listStream describes the rows of a list endpoint for streamList. name is
+// the file name, without extension, of CSV and XLSX downloads; columns lay
+// the rows out in those two formats.
+type listStream[T any] struct {
+	name    string
+	columns []domain.ExportColumn[T]
+	list    func(ctx context.Context, pagination domain.Pagination) ([]T, error)
+	id      func(T) uuid.UUID
+}
+
+// streamList writes every row list returns in format. Rows are fetched
+// streamBatchSize at a time in id order, each batch starting after the last
+// id of the previous one, and flushed as they come, so only one batch is
+// ever held in memory. limit, offset and sort do not apply.
+//
+// The write deadline is pushed back before each batch so that the server's
+// write timeout bounds a stalled client rather than the whole export. Once
+// the first rows are sent the status cannot change: a later failure ends
+// the stream early and is only logged.
+func streamList[T any](c *gin.Context, logger *logrus.Logger, format string, stream listStream[T]) {
+	ctx := c.Request.Context()
+	controller := http.NewResponseController(c.Writer)
+	pagination := domain.Pagination{Limit: streamBatchSize, Sort: "id asc"}
+
+	var encoder rowEncoder[T]
+	count := 0
+	for {
+		rows, err := stream.list(ctx, pagination)
+		if err != nil {
+			if encoder == nil {
+				abortWithError(c, StatusInternalServerError, err)
+				return
+			}
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"path":  c.Request.URL.Path,
+				"rows":  count,
+			}).Error("Failed to stream rows, response cut short")
+			return
+		}
+
+		if encoder == nil {
+			c.Header("Content-Type", streamContentTypes[format])
+			if format != formatNDJSON {
+				c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", stream.name+"."+format))
+			}
+			c.Status(StatusOK)
+			c.Writer.WriteHeaderNow()
+
+			encoder, err = newRowEncoder(format, c.Writer, stream)
+			if err != nil {
+				logger.WithFields(logrus.Fields{
+					"error": err.Error(),
+					"path":  c.Request.URL.Path,
+				}).Warn("Client stopped reading the stream")
+				return
+			}
+		}
+		_ = controller.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+
+		for _, row := range rows {
+			if err := encoder.encode(row); err != nil {
+				logger.WithFields(logrus.Fields{
+					"error": err.Error(),
+					"path":  c.Request.URL.Path,
+					"rows":  count,
+				}).Warn("Client stopped reading the stream")
+				return
+			}
+			count++
+		}
+		if err := encoder.flush(); err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"path":  c.Request.URL.Path,
+				"rows":  count,
+			}).Warn("Client stopped reading the stream")
+			return
+		}
+		c.Writer.Flush()
+
+		if len(rows) < streamBatchSize {
+			break
+		}
+		last := stream.id(rows[len(rows)-1])
+		pagination.AfterID = &last
+	}
+
+	if err := encoder.close(); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"path":  c.Request.URL.Path,
+			"rows":  count,
+		}).Warn("Client stopped reading the stream")
+		return
+	}
+	c.Writer.Flush()
+
+	logger.WithFields(logrus.Fields{
+		"path":   c.Request.URL.Path,
+		"format": format,
+		"rows":   count,
+	}).Info("Rows streamed successfully")
+}
+
+// rowEncoder writes the rows of a stream in one format. flush is called
+// after every batch and close once after the last one.
+type rowEncoder[T any] interface {
+	encode(row T) error
+	flush() error
+	close() error
+}
+
+func newRowEncoder[T any](format string, w io.Writer, stream listStream[T]) (rowEncoder[T], error) {
+	switch format {
+	case formatCSV:
+		encoder := &csvRowEncoder[T]{writer: csv.NewWriter(w), columns: stream.columns}
+		return encoder, encoder.writer.Write(domain.ExportHeader(stream.columns))
+	case formatXLSX:
+		writer, err := newXLSXWriter(w, stream.name)
+		if err != nil {
+			return nil, err
+		}
+		encoder := &xlsxRowEncoder[T]{writer: writer, columns: stream.columns}
+		return encoder, writer.writeHeader(domain.ExportHeader(stream.columns))
+	default:
+		return &ndjsonRowEncoder[T]{encoder: json.NewEncoder(w)}, nil
+	}
+}
+
+type ndjsonRowEncoder[T any] struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonRowEncoder[T]) encode(row T) error { return e.encoder.Encode(row) }
+func (e *ndjsonRowEncoder[T]) flush() error       { return nil }
+func (e *ndjsonRowEncoder[T]) close() error       { return nil }
+
+type csvRowEncoder[T any] struct {
+	writer  *csv.Writer
+	columns []domain.ExportColumn[T]
+}
+
+func (e *csvRowEncoder[T]) encode(row T) error {
+	return e.writer.Write(domain.ExportRow(e.columns, row))
+}
+
+func (e *csvRowEncoder[T]) flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvRowEncoder[T]) close() error { return e.flush() }
+
+type xlsxRowEncoder[T any] struct {
+	writer  *xlsxWriter
+	columns []domain.ExportColumn[T]
+}
+
+func (e *xlsxRowEncoder[T]) encode(row T) error {
+	cells := make([]xlsxCell, len(e.columns))
+	for i, column := range e.columns {
+		cells[i] = xlsxCell{Value: column.Value(row), Numeric: column.Numeric}
+	}
+	return e.writer.writeRow(cells)
+}
+
+func (e *xlsxRowEncoder[T]) flush() error { return e.writer.flush() }
+func (e *xlsxRowEncoder[T]) close() error { return e.writer.close() }
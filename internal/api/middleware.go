@@ -1,18 +1,28 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
-	logger := logrus.New()
+// AuthMiddleware validates the bearer token. When tokens is set, tokens
+// revoked at logout are rejected. When users is set, tokens of accounts
+// that have since been deactivated, suspended or deleted are rejected, as
+// are tokens whose subject is not a stored account, offline service tokens
+// included.
+func AuthMiddleware(users UserService, tokens TokenService) gin.HandlerFunc {
+	logger := infrastructure.WithRedaction(logrus.New())
 
 	return func(c *gin.Context) {
 		logger.WithFields(logrus.Fields{
@@ -24,11 +34,11 @@ func AuthMiddleware() gin.HandlerFunc {
 		header := c.GetHeader("Authorization")
 		if !strings.HasPrefix(header, "Bearer ") {
 			logger.WithFields(logrus.Fields{
-				"ip":     c.ClientIP(),
-				"path":   c.Request.URL.Path,
-				"header": header,
+				"ip":            c.ClientIP(),
+				"path":          c.Request.URL.Path,
+				"authorization": header,
 			}).Warn("Missing or invalid Authorization header")
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid token"})
+			abortWithMessage(c, StatusUnauthorized, "missing or invalid token")
 			return
 		}
 
@@ -40,20 +50,44 @@ func AuthMiddleware() gin.HandlerFunc {
 			"path": c.Request.URL.Path,
 		}).Debug("Parsing JWT token")
 
+		// Only HMAC tokens are ours; accepting another method would let a
+		// token signed with "none" or a public key pass as valid.
 		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+			}
 			return []byte(secret), nil
 		})
 
 		if err != nil || !token.Valid {
-			logger.WithFields(logrus.Fields{
-				"error": err.Error(),
-				"ip":    c.ClientIP(),
-				"path":  c.Request.URL.Path,
-			}).Warn("Invalid JWT token")
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			fields := logrus.Fields{
+				"ip":   c.ClientIP(),
+				"path": c.Request.URL.Path,
+			}
+			if err != nil {
+				fields["error"] = err.Error()
+			}
+			logger.WithFields(fields).Warn("Invalid JWT token")
+			abortWithMessage(c, StatusUnauthorized, "invalid token")
 			return
 		}
 
+		if tokens != nil {
+			revoked, err := tokens.IsAccessTokenRevoked(c.Request.Context(), tokenStr)
+			if err != nil {
+				abortWithError(c, StatusInternalServerError, err)
+				return
+			}
+			if revoked {
+				logger.WithFields(logrus.Fields{
+					"ip":   c.ClientIP(),
+					"path": c.Request.URL.Path,
+				}).Warn("Revoked JWT token")
+				abortWithMessage(c, StatusUnauthorized, "token has been revoked")
+				return
+			}
+		}
+
 		if claims, ok := token.Claims.(jwt.MapClaims); ok {
 			userID := claims["sub"]
 			userEmail := claims["email"]
@@ -67,14 +101,127 @@ func AuthMiddleware() gin.HandlerFunc {
 
 			c.Set("user_id", userID)
 			c.Set("user_email", userEmail)
+			c.Set("user_role", claims["role"])
+
+			// The account is looked up on every request so deactivating or
+			// deleting it takes effect before the token expires. When that
+			// cannot be checked the request is refused, never let through.
+			if users != nil {
+				subject, _ := userID.(string)
+				id, err := uuid.Parse(subject)
+				if err != nil {
+					logger.WithFields(logrus.Fields{
+						"user_id": userID,
+						"ip":      c.ClientIP(),
+						"path":    c.Request.URL.Path,
+					}).Warn("Token rejected without a valid subject")
+					abortWithMessage(c, StatusUnauthorized, "invalid token")
+					return
+				}
+
+				user, err := users.GetAccount(c.Request.Context(), id)
+				if errors.Is(err, domain.ErrAccountNotFound) {
+					logger.WithFields(logrus.Fields{
+						"user_id": userID,
+						"ip":      c.ClientIP(),
+						"path":    c.Request.URL.Path,
+					}).Warn("Token rejected for unknown account")
+					abortWithMessage(c, StatusUnauthorized, "invalid token")
+					return
+				}
+				if err != nil {
+					logger.WithFields(logrus.Fields{
+						"error":   err.Error(),
+						"user_id": userID,
+						"path":    c.Request.URL.Path,
+					}).Error("Failed to load account of token")
+					abortWithError(c, StatusInternalServerError, err)
+					return
+				}
+
+				if err := users.CheckSignIn(user); err != nil {
+					logger.WithFields(logrus.Fields{
+						"user_id": userID,
+						"ip":      c.ClientIP(),
+						"path":    c.Request.URL.Path,
+					}).Warn("Token rejected for inactive account")
+					abortWithError(c, StatusUnauthorized, err)
+					return
+				}
+			}
+
+			if rawScopes, ok := claims["scopes"].([]interface{}); ok {
+				scopes := make([]string, 0, len(rawScopes))
+				for _, raw := range rawScopes {
+					if scope, ok := raw.(string); ok {
+						scopes = append(scopes, scope)
+					}
+				}
+
+				action, resource := requestScope(c)
+				if !domain.ScopesAllow(scopes, action, resource) {
+					logger.WithFields(logrus.Fields{
+						"user_id":  userID,
+						"scopes":   scopes,
+						"action":   action,
+						"resource": resource,
+						"ip":       c.ClientIP(),
+					}).Warn("Token scopes do not allow this request")
+					abortWithMessage(c, StatusForbidden, "insufficient scope")
+					return
+				}
+				c.Set("token_scopes", scopes)
+			}
 		}
 
 		c.Next()
 	}
 }
 
+// RequireRole rejects requests whose token does not carry role. It must run
+// after AuthMiddleware.
+func RequireRole(role string) gin.HandlerFunc {
+	logger := infrastructure.WithRedaction(logrus.New())
+
+	return func(c *gin.Context) {
+		if current, _ := c.Get("user_role"); current != role {
+			logger.WithFields(logrus.Fields{
+				"user_id":       c.GetString("user_id"),
+				"role":          current,
+				"required_role": role,
+				"path":          c.Request.URL.Path,
+			}).Warn("Request rejected for missing role")
+			abortWithMessage(c, StatusForbidden, "insufficient role")
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePermission rejects requests whose role is not granted action on
+// resource by domain.RolePermissions. It must run after AuthMiddleware.
+func RequirePermission(action, resource string) gin.HandlerFunc {
+	logger := infrastructure.WithRedaction(logrus.New())
+
+	return func(c *gin.Context) {
+		role := c.GetString("user_role")
+		if !domain.RoleAllows(role, action, resource) {
+			logger.WithFields(logrus.Fields{
+				"user_id":  c.GetString("user_id"),
+				"role":     role,
+				"action":   action,
+				"resource": resource,
+				"path":     c.Request.URL.Path,
+			}).Warn("Request rejected for missing permission")
+			abortWithMessage(c, StatusForbidden, "insufficient permission")
+			return
+		}
+		c.Next()
+	}
+}
+
 func LoggingMiddleware() gin.HandlerFunc {
-	logger := logrus.New()
+	logger := infrastructure.WithRedaction(logrus.New())
 
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -124,7 +271,7 @@ func LoggingMiddleware() gin.HandlerFunc {
 }
 
 func ErrorRecoveryMiddleware() gin.HandlerFunc {
-	logger := logrus.New()
+	logger := infrastructure.WithRedaction(logrus.New())
 
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
 		if err, ok := recovered.(string); ok {
@@ -142,3 +289,38 @@ func ErrorRecoveryMiddleware() gin.HandlerFunc {
 		})
 	})
 }
+
+func requestScope(c *gin.Context) (string, string) {
+	action := domain.ScopeActionWrite
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead || c.Request.Method == http.MethodOptions {
+		action = domain.ScopeActionRead
+	}
+
+	path := strings.TrimPrefix(c.FullPath(), APIVersion)
+	resource, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+
+	return action, resource
+}
+
+// currentUserID returns the subject of the token AuthMiddleware validated.
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	raw, ok := c.Get("user_id")
+	if !ok {
+		return uuid.Nil, false
+	}
+	subject, ok := raw.(string)
+	if !ok {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(subject)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// isAdmin reports whether the token AuthMiddleware validated carries the
+// admin role.
+func isAdmin(c *gin.Context) bool {
+	return c.GetString("user_role") == domain.RoleAdmin
+}
@@ -0,0 +1,89 @@
+package api_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/api"
+	"github.com/edumes/golang-api-rest/internal/application"
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const authTestSecret = "auth-middleware-test-secret-0123456789"
+
+var authTestUser = domain.User{ID: uuid.MustParse("22222222-2222-2222-2222-222222222222"), Email: "ana@example.com", Role: domain.RoleUser, Active: true}
+
+func newAuthTestEngine(users api.UserService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	viper.Set("APP_JWT_SECRET", authTestSecret)
+
+	engine := gin.New()
+	engine.Use(api.ErrorHandlerMiddleware())
+	engine.GET("/me", api.AuthMiddleware(users, nil), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	return engine
+}
+
+func getWithToken(engine *gin.Engine, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func issueAuthTestToken(t *testing.T) string {
+	t.Helper()
+	token, _, err := application.NewTokenService(authTestSecret, time.Hour).IssueAccessToken(&authTestUser)
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthMiddlewareAcceptsActiveAccount(t *testing.T) {
+	users := &mocks.UserService{}
+	users.On("GetAccount", mock.Anything, authTestUser.ID).Return(&authTestUser, nil)
+	users.On("CheckSignIn", &authTestUser).Return(nil)
+
+	rec := getWithToken(newAuthTestEngine(users), issueAuthTestToken(t))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestAuthMiddlewareRejectsUnsignedToken(t *testing.T) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+		"sub":  authTestUser.ID.String(),
+		"role": domain.RoleAdmin,
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	rec := getWithToken(newAuthTestEngine(&mocks.UserService{}), token)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthMiddlewareRejectsUnknownAccount(t *testing.T) {
+	users := &mocks.UserService{}
+	users.On("GetAccount", mock.Anything, authTestUser.ID).Return(nil, domain.ErrAccountNotFound)
+
+	rec := getWithToken(newAuthTestEngine(users), issueAuthTestToken(t))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthMiddlewareFailsClosedWhenAccountLookupFails(t *testing.T) {
+	users := &mocks.UserService{}
+	users.On("GetAccount", mock.Anything, authTestUser.ID).Return(nil, errors.New("connection refused"))
+
+	rec := getWithToken(newAuthTestEngine(users), issueAuthTestToken(t))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
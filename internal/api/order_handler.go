@@ -0,0 +1,432 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type OrderHandler struct {
+	service OrderService
+	logger  *logrus.Logger
+}
+
+func NewOrderHandler(service OrderService) *OrderHandler {
+	return &OrderHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *OrderHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering order routes")
+	r.POST(OrdersEndpoint, h.CreateOrder)
+	r.GET(OrdersEndpoint, h.ListOrders)
+	r.GET(OrderByID, h.GetOrder)
+	r.PUT(OrderByID, h.UpdateOrder)
+	r.DELETE(OrderByID, h.DeleteOrder)
+	r.PUT(OrderStatus, h.UpdateOrderStatus)
+}
+
+type createOrderRequest struct {
+	WarehouseID *uuid.UUID         `json:"warehouse_id" binding:"omitempty,exists=warehouse"`
+	Notes       string             `json:"notes"`
+	CouponCode  string             `json:"coupon_code"`
+	Items       []domain.OrderItem `json:"items" binding:"required,min=1,dive"`
+}
+
+type updateOrderRequest struct {
+	Notes string             `json:"notes"`
+	Items []domain.OrderItem `json:"items" binding:"required,min=1,dive"`
+}
+
+type orderStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending paid shipped delivered cancelled"`
+}
+
+// parseOrderID reads the :id path parameter and the user the token names,
+// aborting the request when either is missing or malformed.
+func (h *OrderHandler) parseOrderID(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid order ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"order_id":  id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Order request without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, actorID, true
+}
+
+// @Summary Create order
+// @Description Place a pending order for the authenticated user. Items are priced at the current product price and taken out of stock through sale stock adjustments, from the given warehouse when there is one, in the same transaction that stores the order: when any item is out of stock nothing is placed. A coupon_code is checked like in POST /v1/coupons/redeem and redeemed in that same transaction: it takes discount off the subtotal of the items it applies to, and total is what is left.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body createOrderRequest true "Order data"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Param Idempotency-Key header string false "Key that makes retries of this request replay its response instead of creating again"
+// @Success 201 {object} domain.Order
+// @Header 201 {string} Location "URL of the created order"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "Insufficient stock, a product is archived, the coupon has no uses left or the idempotency key is in use"
+// @Failure 422 {object} map[string]interface{} "Idempotency key was used for a different request"
+// @Router /v1/orders [post]
+func (h *OrderHandler) CreateOrder(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"client_ip": c.ClientIP(),
+		}).Warn("Order creation without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": actorID,
+		"ip":      c.ClientIP(),
+	}).Info("Creating new order")
+
+	var req createOrderRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for order creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	order, err := h.service.CreateOrder(c.Request.Context(), &domain.Order{
+		UserID:      actorID,
+		WarehouseID: req.WarehouseID,
+		Notes:       req.Notes,
+		CouponCode:  req.CouponCode,
+		Items:       req.Items,
+	})
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": actorID,
+		}).Error("Failed to create order")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+		"total":    order.Total,
+	}).Info("Order created successfully")
+
+	respondCreated(c, order, OrderByID, order.ID.String())
+}
+
+// @Summary List orders
+// @Description Get a list of orders with their items, with optional filtering and pagination. Admins see every order and can filter by user; other users see their own.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query string false "Filter by the user who placed the order (admin only)"
+// @Param status query string false "Filter by status (pending, paid, shipped, delivered, cancelled)"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. created_at desc; fields: status, total, paid_at, shipped_at, delivered_at, cancelled_at, created_at, updated_at (default: created_at desc)"
+// @Success 200 {array} domain.Order
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/orders [get]
+func (h *OrderHandler) ListOrders(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": actorID,
+		"ip":      c.ClientIP(),
+	}).Info("Listing orders")
+
+	filter := domain.OrderParams{
+		Status: c.Query("status"),
+	}
+	if !isAdmin(c) {
+		filter.UserID = &actorID
+	} else if raw := c.Query("user_id"); raw != "" {
+		userID, err := uuid.Parse(raw)
+		if err != nil {
+			abortWithMessage(c, StatusBadRequest, "invalid user_id")
+			return
+		}
+		filter.UserID = &userID
+	}
+
+	var page pageQuery
+	if err := bindQuery(c, &page); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	sort, err := sortQuery(c, domain.OrderSort, "created_at desc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := page.pagination(sort)
+
+	h.logger.WithFields(logrus.Fields{
+		"filter_user_id": filter.UserID,
+		"filter_status":  filter.Status,
+		"limit":          pagination.Limit,
+		"offset":         pagination.Offset,
+		"sort":           pagination.Sort,
+	}).Debug("List orders with filters and pagination")
+
+	orders, err := h.service.ListOrders(c.Request.Context(), filter, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list orders")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"count": len(orders),
+	}).Info("Orders listed successfully")
+
+	c.JSON(StatusOK, orders)
+}
+
+// @Summary Get order by ID
+// @Description Get a specific order and its items. Users other than admins only see their own orders.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Param If-None-Match header string false "ETag of a copy the client already has; answered with 304 when it is still current"
+// @Success 200 {object} domain.Order
+// @Success 304 "Not Modified"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/orders/{id} [get]
+func (h *OrderHandler) GetOrder(c *gin.Context) {
+	id, actorID, ok := h.parseOrderID(c)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":   c.Request.Method,
+		"path":     c.Request.URL.Path,
+		"order_id": id,
+		"ip":       c.ClientIP(),
+	}).Info("Getting order by ID")
+
+	order, err := h.service.GetOrder(c.Request.Context(), id, actorID, isAdmin(c))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"order_id":  id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Order not found")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+		"status":   order.Status,
+	}).Info("Order retrieved successfully")
+
+	setETag(c, order.UpdatedAt)
+	c.JSON(StatusOK, order)
+}
+
+// @Summary Update order
+// @Description Replace the notes and items of a pending order. Items are repriced at the current product price and stock moves by the difference between the old and new items in one transaction.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Param request body updateOrderRequest true "Order data"
+// @Success 200 {object} domain.Order
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Order is no longer pending, insufficient stock, a product added is archived or a product removed is deleted"
+// @Router /v1/orders/{id} [put]
+func (h *OrderHandler) UpdateOrder(c *gin.Context) {
+	id, actorID, ok := h.parseOrderID(c)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":   c.Request.Method,
+		"path":     c.Request.URL.Path,
+		"order_id": id,
+		"ip":       c.ClientIP(),
+	}).Info("Updating order")
+
+	var req updateOrderRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"order_id":  id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid request body for order update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	order, err := h.service.UpdateOrder(c.Request.Context(), &domain.Order{
+		ID:    id,
+		Notes: req.Notes,
+		Items: req.Items,
+	}, actorID, isAdmin(c))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"order_id":  id,
+			"client_ip": c.ClientIP(),
+		}).Error("Failed to update order")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+	}).Info("Order updated successfully")
+
+	c.JSON(StatusOK, order)
+}
+
+// @Summary Delete order
+// @Description Delete an order that has not shipped and put its items back into stock. Users other than admins can only delete their pending orders.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Order has shipped or a product of the order is deleted"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/orders/{id} [delete]
+func (h *OrderHandler) DeleteOrder(c *gin.Context) {
+	id, actorID, ok := h.parseOrderID(c)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":   c.Request.Method,
+		"path":     c.Request.URL.Path,
+		"order_id": id,
+		"ip":       c.ClientIP(),
+	}).Info("Deleting order")
+
+	if err := h.service.DeleteOrder(c.Request.Context(), id, actorID, isAdmin(c)); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"order_id":  id,
+			"client_ip": c.ClientIP(),
+		}).Error("Failed to delete order")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"order_id": id,
+	}).Info("Order deleted successfully")
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary Update order status
+// @Description Move an order along its workflow: pending to paid or cancelled, paid to shipped or cancelled, shipped to delivered. Cancelling puts the items back into stock in the same transaction. Users other than admins can only cancel their own pending orders.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Param request body orderStatusRequest true "New status"
+// @Success 200 {object} domain.Order
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Only admins can set this status"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "The order cannot move to this status or a product of the order is deleted"
+// @Router /v1/orders/{id}/status [put]
+func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
+	id, actorID, ok := h.parseOrderID(c)
+	if !ok {
+		return
+	}
+
+	var req orderStatusRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"order_id":  id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid request body for order status update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":   c.Request.Method,
+		"path":     c.Request.URL.Path,
+		"order_id": id,
+		"status":   req.Status,
+		"ip":       c.ClientIP(),
+	}).Info("Updating order status")
+
+	order, err := h.service.UpdateOrderStatus(c.Request.Context(), id, req.Status, actorID, isAdmin(c))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"order_id":  id,
+			"status":    req.Status,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to update order status")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+		"status":   order.Status,
+	}).Info("Order status updated successfully")
+
+	c.JSON(StatusOK, order)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type OutboundHandler struct {
+	client *infrastructure.HTTPClient
+	logger *logrus.Logger
+}
+
+func NewOutboundHandler(client *infrastructure.HTTPClient) *OutboundHandler {
+	return &OutboundHandler{
+		client: client,
+		logger: infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *OutboundHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering outbound HTTP routes")
+	r.GET(AdminOutboundMetrics, RequireRole(domain.RoleAdmin), h.OutboundMetrics)
+}
+
+// @Summary Outbound HTTP metrics
+// @Description Calls made to third-party services since the server started, per host and ordered by host (admin only): requests sent, retries, failed attempts, requests rejected while the host's circuit was open, whether it is open now and when the last failure happened.
+// @Tags outbound
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} infrastructure.HTTPHostStats
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /v1/admin/outbound/metrics [get]
+func (h *OutboundHandler) OutboundMetrics(c *gin.Context) {
+	c.JSON(StatusOK, h.client.Stats())
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// pageMeta tells a client where a page sits in the whole list: Total
+// records match the filters, split into Pages pages of Limit.
+type pageMeta struct {
+	Total  int64 `json:"total"`
+	Limit  int   `json:"limit"`
+	Offset int   `json:"offset"`
+	Pages  int64 `json:"pages"`
+}
+
+func newPageMeta(total int64, pagination domain.Pagination) pageMeta {
+	meta := pageMeta{Total: total, Limit: pagination.Limit, Offset: pagination.Offset}
+	if pagination.Limit > 0 {
+		meta.Pages = (total + int64(pagination.Limit) - 1) / int64(pagination.Limit)
+	}
+	return meta
+}
+
+// pageResponse is one page of a paginated list endpoint.
+type pageResponse[T any] struct {
+	Data []T      `json:"data"`
+	Meta pageMeta `json:"meta"`
+}
+
+// respondPage answers a list request with data, never null, and the
+// pagination metadata for total matching records.
+func respondPage[T any](c *gin.Context, data []T, total int64, pagination domain.Pagination) {
+	if data == nil {
+		data = []T{}
+	}
+	c.JSON(StatusOK, pageResponse[T]{Data: data, Meta: newPageMeta(total, pagination)})
+}
@@ -0,0 +1,370 @@
+package api
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// policyExemptRoutes stay reachable while a user has policies to accept, so
+// they can read and accept them, and still see or take away their own data.
+var policyExemptRoutes = []string{
+	APIVersion + PoliciesEndpoint,
+	APIVersion + UserMe,
+	APIVersion + AdminPolicies,
+}
+
+type PolicyHandler struct {
+	service PolicyService
+	logger  *logrus.Logger
+}
+
+func NewPolicyHandler(service PolicyService) *PolicyHandler {
+	return &PolicyHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+// RegisterPublicRoutes registers the routes that serve the current documents,
+// which have to be readable before signing in.
+func (h *PolicyHandler) RegisterPublicRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering public policy routes")
+	r.GET(PoliciesEndpoint, h.ListCurrentPolicies)
+	r.GET(PolicyByID, h.GetPolicy)
+}
+
+func (h *PolicyHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering policy routes")
+	admin := RequireRole(domain.RoleAdmin)
+	r.POST(PolicyAccept, h.AcceptPolicy)
+	r.GET(UserPolicies, h.GetPolicyStatus)
+	r.POST(AdminPolicies, admin, h.PublishPolicy)
+	r.GET(AdminPolicies, admin, h.ListPolicies)
+	r.GET(AdminPolicyAcceptances, admin, h.ListPolicyAcceptances)
+}
+
+// RequirePolicyAcceptance answers 426 while policy enforcement is on and the
+// caller has not accepted the latest version of every policy. It must run
+// after AuthMiddleware.
+func (h *PolicyHandler) RequirePolicyAcceptance(c *gin.Context) {
+	for _, prefix := range policyExemptRoutes {
+		if strings.HasPrefix(c.FullPath(), prefix) {
+			return
+		}
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	pending, err := h.service.CheckPolicyAcceptance(c.Request.Context(), userID)
+	if errors.Is(err, domain.ErrPolicyAcceptanceRequired) {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"pending": len(pending),
+			"path":    c.Request.URL.Path,
+		}).Warn("Request blocked until policies are accepted")
+		abortWithDetails(c, StatusUpgradeRequired, err, gin.H{
+			"pending": pending,
+			"accept":  APIVersion + PolicyAccept,
+		})
+		return
+	}
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to check policy acceptance")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+}
+
+type publishPolicyRequest struct {
+	Kind    domain.PolicyKind `json:"kind" binding:"required,enum"`
+	Version string            `json:"version" binding:"required"`
+	Title   string            `json:"title" binding:"required"`
+	Content string            `json:"content" binding:"required"`
+}
+
+// @Summary List current policies
+// @Description List the latest version of each policy document (terms of service, privacy policy). No authentication required.
+// @Tags policies
+// @Accept json
+// @Produce json
+// @Success 200 {array} domain.PolicyDocument
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/policies [get]
+func (h *PolicyHandler) ListCurrentPolicies(c *gin.Context) {
+	documents, err := h.service.CurrentPolicyDocuments(c.Request.Context())
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list current policies")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, documents)
+}
+
+// @Summary Get policy
+// @Description Get any version of a policy document by ID. No authentication required.
+// @Tags policies
+// @Accept json
+// @Produce json
+// @Param id path string true "Policy document ID"
+// @Success 200 {object} domain.PolicyDocument
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/policies/{id} [get]
+func (h *PolicyHandler) GetPolicy(c *gin.Context) {
+	id, ok := h.documentID(c)
+	if !ok {
+		return
+	}
+
+	document, err := h.service.GetPolicyDocument(c.Request.Context(), id)
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, document)
+}
+
+// @Summary Accept policy
+// @Description Record that the authenticated user accepts a policy document, with the time and client IP. Only the latest version of a kind can be accepted; accepting it again returns the original record.
+// @Tags policies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Policy document ID"
+// @Success 200 {object} domain.PolicyAcceptance
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "A newer version has been published"
+// @Router /v1/policies/{id}/accept [post]
+func (h *PolicyHandler) AcceptPolicy(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+	id, ok := h.documentID(c)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":      c.Request.Method,
+		"path":        c.Request.URL.Path,
+		"user_id":     userID,
+		"document_id": id,
+		"ip":          c.ClientIP(),
+	}).Info("Accepting policy")
+
+	acceptance, err := h.service.AcceptPolicyDocument(c.Request.Context(), id, userID, c.ClientIP())
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"document_id": id,
+			"user_id":     userID,
+		}).Warn("Failed to accept policy")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, acceptance)
+}
+
+// @Summary My policy status
+// @Description List the policy versions the authenticated user accepted, newest first, and the latest versions still waiting for acceptance
+// @Tags policies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.PolicyStatus
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/policies [get]
+func (h *PolicyHandler) GetPolicyStatus(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	status, err := h.service.GetPolicyStatus(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to get policy status")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, status)
+}
+
+// @Summary Publish policy
+// @Description Publish a new version of a policy document (admin only). It becomes the version users have to accept; versions are unique per kind and documents cannot be edited afterwards.
+// @Tags policies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body publishPolicyRequest true "Policy document"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.PolicyDocument
+// @Header 201 {string} Location "URL of the created policy document"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Version already published"
+// @Router /v1/admin/policies [post]
+func (h *PolicyHandler) PublishPolicy(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req publishPolicyRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for policy publication")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": userID,
+		"kind":    req.Kind,
+		"version": req.Version,
+		"ip":      c.ClientIP(),
+	}).Info("Publishing policy")
+
+	document, err := h.service.PublishPolicyDocument(c.Request.Context(), &domain.PolicyDocument{
+		Kind:    req.Kind,
+		Version: req.Version,
+		Title:   req.Title,
+		Content: req.Content,
+	}, userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"kind":    req.Kind,
+			"version": req.Version,
+		}).Warn("Failed to publish policy")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	respondCreated(c, document, PolicyByID, document.ID.String())
+}
+
+type listPoliciesQuery struct {
+	pageQuery
+	Kind domain.PolicyKind `form:"kind" binding:"omitempty,enum"`
+}
+
+// @Summary List policy versions
+// @Description List every published policy version, newest first (admin only)
+// @Tags policies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param kind query string false "Filter by kind (terms_of_service, privacy_policy)"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.PolicyDocument
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /v1/admin/policies [get]
+func (h *PolicyHandler) ListPolicies(c *gin.Context) {
+	var query listPoliciesQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	documents, err := h.service.ListPolicyDocuments(c.Request.Context(), query.Kind, query.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Warn("Failed to list policies")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, documents)
+}
+
+// @Summary List policy acceptances
+// @Description List who accepted a policy version and when, newest first (admin only)
+// @Tags policies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Policy document ID"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.PolicyAcceptance
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/policies/{id}/acceptances [get]
+func (h *PolicyHandler) ListPolicyAcceptances(c *gin.Context) {
+	id, ok := h.documentID(c)
+	if !ok {
+		return
+	}
+
+	var page pageQuery
+	if err := bindQuery(c, &page); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	acceptances, err := h.service.ListPolicyAcceptances(c.Request.Context(), id, page.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"document_id": id,
+		}).Warn("Failed to list policy acceptances")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, acceptances)
+}
+
+func (h *PolicyHandler) documentID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid policy document ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
@@ -1,9 +1,10 @@
 package api
 
 import (
-	"strconv"
+	"context"
+
+	"strings"
 
-	"github.com/edumes/golang-api-rest/internal/application"
 	"github.com/edumes/golang-api-rest/internal/domain"
 	"github.com/edumes/golang-api-rest/internal/infrastructure"
 	"github.com/gin-gonic/gin"
@@ -12,11 +13,11 @@ import (
 )
 
 type ProductHandler struct {
-	service *application.ProductService
+	service ProductService
 	logger  *logrus.Logger
 }
 
-func NewProductHandler(service *application.ProductService) *ProductHandler {
+func NewProductHandler(service ProductService) *ProductHandler {
 	return &ProductHandler{
 		service: service,
 		logger:  infrastructure.GetColoredLogger(),
@@ -25,38 +26,58 @@ func NewProductHandler(service *application.ProductService) *ProductHandler {
 
 func (h *ProductHandler) RegisterRoutes(r *gin.RouterGroup) {
 	h.logger.Info("Registering product routes")
-	r.POST(ProductsEndpoint, h.CreateProduct)
+	write := RequirePermission(domain.ScopeActionWrite, "products")
+	r.POST(ProductsEndpoint, write, h.CreateProduct)
 	r.GET(ProductsEndpoint, h.ListProducts)
 	r.GET(ProductByID, h.GetProduct)
-	r.PUT(ProductByID, h.UpdateProduct)
-	r.DELETE(ProductByID, h.DeleteProduct)
-	r.PATCH(ProductStockEndpoint, h.UpdateProductStock)
+	r.PUT(ProductByID, write, h.UpdateProduct)
+	r.PATCH(ProductByID, write, h.PatchProduct)
+	r.DELETE(ProductByID, write, h.DeleteProduct)
 	r.GET(ProductBySKUEndpoint, h.GetProductBySKU)
+	r.GET(ProductsSuggest, h.SuggestProducts)
+	r.GET(ProductsSearch, h.SearchProducts)
+	r.GET(ProductsLowStock, write, h.ListLowStockProducts)
+	r.GET(ProductRelated, h.RelatedProducts)
+	r.GET(ProductByBarcode, h.GetProductByBarcode)
+	r.POST(ProductArchive, write, h.ArchiveProduct)
+	r.POST(ProductUnarchive, write, h.UnarchiveProduct)
+	r.POST(ProductsImport, write, h.ImportProducts)
+	r.GET(ProductImportByID, h.GetProductImport)
 }
 
 type createProductRequest struct {
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	Stock       int     `json:"stock" binding:"gte=0"`
-	Category    string  `json:"category"`
-	SKU         string  `json:"sku" binding:"required"`
+	Name        string     `json:"name" binding:"required"`
+	Description string     `json:"description"`
+	Price       float64    `json:"price" binding:"required,gt=0"`
+	Stock       int        `json:"stock" binding:"gte=0"`
+	Category    string     `json:"category"`
+	CategoryID  *uuid.UUID `json:"category_id" binding:"omitempty,exists=category"`
+	SKU         string     `json:"sku"`
+	Barcode     string     `json:"barcode"`
 }
 
-type updateProductStockRequest struct {
-	Quantity int `json:"quantity" binding:"required"`
+type productListResponse struct {
+	Data   []domain.Product      `json:"data"`
+	Meta   pageMeta              `json:"meta"`
+	Facets *domain.ProductFacets `json:"facets,omitempty"`
 }
 
 // @Summary Create product
-// @Description Create a new product
+// @Description Create a new product (admin only). The category is given by category_id or by the name of an existing category; category_id wins when both are set. When sku is omitted one is generated from the configured pattern (PRODUCT_SKU_PATTERN, default {CAT}-{SEQ}).
 // @Tags products
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body createProductRequest true "Product data"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Param Idempotency-Key header string false "Key that makes retries of this request replay its response instead of creating again"
 // @Success 201 {object} domain.Product
+// @Header 201 {string} Location "URL of the created product"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "A request with the same Idempotency-Key is still running"
+// @Failure 422 {object} map[string]interface{} "Idempotency-Key already used for a different request"
 // @Router /v1/products [post]
 func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	h.logger.WithFields(logrus.Fields{
@@ -66,30 +87,32 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	}).Info("Creating new product")
 
 	var req createProductRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 			"ip":    c.ClientIP(),
 		}).Warn("Invalid request body for product creation")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
 	h.logger.WithFields(logrus.Fields{
-		"name":     req.Name,
-		"sku":      req.SKU,
-		"price":    req.Price,
-		"stock":    req.Stock,
-		"category": req.Category,
+		"name":        req.Name,
+		"sku":         req.SKU,
+		"barcode":     req.Barcode,
+		"price":       req.Price,
+		"stock":       req.Stock,
+		"category":    req.Category,
+		"category_id": req.CategoryID,
 	}).Debug("Processing product creation request")
 
-	product, err := h.service.CreateProduct(c.Request.Context(), req.Name, req.Description, req.Category, req.SKU, req.Price, req.Stock)
+	product, err := h.service.CreateProduct(c.Request.Context(), req.Name, req.Description, req.Category, req.CategoryID, req.SKU, req.Barcode, req.Price, req.Stock)
 	if err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 			"sku":   req.SKU,
 		}).Error("Failed to create product")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
@@ -98,26 +121,62 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 		"sku":        product.SKU,
 	}).Info("Product created successfully")
 
-	c.JSON(StatusCreated, product)
+	respondCreated(c, product, ProductByID, product.ID.String())
+}
+
+// listProductsQuery is the query string of ListProducts.
+type listProductsQuery struct {
+	pageQuery
+	formatQuery
+	Name            string     `form:"name"`
+	Category        string     `form:"category"`
+	CategoryID      *uuid.UUID `form:"category_id"`
+	SKU             string     `form:"sku"`
+	PriceFrom       *float64   `form:"price_from" binding:"omitempty,min=0"`
+	PriceTo         *float64   `form:"price_to" binding:"omitempty,min=0"`
+	StockFrom       *int       `form:"stock_from"`
+	StockTo         *int       `form:"stock_to"`
+	IncludeArchived bool       `form:"include_archived"`
+	Facets          string     `form:"facets"`
+}
+
+func (q listProductsQuery) params() domain.ProductParams {
+	return domain.ProductParams{
+		Name:            q.Name,
+		Category:        q.Category,
+		CategoryID:      q.CategoryID,
+		SKU:             q.SKU,
+		PriceFrom:       q.PriceFrom,
+		PriceTo:         q.PriceTo,
+		StockFrom:       q.StockFrom,
+		StockTo:         q.StockTo,
+		IncludeArchived: q.IncludeArchived,
+	}
 }
 
 // @Summary List products
-// @Description Get a list of products with optional filtering and pagination
+// @Description Get a page of products with optional filtering and pagination. When facets is set the response also carries "facets": {...} with counts per category, price range and stock availability for the same filters.
 // @Tags products
 // @Accept json
-// @Produce json
+// @Produce json,application/x-ndjson,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Security BearerAuth
 // @Param name query string false "Filter by name"
 // @Param category query string false "Filter by category"
+// @Param category_id query string false "Filter by category ID, including its subcategories"
 // @Param sku query string false "Filter by SKU"
 // @Param price_from query number false "Minimum price filter"
 // @Param price_to query number false "Maximum price filter"
 // @Param stock_from query integer false "Minimum stock filter"
 // @Param stock_to query integer false "Maximum stock filter"
-// @Param limit query int false "Number of items per page (default: 20)"
+// @Param format query string false "json (default) for one page; ndjson, csv or xlsx to download every matching row as newline-delimited JSON, CSV or an Excel sheet in id order, ignoring limit, offset and sort"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
 // @Param offset query int false "Number of items to skip (default: 0)"
-// @Param sort query string false "Sort order (default: created_at desc)"
-// @Success 200 {array} domain.Product
+// @Param sort query string false "Field and direction, e.g. created_at desc; fields: name, price, cost_price, stock, category, sku, created_at, updated_at (default: created_at desc)"
+// @Param include_archived query bool false "Also return archived products"
+// @Param facets query string false "Comma-separated facets to aggregate (category, price, stock)"
+// @Param saved_filter query string false "Apply the query parameters and sort of a saved filter; parameters given here take precedence"
+// @Success 200 {object} pageResponse[domain.Product]
+// @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /v1/products [get]
@@ -128,54 +187,36 @@ func (h *ProductHandler) ListProducts(c *gin.Context) {
 		"ip":     c.ClientIP(),
 	}).Info("Listing products")
 
-	var priceFrom, priceTo *float64
-	if priceFromStr := c.Query("price_from"); priceFromStr != "" {
-		if val, err := strconv.ParseFloat(priceFromStr, 64); err == nil {
-			priceFrom = &val
-		}
-	}
-	if priceToStr := c.Query("price_to"); priceToStr != "" {
-		if val, err := strconv.ParseFloat(priceToStr, 64); err == nil {
-			priceTo = &val
-		}
-	}
-
-	var stockFrom, stockTo *int
-	if stockFromStr := c.Query("stock_from"); stockFromStr != "" {
-		if val, err := strconv.Atoi(stockFromStr); err == nil {
-			stockFrom = &val
-		}
-	}
-	if stockToStr := c.Query("stock_to"); stockToStr != "" {
-		if val, err := strconv.Atoi(stockToStr); err == nil {
-			stockTo = &val
-		}
+	var query listProductsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
 	}
-
-	filter := domain.ProductParams{
-		Name:      c.Query("name"),
-		Category:  c.Query("category"),
-		SKU:       c.Query("sku"),
-		PriceFrom: priceFrom,
-		PriceTo:   priceTo,
-		StockFrom: stockFrom,
-		StockTo:   stockTo,
+	filter := query.params()
+	if query.streaming() {
+		streamList(c, h.logger, query.Format, listStream[domain.Product]{
+			name:    "products",
+			columns: domain.ProductExportColumns,
+			list: func(ctx context.Context, pagination domain.Pagination) ([]domain.Product, error) {
+				return h.service.ListProducts(ctx, filter, pagination)
+			},
+			id: func(p domain.Product) uuid.UUID { return p.ID },
+		})
+		return
 	}
-
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	pagination := domain.Pagination{
-		Limit:  limit,
-		Offset: offset,
-		Sort:   c.DefaultQuery("sort", "created_at desc"),
+	sort, err := sortQuery(c, domain.ProductSort, "created_at desc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
 	}
+	pagination := query.pagination(sort)
 
 	h.logger.WithFields(logrus.Fields{
 		"filter_name":     filter.Name,
 		"filter_category": filter.Category,
 		"filter_sku":      filter.SKU,
-		"limit":           limit,
-		"offset":          offset,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
 		"sort":            pagination.Sort,
 	}).Debug("🔍 List products with filters and pagination")
 
@@ -184,15 +225,50 @@ func (h *ProductHandler) ListProducts(c *gin.Context) {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Error("Failed to list products")
-		c.JSON(StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	total, err := h.service.CountProducts(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count products")
+		abortWithError(c, StatusInternalServerError, err)
 		return
 	}
 
 	h.logger.WithFields(logrus.Fields{
 		"count": len(products),
+		"total": total,
 	}).Info("Products listed successfully")
 
-	c.JSON(StatusOK, products)
+	if query.Facets == "" {
+		respondPage(c, products, total, pagination)
+		return
+	}
+
+	var facetNames []string
+	for _, name := range strings.Split(query.Facets, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			facetNames = append(facetNames, name)
+		}
+	}
+
+	facets, err := h.service.GetProductFacets(c.Request.Context(), filter, facetNames)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"facets": facetNames,
+		}).Error("Failed to compute product facets")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	if products == nil {
+		products = []domain.Product{}
+	}
+	c.JSON(StatusOK, productListResponse{Data: products, Meta: newPageMeta(total, pagination), Facets: facets})
 }
 
 // @Summary Get product by ID
@@ -202,7 +278,9 @@ func (h *ProductHandler) ListProducts(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Product ID"
+// @Param If-None-Match header string false "ETag of the copy the client already has"
 // @Success 200 {object} domain.Product
+// @Success 304 "Not Modified"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 404 {object} map[string]interface{} "Not Found"
@@ -215,7 +293,7 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid product ID format")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -233,7 +311,7 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 			"product_id": id,
 			"client_ip":  c.ClientIP(),
 		}).Warn("Product not found")
-		c.JSON(StatusNotFound, gin.H{"error": err.Error()})
+		abortWithError(c, StatusNotFound, err)
 		return
 	}
 
@@ -242,9 +320,59 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 		"sku":        product.SKU,
 	}).Info("Product retrieved successfully")
 
+	setETag(c, product.UpdatedAt, product.Availability)
 	c.JSON(StatusOK, product)
 }
 
+// @Summary Related products
+// @Description Recommend products to show next to this one: unarchived products in the same category or priced within 25% of it, same category first, then in stock, then closest in price. Recommendations may be reused for a few minutes.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param limit query int false "Number of products (default: 8, at most 24)"
+// @Success 200 {array} domain.Product
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/products/{id}/related [get]
+func (h *ProductHandler) RelatedProducts(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid product ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	var query relatedProductsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	products, err := h.service.RelatedProducts(c.Request.Context(), id, query.Limit)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Failed to get related products")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	c.JSON(StatusOK, products)
+}
+
+type relatedProductsQuery struct {
+	Limit int `form:"limit,default=8" binding:"min=1,max=24"`
+}
+
 // @Summary Get product by SKU
 // @Description Get a specific product by its SKU
 // @Tags products
@@ -252,7 +380,9 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param sku path string true "Product SKU"
+// @Param If-None-Match header string false "ETag of the copy the client already has"
 // @Success 200 {object} domain.Product
+// @Success 304 "Not Modified"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 404 {object} map[string]interface{} "Not Found"
@@ -263,7 +393,7 @@ func (h *ProductHandler) GetProductBySKU(c *gin.Context) {
 		h.logger.WithFields(logrus.Fields{
 			"client_ip": c.ClientIP(),
 		}).Warn("Empty SKU parameter")
-		c.JSON(StatusBadRequest, gin.H{"error": "sku parameter is required"})
+		abortWithMessage(c, StatusBadRequest, "sku parameter is required")
 		return
 	}
 
@@ -281,7 +411,7 @@ func (h *ProductHandler) GetProductBySKU(c *gin.Context) {
 			"sku":       sku,
 			"client_ip": c.ClientIP(),
 		}).Warn("Product not found by SKU")
-		c.JSON(StatusNotFound, gin.H{"error": err.Error()})
+		abortWithError(c, StatusNotFound, err)
 		return
 	}
 
@@ -290,20 +420,209 @@ func (h *ProductHandler) GetProductBySKU(c *gin.Context) {
 		"sku":        product.SKU,
 	}).Info("Product retrieved successfully by SKU")
 
+	setETag(c, product.UpdatedAt, product.Availability)
+	c.JSON(StatusOK, product)
+}
+
+// @Summary List low stock products
+// @Description Unarchived products whose stock is at or below their reorder_level, or PRODUCT_LOW_STOCK_THRESHOLD when they have none, for whoever restocks them (admin only).
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. stock asc; fields: name, price, cost_price, stock, category, sku, created_at, updated_at (default: stock asc)"
+// @Success 200 {array} domain.Product
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/products/low-stock [get]
+func (h *ProductHandler) ListLowStockProducts(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Listing low stock products")
+
+	var query pageQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	sort, err := sortQuery(c, domain.ProductSort, "stock asc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := query.pagination(sort)
+
+	products, err := h.service.ListLowStockProducts(c.Request.Context(), pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list low stock products")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"count": len(products),
+	}).Info("Low stock products listed successfully")
+
+	c.JSON(StatusOK, products)
+}
+
+// @Summary Suggest products
+// @Description Typeahead for search boxes: unarchived products whose name contains q or whose SKU starts with it, as id and label, best matches first. Answers may be cached for up to 30 seconds.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Text typed so far"
+// @Param limit query int false "Number of suggestions (default: 10, at most 25)"
+// @Success 200 {array} domain.Suggestion
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/products/suggest [get]
+func (h *ProductHandler) SuggestProducts(c *gin.Context) {
+	var query suggestQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	suggestions, err := h.service.SuggestProducts(c.Request.Context(), query.Q, query.Limit)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"query": query.Q,
+		}).Error("Failed to suggest products")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Cache-Control", suggestionCacheControl)
+	c.JSON(StatusOK, suggestions)
+}
+
+// @Summary Search products
+// @Description Full-text search over the name, description and SKU of unarchived products, best ranked first. q is written like a web search: words must all match, "quoted phrases" match in order, or between words matches either and -word excludes it. Words match whole, case-insensitively and without stemming. Each hit carries its rank, from 0 to 1, and the name and a snippet of the description, HTML-escaped, with the matched words in <mark> tags.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search text (at most 200 characters)"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {object} pageResponse[domain.ProductSearchHit]
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/products/search [get]
+func (h *ProductHandler) SearchProducts(c *gin.Context) {
+	var query productSearchQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := query.pagination("")
+
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"query":  query.Q,
+		"ip":     c.ClientIP(),
+	}).Info("Searching products")
+
+	hits, err := h.service.SearchProducts(c.Request.Context(), query.Q, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"query": query.Q,
+		}).Error("Failed to search products")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+	total, err := h.service.CountProductSearch(c.Request.Context(), query.Q)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"query": query.Q,
+		}).Error("Failed to count product search")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"query": query.Q,
+		"count": len(hits),
+		"total": total,
+	}).Info("Products searched successfully")
+
+	respondPage(c, hits, total, pagination)
+}
+
+// @Summary Get product by barcode
+// @Description Get a specific product by its EAN-8, UPC-A or EAN-13 barcode
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code path string true "Product barcode"
+// @Param If-None-Match header string false "ETag of the copy the client already has"
+// @Success 200 {object} domain.Product
+// @Success 304 "Not Modified"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/products/barcode/{code} [get]
+func (h *ProductHandler) GetProductByBarcode(c *gin.Context) {
+	code := c.Param("code")
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"barcode": code,
+		"ip":      c.ClientIP(),
+	}).Info("Getting product by barcode")
+
+	product, err := h.service.GetProductByBarcode(c.Request.Context(), code)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"barcode":   code,
+			"client_ip": c.ClientIP(),
+		}).Warn("Product not found by barcode")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"product_id": product.ID,
+		"barcode":    code,
+	}).Info("Product retrieved successfully by barcode")
+
+	setETag(c, product.UpdatedAt, product.Availability)
 	c.JSON(StatusOK, product)
 }
 
 // @Summary Update product
-// @Description Update an existing product
+// @Description Update an existing product (admin only). Stock is left untouched; change it through stock adjustments. The category is resolved as on create.
 // @Tags products
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Product ID"
 // @Param product body domain.Product true "Product data"
+// @Param If-Match header string false "Version the product must still be at, as an entity tag"
 // @Success 200 {object} domain.Product
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Changed since the given version"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /v1/products/{id} [put]
 func (h *ProductHandler) UpdateProduct(c *gin.Context) {
@@ -314,7 +633,7 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid product ID format for update")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -326,24 +645,33 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	}).Info("Updating product")
 
 	var product domain.Product
-	if err := c.ShouldBindJSON(&product); err != nil {
+	if err := bindJSON(c, &product); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
 			"product_id": id,
 			"client_ip":  c.ClientIP(),
 		}).Warn("Invalid request body for product update")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
 	product.ID = id
+	if product.Version, err = ifMatchVersion(c, product.Version); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Invalid If-Match for product update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
 	if err := h.service.UpdateProduct(c.Request.Context(), &product); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
 			"product_id": id,
 			"client_ip":  c.ClientIP(),
 		}).Error("Failed to update product")
-		c.JSON(StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, StatusInternalServerError, err)
 		return
 	}
 
@@ -355,8 +683,94 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	c.JSON(StatusOK, product)
 }
 
+// @Summary Patch product
+// @Description Change only the fields named in a JSON Merge Patch (RFC 7396) of a product (admin only); every other field keeps its value and null clears a field, such as the barcode. Stock, cost price and the archived state cannot be patched.
+// @Tags products
+// @Accept json,application/merge-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param patch body object true "JSON Merge Patch; fields: name, description, category, category_id, sku, barcode, price, reorder_level"
+// @Param If-Match header string false "Version the product must still be at, as an entity tag"
+// @Success 200 {object} domain.Product
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Changed since the given version"
+// @Failure 415 {object} map[string]interface{} "Unsupported Media Type"
+// @Router /v1/products/{id} [patch]
+func (h *ProductHandler) PatchProduct(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid product ID format for patch")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"product_id": id,
+		"ip":         c.ClientIP(),
+	}).Info("Patching product")
+
+	current, err := h.service.GetProductByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Product not found for patch")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	product, fields, err := bindMergePatch(c, domain.ProductPatch, current)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Invalid merge patch for product")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if product.Version, err = ifMatchVersion(c, current.Version); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Invalid If-Match for product patch")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.PatchProduct(c.Request.Context(), product, fields); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Error("Failed to patch product")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"product_id": id,
+		"fields":     fields,
+	}).Info("Product patched successfully")
+
+	c.JSON(StatusOK, product)
+}
+
 // @Summary Delete product
-// @Description Delete a product by ID
+// @Description Delete a product by ID (admin only)
 // @Tags products
 // @Accept json
 // @Produce json
@@ -365,6 +779,7 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /v1/products/{id} [delete]
 func (h *ProductHandler) DeleteProduct(c *gin.Context) {
@@ -375,7 +790,7 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid product ID format for deletion")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -392,7 +807,7 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 			"product_id": id,
 			"client_ip":  c.ClientIP(),
 		}).Error("Failed to delete product")
-		c.JSON(StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, StatusInternalServerError, err)
 		return
 	}
 
@@ -403,27 +818,49 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 	c.JSON(StatusNoContent, nil)
 }
 
-// @Summary Update product stock
-// @Description Update the stock quantity of a product
+// @Summary Archive product
+// @Description Archive a product (admin only). Archived products stay readable by ID but are hidden from listings and reject stock adjustments, except order items returned to stock.
 // @Tags products
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Product ID"
-// @Param request body updateProductStockRequest true "Stock update data"
-// @Success 200 "OK"
+// @Success 200 {object} domain.Product
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Router /v1/products/{id}/stock [patch]
-func (h *ProductHandler) UpdateProductStock(c *gin.Context) {
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/products/{id}/archive [post]
+func (h *ProductHandler) ArchiveProduct(c *gin.Context) {
+	h.changeArchived(c, true)
+}
+
+// @Summary Unarchive product
+// @Description Restore an archived product to listings and stock operations (admin only)
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Success 200 {object} domain.Product
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/products/{id}/unarchive [post]
+func (h *ProductHandler) UnarchiveProduct(c *gin.Context) {
+	h.changeArchived(c, false)
+}
+
+func (h *ProductHandler) changeArchived(c *gin.Context, archive bool) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error":     err.Error(),
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
-		}).Warn("Invalid product ID format for stock update")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		}).Warn("Invalid product ID format for archive change")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -431,35 +868,30 @@ func (h *ProductHandler) UpdateProductStock(c *gin.Context) {
 		"method":     c.Request.Method,
 		"path":       c.Request.URL.Path,
 		"product_id": id,
+		"archive":    archive,
 		"ip":         c.ClientIP(),
-	}).Info("Updating product stock")
+	}).Info("Changing product archived state")
 
-	var req updateProductStockRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"error":      err.Error(),
-			"product_id": id,
-			"client_ip":  c.ClientIP(),
-		}).Warn("Invalid request body for stock update")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
-		return
+	var product *domain.Product
+	if archive {
+		product, err = h.service.ArchiveProduct(c.Request.Context(), id)
+	} else {
+		product, err = h.service.UnarchiveProduct(c.Request.Context(), id)
 	}
-
-	if err := h.service.UpdateProductStock(c.Request.Context(), id, req.Quantity); err != nil {
+	if err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
 			"product_id": id,
-			"quantity":   req.Quantity,
 			"client_ip":  c.ClientIP(),
-		}).Error("Failed to update product stock")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		}).Warn("Failed to change product archived state")
+		abortWithError(c, StatusNotFound, err)
 		return
 	}
 
 	h.logger.WithFields(logrus.Fields{
-		"product_id": id,
-		"quantity":   req.Quantity,
-	}).Info("Product stock updated successfully")
+		"product_id":  product.ID,
+		"archived_at": product.ArchivedAt,
+	}).Info("Product archived state changed successfully")
 
-	c.JSON(StatusOK, gin.H{"message": "Product stock updated successfully"})
+	c.JSON(StatusOK, product)
 }
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// productImportFileField is the multipart field that carries the CSV file.
+const productImportFileField = "file"
+
+type importProductsQuery struct {
+	Async bool `form:"async"`
+}
+
+// @Summary Import products from CSV
+// @Description Upsert products by SKU from a CSV file sent in the multipart field "file" (admin only). The header must name sku, name and price; description, category and stock are optional, and products already stored keep their values of the optional columns the file lacks. Stock is reached through import stock adjustments made by the authenticated user, so archived products and stock below what warehouses hold fail the batch. The file is read one row at a time and stored in batches of 500; rows that do not parse, fail validation or repeat an SKU are listed in errors with their line. With async=true the file, up to 32 MiB, is imported in the background instead: the answer is 202 with an import whose progress is polled at /v1/product-imports/{id}.
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV file with a header row"
+// @Param async query bool false "Import in the background and answer right away"
+// @Success 200 {object} domain.ImportResult
+// @Success 202 {object} domain.ProductImport
+// @Header 202 {string} Location "URL of the import to poll"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 413 {object} map[string]interface{} "File too large for a background import"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/products/import [post]
+func (h *ProductHandler) ImportProducts(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var query importProductsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": userID,
+		"async":   query.Async,
+		"ip":      c.ClientIP(),
+	}).Info("Importing products")
+
+	file, err := multipartFile(c, productImportFileField)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid product import upload")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	defer file.Close()
+
+	if query.Async {
+		h.startProductImport(c, file, userID)
+		return
+	}
+
+	result, err := h.service.ImportProductCSV(c.Request.Context(), file, domain.ProductImportBatchSize, userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Warn("Failed to import products")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"total":    result.Total,
+		"imported": result.Imported,
+		"failed":   result.Failed,
+	}).Info("Products imported successfully")
+
+	c.JSON(StatusOK, result)
+}
+
+func (h *ProductHandler) startProductImport(c *gin.Context, file io.Reader, userID uuid.UUID) {
+	content, err := io.ReadAll(io.LimitReader(file, domain.ProductImportMaxBytes+1))
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	if len(content) > domain.ProductImportMaxBytes {
+		abortWithMessage(c, StatusRequestEntityTooLarge, fmt.Sprintf("a background import takes files of up to %d bytes", domain.ProductImportMaxBytes))
+		return
+	}
+
+	productImport, err := h.service.StartProductImport(c.Request.Context(), content, userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Warn("Failed to start product import")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"import_id": productImport.ID,
+		"size":      productImport.Size,
+	}).Info("Product import queued")
+
+	c.Header("Location", resourcePath(ProductImportByID, productImport.ID.String()))
+	c.JSON(StatusAccepted, productImport)
+}
+
+// @Summary Get product import
+// @Description Get the progress of a background product import started by the authenticated user: the percentage of the file read, the rows read, imported and failed so far, and up to 1000 row errors.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Import ID"
+// @Success 200 {object} domain.ProductImport
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/product-imports/{id} [get]
+func (h *ProductHandler) GetProductImport(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid product import ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	productImport, err := h.service.GetProductImport(c.Request.Context(), id)
+	if err == nil && productImport.RequestedBy != userID {
+		err = domain.ErrProductImportNotFound
+	}
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"import_id": id,
+			"user_id":   userID,
+		}).Warn("Product import not found")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, productImport)
+}
+
+// multipartFile returns the part named field of a multipart/form-data
+// request. It is read straight from the request body, so large files are
+// not buffered in memory or on disk first.
+func multipartFile(c *gin.Context, field string) (*multipart.Part, error) {
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, errors.New("the request must be multipart/form-data")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, fmt.Errorf("missing %q file", field)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == field {
+			return part, nil
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ProjectExportHandler struct {
+	service ProjectExportService
+	logger  *logrus.Logger
+}
+
+func NewProjectExportHandler(service ProjectExportService) *ProjectExportHandler {
+	return &ProjectExportHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *ProjectExportHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering project export routes")
+	r.GET(ProjectExport, h.ExportProject)
+	r.GET(ProjectExportByID, h.GetProjectExport)
+	r.GET(ProjectExportDownload, h.DownloadProjectExport)
+}
+
+// @Summary Export project
+// @Description Export a complete snapshot of the project (members, items, hours, expenses and budget stats) as JSON or as a PDF report. Projects with up to 200 items are returned directly; larger ones are generated in the background and answered with 202 and an export to poll at /v1/project-exports/{id}.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param format query string false "Export format: json or pdf (default: json)"
+// @Success 200 {object} domain.ProjectSnapshot
+// @Success 202 {object} domain.ProjectExport
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/projects/{id}/export [get]
+func (h *ProjectExportHandler) ExportProject(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project ID format for export")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	format := c.DefaultQuery("format", domain.ProjectExportFormatJSON)
+	if format != domain.ProjectExportFormatJSON && format != domain.ProjectExportFormatPDF {
+		abortWithMessage(c, StatusBadRequest, "format must be json or pdf")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"project_id": id,
+		"format":     format,
+		"ip":         c.ClientIP(),
+	}).Info("Exporting project")
+
+	export, err := h.service.ExportProject(c.Request.Context(), id, format, userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Failed to export project")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	if export.Status != domain.ProjectExportStatusReady {
+		h.logger.WithFields(logrus.Fields{
+			"export_id":  export.ID,
+			"project_id": id,
+		}).Info("Project export queued")
+		c.JSON(StatusAccepted, export)
+		return
+	}
+
+	h.writeExport(c, export)
+}
+
+// @Summary Get project export
+// @Description Get the status of a background project export requested by the authenticated user
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Export ID"
+// @Success 200 {object} domain.ProjectExport
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/project-exports/{id} [get]
+func (h *ProjectExportHandler) GetProjectExport(c *gin.Context) {
+	export, ok := h.loadExport(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(StatusOK, export)
+}
+
+// @Summary Download project export
+// @Description Download a finished background project export as JSON or PDF, depending on the format it was requested in
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path string true "Export ID"
+// @Success 200 {object} domain.ProjectSnapshot
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Export is still pending or failed"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/project-exports/{id}/download [get]
+func (h *ProjectExportHandler) DownloadProjectExport(c *gin.Context) {
+	export, ok := h.loadExport(c)
+	if !ok {
+		return
+	}
+
+	if export.Status != domain.ProjectExportStatusReady {
+		h.logger.WithFields(logrus.Fields{
+			"export_id": export.ID,
+			"status":    export.Status,
+		}).Warn("Project export not ready for download")
+		abortWithDetails(c, StatusConflict, domain.ErrProjectExportNotReady, gin.H{"status": export.Status})
+		return
+	}
+
+	h.writeExport(c, export)
+}
+
+// loadExport fetches the export named in the path. Exports belong to the user
+// who requested them; anyone else gets 404.
+func (h *ProjectExportHandler) loadExport(c *gin.Context) (*domain.ProjectExport, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project export ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return nil, false
+	}
+
+	export, err := h.service.GetProjectExport(c.Request.Context(), id)
+	if err == nil && export.RequestedBy != userID {
+		err = domain.ErrProjectExportNotFound
+	}
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"export_id": id,
+			"user_id":   userID,
+		}).Warn("Project export not found")
+		abortWithError(c, StatusInternalServerError, err)
+		return nil, false
+	}
+
+	return export, true
+}
+
+func (h *ProjectExportHandler) writeExport(c *gin.Context, export *domain.ProjectExport) {
+	h.logger.WithFields(logrus.Fields{
+		"export_id":  export.ID,
+		"project_id": export.ProjectID,
+		"format":     export.Format,
+		"bytes":      len(export.Content),
+	}).Info("Sending project export")
+
+	if export.Format == domain.ProjectExportFormatPDF {
+		c.Header("Content-Disposition", `attachment; filename="project-`+export.ProjectID.String()+`.pdf"`)
+		c.Data(StatusOK, "application/pdf", export.Content)
+		return
+	}
+
+	c.Data(StatusOK, "application/json; charset=utf-8", export.Content)
+}
@@ -1,10 +1,10 @@
 package api
 
 import (
-	"strconv"
+	"context"
+
 	"time"
 
-	"github.com/edumes/golang-api-rest/internal/application"
 	"github.com/edumes/golang-api-rest/internal/domain"
 	"github.com/edumes/golang-api-rest/internal/infrastructure"
 	"github.com/gin-gonic/gin"
@@ -13,11 +13,11 @@ import (
 )
 
 type ProjectHandler struct {
-	service *application.ProjectService
+	service ProjectService
 	logger  *logrus.Logger
 }
 
-func NewProjectHandler(service *application.ProjectService) *ProjectHandler {
+func NewProjectHandler(service ProjectService) *ProjectHandler {
 	return &ProjectHandler{
 		service: service,
 		logger:  infrastructure.GetColoredLogger(),
@@ -30,29 +30,38 @@ func (h *ProjectHandler) RegisterRoutes(r *gin.RouterGroup) {
 	r.GET(ProjectsEndpoint, h.ListProjects)
 	r.GET(ProjectByID, h.GetProject)
 	r.PUT(ProjectByID, h.UpdateProject)
+	r.PATCH(ProjectByID, h.PatchProject)
 	r.DELETE(ProjectByID, h.DeleteProject)
+	r.POST(ProjectArchive, h.ArchiveProject)
+	r.POST(ProjectUnarchive, h.UnarchiveProject)
 }
 
 type createProjectRequest struct {
-	Name        string     `json:"name" binding:"required"`
-	Description string     `json:"description"`
-	Status      string     `json:"status"`
-	StartDate   *time.Time `json:"start_date"`
-	EndDate     *time.Time `json:"end_date"`
-	Budget      *float64   `json:"budget"`
-	OwnerID     uuid.UUID  `json:"owner_id" binding:"required"`
+	Name         string                   `json:"name" binding:"required"`
+	Description  string                   `json:"description"`
+	Status       domain.ProjectStatus     `json:"status" binding:"omitempty,enum"`
+	StartDate    *time.Time               `json:"start_date"`
+	EndDate      *time.Time               `json:"end_date"`
+	Budget       *float64                 `json:"budget"`
+	OwnerID      uuid.UUID                `json:"owner_id" binding:"required,exists=user"`
+	CustomFields domain.CustomFieldValues `json:"custom_fields"`
 }
 
 // @Summary Create project
-// @Description Create a new project
+// @Description Create a new project. Custom field values are checked against the project custom field definitions.
 // @Tags projects
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body createProjectRequest true "Project data"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Param Idempotency-Key header string false "Key that makes retries of this request replay its response instead of creating again"
 // @Success 201 {object} domain.Project
+// @Header 201 {string} Location "URL of the created project"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "A request with the same Idempotency-Key is still running"
+// @Failure 422 {object} map[string]interface{} "Idempotency-Key already used for a different request"
 // @Router /v1/projects [post]
 func (h *ProjectHandler) CreateProject(c *gin.Context) {
 	h.logger.WithFields(logrus.Fields{
@@ -62,12 +71,12 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 	}).Info("Creating new project")
 
 	var req createProjectRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 			"ip":    c.ClientIP(),
 		}).Warn("Invalid request body for project creation")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
@@ -77,13 +86,13 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 		"owner_id": req.OwnerID,
 	}).Debug("Processing project creation request")
 
-	project, err := h.service.CreateProject(c.Request.Context(), req.Name, req.Description, req.Status, req.StartDate, req.EndDate, req.Budget, req.OwnerID)
+	project, err := h.service.CreateProject(c.Request.Context(), req.Name, req.Description, req.Status, req.StartDate, req.EndDate, req.Budget, req.OwnerID, req.CustomFields)
 	if err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 			"name":  req.Name,
 		}).Error("Failed to create project")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
@@ -93,22 +102,35 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 		"owner_id":   project.OwnerID,
 	}).Info("Project created successfully")
 
-	c.JSON(StatusCreated, project)
+	respondCreated(c, project, ProjectByID, project.ID.String())
+}
+
+type listProjectsQuery struct {
+	pageQuery
+	formatQuery
+	Name            string               `form:"name"`
+	Status          domain.ProjectStatus `form:"status" binding:"omitempty,enum"`
+	OwnerID         *uuid.UUID           `form:"owner_id"`
+	IncludeArchived bool                 `form:"include_archived"`
 }
 
 // @Summary List projects
-// @Description Get a list of projects with optional filtering and pagination
+// @Description Get a list of projects with optional filtering and pagination. Add cf.<key>=<value> parameters to filter by custom field values.
 // @Tags projects
 // @Accept json
-// @Produce json
+// @Produce json,application/x-ndjson,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Security BearerAuth
 // @Param name query string false "Filter by name"
-// @Param status query string false "Filter by status"
+// @Param status query string false "Filter by status: active, on_hold, completed or cancelled"
 // @Param owner_id query string false "Filter by owner ID"
-// @Param limit query int false "Number of items per page (default: 20)"
+// @Param include_archived query bool false "Also return archived projects"
+// @Param format query string false "json (default) for one page; ndjson, csv or xlsx to download every matching row as newline-delimited JSON, CSV or an Excel sheet in id order, ignoring limit, offset and sort"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
 // @Param offset query int false "Number of items to skip (default: 0)"
-// @Param sort query string false "Sort order (default: created_at desc)"
-// @Success 200 {array} domain.Project
+// @Param sort query string false "Field and direction, e.g. created_at desc; fields: name, status, start_date, end_date, budget, created_at, updated_at (default: created_at desc)"
+// @Param saved_filter query string false "Apply the query parameters and sort of a saved filter; parameters given here take precedence"
+// @Success 200 {object} pageResponse[domain.Project]
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters; invalid status comes with the allowed values"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /v1/projects [get]
@@ -119,30 +141,42 @@ func (h *ProjectHandler) ListProjects(c *gin.Context) {
 		"ip":     c.ClientIP(),
 	}).Info("Listing projects")
 
-	filter := domain.ProjectParams{
-		Name:   c.Query("name"),
-		Status: c.Query("status"),
+	var query listProjectsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
 	}
 
-	if ownerIDStr := c.Query("owner_id"); ownerIDStr != "" {
-		if ownerID, err := uuid.Parse(ownerIDStr); err == nil {
-			filter.OwnerID = &ownerID
-		}
+	filter := domain.ProjectParams{
+		Name:            query.Name,
+		Status:          query.Status,
+		OwnerID:         query.OwnerID,
+		IncludeArchived: query.IncludeArchived,
+		CustomFields:    parseCustomFieldQuery(c.Request.URL.Query()),
 	}
-
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	pagination := domain.Pagination{
-		Limit:  limit,
-		Offset: offset,
-		Sort:   c.DefaultQuery("sort", "created_at desc"),
+	if query.streaming() {
+		streamList(c, h.logger, query.Format, listStream[domain.Project]{
+			name:    "projects",
+			columns: domain.ProjectExportColumns,
+			list: func(ctx context.Context, pagination domain.Pagination) ([]domain.Project, error) {
+				return h.service.ListProjects(ctx, filter, pagination)
+			},
+			id: func(p domain.Project) uuid.UUID { return p.ID },
+		})
+		return
 	}
+	sort, err := sortQuery(c, domain.ProjectSort, "created_at desc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := query.pagination(sort)
 
 	h.logger.WithFields(logrus.Fields{
 		"filter_name":   filter.Name,
 		"filter_status": filter.Status,
-		"limit":         limit,
-		"offset":        offset,
+		"limit":         pagination.Limit,
+		"offset":        pagination.Offset,
 		"sort":          pagination.Sort,
 	}).Debug("List projects with filters and pagination")
 
@@ -151,15 +185,25 @@ func (h *ProjectHandler) ListProjects(c *gin.Context) {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Error("Failed to list projects")
-		c.JSON(StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	total, err := h.service.CountProjects(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count projects")
+		abortWithError(c, StatusInternalServerError, err)
 		return
 	}
 
 	h.logger.WithFields(logrus.Fields{
 		"count": len(projects),
+		"total": total,
 	}).Info("Projects listed successfully")
 
-	c.JSON(StatusOK, projects)
+	respondPage(c, projects, total, pagination)
 }
 
 // @Summary Get project by ID
@@ -169,7 +213,9 @@ func (h *ProjectHandler) ListProjects(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Project ID"
+// @Param If-None-Match header string false "ETag of the copy the client already has"
 // @Success 200 {object} domain.Project
+// @Success 304 "Not Modified"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 404 {object} map[string]interface{} "Not Found"
@@ -182,7 +228,7 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid project ID format")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -200,7 +246,7 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 			"project_id": id,
 			"client_ip":  c.ClientIP(),
 		}).Warn("Project not found")
-		c.JSON(StatusNotFound, gin.H{"error": err.Error()})
+		abortWithError(c, StatusNotFound, err)
 		return
 	}
 
@@ -210,6 +256,7 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 		"owner_id":   project.OwnerID,
 	}).Info("Project retrieved successfully")
 
+	setETag(c, project.UpdatedAt, project.Progress)
 	c.JSON(StatusOK, project)
 }
 
@@ -221,10 +268,12 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path string true "Project ID"
 // @Param request body domain.Project true "Project data"
+// @Param If-Match header string false "Version the project must still be at, as an entity tag"
 // @Success 200 {object} domain.Project
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Project is archived, or changed since the given version"
 // @Router /v1/projects/{id} [put]
 func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -234,7 +283,7 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid project ID format")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -246,16 +295,25 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	}).Info("Updating project")
 
 	var project domain.Project
-	if err := c.ShouldBindJSON(&project); err != nil {
+	if err := bindJSON(c, &project); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 			"ip":    c.ClientIP(),
 		}).Warn("Invalid request body for project update")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
 	project.ID = id
+	if project.Version, err = ifMatchVersion(c, project.Version); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Invalid If-Match for project update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
 
 	err = h.service.UpdateProject(c.Request.Context(), &project)
 	if err != nil {
@@ -263,7 +321,7 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 			"error":      err.Error(),
 			"project_id": id,
 		}).Error("Failed to update project")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
@@ -275,6 +333,91 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	c.JSON(StatusOK, project)
 }
 
+// @Summary Patch project
+// @Description Change only the fields named in a JSON Merge Patch (RFC 7396) of a project; every other field keeps its value and null clears a field, such as the end date. Custom fields are merged key by key, so null removes one value.
+// @Tags projects
+// @Accept json,application/merge-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param patch body object true "JSON Merge Patch; fields: name, description, status, start_date, end_date, budget, owner_id, custom_fields"
+// @Param If-Match header string false "Version the project must still be at, as an entity tag"
+// @Success 200 {object} domain.Project
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Project is archived, or changed since the given version"
+// @Failure 415 {object} map[string]interface{} "Unsupported Media Type"
+// @Router /v1/projects/{id} [patch]
+func (h *ProjectHandler) PatchProject(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project ID format for patch")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"project_id": id,
+		"ip":         c.ClientIP(),
+	}).Info("Patching project")
+
+	current, err := h.service.GetProjectByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Project not found for patch")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	project, fields, err := bindMergePatch(c, domain.ProjectPatch, current)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Invalid merge patch for project")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if project.Version, err = ifMatchVersion(c, current.Version); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Invalid If-Match for project patch")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.PatchProject(c.Request.Context(), project, fields); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Error("Failed to patch project")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"project_id": id,
+		"fields":     fields,
+	}).Info("Project patched successfully")
+
+	c.JSON(StatusOK, project)
+}
+
 // @Summary Delete project
 // @Description Delete a project (soft delete)
 // @Tags projects
@@ -295,7 +438,7 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid project ID format")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -312,7 +455,7 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 			"error":      err.Error(),
 			"project_id": id,
 		}).Error("Failed to delete project")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
@@ -322,3 +465,79 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 
 	c.JSON(StatusNoContent, nil)
 }
+
+// @Summary Archive project
+// @Description Archive a project. Archived projects stay readable by ID and in reports but are hidden from listings, and neither the project nor its items can be changed until it is unarchived.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} domain.Project
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/projects/{id}/archive [post]
+func (h *ProjectHandler) ArchiveProject(c *gin.Context) {
+	h.changeArchived(c, true)
+}
+
+// @Summary Unarchive project
+// @Description Restore an archived project to listings and make it and its items editable again
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} domain.Project
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/projects/{id}/unarchive [post]
+func (h *ProjectHandler) UnarchiveProject(c *gin.Context) {
+	h.changeArchived(c, false)
+}
+
+func (h *ProjectHandler) changeArchived(c *gin.Context, archive bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project ID format for archive change")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"project_id": id,
+		"archive":    archive,
+		"ip":         c.ClientIP(),
+	}).Info("Changing project archived state")
+
+	var project *domain.Project
+	if archive {
+		project, err = h.service.ArchiveProject(c.Request.Context(), id)
+	} else {
+		project, err = h.service.UnarchiveProject(c.Request.Context(), id)
+	}
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Failed to change project archived state")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"project_id":  project.ID,
+		"archived_at": project.ArchivedAt,
+	}).Info("Project archived state changed successfully")
+
+	c.JSON(StatusOK, project)
+}
@@ -1,10 +1,10 @@
 package api
 
 import (
-	"strconv"
+	"context"
+
 	"time"
 
-	"github.com/edumes/golang-api-rest/internal/application"
 	"github.com/edumes/golang-api-rest/internal/domain"
 	"github.com/edumes/golang-api-rest/internal/infrastructure"
 	"github.com/gin-gonic/gin"
@@ -13,11 +13,11 @@ import (
 )
 
 type ProjectItemHandler struct {
-	service *application.ProjectItemService
+	service ProjectItemService
 	logger  *logrus.Logger
 }
 
-func NewProjectItemHandler(service *application.ProjectItemService) *ProjectItemHandler {
+func NewProjectItemHandler(service ProjectItemService) *ProjectItemHandler {
 	return &ProjectItemHandler{
 		service: service,
 		logger:  infrastructure.GetColoredLogger(),
@@ -28,34 +28,52 @@ func (h *ProjectItemHandler) RegisterRoutes(r *gin.RouterGroup) {
 	h.logger.Info("Registering project item routes")
 	r.POST(ProjectItemsEndpoint, h.CreateProjectItem)
 	r.GET(ProjectItemsEndpoint, h.ListProjectItems)
+	r.GET(ProjectItemsOverdue, h.ListOverdueProjectItems)
+	r.GET(ProjectItemsUpcoming, h.ListUpcomingProjectItems)
 	r.GET(ProjectItemByID, h.GetProjectItem)
 	r.PUT(ProjectItemByID, h.UpdateProjectItem)
+	r.PATCH(ProjectItemByID, h.PatchProjectItem)
 	r.DELETE(ProjectItemByID, h.DeleteProjectItem)
 	r.GET(ProjectItemsByProject, h.GetProjectItemsByProject)
+	r.GET(ProjectItemAssignments, h.ListProjectItemAssignments)
+	r.POST(ProjectItemTransition, h.TransitionProjectItem)
+	r.GET(ProjectItemTransitions, h.ListProjectItemTransitions)
+	r.GET(ProjectItemChildren, h.ListProjectItemChildren)
+	r.GET(ProjectHours, h.GetProjectHours)
+	r.GET(ProjectBoard, h.GetProjectBoard)
+	r.PATCH(ProjectBoardItem, h.MoveProjectItemOnBoard)
+	r.GET(UserHours, h.GetUserHours)
 }
 
 type createProjectItemRequest struct {
-	ProjectID      uuid.UUID  `json:"project_id" binding:"required"`
-	Name           string     `json:"name" binding:"required"`
-	Description    string     `json:"description"`
-	Status         string     `json:"status"`
-	Priority       string     `json:"priority"`
-	EstimatedHours *float64   `json:"estimated_hours"`
-	ActualHours    *float64   `json:"actual_hours"`
-	DueDate        *time.Time `json:"due_date"`
-	AssignedTo     *uuid.UUID `json:"assigned_to"`
+	ProjectID      uuid.UUID                  `json:"project_id" binding:"required,exists=project"`
+	Name           string                     `json:"name" binding:"required"`
+	Description    string                     `json:"description"`
+	Status         domain.ProjectItemStatus   `json:"status" binding:"omitempty,enum"`
+	Priority       domain.ProjectItemPriority `json:"priority" binding:"omitempty,enum"`
+	EstimatedHours *float64                   `json:"estimated_hours"`
+	ActualHours    *float64                   `json:"actual_hours"`
+	DueDate        *time.Time                 `json:"due_date"`
+	AssignedTo     *uuid.UUID                 `json:"assigned_to" binding:"omitempty,exists=user"`
+	ParentItemID   *uuid.UUID                 `json:"parent_item_id"`
+	CustomFields   domain.CustomFieldValues   `json:"custom_fields"`
 }
 
 // @Summary Create project item
-// @Description Create a new project item
+// @Description Create a new project item. Custom field values are checked against the item custom field definitions of its project, global ones included. parent_item_id makes it a sub-task of another item of the same project.
 // @Tags project-items
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body createProjectItemRequest true "Project item data"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Param Idempotency-Key header string false "Key that makes retries of this request replay its response instead of creating again"
 // @Success 201 {object} domain.ProjectItem
+// @Header 201 {string} Location "URL of the created project item"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "Project is archived, or a request with the same Idempotency-Key still running"
+// @Failure 422 {object} map[string]interface{} "Idempotency-Key already used for a different request"
 // @Router /v1/project-items [post]
 func (h *ProjectItemHandler) CreateProjectItem(c *gin.Context) {
 	h.logger.WithFields(logrus.Fields{
@@ -65,12 +83,12 @@ func (h *ProjectItemHandler) CreateProjectItem(c *gin.Context) {
 	}).Info("Creating new project item")
 
 	var req createProjectItemRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 			"ip":    c.ClientIP(),
 		}).Warn("Invalid request body for project item creation")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
@@ -81,13 +99,13 @@ func (h *ProjectItemHandler) CreateProjectItem(c *gin.Context) {
 		"project_id": req.ProjectID,
 	}).Debug("Processing project item creation request")
 
-	item, err := h.service.CreateProjectItem(c.Request.Context(), req.ProjectID, req.Name, req.Description, req.Status, req.Priority, req.EstimatedHours, req.ActualHours, req.DueDate, req.AssignedTo)
+	item, err := h.service.CreateProjectItem(c.Request.Context(), req.ProjectID, req.Name, req.Description, req.Status, req.Priority, req.EstimatedHours, req.ActualHours, req.DueDate, req.AssignedTo, req.ParentItemID, req.CustomFields)
 	if err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 			"name":  req.Name,
 		}).Error("Failed to create project item")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
@@ -97,24 +115,73 @@ func (h *ProjectItemHandler) CreateProjectItem(c *gin.Context) {
 		"project_id": item.ProjectID,
 	}).Info("Project item created successfully")
 
-	c.JSON(StatusCreated, item)
+	respondCreated(c, item, ProjectItemByID, item.ID.String())
+}
+
+// listProjectItemsQuery is the query string of ListProjectItems. Custom
+// field filters (cf.<key>) are read separately by parseCustomFieldQuery.
+type listProjectItemsQuery struct {
+	pageQuery
+	formatQuery
+	Name               string                     `form:"name"`
+	Status             domain.ProjectItemStatus   `form:"status" binding:"omitempty,enum"`
+	Priority           domain.ProjectItemPriority `form:"priority" binding:"omitempty,enum"`
+	ProjectID          *uuid.UUID                 `form:"project_id"`
+	AssignedTo         *uuid.UUID                 `form:"assigned_to"`
+	DueDateFrom        *time.Time                 `form:"due_date_from"`
+	DueDateTo          *time.Time                 `form:"due_date_to,end_of_day"`
+	EstimatedHoursFrom *float64                   `form:"estimated_hours_from" binding:"omitempty,min=0"`
+	EstimatedHoursTo   *float64                   `form:"estimated_hours_to" binding:"omitempty,min=0"`
+	ActualHoursFrom    *float64                   `form:"actual_hours_from" binding:"omitempty,min=0"`
+	ActualHoursTo      *float64                   `form:"actual_hours_to" binding:"omitempty,min=0"`
+	CreatedAtFrom      *time.Time                 `form:"created_at_from"`
+	CreatedAtTo        *time.Time                 `form:"created_at_to,end_of_day"`
+}
+
+func (q listProjectItemsQuery) params() domain.ProjectItemParams {
+	return domain.ProjectItemParams{
+		Name:               q.Name,
+		Status:             q.Status,
+		Priority:           q.Priority,
+		ProjectID:          q.ProjectID,
+		AssignedTo:         q.AssignedTo,
+		DueDateFrom:        q.DueDateFrom,
+		DueDateTo:          q.DueDateTo,
+		EstimatedHoursFrom: q.EstimatedHoursFrom,
+		EstimatedHoursTo:   q.EstimatedHoursTo,
+		ActualHoursFrom:    q.ActualHoursFrom,
+		ActualHoursTo:      q.ActualHoursTo,
+		CreatedAtFrom:      q.CreatedAtFrom,
+		CreatedAtTo:        q.CreatedAtTo,
+	}
 }
 
 // @Summary List project items
-// @Description Get a list of project items with optional filtering and pagination
+// @Description Get a list of project items with optional filtering and pagination. Add cf.<key>=<value> parameters to filter by custom field values.
 // @Tags project-items
 // @Accept json
-// @Produce json
+// @Produce json,application/x-ndjson,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Security BearerAuth
 // @Param project_id query string false "Filter by project ID"
 // @Param name query string false "Filter by name"
-// @Param status query string false "Filter by status"
-// @Param priority query string false "Filter by priority"
+// @Param status query string false "Filter by status: pending, in_progress, completed or cancelled"
+// @Param priority query string false "Filter by priority: low, medium or high"
 // @Param assigned_to query string false "Filter by assigned user ID"
-// @Param limit query int false "Number of items per page (default: 20)"
+// @Param due_date_from query string false "Minimum due date (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param due_date_to query string false "Maximum due date, inclusive (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param estimated_hours_from query number false "Minimum estimated hours"
+// @Param estimated_hours_to query number false "Maximum estimated hours"
+// @Param actual_hours_from query number false "Minimum actual hours"
+// @Param actual_hours_to query number false "Maximum actual hours"
+// @Param created_at_from query string false "Created on or after (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param created_at_to query string false "Created on or before, inclusive (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param format query string false "json (default) for one page; ndjson, csv or xlsx to download every matching row as newline-delimited JSON, CSV or an Excel sheet in id order, ignoring limit, offset and sort"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
 // @Param offset query int false "Number of items to skip (default: 0)"
-// @Param sort query string false "Sort order (default: created_at desc)"
-// @Success 200 {array} domain.ProjectItem
+// @Param sort query string false "Field and direction, e.g. created_at desc; fields: name, status, priority, estimated_hours, actual_hours, due_date, created_at, updated_at (default: created_at desc)"
+// @Param saved_filter query string false "Apply the query parameters and sort of a saved filter; parameters given here take precedence"
+// @Success 200 {object} pageResponse[domain.ProjectItem]
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters; invalid status or priority comes with the allowed values"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /v1/project-items [get]
@@ -125,38 +192,39 @@ func (h *ProjectItemHandler) ListProjectItems(c *gin.Context) {
 		"ip":     c.ClientIP(),
 	}).Info("Listing project items")
 
-	filter := domain.ProjectItemParams{
-		Name:     c.Query("name"),
-		Status:   c.Query("status"),
-		Priority: c.Query("priority"),
-	}
-
-	if projectIDStr := c.Query("project_id"); projectIDStr != "" {
-		if projectID, err := uuid.Parse(projectIDStr); err == nil {
-			filter.ProjectID = &projectID
-		}
+	var query listProjectItemsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
 	}
-
-	if assignedToStr := c.Query("assigned_to"); assignedToStr != "" {
-		if assignedTo, err := uuid.Parse(assignedToStr); err == nil {
-			filter.AssignedTo = &assignedTo
-		}
+	filter := query.params()
+	filter.CustomFields = parseCustomFieldQuery(c.Request.URL.Query())
+	if query.streaming() {
+		streamList(c, h.logger, query.Format, listStream[domain.ProjectItem]{
+			name:    "project-items",
+			columns: domain.ProjectItemExportColumns,
+			list: func(ctx context.Context, pagination domain.Pagination) ([]domain.ProjectItem, error) {
+				return h.service.ListProjectItems(ctx, filter, pagination)
+			},
+			id: func(i domain.ProjectItem) uuid.UUID { return i.ID },
+		})
+		return
 	}
-
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	pagination := domain.Pagination{
-		Limit:  limit,
-		Offset: offset,
-		Sort:   c.DefaultQuery("sort", "created_at desc"),
+	sort, err := sortQuery(c, domain.ProjectItemSort, "created_at desc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
 	}
+	pagination := query.pagination(sort)
 
 	h.logger.WithFields(logrus.Fields{
 		"filter_name":     filter.Name,
 		"filter_status":   filter.Status,
 		"filter_priority": filter.Priority,
-		"limit":           limit,
-		"offset":          offset,
+		"due_date_from":   filter.DueDateFrom,
+		"due_date_to":     filter.DueDateTo,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
 		"sort":            pagination.Sort,
 	}).Debug("List project items with filters and pagination")
 
@@ -165,15 +233,25 @@ func (h *ProjectItemHandler) ListProjectItems(c *gin.Context) {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Error("Failed to list project items")
-		c.JSON(StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	total, err := h.service.CountProjectItems(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count project items")
+		abortWithError(c, StatusInternalServerError, err)
 		return
 	}
 
 	h.logger.WithFields(logrus.Fields{
 		"count": len(items),
+		"total": total,
 	}).Info("Project items listed successfully")
 
-	c.JSON(StatusOK, items)
+	respondPage(c, items, total, pagination)
 }
 
 // @Summary Get project item by ID
@@ -183,7 +261,9 @@ func (h *ProjectItemHandler) ListProjectItems(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Project item ID"
+// @Param If-None-Match header string false "ETag of the copy the client already has"
 // @Success 200 {object} domain.ProjectItem
+// @Success 304 "Not Modified"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 404 {object} map[string]interface{} "Not Found"
@@ -196,7 +276,7 @@ func (h *ProjectItemHandler) GetProjectItem(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid project item ID format")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -214,7 +294,7 @@ func (h *ProjectItemHandler) GetProjectItem(c *gin.Context) {
 			"item_id":   id,
 			"client_ip": c.ClientIP(),
 		}).Warn("Project item not found")
-		c.JSON(StatusNotFound, gin.H{"error": err.Error()})
+		abortWithError(c, StatusNotFound, err)
 		return
 	}
 
@@ -224,6 +304,7 @@ func (h *ProjectItemHandler) GetProjectItem(c *gin.Context) {
 		"project_id": item.ProjectID,
 	}).Info("Project item retrieved successfully")
 
+	setETag(c, item.UpdatedAt)
 	c.JSON(StatusOK, item)
 }
 
@@ -235,10 +316,12 @@ func (h *ProjectItemHandler) GetProjectItem(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path string true "Project item ID"
 // @Param request body domain.ProjectItem true "Project item data"
+// @Param If-Match header string false "Version the project item must still be at, as an entity tag"
 // @Success 200 {object} domain.ProjectItem
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Project is archived, or changed since the given version"
 // @Router /v1/project-items/{id} [put]
 func (h *ProjectItemHandler) UpdateProjectItem(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -248,7 +331,7 @@ func (h *ProjectItemHandler) UpdateProjectItem(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid project item ID format")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -260,16 +343,25 @@ func (h *ProjectItemHandler) UpdateProjectItem(c *gin.Context) {
 	}).Info("Updating project item")
 
 	var item domain.ProjectItem
-	if err := c.ShouldBindJSON(&item); err != nil {
+	if err := bindJSON(c, &item); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 			"ip":    c.ClientIP(),
 		}).Warn("Invalid request body for project item update")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
 	item.ID = id
+	if item.Version, err = ifMatchVersion(c, item.Version); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"item_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid If-Match for project item update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
 
 	err = h.service.UpdateProjectItem(c.Request.Context(), &item)
 	if err != nil {
@@ -277,7 +369,7 @@ func (h *ProjectItemHandler) UpdateProjectItem(c *gin.Context) {
 			"error":   err.Error(),
 			"item_id": id,
 		}).Error("Failed to update project item")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
@@ -290,6 +382,91 @@ func (h *ProjectItemHandler) UpdateProjectItem(c *gin.Context) {
 	c.JSON(StatusOK, item)
 }
 
+// @Summary Patch project item
+// @Description Change only the fields named in a JSON Merge Patch (RFC 7396) of a project item; every other field keeps its value and null clears a field, such as the assignee. Custom fields are merged key by key, so null removes one value.
+// @Tags project-items
+// @Accept json,application/merge-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project item ID"
+// @Param patch body object true "JSON Merge Patch; fields: project_id, name, description, status, priority, estimated_hours, actual_hours, due_date, assigned_to, custom_fields"
+// @Param If-Match header string false "Version the project item must still be at, as an entity tag"
+// @Success 200 {object} domain.ProjectItem
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Project is archived, or changed since the given version"
+// @Failure 415 {object} map[string]interface{} "Unsupported Media Type"
+// @Router /v1/project-items/{id} [patch]
+func (h *ProjectItemHandler) PatchProjectItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project item ID format for patch")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"item_id": id,
+		"ip":      c.ClientIP(),
+	}).Info("Patching project item")
+
+	current, err := h.service.GetProjectItemByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"item_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Project item not found for patch")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	item, fields, err := bindMergePatch(c, domain.ProjectItemPatch, current)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"item_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid merge patch for project item")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if item.Version, err = ifMatchVersion(c, current.Version); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"item_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid If-Match for project item patch")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.PatchProjectItem(c.Request.Context(), item, fields); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"item_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Error("Failed to patch project item")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"item_id": id,
+		"fields":  fields,
+	}).Info("Project item patched successfully")
+
+	c.JSON(StatusOK, item)
+}
+
 // @Summary Delete project item
 // @Description Delete a project item (soft delete)
 // @Tags project-items
@@ -301,6 +478,7 @@ func (h *ProjectItemHandler) UpdateProjectItem(c *gin.Context) {
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Project is archived"
 // @Router /v1/project-items/{id} [delete]
 func (h *ProjectItemHandler) DeleteProjectItem(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -310,7 +488,7 @@ func (h *ProjectItemHandler) DeleteProjectItem(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid project item ID format")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -327,7 +505,7 @@ func (h *ProjectItemHandler) DeleteProjectItem(c *gin.Context) {
 			"error":   err.Error(),
 			"item_id": id,
 		}).Error("Failed to delete project item")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
@@ -339,7 +517,7 @@ func (h *ProjectItemHandler) DeleteProjectItem(c *gin.Context) {
 }
 
 // @Summary Get project items by project ID
-// @Description Get all project items for a specific project
+// @Description Get all project items for a specific project. Each item carries a rollup with its estimated and actual hours plus those of all its sub-tasks, nested ones included, and how many sub-tasks it has.
 // @Tags project-items
 // @Accept json
 // @Produce json
@@ -358,7 +536,7 @@ func (h *ProjectItemHandler) GetProjectItemsByProject(c *gin.Context) {
 			"param_project_id": c.Param("projectId"),
 			"client_ip":        c.ClientIP(),
 		}).Warn("Invalid project ID format")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid project id"})
+		abortWithMessage(c, StatusBadRequest, "invalid project id")
 		return
 	}
 
@@ -375,7 +553,7 @@ func (h *ProjectItemHandler) GetProjectItemsByProject(c *gin.Context) {
 			"error":      err.Error(),
 			"project_id": projectID,
 		}).Error("Failed to get project items by project ID")
-		c.JSON(StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, StatusInternalServerError, err)
 		return
 	}
 
@@ -386,3 +564,626 @@ func (h *ProjectItemHandler) GetProjectItemsByProject(c *gin.Context) {
 
 	c.JSON(StatusOK, items)
 }
+
+// @Summary List overdue project items
+// @Description Get the open project items whose due date has passed, scoped to a project when project_id is given and to the items assigned to the authenticated user otherwise. Completed and cancelled items are excluded.
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param project_id query string false "Scope to a project instead of the authenticated user"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. due_date desc; fields: name, status, priority, estimated_hours, actual_hours, due_date, created_at, updated_at (default: due_date asc)"
+// @Success 200 {array} domain.ProjectItem
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/project-items/overdue [get]
+func (h *ProjectItemHandler) ListOverdueProjectItems(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Listing overdue project items")
+
+	var query dueItemsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	filter, ok := h.dueItemsScope(c, query)
+	if !ok {
+		return
+	}
+
+	sort, err := sortQuery(c, domain.ProjectItemSort, "due_date asc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	items, err := h.service.ListOverdueProjectItems(c.Request.Context(), filter, query.pagination(sort))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list overdue project items")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"count": len(items),
+	}).Info("Overdue project items listed successfully")
+
+	c.JSON(StatusOK, items)
+}
+
+// @Summary List upcoming project items
+// @Description Get the open project items due from today through the next days days, scoped like the overdue view.
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Look-ahead window in days, 1 to 90 (default: 7)"
+// @Param project_id query string false "Scope to a project instead of the authenticated user"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. due_date desc; fields: name, status, priority, estimated_hours, actual_hours, due_date, created_at, updated_at (default: due_date asc)"
+// @Success 200 {array} domain.ProjectItem
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /v1/project-items/upcoming [get]
+func (h *ProjectItemHandler) ListUpcomingProjectItems(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Listing upcoming project items")
+
+	var query upcomingItemsQuery
+	if err := bindQuery(c, &query); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid upcoming items query")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	days := query.Days
+
+	filter, ok := h.dueItemsScope(c, query.dueItemsQuery)
+	if !ok {
+		return
+	}
+
+	sort, err := sortQuery(c, domain.ProjectItemSort, "due_date asc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	items, err := h.service.ListUpcomingProjectItems(c.Request.Context(), filter, days, query.pagination(sort))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"days":  days,
+		}).Warn("Failed to list upcoming project items")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"days":  days,
+		"count": len(items),
+	}).Info("Upcoming project items listed successfully")
+
+	c.JSON(StatusOK, items)
+}
+
+// @Summary List project item assignments
+// @Description Get who held a project item and when, most recent first. The current assignee has no unassigned_at.
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project item ID"
+// @Success 200 {array} domain.ProjectItemAssignment
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/project-items/{id}/assignments [get]
+func (h *ProjectItemHandler) ListProjectItemAssignments(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project item ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"item_id": id,
+		"ip":      c.ClientIP(),
+	}).Info("Listing project item assignments")
+
+	assignments, err := h.service.ListProjectItemAssignments(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+		}).Warn("Failed to list project item assignments")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	c.JSON(StatusOK, assignments)
+}
+
+type transitionProjectItemRequest struct {
+	Status domain.ProjectItemStatus `json:"status" binding:"required,enum"`
+}
+
+// @Summary Transition project item
+// @Description Move a project item along its status workflow: pending to in_progress, blocked or cancelled; in_progress to pending, blocked, review, completed or cancelled; blocked to pending, in_progress or cancelled; review to in_progress, completed or cancelled; completed back to in_progress; cancelled back to pending. The change is recorded with the authenticated user as its actor and watchers are notified. Status changes through PUT and PATCH follow the same workflow.
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project item ID"
+// @Param request body transitionProjectItemRequest true "New status"
+// @Success 200 {object} domain.ProjectItem
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "The item cannot move to this status or changed project meanwhile"
+// @Router /v1/project-items/{id}/transition [post]
+func (h *ProjectItemHandler) TransitionProjectItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project item ID format for transition")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"item_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Project item transition without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	var req transitionProjectItemRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"item_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid request body for project item transition")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":   c.Request.Method,
+		"path":     c.Request.URL.Path,
+		"item_id":  id,
+		"status":   req.Status,
+		"actor_id": actorID,
+		"ip":       c.ClientIP(),
+	}).Info("Transitioning project item")
+
+	item, err := h.service.TransitionProjectItem(c.Request.Context(), id, req.Status, actorID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"item_id":   id,
+			"status":    req.Status,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to transition project item")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, item)
+}
+
+// @Summary List project item transitions
+// @Description Get the status changes of a project item, most recent first. actor_id is null for changes made by editing the item with PUT or PATCH.
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project item ID"
+// @Success 200 {array} domain.ProjectItemTransition
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/project-items/{id}/transitions [get]
+func (h *ProjectItemHandler) ListProjectItemTransitions(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project item ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"item_id": id,
+		"ip":      c.ClientIP(),
+	}).Info("Listing project item transitions")
+
+	transitions, err := h.service.ListProjectItemTransitions(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+		}).Warn("Failed to list project item transitions")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	c.JSON(StatusOK, transitions)
+}
+
+// @Summary List project item children
+// @Description Get the direct sub-tasks of a project item, oldest first.
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project item ID"
+// @Success 200 {array} domain.ProjectItem
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/project-items/{id}/children [get]
+func (h *ProjectItemHandler) ListProjectItemChildren(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project item ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"item_id": id,
+		"ip":      c.ClientIP(),
+	}).Info("Listing project item children")
+
+	children, err := h.service.ListProjectItemChildren(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+		}).Warn("Failed to list project item children")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	c.JSON(StatusOK, children)
+}
+
+// projectBoardQuery is the query string of GetProjectBoard. The page and
+// sort apply to each column on its own.
+type projectBoardQuery struct {
+	pageQuery
+	Status     []domain.ProjectItemStatus `form:"status" binding:"omitempty,dive,enum"`
+	Priority   domain.ProjectItemPriority `form:"priority" binding:"omitempty,enum"`
+	AssignedTo *uuid.UUID                 `form:"assigned_to"`
+}
+
+// @Summary Project board
+// @Description Get the project's items as a kanban board, in one column per status following the workflow order: pending, in_progress, blocked, review, completed and cancelled. limit, offset and sort page and order every column on its own, and each column reports its total, so a column can be read further with status and a larger offset. Columns are in board order unless sorted otherwise; items are placed with PATCH /v1/projects/{id}/board/items/{itemId}.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param status query []string false "Only these columns, repeated for several: pending, in_progress, blocked, review, completed or cancelled" collectionFormat(multi)
+// @Param priority query string false "Filter by priority: low, medium or high"
+// @Param assigned_to query string false "Filter by assigned user ID"
+// @Param limit query int false "Number of items per column (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip in each column (default: 0)"
+// @Param sort query string false "Field and direction within each column, e.g. due_date asc; fields: rank, name, priority, due_date, created_at, updated_at (default: rank asc, the board order)"
+// @Success 200 {object} domain.ProjectBoard
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/projects/{id}/board [get]
+func (h *ProjectItemHandler) GetProjectBoard(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project ID format for board")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	var query projectBoardQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	sort, err := sortQuery(c, domain.ProjectItemBoardSort, "rank")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"project_id": id,
+		"statuses":   query.Status,
+		"ip":         c.ClientIP(),
+	}).Info("Getting project board")
+
+	filter := domain.ProjectItemParams{Priority: query.Priority, AssignedTo: query.AssignedTo}
+	board, err := h.service.GetProjectBoard(c.Request.Context(), id, filter, query.Status, query.pagination(sort))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": id,
+		}).Error("Failed to get project board")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, board)
+}
+
+type moveProjectItemOnBoardRequest struct {
+	Status   domain.ProjectItemStatus `json:"status" binding:"omitempty,enum"`
+	Position *int                     `json:"position" binding:"required,min=0"`
+}
+
+// @Summary Move item on project board
+// @Description Place a project item at position, counted from zero, in a column of the project board. Without status the item is reordered within its own column; a position past the end of the column puts it last. Moving to another column changes the item's status, which must be allowed by the workflow of POST /v1/project-items/{id}/transition, is recorded with the authenticated user as its actor and notifies watchers. Items that change status or project any other way go to the bottom of their new column.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param itemId path string true "Project item ID"
+// @Param request body moveProjectItemOnBoardRequest true "Target column and position"
+// @Success 200 {object} domain.ProjectItem
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "The item cannot move to this status, changed columns during the move, or the project is archived"
+// @Router /v1/projects/{id}/board/items/{itemId} [patch]
+func (h *ProjectItemHandler) MoveProjectItemOnBoard(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+	id, err := uuid.Parse(c.Param("itemId"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("itemId"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid project item ID format for board move")
+		abortWithMessage(c, StatusBadRequest, "invalid item id")
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"item_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Project board move without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	var req moveProjectItemOnBoardRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"item_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid request body for project board move")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"project_id": projectID,
+		"item_id":    id,
+		"status":     req.Status,
+		"position":   *req.Position,
+		"actor_id":   actorID,
+		"ip":         c.ClientIP(),
+	}).Info("Moving project item on board")
+
+	move := domain.BoardMove{Status: req.Status, Position: *req.Position}
+	item, err := h.service.MoveProjectItemOnBoard(c.Request.Context(), projectID, id, move, actorID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"item_id":   id,
+			"status":    req.Status,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to move project item on board")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, item)
+}
+
+// dueItemsQuery is the query of the overdue and upcoming views.
+type dueItemsQuery struct {
+	pageQuery
+	ProjectID *uuid.UUID `form:"project_id"`
+}
+
+type upcomingItemsQuery struct {
+	dueItemsQuery
+	Days int `form:"days,default=7"`
+}
+
+// dueItemsScope scopes the overdue and upcoming views to the project_id query
+// parameter, falling back to the items assigned to the authenticated user.
+func (h *ProjectItemHandler) dueItemsScope(c *gin.Context, query dueItemsQuery) (domain.ProjectItemParams, bool) {
+	var filter domain.ProjectItemParams
+
+	if query.ProjectID != nil {
+		filter.ProjectID = query.ProjectID
+		return filter, true
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"client_ip": c.ClientIP(),
+		}).Warn("Due items requested without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return filter, false
+	}
+	filter.AssignedTo = &userID
+	return filter, true
+}
+
+// @Summary Project hours rollup
+// @Description Sum the estimated and actual hours of the project's items, broken down by status, assignee and due week (weeks start on Monday; items without a due date fall in a null week).
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param from query string false "Only items due from this date (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param to query string false "Only items due up to this date, inclusive (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Success 200 {object} domain.HoursRollup
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/projects/{id}/hours [get]
+func (h *ProjectItemHandler) GetProjectHours(c *gin.Context) {
+	id, ok := h.hoursRollupID(c)
+	if !ok {
+		return
+	}
+	h.hoursRollup(c, domain.ProjectItemParams{ProjectID: &id})
+}
+
+// @Summary User hours rollup
+// @Description Sum the estimated and actual hours of the items assigned to the user across projects, broken down by status, assignee and due week (weeks start on Monday; items without a due date fall in a null week).
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param from query string false "Only items due from this date (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param to query string false "Only items due up to this date, inclusive (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Success 200 {object} domain.HoursRollup
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/{id}/hours [get]
+func (h *ProjectItemHandler) GetUserHours(c *gin.Context) {
+	id, ok := h.hoursRollupID(c)
+	if !ok {
+		return
+	}
+	h.hoursRollup(c, domain.ProjectItemParams{AssignedTo: &id})
+}
+
+func (h *ProjectItemHandler) hoursRollupID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid ID format for hours rollup")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// hoursRollup reads the from/to due date range and writes the rollup for
+// filter. Unlike list filters, a malformed date is rejected rather than
+// ignored, since it would silently widen the report.
+func (h *ProjectItemHandler) hoursRollup(c *gin.Context, filter domain.ProjectItemParams) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Getting hours rollup")
+
+	if from := c.Query("from"); from != "" {
+		date, err := parseDateQuery(from, false)
+		if err != nil {
+			abortWithMessage(c, StatusBadRequest, "invalid from")
+			return
+		}
+		filter.DueDateFrom = date
+	}
+	if to := c.Query("to"); to != "" {
+		date, err := parseDateQuery(to, true)
+		if err != nil {
+			abortWithMessage(c, StatusBadRequest, "invalid to")
+			return
+		}
+		filter.DueDateTo = date
+	}
+	if filter.DueDateFrom != nil && filter.DueDateTo != nil && filter.DueDateFrom.After(*filter.DueDateTo) {
+		abortWithMessage(c, StatusBadRequest, "from must not be after to")
+		return
+	}
+
+	rollup, err := h.service.GetHoursRollup(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"project_id":  filter.ProjectID,
+			"assigned_to": filter.AssignedTo,
+		}).Warn("Failed to get hours rollup")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"items":     rollup.Items,
+		"estimated": rollup.Estimated,
+		"actual":    rollup.Actual,
+	}).Info("Hours rollup retrieved successfully")
+
+	c.JSON(StatusOK, rollup)
+}
@@ -0,0 +1,452 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type PurchaseOrderHandler struct {
+	service PurchaseOrderService
+	logger  *logrus.Logger
+}
+
+func NewPurchaseOrderHandler(service PurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *PurchaseOrderHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering purchase order routes")
+	write := RequirePermission(domain.ScopeActionWrite, "purchase-orders")
+	r.POST(PurchaseOrdersEndpoint, write, h.CreatePurchaseOrder)
+	r.GET(PurchaseOrdersEndpoint, h.ListPurchaseOrders)
+	r.GET(PurchaseOrderByID, h.GetPurchaseOrder)
+	r.PUT(PurchaseOrderByID, write, h.UpdatePurchaseOrder)
+	r.DELETE(PurchaseOrderByID, write, h.DeletePurchaseOrder)
+	r.POST(PurchaseOrderSubmit, write, h.SubmitPurchaseOrder)
+	r.POST(PurchaseOrderReceive, write, h.ReceivePurchaseOrder)
+}
+
+type purchaseOrderRequest struct {
+	Supplier    string                     `json:"supplier" binding:"required"`
+	WarehouseID *uuid.UUID                 `json:"warehouse_id" binding:"omitempty,exists=warehouse"`
+	Notes       string                     `json:"notes"`
+	Lines       []domain.PurchaseOrderLine `json:"lines" binding:"required,min=1,dive"`
+}
+
+// @Summary Create purchase order
+// @Description Create a draft purchase order (admin only). The authenticated user is recorded as its creator.
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body purchaseOrderRequest true "Purchase order data"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.PurchaseOrder
+// @Header 201 {string} Location "URL of the created purchase order"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "A line references an archived product"
+// @Router /v1/purchase-orders [post]
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"client_ip": c.ClientIP(),
+		}).Warn("Purchase order creation without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":   c.Request.Method,
+		"path":     c.Request.URL.Path,
+		"actor_id": actorID,
+		"ip":       c.ClientIP(),
+	}).Info("Creating new purchase order")
+
+	var req purchaseOrderRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for purchase order creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	order, err := h.service.CreatePurchaseOrder(c.Request.Context(), &domain.PurchaseOrder{
+		Supplier:    req.Supplier,
+		WarehouseID: req.WarehouseID,
+		Notes:       req.Notes,
+		CreatedBy:   actorID,
+		Lines:       req.Lines,
+	})
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"supplier": req.Supplier,
+		}).Error("Failed to create purchase order")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+		"supplier":          order.Supplier,
+	}).Info("Purchase order created successfully")
+
+	respondCreated(c, order, PurchaseOrderByID, order.ID.String())
+}
+
+// @Summary List purchase orders
+// @Description Get a list of purchase orders with their lines, with optional filtering and pagination
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param supplier query string false "Filter by supplier"
+// @Param status query string false "Filter by status (draft, submitted, received)"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. created_at desc; fields: supplier, status, submitted_at, received_at, created_at, updated_at (default: created_at desc)"
+// @Param saved_filter query string false "Apply the query parameters and sort of a saved filter; parameters given here take precedence"
+// @Success 200 {array} domain.PurchaseOrder
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/purchase-orders [get]
+func (h *PurchaseOrderHandler) ListPurchaseOrders(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Listing purchase orders")
+
+	filter := domain.PurchaseOrderParams{
+		Supplier: c.Query("supplier"),
+		Status:   c.Query("status"),
+	}
+
+	var page pageQuery
+	if err := bindQuery(c, &page); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	sort, err := sortQuery(c, domain.PurchaseOrderSort, "created_at desc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := page.pagination(sort)
+
+	h.logger.WithFields(logrus.Fields{
+		"filter_supplier": filter.Supplier,
+		"filter_status":   filter.Status,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
+		"sort":            pagination.Sort,
+	}).Debug("List purchase orders with filters and pagination")
+
+	orders, err := h.service.ListPurchaseOrders(c.Request.Context(), filter, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list purchase orders")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"count": len(orders),
+	}).Info("Purchase orders listed successfully")
+
+	c.JSON(StatusOK, orders)
+}
+
+// @Summary Get purchase order by ID
+// @Description Get a specific purchase order and its lines
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Purchase order ID"
+// @Success 200 {object} domain.PurchaseOrder
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/purchase-orders/{id} [get]
+func (h *PurchaseOrderHandler) GetPurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid purchase order ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":            c.Request.Method,
+		"path":              c.Request.URL.Path,
+		"purchase_order_id": id,
+		"ip":                c.ClientIP(),
+	}).Info("Getting purchase order by ID")
+
+	order, err := h.service.GetPurchaseOrderByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+			"client_ip":         c.ClientIP(),
+		}).Warn("Purchase order not found")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+		"status":            order.Status,
+	}).Info("Purchase order retrieved successfully")
+
+	c.JSON(StatusOK, order)
+}
+
+// @Summary Update purchase order
+// @Description Replace the supplier, notes, receiving warehouse and lines of a draft purchase order (admin only)
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Purchase order ID"
+// @Param request body purchaseOrderRequest true "Purchase order data"
+// @Success 200 {object} domain.PurchaseOrder
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Purchase order is no longer a draft"
+// @Router /v1/purchase-orders/{id} [put]
+func (h *PurchaseOrderHandler) UpdatePurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid purchase order ID format for update")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":            c.Request.Method,
+		"path":              c.Request.URL.Path,
+		"purchase_order_id": id,
+		"ip":                c.ClientIP(),
+	}).Info("Updating purchase order")
+
+	var req purchaseOrderRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+			"client_ip":         c.ClientIP(),
+		}).Warn("Invalid request body for purchase order update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	order, err := h.service.UpdatePurchaseOrder(c.Request.Context(), &domain.PurchaseOrder{
+		ID:          id,
+		Supplier:    req.Supplier,
+		WarehouseID: req.WarehouseID,
+		Notes:       req.Notes,
+		Lines:       req.Lines,
+	})
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+			"client_ip":         c.ClientIP(),
+		}).Error("Failed to update purchase order")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+	}).Info("Purchase order updated successfully")
+
+	c.JSON(StatusOK, order)
+}
+
+// @Summary Delete purchase order
+// @Description Delete a draft purchase order by ID (admin only)
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Purchase order ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Purchase order is no longer a draft"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/purchase-orders/{id} [delete]
+func (h *PurchaseOrderHandler) DeletePurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid purchase order ID format for deletion")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":            c.Request.Method,
+		"path":              c.Request.URL.Path,
+		"purchase_order_id": id,
+		"ip":                c.ClientIP(),
+	}).Info("Deleting purchase order")
+
+	if err := h.service.DeletePurchaseOrder(c.Request.Context(), id); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+			"client_ip":         c.ClientIP(),
+		}).Error("Failed to delete purchase order")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+	}).Info("Purchase order deleted successfully")
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary Submit purchase order
+// @Description Move a draft purchase order to submitted (admin only). Submitted orders can no longer be edited.
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Purchase order ID"
+// @Success 200 {object} domain.PurchaseOrder
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Purchase order is not a draft"
+// @Router /v1/purchase-orders/{id}/submit [post]
+func (h *PurchaseOrderHandler) SubmitPurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid purchase order ID format for submit")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":            c.Request.Method,
+		"path":              c.Request.URL.Path,
+		"purchase_order_id": id,
+		"ip":                c.ClientIP(),
+	}).Info("Submitting purchase order")
+
+	order, err := h.service.SubmitPurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+			"client_ip":         c.ClientIP(),
+		}).Warn("Failed to submit purchase order")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+		"status":            order.Status,
+	}).Info("Purchase order submitted successfully")
+
+	c.JSON(StatusOK, order)
+}
+
+// @Summary Receive purchase order
+// @Description Receive a submitted purchase order: every line is added to stock through a purchase stock adjustment, into the order's warehouse when it has one, and its unit cost becomes the product's cost price (admin only). All lines are booked in one transaction. The authenticated user is recorded as the actor.
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Purchase order ID"
+// @Success 200 {object} domain.PurchaseOrder
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Purchase order is not submitted or a product is archived"
+// @Router /v1/purchase-orders/{id}/receive [post]
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid purchase order ID format for receive")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"purchase_order_id": id,
+			"client_ip":         c.ClientIP(),
+		}).Warn("Purchase order receipt without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":            c.Request.Method,
+		"path":              c.Request.URL.Path,
+		"purchase_order_id": id,
+		"actor_id":          actorID,
+		"ip":                c.ClientIP(),
+	}).Info("Receiving purchase order")
+
+	order, err := h.service.ReceivePurchaseOrder(c.Request.Context(), id, actorID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+			"client_ip":         c.ClientIP(),
+		}).Error("Failed to receive purchase order")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+		"lines":             len(order.Lines),
+	}).Info("Purchase order received successfully")
+
+	c.JSON(StatusOK, order)
+}
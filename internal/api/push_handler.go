@@ -0,0 +1,245 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type PushHandler struct {
+	service PushService
+	logger  *logrus.Logger
+}
+
+func NewPushHandler(service PushService) *PushHandler {
+	return &PushHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *PushHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering push notification routes")
+	r.POST(UserDevices, h.RegisterDevice)
+	r.GET(UserDevices, h.ListDevices)
+	r.DELETE(UserDeviceByID, h.UnregisterDevice)
+	r.GET(UserNotificationPreferences, h.GetNotificationPreferences)
+	r.PUT(UserNotificationPreferences, h.UpdateNotificationPreferences)
+}
+
+type registerDeviceRequest struct {
+	Platform domain.PushPlatform `json:"platform" binding:"required,enum"`
+	Token    string              `json:"token" binding:"required"`
+}
+
+// notificationPreferencesRequest replaces every preference, so each one is
+// required.
+type notificationPreferencesRequest struct {
+	PushAssigned *bool `json:"push_assigned" binding:"required"`
+	PushDueSoon  *bool `json:"push_due_soon" binding:"required"`
+	PushChanges  *bool `json:"push_changes" binding:"required"`
+}
+
+// @Summary Register device
+// @Description Register a device of the authenticated user for push notifications, with its FCM registration token or APNS device token. Registering a known token refreshes it, so apps can call this on every start; a token registered by another user moves to the caller. Each user keeps their 10 most recently seen devices.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body registerDeviceRequest true "Device"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.Device
+// @Header 201 {string} Location "URL of the user's devices"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/devices [post]
+func (h *PushHandler) RegisterDevice(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req registerDeviceRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for device registration")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":   c.Request.Method,
+		"path":     c.Request.URL.Path,
+		"user_id":  userID,
+		"platform": req.Platform,
+		"ip":       c.ClientIP(),
+	}).Info("Registering device")
+
+	device, err := h.service.RegisterDevice(c.Request.Context(), userID, req.Platform, req.Token)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Warn("Failed to register device")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	respondCreated(c, device, UserDevices)
+}
+
+// @Summary List devices
+// @Description List the authenticated user's devices registered for push notifications, most recently seen first
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.Device
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/devices [get]
+func (h *PushHandler) ListDevices(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	devices, err := h.service.ListDevices(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list devices")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, devices)
+}
+
+// @Summary Unregister device
+// @Description Stop pushing notifications to one of the authenticated user's devices, for instance on sign out
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Device ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/devices/{id} [delete]
+func (h *PushHandler) UnregisterDevice(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid device ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	if err := h.service.UnregisterDevice(c.Request.Context(), userID, id); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"device_id": id,
+		}).Warn("Failed to unregister device")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary Get notification preferences
+// @Description Get which notifications are pushed to the authenticated user's devices. Every notification still appears in /v1/notifications.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.NotificationPreferences
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/notification-preferences [get]
+func (h *PushHandler) GetNotificationPreferences(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	preferences, err := h.service.GetNotificationPreferences(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to get notification preferences")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, preferences)
+}
+
+// @Summary Update notification preferences
+// @Description Choose which notifications are pushed to the authenticated user's devices: assignments, due date reminders and changes to watched projects and items
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body notificationPreferencesRequest true "Notification preferences"
+// @Success 200 {object} domain.NotificationPreferences
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/notification-preferences [put]
+func (h *PushHandler) UpdateNotificationPreferences(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req notificationPreferencesRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for notification preferences")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	preferences, err := h.service.UpdateNotificationPreferences(c.Request.Context(), &domain.NotificationPreferences{
+		UserID:       userID,
+		PushAssigned: *req.PushAssigned,
+		PushDueSoon:  *req.PushDueSoon,
+		PushChanges:  *req.PushChanges,
+	})
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to update notification preferences")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, preferences)
+}
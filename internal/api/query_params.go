@@ -0,0 +1,221 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const dateOnlyLayout = "2006-01-02"
+
+// parseDateQuery accepts RFC3339 timestamps or date-only values. Date-only
+// values are read in the application timezone; with endOfDay set the last
+// instant of that day is returned so "_to" filters include the whole day.
+func parseDateQuery(value string, endOfDay bool) (*time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.ParseInLocation(dateOnlyLayout, value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return &t, nil
+}
+
+// sortQuery reads the sort parameter, or def when it is missing, and turns
+// it into an ORDER BY clause through spec, so only whitelisted columns reach
+// the database. An invalid value is an error that answers 400.
+func sortQuery(c *gin.Context, spec domain.SortSpec, def string) (string, error) {
+	return spec.Parse(c.DefaultQuery("sort", def))
+}
+
+// customFieldQueryPrefix marks list query parameters that filter by a custom
+// field value, as in ?cf.region=south.
+const customFieldQueryPrefix = "cf."
+
+// parseCustomFieldQuery collects the custom field filters of a query string.
+// It returns nil when there are none.
+func parseCustomFieldQuery(query url.Values) map[string]string {
+	var values map[string]string
+	for name, value := range query {
+		key := strings.TrimPrefix(name, customFieldQueryPrefix)
+		if key == name || key == "" || len(value) == 0 {
+			continue
+		}
+		if values == nil {
+			values = make(map[string]string)
+		}
+		values[key] = value[0]
+	}
+	return values
+}
+
+// maxPageSize is the largest limit a list request may ask for. It is set
+// once, by Router.WithMaxPageSize, before the routes are served.
+var maxPageSize atomic.Int64
+
+func init() {
+	maxPageSize.Store(domain.DefaultMaxPageSize)
+}
+
+// pageQuery is the pagination every list endpoint accepts. Embed it in the
+// endpoint's query struct.
+type pageQuery struct {
+	Limit  int `form:"limit,default=20" binding:"min=1,maxpage"`
+	Offset int `form:"offset" binding:"min=0"`
+}
+
+func (q pageQuery) pagination(sort string) domain.Pagination {
+	return domain.Pagination{Limit: q.Limit, Offset: q.Offset, Sort: sort}
+}
+
+// productSearchQuery is the query string of the product search.
+type productSearchQuery struct {
+	pageQuery
+	Q string `form:"q" binding:"required,max=200"`
+}
+
+// suggestQuery is the query string of the typeahead endpoints.
+type suggestQuery struct {
+	Q     string `form:"q" binding:"required"`
+	Limit int    `form:"limit,default=10" binding:"min=1,max=25"`
+}
+
+// bindQuery decodes the query string into obj, a pointer to a struct whose
+// fields carry "form" tags, and validates it with requestValidator. Unlike
+// gin's form binding it does not stop at the first bad parameter: every
+// value that does not parse and every field that fails validation is
+// returned in one domain.ValidationErrors.
+//
+// A tag may add ",default=<value>" for a missing parameter and, on dates,
+// ",end_of_day" to read date-only values as the last instant of that day.
+// Dates are parsed by parseDateQuery; UUIDs, booleans and numbers by their
+// usual parsers.
+func bindQuery(c *gin.Context, obj any) error {
+	errs := decodeQuery(c.Request.URL.Query(), reflect.ValueOf(obj).Elem())
+
+	err := validateRequest(c.Request.Context(), obj)
+	var fields domain.ValidationErrors
+	if err != nil && !errors.As(err, &fields) {
+		return err
+	}
+	for _, field := range fields {
+		if !errs.Has(field.Field) {
+			errs = append(errs, field)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func decodeQuery(query url.Values, v reflect.Value) domain.ValidationErrors {
+	var errs domain.ValidationErrors
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			errs = append(errs, decodeQuery(query, v.Field(i))...)
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		raw := strings.TrimSpace(query.Get(name))
+		if raw == "" {
+			def, ok := queryTagOption(options, "default")
+			if !ok {
+				continue
+			}
+			raw = def
+		}
+		_, endOfDay := queryTagOption(options, "end_of_day")
+
+		if message := setQueryValue(v.Field(i), raw, endOfDay); message != "" {
+			errs = append(errs, domain.FieldError{Field: name, Message: name + " " + message})
+		}
+	}
+	return errs
+}
+
+func queryTagOption(options, key string) (string, bool) {
+	for _, option := range strings.Split(options, ",") {
+		name, value, _ := strings.Cut(option, "=")
+		if name == key {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// setQueryValue parses raw into field and returns what is wrong with it, or
+// "" when it parsed.
+func setQueryValue(field reflect.Value, raw string, endOfDay bool) string {
+	if field.Kind() == reflect.Pointer {
+		value := reflect.New(field.Type().Elem())
+		if message := setQueryValue(value.Elem(), raw, endOfDay); message != "" {
+			return message
+		}
+		field.Set(value)
+		return ""
+	}
+
+	switch field.Interface().(type) {
+	case time.Time:
+		t, err := parseDateQuery(raw, endOfDay)
+		if err != nil {
+			return "must be a date (YYYY-MM-DD) or an RFC3339 time"
+		}
+		field.Set(reflect.ValueOf(*t))
+		return ""
+	case uuid.UUID:
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return "must be a valid UUID"
+		}
+		field.Set(reflect.ValueOf(id))
+		return ""
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "must be true or false"
+		}
+		field.SetBool(value)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || field.OverflowInt(value) {
+			return "must be an integer"
+		}
+		field.SetInt(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return "must be a number"
+		}
+		field.SetFloat(value)
+	default:
+		return "is not supported"
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// RateLimitKey picks the bucket a request takes its token from. Requests
+// it returns false for are not limited.
+type RateLimitKey func(c *gin.Context) (string, bool)
+
+// RateLimitByIP counts requests per client address.
+func RateLimitByIP(c *gin.Context) (string, bool) {
+	return "ip:" + c.ClientIP(), true
+}
+
+// RateLimitByUser counts requests per authenticated user. It needs
+// AuthMiddleware before it.
+func RateLimitByUser(c *gin.Context) (string, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return "", false
+	}
+	return "user:" + userID.String(), true
+}
+
+// RateLimiterMiddleware takes a token from the bucket key picks for each
+// request and answers 429 with Retry-After once it is empty. Responses
+// carry the limit and the tokens left in X-RateLimit-Limit and
+// X-RateLimit-Remaining. When the store fails the request goes through, so
+// an unreachable Redis does not take the API down.
+func RateLimiterMiddleware(store domain.RateLimitStore, limit domain.RateLimit, key RateLimitKey) gin.HandlerFunc {
+	logger := infrastructure.WithRedaction(logrus.New())
+
+	return func(c *gin.Context) {
+		bucket, ok := key(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		decision, err := store.Take(c.Request.Context(), bucket, limit, time.Now())
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error":  err.Error(),
+				"bucket": bucket,
+			}).Warn("Rate limit store failed, letting the request through")
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		if !decision.Allowed {
+			logger.WithFields(logrus.Fields{
+				"bucket":      bucket,
+				"path":        c.Request.URL.Path,
+				"retry_after": decision.RetryAfter,
+			}).Warn("Request refused by rate limit")
+			c.Header("Retry-After", strconv.Itoa(retryAfterSeconds(decision.RetryAfter)))
+			abortWithError(c, StatusTooManyRequests, domain.ErrRateLimited)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// retryAfterSeconds rounds wait up to whole seconds, at least one, since
+// Retry-After has no finer unit.
+func retryAfterSeconds(wait time.Duration) int {
+	return max(1, int(math.Ceil(wait.Seconds())))
+}
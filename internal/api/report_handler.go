@@ -0,0 +1,209 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ReportHandler struct {
+	service ReportService
+	logger  *logrus.Logger
+}
+
+func NewReportHandler(service ReportService) *ReportHandler {
+	return &ReportHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *ReportHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering report routes")
+	r.GET(ReportItemsByStatus, h.ItemsByStatus)
+	r.GET(ReportStockByCategory, h.StockByCategory)
+	r.GET(ReportProjectsBudget, h.ProjectsBudget)
+}
+
+// @Summary Project items report
+// @Description Count project items and sum their estimated and actual hours, grouped by status or another dimension and optionally bucketed by creation date
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string false "Group by status, priority, assigned_to or project_id (default: status)"
+// @Param interval query string false "Bucket by creation date: day, week, month or year"
+// @Param from query string false "Minimum creation date (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param to query string false "Maximum creation date, inclusive (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param project_id query string false "Filter by project ID"
+// @Param assigned_to query string false "Filter by assigned user ID"
+// @Param status query string false "Filter by status"
+// @Param priority query string false "Filter by priority"
+// @Success 200 {array} domain.ItemsReportRow
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/reports/items-by-status [get]
+func (h *ReportHandler) ItemsByStatus(c *gin.Context) {
+	var query itemsReportQuery
+	params, ok := h.reportParams(c, &query)
+	if !ok {
+		return
+	}
+	params.ProjectID = query.ProjectID
+	params.AssignedTo = query.AssignedTo
+	params.Status = query.Status
+	params.Priority = query.Priority
+
+	rows, err := h.service.ItemsReport(c.Request.Context(), params)
+	h.writeReport(c, "items-by-status", rows, err)
+}
+
+// @Summary Stock report
+// @Description Sum product stock and its value at sale and cost price, grouped by category or warehouse and optionally bucketed by product creation date. Archived products are left out unless include_archived is set.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string false "Group by category or warehouse (default: category)"
+// @Param interval query string false "Bucket by creation date: day, week, month or year"
+// @Param from query string false "Minimum creation date (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param to query string false "Maximum creation date, inclusive (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param category query string false "Filter by category"
+// @Param include_archived query bool false "Include archived products"
+// @Success 200 {array} domain.StockReportRow
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/reports/stock-by-category [get]
+func (h *ReportHandler) StockByCategory(c *gin.Context) {
+	var query stockReportQuery
+	params, ok := h.reportParams(c, &query)
+	if !ok {
+		return
+	}
+	params.Category = query.Category
+	params.IncludeArchived = query.IncludeArchived
+
+	rows, err := h.service.StockReport(c.Request.Context(), params)
+	h.writeReport(c, "stock-by-category", rows, err)
+}
+
+// @Summary Projects budget report
+// @Description Sum project budgets, expenses and remaining budget, grouped by status or owner and optionally bucketed by project creation date. Archived projects are left out unless include_archived is set.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string false "Group by status or owner_id (default: status)"
+// @Param interval query string false "Bucket by creation date: day, week, month or year"
+// @Param from query string false "Minimum creation date (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param to query string false "Maximum creation date, inclusive (YYYY-MM-DD in the application timezone, or RFC3339)"
+// @Param owner_id query string false "Filter by owner ID"
+// @Param status query string false "Filter by status"
+// @Param include_archived query bool false "Include archived projects"
+// @Success 200 {array} domain.ProjectsReportRow
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/reports/projects-budget [get]
+func (h *ReportHandler) ProjectsBudget(c *gin.Context) {
+	var query projectsReportQuery
+	params, ok := h.reportParams(c, &query)
+	if !ok {
+		return
+	}
+	params.OwnerID = query.OwnerID
+	params.Status = query.Status
+	params.IncludeArchived = query.IncludeArchived
+
+	rows, err := h.service.ProjectsReport(c.Request.Context(), params)
+	h.writeReport(c, "projects-budget", rows, err)
+}
+
+// reportQuery holds the parameters shared by every report.
+type reportQuery struct {
+	GroupBy  string                `form:"group_by"`
+	Interval domain.ReportInterval `form:"interval"`
+	From     *time.Time            `form:"from"`
+	To       *time.Time            `form:"to,end_of_day"`
+}
+
+type itemsReportQuery struct {
+	reportQuery
+	ProjectID  *uuid.UUID `form:"project_id"`
+	AssignedTo *uuid.UUID `form:"assigned_to"`
+	Status     string     `form:"status"`
+	Priority   string     `form:"priority"`
+}
+
+type stockReportQuery struct {
+	reportQuery
+	Category        string `form:"category"`
+	IncludeArchived bool   `form:"include_archived"`
+}
+
+type projectsReportQuery struct {
+	reportQuery
+	OwnerID         *uuid.UUID `form:"owner_id"`
+	Status          string     `form:"status"`
+	IncludeArchived bool       `form:"include_archived"`
+}
+
+func (q reportQuery) params() domain.ReportParams {
+	return domain.ReportParams{
+		GroupBy:  q.GroupBy,
+		Interval: q.Interval,
+		From:     q.From,
+		To:       q.To,
+	}
+}
+
+// reportParams binds the query string into query, a pointer to one of the
+// report query structs, and returns the parameters shared by every report.
+// As with the hours rollup, malformed values are rejected rather than
+// ignored.
+func (h *ReportHandler) reportParams(c *gin.Context, query interface{ params() domain.ReportParams }) (domain.ReportParams, bool) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Computing report")
+
+	if err := bindQuery(c, query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return domain.ReportParams{}, false
+	}
+
+	params := query.params()
+	if params.From != nil && params.To != nil && params.From.After(*params.To) {
+		abortWithMessage(c, StatusBadRequest, "from must not be after to")
+		return params, false
+	}
+	return params, true
+}
+
+// writeReport answers with the rows, or with 400 when the service rejected
+// an enumerated parameter and 500 otherwise.
+func (h *ReportHandler) writeReport(c *gin.Context, report string, rows interface{}, err error) {
+	if err != nil {
+		var invalid *domain.InvalidValueError
+		if errors.As(err, &invalid) {
+			abortWithError(c, StatusBadRequest, err)
+			return
+		}
+		h.logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"report": report,
+		}).Error("Failed to compute report")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, rows)
+}
@@ -0,0 +1,318 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ReportSubscriptionHandler struct {
+	service ReportSubscriptionService
+	logger  *logrus.Logger
+}
+
+func NewReportSubscriptionHandler(service ReportSubscriptionService) *ReportSubscriptionHandler {
+	return &ReportSubscriptionHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *ReportSubscriptionHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering report subscription routes")
+	r.POST(ReportSubscriptionsEndpoint, h.CreateReportSubscription)
+	r.GET(ReportSubscriptionsEndpoint, h.ListReportSubscriptions)
+	r.GET(ReportSubscriptionByID, h.GetReportSubscription)
+	r.PUT(ReportSubscriptionByID, h.UpdateReportSubscription)
+	r.DELETE(ReportSubscriptionByID, h.DeleteReportSubscription)
+	r.GET(ReportSubscriptionDeliveries, h.ListReportDeliveries)
+}
+
+// reportSubscriptionRequest is the body of create and update requests.
+// Active defaults to true; the report cannot change once created.
+type reportSubscriptionRequest struct {
+	Name       string              `json:"name" binding:"required"`
+	Report     domain.ReportType   `json:"report" binding:"omitempty,enum"`
+	Format     domain.ReportFormat `json:"format" binding:"omitempty,enum"`
+	Filters    map[string]string   `json:"filters"`
+	Schedule   string              `json:"schedule" binding:"required"`
+	Recipients []string            `json:"recipients" binding:"required"`
+	Active     *bool               `json:"active"`
+}
+
+func (r reportSubscriptionRequest) subscription(id uuid.UUID) *domain.ReportSubscription {
+	active := r.Active == nil || *r.Active
+	return &domain.ReportSubscription{
+		ID:         id,
+		Name:       r.Name,
+		Report:     r.Report,
+		Format:     r.Format,
+		Filters:    r.Filters,
+		Schedule:   r.Schedule,
+		Recipients: r.Recipients,
+		Active:     active,
+	}
+}
+
+// @Summary Create report subscription
+// @Description Email a report (items-by-status, stock-by-category or projects-budget) as a CSV or PDF attachment whenever a cron schedule fires. The schedule has five fields (minute, hour, day of month, month, day of week) evaluated in the application timezone, or one of @hourly, @daily, @weekly, @monthly and @yearly. Filters take the query parameters of the report endpoint except from and to.
+// @Tags report-subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body reportSubscriptionRequest true "Report subscription"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.ReportSubscription
+// @Header 201 {string} Location "URL of the created report subscription"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /v1/report-subscriptions [post]
+func (h *ReportSubscriptionHandler) CreateReportSubscription(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req reportSubscriptionRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for report subscription creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": userID,
+		"report":  req.Report,
+		"ip":      c.ClientIP(),
+	}).Info("Creating report subscription")
+
+	subscription, err := h.service.CreateReportSubscription(c.Request.Context(), req.subscription(uuid.Nil), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Warn("Failed to create report subscription")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	respondCreated(c, subscription, ReportSubscriptionByID, subscription.ID.String())
+}
+
+type listReportSubscriptionsQuery struct {
+	pageQuery
+	Report domain.ReportType `form:"report" binding:"omitempty,enum"`
+}
+
+// @Summary List report subscriptions
+// @Description List the authenticated user's report subscriptions
+// @Tags report-subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param report query string false "Filter by report"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.ReportSubscription
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/report-subscriptions [get]
+func (h *ReportSubscriptionHandler) ListReportSubscriptions(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var query listReportSubscriptionsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	params := domain.ReportSubscriptionParams{
+		UserID: userID,
+		Report: query.Report,
+	}
+
+	subscriptions, err := h.service.ListReportSubscriptions(c.Request.Context(), params, query.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list report subscriptions")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, subscriptions)
+}
+
+// @Summary Get report subscription
+// @Description Get a report subscription owned by the authenticated user
+// @Tags report-subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Report subscription ID"
+// @Success 200 {object} domain.ReportSubscription
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/report-subscriptions/{id} [get]
+func (h *ReportSubscriptionHandler) GetReportSubscription(c *gin.Context) {
+	userID, id, ok := h.requestIDs(c)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.service.GetReportSubscription(c.Request.Context(), id, userID)
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, subscription)
+}
+
+// @Summary Update report subscription
+// @Description Replace the name, format, filters, schedule, recipients and active flag of a report subscription. The report is fixed and the next run is recomputed from now.
+// @Tags report-subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Report subscription ID"
+// @Param request body reportSubscriptionRequest true "Report subscription"
+// @Success 200 {object} domain.ReportSubscription
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/report-subscriptions/{id} [put]
+func (h *ReportSubscriptionHandler) UpdateReportSubscription(c *gin.Context) {
+	userID, id, ok := h.requestIDs(c)
+	if !ok {
+		return
+	}
+
+	var req reportSubscriptionRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for report subscription update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	subscription, err := h.service.UpdateReportSubscription(c.Request.Context(), req.subscription(id), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": id,
+		}).Warn("Failed to update report subscription")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, subscription)
+}
+
+// @Summary Delete report subscription
+// @Description Stop and delete a report subscription owned by the authenticated user
+// @Tags report-subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Report subscription ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/report-subscriptions/{id} [delete]
+func (h *ReportSubscriptionHandler) DeleteReportSubscription(c *gin.Context) {
+	userID, id, ok := h.requestIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteReportSubscription(c.Request.Context(), id, userID); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": id,
+		}).Warn("Failed to delete report subscription")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary List report deliveries
+// @Description Get the delivery history of a report subscription, newest first. Failed deliveries carry the error.
+// @Tags report-subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Report subscription ID"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.ReportDelivery
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/report-subscriptions/{id}/deliveries [get]
+func (h *ReportSubscriptionHandler) ListReportDeliveries(c *gin.Context) {
+	userID, id, ok := h.requestIDs(c)
+	if !ok {
+		return
+	}
+
+	var page pageQuery
+	if err := bindQuery(c, &page); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	deliveries, err := h.service.ListReportDeliveries(c.Request.Context(), id, userID, page.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": id,
+		}).Error("Failed to list report deliveries")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, deliveries)
+}
+
+func (h *ReportSubscriptionHandler) requestIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid report subscription ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, id, true
+}
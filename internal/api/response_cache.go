@@ -0,0 +1,166 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// cacheableResources are the resources whose GET responses are cached.
+var cacheableResources = map[string]bool{
+	"products":      true,
+	"projects":      true,
+	"project-items": true,
+	"warehouses":    true,
+	"custom-fields": true,
+}
+
+// suggestionCacheTTL bounds how long typeahead answers are cached, by the
+// response cache and by clients, so pickers do not lag behind edits.
+const suggestionCacheTTL = 30 * time.Second
+
+var suggestionCacheControl = "private, max-age=" + strconv.Itoa(int(suggestionCacheTTL.Seconds()))
+
+// shortLivedRoutes are cached even when their resource is not, for at most
+// the given time.
+var shortLivedRoutes = map[string]time.Duration{
+	APIVersion + ProductsSuggest: suggestionCacheTTL,
+	APIVersion + UsersSuggest:    suggestionCacheTTL,
+}
+
+// cacheInvalidates lists the cached resources a successful write to a
+// resource can change besides itself, e.g. receiving a purchase order moves
+// product and warehouse stock.
+var cacheInvalidates = map[string][]string{
+	"stock-transfers": {"products", "warehouses"},
+	"purchase-orders": {"products", "warehouses"},
+	"orders":          {"products", "warehouses"},
+	"products":        {"warehouses"},
+	"categories":      {"products"},
+	"project-items":   {"projects"},
+	"projects":        {"project-items"},
+	"custom-fields":   {"products", "projects", "project-items"},
+	"admin":           {"users"},
+}
+
+// cacheWriter keeps a copy of the body written by the handler.
+type cacheWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// ResponseCacheMiddleware serves repeated GETs of cacheable resources from
+// cache for ttl, or less for shortLivedRoutes. Entries are per principal
+// (user, role and token scopes) and per query, so nobody is served a
+// response computed for someone else.
+// Successful writes invalidate the resource they touched and the resources
+// listed in cacheInvalidates. It must run after AuthMiddleware and
+// ApplySavedFilter.
+func ResponseCacheMiddleware(cache domain.ResponseCache, ttl time.Duration) gin.HandlerFunc {
+	logger := infrastructure.WithRedaction(logrus.New())
+
+	return func(c *gin.Context) {
+		action, resource := requestScope(c)
+
+		if action == domain.ScopeActionWrite {
+			c.Next()
+			if len(c.Errors) == 0 && c.Writer.Status() < http.StatusBadRequest {
+				cache.Invalidate(append([]string{resource}, cacheInvalidates[resource]...)...)
+				logger.WithFields(logrus.Fields{
+					"resource": resource,
+					"path":     c.Request.URL.Path,
+				}).Debug("Response cache invalidated")
+			}
+			return
+		}
+		if c.Request.Method != http.MethodGet {
+			return
+		}
+		// Streamed lists are too large to keep and have to reach the client
+		// as they are written.
+		if _, ok := streamContentTypes[c.Query("format")]; ok {
+			return
+		}
+		entryTTL := ttl
+		if limit, ok := shortLivedRoutes[c.FullPath()]; ok {
+			entryTTL = min(ttl, limit)
+		} else if !cacheableResources[resource] {
+			return
+		}
+		maxAge := "private, max-age=" + strconv.Itoa(int(entryTTL.Seconds()))
+
+		directives := c.GetHeader("Cache-Control")
+		if strings.Contains(directives, "no-store") {
+			return
+		}
+
+		key := responseCacheKey(c, cache.Generation(resource))
+		if cached, ok := cache.Get(key); ok && !strings.Contains(directives, "no-cache") {
+			c.Header("Cache-Control", maxAge)
+			c.Header("Vary", "Authorization")
+			c.Header("X-Cache", "HIT")
+			if cached.ETag != "" {
+				c.Header("ETag", cached.ETag)
+			}
+			c.Data(cached.Status, cached.ContentType, cached.Body)
+			c.Abort()
+			return
+		}
+
+		writer := &cacheWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Header("Cache-Control", maxAge)
+		c.Header("Vary", "Authorization")
+		c.Header("X-Cache", "MISS")
+		c.Next()
+
+		if len(c.Errors) == 0 && writer.Status() == http.StatusOK {
+			cache.Set(key, &domain.CachedResponse{
+				Status:      http.StatusOK,
+				ContentType: writer.Header().Get("Content-Type"),
+				ETag:        writer.Header().Get("ETag"),
+				Body:        writer.body.Bytes(),
+			}, entryTTL)
+		}
+	}
+}
+
+// responseCacheKey identifies a GET by principal, resource generation, path
+// and query. url.Values.Encode sorts the parameters.
+func responseCacheKey(c *gin.Context, generation uint64) string {
+	scopes, _ := c.Get("token_scopes")
+	scopeList, _ := scopes.([]string)
+
+	hash := sha256.New()
+	for _, part := range []string{
+		c.GetString("user_id"),
+		c.GetString("user_role"),
+		strings.Join(scopeList, ","),
+		strconv.FormatUint(generation, 10),
+		c.Request.URL.Path,
+		c.Request.URL.Query().Encode(),
+	} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,518 @@
+package api
+
+import (
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type RetentionHandler struct {
+	service RetentionService
+	logger  *logrus.Logger
+}
+
+func NewRetentionHandler(service RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *RetentionHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering retention routes")
+	admin := RequireRole(domain.RoleAdmin)
+	r.POST(AdminRetentionRules, admin, h.CreateRetentionRule)
+	r.GET(AdminRetentionRules, admin, h.ListRetentionRules)
+	r.GET(AdminRetentionRuleByID, admin, h.GetRetentionRule)
+	r.PUT(AdminRetentionRuleByID, admin, h.UpdateRetentionRule)
+	r.DELETE(AdminRetentionRuleByID, admin, h.DeleteRetentionRule)
+	r.POST(AdminRetentionRuns, admin, h.RunRetention)
+	r.GET(AdminRetentionRuns, admin, h.ListRetentionRuns)
+	r.GET(AdminRetentionMetrics, admin, h.RetentionMetrics)
+	r.GET(AdminArchivedRecords, admin, h.ListArchivedRecords)
+	r.GET(AdminArchivedRecord, admin, h.GetArchivedRecord)
+	r.POST(AdminArchivedRestore, admin, h.RestoreArchivedRecord)
+}
+
+// retentionRuleRequest is the body of create and update requests. Enabled
+// defaults to true and the action to the one the entity supports; the
+// entity cannot change once created.
+type retentionRuleRequest struct {
+	Entity     domain.RetentionEntity `json:"entity" binding:"omitempty,enum"`
+	Action     domain.RetentionAction `json:"action"`
+	RetainDays int                    `json:"retain_days" binding:"required"`
+	Enabled    *bool                  `json:"enabled"`
+}
+
+func (r retentionRuleRequest) rule(id uuid.UUID) *domain.RetentionRule {
+	return &domain.RetentionRule{
+		ID:         id,
+		Entity:     r.Entity,
+		Action:     r.Action,
+		RetainDays: r.RetainDays,
+		Enabled:    r.Enabled == nil || *r.Enabled,
+	}
+}
+
+// @Summary Create retention rule
+// @Description Purge, anonymize or archive the records of an entity once they are older than retain_days (admin only). Notifications, report deliveries, project exports and stock adjustments are purged by creation date; stock adjustments can be archived instead. Users are anonymized once they have not logged in for the period, admins excepted. Completed projects and project items are archived once they have not changed for the period, a project together with its items and expenses. Archived records move to /v1/admin/archived-records. Each entity has at most one rule.
+// @Tags retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body retentionRuleRequest true "Retention rule"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.RetentionRule
+// @Header 201 {string} Location "URL of the created retention rule"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Conflict"
+// @Router /v1/admin/retention-rules [post]
+func (h *RetentionHandler) CreateRetentionRule(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req retentionRuleRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for retention rule creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": userID,
+		"entity":  req.Entity,
+		"ip":      c.ClientIP(),
+	}).Info("Creating retention rule")
+
+	rule, err := h.service.CreateRetentionRule(c.Request.Context(), req.rule(uuid.Nil), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"entity": req.Entity,
+		}).Warn("Failed to create retention rule")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	respondCreated(c, rule, AdminRetentionRuleByID, rule.ID.String())
+}
+
+// @Summary List retention rules
+// @Description List the retention rules, ordered by entity (admin only)
+// @Tags retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.RetentionRule
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/admin/retention-rules [get]
+func (h *RetentionHandler) ListRetentionRules(c *gin.Context) {
+	rules, err := h.service.ListRetentionRules(c.Request.Context())
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list retention rules")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, rules)
+}
+
+// @Summary Get retention rule
+// @Description Get a retention rule by ID (admin only)
+// @Tags retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Retention rule ID"
+// @Success 200 {object} domain.RetentionRule
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/retention-rules/{id} [get]
+func (h *RetentionHandler) GetRetentionRule(c *gin.Context) {
+	id, ok := h.ruleID(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.service.GetRetentionRule(c.Request.Context(), id)
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, rule)
+}
+
+// @Summary Update retention rule
+// @Description Replace the action, retention period and enabled flag of a retention rule (admin only). The entity is fixed.
+// @Tags retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Retention rule ID"
+// @Param request body retentionRuleRequest true "Retention rule"
+// @Success 200 {object} domain.RetentionRule
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/retention-rules/{id} [put]
+func (h *RetentionHandler) UpdateRetentionRule(c *gin.Context) {
+	id, ok := h.ruleID(c)
+	if !ok {
+		return
+	}
+
+	var req retentionRuleRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for retention rule update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	rule, err := h.service.UpdateRetentionRule(c.Request.Context(), req.rule(id))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"rule_id": id,
+		}).Warn("Failed to update retention rule")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, rule)
+}
+
+// @Summary Delete retention rule
+// @Description Delete a retention rule; its run history is kept (admin only)
+// @Tags retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Retention rule ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/retention-rules/{id} [delete]
+func (h *RetentionHandler) DeleteRetentionRule(c *gin.Context) {
+	id, ok := h.ruleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRetentionRule(c.Request.Context(), id); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"rule_id": id,
+		}).Warn("Failed to delete retention rule")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
+
+type runRetentionQuery struct {
+	RuleID *uuid.UUID `form:"rule_id"`
+	DryRun bool       `form:"dry_run"`
+}
+
+// @Summary Run retention rules
+// @Description Apply every enabled retention rule now, or only rule_id, and return the recorded runs (admin only). With dry_run=true nothing is changed and each run reports how many records would be affected.
+// @Tags retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule_id query string false "Only run this rule, even if disabled"
+// @Param dry_run query bool false "Count the affected records without changing them (default: false)"
+// @Success 200 {array} domain.RetentionRun
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/retention-runs [post]
+func (h *RetentionHandler) RunRetention(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var query runRetentionQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	ruleID, dryRun := query.RuleID, query.DryRun
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": userID,
+		"rule_id": ruleID,
+		"dry_run": dryRun,
+		"ip":      c.ClientIP(),
+	}).Info("Running retention rules")
+
+	runs, err := h.service.RunRetention(c.Request.Context(), ruleID, dryRun, userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"rule_id": ruleID,
+		}).Warn("Failed to run retention rules")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, runs)
+}
+
+type listRetentionRunsQuery struct {
+	pageQuery
+	RuleID *uuid.UUID `form:"rule_id"`
+	DryRun *bool      `form:"dry_run"`
+}
+
+// @Summary List retention runs
+// @Description List the recorded retention runs, newest first (admin only). Scheduled runs have no triggered_by.
+// @Tags retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule_id query string false "Filter by rule"
+// @Param dry_run query bool false "Filter by dry run"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.RetentionRun
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/admin/retention-runs [get]
+func (h *RetentionHandler) ListRetentionRuns(c *gin.Context) {
+	var query listRetentionRunsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	params := domain.RetentionRunParams{RuleID: query.RuleID, DryRun: query.DryRun}
+
+	runs, err := h.service.ListRetentionRuns(c.Request.Context(), params, query.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list retention runs")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, runs)
+}
+
+// @Summary Retention metrics
+// @Description Total runs, failed runs and purged, anonymized or archived records per entity and action, counting real runs only (admin only)
+// @Tags retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.RetentionMetric
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/admin/retention-runs/metrics [get]
+func (h *RetentionHandler) RetentionMetrics(c *gin.Context) {
+	metrics, err := h.service.RetentionMetrics(c.Request.Context())
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to compute retention metrics")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, metrics)
+}
+
+type listArchivedRecordsQuery struct {
+	pageQuery
+	Entity       domain.RetentionEntity `form:"entity" binding:"omitempty,enum"`
+	ProjectID    *uuid.UUID             `form:"project_id"`
+	ArchivedFrom *time.Time             `form:"archived_from"`
+	ArchivedTo   *time.Time             `form:"archived_to,end_of_day"`
+}
+
+// @Summary List archived records
+// @Description List the records archive rules moved out of their tables, most recently archived first (admin only). project_id matches archived projects and the archived items of a project.
+// @Tags retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entity query string false "Filter by entity (project, project_item, stock_adjustment)"
+// @Param project_id query string false "Filter by project"
+// @Param archived_from query string false "Archived on or after this date (RFC3339 or YYYY-MM-DD)"
+// @Param archived_to query string false "Archived on or before this date (RFC3339 or YYYY-MM-DD)"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.ArchivedRecord
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/admin/archived-records [get]
+func (h *RetentionHandler) ListArchivedRecords(c *gin.Context) {
+	var query listArchivedRecordsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	params := domain.ArchivedRecordParams{
+		Entity:       query.Entity,
+		ProjectID:    query.ProjectID,
+		ArchivedFrom: query.ArchivedFrom,
+		ArchivedTo:   query.ArchivedTo,
+	}
+
+	records, err := h.service.ListArchivedRecords(c.Request.Context(), params, query.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list archived records")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, records)
+}
+
+// @Summary Get archived record
+// @Description Get an archived record with the rows archived along with it (admin only)
+// @Tags retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entity path string true "Entity (project, project_item, stock_adjustment)"
+// @Param id path string true "ID the record had before it was archived"
+// @Success 200 {object} domain.ArchivedRecord
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/archived-records/{entity}/{id} [get]
+func (h *RetentionHandler) GetArchivedRecord(c *gin.Context) {
+	id, ok := h.archivedRecordID(c)
+	if !ok {
+		return
+	}
+
+	record, err := h.service.GetArchivedRecord(c.Request.Context(), domain.RetentionEntity(c.Param("entity")), id)
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, record)
+}
+
+// @Summary Restore archived record
+// @Description Move an archived record, and the rows archived along with it, back into its table (admin only). Items and stock adjustments whose project or product is no longer there answer 409; restore the project first. An archive rule picks the record up again on its next run unless it changes in the meantime.
+// @Tags retention
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entity path string true "Entity (project, project_item, stock_adjustment)"
+// @Param id path string true "ID the record had before it was archived"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Project or product missing"
+// @Router /v1/admin/archived-records/{entity}/{id}/restore [post]
+func (h *RetentionHandler) RestoreArchivedRecord(c *gin.Context) {
+	id, ok := h.archivedRecordID(c)
+	if !ok {
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+	entity := domain.RetentionEntity(c.Param("entity"))
+
+	h.logger.WithFields(logrus.Fields{
+		"method":    c.Request.Method,
+		"path":      c.Request.URL.Path,
+		"user_id":   userID,
+		"entity":    entity,
+		"record_id": id,
+		"ip":        c.ClientIP(),
+	}).Info("Restoring archived record")
+
+	if err := h.service.RestoreArchivedRecord(c.Request.Context(), entity, id, userID); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"entity":    entity,
+			"record_id": id,
+		}).Warn("Failed to restore archived record")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
+
+func (h *RetentionHandler) archivedRecordID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid archived record ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func (h *RetentionHandler) ruleID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid retention rule ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
@@ -1,33 +1,145 @@
 package api
 
 import (
-	"github.com/edumes/golang-api-rest/internal/application"
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 type Router struct {
-	engine *gin.Engine
-	logger *logrus.Logger
+	engine    *gin.Engine
+	logger    *logrus.Logger
+	protected map[string]bool
+	cache     domain.ResponseCache
+	cacheTTL  time.Duration
+	faults    *FaultHandler
+	outbound  *OutboundHandler
+	// rateLimits keeps the buckets of ipLimit and userLimit; nil turns
+	// rate limiting off.
+	rateLimits domain.RateLimitStore
+	ipLimit    domain.RateLimit
+	userLimit  domain.RateLimit
+	// idempotency keeps the responses of Idempotency-Key requests for
+	// idempotencyTTL; nil turns the header off.
+	idempotency    domain.IdempotencyStore
+	idempotencyTTL time.Duration
+	clock          domain.Clock
+}
+
+type RouteInfo struct {
+	Method       string `json:"method"`
+	Path         string `json:"path"`
+	Handler      string `json:"handler"`
+	AuthRequired bool   `json:"auth_required"`
 }
 
 func NewRouter() *Router {
+	registerValidators()
+
+	engine := gin.New()
+	// Without trusted proxies any client could pick its own address, and
+	// with it a fresh rate limit and login throttle, through
+	// X-Forwarded-For.
+	_ = engine.SetTrustedProxies(nil)
+
 	return &Router{
-		engine: gin.New(),
-		logger: logrus.New(),
+		engine:    engine,
+		logger:    infrastructure.WithRedaction(logrus.New()),
+		protected: make(map[string]bool),
+		clock:     domain.SystemClock{},
+	}
+}
+
+// WithClock sets the clock the middlewares read the time from. Call it
+// before SetupRoutes.
+func (r *Router) WithClock(clock domain.Clock) *Router {
+	r.clock = clock
+	return r
+}
+
+// WithResponseCache caches GET responses of the cacheable resources for
+// ttl. Call it before SetupRoutes.
+func (r *Router) WithResponseCache(cache domain.ResponseCache, ttl time.Duration) *Router {
+	r.cache = cache
+	r.cacheTTL = ttl
+	return r
+}
+
+// WithRateLimit limits the requests to /v1 of each address to ipLimit and,
+// once signed in, of each user to userLimit too, keeping the buckets in
+// store. A zero PerMinute leaves that limit off. Call it before
+// SetupRoutes.
+func (r *Router) WithRateLimit(store domain.RateLimitStore, ipLimit, userLimit domain.RateLimit) *Router {
+	r.rateLimits = store
+	r.ipLimit = ipLimit
+	r.userLimit = userLimit
+	return r
+}
+
+// WithIdempotency lets POSTs to protected routes carry an Idempotency-Key,
+// keeping their responses in store for ttl to replay to retries. Call it
+// before SetupRoutes.
+func (r *Router) WithIdempotency(store domain.IdempotencyStore, ttl time.Duration) *Router {
+	r.idempotency = store
+	r.idempotencyTTL = ttl
+	return r
+}
+
+// WithFaultInjection lets admins make routes slow, fail or drop connections
+// on purpose through /v1/admin/faults. It is meant for resilience testing
+// and must stay off in production. Call it before SetupRoutes.
+func (r *Router) WithFaultInjection(faults FaultService) *Router {
+	r.faults = NewFaultHandler(faults)
+	return r
+}
+
+// WithOutboundStats reports the per-host counters of client, the client
+// third-party integrations call through, at /v1/admin/outbound/metrics.
+// Call it before SetupRoutes.
+func (r *Router) WithOutboundStats(client *infrastructure.HTTPClient) *Router {
+	r.outbound = NewOutboundHandler(client)
+	return r
+}
+
+// WithTrustedProxies lets the proxies at the addresses or CIDR ranges in
+// proxies name the client address in X-Forwarded-For and X-Real-IP, which
+// rate limits, login throttling and logs key on. Call it before
+// SetupRoutes.
+func (r *Router) WithTrustedProxies(proxies []string) (*Router, error) {
+	if err := r.engine.SetTrustedProxies(proxies); err != nil {
+		return nil, err
 	}
+	return r, nil
 }
 
-func (r *Router) SetupRoutes(userService *application.UserService, productService *application.ProductService, projectService *application.ProjectService, projectItemService *application.ProjectItemService) {
+// WithMaxPageSize caps the limit list endpoints accept; larger values are
+// rejected with 400. Call it before SetupRoutes.
+func (r *Router) WithMaxPageSize(size int) *Router {
+	maxPageSize.Store(int64(size))
+	return r
+}
+
+func (r *Router) SetupRoutes(userService UserService, tokenService TokenService, productService ProductService, categoryService CategoryService, attachmentService AttachmentService, projectService ProjectService, projectItemService ProjectItemService, commentService CommentService, couponService CouponService, stockAdjustmentService StockAdjustmentService, warehouseService WarehouseService, purchaseOrderService PurchaseOrderService, orderService OrderService, expenseService ExpenseService, watchService WatchService, projectExportService ProjectExportService, customFieldService CustomFieldService, savedFilterService SavedFilterService, reportService ReportService, dashboardService DashboardService, reportSubscriptionService ReportSubscriptionService, retentionService RetentionService, userExportService UserExportService, policyService PolicyService, emailTemplateService EmailTemplateService, pushService PushService, chatService ChatService, calendarService CalendarService, exchangeRateService ExchangeRateService) {
 	r.logger.Info("Setting up application routes")
 
 	r.engine.Use(gin.Recovery())
 	r.engine.Use(cors.Default())
 	r.engine.Use(LoggingMiddleware())
 	r.engine.Use(ErrorRecoveryMiddleware())
+	r.engine.Use(ErrorHandlerMiddleware())
+	if r.faults != nil {
+		r.engine.Use(r.faults.InjectFaults)
+	}
 
 	r.logger.Debug("Middleware configured successfully")
 
@@ -37,34 +149,138 @@ func (r *Router) SetupRoutes(userService *application.UserService, productServic
 	r.setupHealthRoutes()
 	r.logger.Debug("Health routes configured")
 
+	registerExistenceCheck("user", func(ctx context.Context, id uuid.UUID) error {
+		_, err := userService.GetUserByID(ctx, id)
+		return err
+	})
+	registerExistenceCheck("product", func(ctx context.Context, id uuid.UUID) error {
+		_, err := productService.GetProductByID(ctx, id)
+		return err
+	})
+	registerExistenceCheck("category", func(ctx context.Context, id uuid.UUID) error {
+		_, err := categoryService.GetCategoryByID(ctx, id)
+		return err
+	})
+	registerExistenceCheck("project", func(ctx context.Context, id uuid.UUID) error {
+		_, err := projectService.GetProjectByID(ctx, id)
+		return err
+	})
+	registerExistenceCheck("warehouse", func(ctx context.Context, id uuid.UUID) error {
+		_, err := warehouseService.GetWarehouseByID(ctx, id)
+		return err
+	})
+
 	userHandler := NewUserHandler(userService)
-	authHandler := NewAuthHandler(userService)
+	authHandler := NewAuthHandler(userService, tokenService)
 	productHandler := NewProductHandler(productService)
+	categoryHandler := NewCategoryHandler(categoryService)
+	attachmentHandler := NewAttachmentHandler(attachmentService)
 	projectHandler := NewProjectHandler(projectService)
 	projectItemHandler := NewProjectItemHandler(projectItemService)
+	commentHandler := NewCommentHandler(commentService)
+	couponHandler := NewCouponHandler(couponService)
+	stockAdjustmentHandler := NewStockAdjustmentHandler(stockAdjustmentService)
+	warehouseHandler := NewWarehouseHandler(warehouseService)
+	purchaseOrderHandler := NewPurchaseOrderHandler(purchaseOrderService)
+	orderHandler := NewOrderHandler(orderService)
+	expenseHandler := NewExpenseHandler(expenseService)
+	watchHandler := NewWatchHandler(watchService)
+	projectExportHandler := NewProjectExportHandler(projectExportService)
+	customFieldHandler := NewCustomFieldHandler(customFieldService)
+	savedFilterHandler := NewSavedFilterHandler(savedFilterService)
+	reportHandler := NewReportHandler(reportService)
+	dashboardHandler := NewDashboardHandler(dashboardService)
+	reportSubscriptionHandler := NewReportSubscriptionHandler(reportSubscriptionService)
+	retentionHandler := NewRetentionHandler(retentionService)
+	userExportHandler := NewUserExportHandler(userExportService)
+	policyHandler := NewPolicyHandler(policyService)
+	emailTemplateHandler := NewEmailTemplateHandler(emailTemplateService)
+	pushHandler := NewPushHandler(pushService)
+	chatHandler := NewChatHandler(chatService)
+	calendarHandler := NewCalendarHandler(calendarService)
+	exchangeRateHandler := NewExchangeRateHandler(exchangeRateService)
 
 	r.logger.Debug("Handlers created successfully")
 
-	r.setupV1Routes(userHandler, authHandler, productHandler, projectHandler, projectItemHandler)
+	r.setupV1Routes(userHandler, authHandler, productHandler, categoryHandler, attachmentHandler, projectHandler, projectItemHandler, commentHandler, couponHandler, stockAdjustmentHandler, warehouseHandler, purchaseOrderHandler, orderHandler, expenseHandler, watchHandler, projectExportHandler, customFieldHandler, savedFilterHandler, reportHandler, dashboardHandler, reportSubscriptionHandler, retentionHandler, userExportHandler, policyHandler, emailTemplateHandler, pushHandler, chatHandler, calendarHandler, exchangeRateHandler)
 
 	r.logger.Info("All routes configured successfully")
 }
 
-func (r *Router) setupV1Routes(userHandler *UserHandler, authHandler *AuthHandler, productHandler *ProductHandler, projectHandler *ProjectHandler, projectItemHandler *ProjectItemHandler) {
+func (r *Router) setupV1Routes(userHandler *UserHandler, authHandler *AuthHandler, productHandler *ProductHandler, categoryHandler *CategoryHandler, attachmentHandler *AttachmentHandler, projectHandler *ProjectHandler, projectItemHandler *ProjectItemHandler, commentHandler *CommentHandler, couponHandler *CouponHandler, stockAdjustmentHandler *StockAdjustmentHandler, warehouseHandler *WarehouseHandler, purchaseOrderHandler *PurchaseOrderHandler, orderHandler *OrderHandler, expenseHandler *ExpenseHandler, watchHandler *WatchHandler, projectExportHandler *ProjectExportHandler, customFieldHandler *CustomFieldHandler, savedFilterHandler *SavedFilterHandler, reportHandler *ReportHandler, dashboardHandler *DashboardHandler, reportSubscriptionHandler *ReportSubscriptionHandler, retentionHandler *RetentionHandler, userExportHandler *UserExportHandler, policyHandler *PolicyHandler, emailTemplateHandler *EmailTemplateHandler, pushHandler *PushHandler, chatHandler *ChatHandler, calendarHandler *CalendarHandler, exchangeRateHandler *ExchangeRateHandler) {
 	r.logger.Info("Setting up v1 API routes")
 
 	v1 := r.engine.Group(APIVersion)
+	if r.rateLimits != nil && r.ipLimit.PerMinute > 0 {
+		v1.Use(RateLimiterMiddleware(r.rateLimits, r.ipLimit, RateLimitByIP))
+	}
 
 	r.logger.Info("Registering public routes")
 	authHandler.RegisterRoutes(v1)
+	policyHandler.RegisterPublicRoutes(v1)
+	calendarHandler.RegisterPublicRoutes(v1)
+	attachmentHandler.RegisterPublicRoutes(v1)
 
 	r.logger.Info("Registering protected routes")
+	public := make(map[string]bool)
+	for _, route := range r.engine.Routes() {
+		public[route.Method+" "+route.Path] = true
+	}
+
 	protected := v1.Group("")
-	protected.Use(AuthMiddleware())
+	protected.Use(AuthMiddleware(userHandler.service, authHandler.tokenService))
+	if r.rateLimits != nil && r.userLimit.PerMinute > 0 {
+		protected.Use(RateLimiterMiddleware(r.rateLimits, r.userLimit, RateLimitByUser))
+	}
+	protected.Use(policyHandler.RequirePolicyAcceptance)
+	if r.idempotency != nil {
+		protected.Use(IdempotencyMiddleware(r.idempotency, r.idempotencyTTL, r.clock))
+	}
+	protected.Use(savedFilterHandler.ApplySavedFilter)
+	protected.Use(ConditionalGetMiddleware())
+	if r.cache != nil {
+		protected.Use(ResponseCacheMiddleware(r.cache, r.cacheTTL))
+	}
 	userHandler.RegisterRoutes(protected)
 	productHandler.RegisterRoutes(protected)
+	categoryHandler.RegisterRoutes(protected)
+	attachmentHandler.RegisterRoutes(protected)
 	projectHandler.RegisterRoutes(protected)
 	projectItemHandler.RegisterRoutes(protected)
+	commentHandler.RegisterRoutes(protected)
+	couponHandler.RegisterRoutes(protected)
+	stockAdjustmentHandler.RegisterRoutes(protected)
+	warehouseHandler.RegisterRoutes(protected)
+	purchaseOrderHandler.RegisterRoutes(protected)
+	orderHandler.RegisterRoutes(protected)
+	expenseHandler.RegisterRoutes(protected)
+	watchHandler.RegisterRoutes(protected)
+	projectExportHandler.RegisterRoutes(protected)
+	customFieldHandler.RegisterRoutes(protected)
+	savedFilterHandler.RegisterRoutes(protected)
+	reportHandler.RegisterRoutes(protected)
+	dashboardHandler.RegisterRoutes(protected)
+	reportSubscriptionHandler.RegisterRoutes(protected)
+	retentionHandler.RegisterRoutes(protected)
+	userExportHandler.RegisterRoutes(protected)
+	policyHandler.RegisterRoutes(protected)
+	emailTemplateHandler.RegisterRoutes(protected)
+	pushHandler.RegisterRoutes(protected)
+	chatHandler.RegisterRoutes(protected)
+	calendarHandler.RegisterRoutes(protected)
+	exchangeRateHandler.RegisterRoutes(protected)
+	if r.outbound != nil {
+		r.outbound.RegisterRoutes(protected)
+	}
+	if r.faults != nil {
+		r.faults.RegisterRoutes(protected)
+	}
+
+	for _, route := range r.engine.Routes() {
+		if key := route.Method + " " + route.Path; !public[key] {
+			r.protected[key] = true
+		}
+	}
 }
 
 func (r *Router) setupHealthRoutes() {
@@ -96,6 +312,32 @@ func (r *Router) setupHealthRoutes() {
 	}
 }
 
+func (r *Router) Routes() []RouteInfo {
+	routes := r.engine.Routes()
+	infos := make([]RouteInfo, 0, len(routes))
+	for _, route := range routes {
+		handler := route.Handler
+		if i := strings.LastIndex(handler, "/"); i >= 0 {
+			handler = handler[i+1:]
+		}
+		infos = append(infos, RouteInfo{
+			Method:       route.Method,
+			Path:         route.Path,
+			Handler:      strings.TrimSuffix(handler, "-fm"),
+			AuthRequired: r.protected[route.Method+" "+route.Path],
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Path != infos[j].Path {
+			return infos[i].Path < infos[j].Path
+		}
+		return infos[i].Method < infos[j].Method
+	})
+
+	return infos
+}
+
 func (r *Router) GetEngine() *gin.Engine {
 	return r.engine
 }
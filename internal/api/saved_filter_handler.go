@@ -0,0 +1,351 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// savedFilterQueryParam selects a saved filter on a list endpoint.
+const savedFilterQueryParam = "saved_filter"
+
+// savedFilterListRoutes maps the list endpoints saved filters can run on to
+// the entity they list.
+var savedFilterListRoutes = map[string]domain.SavedFilterEntity{
+	APIVersion + ProductsEndpoint:       domain.SavedFilterProduct,
+	APIVersion + ProjectsEndpoint:       domain.SavedFilterProject,
+	APIVersion + ProjectItemsEndpoint:   domain.SavedFilterProjectItem,
+	APIVersion + UsersEndpoint:          domain.SavedFilterUser,
+	APIVersion + CouponsEndpoint:        domain.SavedFilterCoupon,
+	APIVersion + PurchaseOrdersEndpoint: domain.SavedFilterPurchaseOrder,
+}
+
+type SavedFilterHandler struct {
+	service SavedFilterService
+	logger  *logrus.Logger
+}
+
+func NewSavedFilterHandler(service SavedFilterService) *SavedFilterHandler {
+	return &SavedFilterHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *SavedFilterHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering saved filter routes")
+	r.POST(SavedFiltersEndpoint, h.CreateSavedFilter)
+	r.GET(SavedFiltersEndpoint, h.ListSavedFilters)
+	r.GET(SavedFilterByID, h.GetSavedFilter)
+	r.PUT(SavedFilterByID, h.UpdateSavedFilter)
+	r.DELETE(SavedFilterByID, h.DeleteSavedFilter)
+}
+
+// ApplySavedFilter expands ?saved_filter=<id> on a list endpoint into the
+// filter's stored query parameters and sort. Parameters given in the request
+// take precedence, so a saved filter can be narrowed or re-sorted. It must
+// run after AuthMiddleware and before anything reads the query.
+func (h *SavedFilterHandler) ApplySavedFilter(c *gin.Context) {
+	query := c.Request.URL.Query()
+	raw := query.Get(savedFilterQueryParam)
+	if raw == "" {
+		return
+	}
+
+	entity, ok := savedFilterListRoutes[c.FullPath()]
+	if !ok || c.Request.Method != http.MethodGet {
+		abortWithMessage(c, StatusBadRequest, "saved filters are not supported on this endpoint")
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		abortWithMessage(c, StatusBadRequest, "invalid saved filter id")
+		return
+	}
+
+	filter, err := h.service.GetSavedFilter(c.Request.Context(), id, userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"saved_filter_id": id,
+			"path":            c.Request.URL.Path,
+		}).Warn("Failed to load saved filter for list request")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	if filter.Entity != entity {
+		abortWithMessage(c, StatusBadRequest, "saved filter is for "+string(filter.Entity)+" lists")
+		return
+	}
+
+	for key, value := range filter.Query {
+		if !query.Has(key) {
+			query.Set(key, value)
+		}
+	}
+	if filter.Sort != "" && !query.Has("sort") {
+		query.Set("sort", filter.Sort)
+	}
+	query.Del(savedFilterQueryParam)
+	c.Request.URL.RawQuery = query.Encode()
+
+	h.logger.WithFields(logrus.Fields{
+		"saved_filter_id": id,
+		"path":            c.Request.URL.Path,
+		"query":           c.Request.URL.RawQuery,
+	}).Debug("Saved filter applied to list request")
+}
+
+type savedFilterRequest struct {
+	Name   string                   `json:"name" binding:"required"`
+	Entity domain.SavedFilterEntity `json:"entity" binding:"omitempty,enum"`
+	Query  map[string]string        `json:"query"`
+	Sort   string                   `json:"sort"`
+	Shared bool                     `json:"shared"`
+}
+
+// @Summary Create saved filter
+// @Description Save a named set of list query parameters and a sort order for an entity: product, project, project_item, user, coupon or purchase_order. Apply it with ?saved_filter=<id> on the entity's list endpoint. Shared filters are visible to every user.
+// @Tags saved-filters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body savedFilterRequest true "Saved filter"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.SavedFilter
+// @Header 201 {string} Location "URL of the created saved filter"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /v1/saved-filters [post]
+func (h *SavedFilterHandler) CreateSavedFilter(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req savedFilterRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for saved filter creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": userID,
+		"entity":  req.Entity,
+		"ip":      c.ClientIP(),
+	}).Info("Creating saved filter")
+
+	filter, err := h.service.CreateSavedFilter(c.Request.Context(), &domain.SavedFilter{
+		Name:   req.Name,
+		Entity: req.Entity,
+		Query:  req.Query,
+		Sort:   req.Sort,
+		Shared: req.Shared,
+	}, userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Warn("Failed to create saved filter")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	respondCreated(c, filter, SavedFilterByID, filter.ID.String())
+}
+
+type listSavedFiltersQuery struct {
+	pageQuery
+	Entity domain.SavedFilterEntity `form:"entity" binding:"omitempty,enum"`
+}
+
+// @Summary List saved filters
+// @Description List the authenticated user's saved filters and the ones shared by others
+// @Tags saved-filters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entity query string false "Filter by entity"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.SavedFilter
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/saved-filters [get]
+func (h *SavedFilterHandler) ListSavedFilters(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var query listSavedFiltersQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	params := domain.SavedFilterParams{
+		UserID: userID,
+		Entity: query.Entity,
+	}
+
+	filters, err := h.service.ListSavedFilters(c.Request.Context(), params, query.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list saved filters")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, filters)
+}
+
+// @Summary Get saved filter
+// @Description Get a saved filter owned by or shared with the authenticated user
+// @Tags saved-filters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved filter ID"
+// @Success 200 {object} domain.SavedFilter
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/saved-filters/{id} [get]
+func (h *SavedFilterHandler) GetSavedFilter(c *gin.Context) {
+	userID, id, ok := h.savedFilterRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	filter, err := h.service.GetSavedFilter(c.Request.Context(), id, userID)
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, filter)
+}
+
+// @Summary Update saved filter
+// @Description Replace the name, query, sort and sharing of a saved filter. Only its owner can change it; the entity is fixed.
+// @Tags saved-filters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved filter ID"
+// @Param request body savedFilterRequest true "Saved filter"
+// @Success 200 {object} domain.SavedFilter
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/saved-filters/{id} [put]
+func (h *SavedFilterHandler) UpdateSavedFilter(c *gin.Context) {
+	userID, id, ok := h.savedFilterRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	var req savedFilterRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for saved filter update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	filter, err := h.service.UpdateSavedFilter(c.Request.Context(), &domain.SavedFilter{
+		ID:     id,
+		Name:   req.Name,
+		Query:  req.Query,
+		Sort:   req.Sort,
+		Shared: req.Shared,
+	}, userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"saved_filter_id": id,
+		}).Warn("Failed to update saved filter")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusOK, filter)
+}
+
+// @Summary Delete saved filter
+// @Description Delete a saved filter owned by the authenticated user
+// @Tags saved-filters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved filter ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/saved-filters/{id} [delete]
+func (h *SavedFilterHandler) DeleteSavedFilter(c *gin.Context) {
+	userID, id, ok := h.savedFilterRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSavedFilter(c.Request.Context(), id, userID); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"saved_filter_id": id,
+		}).Warn("Failed to delete saved filter")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
+
+func (h *SavedFilterHandler) savedFilterRequestIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid saved filter ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, id, true
+}
@@ -0,0 +1,317 @@
+package api
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+)
+
+// Handlers depend on these interfaces rather than the concrete application
+// services so they can be exercised with the mocks in internal/mocks.
+
+type UserService interface {
+	CreateUser(ctx context.Context, name, email, password string) (*domain.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
+	ListUsers(ctx context.Context, filter domain.Params, pagination domain.Pagination) ([]domain.User, error)
+	CountUsers(ctx context.Context, filter domain.Params) (int64, error)
+	UpdateUser(ctx context.Context, user *domain.User) error
+	PatchUser(ctx context.Context, user *domain.User, fields []string) error
+	UpdateProfile(ctx context.Context, id uuid.UUID, name string, locale domain.Locale) (*domain.User, error)
+	DeleteUser(ctx context.Context, id uuid.UUID) error
+	CheckPassword(user *domain.User, password string) bool
+	CheckSignIn(user *domain.User) error
+	DeactivateUser(ctx context.Context, id uuid.UUID, suspendedUntil *time.Time) (*domain.User, error)
+	ReactivateUser(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	RecordLogin(ctx context.Context, user *domain.User) error
+	CheckPasswordAge(user *domain.User) error
+	ChangePassword(ctx context.Context, user *domain.User, newPassword string) error
+	ListStaleUsers(ctx context.Context, days int, pagination domain.Pagination) ([]domain.User, error)
+	GetAccount(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	GetDeletedAccountByEmail(ctx context.Context, email string) (*domain.User, error)
+	DeleteAccount(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	RestoreAccount(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	SuggestUsers(ctx context.Context, query string, limit int) ([]domain.Suggestion, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) (*domain.User, error)
+	OAuthLoginURL(provider domain.OAuthProvider) (string, string, error)
+	SignInWithOAuth(ctx context.Context, provider domain.OAuthProvider, code, state, expectedState string) (*domain.User, error)
+	EnrollMFA(ctx context.Context, id uuid.UUID) (*domain.MFAEnrollment, error)
+	ConfirmMFA(ctx context.Context, id uuid.UUID, code string) (*domain.User, error)
+	DisableMFA(ctx context.Context, id uuid.UUID, code string) (*domain.User, error)
+	CheckMFACode(user *domain.User, code string) error
+	CheckLoginThrottle(ctx context.Context, ip string) error
+	CheckLockout(user *domain.User) error
+	RecordLoginFailure(ctx context.Context, email string, user *domain.User, ip string) error
+	UnlockUser(ctx context.Context, id uuid.UUID) (*domain.User, error)
+}
+
+type TokenService interface {
+	IssueAccessToken(user *domain.User) (string, time.Time, error)
+	IssueMFAChallenge(user *domain.User) (string, time.Time, error)
+	RedeemMFAChallenge(token string) (uuid.UUID, error)
+	IssueRefreshToken(ctx context.Context, userID uuid.UUID) (*domain.IssuedRefreshToken, error)
+	RedeemRefreshToken(ctx context.Context, token string) (uuid.UUID, error)
+	RevokeRefreshToken(ctx context.Context, token string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+	RevokeAccessToken(ctx context.Context, token string) error
+	IsAccessTokenRevoked(ctx context.Context, token string) (bool, error)
+}
+
+type ProductService interface {
+	CreateProduct(ctx context.Context, name, description, category string, categoryID *uuid.UUID, sku, barcode string, price float64, stock int) (*domain.Product, error)
+	GetProductByID(ctx context.Context, id uuid.UUID) (*domain.Product, error)
+	GetProductBySKU(ctx context.Context, sku string) (*domain.Product, error)
+	GetProductByBarcode(ctx context.Context, barcode string) (*domain.Product, error)
+	ListProducts(ctx context.Context, filter domain.ProductParams, pagination domain.Pagination) ([]domain.Product, error)
+	CountProducts(ctx context.Context, filter domain.ProductParams) (int64, error)
+	GetProductFacets(ctx context.Context, filter domain.ProductParams, facets []string) (*domain.ProductFacets, error)
+	UpdateProduct(ctx context.Context, product *domain.Product) error
+	PatchProduct(ctx context.Context, product *domain.Product, fields []string) error
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	ArchiveProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error)
+	UnarchiveProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error)
+	ListLowStockProducts(ctx context.Context, pagination domain.Pagination) ([]domain.Product, error)
+	SuggestProducts(ctx context.Context, query string, limit int) ([]domain.Suggestion, error)
+	SearchProducts(ctx context.Context, query string, pagination domain.Pagination) ([]domain.ProductSearchHit, error)
+	CountProductSearch(ctx context.Context, query string) (int64, error)
+	RelatedProducts(ctx context.Context, id uuid.UUID, limit int) ([]domain.Product, error)
+	ImportProductCSV(ctx context.Context, r io.Reader, batchSize int, actorID uuid.UUID) (*domain.ImportResult, error)
+	StartProductImport(ctx context.Context, content []byte, requestedBy uuid.UUID) (*domain.ProductImport, error)
+	GetProductImport(ctx context.Context, id uuid.UUID) (*domain.ProductImport, error)
+}
+
+type AttachmentService interface {
+	UploadProductImage(ctx context.Context, productID, uploadedBy uuid.UUID, fileName string, content io.Reader) (*domain.Attachment, error)
+	ListProductImages(ctx context.Context, productID uuid.UUID) ([]domain.Attachment, error)
+	DeleteProductImage(ctx context.Context, productID, imageID uuid.UUID) error
+	OpenFile(ctx context.Context, key string, expiresAt time.Time, signature string) (*domain.Attachment, io.ReadCloser, error)
+}
+
+type ProjectService interface {
+	CreateProject(ctx context.Context, name, description string, status domain.ProjectStatus, startDate, endDate *time.Time, budget *float64, ownerID uuid.UUID, customFields domain.CustomFieldValues) (*domain.Project, error)
+	GetProjectByID(ctx context.Context, id uuid.UUID) (*domain.Project, error)
+	ListProjects(ctx context.Context, filter domain.ProjectParams, pagination domain.Pagination) ([]domain.Project, error)
+	CountProjects(ctx context.Context, filter domain.ProjectParams) (int64, error)
+	UpdateProject(ctx context.Context, project *domain.Project) error
+	PatchProject(ctx context.Context, project *domain.Project, fields []string) error
+	DeleteProject(ctx context.Context, id uuid.UUID) error
+	ArchiveProject(ctx context.Context, id uuid.UUID) (*domain.Project, error)
+	UnarchiveProject(ctx context.Context, id uuid.UUID) (*domain.Project, error)
+}
+
+type ProjectItemService interface {
+	CreateProjectItem(ctx context.Context, projectID uuid.UUID, name, description string, status domain.ProjectItemStatus, priority domain.ProjectItemPriority, estimatedHours, actualHours *float64, dueDate *time.Time, assignedTo, parentItemID *uuid.UUID, customFields domain.CustomFieldValues) (*domain.ProjectItem, error)
+	GetProjectItemByID(ctx context.Context, id uuid.UUID) (*domain.ProjectItem, error)
+	ListProjectItems(ctx context.Context, filter domain.ProjectItemParams, pagination domain.Pagination) ([]domain.ProjectItem, error)
+	CountProjectItems(ctx context.Context, filter domain.ProjectItemParams) (int64, error)
+	UpdateProjectItem(ctx context.Context, item *domain.ProjectItem) error
+	PatchProjectItem(ctx context.Context, item *domain.ProjectItem, fields []string) error
+	DeleteProjectItem(ctx context.Context, id uuid.UUID) error
+	GetProjectItemsByProjectID(ctx context.Context, projectID uuid.UUID) ([]domain.ProjectItem, error)
+	ListOverdueProjectItems(ctx context.Context, filter domain.ProjectItemParams, pagination domain.Pagination) ([]domain.ProjectItem, error)
+	ListUpcomingProjectItems(ctx context.Context, filter domain.ProjectItemParams, days int, pagination domain.Pagination) ([]domain.ProjectItem, error)
+	ListProjectItemAssignments(ctx context.Context, id uuid.UUID) ([]domain.ProjectItemAssignment, error)
+	TransitionProjectItem(ctx context.Context, id uuid.UUID, status domain.ProjectItemStatus, actorID uuid.UUID) (*domain.ProjectItem, error)
+	ListProjectItemTransitions(ctx context.Context, id uuid.UUID) ([]domain.ProjectItemTransition, error)
+	ListProjectItemChildren(ctx context.Context, id uuid.UUID) ([]domain.ProjectItem, error)
+	GetProjectBoard(ctx context.Context, projectID uuid.UUID, filter domain.ProjectItemParams, statuses []domain.ProjectItemStatus, pagination domain.Pagination) (*domain.ProjectBoard, error)
+	MoveProjectItemOnBoard(ctx context.Context, projectID, id uuid.UUID, move domain.BoardMove, actorID uuid.UUID) (*domain.ProjectItem, error)
+	GetHoursRollup(ctx context.Context, filter domain.ProjectItemParams) (*domain.HoursRollup, error)
+}
+
+type CommentService interface {
+	CreateComment(ctx context.Context, itemID, authorID uuid.UUID, body string) (*domain.Comment, error)
+	ListComments(ctx context.Context, itemID uuid.UUID, pagination domain.Pagination) ([]domain.Comment, error)
+	CountComments(ctx context.Context, itemID uuid.UUID) (int64, error)
+	DeleteComment(ctx context.Context, itemID, id, actorID uuid.UUID, admin bool) error
+}
+
+type CouponService interface {
+	CreateCoupon(ctx context.Context, coupon *domain.Coupon) (*domain.Coupon, error)
+	GetCouponByID(ctx context.Context, id uuid.UUID) (*domain.Coupon, error)
+	ListCoupons(ctx context.Context, filter domain.CouponParams, pagination domain.Pagination) ([]domain.Coupon, error)
+	UpdateCoupon(ctx context.Context, coupon *domain.Coupon) error
+	DeleteCoupon(ctx context.Context, id uuid.UUID) error
+	QuoteCart(ctx context.Context, code string, lines []domain.CartLine) (*domain.CartQuote, error)
+	RedeemCoupon(ctx context.Context, code string, lines []domain.CartLine) (*domain.CartQuote, error)
+}
+
+type StockAdjustmentService interface {
+	AdjustStock(ctx context.Context, productID, actorID uuid.UUID, warehouseID *uuid.UUID, quantity int, reason, note string) (*domain.StockAdjustment, error)
+	ListStockAdjustments(ctx context.Context, productID uuid.UUID, pagination domain.Pagination) ([]domain.StockAdjustment, error)
+	ListStockMovements(ctx context.Context, productID uuid.UUID, filter domain.StockMovementParams, pagination domain.Pagination) ([]domain.StockMovement, error)
+}
+
+type WarehouseService interface {
+	CreateWarehouse(ctx context.Context, warehouse *domain.Warehouse) (*domain.Warehouse, error)
+	GetWarehouseByID(ctx context.Context, id uuid.UUID) (*domain.Warehouse, error)
+	ListWarehouses(ctx context.Context, filter domain.WarehouseParams, pagination domain.Pagination) ([]domain.Warehouse, error)
+	UpdateWarehouse(ctx context.Context, warehouse *domain.Warehouse) error
+	DeleteWarehouse(ctx context.Context, id uuid.UUID) error
+	ListWarehouseStock(ctx context.Context, id uuid.UUID, pagination domain.Pagination) ([]domain.WarehouseStock, error)
+	TransferStock(ctx context.Context, productID, actorID uuid.UUID, from, to *uuid.UUID, quantity int, note string) (*domain.StockTransfer, error)
+}
+
+type PurchaseOrderService interface {
+	CreatePurchaseOrder(ctx context.Context, order *domain.PurchaseOrder) (*domain.PurchaseOrder, error)
+	GetPurchaseOrderByID(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error)
+	ListPurchaseOrders(ctx context.Context, filter domain.PurchaseOrderParams, pagination domain.Pagination) ([]domain.PurchaseOrder, error)
+	UpdatePurchaseOrder(ctx context.Context, order *domain.PurchaseOrder) (*domain.PurchaseOrder, error)
+	DeletePurchaseOrder(ctx context.Context, id uuid.UUID) error
+	SubmitPurchaseOrder(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error)
+	ReceivePurchaseOrder(ctx context.Context, id, actorID uuid.UUID) (*domain.PurchaseOrder, error)
+}
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	GetCategoryByID(ctx context.Context, id uuid.UUID) (*domain.Category, error)
+	ListCategories(ctx context.Context, filter domain.CategoryParams, pagination domain.Pagination) ([]domain.Category, error)
+	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID) error
+}
+
+type OrderService interface {
+	CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
+	GetOrder(ctx context.Context, id, actorID uuid.UUID, admin bool) (*domain.Order, error)
+	ListOrders(ctx context.Context, filter domain.OrderParams, pagination domain.Pagination) ([]domain.Order, error)
+	UpdateOrder(ctx context.Context, order *domain.Order, actorID uuid.UUID, admin bool) (*domain.Order, error)
+	DeleteOrder(ctx context.Context, id, actorID uuid.UUID, admin bool) error
+	UpdateOrderStatus(ctx context.Context, id uuid.UUID, status string, actorID uuid.UUID, admin bool) (*domain.Order, error)
+}
+
+type ExpenseService interface {
+	CreateExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error)
+	GetExpense(ctx context.Context, projectID, id uuid.UUID) (*domain.Expense, error)
+	ListExpenses(ctx context.Context, projectID uuid.UUID, filter domain.ExpenseParams, pagination domain.Pagination) ([]domain.Expense, error)
+	UpdateExpense(ctx context.Context, expense *domain.Expense) error
+	DeleteExpense(ctx context.Context, projectID, id uuid.UUID) error
+	GetProjectStats(ctx context.Context, projectID uuid.UUID) (*domain.ProjectBudgetStats, error)
+}
+
+type WatchService interface {
+	Watch(ctx context.Context, targetType string, targetID, userID uuid.UUID) (*domain.Watch, error)
+	Unwatch(ctx context.Context, targetType string, targetID, userID uuid.UUID) error
+	ListWatchers(ctx context.Context, targetType string, targetID uuid.UUID, pagination domain.Pagination) ([]domain.Watch, error)
+	ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination domain.Pagination) ([]domain.Notification, error)
+	MarkNotificationRead(ctx context.Context, userID, id uuid.UUID) error
+}
+
+type PushService interface {
+	RegisterDevice(ctx context.Context, userID uuid.UUID, platform domain.PushPlatform, token string) (*domain.Device, error)
+	ListDevices(ctx context.Context, userID uuid.UUID) ([]domain.Device, error)
+	UnregisterDevice(ctx context.Context, userID, id uuid.UUID) error
+	GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, preferences *domain.NotificationPreferences) (*domain.NotificationPreferences, error)
+}
+
+type CalendarService interface {
+	CreateCalendarFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeedToken, error)
+	GetCalendarFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error)
+	DeleteCalendarFeed(ctx context.Context, userID uuid.UUID) error
+	RenderCalendarFeed(ctx context.Context, token string) ([]byte, error)
+}
+
+type ProjectExportService interface {
+	ExportProject(ctx context.Context, projectID uuid.UUID, format string, requestedBy uuid.UUID) (*domain.ProjectExport, error)
+	GetProjectExport(ctx context.Context, id uuid.UUID) (*domain.ProjectExport, error)
+}
+
+type CustomFieldService interface {
+	CreateCustomField(ctx context.Context, definition *domain.CustomFieldDefinition, actorID uuid.UUID, admin bool) (*domain.CustomFieldDefinition, error)
+	GetCustomField(ctx context.Context, id uuid.UUID) (*domain.CustomFieldDefinition, error)
+	ListCustomFields(ctx context.Context, filter domain.CustomFieldParams) ([]domain.CustomFieldDefinition, error)
+	UpdateCustomField(ctx context.Context, definition *domain.CustomFieldDefinition, actorID uuid.UUID, admin bool) (*domain.CustomFieldDefinition, error)
+	DeleteCustomField(ctx context.Context, id, actorID uuid.UUID, admin bool) error
+}
+
+type SavedFilterService interface {
+	CreateSavedFilter(ctx context.Context, filter *domain.SavedFilter, actorID uuid.UUID) (*domain.SavedFilter, error)
+	GetSavedFilter(ctx context.Context, id, actorID uuid.UUID) (*domain.SavedFilter, error)
+	ListSavedFilters(ctx context.Context, params domain.SavedFilterParams, pagination domain.Pagination) ([]domain.SavedFilter, error)
+	UpdateSavedFilter(ctx context.Context, filter *domain.SavedFilter, actorID uuid.UUID) (*domain.SavedFilter, error)
+	DeleteSavedFilter(ctx context.Context, id, actorID uuid.UUID) error
+}
+
+type ReportService interface {
+	ItemsReport(ctx context.Context, params domain.ReportParams) ([]domain.ItemsReportRow, error)
+	StockReport(ctx context.Context, params domain.ReportParams) ([]domain.StockReportRow, error)
+	ProjectsReport(ctx context.Context, params domain.ReportParams) ([]domain.ProjectsReportRow, error)
+}
+
+type DashboardService interface {
+	GetDashboard(ctx context.Context, userID uuid.UUID) (*domain.Dashboard, error)
+}
+
+type ReportSubscriptionService interface {
+	CreateReportSubscription(ctx context.Context, subscription *domain.ReportSubscription, actorID uuid.UUID) (*domain.ReportSubscription, error)
+	GetReportSubscription(ctx context.Context, id, actorID uuid.UUID) (*domain.ReportSubscription, error)
+	ListReportSubscriptions(ctx context.Context, params domain.ReportSubscriptionParams, pagination domain.Pagination) ([]domain.ReportSubscription, error)
+	UpdateReportSubscription(ctx context.Context, subscription *domain.ReportSubscription, actorID uuid.UUID) (*domain.ReportSubscription, error)
+	DeleteReportSubscription(ctx context.Context, id, actorID uuid.UUID) error
+	ListReportDeliveries(ctx context.Context, id, actorID uuid.UUID, pagination domain.Pagination) ([]domain.ReportDelivery, error)
+}
+
+type RetentionService interface {
+	CreateRetentionRule(ctx context.Context, rule *domain.RetentionRule, actorID uuid.UUID) (*domain.RetentionRule, error)
+	GetRetentionRule(ctx context.Context, id uuid.UUID) (*domain.RetentionRule, error)
+	ListRetentionRules(ctx context.Context) ([]domain.RetentionRule, error)
+	UpdateRetentionRule(ctx context.Context, rule *domain.RetentionRule) (*domain.RetentionRule, error)
+	DeleteRetentionRule(ctx context.Context, id uuid.UUID) error
+	RunRetention(ctx context.Context, ruleID *uuid.UUID, dryRun bool, actorID uuid.UUID) ([]domain.RetentionRun, error)
+	ListRetentionRuns(ctx context.Context, params domain.RetentionRunParams, pagination domain.Pagination) ([]domain.RetentionRun, error)
+	RetentionMetrics(ctx context.Context) ([]domain.RetentionMetric, error)
+	ListArchivedRecords(ctx context.Context, params domain.ArchivedRecordParams, pagination domain.Pagination) ([]domain.ArchivedRecord, error)
+	GetArchivedRecord(ctx context.Context, entity domain.RetentionEntity, id uuid.UUID) (*domain.ArchivedRecord, error)
+	RestoreArchivedRecord(ctx context.Context, entity domain.RetentionEntity, id uuid.UUID, actorID uuid.UUID) error
+}
+
+type ExchangeRateService interface {
+	ListExchangeRates(ctx context.Context) ([]domain.ExchangeRate, error)
+	RefreshExchangeRates(ctx context.Context, actorID uuid.UUID) (*domain.ExchangeRateSync, error)
+	ListExchangeRateSyncs(ctx context.Context, pagination domain.Pagination) ([]domain.ExchangeRateSync, error)
+	ExchangeRateMetrics(ctx context.Context) (*domain.ExchangeRateMetrics, error)
+}
+
+type FaultService interface {
+	CreateFaultRule(ctx context.Context, rule *domain.FaultRule, actorID uuid.UUID) (*domain.FaultRule, error)
+	ListFaultRules(ctx context.Context) ([]domain.FaultRule, error)
+	DeleteFaultRule(ctx context.Context, id uuid.UUID) error
+	ClearFaultRules(ctx context.Context) (int, error)
+	Fault(method, route string) (domain.Fault, bool)
+}
+
+type UserExportService interface {
+	RequestUserExport(ctx context.Context, userID uuid.UUID) (*domain.UserExport, error)
+	GetUserExport(ctx context.Context, id, userID uuid.UUID) (*domain.UserExport, error)
+	ListUserExports(ctx context.Context, userID uuid.UUID, pagination domain.Pagination) ([]domain.UserExport, error)
+	DownloadUserExport(ctx context.Context, id, userID uuid.UUID) (*domain.UserExport, error)
+}
+
+type PolicyService interface {
+	PublishPolicyDocument(ctx context.Context, document *domain.PolicyDocument, actorID uuid.UUID) (*domain.PolicyDocument, error)
+	GetPolicyDocument(ctx context.Context, id uuid.UUID) (*domain.PolicyDocument, error)
+	ListPolicyDocuments(ctx context.Context, kind domain.PolicyKind, pagination domain.Pagination) ([]domain.PolicyDocument, error)
+	CurrentPolicyDocuments(ctx context.Context) ([]domain.PolicyDocument, error)
+	AcceptPolicyDocument(ctx context.Context, id, userID uuid.UUID, ipAddress string) (*domain.PolicyAcceptance, error)
+	GetPolicyStatus(ctx context.Context, userID uuid.UUID) (*domain.PolicyStatus, error)
+	ListPolicyAcceptances(ctx context.Context, documentID uuid.UUID, pagination domain.Pagination) ([]domain.PolicyAcceptance, error)
+	CheckPolicyAcceptance(ctx context.Context, userID uuid.UUID) ([]domain.PolicyDocument, error)
+}
+
+type EmailTemplateService interface {
+	ListEmailTemplates() []domain.EmailTemplateInfo
+	PreviewEmailTemplate(template domain.EmailTemplate, locale domain.Locale) (*domain.EmailContent, error)
+}
+
+type ChatService interface {
+	CreateChatConnector(ctx context.Context, connector *domain.ChatConnector, actorID uuid.UUID) (*domain.ChatConnector, error)
+	GetChatConnector(ctx context.Context, id uuid.UUID) (*domain.ChatConnector, error)
+	ListChatConnectors(ctx context.Context, params domain.ChatConnectorParams, pagination domain.Pagination) ([]domain.ChatConnector, error)
+	UpdateChatConnector(ctx context.Context, connector *domain.ChatConnector) (*domain.ChatConnector, error)
+	DeleteChatConnector(ctx context.Context, id uuid.UUID) error
+	TestChatConnector(ctx context.Context, id uuid.UUID) (*domain.ChatTestResult, error)
+}
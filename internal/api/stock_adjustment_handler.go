@@ -0,0 +1,264 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type StockAdjustmentHandler struct {
+	service StockAdjustmentService
+	logger  *logrus.Logger
+}
+
+func NewStockAdjustmentHandler(service StockAdjustmentService) *StockAdjustmentHandler {
+	return &StockAdjustmentHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *StockAdjustmentHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering stock adjustment routes")
+	write := RequirePermission(domain.ScopeActionWrite, "products")
+	r.POST(ProductStockAdjustments, write, h.CreateStockAdjustment)
+	r.GET(ProductStockAdjustments, h.ListStockAdjustments)
+	r.GET(ProductStockMovements, h.ListStockMovements)
+}
+
+type createStockAdjustmentRequest struct {
+	Quantity    int        `json:"quantity" binding:"required"`
+	Reason      string     `json:"reason" binding:"required,oneof=damage recount sale return"`
+	Note        string     `json:"note"`
+	WarehouseID *uuid.UUID `json:"warehouse_id" binding:"omitempty,exists=warehouse"`
+}
+
+// listStockMovementsQuery is the query string of ListStockMovements.
+type listStockMovementsQuery struct {
+	pageQuery
+	Reason      string     `form:"reason" binding:"omitempty,oneof=damage recount sale return purchase import transfer"`
+	WarehouseID *uuid.UUID `form:"warehouse_id"`
+}
+
+// @Summary Adjust product stock
+// @Description Apply a signed stock change with a reason code (admin only). damage and sale must be negative, return positive, recount either. With warehouse_id the change is applied to that location's level as well as the product total. The authenticated user is recorded as the actor.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param request body createStockAdjustmentRequest true "Stock adjustment"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.StockAdjustment
+// @Header 201 {string} Location "URL of the product's stock adjustments"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Product is archived or stock would go negative at the product or warehouse"
+// @Router /v1/products/{id}/stock-adjustments [post]
+func (h *StockAdjustmentHandler) CreateStockAdjustment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid product ID format for stock adjustment")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	actorID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"product_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Stock adjustment without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"product_id": id,
+		"actor_id":   actorID,
+		"ip":         c.ClientIP(),
+	}).Info("Creating stock adjustment")
+
+	var req createStockAdjustmentRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Invalid request body for stock adjustment")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	adjustment, err := h.service.AdjustStock(c.Request.Context(), id, actorID, req.WarehouseID, req.Quantity, req.Reason, req.Note)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+			"quantity":   req.Quantity,
+			"reason":     req.Reason,
+			"client_ip":  c.ClientIP(),
+		}).Error("Failed to adjust product stock")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"adjustment_id": adjustment.ID,
+		"product_id":    id,
+		"stock_after":   adjustment.StockAfter,
+	}).Info("Stock adjustment created successfully")
+
+	respondCreated(c, adjustment, ProductStockAdjustments, adjustment.ProductID.String())
+}
+
+// @Summary List stock adjustments
+// @Description Get the stock adjustment history of a product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. created_at desc; fields: quantity, reason, created_at (default: created_at desc)"
+// @Success 200 {array} domain.StockAdjustment
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/products/{id}/stock-adjustments [get]
+func (h *StockAdjustmentHandler) ListStockAdjustments(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid product ID format for stock adjustment history")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	var page pageQuery
+	if err := bindQuery(c, &page); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	sort, err := sortQuery(c, domain.StockAdjustmentSort, "created_at desc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := page.pagination(sort)
+
+	h.logger.WithFields(logrus.Fields{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"product_id": id,
+		"limit":      pagination.Limit,
+		"offset":     pagination.Offset,
+		"ip":         c.ClientIP(),
+	}).Info("Listing stock adjustments")
+
+	adjustments, err := h.service.ListStockAdjustments(c.Request.Context(), id, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Failed to list stock adjustments")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"product_id": id,
+		"count":      len(adjustments),
+	}).Info("Stock adjustments listed successfully")
+
+	c.JSON(StatusOK, adjustments)
+}
+
+// @Summary List stock movements
+// @Description Get every stock movement of a product in one history: stock adjustments, which change the product total, and transfers between warehouses, reported with reason transfer, which do not. quantity is the signed change to the total for adjustments and the quantity moved for transfers; stock_before and stock_after are only set for adjustments.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param reason query string false "Filter by reason (damage, recount, sale, return, purchase, import, transfer)"
+// @Param warehouse_id query string false "Filter by movements into or out of a warehouse"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. created_at desc; fields: quantity, reason, created_at (default: created_at desc)"
+// @Success 200 {array} domain.StockMovement
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/products/{id}/stock-movements [get]
+func (h *StockAdjustmentHandler) ListStockMovements(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid product ID format for stock movement history")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	var query listStockMovementsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	sort, err := sortQuery(c, domain.StockMovementSort, "created_at desc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := query.pagination(sort)
+	filter := domain.StockMovementParams{
+		Reason:      query.Reason,
+		WarehouseID: query.WarehouseID,
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":              c.Request.Method,
+		"path":                c.Request.URL.Path,
+		"product_id":          id,
+		"filter_reason":       filter.Reason,
+		"filter_warehouse_id": filter.WarehouseID,
+		"limit":               pagination.Limit,
+		"offset":              pagination.Offset,
+		"ip":                  c.ClientIP(),
+	}).Info("Listing stock movements")
+
+	movements, err := h.service.ListStockMovements(c.Request.Context(), id, filter, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+			"client_ip":  c.ClientIP(),
+		}).Warn("Failed to list stock movements")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"product_id": id,
+		"count":      len(movements),
+	}).Info("Stock movements listed successfully")
+
+	c.JSON(StatusOK, movements)
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type UserExportHandler struct {
+	service UserExportService
+	logger  *logrus.Logger
+}
+
+func NewUserExportHandler(service UserExportService) *UserExportHandler {
+	return &UserExportHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *UserExportHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering user export routes")
+	r.POST(UserExportEndpoint, h.RequestUserExport)
+	r.GET(UserExportsEndpoint, h.ListUserExports)
+	r.GET(UserExportByID, h.GetUserExport)
+	r.GET(UserExportDownload, h.DownloadUserExport)
+}
+
+// @Summary Export my data
+// @Description Assemble every record tied to the authenticated user (profile, owned projects, assigned items, assignment history, expenses, stock adjustments, purchase orders, watches, notifications, push devices, saved filters and report subscriptions) into a zip archive of JSON files. The archive is built in the background: poll /v1/users/me/exports/{id} and download it once ready, before it expires.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} domain.UserExport
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "An export is already in progress"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/export [post]
+func (h *UserExportHandler) RequestUserExport(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": userID,
+		"ip":      c.ClientIP(),
+	}).Info("Requesting user data export")
+
+	export, err := h.service.RequestUserExport(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Warn("Failed to request user data export")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusAccepted, export)
+}
+
+// @Summary List my data exports
+// @Description List the authenticated user's data exports, newest first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.UserExport
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/exports [get]
+func (h *UserExportHandler) ListUserExports(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var page pageQuery
+	if err := bindQuery(c, &page); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	exports, err := h.service.ListUserExports(c.Request.Context(), userID, page.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list user exports")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, exports)
+}
+
+// @Summary Get my data export
+// @Description Get the status of a data export requested by the authenticated user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Export ID"
+// @Success 200 {object} domain.UserExport
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/exports/{id} [get]
+func (h *UserExportHandler) GetUserExport(c *gin.Context) {
+	userID, id, ok := h.requestIDs(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.service.GetUserExport(c.Request.Context(), id, userID)
+	if err != nil {
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusOK, export)
+}
+
+// @Summary Download my data export
+// @Description Download a finished data export as a zip archive
+// @Tags users
+// @Accept json
+// @Produce application/zip
+// @Security BearerAuth
+// @Param id path string true "Export ID"
+// @Success 200 {file} file "Zip archive"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Export is still pending or failed"
+// @Failure 410 {object} map[string]interface{} "Export has expired"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/me/exports/{id}/download [get]
+func (h *UserExportHandler) DownloadUserExport(c *gin.Context) {
+	userID, id, ok := h.requestIDs(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.service.DownloadUserExport(c.Request.Context(), id, userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"export_id": id,
+			"user_id":   userID,
+		}).Warn("User export not available for download")
+		if export != nil {
+			abortWithDetails(c, StatusInternalServerError, err, gin.H{"status": export.Status})
+			return
+		}
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="user-data-`+export.CreatedAt.Format("20060102")+`.zip"`)
+	c.Data(StatusOK, "application/zip", export.Content)
+}
+
+func (h *UserExportHandler) requestIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid user export ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, id, true
+}
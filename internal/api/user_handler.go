@@ -1,9 +1,10 @@
 package api
 
 import (
-	"strconv"
+	"context"
+
+	"time"
 
-	"github.com/edumes/golang-api-rest/internal/application"
 	"github.com/edumes/golang-api-rest/internal/domain"
 	"github.com/edumes/golang-api-rest/internal/infrastructure"
 	"github.com/gin-gonic/gin"
@@ -12,11 +13,11 @@ import (
 )
 
 type UserHandler struct {
-	service *application.UserService
+	service UserService
 	logger  *logrus.Logger
 }
 
-func NewUserHandler(service *application.UserService) *UserHandler {
+func NewUserHandler(service UserService) *UserHandler {
 	return &UserHandler{
 		service: service,
 		logger:  infrastructure.GetColoredLogger(),
@@ -25,11 +26,27 @@ func NewUserHandler(service *application.UserService) *UserHandler {
 
 func (h *UserHandler) RegisterRoutes(r *gin.RouterGroup) {
 	h.logger.Info("Registering user routes")
-	r.POST(UsersEndpoint, h.CreateUser)
+	write := RequirePermission(domain.ScopeActionWrite, "users")
+	r.POST(UsersEndpoint, write, h.CreateUser)
 	r.GET(UsersEndpoint, h.ListUsers)
+	r.GET(UsersSuggest, h.SuggestUsers)
 	r.GET(UserByID, h.GetUser)
-	r.PUT(UserByID, h.UpdateUser)
-	r.DELETE(UserByID, h.DeleteUser)
+	r.PUT(UserByID, write, h.UpdateUser)
+	r.PATCH(UserByID, write, h.PatchUser)
+	r.DELETE(UserByID, write, h.DeleteUser)
+	r.GET(AdminUsers, RequireRole(domain.RoleAdmin), h.ListUsersForAdmin)
+	r.GET(AdminStaleUsers, RequireRole(domain.RoleAdmin), h.ListStaleUsers)
+	r.POST(AdminUserDeactivate, RequireRole(domain.RoleAdmin), h.DeactivateUser)
+	r.POST(AdminUserReactivate, RequireRole(domain.RoleAdmin), h.ReactivateUser)
+	r.POST(AdminUserUnlock, RequireRole(domain.RoleAdmin), h.UnlockUser)
+	r.GET(UserMe, h.GetProfile)
+	r.PUT(UserMe, h.UpdateProfile)
+	r.DELETE(UserMe, h.DeleteOwnAccount)
+	r.POST(UserMFAEnroll, h.EnrollMFA)
+	r.POST(UserMFAConfirm, h.ConfirmMFA)
+	r.POST(UserMFADisable, h.DisableMFA)
+	r.DELETE(AdminUserByID, RequireRole(domain.RoleAdmin), h.DeleteAccount)
+	r.POST(AdminUserRestore, RequireRole(domain.RoleAdmin), h.RestoreAccount)
 }
 
 type createUserRequest struct {
@@ -38,16 +55,29 @@ type createUserRequest struct {
 	Password string `json:"password" binding:"required,min=6"`
 }
 
+// updateProfileRequest lists what users may change about themselves. The
+// email is the sign-in identity and is not among them.
+type updateProfileRequest struct {
+	Name   string        `json:"name" binding:"required"`
+	Locale domain.Locale `json:"locale" binding:"omitempty,enum"`
+}
+
 // @Summary Create user
-// @Description Create a new user
+// @Description Create a new user (admin only)
 // @Tags users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body createUserRequest true "User data"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Param Idempotency-Key header string false "Key that makes retries of this request replay its response instead of creating again"
 // @Success 201 {object} domain.User
+// @Header 201 {string} Location "URL of the created user"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Email already registered, or a request with the same Idempotency-Key still running"
+// @Failure 422 {object} map[string]interface{} "Idempotency-Key already used for a different request"
 // @Router /v1/users [post]
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	h.logger.WithFields(logrus.Fields{
@@ -57,12 +87,12 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	}).Info("Creating new user")
 
 	var req createUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 			"ip":    c.ClientIP(),
 		}).Warn("Invalid request body for user creation")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
@@ -77,7 +107,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 			"error": err.Error(),
 			"email": req.Email,
 		}).Error("Failed to create user")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
@@ -86,21 +116,33 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		"email":   user.Email,
 	}).Info("User created successfully")
 
-	c.JSON(StatusCreated, user)
+	respondCreated(c, user, UserByID, user.ID.String())
+}
+
+type listUsersQuery struct {
+	pageQuery
+	formatQuery
+	Name   string `form:"name"`
+	Email  string `form:"email"`
+	Active bool   `form:"active"`
 }
 
 // @Summary List users
 // @Description Get a list of users with optional filtering and pagination
 // @Tags users
 // @Accept json
-// @Produce json
+// @Produce json,application/x-ndjson,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Security BearerAuth
 // @Param name query string false "Filter by name"
 // @Param email query string false "Filter by email"
-// @Param limit query int false "Number of items per page (default: 20)"
+// @Param active query bool false "Only return users that can currently sign in, e.g. for assignee pickers"
+// @Param format query string false "json (default) for one page; ndjson, csv or xlsx to download every matching row as newline-delimited JSON, CSV or an Excel sheet in id order, ignoring limit, offset and sort"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
 // @Param offset query int false "Number of items to skip (default: 0)"
-// @Param sort query string false "Sort order (default: created_at desc)"
-// @Success 200 {array} domain.User
+// @Param sort query string false "Field and direction, e.g. created_at desc; fields: name, email, role, created_at, updated_at, last_login_at (default: created_at desc)"
+// @Param saved_filter query string false "Apply the query parameters and sort of a saved filter; parameters given here take precedence"
+// @Success 200 {object} pageResponse[domain.User]
+// @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /v1/users [get]
@@ -111,23 +153,40 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 		"ip":     c.ClientIP(),
 	}).Info("Listing users")
 
+	var query listUsersQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
 	filter := domain.Params{
-		Name:  c.Query("name"),
-		Email: c.Query("email"),
+		Name:       query.Name,
+		Email:      query.Email,
+		ActiveOnly: query.Active,
+	}
+	if query.streaming() {
+		streamList(c, h.logger, query.Format, listStream[domain.User]{
+			name:    "users",
+			columns: domain.UserExportColumns,
+			list: func(ctx context.Context, pagination domain.Pagination) ([]domain.User, error) {
+				return h.service.ListUsers(ctx, filter, pagination)
+			},
+			id: func(u domain.User) uuid.UUID { return u.ID },
+		})
+		return
 	}
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	pagination := domain.Pagination{
-		Limit:  limit,
-		Offset: offset,
-		Sort:   c.DefaultQuery("sort", "created_at desc"),
+	sort, err := sortQuery(c, domain.UserSort, "created_at desc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
 	}
+	pagination := query.pagination(sort)
 
 	h.logger.WithFields(logrus.Fields{
 		"filter_name":  filter.Name,
 		"filter_email": filter.Email,
-		"limit":        limit,
-		"offset":       offset,
+		"limit":        pagination.Limit,
+		"offset":       pagination.Offset,
 		"sort":         pagination.Sort,
 	}).Debug("List users with filters and pagination")
 
@@ -136,15 +195,59 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 		h.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Error("Failed to list users")
-		c.JSON(StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	total, err := h.service.CountUsers(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count users")
+		abortWithError(c, StatusInternalServerError, err)
 		return
 	}
 
 	h.logger.WithFields(logrus.Fields{
 		"count": len(users),
+		"total": total,
 	}).Info("Users listed successfully")
 
-	c.JSON(StatusOK, users)
+	respondPage(c, users, total, pagination)
+}
+
+// @Summary Suggest users
+// @Description Typeahead for assignment pickers: users who can sign in and whose name contains q or whose email starts with it, as id and label, best matches first. Answers may be cached for up to 30 seconds.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Text typed so far"
+// @Param limit query int false "Number of suggestions (default: 10, at most 25)"
+// @Success 200 {array} domain.Suggestion
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/users/suggest [get]
+func (h *UserHandler) SuggestUsers(c *gin.Context) {
+	var query suggestQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	suggestions, err := h.service.SuggestUsers(c.Request.Context(), query.Q, query.Limit)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"query": query.Q,
+		}).Error("Failed to suggest users")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Cache-Control", suggestionCacheControl)
+	c.JSON(StatusOK, suggestions)
 }
 
 // @Summary Get user by ID
@@ -154,7 +257,9 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "User ID"
+// @Param If-None-Match header string false "ETag of the copy the client already has"
 // @Success 200 {object} domain.User
+// @Success 304 "Not Modified"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 404 {object} map[string]interface{} "Not Found"
@@ -167,7 +272,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid user ID format")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -185,7 +290,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 			"user_id":   id,
 			"client_ip": c.ClientIP(),
 		}).Warn("User not found")
-		c.JSON(StatusNotFound, gin.H{"error": err.Error()})
+		abortWithError(c, StatusNotFound, err)
 		return
 	}
 
@@ -194,20 +299,24 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		"email":   user.Email,
 	}).Info("User retrieved successfully")
 
+	setETag(c, user.UpdatedAt)
 	c.JSON(StatusOK, user)
 }
 
 // @Summary Update user
-// @Description Update an existing user
+// @Description Update an existing user (admin only)
 // @Tags users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "User ID"
 // @Param user body domain.User true "User data"
+// @Param If-Match header string false "Version the user must still be at, as an entity tag"
 // @Success 200 {object} domain.User
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Changed since the given version"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /v1/users/{id} [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
@@ -218,7 +327,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid user ID format for update")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -230,24 +339,33 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}).Info("Updating user")
 
 	var user domain.User
-	if err := c.ShouldBindJSON(&user); err != nil {
+	if err := bindJSON(c, &user); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error":     err.Error(),
 			"user_id":   id,
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid request body for user update")
-		c.JSON(StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, StatusBadRequest, err)
 		return
 	}
 
 	user.ID = id
+	if user.Version, err = ifMatchVersion(c, user.Version); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid If-Match for user update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
 	if err := h.service.UpdateUser(c.Request.Context(), &user); err != nil {
 		h.logger.WithFields(logrus.Fields{
 			"error":     err.Error(),
 			"user_id":   id,
 			"client_ip": c.ClientIP(),
 		}).Error("Failed to update user")
-		c.JSON(StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, StatusInternalServerError, err)
 		return
 	}
 
@@ -259,8 +377,94 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	c.JSON(StatusOK, user)
 }
 
+// @Summary Patch user
+// @Description Change only the fields named in a JSON Merge Patch (RFC 7396) of a user (admin only); every other field keeps its value. Name and email cannot be cleared.
+// @Tags users
+// @Accept json,application/merge-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param patch body object true "JSON Merge Patch; fields: name, email, role, locale"
+// @Param If-Match header string false "Version the user must still be at, as an entity tag"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Changed since the given version"
+// @Failure 415 {object} map[string]interface{} "Unsupported Media Type"
+// @Router /v1/users/{id} [patch]
+func (h *UserHandler) PatchUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid user ID format for patch")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": id,
+		"ip":      c.ClientIP(),
+	}).Info("Patching user")
+
+	current, err := h.service.GetUserByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("User not found for patch")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	user, fields, err := bindMergePatch(c, domain.UserPatch, current)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid merge patch for user")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if user.Version, err = ifMatchVersion(c, current.Version); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid If-Match for user patch")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.PatchUser(c.Request.Context(), user, fields); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Error("Failed to patch user")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": id,
+		"fields":  fields,
+	}).Info("User patched successfully")
+
+	c.JSON(StatusOK, user)
+}
+
 // @Summary Delete user
-// @Description Delete a user by ID
+// @Description Delete a user by ID (admin only)
 // @Tags users
 // @Accept json
 // @Produce json
@@ -269,6 +473,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /v1/users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
@@ -279,7 +484,7 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 			"param_id":  c.Param("id"),
 			"client_ip": c.ClientIP(),
 		}).Warn("Invalid user ID format for deletion")
-		c.JSON(StatusBadRequest, gin.H{"error": "invalid id"})
+		abortWithMessage(c, StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -296,7 +501,7 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 			"user_id":   id,
 			"client_ip": c.ClientIP(),
 		}).Error("Failed to delete user")
-		c.JSON(StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, StatusInternalServerError, err)
 		return
 	}
 
@@ -306,3 +511,639 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 	c.Status(StatusNoContent)
 }
+
+type deactivateUserRequest struct {
+	SuspendedUntil *time.Time `json:"suspended_until"`
+}
+
+// @Summary Deactivate user
+// @Description Deactivate an account (admin only). With suspended_until the account is only suspended until that time. Login is refused and existing tokens stop working immediately.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body deactivateUserRequest false "Optional suspension end"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/users/{id}/deactivate [post]
+func (h *UserHandler) DeactivateUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid user ID format for deactivation")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": id,
+		"ip":      c.ClientIP(),
+	}).Info("Deactivating user")
+
+	if actorID, ok := currentUserID(c); ok && actorID == id {
+		h.logger.WithFields(logrus.Fields{
+			"user_id": id,
+		}).Warn("Admin attempted to deactivate own account")
+		abortWithMessage(c, StatusBadRequest, "cannot deactivate your own account")
+		return
+	}
+
+	var req deactivateUserRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			h.logger.WithFields(logrus.Fields{
+				"error":     err.Error(),
+				"user_id":   id,
+				"client_ip": c.ClientIP(),
+			}).Warn("Invalid request body for user deactivation")
+			abortWithError(c, StatusBadRequest, err)
+			return
+		}
+	}
+
+	user, err := h.service.DeactivateUser(c.Request.Context(), id, req.SuspendedUntil)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to deactivate user")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id":         user.ID,
+		"suspended_until": user.SuspendedUntil,
+	}).Info("User deactivated successfully")
+
+	c.JSON(StatusOK, user)
+}
+
+// @Summary Reactivate user
+// @Description Reactivate a deactivated or suspended account (admin only)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/users/{id}/reactivate [post]
+func (h *UserHandler) ReactivateUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid user ID format for reactivation")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": id,
+		"ip":      c.ClientIP(),
+	}).Info("Reactivating user")
+
+	user, err := h.service.ReactivateUser(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to reactivate user")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+	}).Info("User reactivated successfully")
+
+	c.JSON(StatusOK, user)
+}
+
+// @Summary Unlock user
+// @Description End the lockout that failed logins put on an account before it expires, and clear its failed logins (admin only)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/users/{id}/unlock [post]
+func (h *UserHandler) UnlockUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid user ID format for unlock")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	user, err := h.service.UnlockUser(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to unlock user")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+	}).Info("User unlocked successfully")
+
+	c.JSON(StatusOK, user)
+}
+
+type staleUsersQuery struct {
+	pageQuery
+	Days int `form:"days,default=90"`
+}
+
+// @Summary List stale users
+// @Description List accounts that have not logged in for at least days days, for access reviews (admin only). Accounts that never logged in count from their creation date.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Inactivity window in days, 1 to 3650 (default: 90)"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.User
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /v1/admin/users/stale [get]
+func (h *UserHandler) ListStaleUsers(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Listing stale users")
+
+	var query staleUsersQuery
+	if err := bindQuery(c, &query); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid stale users query")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	days := query.Days
+	pagination := query.pagination("last_login_at asc nulls first, created_at asc")
+
+	users, err := h.service.ListStaleUsers(c.Request.Context(), days, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"days":  days,
+		}).Warn("Failed to list stale users")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"days":  days,
+		"count": len(users),
+	}).Info("Stale users listed successfully")
+
+	c.JSON(StatusOK, users)
+}
+
+type adminUsersQuery struct {
+	pageQuery
+	Name           string     `form:"name"`
+	Email          string     `form:"email"`
+	Role           string     `form:"role" binding:"omitempty,oneof=admin user"`
+	Status         string     `form:"status" binding:"omitempty,oneof=active suspended deactivated deleted"`
+	IncludeDeleted bool       `form:"include_deleted"`
+	CreatedFrom    *time.Time `form:"created_from"`
+	CreatedTo      *time.Time `form:"created_to,end_of_day"`
+	LastLoginFrom  *time.Time `form:"last_login_from"`
+	LastLoginTo    *time.Time `form:"last_login_to,end_of_day"`
+}
+
+// @Summary List users (admin)
+// @Description List users for administration (admin only). Unlike /v1/users it can include soft-deleted accounts and filter by role, account status and creation or last-login ranges. Only whitelisted columns can be sorted by.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name query string false "Filter by name (partial match)"
+// @Param email query string false "Filter by email"
+// @Param role query string false "Filter by role (admin, user)"
+// @Param status query string false "Filter by account status (active, suspended, deactivated, deleted)"
+// @Param include_deleted query bool false "Include soft-deleted users (default: false)"
+// @Param created_from query string false "Created on or after (YYYY-MM-DD or RFC3339)"
+// @Param created_to query string false "Created on or before (YYYY-MM-DD or RFC3339)"
+// @Param last_login_from query string false "Last login on or after (YYYY-MM-DD or RFC3339)"
+// @Param last_login_to query string false "Last login on or before (YYYY-MM-DD or RFC3339)"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Column and direction, e.g. last_login_at desc; columns: name, email, role, created_at, updated_at, last_login_at, login_count, deleted_at (default: created_at desc)"
+// @Success 200 {object} pageResponse[domain.User]
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/admin/users [get]
+func (h *UserHandler) ListUsersForAdmin(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Listing users for admin")
+
+	var query adminUsersQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	filter := domain.Params{
+		Name:           query.Name,
+		Email:          query.Email,
+		Role:           query.Role,
+		Status:         query.Status,
+		IncludeDeleted: query.IncludeDeleted,
+		CreatedAtFrom:  query.CreatedFrom,
+		CreatedAtTo:    query.CreatedTo,
+		LastLoginFrom:  query.LastLoginFrom,
+		LastLoginTo:    query.LastLoginTo,
+	}
+
+	sort, err := sortQuery(c, domain.AdminUserSort, "created_at desc")
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"sort":  c.Query("sort"),
+		}).Warn("Invalid sort parameter")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	pagination := query.pagination(sort)
+
+	h.logger.WithFields(logrus.Fields{
+		"filter_role":     filter.Role,
+		"filter_status":   filter.Status,
+		"include_deleted": filter.IncludeDeleted,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
+		"sort":            sort,
+	}).Debug("List users for admin with filters and pagination")
+
+	users, err := h.service.ListUsers(c.Request.Context(), filter, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list users for admin")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	total, err := h.service.CountUsers(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count users for admin")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"count": len(users),
+		"total": total,
+	}).Info("Users listed successfully for admin")
+
+	respondPage(c, users, total, pagination)
+}
+
+// @Summary Get my profile
+// @Description Get the authenticated user's account
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param If-None-Match header string false "ETag of the copy the client already has"
+// @Success 200 {object} domain.User
+// @Success 304 "Not Modified"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/users/me [get]
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	user, err := h.service.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   userID,
+			"client_ip": c.ClientIP(),
+		}).Warn("Profile not found")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	setETag(c, user.UpdatedAt)
+	c.JSON(StatusOK, user)
+}
+
+// @Summary Update my profile
+// @Description Change the authenticated user's name and the locale of the emails they get; an empty locale keeps the current one. Email, role, password and status cannot be changed here.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body updateProfileRequest true "Profile"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/users/me [put]
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req updateProfileRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   userID,
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid request body for profile update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.service.UpdateProfile(c.Request.Context(), userID, req.Name, req.Locale)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   userID,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to update profile")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+	}).Info("Profile updated successfully")
+
+	c.JSON(StatusOK, user)
+}
+
+// mfaCodeRequest carries the current code of the user's authenticator app.
+type mfaCodeRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// @Summary Enroll in two-factor authentication
+// @Description Generate a new TOTP secret for the authenticated user, replacing any unconfirmed one. Add it to an authenticator app, typically by turning otpauth_url into a QR code, then send a code from the app to /v1/users/me/mfa/confirm. Codes are not asked for at login until then.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.MFAEnrollment
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Two-factor authentication already enabled"
+// @Router /v1/users/me/mfa/enroll [post]
+func (h *UserHandler) EnrollMFA(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	enrollment, err := h.service.EnrollMFA(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   userID,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to enroll in MFA")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	c.JSON(StatusOK, enrollment)
+}
+
+// @Summary Confirm two-factor authentication
+// @Description Turn two-factor authentication on with a code from the authenticator app the enrolled secret was added to. From then on, login returns a challenge to be completed at /v1/auth/mfa/verify.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body mfaCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized or wrong code"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Not enrolled, or already enabled"
+// @Router /v1/users/me/mfa/confirm [post]
+func (h *UserHandler) ConfirmMFA(c *gin.Context) {
+	h.updateMFA(c, h.service.ConfirmMFA)
+}
+
+// @Summary Disable two-factor authentication
+// @Description Turn two-factor authentication off and forget the secret, with a current code from the authenticator app.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body mfaCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized or wrong code"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Two-factor authentication not enabled"
+// @Router /v1/users/me/mfa/disable [post]
+func (h *UserHandler) DisableMFA(c *gin.Context) {
+	h.updateMFA(c, h.service.DisableMFA)
+}
+
+// updateMFA runs update, ConfirmMFA or DisableMFA of the service, for the
+// authenticated user with the code in the request.
+func (h *UserHandler) updateMFA(c *gin.Context, update func(context.Context, uuid.UUID, string) (*domain.User, error)) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req mfaCodeRequest
+	if err := bindJSON(c, &req); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	user, err := update(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   userID,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to update MFA")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id":     user.ID,
+		"mfa_enabled": user.MFAEnabled,
+	}).Info("MFA updated successfully")
+
+	c.JSON(StatusOK, user)
+}
+
+// @Summary Delete my account
+// @Description Delete the authenticated user's account. Sign-in and every issued token stop working at once. The account can be restored through /v1/auth/restore until erase_after; after that its name, email and password are anonymized and its watches, notifications, saved filters, report subscriptions and data exports are deleted. Projects, items, assignments and expenses stay and point at the anonymized account.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.User
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/users/me [delete]
+func (h *UserHandler) DeleteOwnAccount(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		abortWithMessage(c, StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	h.deleteAccount(c, userID)
+}
+
+// @Summary Delete account
+// @Description Delete an account on its owner's behalf, with the same grace period and erasure as /v1/users/me (admin only)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/admin/users/{id} [delete]
+func (h *UserHandler) DeleteAccount(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid user ID format for account deletion")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.deleteAccount(c, id)
+}
+
+func (h *UserHandler) deleteAccount(c *gin.Context, id uuid.UUID) {
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": id,
+		"ip":      c.ClientIP(),
+	}).Info("Deleting account")
+
+	user, err := h.service.DeleteAccount(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to delete account")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id":     user.ID,
+		"erase_after": user.EraseAfter,
+	}).Info("Account deleted successfully")
+
+	c.JSON(StatusOK, user)
+}
+
+// @Summary Restore account
+// @Description Undo an account deletion before its grace period ends (admin only)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Failure 409 {object} map[string]interface{} "Account is not deleted, already erased or past its grace period"
+// @Router /v1/admin/users/{id}/restore [post]
+func (h *UserHandler) RestoreAccount(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid user ID format for account restore")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	user, err := h.service.RestoreAccount(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   id,
+			"client_ip": c.ClientIP(),
+		}).Warn("Failed to restore account")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	c.JSON(StatusOK, user)
+}
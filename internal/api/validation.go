@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	"github.com/google/uuid"
+)
+
+// enumValue is implemented by the domain enums, such as domain.ProjectStatus.
+type enumValue interface {
+	Validate() error
+}
+
+// embeddedFieldName stands for embedded structs, such as pageQuery, in field
+// paths; validateRequest drops it so their fields are named as if declared
+// directly.
+const embeddedFieldName = "~"
+
+// existenceCheck returns an error when no record has the id.
+type existenceCheck func(ctx context.Context, id uuid.UUID) error
+
+var (
+	validatorOnce sync.Once
+	// requestValidator validates every bound request body. It reads the
+	// "binding" tags gin uses, plus the rules added by registerValidators.
+	requestValidator  *validator.Validate
+	requestTranslator ut.Translator
+
+	existenceMu     sync.RWMutex
+	existenceChecks = map[string]existenceCheck{}
+)
+
+// registerValidators sets up requestValidator and takes validation away from
+// gin's binding, so bodies are validated once, by bindJSON, with the request
+// context. On top of the built-in rules it adds:
+//
+//   - "enum", which accepts a field only when its Validate method does;
+//   - "exists=<kind>", which looks the UUID up with the check registered for
+//     kind by registerExistenceCheck;
+//   - "maxpage", which caps a page size at maxPageSize.
+func registerValidators() {
+	validatorOnce.Do(func() {
+		v := validator.New()
+		v.SetTagName("binding")
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			if field.Anonymous {
+				return embeddedFieldName
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" {
+				name, _, _ = strings.Cut(field.Tag.Get("form"), ",")
+			}
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+
+		_ = v.RegisterValidation("enum", func(fl validator.FieldLevel) bool {
+			value, ok := fl.Field().Interface().(enumValue)
+			return !ok || value.Validate() == nil
+		})
+		_ = v.RegisterValidationCtx("exists", func(ctx context.Context, fl validator.FieldLevel) bool {
+			id, ok := fl.Field().Interface().(uuid.UUID)
+			if !ok || id == uuid.Nil {
+				return true
+			}
+			existenceMu.RLock()
+			check := existenceChecks[fl.Param()]
+			existenceMu.RUnlock()
+			return check == nil || check(ctx, id) == nil
+		})
+
+		_ = v.RegisterValidation("maxpage", func(fl validator.FieldLevel) bool {
+			return fl.Field().Int() <= maxPageSize.Load()
+		})
+
+		translator, _ := ut.New(en.New()).GetTranslator("en")
+		_ = en_translations.RegisterDefaultTranslations(v, translator)
+		registerTranslation(v, translator, "exists", "{0} does not exist")
+		_ = v.RegisterTranslation("maxpage", translator, func(t ut.Translator) error {
+			return nil
+		}, func(t ut.Translator, fe validator.FieldError) string {
+			return fmt.Sprintf("%s must be %d or less", fe.Field(), maxPageSize.Load())
+		})
+
+		requestValidator = v
+		requestTranslator = translator
+		binding.Validator = nil
+	})
+}
+
+func registerTranslation(v *validator.Validate, translator ut.Translator, tag, text string) {
+	_ = v.RegisterTranslation(tag, translator, func(t ut.Translator) error {
+		return t.Add(tag, text, true)
+	}, func(t ut.Translator, fe validator.FieldError) string {
+		message, _ := t.T(tag, fe.Field())
+		return message
+	})
+}
+
+// registerExistenceCheck makes "exists=<kind>" fields call check.
+func registerExistenceCheck(kind string, check existenceCheck) {
+	existenceMu.Lock()
+	defer existenceMu.Unlock()
+	existenceChecks[kind] = check
+}
+
+// bindJSON decodes the request body into obj and validates it. Validation
+// failures are returned as domain.ValidationErrors.
+func bindJSON(c *gin.Context, obj any) error {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		return err
+	}
+	return validateRequest(c.Request.Context(), obj)
+}
+
+// mergePatchContentType is the media type of a JSON Merge Patch body.
+// Plain application/json is accepted too.
+const mergePatchContentType = "application/merge-patch+json"
+
+var errUnsupportedPatchType = errors.New("the body must be " + mergePatchContentType + " or application/json")
+
+// bindMergePatch applies the request body, a JSON Merge Patch, to current and
+// validates the result like bindJSON would a full body. It returns the
+// patched copy and the fields the patch changes.
+func bindMergePatch[T any](c *gin.Context, spec domain.PatchSpec, current *T) (*T, []string, error) {
+	if contentType := c.ContentType(); contentType != mergePatchContentType && contentType != gin.MIMEJSON {
+		return nil, nil, errUnsupportedPatchType
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
+		return nil, nil, err
+	}
+	patched, fields, err := domain.ApplyMergePatch(spec, current, patch)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateRequest(c.Request.Context(), patched); err != nil {
+		return nil, nil, err
+	}
+	return patched, fields, nil
+}
+
+var errInvalidIfMatch = errors.New(`If-Match must be the version to update, as in "3"`)
+
+// ifMatchVersion returns the version an update is conditional on: the one in
+// the If-Match header, an entity tag holding the version such as "3", or else
+// body, the version sent in the request body. Zero, as with If-Match: * or
+// no version at all, makes the update unconditional.
+func ifMatchVersion(c *gin.Context, body int) (int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	switch header {
+	case "":
+		return body, nil
+	case "*":
+		return 0, nil
+	}
+
+	tag, ok := strings.CutPrefix(header, `"`)
+	if ok {
+		tag, ok = strings.CutSuffix(tag, `"`)
+	}
+	version, err := strconv.Atoi(tag)
+	if !ok || err != nil || version <= 0 {
+		return 0, errInvalidIfMatch
+	}
+	return version, nil
+}
+
+// validateRequest runs requestValidator over obj, reporting every invalid
+// field at once.
+func validateRequest(ctx context.Context, obj any) error {
+	err := requestValidator.StructCtx(ctx, obj)
+	var fields validator.ValidationErrors
+	if !errors.As(err, &fields) {
+		return err
+	}
+
+	errs := make(domain.ValidationErrors, 0, len(fields))
+	for _, field := range fields {
+		path := field.Namespace()
+		if _, nested, ok := strings.Cut(path, "."); ok {
+			path = nested
+		}
+		path = strings.ReplaceAll(path, embeddedFieldName+".", "")
+		fieldErr := domain.FieldError{Field: path, Message: field.Translate(requestTranslator)}
+
+		var invalid *domain.InvalidValueError
+		if value, ok := field.Value().(enumValue); ok && field.Tag() == "enum" && errors.As(value.Validate(), &invalid) {
+			fieldErr.Message = invalid.Error()
+			fieldErr.Allowed = invalid.Allowed
+		}
+		errs = append(errs, fieldErr)
+	}
+	return errs
+}
+
+// errorResponse is the body for err. Validation failures list every invalid
+// field under "fields"; enum violations also carry the allowed values, so
+// clients do not have to guess them.
+func errorResponse(err error) gin.H {
+	var invalid *domain.InvalidValueError
+	if errors.As(err, &invalid) {
+		err = domain.ValidationErrors{{Field: invalid.Field, Message: invalid.Error(), Allowed: invalid.Allowed}}
+	}
+
+	var fields domain.ValidationErrors
+	if errors.As(err, &fields) {
+		body := gin.H{"error": fields.Error(), "fields": fields}
+		if len(fields) == 1 && fields[0].Allowed != nil {
+			body["allowed"] = fields[0].Allowed
+		}
+		return body
+	}
+
+	return gin.H{"error": err.Error()}
+}
@@ -0,0 +1,455 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type WarehouseHandler struct {
+	service WarehouseService
+	logger  *logrus.Logger
+}
+
+func NewWarehouseHandler(service WarehouseService) *WarehouseHandler {
+	return &WarehouseHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *WarehouseHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering warehouse routes")
+	write := RequirePermission(domain.ScopeActionWrite, "warehouses")
+	r.POST(WarehousesEndpoint, write, h.CreateWarehouse)
+	r.GET(WarehousesEndpoint, h.ListWarehouses)
+	r.GET(WarehouseByID, h.GetWarehouse)
+	r.PUT(WarehouseByID, write, h.UpdateWarehouse)
+	r.DELETE(WarehouseByID, write, h.DeleteWarehouse)
+	r.GET(WarehouseStockEndpoint, h.ListWarehouseStock)
+	r.POST(StockTransfersEndpoint, RequirePermission(domain.ScopeActionWrite, "stock-transfers"), h.TransferStock)
+}
+
+type createWarehouseRequest struct {
+	Code    string `json:"code" binding:"required"`
+	Name    string `json:"name" binding:"required"`
+	Address string `json:"address"`
+}
+
+type stockTransferRequest struct {
+	ProductID       uuid.UUID  `json:"product_id" binding:"required,exists=product"`
+	FromWarehouseID *uuid.UUID `json:"from_warehouse_id" binding:"omitempty,exists=warehouse"`
+	ToWarehouseID   *uuid.UUID `json:"to_warehouse_id" binding:"omitempty,exists=warehouse"`
+	Quantity        int        `json:"quantity" binding:"required,gt=0"`
+	Note            string     `json:"note"`
+}
+
+// @Summary Create warehouse
+// @Description Create a new stock location (admin only)
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body createWarehouseRequest true "Warehouse data"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.Warehouse
+// @Header 201 {string} Location "URL of the created warehouse"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /v1/warehouses [post]
+func (h *WarehouseHandler) CreateWarehouse(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Creating new warehouse")
+
+	var req createWarehouseRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    c.ClientIP(),
+		}).Warn("Invalid request body for warehouse creation")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	warehouse, err := h.service.CreateWarehouse(c.Request.Context(), &domain.Warehouse{
+		Code:    req.Code,
+		Name:    req.Name,
+		Address: req.Address,
+	})
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"code":  req.Code,
+		}).Error("Failed to create warehouse")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+		"code":         warehouse.Code,
+	}).Info("Warehouse created successfully")
+
+	respondCreated(c, warehouse, WarehouseByID, warehouse.ID.String())
+}
+
+// @Summary List warehouses
+// @Description Get a list of warehouses with optional filtering and pagination
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param code query string false "Filter by code"
+// @Param name query string false "Filter by name"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. created_at desc; fields: code, name, created_at, updated_at (default: created_at desc)"
+// @Success 200 {array} domain.Warehouse
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/warehouses [get]
+func (h *WarehouseHandler) ListWarehouses(c *gin.Context) {
+	h.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"ip":     c.ClientIP(),
+	}).Info("Listing warehouses")
+
+	filter := domain.WarehouseParams{
+		Code: c.Query("code"),
+		Name: c.Query("name"),
+	}
+
+	var page pageQuery
+	if err := bindQuery(c, &page); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	sort, err := sortQuery(c, domain.WarehouseSort, "created_at desc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := page.pagination(sort)
+
+	h.logger.WithFields(logrus.Fields{
+		"filter_code": filter.Code,
+		"filter_name": filter.Name,
+		"limit":       pagination.Limit,
+		"offset":      pagination.Offset,
+		"sort":        pagination.Sort,
+	}).Debug("List warehouses with filters and pagination")
+
+	warehouses, err := h.service.ListWarehouses(c.Request.Context(), filter, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list warehouses")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"count": len(warehouses),
+	}).Info("Warehouses listed successfully")
+
+	c.JSON(StatusOK, warehouses)
+}
+
+// @Summary Get warehouse by ID
+// @Description Get a specific warehouse by its ID
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Warehouse ID"
+// @Success 200 {object} domain.Warehouse
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/warehouses/{id} [get]
+func (h *WarehouseHandler) GetWarehouse(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid warehouse ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":       c.Request.Method,
+		"path":         c.Request.URL.Path,
+		"warehouse_id": id,
+		"ip":           c.ClientIP(),
+	}).Info("Getting warehouse by ID")
+
+	warehouse, err := h.service.GetWarehouseByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+			"client_ip":    c.ClientIP(),
+		}).Warn("Warehouse not found")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+		"code":         warehouse.Code,
+	}).Info("Warehouse retrieved successfully")
+
+	c.JSON(StatusOK, warehouse)
+}
+
+// @Summary Update warehouse
+// @Description Update an existing warehouse (admin only)
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Warehouse ID"
+// @Param warehouse body domain.Warehouse true "Warehouse data"
+// @Success 200 {object} domain.Warehouse
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /v1/warehouses/{id} [put]
+func (h *WarehouseHandler) UpdateWarehouse(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid warehouse ID format for update")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":       c.Request.Method,
+		"path":         c.Request.URL.Path,
+		"warehouse_id": id,
+		"ip":           c.ClientIP(),
+	}).Info("Updating warehouse")
+
+	var warehouse domain.Warehouse
+	if err := bindJSON(c, &warehouse); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+			"client_ip":    c.ClientIP(),
+		}).Warn("Invalid request body for warehouse update")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	warehouse.ID = id
+	if err := h.service.UpdateWarehouse(c.Request.Context(), &warehouse); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+			"client_ip":    c.ClientIP(),
+		}).Error("Failed to update warehouse")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+		"code":         warehouse.Code,
+	}).Info("Warehouse updated successfully")
+
+	c.JSON(StatusOK, warehouse)
+}
+
+// @Summary Delete warehouse
+// @Description Delete a warehouse by ID (admin only). Warehouses that still hold stock cannot be deleted.
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Warehouse ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Warehouse still holds stock"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/warehouses/{id} [delete]
+func (h *WarehouseHandler) DeleteWarehouse(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid warehouse ID format for deletion")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":       c.Request.Method,
+		"path":         c.Request.URL.Path,
+		"warehouse_id": id,
+		"ip":           c.ClientIP(),
+	}).Info("Deleting warehouse")
+
+	if err := h.service.DeleteWarehouse(c.Request.Context(), id); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+			"client_ip":    c.ClientIP(),
+		}).Error("Failed to delete warehouse")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"warehouse_id": id,
+	}).Info("Warehouse deleted successfully")
+
+	c.JSON(StatusNoContent, nil)
+}
+
+// @Summary List warehouse stock
+// @Description Get the per-product stock levels held at a warehouse
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Warehouse ID"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Param sort query string false "Field and direction, e.g. product_id desc; fields: product_id, quantity, updated_at (default: product_id asc)"
+// @Success 200 {array} domain.WarehouseStock
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/warehouses/{id}/stock [get]
+func (h *WarehouseHandler) ListWarehouseStock(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid warehouse ID format for stock listing")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	var page pageQuery
+	if err := bindQuery(c, &page); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	sort, err := sortQuery(c, domain.WarehouseStockSort, "product_id asc")
+	if err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+	pagination := page.pagination(sort)
+
+	h.logger.WithFields(logrus.Fields{
+		"method":       c.Request.Method,
+		"path":         c.Request.URL.Path,
+		"warehouse_id": id,
+		"limit":        pagination.Limit,
+		"offset":       pagination.Offset,
+		"ip":           c.ClientIP(),
+	}).Info("Listing warehouse stock")
+
+	stock, err := h.service.ListWarehouseStock(c.Request.Context(), id, pagination)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+			"client_ip":    c.ClientIP(),
+		}).Warn("Failed to list warehouse stock")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"warehouse_id": id,
+		"count":        len(stock),
+	}).Info("Warehouse stock listed successfully")
+
+	c.JSON(StatusOK, stock)
+}
+
+// @Summary Transfer stock between warehouses
+// @Description Move quantity of a product between two locations without changing its total (admin only). Omit from_warehouse_id to allocate unassigned stock, or to_warehouse_id to release stock back to the unallocated pool. The authenticated user is recorded as the actor.
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body stockTransferRequest true "Stock transfer"
+// @Param Prefer header string false "return=minimal to answer with an empty body"
+// @Success 201 {object} domain.StockTransfer
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Insufficient stock in source location"
+// @Router /v1/stock-transfers [post]
+func (h *WarehouseHandler) TransferStock(c *gin.Context) {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"client_ip": c.ClientIP(),
+		}).Warn("Stock transfer without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":   c.Request.Method,
+		"path":     c.Request.URL.Path,
+		"actor_id": actorID,
+		"ip":       c.ClientIP(),
+	}).Info("Transferring stock")
+
+	var req stockTransferRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid request body for stock transfer")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	transfer, err := h.service.TransferStock(c.Request.Context(), req.ProductID, actorID, req.FromWarehouseID, req.ToWarehouseID, req.Quantity, req.Note)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": req.ProductID,
+			"quantity":   req.Quantity,
+			"client_ip":  c.ClientIP(),
+		}).Error("Failed to transfer stock")
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"transfer_id": transfer.ID,
+		"product_id":  transfer.ProductID,
+		"quantity":    transfer.Quantity,
+	}).Info("Stock transferred successfully")
+
+	respondCreated(c, transfer, "")
+}
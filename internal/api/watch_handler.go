@@ -0,0 +1,358 @@
+package api
+
+import (
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type WatchHandler struct {
+	service WatchService
+	logger  *logrus.Logger
+}
+
+func NewWatchHandler(service WatchService) *WatchHandler {
+	return &WatchHandler{
+		service: service,
+		logger:  infrastructure.GetColoredLogger(),
+	}
+}
+
+func (h *WatchHandler) RegisterRoutes(r *gin.RouterGroup) {
+	h.logger.Info("Registering watch routes")
+	r.POST(ProjectWatch, h.WatchProject)
+	r.DELETE(ProjectWatch, h.UnwatchProject)
+	r.GET(ProjectWatchers, h.ListProjectWatchers)
+	r.POST(ProjectItemWatch, h.WatchProjectItem)
+	r.DELETE(ProjectItemWatch, h.UnwatchProjectItem)
+	r.GET(ProjectItemWatchers, h.ListProjectItemWatchers)
+	r.GET(NotificationsEndpoint, h.ListNotifications)
+	r.POST(NotificationRead, h.MarkNotificationRead)
+}
+
+// @Summary Watch project
+// @Description Subscribe the authenticated user to changes of the project and its items. Watching twice is a no-op.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 200 {object} domain.Watch
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/projects/{id}/watch [post]
+func (h *WatchHandler) WatchProject(c *gin.Context) {
+	h.watch(c, domain.WatchTargetProject)
+}
+
+// @Summary Unwatch project
+// @Description Stop notifying the authenticated user about changes of the project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /v1/projects/{id}/watch [delete]
+func (h *WatchHandler) UnwatchProject(c *gin.Context) {
+	h.unwatch(c, domain.WatchTargetProject)
+}
+
+// @Summary List project watchers
+// @Description Get the users watching a project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project ID"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.Watch
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/projects/{id}/watchers [get]
+func (h *WatchHandler) ListProjectWatchers(c *gin.Context) {
+	h.listWatchers(c, domain.WatchTargetProject)
+}
+
+// @Summary Watch project item
+// @Description Subscribe the authenticated user to changes of the project item. Watching twice is a no-op.
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project Item ID"
+// @Success 200 {object} domain.Watch
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/project-items/{id}/watch [post]
+func (h *WatchHandler) WatchProjectItem(c *gin.Context) {
+	h.watch(c, domain.WatchTargetProjectItem)
+}
+
+// @Summary Unwatch project item
+// @Description Stop notifying the authenticated user about changes of the project item
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project Item ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /v1/project-items/{id}/watch [delete]
+func (h *WatchHandler) UnwatchProjectItem(c *gin.Context) {
+	h.unwatch(c, domain.WatchTargetProjectItem)
+}
+
+// @Summary List project item watchers
+// @Description Get the users watching a project item directly. Watchers of the parent project are notified too but are not listed here.
+// @Tags project-items
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Project Item ID"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.Watch
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/project-items/{id}/watchers [get]
+func (h *WatchHandler) ListProjectItemWatchers(c *gin.Context) {
+	h.listWatchers(c, domain.WatchTargetProjectItem)
+}
+
+type listNotificationsQuery struct {
+	pageQuery
+	Unread bool `form:"unread"`
+}
+
+// @Summary List notifications
+// @Description Get the authenticated user's notifications about watched projects and items, newest first
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only return unread notifications"
+// @Param limit query int false "Number of items per page (default: 20, at most 100 unless configured otherwise)"
+// @Param offset query int false "Number of items to skip (default: 0)"
+// @Success 200 {array} domain.Notification
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /v1/notifications [get]
+func (h *WatchHandler) ListNotifications(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":  c.Request.Method,
+		"path":    c.Request.URL.Path,
+		"user_id": userID,
+		"ip":      c.ClientIP(),
+	}).Info("Listing notifications")
+
+	var query listNotificationsQuery
+	if err := bindQuery(c, &query); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	notifications, err := h.service.ListNotifications(c.Request.Context(), userID, query.Unread, query.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"user_id":   userID,
+			"client_ip": c.ClientIP(),
+		}).Error("Failed to list notifications")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"count":   len(notifications),
+	}).Info("Notifications listed successfully")
+
+	c.JSON(StatusOK, notifications)
+}
+
+// @Summary Mark notification as read
+// @Description Mark one of the authenticated user's notifications as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Notification ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found"
+// @Router /v1/notifications/{id}/read [post]
+func (h *WatchHandler) MarkNotificationRead(c *gin.Context) {
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"param_id":  c.Param("id"),
+			"client_ip": c.ClientIP(),
+		}).Warn("Invalid notification ID format")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	if err := h.service.MarkNotificationRead(c.Request.Context(), userID, id); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"notification_id": id,
+			"client_ip":       c.ClientIP(),
+		}).Warn("Failed to mark notification as read")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
+
+func (h *WatchHandler) watch(c *gin.Context, targetType string) {
+	targetID, userID, ok := h.parseWatchRequest(c, targetType)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":      c.Request.Method,
+		"path":        c.Request.URL.Path,
+		"target_type": targetType,
+		"target_id":   targetID,
+		"user_id":     userID,
+		"ip":          c.ClientIP(),
+	}).Info("Watching target")
+
+	watch, err := h.service.Watch(c.Request.Context(), targetType, targetID, userID)
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"target_type": targetType,
+			"target_id":   targetID,
+			"client_ip":   c.ClientIP(),
+		}).Warn("Failed to watch target")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	c.JSON(StatusOK, watch)
+}
+
+func (h *WatchHandler) unwatch(c *gin.Context, targetType string) {
+	targetID, userID, ok := h.parseWatchRequest(c, targetType)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"method":      c.Request.Method,
+		"path":        c.Request.URL.Path,
+		"target_type": targetType,
+		"target_id":   targetID,
+		"user_id":     userID,
+		"ip":          c.ClientIP(),
+	}).Info("Unwatching target")
+
+	if err := h.service.Unwatch(c.Request.Context(), targetType, targetID, userID); err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"target_type": targetType,
+			"target_id":   targetID,
+			"client_ip":   c.ClientIP(),
+		}).Error("Failed to unwatch target")
+		abortWithError(c, StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(StatusNoContent, nil)
+}
+
+func (h *WatchHandler) listWatchers(c *gin.Context, targetType string) {
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"param_id":    c.Param("id"),
+			"target_type": targetType,
+			"client_ip":   c.ClientIP(),
+		}).Warn("Invalid ID format for watcher listing")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return
+	}
+
+	var page pageQuery
+	if err := bindQuery(c, &page); err != nil {
+		abortWithError(c, StatusBadRequest, err)
+		return
+	}
+
+	watches, err := h.service.ListWatchers(c.Request.Context(), targetType, targetID, page.pagination(""))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"target_type": targetType,
+			"target_id":   targetID,
+			"client_ip":   c.ClientIP(),
+		}).Warn("Failed to list watchers")
+		abortWithError(c, StatusNotFound, err)
+		return
+	}
+
+	c.JSON(StatusOK, watches)
+}
+
+// parseWatchRequest reads the target ID from the path and the watcher from
+// the token, writing the error response and returning false on failure.
+func (h *WatchHandler) parseWatchRequest(c *gin.Context, targetType string) (uuid.UUID, uuid.UUID, bool) {
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"param_id":    c.Param("id"),
+			"target_type": targetType,
+			"client_ip":   c.ClientIP(),
+		}).Warn("Invalid ID format for watch")
+		abortWithMessage(c, StatusBadRequest, "invalid id")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, ok := h.requireUser(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	return targetID, userID, true
+}
+
+func (h *WatchHandler) requireUser(c *gin.Context) (uuid.UUID, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"path":      c.Request.URL.Path,
+			"client_ip": c.ClientIP(),
+		}).Warn("Watch request without a user subject in token")
+		abortWithMessage(c, StatusUnauthorized, "token has no user subject")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
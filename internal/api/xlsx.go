@@ -0,0 +1,157 @@
+package api
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// An xlsx file is a zip of XML parts. xlsxWriter writes a workbook with a
+// single sheet row by row straight into the zip, with strings inline rather
+// than in a shared table, so a sheet of any length is written without being
+// held in memory.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>` +
+		`<sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+
+	// xlsxMaxSheetName is the longest sheet name spreadsheet programs accept.
+	xlsxMaxSheetName = 31
+)
+
+// xlsxCell is a cell of a row. Numeric cells are written as numbers, and
+// left out when empty; all others as text.
+type xlsxCell struct {
+	Value   string
+	Numeric bool
+}
+
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+}
+
+// newXLSXWriter writes the workbook parts to w and opens its only sheet,
+// named sheetName.
+func newXLSXWriter(w io.Writer, sheetName string) (*xlsxWriter, error) {
+	if len(sheetName) > xlsxMaxSheetName {
+		sheetName = sheetName[:xlsxMaxSheetName]
+	}
+
+	archive := zip.NewWriter(w)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xmlEscape(sheetName))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		f, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(f)
+	if _, err := sheet.WriteString(xlsxSheetStart); err != nil {
+		return nil, err
+	}
+	return &xlsxWriter{zip: archive, sheet: sheet}, nil
+}
+
+// writeHeader writes names as a row of text cells. The first row stays in
+// view while scrolling.
+func (w *xlsxWriter) writeHeader(names []string) error {
+	cells := make([]xlsxCell, len(names))
+	for i, name := range names {
+		cells[i] = xlsxCell{Value: name}
+	}
+	return w.writeRow(cells)
+}
+
+func (w *xlsxWriter) writeRow(cells []xlsxCell) error {
+	w.rows++
+	fmt.Fprintf(w.sheet, `<row r="%d">`, w.rows)
+	for i, cell := range cells {
+		ref := xlsxColumn(i) + strconv.Itoa(w.rows)
+		if cell.Numeric {
+			if cell.Value != "" {
+				fmt.Fprintf(w.sheet, `<c r="%s"><v>%s</v></c>`, ref, xmlEscape(cell.Value))
+			}
+			continue
+		}
+		fmt.Fprintf(w.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(cell.Value))
+	}
+	_, err := w.sheet.WriteString(`</row>`)
+	return err
+}
+
+// flush pushes the rows written so far down to the underlying writer.
+func (w *xlsxWriter) flush() error {
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Flush()
+}
+
+// close ends the sheet and writes the zip directory. It does not close the
+// underlying writer.
+func (w *xlsxWriter) close() error {
+	if _, err := w.sheet.WriteString(xlsxSheetEnd); err != nil {
+		return err
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+// xlsxColumn returns the letters of the zero-based column i: A to Z, then
+// AA, AB and so on.
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// xmlEscape escapes s for XML text and attributes. Characters XML cannot
+// hold are replaced.
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
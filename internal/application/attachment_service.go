@@ -0,0 +1,281 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type AttachmentService struct {
+	repo         domain.AttachmentRepository
+	productRepo  domain.ProductRepository
+	storage      domain.FileStorage
+	maxImageSize int64
+	urlTTL       time.Duration
+	logger       *logrus.Logger
+	clock        domain.Clock
+	ids          domain.IDGenerator
+}
+
+func NewAttachmentService(repo domain.AttachmentRepository, productRepo domain.ProductRepository, storage domain.FileStorage) *AttachmentService {
+	return &AttachmentService{
+		repo:         repo,
+		productRepo:  productRepo,
+		storage:      storage,
+		maxImageSize: domain.DefaultMaxImageSize,
+		urlTTL:       domain.DefaultSignedURLTTL,
+		logger:       logrus.New(),
+		clock:        domain.SystemClock{},
+		ids:          domain.UUIDv7Generator{},
+	}
+}
+
+func (s *AttachmentService) WithClock(clock domain.Clock) *AttachmentService {
+	s.clock = clock
+	return s
+}
+
+func (s *AttachmentService) WithIDGenerator(ids domain.IDGenerator) *AttachmentService {
+	s.ids = ids
+	return s
+}
+
+// WithMaxImageSize sets the largest image accepted, in bytes.
+func (s *AttachmentService) WithMaxImageSize(size int64) *AttachmentService {
+	s.maxImageSize = size
+	return s
+}
+
+// WithSignedURLTTL sets how long the download links handed out work.
+func (s *AttachmentService) WithSignedURLTTL(ttl time.Duration) *AttachmentService {
+	s.urlTTL = ttl
+	return s
+}
+
+// UploadProductImage stores the image read from content for the product.
+// The type is sniffed from the content rather than trusted from the
+// client, and must be one of domain.ImageExtensions.
+func (s *AttachmentService) UploadProductImage(ctx context.Context, productID, uploadedBy uuid.UUID, fileName string, content io.Reader) (*domain.Attachment, error) {
+	s.logger.WithFields(logrus.Fields{
+		"product_id":  productID,
+		"uploaded_by": uploadedBy,
+		"file_name":   fileName,
+	}).Info("Uploading product image")
+
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Warn("Product not found for image upload")
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, s.maxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, domain.ErrEmptyFile
+	}
+	if int64(len(data)) > s.maxImageSize {
+		s.logger.WithFields(logrus.Fields{
+			"product_id": productID,
+			"max_size":   s.maxImageSize,
+		}).Warn("Product image too large")
+		return nil, domain.ErrFileTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	extension, ok := domain.ImageExtensions[contentType]
+	if !ok {
+		s.logger.WithFields(logrus.Fields{
+			"product_id":   productID,
+			"content_type": contentType,
+		}).Warn("Unsupported product image type")
+		return nil, domain.ErrUnsupportedFileType
+	}
+
+	id := s.ids.NewID()
+	attachment := &domain.Attachment{
+		ID:          id,
+		OwnerType:   domain.AttachmentOwnerProduct,
+		OwnerID:     productID,
+		Key:         "products/" + productID.String() + "/" + id.String() + extension,
+		FileName:    cleanFileName(fileName, id.String()+extension),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		UploadedBy:  uploadedBy,
+		CreatedAt:   s.clock.Now(),
+	}
+
+	if err := s.storage.Put(ctx, attachment.Key, contentType, data); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+			"key":        attachment.Key,
+		}).Error("Failed to store product image")
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, attachment); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Error("Failed to create attachment in repository")
+		s.removeFile(attachment.Key)
+		return nil, err
+	}
+	if err := s.sign(ctx, attachment); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"attachment_id": attachment.ID,
+		"product_id":    productID,
+		"size":          attachment.Size,
+	}).Info("Product image uploaded successfully")
+
+	return attachment, nil
+}
+
+// ListProductImages returns the product's images with fresh download links.
+func (s *AttachmentService) ListProductImages(ctx context.Context, productID uuid.UUID) ([]domain.Attachment, error) {
+	s.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+	}).Debug("Listing product images")
+
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.repo.List(ctx, domain.AttachmentOwnerProduct, productID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Error("Failed to list product images from repository")
+		return nil, err
+	}
+	for i := range attachments {
+		if err := s.sign(ctx, &attachments[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return attachments, nil
+}
+
+// DeleteProductImage removes the image record and then its file. A file
+// left behind by a storage failure is logged, not returned, since the
+// image is already gone for clients.
+func (s *AttachmentService) DeleteProductImage(ctx context.Context, productID, imageID uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+		"image_id":   imageID,
+	}).Info("Deleting product image")
+
+	attachment, err := s.repo.GetByID(ctx, imageID)
+	if err != nil {
+		return err
+	}
+	if attachment.OwnerType != domain.AttachmentOwnerProduct || attachment.OwnerID != productID {
+		return domain.ErrAttachmentNotFound
+	}
+
+	if err := s.repo.Delete(ctx, imageID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"image_id": imageID,
+		}).Error("Failed to delete attachment in repository")
+		return err
+	}
+	s.removeFile(attachment.Key)
+
+	s.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+		"image_id":   imageID,
+	}).Info("Product image deleted successfully")
+
+	return nil
+}
+
+// OpenFile checks a download link served by the API itself and returns the
+// file it points at. Storages whose links are served elsewhere have no such
+// links, so every key is reported as not found.
+func (s *AttachmentService) OpenFile(ctx context.Context, key string, expiresAt time.Time, signature string) (*domain.Attachment, io.ReadCloser, error) {
+	verifier, ok := s.storage.(domain.SignedURLVerifier)
+	if !ok {
+		return nil, nil, domain.ErrFileNotFound
+	}
+	if err := verifier.VerifySignedURL(key, expiresAt, signature); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"key": key,
+		}).Warn("Invalid file download signature")
+		return nil, nil, err
+	}
+
+	attachment, err := s.repo.GetByKey(ctx, key)
+	if errors.Is(err, domain.ErrAttachmentNotFound) {
+		return nil, nil, domain.ErrFileNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := s.storage.Open(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, file, nil
+}
+
+func (s *AttachmentService) sign(ctx context.Context, attachment *domain.Attachment) error {
+	expiresAt := s.clock.Now().Add(s.urlTTL).Truncate(time.Second)
+	u, err := s.storage.SignedURL(ctx, attachment.Key, expiresAt)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":         err.Error(),
+			"attachment_id": attachment.ID,
+		}).Error("Failed to sign attachment URL")
+		return err
+	}
+	attachment.URL = u
+	attachment.URLExpiresAt = &expiresAt
+	return nil
+}
+
+// removeFile deletes a stored file that no record points at any more,
+// logging failures instead of returning them.
+func (s *AttachmentService) removeFile(key string) {
+	if err := s.storage.Delete(context.Background(), key); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"key":   key,
+		}).Warn("Failed to delete stored file")
+	}
+}
+
+// cleanFileName keeps the base name of the client's file name, or fallback
+// when there is none, so it is safe to send back in Content-Disposition.
+func cleanFileName(name, fallback string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return fallback
+	}
+	if len(name) > 255 {
+		name = strings.ToValidUTF8(name[:255], "")
+	}
+	return name
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+)
+
+// icalLineLimit is the longest content line RFC 5545 allows, in octets,
+// before it has to be folded.
+const icalLineLimit = 75
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// renderICalendar writes events as an iCalendar (RFC 5545) feed. All-day
+// events are dated in the application timezone; the others are in UTC.
+func renderICalendar(name string, events []domain.CalendarEvent, now time.Time) []byte {
+	var buf bytes.Buffer
+	line := func(content string) {
+		writeICalLine(&buf, content)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//golang-api-rest//calendar feed//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + icalEscaper.Replace(name))
+	line("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	line("X-PUBLISHED-TTL:PT1H")
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, event := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + event.UID)
+		line("DTSTAMP:" + stamp)
+		if event.AllDay {
+			day := startOfDay(event.Start.In(time.Local))
+			line("DTSTART;VALUE=DATE:" + day.Format("20060102"))
+			line("DTEND;VALUE=DATE:" + day.AddDate(0, 0, 1).Format("20060102"))
+		} else {
+			line("DTSTART:" + event.Start.UTC().Format("20060102T150405Z"))
+		}
+		line("SUMMARY:" + icalEscaper.Replace(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION:" + icalEscaper.Replace(event.Description))
+		}
+		if !event.UpdatedAt.IsZero() {
+			line("LAST-MODIFIED:" + event.UpdatedAt.UTC().Format("20060102T150405Z"))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// writeICalLine ends content with CRLF, folding it into continuation lines
+// that start with a space whenever it exceeds icalLineLimit octets. Folds
+// never split a UTF-8 sequence.
+func writeICalLine(buf *bytes.Buffer, content string) {
+	limit := icalLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		buf.WriteString(content[:cut])
+		buf.WriteString("\r\n ")
+		content = content[cut:]
+		// The leading space of a continuation line counts towards its length.
+		limit = icalLineLimit - 1
+	}
+	buf.WriteString(content)
+	buf.WriteString("\r\n")
+}
+
+// isCalendarDate tells whether t is a date rather than a point in time:
+// dates are stored at local midnight.
+func isCalendarDate(t time.Time) bool {
+	local := t.In(time.Local)
+	return local.Equal(startOfDay(local))
+}
@@ -0,0 +1,178 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// calendarTokenBytes is the entropy of a feed token.
+const calendarTokenBytes = 32
+
+type CalendarService struct {
+	repo   domain.CalendarRepository
+	users  domain.UserRepository
+	logger *logrus.Logger
+	clock  domain.Clock
+}
+
+func NewCalendarService(repo domain.CalendarRepository, users domain.UserRepository) *CalendarService {
+	return &CalendarService{
+		repo:   repo,
+		users:  users,
+		logger: logrus.New(),
+		clock:  domain.SystemClock{},
+	}
+}
+
+func (s *CalendarService) WithClock(clock domain.Clock) *CalendarService {
+	s.clock = clock
+	return s
+}
+
+// CreateCalendarFeed issues a new feed token for userID, revoking the one
+// issued before. The token is only ever returned here.
+func (s *CalendarService) CreateCalendarFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeedToken, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+	}).Info("Creating calendar feed")
+
+	secret := make([]byte, calendarTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("generate calendar token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	feed := &domain.CalendarFeed{
+		UserID:    userID,
+		TokenHash: calendarTokenHash(token),
+		CreatedAt: s.clock.Now(),
+	}
+	if err := s.repo.SaveFeed(ctx, feed); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to save calendar feed in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+	}).Info("Calendar feed created successfully")
+
+	return &domain.CalendarFeedToken{Token: token, CreatedAt: feed.CreatedAt}, nil
+}
+
+func (s *CalendarService) GetCalendarFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	return s.repo.GetFeed(ctx, userID)
+}
+
+func (s *CalendarService) DeleteCalendarFeed(ctx context.Context, userID uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+	}).Info("Revoking calendar feed")
+
+	return s.repo.DeleteFeed(ctx, userID)
+}
+
+// RenderCalendarFeed returns the iCalendar feed the token opens: the due
+// dates of the open items assigned to its owner and the start and end
+// dates of their projects. Unknown tokens and accounts that cannot sign in
+// are reported as domain.ErrCalendarFeedNotFound.
+func (s *CalendarService) RenderCalendarFeed(ctx context.Context, token string) ([]byte, error) {
+	feed, err := s.repo.GetFeedByTokenHash(ctx, calendarTokenHash(token))
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	user, err := s.users.GetAccount(ctx, feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.CanSignIn(now) {
+		s.logger.WithFields(logrus.Fields{
+			"user_id": feed.UserID,
+		}).Warn("Calendar feed of an account that cannot sign in")
+		return nil, domain.ErrCalendarFeedNotFound
+	}
+
+	from := startOfDay(now.Add(-domain.CalendarPastWindow))
+	items, err := s.repo.ListAssignedDue(ctx, feed.UserID, from, domain.MaxCalendarEvents)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.repo.ListMilestones(ctx, feed.UserID, from, domain.MaxCalendarEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.TouchFeed(ctx, feed.UserID, now); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": feed.UserID,
+		}).Warn("Failed to record calendar feed use")
+	}
+
+	events := calendarEvents(items, projects, from)
+	s.logger.WithFields(logrus.Fields{
+		"user_id": feed.UserID,
+		"events":  len(events),
+	}).Debug("Calendar feed rendered")
+
+	return renderICalendar(user.Name+" - due dates", events, now), nil
+}
+
+// calendarEvents lists the item due dates and then the project start and
+// end dates at or after from. Event UIDs are stable, so calendar apps
+// update events in place when dates move.
+func calendarEvents(items []domain.ProjectItem, projects []domain.Project, from time.Time) []domain.CalendarEvent {
+	events := make([]domain.CalendarEvent, 0, len(items)+2*len(projects))
+	for _, item := range items {
+		if item.DueDate == nil {
+			continue
+		}
+		events = append(events, domain.CalendarEvent{
+			UID:         "item-" + item.ID.String() + "@golang-api-rest",
+			Summary:     "Due: " + item.Name,
+			Description: strings.TrimSpace(fmt.Sprintf("Priority: %s\nStatus: %s\n\n%s", item.Priority, item.Status, item.Description)),
+			Start:       *item.DueDate,
+			AllDay:      isCalendarDate(*item.DueDate),
+			UpdatedAt:   item.UpdatedAt,
+		})
+	}
+
+	milestone := func(project domain.Project, date *time.Time, kind, label string) {
+		if date == nil || date.Before(from) {
+			return
+		}
+		events = append(events, domain.CalendarEvent{
+			UID:         "project-" + project.ID.String() + "-" + kind + "@golang-api-rest",
+			Summary:     label + ": " + project.Name,
+			Description: project.Description,
+			Start:       *date,
+			AllDay:      isCalendarDate(*date),
+			UpdatedAt:   project.UpdatedAt,
+		})
+	}
+	for _, project := range projects {
+		milestone(project, project.StartDate, "start", "Project starts")
+		milestone(project, project.EndDate, "end", "Project ends")
+	}
+	return events
+}
+
+// calendarTokenHash is how feed tokens are stored and looked up.
+func calendarTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,384 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ChatService struct {
+	repo        domain.ChatConnectorRepository
+	projectRepo domain.ProjectRepository
+	poster      domain.ChatPoster
+	logger      *logrus.Logger
+	clock       domain.Clock
+	ids         domain.IDGenerator
+}
+
+func NewChatService(repo domain.ChatConnectorRepository, projectRepo domain.ProjectRepository) *ChatService {
+	return &ChatService{
+		repo:        repo,
+		projectRepo: projectRepo,
+		logger:      logrus.New(),
+		clock:       domain.SystemClock{},
+		ids:         domain.UUIDv7Generator{},
+	}
+}
+
+func (s *ChatService) WithClock(clock domain.Clock) *ChatService {
+	s.clock = clock
+	return s
+}
+
+func (s *ChatService) WithIDGenerator(ids domain.IDGenerator) *ChatService {
+	s.ids = ids
+	return s
+}
+
+// WithPoster sets how messages reach the webhooks. Without it events are
+// dropped and test deliveries fail.
+func (s *ChatService) WithPoster(poster domain.ChatPoster) *ChatService {
+	s.poster = poster
+	return s
+}
+
+func (s *ChatService) CreateChatConnector(ctx context.Context, connector *domain.ChatConnector, actorID uuid.UUID) (*domain.ChatConnector, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id":    actorID,
+		"provider":   connector.Provider,
+		"project_id": connector.ProjectID,
+	}).Info("Creating chat connector")
+
+	if err := s.normalizeChatConnector(ctx, connector); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	connector.ID = s.ids.NewID()
+	connector.CreatedBy = actorID
+	connector.CreatedAt = now
+	connector.UpdatedAt = now
+	connector.DeletedAt = nil
+
+	if err := s.repo.Create(ctx, connector); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": actorID,
+		}).Error("Failed to create chat connector in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"connector_id": connector.ID,
+		"events":       connector.Events,
+	}).Info("Chat connector created successfully")
+
+	return connector, nil
+}
+
+func (s *ChatService) GetChatConnector(ctx context.Context, id uuid.UUID) (*domain.ChatConnector, error) {
+	s.logger.WithFields(logrus.Fields{
+		"connector_id": id,
+	}).Debug("Getting chat connector")
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *ChatService) ListChatConnectors(ctx context.Context, params domain.ChatConnectorParams, pagination domain.Pagination) ([]domain.ChatConnector, error) {
+	s.logger.WithFields(logrus.Fields{
+		"provider":   params.Provider,
+		"project_id": params.ProjectID,
+	}).Debug("Listing chat connectors")
+
+	if params.Provider != "" {
+		if err := params.Provider.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	connectors, err := s.repo.List(ctx, params, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list chat connectors from repository")
+		return nil, err
+	}
+
+	return connectors, nil
+}
+
+// UpdateChatConnector replaces everything but the provider of a connector.
+func (s *ChatService) UpdateChatConnector(ctx context.Context, connector *domain.ChatConnector) (*domain.ChatConnector, error) {
+	s.logger.WithFields(logrus.Fields{
+		"connector_id": connector.ID,
+	}).Info("Updating chat connector")
+
+	existing, err := s.repo.GetByID(ctx, connector.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.Name = connector.Name
+	existing.WebhookURL = connector.WebhookURL
+	existing.Events = connector.Events
+	existing.ProjectID = connector.ProjectID
+	existing.Active = connector.Active
+	if err := s.normalizeChatConnector(ctx, existing); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = s.clock.Now()
+
+	if err := s.repo.Update(ctx, existing); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"connector_id": connector.ID,
+		}).Error("Failed to update chat connector in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"connector_id": existing.ID,
+		"events":       existing.Events,
+	}).Info("Chat connector updated successfully")
+
+	return existing, nil
+}
+
+func (s *ChatService) DeleteChatConnector(ctx context.Context, id uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"connector_id": id,
+	}).Info("Deleting chat connector")
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if !errors.Is(err, domain.ErrChatConnectorNotFound) {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err.Error(),
+				"connector_id": id,
+			}).Error("Failed to delete chat connector in repository")
+		}
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"connector_id": id,
+	}).Info("Chat connector deleted successfully")
+
+	return nil
+}
+
+// TestChatConnector posts a sample message through a connector, active or
+// not, and reports whether the provider accepted it. A rejected post is a
+// result, not an error, so the caller can show what the provider said.
+func (s *ChatService) TestChatConnector(ctx context.Context, id uuid.UUID) (*domain.ChatTestResult, error) {
+	s.logger.WithFields(logrus.Fields{
+		"connector_id": id,
+	}).Info("Testing chat connector")
+
+	connector, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ChatTestResult{SentAt: s.clock.Now()}
+	if s.poster == nil {
+		result.Error = "chat delivery is not configured"
+		return result, nil
+	}
+
+	fields := []domain.ChatField{{Name: "Events", Value: strings.Join(connector.Events, ", ")}}
+	if connector.ProjectID != nil {
+		fields = append(fields, domain.ChatField{Name: "Project", Value: connector.ProjectID.String()})
+	}
+	message := domain.ChatMessage{
+		Title:  "Test message",
+		Text:   fmt.Sprintf("The %q connector is set up to post here.", connector.Name),
+		Fields: fields,
+	}
+
+	postCtx, cancel := context.WithTimeout(ctx, domain.ChatDeliveryTimeout)
+	defer cancel()
+	if err := s.poster.Post(postCtx, connector.Provider, connector.WebhookURL, message); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"connector_id": id,
+		}).Warn("Chat connector test delivery failed")
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	result.Delivered = true
+	s.logger.WithFields(logrus.Fields{
+		"connector_id": id,
+	}).Info("Chat connector test delivered")
+
+	return result, nil
+}
+
+func (s *ChatService) ProjectCreated(ctx context.Context, project *domain.Project) {
+	fields := []domain.ChatField{{Name: "Status", Value: string(project.Status)}}
+	if project.Budget != nil {
+		fields = append(fields, domain.ChatField{Name: "Budget", Value: strconv.FormatFloat(project.Budget.Float64(), 'f', 2, 64)})
+	}
+	if project.EndDate != nil {
+		fields = append(fields, domain.ChatField{Name: "Ends", Value: project.EndDate.Format("2006-01-02")})
+	}
+
+	message := domain.ChatMessage{
+		Title:  "Project created",
+		Text:   project.Name,
+		Fields: fields,
+	}
+	projectID := project.ID
+	s.announce(domain.ChatEventProjectCreated, &projectID, func(context.Context) domain.ChatMessage {
+		return message
+	})
+}
+
+func (s *ChatService) ProjectItemCompleted(ctx context.Context, item *domain.ProjectItem) {
+	completed := *item
+	projectID := item.ProjectID
+	s.announce(domain.ChatEventProjectItemCompleted, &projectID, func(ctx context.Context) domain.ChatMessage {
+		fields := []domain.ChatField{}
+		if project, err := s.projectRepo.GetByID(ctx, completed.ProjectID); err == nil {
+			fields = append(fields, domain.ChatField{Name: "Project", Value: project.Name})
+		}
+		if completed.ActualHours != nil {
+			fields = append(fields, domain.ChatField{Name: "Hours", Value: strconv.FormatFloat(*completed.ActualHours, 'f', -1, 64)})
+		}
+		return domain.ChatMessage{
+			Title:  "Item completed",
+			Text:   completed.Name,
+			Fields: fields,
+		}
+	})
+}
+
+func (s *ChatService) ProductLowStock(ctx context.Context, product *domain.Product, threshold int) {
+	message := domain.ChatMessage{
+		Title: "Low stock",
+		Text:  product.Name,
+		Fields: []domain.ChatField{
+			{Name: "SKU", Value: string(product.SKU)},
+			{Name: "Stock", Value: strconv.Itoa(product.Stock)},
+			{Name: "Threshold", Value: strconv.Itoa(threshold)},
+		},
+	}
+	s.announce(domain.ChatEventProductLowStock, nil, func(context.Context) domain.ChatMessage {
+		return message
+	})
+}
+
+// announce posts the message built by compose to every connector subscribed
+// to event for projectID, in the background. Failures are logged per
+// connector and never reach the caller.
+func (s *ChatService) announce(event domain.ChatEvent, projectID *uuid.UUID, compose func(context.Context) domain.ChatMessage) {
+	if s.poster == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), domain.ChatDeliveryTimeout)
+		defer cancel()
+
+		connectors, err := s.repo.ListSubscribed(ctx, event, projectID)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"event": event,
+			}).Error("Failed to load chat connectors for event")
+			return
+		}
+		if len(connectors) == 0 {
+			return
+		}
+
+		message := compose(ctx)
+		posted := 0
+		for _, connector := range connectors {
+			if err := s.poster.Post(ctx, connector.Provider, connector.WebhookURL, message); err != nil {
+				s.logger.WithFields(logrus.Fields{
+					"error":        err.Error(),
+					"connector_id": connector.ID,
+					"event":        event,
+				}).Warn("Failed to post event to chat connector")
+				continue
+			}
+			posted++
+		}
+
+		s.logger.WithFields(logrus.Fields{
+			"event":      event,
+			"connectors": len(connectors),
+			"posted":     posted,
+		}).Info("Chat event announced")
+	}()
+}
+
+// normalizeChatConnector validates a connector, dropping duplicate events.
+// Product events belong to no project, so connectors mapped to a project
+// cannot subscribe to them.
+func (s *ChatService) normalizeChatConnector(ctx context.Context, connector *domain.ChatConnector) error {
+	connector.Name = strings.TrimSpace(connector.Name)
+	if connector.Name == "" {
+		return errors.New("chat connector name is required")
+	}
+	if err := connector.Provider.Validate(); err != nil {
+		return err
+	}
+
+	connector.WebhookURL = strings.TrimSpace(connector.WebhookURL)
+	if err := validateChatWebhookURL(connector.Provider, connector.WebhookURL); err != nil {
+		return err
+	}
+
+	events := domain.StringList{}
+	for _, event := range connector.Events {
+		if err := domain.ChatEvent(event).Validate(); err != nil {
+			return err
+		}
+		if connector.ProjectID != nil && domain.ChatEvent(event) == domain.ChatEventProductLowStock {
+			return fmt.Errorf("%s cannot be sent by a connector mapped to a project", event)
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		return errors.New("chat connector needs at least one event")
+	}
+	connector.Events = events
+
+	if connector.ProjectID != nil {
+		if _, err := s.projectRepo.GetByID(ctx, *connector.ProjectID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateChatWebhookURL only accepts https URLs on the provider's webhook
+// hosts, so connectors cannot make the server call anywhere else.
+func validateChatWebhookURL(provider domain.ChatProvider, raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" || parsed.User != nil {
+		return errors.New("webhook_url must be an https URL")
+	}
+	if parsed.Port() != "" && parsed.Port() != "443" {
+		return errors.New("webhook_url must use the default https port")
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	for _, allowed := range domain.ChatWebhookHosts[provider] {
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("webhook_url is not a %s webhook", provider)
+}
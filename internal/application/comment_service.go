@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type CommentService struct {
+	repo     domain.CommentRepository
+	itemRepo domain.ProjectItemRepository
+	notifier domain.ChangeNotifier
+	logger   *logrus.Logger
+	clock    domain.Clock
+	ids      domain.IDGenerator
+}
+
+func NewCommentService(repo domain.CommentRepository, itemRepo domain.ProjectItemRepository) *CommentService {
+	return &CommentService{
+		repo:     repo,
+		itemRepo: itemRepo,
+		logger:   logrus.New(),
+		clock:    domain.SystemClock{},
+		ids:      domain.UUIDv7Generator{},
+	}
+}
+
+func (s *CommentService) WithClock(clock domain.Clock) *CommentService {
+	s.clock = clock
+	return s
+}
+
+func (s *CommentService) WithIDGenerator(ids domain.IDGenerator) *CommentService {
+	s.ids = ids
+	return s
+}
+
+// WithNotifier tells the watchers of an item, of its project and its
+// assignee about each new comment.
+func (s *CommentService) WithNotifier(notifier domain.ChangeNotifier) *CommentService {
+	s.notifier = notifier
+	return s
+}
+
+func (s *CommentService) CreateComment(ctx context.Context, itemID, authorID uuid.UUID, body string) (*domain.Comment, error) {
+	s.logger.WithFields(logrus.Fields{
+		"item_id":   itemID,
+		"author_id": authorID,
+	}).Info("Creating comment")
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, domain.ErrEmptyComment
+	}
+
+	item, err := s.itemRepo.GetByID(ctx, itemID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": itemID,
+		}).Warn("Project item not found for comment")
+		return nil, err
+	}
+
+	comment := &domain.Comment{
+		ID:        s.ids.NewID(),
+		ItemID:    itemID,
+		AuthorID:  authorID,
+		Body:      body,
+		CreatedAt: s.clock.Now(),
+	}
+	if err := s.repo.Create(ctx, comment); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": itemID,
+		}).Error("Failed to create comment in repository")
+		return nil, err
+	}
+
+	if s.notifier != nil {
+		s.notifier.ProjectItemCommented(ctx, item, comment)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"comment_id": comment.ID,
+		"item_id":    itemID,
+	}).Info("Comment created successfully")
+
+	return comment, nil
+}
+
+func (s *CommentService) ListComments(ctx context.Context, itemID uuid.UUID, pagination domain.Pagination) ([]domain.Comment, error) {
+	s.logger.WithFields(logrus.Fields{
+		"item_id": itemID,
+		"limit":   pagination.Limit,
+		"offset":  pagination.Offset,
+	}).Debug("Listing comments")
+
+	if _, err := s.itemRepo.GetByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.List(ctx, itemID, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": itemID,
+		}).Error("Failed to list comments from repository")
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// CountComments returns how many comments the item has, for the totals of
+// a paginated list.
+func (s *CommentService) CountComments(ctx context.Context, itemID uuid.UUID) (int64, error) {
+	return s.repo.Count(ctx, itemID)
+}
+
+// DeleteComment soft deletes a comment of the item. Only its author and
+// admins may delete it.
+func (s *CommentService) DeleteComment(ctx context.Context, itemID, id, actorID uuid.UUID, admin bool) error {
+	s.logger.WithFields(logrus.Fields{
+		"item_id":    itemID,
+		"comment_id": id,
+		"actor_id":   actorID,
+	}).Info("Deleting comment")
+
+	comment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if comment.ItemID != itemID {
+		return domain.ErrCommentNotFound
+	}
+	if comment.AuthorID != actorID && !admin {
+		s.logger.WithFields(logrus.Fields{
+			"comment_id": id,
+			"actor_id":   actorID,
+		}).Warn("Comment deletion by another user refused")
+		return domain.ErrCommentForbidden
+	}
+
+	if err := s.repo.Delete(ctx, id, s.clock.Now()); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"comment_id": id,
+		}).Error("Failed to delete comment in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"comment_id": id,
+	}).Info("Comment deleted successfully")
+
+	return nil
+}
@@ -0,0 +1,410 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type CouponService struct {
+	repo        domain.CouponRepository
+	productRepo domain.ProductRepository
+	logger      *logrus.Logger
+	clock       domain.Clock
+	ids         domain.IDGenerator
+}
+
+func NewCouponService(repo domain.CouponRepository, productRepo domain.ProductRepository) *CouponService {
+	return &CouponService{
+		repo:        repo,
+		productRepo: productRepo,
+		logger:      logrus.New(),
+		clock:       domain.SystemClock{},
+		ids:         domain.UUIDv7Generator{},
+	}
+}
+
+func (s *CouponService) WithClock(clock domain.Clock) *CouponService {
+	s.clock = clock
+	return s
+}
+
+func (s *CouponService) WithIDGenerator(ids domain.IDGenerator) *CouponService {
+	s.ids = ids
+	return s
+}
+
+func (s *CouponService) CreateCoupon(ctx context.Context, coupon *domain.Coupon) (*domain.Coupon, error) {
+	s.logger.WithFields(logrus.Fields{
+		"code":  coupon.Code,
+		"type":  coupon.Type,
+		"value": coupon.Value,
+	}).Info("Creating new coupon")
+
+	coupon.Code = normalizeCouponCode(coupon.Code)
+	if err := s.validateCoupon(coupon); err != nil {
+		return nil, err
+	}
+
+	existingCoupon, err := s.repo.GetByCode(ctx, coupon.Code)
+	if err == nil && existingCoupon != nil {
+		s.logger.WithFields(logrus.Fields{
+			"code": coupon.Code,
+		}).Warn("Coupon code already exists")
+		return nil, errors.New("coupon code already exists")
+	}
+
+	now := s.clock.Now()
+	coupon.ID = s.ids.NewID()
+	coupon.UsedCount = 0
+	coupon.CreatedAt = now
+	coupon.UpdatedAt = now
+	coupon.DeletedAt = nil
+
+	if err := s.repo.Create(ctx, coupon); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"code":  coupon.Code,
+		}).Error("Failed to create coupon in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+	}).Info("Coupon created successfully")
+
+	return coupon, nil
+}
+
+func (s *CouponService) GetCouponByID(ctx context.Context, id uuid.UUID) (*domain.Coupon, error) {
+	s.logger.WithFields(logrus.Fields{
+		"coupon_id": id,
+	}).Debug("Getting coupon by ID")
+
+	coupon, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": id,
+		}).Warn("Coupon not found by ID")
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+func (s *CouponService) ListCoupons(ctx context.Context, filter domain.CouponParams, pagination domain.Pagination) ([]domain.Coupon, error) {
+	s.logger.WithFields(logrus.Fields{
+		"filter_code": filter.Code,
+		"filter_type": filter.Type,
+		"limit":       pagination.Limit,
+		"offset":      pagination.Offset,
+	}).Debug("Listing coupons")
+
+	coupons, err := s.repo.List(ctx, filter, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list coupons from repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"count": len(coupons),
+	}).Info("Coupons listed successfully")
+
+	return coupons, nil
+}
+
+func (s *CouponService) UpdateCoupon(ctx context.Context, coupon *domain.Coupon) error {
+	s.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+	}).Info("Updating coupon")
+
+	coupon.Code = normalizeCouponCode(coupon.Code)
+	if err := s.validateCoupon(coupon); err != nil {
+		return err
+	}
+
+	existingCoupon, err := s.repo.GetByID(ctx, coupon.ID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": coupon.ID,
+		}).Warn("Coupon not found for update")
+		return err
+	}
+
+	// Usage is only ever changed through IncrementUsage.
+	coupon.UsedCount = existingCoupon.UsedCount
+	coupon.CreatedAt = existingCoupon.CreatedAt
+	coupon.UpdatedAt = s.clock.Now()
+
+	if err := s.repo.Update(ctx, coupon); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": coupon.ID,
+		}).Error("Failed to update coupon in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+	}).Info("Coupon updated successfully")
+
+	return nil
+}
+
+func (s *CouponService) DeleteCoupon(ctx context.Context, id uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"coupon_id": id,
+	}).Info("Deleting coupon")
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": id,
+		}).Error("Failed to delete coupon in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"coupon_id": id,
+	}).Info("Coupon deleted successfully")
+
+	return nil
+}
+
+// QuoteCart prices the cart lines and applies the coupon without consuming a
+// use. An empty code returns the undiscounted totals.
+func (s *CouponService) QuoteCart(ctx context.Context, code string, lines []domain.CartLine) (*domain.CartQuote, error) {
+	quote, _, err := s.quote(ctx, code, lines)
+	return quote, err
+}
+
+// RedeemCoupon prices the cart like QuoteCart and then consumes one use of the
+// coupon.
+func (s *CouponService) RedeemCoupon(ctx context.Context, code string, lines []domain.CartLine) (*domain.CartQuote, error) {
+	quote, coupon, err := s.quote(ctx, code, lines)
+	if err != nil {
+		return nil, err
+	}
+	if coupon == nil {
+		return nil, errors.New("coupon code is required")
+	}
+
+	if err := s.repo.IncrementUsage(ctx, coupon.ID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": coupon.ID,
+		}).Warn("Failed to redeem coupon")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+		"discount":  quote.Discount,
+	}).Info("Coupon redeemed successfully")
+
+	return quote, nil
+}
+
+func (s *CouponService) quote(ctx context.Context, code string, lines []domain.CartLine) (*domain.CartQuote, *domain.Coupon, error) {
+	s.logger.WithFields(logrus.Fields{
+		"code":  code,
+		"lines": len(lines),
+	}).Debug("Quoting cart")
+
+	if len(lines) == 0 {
+		return nil, nil, errors.New("cart must contain at least one item")
+	}
+
+	var coupon *domain.Coupon
+	if code = normalizeCouponCode(code); code != "" {
+		var err error
+		coupon, err = s.repo.GetByCode(ctx, code)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"code":  code,
+			}).Warn("Coupon not found")
+			return nil, nil, errors.New("coupon not found")
+		}
+		if err := checkCouponRedeemable(s.logger, coupon, s.clock.Now()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	quote := &domain.CartQuote{Lines: make([]domain.CartQuoteLine, 0, len(lines))}
+	var eligibleSubtotal domain.Money
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return nil, nil, errors.New("item quantity must be greater than zero")
+		}
+
+		product, err := s.productRepo.GetByID(ctx, line.ProductID)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":      err.Error(),
+				"product_id": line.ProductID,
+			}).Warn("Cart product not found")
+			return nil, nil, errors.New("product not found: " + line.ProductID.String())
+		}
+
+		if product.ArchivedAt != nil {
+			s.logger.WithFields(logrus.Fields{
+				"product_id": line.ProductID,
+			}).Warn("Cart product is archived")
+			return nil, nil, errors.New("product is archived: " + line.ProductID.String())
+		}
+
+		lineTotal := product.Price.Times(line.Quantity)
+		eligible := coupon != nil && couponApplies(coupon, product)
+		if eligible {
+			eligibleSubtotal += lineTotal
+		}
+
+		quote.Subtotal += lineTotal
+		quote.Lines = append(quote.Lines, domain.CartQuoteLine{
+			ProductID: product.ID,
+			SKU:       product.SKU,
+			Name:      product.Name,
+			Category:  product.Category,
+			Quantity:  line.Quantity,
+			UnitPrice: product.Price,
+			LineTotal: lineTotal,
+			Eligible:  eligible,
+		})
+	}
+
+	if coupon != nil {
+		if eligibleSubtotal == 0 {
+			return nil, nil, errors.New("coupon does not apply to any item in the cart")
+		}
+
+		quote.CouponCode = coupon.Code
+		quote.Discount = couponDiscount(coupon, eligibleSubtotal)
+	}
+
+	quote.Subtotal = domain.RoundMoney(quote.Subtotal.Float64())
+	quote.Total = domain.RoundMoney((quote.Subtotal - quote.Discount).Float64())
+
+	s.logger.WithFields(logrus.Fields{
+		"code":     quote.CouponCode,
+		"subtotal": quote.Subtotal,
+		"discount": quote.Discount,
+		"total":    quote.Total,
+	}).Debug("Cart quoted successfully")
+
+	return quote, coupon, nil
+}
+
+// checkCouponRedeemable checks the coupon can be used at now: that it is
+// within its validity window and has uses left.
+func checkCouponRedeemable(logger *logrus.Logger, coupon *domain.Coupon, now time.Time) error {
+	if coupon.StartsAt != nil && now.Before(*coupon.StartsAt) {
+		logger.WithFields(logrus.Fields{
+			"coupon_id": coupon.ID,
+			"starts_at": coupon.StartsAt,
+		}).Warn("Coupon is not valid yet")
+		return errors.New("coupon is not valid yet")
+	}
+
+	if coupon.EndsAt != nil && now.After(*coupon.EndsAt) {
+		logger.WithFields(logrus.Fields{
+			"coupon_id": coupon.ID,
+			"ends_at":   coupon.EndsAt,
+		}).Warn("Coupon has expired")
+		return errors.New("coupon has expired")
+	}
+
+	if coupon.MaxUses != nil && coupon.UsedCount >= *coupon.MaxUses {
+		logger.WithFields(logrus.Fields{
+			"coupon_id":  coupon.ID,
+			"max_uses":   *coupon.MaxUses,
+			"used_count": coupon.UsedCount,
+		}).Warn("Coupon usage limit reached")
+		return domain.ErrCouponUsageLimitReached
+	}
+
+	return nil
+}
+
+func (s *CouponService) validateCoupon(coupon *domain.Coupon) error {
+	if coupon.Code == "" {
+		s.logger.Warn("Coupon code is empty")
+		return errors.New("coupon code is required")
+	}
+
+	if coupon.Type != domain.CouponTypePercentage && coupon.Type != domain.CouponTypeFixed {
+		s.logger.WithFields(logrus.Fields{
+			"type": coupon.Type,
+		}).Warn("Invalid coupon type")
+		return errors.New("coupon type must be percentage or fixed")
+	}
+
+	if coupon.Value <= 0 {
+		s.logger.WithFields(logrus.Fields{
+			"value": coupon.Value,
+		}).Warn("Invalid coupon value")
+		return errors.New("coupon value must be greater than zero")
+	}
+
+	if coupon.Type == domain.CouponTypePercentage && coupon.Value > 100 {
+		s.logger.WithFields(logrus.Fields{
+			"value": coupon.Value,
+		}).Warn("Invalid coupon percentage")
+		return errors.New("percentage coupon value cannot exceed 100")
+	}
+
+	if coupon.StartsAt != nil && coupon.EndsAt != nil && coupon.EndsAt.Before(*coupon.StartsAt) {
+		s.logger.WithFields(logrus.Fields{
+			"starts_at": coupon.StartsAt,
+			"ends_at":   coupon.EndsAt,
+		}).Warn("Invalid coupon validity window")
+		return errors.New("coupon ends_at must be after starts_at")
+	}
+
+	if coupon.MaxUses != nil && *coupon.MaxUses <= 0 {
+		s.logger.WithFields(logrus.Fields{
+			"max_uses": *coupon.MaxUses,
+		}).Warn("Invalid coupon usage limit")
+		return errors.New("coupon max_uses must be greater than zero")
+	}
+
+	return nil
+}
+
+func couponApplies(coupon *domain.Coupon, product *domain.Product) bool {
+	if coupon.ProductID != nil && *coupon.ProductID != product.ID {
+		return false
+	}
+	if coupon.Category != "" && !strings.EqualFold(coupon.Category, product.Category) {
+		return false
+	}
+	return true
+}
+
+// couponDiscount is what the coupon takes off eligibleSubtotal, the total
+// of the items it applies to.
+func couponDiscount(coupon *domain.Coupon, eligibleSubtotal domain.Money) domain.Money {
+	switch coupon.Type {
+	case domain.CouponTypePercentage:
+		return domain.RoundMoney(eligibleSubtotal.Float64() * coupon.Value / 100)
+	case domain.CouponTypeFixed:
+		return min(domain.RoundMoney(coupon.Value), eligibleSubtotal)
+	}
+	return 0
+}
+
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,335 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type CustomFieldService struct {
+	repo        domain.CustomFieldRepository
+	projectRepo domain.ProjectRepository
+	logger      *logrus.Logger
+	clock       domain.Clock
+	ids         domain.IDGenerator
+}
+
+func NewCustomFieldService(repo domain.CustomFieldRepository, projectRepo domain.ProjectRepository) *CustomFieldService {
+	return &CustomFieldService{
+		repo:        repo,
+		projectRepo: projectRepo,
+		logger:      logrus.New(),
+		clock:       domain.SystemClock{},
+		ids:         domain.UUIDv7Generator{},
+	}
+}
+
+func (s *CustomFieldService) WithClock(clock domain.Clock) *CustomFieldService {
+	s.clock = clock
+	return s
+}
+
+func (s *CustomFieldService) WithIDGenerator(ids domain.IDGenerator) *CustomFieldService {
+	s.ids = ids
+	return s
+}
+
+// CreateCustomField defines a new field. Global definitions may only be
+// created by admins; project-scoped ones also by the project's owner.
+func (s *CustomFieldService) CreateCustomField(ctx context.Context, definition *domain.CustomFieldDefinition, actorID uuid.UUID, admin bool) (*domain.CustomFieldDefinition, error) {
+	s.logger.WithFields(logrus.Fields{
+		"entity":     definition.Entity,
+		"project_id": definition.ProjectID,
+		"key":        definition.Key,
+		"type":       definition.Type,
+	}).Info("Creating custom field")
+
+	if err := definition.Entity.Validate(); err != nil {
+		return nil, err
+	}
+	if err := definition.Type.Validate(); err != nil {
+		return nil, err
+	}
+	definition.Key = strings.TrimSpace(definition.Key)
+	if !domain.ValidCustomFieldKey(definition.Key) {
+		return nil, errors.New("custom field key must start with a lowercase letter and contain only lowercase letters, digits and underscores")
+	}
+	if definition.Entity == domain.CustomFieldEntityProject && definition.ProjectID != nil {
+		return nil, errors.New("project custom fields cannot be scoped to a project")
+	}
+	if err := normalizeCustomFieldDefinition(definition); err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, definition, actorID, admin); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	definition.ID = s.ids.NewID()
+	definition.CreatedAt = now
+	definition.UpdatedAt = now
+	definition.DeletedAt = nil
+
+	if err := s.repo.Create(ctx, definition); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"key":   definition.Key,
+		}).Error("Failed to create custom field in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"custom_field_id": definition.ID,
+		"key":             definition.Key,
+	}).Info("Custom field created successfully")
+
+	return definition, nil
+}
+
+func (s *CustomFieldService) GetCustomField(ctx context.Context, id uuid.UUID) (*domain.CustomFieldDefinition, error) {
+	s.logger.WithFields(logrus.Fields{
+		"custom_field_id": id,
+	}).Debug("Getting custom field")
+
+	definition, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"custom_field_id": id,
+		}).Warn("Custom field not found")
+		return nil, err
+	}
+
+	return definition, nil
+}
+
+func (s *CustomFieldService) ListCustomFields(ctx context.Context, filter domain.CustomFieldParams) ([]domain.CustomFieldDefinition, error) {
+	s.logger.WithFields(logrus.Fields{
+		"entity":      filter.Entity,
+		"project_id":  filter.ProjectID,
+		"exact_scope": filter.ExactScope,
+	}).Debug("Listing custom fields")
+
+	definitions, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list custom fields from repository")
+		return nil, err
+	}
+
+	return definitions, nil
+}
+
+// UpdateCustomField changes the label, options and required flag of a
+// definition. Its key, type and scope are fixed once created, since stored
+// values depend on them.
+func (s *CustomFieldService) UpdateCustomField(ctx context.Context, definition *domain.CustomFieldDefinition, actorID uuid.UUID, admin bool) (*domain.CustomFieldDefinition, error) {
+	s.logger.WithFields(logrus.Fields{
+		"custom_field_id": definition.ID,
+	}).Info("Updating custom field")
+
+	existing, err := s.repo.GetByID(ctx, definition.ID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"custom_field_id": definition.ID,
+		}).Warn("Custom field not found for update")
+		return nil, err
+	}
+	if err := s.authorize(ctx, existing, actorID, admin); err != nil {
+		return nil, err
+	}
+
+	existing.Label = definition.Label
+	existing.Options = definition.Options
+	existing.Required = definition.Required
+	if err := normalizeCustomFieldDefinition(existing); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = s.clock.Now()
+
+	if err := s.repo.Update(ctx, existing); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"custom_field_id": definition.ID,
+		}).Error("Failed to update custom field in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"custom_field_id": existing.ID,
+	}).Info("Custom field updated successfully")
+
+	return existing, nil
+}
+
+// DeleteCustomField removes the definition along with the values stored
+// under its key.
+func (s *CustomFieldService) DeleteCustomField(ctx context.Context, id, actorID uuid.UUID, admin bool) error {
+	s.logger.WithFields(logrus.Fields{
+		"custom_field_id": id,
+	}).Info("Deleting custom field")
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.authorize(ctx, existing, actorID, admin); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"custom_field_id": id,
+		}).Error("Failed to delete custom field in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"custom_field_id": id,
+	}).Info("Custom field deleted successfully")
+
+	return nil
+}
+
+func (s *CustomFieldService) authorize(ctx context.Context, definition *domain.CustomFieldDefinition, actorID uuid.UUID, admin bool) error {
+	if admin {
+		return nil
+	}
+	if definition.ProjectID == nil {
+		return domain.ErrCustomFieldForbidden
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, *definition.ProjectID)
+	if err != nil {
+		return err
+	}
+	if project.OwnerID != actorID {
+		s.logger.WithFields(logrus.Fields{
+			"project_id": project.ID,
+			"actor_id":   actorID,
+		}).Warn("Custom field change rejected for non-owner")
+		return domain.ErrCustomFieldForbidden
+	}
+	return nil
+}
+
+func normalizeCustomFieldDefinition(definition *domain.CustomFieldDefinition) error {
+	definition.Label = strings.TrimSpace(definition.Label)
+	if definition.Label == "" {
+		definition.Label = definition.Key
+	}
+
+	if definition.Type != domain.CustomFieldSelect {
+		definition.Options = domain.StringList{}
+		return nil
+	}
+
+	seen := make(map[string]bool, len(definition.Options))
+	options := make(domain.StringList, 0, len(definition.Options))
+	for _, option := range definition.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || seen[option] {
+			continue
+		}
+		seen[option] = true
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		return errors.New("select custom fields need at least one option")
+	}
+	definition.Options = options
+	return nil
+}
+
+// checkCustomFields validates values against the definitions that apply to
+// a record of entity in projectID and returns them normalised: numbers as
+// float64 and dates as YYYY-MM-DD. A null value clears the field. Without a
+// repository no fields are defined, so any value is rejected.
+func checkCustomFields(ctx context.Context, repo domain.CustomFieldRepository, entity domain.CustomFieldEntity, projectID *uuid.UUID, values domain.CustomFieldValues) (domain.CustomFieldValues, error) {
+	var definitions []domain.CustomFieldDefinition
+	if repo != nil {
+		var err error
+		definitions, err = repo.List(ctx, domain.CustomFieldParams{Entity: entity, ProjectID: projectID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	byKey := make(map[string]domain.CustomFieldDefinition, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	checked := make(domain.CustomFieldValues, len(values))
+	for key, value := range values {
+		definition, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field %q", key)
+		}
+		if value == nil {
+			continue
+		}
+
+		normalized, err := checkCustomFieldValue(definition, value)
+		if err != nil {
+			return nil, err
+		}
+		checked[key] = normalized
+	}
+
+	for _, definition := range definitions {
+		if _, ok := checked[definition.Key]; definition.Required && !ok {
+			return nil, fmt.Errorf("custom field %q is required", definition.Key)
+		}
+	}
+
+	return checked, nil
+}
+
+func checkCustomFieldValue(definition domain.CustomFieldDefinition, value interface{}) (interface{}, error) {
+	switch definition.Type {
+	case domain.CustomFieldNumber:
+		switch number := value.(type) {
+		case float64:
+			return number, nil
+		case int:
+			return float64(number), nil
+		}
+		return nil, fmt.Errorf("custom field %q must be a number", definition.Key)
+	case domain.CustomFieldDate:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("custom field %q must be a date", definition.Key)
+		}
+		if date, err := time.ParseInLocation(time.DateOnly, text, time.Local); err == nil {
+			return date.Format(time.DateOnly), nil
+		}
+		if date, err := time.Parse(time.RFC3339, text); err == nil {
+			return date.In(time.Local).Format(time.DateOnly), nil
+		}
+		return nil, fmt.Errorf("custom field %q must be a date (YYYY-MM-DD or RFC3339)", definition.Key)
+	case domain.CustomFieldSelect:
+		text, ok := value.(string)
+		for _, option := range definition.Options {
+			if ok && text == option {
+				return text, nil
+			}
+		}
+		return nil, &domain.InvalidValueError{Field: "custom field " + definition.Key, Value: fmt.Sprint(value), Allowed: definition.Options}
+	default:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("custom field %q must be text", definition.Key)
+		}
+		return text, nil
+	}
+}
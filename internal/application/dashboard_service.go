@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type DashboardService struct {
+	repo              domain.DashboardRepository
+	itemRepo          domain.ProjectItemRepository
+	notificationRepo  domain.NotificationRepository
+	productRepo       domain.ProductRepository
+	lowStockThreshold int
+	logger            *logrus.Logger
+	clock             domain.Clock
+}
+
+func NewDashboardService(repo domain.DashboardRepository, itemRepo domain.ProjectItemRepository, notificationRepo domain.NotificationRepository, productRepo domain.ProductRepository) *DashboardService {
+	return &DashboardService{
+		repo:              repo,
+		itemRepo:          itemRepo,
+		notificationRepo:  notificationRepo,
+		productRepo:       productRepo,
+		lowStockThreshold: domain.DefaultLowStockThreshold,
+		logger:            logrus.New(),
+		clock:             domain.SystemClock{},
+	}
+}
+
+func (s *DashboardService) WithClock(clock domain.Clock) *DashboardService {
+	s.clock = clock
+	return s
+}
+
+func (s *DashboardService) WithLowStockThreshold(threshold int) *DashboardService {
+	s.lowStockThreshold = threshold
+	return s
+}
+
+// GetDashboard assembles the landing screen of userID. Any failing part
+// fails the whole dashboard rather than returning a partial one.
+func (s *DashboardService) GetDashboard(ctx context.Context, userID uuid.UUID) (*domain.Dashboard, error) {
+	now := s.clock.Now()
+	s.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+	}).Debug("Building dashboard")
+
+	dashboard := &domain.Dashboard{
+		LowStockThreshold: s.lowStockThreshold,
+		GeneratedAt:       now,
+	}
+	limit := domain.Pagination{Limit: domain.DashboardListLimit}
+
+	var err error
+	dashboard.AssignedItems, err = s.itemRepo.List(ctx, domain.ProjectItemParams{AssignedTo: &userID, OpenOnly: true},
+		domain.Pagination{Limit: domain.DashboardListLimit, Sort: "due_date asc NULLS LAST, id asc"})
+	if err != nil {
+		return nil, s.failed("assigned items", userID, err)
+	}
+
+	counts, err := s.repo.ItemCounts(ctx, userID, startOfDay(now))
+	if err != nil {
+		return nil, s.failed("item counts", userID, err)
+	}
+	dashboard.Items = *counts
+
+	dashboard.RecentActivity, err = s.notificationRepo.ListByUser(ctx, userID, false, limit)
+	if err != nil {
+		return nil, s.failed("recent activity", userID, err)
+	}
+
+	dashboard.OwnedProjects, err = s.repo.ProjectStatusCounts(ctx, userID)
+	if err != nil {
+		return nil, s.failed("owned projects", userID, err)
+	}
+
+	dashboard.LowStock, err = s.productRepo.ListLowStock(ctx, s.lowStockThreshold,
+		domain.Pagination{Limit: domain.DashboardListLimit, Sort: "stock asc, id asc"})
+	if err != nil {
+		return nil, s.failed("low stock", userID, err)
+	}
+
+	dashboard.AssignedItems = emptyIfNil(dashboard.AssignedItems)
+	dashboard.RecentActivity = emptyIfNil(dashboard.RecentActivity)
+	dashboard.OwnedProjects = emptyIfNil(dashboard.OwnedProjects)
+	dashboard.LowStock = emptyIfNil(dashboard.LowStock)
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":   userID,
+		"open":      dashboard.Items.Open,
+		"overdue":   dashboard.Items.Overdue,
+		"low_stock": len(dashboard.LowStock),
+	}).Debug("Dashboard built successfully")
+
+	return dashboard, nil
+}
+
+func (s *DashboardService) failed(part string, userID uuid.UUID, err error) error {
+	s.logger.WithFields(logrus.Fields{
+		"error":   err.Error(),
+		"part":    part,
+		"user_id": userID,
+	}).Error("Failed to build dashboard")
+	return err
+}
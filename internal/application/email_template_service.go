@@ -0,0 +1,82 @@
+package application
+
+import (
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type EmailTemplateService struct {
+	renderer domain.EmailRenderer
+	logger   *logrus.Logger
+	clock    domain.Clock
+}
+
+func NewEmailTemplateService(renderer domain.EmailRenderer) *EmailTemplateService {
+	return &EmailTemplateService{
+		renderer: renderer,
+		logger:   logrus.New(),
+		clock:    domain.SystemClock{},
+	}
+}
+
+func (s *EmailTemplateService) WithClock(clock domain.Clock) *EmailTemplateService {
+	s.clock = clock
+	return s
+}
+
+func (s *EmailTemplateService) ListEmailTemplates() []domain.EmailTemplateInfo {
+	templates := make([]domain.EmailTemplateInfo, 0, len(domain.EmailTemplates))
+	for _, template := range domain.EmailTemplates {
+		templates = append(templates, domain.EmailTemplateInfo{Name: template, Locales: domain.Locales})
+	}
+	return templates
+}
+
+// PreviewEmailTemplate renders template in locale with made-up data, so
+// admins can check a template without waiting for the event that sends it.
+func (s *EmailTemplateService) PreviewEmailTemplate(template domain.EmailTemplate, locale domain.Locale) (*domain.EmailContent, error) {
+	s.logger.WithFields(logrus.Fields{
+		"template": template,
+		"locale":   locale,
+	}).Info("Previewing email template")
+
+	if template.Validate() != nil {
+		return nil, domain.ErrEmailTemplateNotFound
+	}
+	if err := locale.Validate(); err != nil {
+		return nil, err
+	}
+	if s.renderer == nil {
+		return nil, errors.New("email templates are not configured")
+	}
+
+	sample, ok := emailTemplateSamples[template]
+	if !ok {
+		return nil, domain.ErrEmailTemplateNotFound
+	}
+	return s.renderer.Render(template, locale, sample(s.clock.Now()))
+}
+
+// emailTemplateSamples builds the preview data of each template.
+var emailTemplateSamples = map[domain.EmailTemplate]func(now time.Time) any{
+	domain.EmailTemplateReportDelivery: func(now time.Time) any {
+		return domain.ReportDeliveryEmail{
+			Name:        "Weekly stock",
+			Report:      domain.ReportStockByCategory,
+			Format:      domain.ReportFormatCSV,
+			GeneratedAt: now,
+			Rows:        12,
+		}
+	},
+	domain.EmailTemplatePasswordReset: func(now time.Time) any {
+		return domain.PasswordResetEmail{
+			Name:      "Jane Doe",
+			Token:     "sample-reset-token",
+			URL:       "https://app.example.com/reset-password?token=sample-reset-token",
+			ExpiresAt: now.Add(domain.DefaultPasswordResetTTL),
+		}
+	},
+}
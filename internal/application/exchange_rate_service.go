@@ -0,0 +1,268 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// ExchangeRateService keeps the rates of the store currency into the others
+// in sync with a provider. Without a fetcher the stored rates are served as
+// they are and refreshing fails with domain.ErrExchangeRatesDisabled.
+type ExchangeRateService struct {
+	repo       domain.ExchangeRateRepository
+	fetcher    domain.ExchangeRateFetcher
+	provider   domain.ExchangeRateProvider
+	base       string
+	staleAfter time.Duration
+	logger     *logrus.Logger
+	clock      domain.Clock
+	ids        domain.IDGenerator
+}
+
+func NewExchangeRateService(repo domain.ExchangeRateRepository) *ExchangeRateService {
+	return &ExchangeRateService{
+		repo:       repo,
+		base:       domain.DefaultCurrency,
+		staleAfter: domain.DefaultExchangeRateStaleAfter,
+		logger:     logrus.New(),
+		clock:      domain.SystemClock{},
+		ids:        domain.UUIDv7Generator{},
+	}
+}
+
+func (s *ExchangeRateService) WithClock(clock domain.Clock) *ExchangeRateService {
+	s.clock = clock
+	return s
+}
+
+func (s *ExchangeRateService) WithIDGenerator(ids domain.IDGenerator) *ExchangeRateService {
+	s.ids = ids
+	return s
+}
+
+// WithFetcher enables syncing the rates from provider.
+func (s *ExchangeRateService) WithFetcher(provider domain.ExchangeRateProvider, fetcher domain.ExchangeRateFetcher) *ExchangeRateService {
+	s.provider = provider
+	s.fetcher = fetcher
+	return s
+}
+
+// WithBase sets the store currency the rates are fetched for.
+func (s *ExchangeRateService) WithBase(base string) *ExchangeRateService {
+	s.base = base
+	return s
+}
+
+// WithStaleAfter sets how old the rates may get before the metrics report
+// them as stale.
+func (s *ExchangeRateService) WithStaleAfter(staleAfter time.Duration) *ExchangeRateService {
+	s.staleAfter = staleAfter
+	return s
+}
+
+// ListExchangeRates returns the stored rates of the store currency.
+func (s *ExchangeRateService) ListExchangeRates(ctx context.Context) ([]domain.ExchangeRate, error) {
+	s.logger.WithFields(logrus.Fields{
+		"base": s.base,
+	}).Debug("Listing exchange rates")
+
+	rates, err := s.repo.ListRates(ctx, s.base)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list exchange rates from repository")
+		return nil, err
+	}
+
+	return emptyIfNil(rates), nil
+}
+
+// RefreshExchangeRates syncs the rates right away and returns the recorded
+// sync, which carries the error when the provider failed.
+func (s *ExchangeRateService) RefreshExchangeRates(ctx context.Context, actorID uuid.UUID) (*domain.ExchangeRateSync, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id":  actorID,
+		"provider": s.provider,
+	}).Info("Refreshing exchange rates")
+
+	if s.fetcher == nil {
+		return nil, domain.ErrExchangeRatesDisabled
+	}
+
+	sync := s.sync(ctx, &actorID)
+	return &sync, nil
+}
+
+func (s *ExchangeRateService) ListExchangeRateSyncs(ctx context.Context, pagination domain.Pagination) ([]domain.ExchangeRateSync, error) {
+	syncs, err := s.repo.ListSyncs(ctx, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list exchange rate syncs from repository")
+		return nil, err
+	}
+
+	return emptyIfNil(syncs), nil
+}
+
+// ExchangeRateMetrics tells how old the stored rates are and how the recent
+// syncs went.
+func (s *ExchangeRateService) ExchangeRateMetrics(ctx context.Context) (*domain.ExchangeRateMetrics, error) {
+	metrics, err := s.repo.Metrics(ctx, s.base)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to compute exchange rate metrics from repository")
+		return nil, err
+	}
+
+	metrics.Provider = s.provider
+	metrics.Stale = true
+	if metrics.OldestFetchedAt != nil {
+		age := s.clock.Now().Sub(*metrics.OldestFetchedAt)
+		metrics.AgeSeconds = int64(age / time.Second)
+		metrics.Stale = age > s.staleAfter
+	}
+
+	return metrics, nil
+}
+
+// RunScheduler syncs the rates every interval until ctx is done.
+func (s *ExchangeRateService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.WithFields(logrus.Fields{
+		"interval": interval,
+		"provider": s.provider,
+		"base":     s.base,
+	}).Info("Exchange rate scheduler started")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.SyncDue(ctx, interval); err != nil && ctx.Err() == nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Exchange rate scheduler run failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Exchange rate scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncDue syncs the rates unless a sync was attempted within interval, so
+// restarts and instances sharing the database do not call the provider more
+// often than configured. It reports whether it synced.
+func (s *ExchangeRateService) SyncDue(ctx context.Context, interval time.Duration) (bool, error) {
+	if s.fetcher == nil {
+		return false, domain.ErrExchangeRatesDisabled
+	}
+
+	metrics, err := s.repo.Metrics(ctx, s.base)
+	if err != nil {
+		return false, err
+	}
+	if metrics.LastSyncAt != nil && s.clock.Now().Sub(*metrics.LastSyncAt) < interval {
+		return false, nil
+	}
+
+	s.sync(ctx, nil)
+	return true, nil
+}
+
+// sync fetches and stores the rates and records the attempt, failed or not.
+func (s *ExchangeRateService) sync(ctx context.Context, actorID *uuid.UUID) domain.ExchangeRateSync {
+	ctx, cancel := context.WithTimeout(ctx, domain.ExchangeRateSyncTimeout)
+	defer cancel()
+
+	started := s.clock.Now()
+	sync := domain.ExchangeRateSync{
+		ID:          s.ids.NewID(),
+		Provider:    s.provider,
+		Base:        s.base,
+		TriggeredBy: actorID,
+		StartedAt:   started,
+	}
+
+	rates, err := s.fetch(ctx, started)
+	if err == nil {
+		err = s.repo.SaveRates(ctx, rates)
+	}
+	sync.FinishedAt = s.clock.Now()
+	if err != nil {
+		sync.Error = err.Error()
+		s.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"provider": s.provider,
+			"base":     s.base,
+		}).Error("Failed to sync exchange rates")
+	} else {
+		sync.Currencies = len(rates)
+		s.logger.WithFields(logrus.Fields{
+			"provider":   s.provider,
+			"base":       s.base,
+			"currencies": len(rates),
+		}).Info("Exchange rates synced")
+	}
+
+	if err := s.repo.CreateSync(ctx, &sync); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to record exchange rate sync")
+	}
+
+	return sync
+}
+
+// fetch asks the provider for the rates of the store currency, dropping the
+// base itself and anything that is not a positive rate into an ISO 4217
+// currency.
+func (s *ExchangeRateService) fetch(ctx context.Context, now time.Time) ([]domain.ExchangeRate, error) {
+	quote, err := s.fetcher.Fetch(ctx, s.base)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveAt := quote.EffectiveAt
+	if effectiveAt.IsZero() {
+		effectiveAt = now
+	}
+
+	rates := make([]domain.ExchangeRate, 0, len(quote.Rates))
+	for currency, rate := range quote.Rates {
+		if currency == s.base {
+			continue
+		}
+		if domain.ValidateCurrencyCode(currency) != nil || rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+			s.logger.WithFields(logrus.Fields{
+				"provider": s.provider,
+				"currency": currency,
+				"rate":     rate,
+			}).Warn("Skipping invalid exchange rate")
+			continue
+		}
+		rates = append(rates, domain.ExchangeRate{
+			Base:        s.base,
+			Currency:    currency,
+			Rate:        rate,
+			Provider:    s.provider,
+			EffectiveAt: effectiveAt,
+			FetchedAt:   now,
+		})
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("provider returned no exchange rates")
+	}
+
+	return rates, nil
+}
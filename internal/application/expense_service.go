@@ -0,0 +1,287 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ExpenseService struct {
+	repo        domain.ExpenseRepository
+	projectRepo domain.ProjectRepository
+	logger      *logrus.Logger
+	clock       domain.Clock
+	ids         domain.IDGenerator
+}
+
+func NewExpenseService(repo domain.ExpenseRepository, projectRepo domain.ProjectRepository) *ExpenseService {
+	return &ExpenseService{
+		repo:        repo,
+		projectRepo: projectRepo,
+		logger:      logrus.New(),
+		clock:       domain.SystemClock{},
+		ids:         domain.UUIDv7Generator{},
+	}
+}
+
+func (s *ExpenseService) WithClock(clock domain.Clock) *ExpenseService {
+	s.clock = clock
+	return s
+}
+
+func (s *ExpenseService) WithIDGenerator(ids domain.IDGenerator) *ExpenseService {
+	s.ids = ids
+	return s
+}
+
+func (s *ExpenseService) CreateExpense(ctx context.Context, expense *domain.Expense) (*domain.Expense, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id": expense.ProjectID,
+		"amount":     expense.Amount,
+		"category":   expense.Category,
+	}).Info("Creating new expense")
+
+	if err := s.validateExpense(expense); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.projectRepo.GetByID(ctx, expense.ProjectID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": expense.ProjectID,
+		}).Warn("Project not found for expense")
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	expense.ID = s.ids.NewID()
+	expense.CreatedAt = now
+	expense.UpdatedAt = now
+	expense.DeletedAt = nil
+	if expense.Date.IsZero() {
+		expense.Date = now
+	}
+
+	if err := s.repo.Create(ctx, expense); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": expense.ProjectID,
+		}).Error("Failed to create expense in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"expense_id": expense.ID,
+		"project_id": expense.ProjectID,
+	}).Info("Expense created successfully")
+
+	return expense, nil
+}
+
+// GetExpense returns an expense only if it belongs to projectID.
+func (s *ExpenseService) GetExpense(ctx context.Context, projectID, id uuid.UUID) (*domain.Expense, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"expense_id": id,
+	}).Debug("Getting expense by ID")
+
+	expense, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"expense_id": id,
+		}).Warn("Expense not found by ID")
+		return nil, err
+	}
+	if expense.ProjectID != projectID {
+		s.logger.WithFields(logrus.Fields{
+			"expense_id": id,
+			"project_id": projectID,
+		}).Warn("Expense belongs to another project")
+		return nil, errors.New("expense not found")
+	}
+
+	return expense, nil
+}
+
+func (s *ExpenseService) ListExpenses(ctx context.Context, projectID uuid.UUID, filter domain.ExpenseParams, pagination domain.Pagination) ([]domain.Expense, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id":      projectID,
+		"filter_category": filter.Category,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
+	}).Debug("Listing expenses")
+
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+		}).Warn("Project not found for expense listing")
+		return nil, err
+	}
+
+	expenses, err := s.repo.List(ctx, projectID, filter, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+		}).Error("Failed to list expenses from repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"count":      len(expenses),
+	}).Info("Expenses listed successfully")
+
+	return expenses, nil
+}
+
+func (s *ExpenseService) UpdateExpense(ctx context.Context, expense *domain.Expense) error {
+	s.logger.WithFields(logrus.Fields{
+		"expense_id": expense.ID,
+		"project_id": expense.ProjectID,
+	}).Info("Updating expense")
+
+	if err := s.validateExpense(expense); err != nil {
+		return err
+	}
+
+	existingExpense, err := s.GetExpense(ctx, expense.ProjectID, expense.ID)
+	if err != nil {
+		return err
+	}
+
+	expense.CreatedBy = existingExpense.CreatedBy
+	expense.CreatedAt = existingExpense.CreatedAt
+	expense.UpdatedAt = s.clock.Now()
+	if expense.Date.IsZero() {
+		expense.Date = existingExpense.Date
+	}
+
+	if err := s.repo.Update(ctx, expense); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"expense_id": expense.ID,
+		}).Error("Failed to update expense in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"expense_id": expense.ID,
+	}).Info("Expense updated successfully")
+
+	return nil
+}
+
+func (s *ExpenseService) DeleteExpense(ctx context.Context, projectID, id uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"expense_id": id,
+	}).Info("Deleting expense")
+
+	if _, err := s.GetExpense(ctx, projectID, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"expense_id": id,
+		}).Error("Failed to delete expense in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"expense_id": id,
+	}).Info("Expense deleted successfully")
+
+	return nil
+}
+
+// GetProjectStats reports how much of the project's budget its expenses have
+// consumed. A warning is added once consumption reaches
+// domain.BudgetWarningThreshold, and OverBudget is set when spending exceeds
+// the budget.
+func (s *ExpenseService) GetProjectStats(ctx context.Context, projectID uuid.UUID) (*domain.ProjectBudgetStats, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+	}).Debug("Computing project budget stats")
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+		}).Warn("Project not found for stats")
+		return nil, err
+	}
+
+	categories, err := s.repo.TotalsByCategory(ctx, projectID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+		}).Error("Failed to sum project expenses")
+		return nil, err
+	}
+	if categories == nil {
+		categories = []domain.ExpenseCategoryTotal{}
+	}
+
+	stats := &domain.ProjectBudgetStats{
+		ProjectID:  project.ID,
+		Budget:     project.Budget,
+		Categories: categories,
+		Warnings:   []string{},
+	}
+	for _, category := range categories {
+		stats.Spent += category.Amount
+	}
+	stats.Spent = domain.RoundMoney(stats.Spent.Float64())
+
+	if project.Budget != nil {
+		remaining := domain.RoundMoney((*project.Budget - stats.Spent).Float64())
+		stats.Remaining = &remaining
+		if *project.Budget > 0 {
+			consumption := stats.Spent.Float64() / project.Budget.Float64()
+			stats.Consumption = &consumption
+		}
+
+		switch {
+		case remaining < 0:
+			stats.OverBudget = true
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("over budget by %.2f", -remaining))
+		case stats.Consumption != nil && *stats.Consumption >= domain.BudgetWarningThreshold:
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("%.0f%% of budget consumed", *stats.Consumption*100))
+		}
+	}
+
+	if stats.OverBudget {
+		s.logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"budget":     *project.Budget,
+			"spent":      stats.Spent,
+		}).Warn("Project is over budget")
+	}
+
+	return stats, nil
+}
+
+func (s *ExpenseService) validateExpense(expense *domain.Expense) error {
+	expense.Category = strings.ToLower(strings.TrimSpace(expense.Category))
+	if expense.Category == "" {
+		return errors.New("expense category is required")
+	}
+	amount, err := domain.NewMoney(expense.Amount.Float64())
+	if err != nil || amount == 0 {
+		return errors.New("expense amount must be greater than zero")
+	}
+	expense.Amount = amount
+	return nil
+}
@@ -0,0 +1,188 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var faultMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// FaultService keeps the fault injection rules in memory, so they apply to
+// the instance they were created on and are gone after a restart.
+type FaultService struct {
+	mu     sync.RWMutex
+	rules  []domain.FaultRule
+	logger *logrus.Logger
+	clock  domain.Clock
+	ids    domain.IDGenerator
+	random func() float64
+}
+
+func NewFaultService() *FaultService {
+	return &FaultService{
+		logger: logrus.New(),
+		clock:  domain.SystemClock{},
+		ids:    domain.UUIDv7Generator{},
+		random: rand.Float64,
+	}
+}
+
+func (s *FaultService) WithClock(clock domain.Clock) *FaultService {
+	s.clock = clock
+	return s
+}
+
+func (s *FaultService) WithIDGenerator(ids domain.IDGenerator) *FaultService {
+	s.ids = ids
+	return s
+}
+
+// WithRandom replaces the source of the rolls deciding which requests fail;
+// random returns values in [0, 1).
+func (s *FaultService) WithRandom(random func() float64) *FaultService {
+	s.random = random
+	return s
+}
+
+func (s *FaultService) CreateFaultRule(ctx context.Context, rule *domain.FaultRule, actorID uuid.UUID) (*domain.FaultRule, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id":    actorID,
+		"method":     rule.Method,
+		"path":       rule.Path,
+		"latency_ms": rule.LatencyMS,
+		"error_rate": rule.ErrorRate,
+		"drop_rate":  rule.DropRate,
+	}).Warn("Creating fault injection rule")
+
+	if err := validateFaultRule(rule); err != nil {
+		return nil, err
+	}
+
+	rule.ID = s.ids.NewID()
+	rule.Method = strings.ToUpper(rule.Method)
+	rule.CreatedBy = actorID
+	rule.CreatedAt = s.clock.Now()
+
+	s.mu.Lock()
+	s.rules = append(s.rules, *rule)
+	s.mu.Unlock()
+
+	return rule, nil
+}
+
+// ListFaultRules returns the rules in the order they are tried, expired
+// ones included.
+func (s *FaultService) ListFaultRules(ctx context.Context) ([]domain.FaultRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]domain.FaultRule{}, s.rules...), nil
+}
+
+func (s *FaultService) DeleteFaultRule(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.rules, func(rule domain.FaultRule) bool { return rule.ID == id })
+	if i < 0 {
+		return domain.ErrFaultRuleNotFound
+	}
+	s.rules = slices.Delete(s.rules, i, i+1)
+
+	s.logger.WithFields(logrus.Fields{
+		"rule_id": id,
+	}).Info("Fault injection rule deleted")
+
+	return nil
+}
+
+// ClearFaultRules deletes every rule and returns how many there were.
+func (s *FaultService) ClearFaultRules(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := len(s.rules)
+	s.rules = nil
+
+	s.logger.WithFields(logrus.Fields{
+		"count": count,
+	}).Info("Fault injection rules cleared")
+
+	return count, nil
+}
+
+// Fault decides what happens to a request for route. The first rule that
+// matches applies; ok is false when none does.
+func (s *FaultService) Fault(method, route string) (domain.Fault, bool) {
+	now := s.clock.Now()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := range s.rules {
+		rule := &s.rules[i]
+		if !rule.Matches(method, route, now) {
+			continue
+		}
+
+		fault := domain.Fault{
+			RuleID:  rule.ID,
+			Latency: time.Duration(rule.LatencyMS) * time.Millisecond,
+		}
+		if rule.JitterMS > 0 {
+			fault.Latency += time.Duration(s.random() * float64(time.Duration(rule.JitterMS)*time.Millisecond))
+		}
+		roll := s.random()
+		switch {
+		case roll < rule.DropRate:
+			fault.Drop = true
+		case roll < rule.DropRate+rule.ErrorRate:
+			fault.Status = rule.ErrorStatus
+		}
+		return fault, true
+	}
+
+	return domain.Fault{}, false
+}
+
+// validateFaultRule defaults the error status and checks the method, path,
+// delay and rates.
+func validateFaultRule(rule *domain.FaultRule) error {
+	if rule.ErrorStatus == 0 {
+		rule.ErrorStatus = domain.DefaultFaultErrorStatus
+	}
+
+	if rule.Method != "" && !slices.Contains(faultMethods, strings.ToUpper(rule.Method)) {
+		return fmt.Errorf("method %q is not one routes are registered for", rule.Method)
+	}
+	if rule.Path != "" && !strings.HasPrefix(rule.Path, "/") {
+		return errors.New("path must start with /")
+	}
+	if rule.LatencyMS < 0 || rule.JitterMS < 0 {
+		return errors.New("latency_ms and jitter_ms must not be negative")
+	}
+	if int64(rule.LatencyMS)+int64(rule.JitterMS) > domain.MaxFaultLatency.Milliseconds() {
+		return fmt.Errorf("latency_ms plus jitter_ms must not exceed %d", domain.MaxFaultLatency.Milliseconds())
+	}
+	if rule.ErrorRate < 0 || rule.DropRate < 0 || rule.ErrorRate+rule.DropRate > 1 {
+		return errors.New("error_rate and drop_rate must not be negative and must add up to at most 1")
+	}
+	if rule.ErrorStatus < 400 || rule.ErrorStatus > 599 {
+		return errors.New("error_status must be between 400 and 599")
+	}
+	if rule.LatencyMS == 0 && rule.JitterMS == 0 && rule.ErrorRate == 0 && rule.DropRate == 0 {
+		return errors.New("a rule must add latency, errors or dropped connections")
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+package application
+
+type ImportRow[T any] struct {
+	Line   int
+	Record T
+}
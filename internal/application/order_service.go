@@ -0,0 +1,365 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type OrderService struct {
+	repo          domain.OrderRepository
+	productRepo   domain.ProductRepository
+	warehouseRepo domain.WarehouseRepository
+	couponRepo    domain.CouponRepository
+	logger        *logrus.Logger
+	clock         domain.Clock
+	ids           domain.IDGenerator
+}
+
+func NewOrderService(repo domain.OrderRepository, productRepo domain.ProductRepository, warehouseRepo domain.WarehouseRepository) *OrderService {
+	return &OrderService{
+		repo:          repo,
+		productRepo:   productRepo,
+		warehouseRepo: warehouseRepo,
+		logger:        logrus.New(),
+		clock:         domain.SystemClock{},
+		ids:           domain.UUIDv7Generator{},
+	}
+}
+
+func (s *OrderService) WithClock(clock domain.Clock) *OrderService {
+	s.clock = clock
+	return s
+}
+
+func (s *OrderService) WithIDGenerator(ids domain.IDGenerator) *OrderService {
+	s.ids = ids
+	return s
+}
+
+// WithCoupons sets where the coupons given with orders are looked up.
+func (s *OrderService) WithCoupons(repo domain.CouponRepository) *OrderService {
+	s.couponRepo = repo
+	return s
+}
+
+// CreateOrder places a pending order for order.UserID, pricing its items
+// from the products and taking them out of stock. A coupon named by
+// order.CouponCode must be redeemable and apply to at least one item; it is
+// redeemed with the order.
+func (s *OrderService) CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": order.UserID,
+		"items":   len(order.Items),
+	}).Info("Creating new order")
+
+	if order.WarehouseID != nil {
+		if _, err := s.warehouseRepo.GetByID(ctx, *order.WarehouseID); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err.Error(),
+				"warehouse_id": *order.WarehouseID,
+			}).Warn("Warehouse not found for order")
+			return nil, err
+		}
+	}
+
+	var coupon *domain.Coupon
+	code := normalizeCouponCode(order.CouponCode)
+	order.CouponID, order.CouponCode = nil, ""
+	if code != "" {
+		if s.couponRepo == nil {
+			return nil, errors.New("coupons are not configured")
+		}
+		var err error
+		coupon, err = s.couponRepo.GetByCode(ctx, code)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"code":  code,
+			}).Warn("Coupon not found for order")
+			return nil, errors.New("coupon not found")
+		}
+		if err := checkCouponRedeemable(s.logger, coupon, s.clock.Now()); err != nil {
+			return nil, err
+		}
+		order.CouponID, order.CouponCode = &coupon.ID, coupon.Code
+	}
+
+	now := s.clock.Now()
+	order.ID = s.ids.NewID()
+	order.Status = domain.OrderStatusPending
+	order.PaidAt = nil
+	order.ShippedAt = nil
+	order.DeliveredAt = nil
+	order.CancelledAt = nil
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	order.DeletedAt = nil
+	if err := s.priceItems(ctx, order, coupon); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, order); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": order.UserID,
+		}).Error("Failed to create order in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+		"user_id":  order.UserID,
+		"total":    order.Total,
+	}).Info("Order created successfully")
+
+	return order, nil
+}
+
+// GetOrder returns an order placed by actorID, or any order for an admin.
+// Other users' orders are reported as not found.
+func (s *OrderService) GetOrder(ctx context.Context, id, actorID uuid.UUID, admin bool) (*domain.Order, error) {
+	s.logger.WithFields(logrus.Fields{
+		"order_id": id,
+		"user_id":  actorID,
+	}).Debug("Getting order by ID")
+
+	order, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"order_id": id,
+		}).Warn("Order not found by ID")
+		return nil, err
+	}
+	if !admin && order.UserID != actorID {
+		s.logger.WithFields(logrus.Fields{
+			"order_id": id,
+			"user_id":  actorID,
+		}).Warn("Order hidden from non-owner")
+		return nil, domain.ErrOrderNotFound
+	}
+
+	return order, nil
+}
+
+func (s *OrderService) ListOrders(ctx context.Context, filter domain.OrderParams, pagination domain.Pagination) ([]domain.Order, error) {
+	s.logger.WithFields(logrus.Fields{
+		"filter_user_id": filter.UserID,
+		"filter_status":  filter.Status,
+		"limit":          pagination.Limit,
+		"offset":         pagination.Offset,
+	}).Debug("Listing orders")
+
+	orders, err := s.repo.List(ctx, filter, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list orders from repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"count": len(orders),
+	}).Info("Orders listed successfully")
+
+	return orders, nil
+}
+
+// UpdateOrder replaces the notes and items of a pending order, repricing
+// the items and moving stock by the difference. The warehouse is kept.
+func (s *OrderService) UpdateOrder(ctx context.Context, order *domain.Order, actorID uuid.UUID, admin bool) (*domain.Order, error) {
+	s.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+		"items":    len(order.Items),
+		"user_id":  actorID,
+	}).Info("Updating order")
+
+	existing, err := s.GetOrder(ctx, order.ID, actorID, admin)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Status != domain.OrderStatusPending {
+		s.logger.WithFields(logrus.Fields{
+			"order_id": order.ID,
+			"status":   existing.Status,
+		}).Warn("Only pending orders can be edited")
+		return nil, domain.ErrOrderStatus
+	}
+
+	order.UserID = existing.UserID
+	order.Status = existing.Status
+	order.WarehouseID = existing.WarehouseID
+	order.CouponID = existing.CouponID
+	order.CouponCode = existing.CouponCode
+	order.CreatedAt = existing.CreatedAt
+	order.UpdatedAt = s.clock.Now()
+
+	// The coupon was redeemed when the order was placed, so it is applied
+	// again without checking its validity window or uses.
+	var coupon *domain.Coupon
+	if order.CouponID != nil {
+		if s.couponRepo == nil {
+			return nil, errors.New("coupons are not configured")
+		}
+		coupon, err = s.couponRepo.GetByID(ctx, *order.CouponID)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":     err.Error(),
+				"order_id":  order.ID,
+				"coupon_id": *order.CouponID,
+			}).Warn("Coupon of order not found")
+			return nil, errors.New("the coupon of this order no longer exists")
+		}
+	}
+	if err := s.priceItems(ctx, order, coupon); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, order, actorID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"order_id": order.ID,
+		}).Error("Failed to update order in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+		"total":    order.Total,
+	}).Info("Order updated successfully")
+
+	return order, nil
+}
+
+// DeleteOrder deletes an order that has not shipped and puts its items back
+// into stock. Users other than admins can only delete their pending orders.
+func (s *OrderService) DeleteOrder(ctx context.Context, id, actorID uuid.UUID, admin bool) error {
+	s.logger.WithFields(logrus.Fields{
+		"order_id": id,
+		"user_id":  actorID,
+	}).Info("Deleting order")
+
+	order, err := s.GetOrder(ctx, id, actorID, admin)
+	if err != nil {
+		return err
+	}
+	if !admin && order.Status != domain.OrderStatusPending {
+		s.logger.WithFields(logrus.Fields{
+			"order_id": id,
+			"status":   order.Status,
+		}).Warn("Only pending orders can be deleted by their owner")
+		return domain.ErrOrderStatus
+	}
+
+	if err := s.repo.Delete(ctx, id, actorID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"order_id": id,
+		}).Error("Failed to delete order in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"order_id": id,
+	}).Info("Order deleted successfully")
+
+	return nil
+}
+
+// UpdateOrderStatus moves an order along its workflow. Users other than
+// admins can only cancel their own pending orders; paying, shipping and
+// delivering are up to admins.
+func (s *OrderService) UpdateOrderStatus(ctx context.Context, id uuid.UUID, status string, actorID uuid.UUID, admin bool) (*domain.Order, error) {
+	s.logger.WithFields(logrus.Fields{
+		"order_id": id,
+		"status":   status,
+		"user_id":  actorID,
+	}).Info("Updating order status")
+
+	if err := domain.ValidateOrderStatus(status); err != nil {
+		return nil, err
+	}
+
+	order, err := s.GetOrder(ctx, id, actorID, admin)
+	if err != nil {
+		return nil, err
+	}
+	if !admin {
+		if status != domain.OrderStatusCancelled {
+			return nil, domain.ErrOrderForbidden
+		}
+		if order.Status != domain.OrderStatusPending {
+			return nil, domain.ErrOrderStatus
+		}
+	}
+
+	order, err = s.repo.UpdateStatus(ctx, id, status, actorID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"order_id": id,
+			"status":   status,
+		}).Warn("Failed to update order status")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"order_id": id,
+		"status":   order.Status,
+	}).Info("Order status updated successfully")
+
+	return order, nil
+}
+
+// priceItems checks the items of an order, assigns their IDs and prices
+// them at the current product price, and totals the order, taking off the
+// discount of coupon when there is one.
+func (s *OrderService) priceItems(ctx context.Context, order *domain.Order, coupon *domain.Coupon) error {
+	if len(order.Items) == 0 {
+		return errors.New("order must contain at least one item")
+	}
+
+	var subtotal, eligibleSubtotal domain.Money
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.Quantity <= 0 {
+			return errors.New("item quantity must be greater than zero")
+		}
+		product, err := s.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":      err.Error(),
+				"product_id": item.ProductID,
+			}).Warn("Product not found for order item")
+			return err
+		}
+		if product.ArchivedAt != nil {
+			return domain.ErrProductArchived
+		}
+
+		item.ID = s.ids.NewID()
+		item.OrderID = order.ID
+		item.UnitPrice = product.Price
+		lineTotal := product.Price.Times(item.Quantity)
+		subtotal += lineTotal
+		if coupon != nil && couponApplies(coupon, product) {
+			eligibleSubtotal += lineTotal
+		}
+	}
+
+	order.Subtotal = domain.RoundMoney(subtotal.Float64())
+	order.Discount = 0
+	if coupon != nil {
+		if eligibleSubtotal == 0 {
+			return errors.New("coupon does not apply to any item in the order")
+		}
+		order.Discount = couponDiscount(coupon, eligibleSubtotal)
+	}
+	order.Total = domain.RoundMoney((order.Subtotal - order.Discount).Float64())
+
+	return nil
+}
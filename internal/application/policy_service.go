@@ -0,0 +1,246 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type PolicyService struct {
+	repo    domain.PolicyRepository
+	enforce bool
+	logger  *logrus.Logger
+	clock   domain.Clock
+	ids     domain.IDGenerator
+}
+
+func NewPolicyService(repo domain.PolicyRepository) *PolicyService {
+	return &PolicyService{
+		repo:   repo,
+		logger: logrus.New(),
+		clock:  domain.SystemClock{},
+		ids:    domain.UUIDv7Generator{},
+	}
+}
+
+func (s *PolicyService) WithClock(clock domain.Clock) *PolicyService {
+	s.clock = clock
+	return s
+}
+
+func (s *PolicyService) WithIDGenerator(ids domain.IDGenerator) *PolicyService {
+	s.ids = ids
+	return s
+}
+
+// WithEnforcement makes CheckPolicyAcceptance refuse users that have not
+// accepted the latest version of every policy. It is off by default.
+func (s *PolicyService) WithEnforcement(enforce bool) *PolicyService {
+	s.enforce = enforce
+	return s
+}
+
+// PublishPolicyDocument stores a new version of a policy, which becomes the
+// one users have to accept.
+func (s *PolicyService) PublishPolicyDocument(ctx context.Context, document *domain.PolicyDocument, actorID uuid.UUID) (*domain.PolicyDocument, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": actorID,
+		"kind":    document.Kind,
+		"version": document.Version,
+	}).Info("Publishing policy document")
+
+	document.Version = strings.TrimSpace(document.Version)
+	document.Title = strings.TrimSpace(document.Title)
+	if err := document.Kind.Validate(); err != nil {
+		return nil, err
+	}
+	if document.Version == "" || len(document.Version) > domain.MaxPolicyVersionLength {
+		return nil, fmt.Errorf("version must be between 1 and %d characters", domain.MaxPolicyVersionLength)
+	}
+	if document.Title == "" {
+		return nil, errors.New("title is required")
+	}
+	if strings.TrimSpace(document.Content) == "" {
+		return nil, errors.New("content is required")
+	}
+
+	document.ID = s.ids.NewID()
+	document.PublishedBy = actorID
+	document.PublishedAt = s.clock.Now()
+
+	if err := s.repo.CreateDocument(ctx, document); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"kind":    document.Kind,
+			"version": document.Version,
+		}).Error("Failed to publish policy document in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"document_id": document.ID,
+		"kind":        document.Kind,
+		"version":     document.Version,
+	}).Info("Policy document published successfully")
+
+	return document, nil
+}
+
+func (s *PolicyService) GetPolicyDocument(ctx context.Context, id uuid.UUID) (*domain.PolicyDocument, error) {
+	s.logger.WithFields(logrus.Fields{
+		"document_id": id,
+	}).Debug("Getting policy document")
+
+	return s.repo.GetDocument(ctx, id)
+}
+
+// ListPolicyDocuments lists every published version, newest first. An empty
+// kind lists all kinds.
+func (s *PolicyService) ListPolicyDocuments(ctx context.Context, kind domain.PolicyKind, pagination domain.Pagination) ([]domain.PolicyDocument, error) {
+	if kind != "" {
+		if err := kind.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	documents, err := s.repo.ListDocuments(ctx, kind, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"kind":  kind,
+		}).Error("Failed to list policy documents from repository")
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// CurrentPolicyDocuments returns the latest version of each published kind.
+func (s *PolicyService) CurrentPolicyDocuments(ctx context.Context) ([]domain.PolicyDocument, error) {
+	documents, err := s.repo.CurrentDocuments(ctx)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to load current policy documents from repository")
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// AcceptPolicyDocument records that userID agreed to the document. Only the
+// latest version of a kind can be accepted; accepting it again returns the
+// original acceptance.
+func (s *PolicyService) AcceptPolicyDocument(ctx context.Context, id, userID uuid.UUID, ipAddress string) (*domain.PolicyAcceptance, error) {
+	s.logger.WithFields(logrus.Fields{
+		"document_id": id,
+		"user_id":     userID,
+	}).Info("Accepting policy document")
+
+	document, err := s.repo.GetDocument(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.CurrentDocuments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, latest := range current {
+		if latest.Kind == document.Kind && latest.ID != document.ID {
+			s.logger.WithFields(logrus.Fields{
+				"document_id": id,
+				"latest_id":   latest.ID,
+				"user_id":     userID,
+			}).Warn("Attempted to accept a superseded policy document")
+			return nil, domain.ErrPolicyDocumentSuperseded
+		}
+	}
+
+	acceptance := &domain.PolicyAcceptance{
+		ID:         s.ids.NewID(),
+		UserID:     userID,
+		DocumentID: document.ID,
+		Kind:       document.Kind,
+		Version:    document.Version,
+		IPAddress:  ipAddress,
+		AcceptedAt: s.clock.Now(),
+	}
+	if err := s.repo.Accept(ctx, acceptance); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"document_id": id,
+			"user_id":     userID,
+		}).Error("Failed to record policy acceptance in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"document_id": id,
+		"user_id":     userID,
+		"version":     acceptance.Version,
+	}).Info("Policy document accepted")
+
+	return acceptance, nil
+}
+
+// GetPolicyStatus returns the user's acceptances and the latest versions
+// they have yet to accept.
+func (s *PolicyService) GetPolicyStatus(ctx context.Context, userID uuid.UUID) (*domain.PolicyStatus, error) {
+	accepted, err := s.repo.ListAcceptances(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.repo.PendingDocuments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PolicyStatus{Accepted: emptyIfNil(accepted), Pending: emptyIfNil(pending)}, nil
+}
+
+// ListPolicyAcceptances lists who accepted a document, newest first.
+func (s *PolicyService) ListPolicyAcceptances(ctx context.Context, documentID uuid.UUID, pagination domain.Pagination) ([]domain.PolicyAcceptance, error) {
+	if _, err := s.repo.GetDocument(ctx, documentID); err != nil {
+		return nil, err
+	}
+
+	acceptances, err := s.repo.ListDocumentAcceptances(ctx, documentID, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"document_id": documentID,
+		}).Error("Failed to list policy acceptances from repository")
+		return nil, err
+	}
+
+	return acceptances, nil
+}
+
+// CheckPolicyAcceptance returns domain.ErrPolicyAcceptanceRequired, along
+// with the documents to accept, when enforcement is on and userID has not
+// accepted the latest version of every policy.
+func (s *PolicyService) CheckPolicyAcceptance(ctx context.Context, userID uuid.UUID) ([]domain.PolicyDocument, error) {
+	if !s.enforce {
+		return nil, nil
+	}
+
+	pending, err := s.repo.PendingDocuments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		s.logger.WithFields(logrus.Fields{
+			"user_id": userID,
+			"pending": len(pending),
+		}).Debug("User has policy documents to accept")
+		return pending, domain.ErrPolicyAcceptanceRequired
+	}
+
+	return nil, nil
+}
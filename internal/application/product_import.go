@@ -0,0 +1,392 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// productCSVColumns are the header names a product CSV file must have.
+var productCSVColumns = []string{"sku", "name", "price"}
+
+// productCSVOptionalColumns are the header names a product CSV file may
+// have. Products already stored keep their value of those a file lacks.
+var productCSVOptionalColumns = []string{"description", "category", "stock"}
+
+// productCSVReader reads products from a CSV file with a header row, one
+// row at a time, so files of any size can be imported.
+type productCSVReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	line    int
+}
+
+func newProductCSVReader(r io.Reader) (*productCSVReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", domain.ErrInvalidImportFile)
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidImportFile, parseErr.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range productCSVColumns {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing required column %q", domain.ErrInvalidImportFile, required)
+		}
+	}
+
+	return &productCSVReader{reader: reader, columns: columns, line: 1}, nil
+}
+
+// optionalColumns returns the productCSVOptionalColumns the file has.
+func (r *productCSVReader) optionalColumns() []string {
+	var present []string
+	for _, name := range productCSVOptionalColumns {
+		if _, ok := r.columns[name]; ok {
+			present = append(present, name)
+		}
+	}
+	return present
+}
+
+// next reads the following row. A row that cannot be turned into a product
+// comes back as rowErr; err is io.EOF at the end of the file or the error
+// that stopped reading it.
+func (r *productCSVReader) next() (row ImportRow[domain.Product], rowErr *domain.ImportRowError, err error) {
+	record, err := r.reader.Read()
+	r.line++
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return row, &domain.ImportRowError{Line: r.line, Error: parseErr.Error()}, nil
+	}
+	if err != nil {
+		return row, nil, err
+	}
+
+	field := func(name string) string {
+		if i, ok := r.columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	product := domain.Product{
+		SKU:         domain.SKU(field("sku")),
+		Name:        field("name"),
+		Description: field("description"),
+		Category:    field("category"),
+	}
+
+	price, err := strconv.ParseFloat(field("price"), 64)
+	if err == nil {
+		product.Price, err = domain.NewMoney(price)
+	}
+	if err != nil {
+		return row, &domain.ImportRowError{Line: r.line, Key: product.SKU.String(), Error: "invalid price: " + field("price")}, nil
+	}
+
+	if stock := field("stock"); stock != "" {
+		product.Stock, err = strconv.Atoi(stock)
+		if err != nil {
+			return row, &domain.ImportRowError{Line: r.line, Key: product.SKU.String(), Error: "invalid stock: " + stock}, nil
+		}
+	}
+
+	return ImportRow[domain.Product]{Line: r.line, Record: product}, nil, nil
+}
+
+// ImportProductCSV reads products from a CSV file with a header row (sku,
+// name and price required; description, category and stock optional) and
+// upserts them by SKU, batchSize rows per transaction. Optional columns the
+// file lacks are left as they are on products already stored. The file is read one
+// row at a time. The category column names an existing category, and the
+// stock column is reached through import stock adjustments made by actorID.
+// Rows that do not parse or validate, name an unknown category, or repeat
+// an SKU of the file, are reported in the result instead of stopping the
+// import; a batch whose stock cannot be adjusted is reported as a whole.
+func (s *ProductService) ImportProductCSV(ctx context.Context, r io.Reader, batchSize int, actorID uuid.UUID) (*domain.ImportResult, error) {
+	return s.importProductCSV(ctx, r, batchSize, actorID, nil)
+}
+
+// importProductCSV is ImportProductCSV calling afterBatch with the result so
+// far after each batch is stored.
+func (s *ProductService) importProductCSV(ctx context.Context, r io.Reader, batchSize int, actorID uuid.UUID, afterBatch func(*domain.ImportResult)) (*domain.ImportResult, error) {
+	s.logger.WithFields(logrus.Fields{
+		"batch_size": batchSize,
+	}).Info("Importing products")
+
+	if batchSize <= 0 {
+		return nil, errors.New("batch size must be greater than zero")
+	}
+
+	reader, err := newProductCSVReader(r)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Warn("Cannot read product CSV header")
+		return nil, err
+	}
+
+	columns := reader.optionalColumns()
+	result := &domain.ImportResult{}
+	seen := make(map[domain.SKU]int)
+
+	// Files tend to repeat a handful of categories, so each name is
+	// resolved once.
+	type resolvedCategory struct {
+		id   *uuid.UUID
+		name string
+		err  error
+	}
+	resolved := make(map[string]resolvedCategory)
+	categories := func(name string) (resolvedCategory, error) {
+		key := strings.ToLower(strings.TrimSpace(name))
+		category, ok := resolved[key]
+		if !ok {
+			category.id, category.name, category.err = s.resolveCategory(ctx, nil, name)
+			if category.err == nil || errors.Is(category.err, domain.ErrUnknownCategory) || errors.Is(category.err, domain.ErrAmbiguousCategory) {
+				resolved[key] = category
+			}
+		}
+		return category, category.err
+	}
+	batch := make([]ImportRow[domain.Product], 0, batchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		s.upsertProductBatch(ctx, batch, columns, actorID, result)
+		batch = batch[:0]
+		if afterBatch != nil {
+			afterBatch(result)
+		}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		row, rowErr, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"line":  reader.line,
+			}).Error("Failed to read product CSV")
+			return nil, err
+		}
+
+		result.Total++
+		if rowErr != nil {
+			result.Reject(rowErr.Line, rowErr.Key, rowErr.Error)
+			continue
+		}
+
+		product := row.Record
+		if err := validateProduct(&product); err != nil {
+			result.Reject(row.Line, strings.TrimSpace(product.SKU.String()), err.Error())
+			continue
+		}
+		if firstLine, ok := seen[product.SKU]; ok {
+			result.Reject(row.Line, product.SKU.String(), fmt.Sprintf("duplicate SKU (first seen on line %d)", firstLine))
+			continue
+		}
+		category, err := categories(product.Category)
+		if err != nil {
+			result.Reject(row.Line, product.SKU.String(), err.Error())
+			continue
+		}
+		product.CategoryID, product.Category = category.id, category.name
+		seen[product.SKU] = row.Line
+
+		now := s.clock.Now()
+		product.ID = s.ids.NewID()
+		product.CreatedAt = now
+		product.UpdatedAt = now
+		product.DeletedAt = nil
+		batch = append(batch, ImportRow[domain.Product]{Line: row.Line, Record: product})
+
+		if len(batch) == batchSize {
+			flush()
+		}
+	}
+	flush()
+
+	s.logger.WithFields(logrus.Fields{
+		"total":    result.Total,
+		"imported": result.Imported,
+		"failed":   result.Failed,
+	}).Info("Product import completed")
+
+	return result, nil
+}
+
+func (s *ProductService) upsertProductBatch(ctx context.Context, batch []ImportRow[domain.Product], columns []string, actorID uuid.UUID, result *domain.ImportResult) {
+	products := make([]domain.Product, len(batch))
+	for i, row := range batch {
+		products[i] = row.Record
+	}
+
+	if err := s.repo.UpsertBySKU(ctx, products, columns, actorID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"first_line": batch[0].Line,
+			"last_line":  batch[len(batch)-1].Line,
+		}).Error("Failed to import product batch")
+		for _, row := range batch {
+			result.Reject(row.Line, row.Record.SKU.String(), err.Error())
+		}
+		return
+	}
+	result.Imported += len(batch)
+}
+
+// StartProductImport imports a product CSV file like ImportProductCSV, but
+// in the background: the file is stored and a pending import returned right
+// away, whose progress is polled through GetProductImport. A file whose
+// header cannot be imported is rejected before anything is stored.
+func (s *ProductService) StartProductImport(ctx context.Context, content []byte, requestedBy uuid.UUID) (*domain.ProductImport, error) {
+	s.logger.WithFields(logrus.Fields{
+		"size":         len(content),
+		"requested_by": requestedBy,
+	}).Info("Starting background product import")
+
+	if s.imports == nil {
+		return nil, errors.New("product imports are not configured")
+	}
+	if len(content) > domain.ProductImportMaxBytes {
+		return nil, fmt.Errorf("%w: larger than %d bytes", domain.ErrInvalidImportFile, domain.ProductImportMaxBytes)
+	}
+	if _, err := newProductCSVReader(bytes.NewReader(content)); err != nil {
+		return nil, err
+	}
+
+	productImport := &domain.ProductImport{
+		ID:          s.ids.NewID(),
+		Status:      domain.ProductImportStatusPending,
+		Content:     content,
+		Size:        len(content),
+		Errors:      domain.ImportRowErrors{},
+		RequestedBy: requestedBy,
+		CreatedAt:   s.clock.Now(),
+	}
+	if err := s.imports.Create(ctx, productImport); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to store product import")
+		return nil, err
+	}
+
+	job := *productImport
+	go s.runProductImport(&job)
+
+	s.logger.WithFields(logrus.Fields{
+		"import_id": productImport.ID,
+	}).Info("Product import queued")
+
+	return productImport, nil
+}
+
+func (s *ProductService) GetProductImport(ctx context.Context, id uuid.UUID) (*domain.ProductImport, error) {
+	s.logger.WithFields(logrus.Fields{
+		"import_id": id,
+	}).Debug("Getting product import")
+
+	if s.imports == nil {
+		return nil, domain.ErrProductImportNotFound
+	}
+
+	productImport, err := s.imports.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"import_id": id,
+		}).Warn("Product import not found")
+		return nil, err
+	}
+
+	return productImport, nil
+}
+
+// runProductImport imports a queued file outside the request that uploaded
+// it, storing the progress after every batch.
+func (s *ProductService) runProductImport(productImport *domain.ProductImport) {
+	ctx, cancel := context.WithTimeout(context.Background(), domain.ProductImportTimeout)
+	defer cancel()
+
+	content := bytes.NewReader(productImport.Content)
+	result, err := s.importProductCSV(ctx, content, domain.ProductImportBatchSize, productImport.RequestedBy, func(result *domain.ImportResult) {
+		productImport.Progress = 100 * (productImport.Size - content.Len()) / productImport.Size
+		recordImportResult(productImport, result)
+		if err := s.imports.UpdateProgress(ctx, productImport); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":     err.Error(),
+				"import_id": productImport.ID,
+			}).Error("Failed to store product import progress")
+		}
+	})
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"import_id": productImport.ID,
+		}).Error("Background product import failed")
+		if err := s.imports.Fail(ctx, productImport.ID, err.Error(), s.clock.Now()); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":     err.Error(),
+				"import_id": productImport.ID,
+			}).Error("Failed to mark product import as failed")
+		}
+		return
+	}
+
+	productImport.Progress = 100
+	recordImportResult(productImport, result)
+	if err := s.imports.Complete(ctx, productImport, s.clock.Now()); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"import_id": productImport.ID,
+		}).Error("Failed to store product import result")
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"import_id": productImport.ID,
+		"total":     result.Total,
+		"imported":  result.Imported,
+		"failed":    result.Failed,
+	}).Info("Background product import completed")
+}
+
+// recordImportResult copies the counters and at most
+// domain.ProductImportMaxErrors row errors of result into productImport.
+func recordImportResult(productImport *domain.ProductImport, result *domain.ImportResult) {
+	productImport.Total = result.Total
+	productImport.Imported = result.Imported
+	productImport.Failed = result.Failed
+	productImport.Errors = domain.ImportRowErrors(result.Errors[:min(len(result.Errors), domain.ProductImportMaxErrors)])
+}
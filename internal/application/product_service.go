@@ -3,6 +3,8 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,24 +14,82 @@ import (
 )
 
 type ProductService struct {
-	repo   domain.ProductRepository
-	logger *logrus.Logger
+	repo       domain.ProductRepository
+	logger     *logrus.Logger
+	clock      domain.Clock
+	ids        domain.IDGenerator
+	skuPattern string
+	related    domain.RelatedProductsStrategy
+	imports    domain.ProductImportRepository
+	categories domain.CategoryRepository
+	lowStock   int
 }
 
 func NewProductService(repo domain.ProductRepository) *ProductService {
 	return &ProductService{
-		repo:   repo,
-		logger: logrus.New(),
+		repo:       repo,
+		logger:     logrus.New(),
+		clock:      domain.SystemClock{},
+		ids:        domain.UUIDv7Generator{},
+		skuPattern: domain.DefaultSKUPattern,
+		lowStock:   domain.DefaultLowStockThreshold,
 	}
 }
 
-func (s *ProductService) CreateProduct(ctx context.Context, name, description, category, sku string, price float64, stock int) (*domain.Product, error) {
+func (s *ProductService) WithClock(clock domain.Clock) *ProductService {
+	s.clock = clock
+	return s
+}
+
+func (s *ProductService) WithIDGenerator(ids domain.IDGenerator) *ProductService {
+	s.ids = ids
+	return s
+}
+
+// WithSKUPattern sets the pattern used to generate SKUs for products created
+// without one. The pattern must pass domain.ValidateSKUPattern.
+func (s *ProductService) WithSKUPattern(pattern string) *ProductService {
+	s.skuPattern = pattern
+	return s
+}
+
+// WithRelatedProducts sets the strategy behind RelatedProducts. Without one
+// no products are recommended.
+func (s *ProductService) WithRelatedProducts(strategy domain.RelatedProductsStrategy) *ProductService {
+	s.related = strategy
+	return s
+}
+
+// WithImports stores background CSV imports in repo. Without it
+// StartProductImport fails.
+func (s *ProductService) WithImports(repo domain.ProductImportRepository) *ProductService {
+	s.imports = repo
+	return s
+}
+
+// WithCategories makes products point at categories of repo, named by
+// category_id or by name. Without it the category is free text.
+func (s *ProductService) WithCategories(repo domain.CategoryRepository) *ProductService {
+	s.categories = repo
+	return s
+}
+
+// WithLowStockThreshold sets the level at or below which products without
+// a reorder level of their own are low on stock.
+func (s *ProductService) WithLowStockThreshold(threshold int) *ProductService {
+	s.lowStock = threshold
+	return s
+}
+
+func (s *ProductService) CreateProduct(ctx context.Context, name, description, category string, categoryID *uuid.UUID, sku, barcode string, price float64, stock int) (*domain.Product, error) {
 	s.logger.WithFields(logrus.Fields{
-		"name":     name,
-		"category": category,
-		"sku":      sku,
-		"price":    price,
-		"stock":    stock,
+		"name":        name,
+		"category":    category,
+		"category_id": categoryID,
+		"sku":         sku,
+		"barcode":     barcode,
+		"price":       price,
+		"stock":       stock,
 	}).Info("Creating new product")
 
 	if strings.TrimSpace(name) == "" {
@@ -39,14 +99,8 @@ func (s *ProductService) CreateProduct(ctx context.Context, name, description, c
 		return nil, errors.New("product name is required")
 	}
 
-	if strings.TrimSpace(sku) == "" {
-		s.logger.WithFields(logrus.Fields{
-			"sku": sku,
-		}).Warn("Product SKU is empty")
-		return nil, errors.New("product SKU is required")
-	}
-
-	if price <= 0 {
+	productPrice, err := domain.NewMoney(price)
+	if err != nil || productPrice == 0 {
 		s.logger.WithFields(logrus.Fields{
 			"price": price,
 		}).Warn("Invalid product price")
@@ -60,24 +114,53 @@ func (s *ProductService) CreateProduct(ctx context.Context, name, description, c
 		return nil, errors.New("product stock cannot be negative")
 	}
 
-	existingProduct, err := s.repo.GetBySKU(ctx, sku)
-	if err == nil && existingProduct != nil {
-		s.logger.WithFields(logrus.Fields{
-			"sku": sku,
-		}).Warn("Product SKU already exists")
-		return nil, errors.New("product SKU already exists")
+	categoryID, category, err = s.resolveCategory(ctx, categoryID, category)
+	if err != nil {
+		return nil, err
+	}
+
+	var productSKU domain.SKU
+	if strings.TrimSpace(sku) == "" {
+		productSKU, err = s.generateSKU(ctx, category)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		productSKU, err = domain.ParseSKU(sku)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"sku": sku,
+			}).Warn("Invalid product SKU")
+			return nil, err
+		}
+		if existingProduct, err := s.repo.GetBySKU(ctx, productSKU.String()); err == nil && existingProduct != nil {
+			s.logger.WithFields(logrus.Fields{
+				"sku": productSKU,
+			}).Warn("Product SKU already exists")
+			return nil, errors.New("product SKU already exists")
+		}
+	}
+
+	var barcodePtr *string
+	if barcode = strings.TrimSpace(barcode); barcode != "" {
+		if err := s.checkBarcode(ctx, uuid.Nil, barcode); err != nil {
+			return nil, err
+		}
+		barcodePtr = &barcode
 	}
 
 	product := &domain.Product{
-		ID:          uuid.New(),
+		ID:          s.ids.NewID(),
 		Name:        name,
 		Description: description,
-		Price:       price,
+		Price:       productPrice,
 		Stock:       stock,
+		CategoryID:  categoryID,
 		Category:    category,
-		SKU:         sku,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		SKU:         productSKU,
+		Barcode:     barcodePtr,
+		CreatedAt:   s.clock.Now(),
+		UpdatedAt:   s.clock.Now(),
 	}
 
 	s.logger.WithFields(logrus.Fields{
@@ -116,6 +199,16 @@ func (s *ProductService) GetProductByID(ctx context.Context, id uuid.UUID) (*dom
 		return nil, err
 	}
 
+	levels, err := s.repo.StockLevels(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+		}).Error("Failed to load product stock levels")
+		return nil, err
+	}
+	product.Availability = domain.NewProductAvailability(product.Stock, levels)
+
 	s.logger.WithFields(logrus.Fields{
 		"product_id": product.ID,
 		"sku":        product.SKU,
@@ -124,6 +217,44 @@ func (s *ProductService) GetProductByID(ctx context.Context, id uuid.UUID) (*dom
 	return product, nil
 }
 
+// RelatedProducts recommends up to limit products to show next to the
+// product with the given id.
+func (s *ProductService) RelatedProducts(ctx context.Context, id uuid.UUID, limit int) ([]domain.Product, error) {
+	s.logger.WithFields(logrus.Fields{
+		"product_id": id,
+		"limit":      limit,
+	}).Debug("Getting related products")
+
+	product, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+		}).Warn("Product not found for related products")
+		return nil, err
+	}
+
+	if s.related == nil {
+		return []domain.Product{}, nil
+	}
+
+	related, err := s.related.Related(ctx, product, limit)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": id,
+		}).Error("Failed to get related products")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"product_id": id,
+		"count":      len(related),
+	}).Debug("Related products retrieved successfully")
+
+	return related, nil
+}
+
 func (s *ProductService) GetProductBySKU(ctx context.Context, sku string) (*domain.Product, error) {
 	s.logger.WithFields(logrus.Fields{
 		"sku": sku,
@@ -146,6 +277,92 @@ func (s *ProductService) GetProductBySKU(ctx context.Context, sku string) (*doma
 	return product, nil
 }
 
+// SuggestProducts lists unarchived products matching query by name or SKU
+// prefix, best matches first.
+func (s *ProductService) SuggestProducts(ctx context.Context, query string, limit int) ([]domain.Suggestion, error) {
+	s.logger.WithFields(logrus.Fields{
+		"query": query,
+		"limit": limit,
+	}).Debug("Suggesting products")
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []domain.Suggestion{}, nil
+	}
+	return s.repo.Suggest(ctx, query, limit)
+}
+
+// SearchProducts runs a full-text search over the names, descriptions and
+// SKUs of the unarchived products, best ranked first. Blank queries match
+// nothing.
+func (s *ProductService) SearchProducts(ctx context.Context, query string, pagination domain.Pagination) ([]domain.ProductSearchHit, error) {
+	s.logger.WithFields(logrus.Fields{
+		"query":  query,
+		"limit":  pagination.Limit,
+		"offset": pagination.Offset,
+	}).Debug("Searching products")
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []domain.ProductSearchHit{}, nil
+	}
+
+	hits, err := s.repo.Search(ctx, query, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"query": query,
+		}).Error("Failed to search products in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"query": query,
+		"count": len(hits),
+	}).Info("Products searched successfully")
+
+	return hits, nil
+}
+
+// CountProductSearch returns how many products SearchProducts matches for
+// query, for the totals of its pages.
+func (s *ProductService) CountProductSearch(ctx context.Context, query string) (int64, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return 0, nil
+	}
+	return s.repo.CountSearch(ctx, query)
+}
+
+func (s *ProductService) GetProductByBarcode(ctx context.Context, barcode string) (*domain.Product, error) {
+	s.logger.WithFields(logrus.Fields{
+		"barcode": barcode,
+	}).Debug("Getting product by barcode")
+
+	if !domain.ValidBarcode(barcode) {
+		s.logger.WithFields(logrus.Fields{
+			"barcode": barcode,
+		}).Warn("Invalid product barcode")
+		return nil, domain.ErrInvalidBarcode
+	}
+
+	product, err := s.repo.GetByBarcode(ctx, barcode)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"barcode": barcode,
+		}).Warn("Product not found by barcode")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"product_id": product.ID,
+		"barcode":    barcode,
+	}).Debug("Product retrieved successfully by barcode")
+
+	return product, nil
+}
+
 func (s *ProductService) ListProducts(ctx context.Context, filter domain.ProductParams, pagination domain.Pagination) ([]domain.Product, error) {
 	s.logger.WithFields(logrus.Fields{
 		"filter_name":     filter.Name,
@@ -171,10 +388,114 @@ func (s *ProductService) ListProducts(ctx context.Context, filter domain.Product
 	return products, nil
 }
 
+// ListLowStockProducts returns the unarchived products at or below their
+// reorder level, or the low stock threshold when they have none.
+func (s *ProductService) ListLowStockProducts(ctx context.Context, pagination domain.Pagination) ([]domain.Product, error) {
+	s.logger.WithFields(logrus.Fields{
+		"threshold": s.lowStock,
+		"limit":     pagination.Limit,
+		"offset":    pagination.Offset,
+		"sort":      pagination.Sort,
+	}).Debug("Listing low stock products")
+
+	products, err := s.repo.ListLowStock(ctx, s.lowStock, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list low stock products from repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"count": len(products),
+	}).Info("Low stock products listed successfully")
+
+	return products, nil
+}
+
+// CountProducts returns how many products match filter, for the totals of a
+// paginated list.
+func (s *ProductService) CountProducts(ctx context.Context, filter domain.ProductParams) (int64, error) {
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count products in repository")
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (s *ProductService) GetProductFacets(ctx context.Context, filter domain.ProductParams, facets []string) (*domain.ProductFacets, error) {
+	s.logger.WithFields(logrus.Fields{
+		"facets":          facets,
+		"filter_category": filter.Category,
+	}).Debug("Computing product facets")
+
+	for _, facet := range facets {
+		switch facet {
+		case domain.ProductFacetCategory, domain.ProductFacetPrice, domain.ProductFacetStock:
+		default:
+			s.logger.WithFields(logrus.Fields{
+				"facet": facet,
+			}).Warn("Unknown product facet")
+			return nil, fmt.Errorf("%w %q (expected category, price or stock)", domain.ErrUnknownFacet, facet)
+		}
+	}
+
+	facetCounts, err := s.repo.Facets(ctx, filter, facets)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"facets": facets,
+		}).Error("Failed to compute product facets in repository")
+		return nil, err
+	}
+
+	return facetCounts, nil
+}
+
 func (s *ProductService) UpdateProduct(ctx context.Context, product *domain.Product) error {
+	return s.updateProduct(ctx, product, nil)
+}
+
+// PatchProduct stores fields of product, a product with a merge patch
+// applied, leaving every other column as it is. The patched product has to
+// be as valid as a new one.
+func (s *ProductService) PatchProduct(ctx context.Context, product *domain.Product, fields []string) error {
+	// An empty patch changes nothing; with no fields to select, an update
+	// would write every non-zero column.
+	if len(fields) == 0 {
+		return nil
+	}
+	if err := validateProduct(product); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": product.ID,
+		}).Warn("Invalid patched product")
+		return err
+	}
+	// The category is stored as both its ID and its name. A patch naming
+	// only the name moves the product to the category called that, so the
+	// current ID must not win over it.
+	patchesID, patchesName := slices.Contains(fields, "category_id"), slices.Contains(fields, "category")
+	if patchesName && !patchesID {
+		product.CategoryID = nil
+	}
+	if patchesID || patchesName {
+		fields = append(slices.DeleteFunc(fields, func(field string) bool {
+			return field == "category_id" || field == "category"
+		}), "category_id", "category")
+	}
+	return s.updateProduct(ctx, product, fields)
+}
+
+func (s *ProductService) updateProduct(ctx context.Context, product *domain.Product, fields []string) error {
 	s.logger.WithFields(logrus.Fields{
 		"product_id": product.ID,
 		"sku":        product.SKU,
+		"fields":     fields,
 	}).Info("Updating product")
 
 	if strings.TrimSpace(product.Name) == "" {
@@ -192,17 +513,46 @@ func (s *ProductService) UpdateProduct(ctx context.Context, product *domain.Prod
 		return errors.New("product price must be greater than zero")
 	}
 
-	if product.Stock < 0 {
+	if product.ReorderLevel != nil && *product.ReorderLevel < 0 {
+		s.logger.WithFields(logrus.Fields{
+			"product_id":    product.ID,
+			"reorder_level": *product.ReorderLevel,
+		}).Warn("Invalid product reorder level")
+		return errors.New("product reorder level cannot be negative")
+	}
+
+	existingProduct, err := s.repo.GetByID(ctx, product.ID)
+	if err != nil {
 		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
 			"product_id": product.ID,
-			"stock":      product.Stock,
-		}).Warn("Invalid product stock")
-		return errors.New("product stock cannot be negative")
+		}).Warn("Product not found for update")
+		return err
 	}
 
-	product.UpdatedAt = time.Now()
+	// Stock only changes through stock adjustments so the ledger stays
+	// complete, the archived state only through ArchiveProduct and
+	// UnarchiveProduct, and the cost price only when a purchase order is
+	// received.
+	product.Stock = existingProduct.Stock
+	product.ArchivedAt = existingProduct.ArchivedAt
+	product.CostPrice = existingProduct.CostPrice
+
+	if product.CategoryID, product.Category, err = s.resolveCategory(ctx, product.CategoryID, product.Category); err != nil {
+		return err
+	}
 
-	err := s.repo.Update(ctx, product)
+	if product.Barcode != nil {
+		barcode := strings.TrimSpace(*product.Barcode)
+		if err := s.checkBarcode(ctx, product.ID, barcode); err != nil {
+			return err
+		}
+		product.Barcode = &barcode
+	}
+
+	product.UpdatedAt = s.clock.Now()
+
+	err = s.repo.Update(ctx, product, fields...)
 	if err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
@@ -240,46 +590,176 @@ func (s *ProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
-func (s *ProductService) UpdateProductStock(ctx context.Context, id uuid.UUID, quantity int) error {
+func (s *ProductService) ArchiveProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	now := s.clock.Now()
+	return s.setArchived(ctx, id, &now)
+}
+
+func (s *ProductService) UnarchiveProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	return s.setArchived(ctx, id, nil)
+}
+
+func (s *ProductService) setArchived(ctx context.Context, id uuid.UUID, archivedAt *time.Time) (*domain.Product, error) {
 	s.logger.WithFields(logrus.Fields{
 		"product_id": id,
-		"quantity":   quantity,
-	}).Info("Updating product stock")
+		"archive":    archivedAt != nil,
+	}).Info("Changing product archived state")
 
 	product, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
 			"product_id": id,
-		}).Warn("Product not found for stock update")
-		return err
+		}).Warn("Product not found for archive state change")
+		return nil, err
 	}
 
-	newStock := product.Stock + quantity
-	if newStock < 0 {
+	if (product.ArchivedAt != nil) == (archivedAt != nil) {
 		s.logger.WithFields(logrus.Fields{
-			"product_id":    id,
-			"current_stock": product.Stock,
-			"quantity":      quantity,
-			"new_stock":     newStock,
-		}).Warn("Insufficient stock for update")
-		return errors.New("insufficient stock")
+			"product_id": id,
+		}).Debug("Product archived state already up to date")
+		return product, nil
 	}
 
-	err = s.repo.UpdateStock(ctx, id, newStock)
-	if err != nil {
+	if err := s.repo.SetArchived(ctx, id, archivedAt); err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
 			"product_id": id,
-		}).Error("Failed to update product stock in repository")
-		return err
+		}).Error("Failed to change product archived state in repository")
+		return nil, err
 	}
 
+	product.ArchivedAt = archivedAt
+	product.UpdatedAt = s.clock.Now()
+
 	s.logger.WithFields(logrus.Fields{
-		"product_id": id,
-		"old_stock":  product.Stock,
-		"new_stock":  newStock,
-	}).Info("Product stock updated successfully")
+		"product_id":  id,
+		"archived_at": archivedAt,
+	}).Info("Product archived state changed successfully")
+
+	return product, nil
+}
+
+// maxSKUGenerationAttempts bounds how many sequence values generateSKU skips
+// when a generated SKU was already taken by a manually assigned one.
+const maxSKUGenerationAttempts = 10
+
+func (s *ProductService) generateSKU(ctx context.Context, category string) (domain.SKU, error) {
+	now := s.clock.Now()
+	prefix := domain.SKUSequencePrefix(s.skuPattern, category, now)
+
+	for attempt := 0; attempt < maxSKUGenerationAttempts; attempt++ {
+		seq, err := s.repo.NextSKUSequence(ctx, prefix)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":  err.Error(),
+				"prefix": prefix,
+			}).Error("Failed to allocate SKU sequence value")
+			return "", err
+		}
+
+		sku := domain.RenderSKU(s.skuPattern, category, seq, now)
+		if existingProduct, err := s.repo.GetBySKU(ctx, sku.String()); err == nil && existingProduct != nil {
+			s.logger.WithFields(logrus.Fields{
+				"sku": sku,
+			}).Debug("Generated SKU already taken, trying next sequence value")
+			continue
+		}
+
+		s.logger.WithFields(logrus.Fields{
+			"sku":      sku,
+			"category": category,
+		}).Debug("SKU generated")
+		return sku, nil
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"prefix":   prefix,
+		"attempts": maxSKUGenerationAttempts,
+	}).Error("Could not generate a free SKU")
+	return "", errors.New("could not generate a unique SKU")
+}
+
+// resolveCategory returns the ID and name of the category a product names:
+// the one with categoryID when it is set, otherwise the one called name. An
+// empty name without an ID is no category. Without a category repository
+// the name is kept as free text.
+func (s *ProductService) resolveCategory(ctx context.Context, categoryID *uuid.UUID, name string) (*uuid.UUID, string, error) {
+	name = strings.TrimSpace(name)
+	if s.categories == nil {
+		return categoryID, name, nil
+	}
+
+	if categoryID != nil {
+		category, err := s.categories.GetByID(ctx, *categoryID)
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			return nil, "", domain.ErrUnknownCategory
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return &category.ID, category.Name, nil
+	}
+
+	if name == "" {
+		return nil, "", nil
+	}
+	categories, err := s.categories.FindByName(ctx, name)
+	if err != nil {
+		return nil, "", err
+	}
+	switch len(categories) {
+	case 0:
+		s.logger.WithFields(logrus.Fields{
+			"category": name,
+		}).Warn("Product names an unknown category")
+		return nil, "", fmt.Errorf("%w %q", domain.ErrUnknownCategory, name)
+	case 1:
+		return &categories[0].ID, categories[0].Name, nil
+	default:
+		return nil, "", fmt.Errorf("%w: %q", domain.ErrAmbiguousCategory, name)
+	}
+}
+
+// checkBarcode rejects barcodes with a bad check digit or already assigned to a
+// product other than productID.
+func (s *ProductService) checkBarcode(ctx context.Context, productID uuid.UUID, barcode string) error {
+	if !domain.ValidBarcode(barcode) {
+		s.logger.WithFields(logrus.Fields{
+			"barcode": barcode,
+		}).Warn("Invalid product barcode")
+		return domain.ErrInvalidBarcode
+	}
+
+	existingProduct, err := s.repo.GetByBarcode(ctx, barcode)
+	if err == nil && existingProduct != nil && existingProduct.ID != productID {
+		s.logger.WithFields(logrus.Fields{
+			"barcode":    barcode,
+			"product_id": existingProduct.ID,
+		}).Warn("Product barcode already exists")
+		return errors.New("product barcode already exists")
+	}
+
+	return nil
+}
 
+func validateProduct(product *domain.Product) error {
+	if strings.TrimSpace(product.Name) == "" {
+		return errors.New("product name is required")
+	}
+	if strings.TrimSpace(product.SKU.String()) == "" {
+		return errors.New("product SKU is required")
+	}
+	sku, err := domain.ParseSKU(product.SKU.String())
+	if err != nil {
+		return err
+	}
+	product.SKU = sku
+	if product.Price <= 0 {
+		return errors.New("product price must be greater than zero")
+	}
+	if product.Stock < 0 {
+		return errors.New("product stock cannot be negative")
+	}
 	return nil
 }
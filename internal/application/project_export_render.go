@@ -0,0 +1,196 @@
+package application
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+)
+
+func renderProjectJSON(snapshot *domain.ProjectSnapshot) ([]byte, error) {
+	return json.MarshalIndent(snapshot, "", "  ")
+}
+
+// projectReportTemplate lays out the PDF report as plain text lines; long
+// lines are wrapped when the page is drawn.
+var projectReportTemplate = template.Must(template.New("project-report").Funcs(template.FuncMap{
+	"date":   formatReportDate,
+	"number": formatReportNumber,
+}).Parse(`Project report: {{.Project.Name}}
+Generated at {{date .GeneratedAt}}
+
+Status: {{.Project.Status}}
+Start: {{date .Project.StartDate}}    End: {{date .Project.EndDate}}
+Archived: {{date .Project.ArchivedAt}}
+{{- if .Project.Description}}
+Description: {{.Project.Description}}
+{{- end}}
+
+Members ({{len .Members}})
+{{- range .Members}}
+  - {{.Role}}: {{if .Name}}{{.Name}} <{{.Email}}>{{else}}{{.UserID}}{{end}}
+{{- end}}
+
+Items ({{len .Items}})
+{{- range .Items}}
+  - [{{.Status}}/{{.Priority}}] {{.Name}} - due {{date .DueDate}}, {{number .EstimatedHours}}h estimated, {{number .ActualHours}}h actual
+{{- end}}
+
+Hours: {{printf "%.2f" .Hours.Estimated}} estimated, {{printf "%.2f" .Hours.Actual}} actual
+
+Expenses ({{len .Expenses}})
+{{- range .Expenses}}
+  - {{date .Date}} {{.Category}}: {{printf "%.2f" .Amount}} {{.Description}}
+{{- end}}
+{{with .Stats}}
+Budget: {{number .Budget}}    Spent: {{printf "%.2f" .Spent}}    Remaining: {{number .Remaining}}
+{{- range .Categories}}
+  - {{.Category}}: {{printf "%.2f" .Amount}} in {{.Count}} expenses
+{{- end}}
+{{- range .Warnings}}
+Warning: {{.}}
+{{- end}}
+{{- end}}
+`))
+
+func renderProjectPDF(snapshot *domain.ProjectSnapshot) ([]byte, error) {
+	var text bytes.Buffer
+	if err := projectReportTemplate.Execute(&text, snapshot); err != nil {
+		return nil, err
+	}
+	return writeTextPDF(strings.Split(strings.TrimRight(text.String(), "\n"), "\n")), nil
+}
+
+func formatReportDate(value interface{}) string {
+	switch t := value.(type) {
+	case time.Time:
+		return t.Format(time.DateOnly)
+	case *time.Time:
+		if t != nil {
+			return t.Format(time.DateOnly)
+		}
+	}
+	return "-"
+}
+
+func formatReportNumber(value *float64) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", *value)
+}
+
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 50
+	pdfFontSize     = 10
+	pdfLineHeight   = 14
+	pdfLineChars    = 95
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLineHeight
+)
+
+// writeTextPDF lays lines out on A4 pages in Helvetica. Characters outside
+// Latin-1 are replaced with "?" since the built-in fonts cannot draw them.
+func writeTextPDF(lines []string) []byte {
+	var wrapped []string
+	for _, line := range lines {
+		wrapped = append(wrapped, wrapPDFLine(line)...)
+	}
+
+	var pages [][]string
+	for len(wrapped) > pdfLinesPerPage {
+		pages = append(pages, wrapped[:pdfLinesPerPage])
+		wrapped = wrapped[pdfLinesPerPage:]
+	}
+	pages = append(pages, wrapped)
+
+	// Objects 1-3 are the catalog, the page tree and the font; each page
+	// then takes two objects, the page and its content stream.
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+	)
+
+	for i, page := range pages {
+		var stream bytes.Buffer
+		fmt.Fprintf(&stream, "BT /F1 %d Tf %d TL %d %d Td\n", pdfFontSize, pdfLineHeight, pdfMargin, pdfPageHeight-pdfMargin)
+		for _, line := range page {
+			fmt.Fprintf(&stream, "(%s) '\n", escapePDFText(line))
+		}
+		stream.WriteString("ET")
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pdfPageWidth, pdfPageHeight, 5+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", stream.Len(), stream.String()),
+		)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return out.Bytes()
+}
+
+func wrapPDFLine(line string) []string {
+	runes := []rune(line)
+	if len(runes) <= pdfLineChars {
+		return []string{line}
+	}
+
+	var lines []string
+	for len(runes) > pdfLineChars {
+		cut := pdfLineChars
+		for i := pdfLineChars; i > pdfLineChars/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, string(runes[:cut]))
+		runes = []rune("    " + strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	return append(lines, string(runes))
+}
+
+func escapePDFText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x80:
+			b.WriteRune(r)
+		case r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,286 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ProjectExportService struct {
+	repo        domain.ProjectExportRepository
+	projectRepo domain.ProjectRepository
+	itemRepo    domain.ProjectItemRepository
+	expenseRepo domain.ExpenseRepository
+	userRepo    domain.UserRepository
+	stats       *ExpenseService
+	logger      *logrus.Logger
+	clock       domain.Clock
+	ids         domain.IDGenerator
+}
+
+func NewProjectExportService(repo domain.ProjectExportRepository, projectRepo domain.ProjectRepository, itemRepo domain.ProjectItemRepository, expenseRepo domain.ExpenseRepository, userRepo domain.UserRepository) *ProjectExportService {
+	return &ProjectExportService{
+		repo:        repo,
+		projectRepo: projectRepo,
+		itemRepo:    itemRepo,
+		expenseRepo: expenseRepo,
+		userRepo:    userRepo,
+		stats:       NewExpenseService(expenseRepo, projectRepo),
+		logger:      logrus.New(),
+		clock:       domain.SystemClock{},
+		ids:         domain.UUIDv7Generator{},
+	}
+}
+
+func (s *ProjectExportService) WithClock(clock domain.Clock) *ProjectExportService {
+	s.clock = clock
+	s.stats.WithClock(clock)
+	return s
+}
+
+func (s *ProjectExportService) WithIDGenerator(ids domain.IDGenerator) *ProjectExportService {
+	s.ids = ids
+	return s
+}
+
+// ExportProject renders the project in format. Projects with up to
+// domain.ProjectExportSyncItemLimit items are returned ready with their
+// content; larger ones are returned pending while a background job renders
+// them, and are fetched later through GetProjectExport.
+func (s *ProjectExportService) ExportProject(ctx context.Context, projectID uuid.UUID, format string, requestedBy uuid.UUID) (*domain.ProjectExport, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id":   projectID,
+		"format":       format,
+		"requested_by": requestedBy,
+	}).Info("Exporting project")
+
+	if format != domain.ProjectExportFormatJSON && format != domain.ProjectExportFormatPDF {
+		s.logger.WithFields(logrus.Fields{
+			"format": format,
+		}).Warn("Invalid project export format")
+		return nil, errors.New("format must be json or pdf")
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+		}).Warn("Project not found for export")
+		return nil, err
+	}
+
+	items, err := s.itemRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+		}).Error("Failed to load project items for export")
+		return nil, err
+	}
+
+	export := &domain.ProjectExport{
+		ID:          s.ids.NewID(),
+		ProjectID:   projectID,
+		Format:      format,
+		Status:      domain.ProjectExportStatusPending,
+		RequestedBy: requestedBy,
+		CreatedAt:   s.clock.Now(),
+	}
+
+	if len(items) <= domain.ProjectExportSyncItemLimit {
+		content, err := s.render(ctx, project, items, format)
+		if err != nil {
+			return nil, err
+		}
+
+		completedAt := s.clock.Now()
+		export.Status = domain.ProjectExportStatusReady
+		export.Content = content
+		export.CompletedAt = &completedAt
+
+		s.logger.WithFields(logrus.Fields{
+			"project_id": projectID,
+			"format":     format,
+			"bytes":      len(content),
+		}).Info("Project exported inline")
+
+		return export, nil
+	}
+
+	if err := s.repo.Create(ctx, export); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+		}).Error("Failed to store pending project export")
+		return nil, err
+	}
+
+	go s.generate(export.ID, projectID, format)
+
+	s.logger.WithFields(logrus.Fields{
+		"export_id":  export.ID,
+		"project_id": projectID,
+		"items":      len(items),
+	}).Info("Project export queued")
+
+	return export, nil
+}
+
+func (s *ProjectExportService) GetProjectExport(ctx context.Context, id uuid.UUID) (*domain.ProjectExport, error) {
+	s.logger.WithFields(logrus.Fields{
+		"export_id": id,
+	}).Debug("Getting project export")
+
+	export, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"export_id": id,
+		}).Warn("Project export not found")
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// generate renders a queued export outside the request that asked for it.
+func (s *ProjectExportService) generate(exportID, projectID uuid.UUID, format string) {
+	ctx, cancel := context.WithTimeout(context.Background(), domain.ProjectExportTimeout)
+	defer cancel()
+
+	content, err := s.renderProject(ctx, projectID, format)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"export_id":  exportID,
+			"project_id": projectID,
+		}).Error("Background project export failed")
+		if err := s.repo.Fail(ctx, exportID, err.Error(), s.clock.Now()); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":     err.Error(),
+				"export_id": exportID,
+			}).Error("Failed to mark project export as failed")
+		}
+		return
+	}
+
+	if err := s.repo.Complete(ctx, exportID, content, s.clock.Now()); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"export_id": exportID,
+		}).Error("Failed to store project export")
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"export_id":  exportID,
+		"project_id": projectID,
+		"bytes":      len(content),
+	}).Info("Background project export completed")
+}
+
+func (s *ProjectExportService) renderProject(ctx context.Context, projectID uuid.UUID, format string) ([]byte, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.itemRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.render(ctx, project, items, format)
+}
+
+func (s *ProjectExportService) render(ctx context.Context, project *domain.Project, items []domain.ProjectItem, format string) ([]byte, error) {
+	snapshot, err := s.snapshot(ctx, project, items)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": project.ID,
+		}).Error("Failed to build project snapshot")
+		return nil, err
+	}
+
+	if format == domain.ProjectExportFormatPDF {
+		return renderProjectPDF(snapshot)
+	}
+	return renderProjectJSON(snapshot)
+}
+
+func (s *ProjectExportService) snapshot(ctx context.Context, project *domain.Project, items []domain.ProjectItem) (*domain.ProjectSnapshot, error) {
+	expenses, err := s.expenseRepo.List(ctx, project.ID, domain.ExpenseParams{}, domain.Pagination{Sort: "date asc, created_at asc"})
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.stats.GetProjectStats(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if items == nil {
+		items = []domain.ProjectItem{}
+	}
+	if expenses == nil {
+		expenses = []domain.Expense{}
+	}
+
+	snapshot := &domain.ProjectSnapshot{
+		Project:     *project,
+		Members:     s.members(ctx, project, items),
+		Items:       items,
+		Expenses:    expenses,
+		Stats:       stats,
+		GeneratedAt: s.clock.Now(),
+	}
+	for _, item := range items {
+		if item.EstimatedHours != nil {
+			snapshot.Hours.Estimated += *item.EstimatedHours
+		}
+		if item.ActualHours != nil {
+			snapshot.Hours.Actual += *item.ActualHours
+		}
+	}
+
+	return snapshot, nil
+}
+
+// members lists the owner followed by every distinct assignee. Users that
+// can no longer be loaded are listed by ID only.
+func (s *ProjectExportService) members(ctx context.Context, project *domain.Project, items []domain.ProjectItem) []domain.ProjectMember {
+	members := []domain.ProjectMember{s.member(ctx, project.OwnerID, domain.ProjectMemberOwner)}
+	seen := map[uuid.UUID]bool{project.OwnerID: true}
+
+	for _, item := range items {
+		if item.AssignedTo == nil || seen[*item.AssignedTo] {
+			continue
+		}
+		seen[*item.AssignedTo] = true
+		members = append(members, s.member(ctx, *item.AssignedTo, domain.ProjectMemberAssignee))
+	}
+
+	return members
+}
+
+func (s *ProjectExportService) member(ctx context.Context, userID uuid.UUID, role string) domain.ProjectMember {
+	member := domain.ProjectMember{UserID: userID, Role: role}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Warn("Project member not found for export")
+		return member
+	}
+
+	member.Name = user.Name
+	member.Email = user.Email
+	return member
+}
@@ -3,6 +3,8 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/edumes/golang-api-rest/internal/domain"
@@ -11,18 +13,61 @@ import (
 )
 
 type ProjectItemService struct {
-	repo   domain.ProjectItemRepository
-	logger *logrus.Logger
+	repo         domain.ProjectItemRepository
+	logger       *logrus.Logger
+	clock        domain.Clock
+	ids          domain.IDGenerator
+	notifier     domain.ChangeNotifier
+	chat         domain.ChatNotifier
+	customFields domain.CustomFieldRepository
 }
 
 func NewProjectItemService(repo domain.ProjectItemRepository) *ProjectItemService {
 	return &ProjectItemService{
 		repo:   repo,
 		logger: logrus.New(),
+		clock:  domain.SystemClock{},
+		ids:    domain.UUIDv7Generator{},
 	}
 }
 
-func (s *ProjectItemService) CreateProjectItem(ctx context.Context, projectID uuid.UUID, name, description, status, priority string, estimatedHours, actualHours *float64, dueDate *time.Time, assignedTo *uuid.UUID) (*domain.ProjectItem, error) {
+func (s *ProjectItemService) WithClock(clock domain.Clock) *ProjectItemService {
+	s.clock = clock
+	return s
+}
+
+func (s *ProjectItemService) WithIDGenerator(ids domain.IDGenerator) *ProjectItemService {
+	s.ids = ids
+	return s
+}
+
+// WithNotifier sets where item changes are fanned out to watchers.
+func (s *ProjectItemService) WithNotifier(notifier domain.ChangeNotifier) *ProjectItemService {
+	s.notifier = notifier
+	return s
+}
+
+// WithChatNotifier sets where completed items are announced to chat
+// channels.
+func (s *ProjectItemService) WithChatNotifier(chat domain.ChatNotifier) *ProjectItemService {
+	s.chat = chat
+	return s
+}
+
+// WithCustomFields sets where the item custom field definitions are read
+// from when validating values.
+func (s *ProjectItemService) WithCustomFields(repo domain.CustomFieldRepository) *ProjectItemService {
+	s.customFields = repo
+	return s
+}
+
+func (s *ProjectItemService) notify(ctx context.Context, item *domain.ProjectItem, event string) {
+	if s.notifier != nil {
+		s.notifier.ProjectItemChanged(ctx, item, event)
+	}
+}
+
+func (s *ProjectItemService) CreateProjectItem(ctx context.Context, projectID uuid.UUID, name, description string, status domain.ProjectItemStatus, priority domain.ProjectItemPriority, estimatedHours, actualHours *float64, dueDate *time.Time, assignedTo, parentItemID *uuid.UUID, customFields domain.CustomFieldValues) (*domain.ProjectItem, error) {
 	s.logger.WithFields(logrus.Fields{
 		"project_id": projectID,
 		"name":       name,
@@ -36,15 +81,42 @@ func (s *ProjectItemService) CreateProjectItem(ctx context.Context, projectID uu
 	}
 
 	if status == "" {
-		status = "pending"
+		status = domain.ProjectItemStatusPending
 	}
 
 	if priority == "" {
-		priority = "medium"
+		priority = domain.ProjectItemPriorityMedium
+	}
+
+	if err := validateProjectItemEnums(status, priority); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"status":   status,
+			"priority": priority,
+		}).Warn("Invalid project item status or priority")
+		return nil, err
+	}
+
+	customFields, err := checkCustomFields(ctx, s.customFields, domain.CustomFieldEntityItem, &projectID, customFields)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+		}).Warn("Invalid project item custom fields")
+		return nil, err
+	}
+
+	id := s.ids.NewID()
+	if err := s.checkParent(ctx, id, projectID, parentItemID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":          err.Error(),
+			"project_id":     projectID,
+			"parent_item_id": parentItemID,
+		}).Warn("Invalid project item parent")
+		return nil, err
 	}
 
 	item := &domain.ProjectItem{
-		ID:             uuid.New(),
+		ID:             id,
 		ProjectID:      projectID,
 		Name:           name,
 		Description:    description,
@@ -54,8 +126,10 @@ func (s *ProjectItemService) CreateProjectItem(ctx context.Context, projectID uu
 		ActualHours:    actualHours,
 		DueDate:        dueDate,
 		AssignedTo:     assignedTo,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
+		ParentItemID:   parentItemID,
+		CustomFields:   customFields,
+		CreatedAt:      s.clock.Now(),
+		UpdatedAt:      s.clock.Now(),
 	}
 
 	s.logger.WithFields(logrus.Fields{
@@ -80,6 +154,11 @@ func (s *ProjectItemService) CreateProjectItem(ctx context.Context, projectID uu
 		"project_id": item.ProjectID,
 	}).Info("Project item created successfully")
 
+	s.notify(ctx, item, domain.ChangeEventCreated)
+	if item.AssignedTo != nil && s.notifier != nil {
+		s.notifier.ProjectItemAssigned(ctx, item)
+	}
+
 	return item, nil
 }
 
@@ -131,17 +210,230 @@ func (s *ProjectItemService) ListProjectItems(ctx context.Context, filter domain
 	return items, nil
 }
 
+// CountProjectItems returns how many project items match filter, for the totals of a
+// paginated list.
+func (s *ProjectItemService) CountProjectItems(ctx context.Context, filter domain.ProjectItemParams) (int64, error) {
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count project items in repository")
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// ListOverdueProjectItems returns the open items in filter's scope whose due
+// date is before today.
+func (s *ProjectItemService) ListOverdueProjectItems(ctx context.Context, filter domain.ProjectItemParams, pagination domain.Pagination) ([]domain.ProjectItem, error) {
+	today := startOfDay(s.clock.Now())
+	dueTo := today.Add(-time.Nanosecond)
+
+	filter.OpenOnly = true
+	filter.DueDateFrom = nil
+	filter.DueDateTo = &dueTo
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id":  filter.ProjectID,
+		"assigned_to": filter.AssignedTo,
+		"due_before":  today,
+	}).Debug("Listing overdue project items")
+
+	return s.ListProjectItems(ctx, filter, pagination)
+}
+
+// ListUpcomingProjectItems returns the open items in filter's scope due from
+// today through the next days days.
+func (s *ProjectItemService) ListUpcomingProjectItems(ctx context.Context, filter domain.ProjectItemParams, days int, pagination domain.Pagination) ([]domain.ProjectItem, error) {
+	if days < 1 || days > domain.MaxUpcomingDays {
+		return nil, fmt.Errorf("days must be between 1 and %d", domain.MaxUpcomingDays)
+	}
+
+	today := startOfDay(s.clock.Now())
+	dueTo := today.AddDate(0, 0, days+1).Add(-time.Nanosecond)
+
+	filter.OpenOnly = true
+	filter.DueDateFrom = &today
+	filter.DueDateTo = &dueTo
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id":  filter.ProjectID,
+		"assigned_to": filter.AssignedTo,
+		"due_from":    today,
+		"due_to":      dueTo,
+	}).Debug("Listing upcoming project items")
+
+	return s.ListProjectItems(ctx, filter, pagination)
+}
+
+// GetHoursRollup sums estimated and actual hours of the items in filter's
+// scope. With a due date range, items without a due date are left out.
+func (s *ProjectItemService) GetHoursRollup(ctx context.Context, filter domain.ProjectItemParams) (*domain.HoursRollup, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id":  filter.ProjectID,
+		"assigned_to": filter.AssignedTo,
+		"due_from":    filter.DueDateFrom,
+		"due_to":      filter.DueDateTo,
+	}).Debug("Computing hours rollup")
+
+	rollup, err := s.repo.HoursRollup(ctx, filter)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": filter.ProjectID,
+		}).Error("Failed to compute hours rollup")
+		return nil, err
+	}
+
+	if rollup.ByStatus == nil {
+		rollup.ByStatus = []domain.HoursByStatus{}
+	}
+	if rollup.ByAssignee == nil {
+		rollup.ByAssignee = []domain.HoursByAssignee{}
+	}
+	if rollup.ByWeek == nil {
+		rollup.ByWeek = []domain.HoursByWeek{}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"items":     rollup.Items,
+		"estimated": rollup.Estimated,
+		"actual":    rollup.Actual,
+	}).Info("Hours rollup computed successfully")
+
+	return rollup, nil
+}
+
+func validateProjectItemEnums(status domain.ProjectItemStatus, priority domain.ProjectItemPriority) error {
+	if err := status.Validate(); err != nil {
+		return err
+	}
+	return priority.Validate()
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
 func (s *ProjectItemService) UpdateProjectItem(ctx context.Context, item *domain.ProjectItem) error {
+	return s.updateProjectItem(ctx, item, nil)
+}
+
+// PatchProjectItem stores fields of item, an item with a merge patch
+// applied, leaving every other column as it is.
+func (s *ProjectItemService) PatchProjectItem(ctx context.Context, item *domain.ProjectItem, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	var err error
+	switch {
+	case item.Name == "":
+		err = errors.New("project item name is required")
+	case item.ProjectID == uuid.Nil:
+		err = errors.New("project item project is required")
+	default:
+		err = validateProjectItemEnums(item.Status, item.Priority)
+	}
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": item.ID,
+		}).Warn("Invalid patched project item")
+		return err
+	}
+	return s.updateProjectItem(ctx, item, fields)
+}
+
+func (s *ProjectItemService) updateProjectItem(ctx context.Context, item *domain.ProjectItem, fields []string) error {
 	s.logger.WithFields(logrus.Fields{
 		"item_id":    item.ID,
 		"name":       item.Name,
 		"status":     item.Status,
 		"project_id": item.ProjectID,
+		"fields":     fields,
 	}).Info("Updating project item")
 
-	item.UpdatedAt = time.Now()
+	// An empty status or priority, a nil project or omitted custom fields
+	// keep the current value.
+	var current *domain.ProjectItem
+	if item.Status == "" || item.Priority == "" || item.ProjectID == uuid.Nil || item.CustomFields == nil {
+		var err error
+		current, err = s.repo.GetByID(ctx, item.ID)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":   err.Error(),
+				"item_id": item.ID,
+			}).Warn("Project item not found for update")
+			return err
+		}
+		if item.Status == "" {
+			item.Status = current.Status
+		}
+		if item.Priority == "" {
+			item.Priority = current.Priority
+		}
+	}
 
-	err := s.repo.Update(ctx, item)
+	if item.CustomFields != nil {
+		projectID := item.ProjectID
+		if projectID == uuid.Nil {
+			projectID = current.ProjectID
+		}
+		checked, err := checkCustomFields(ctx, s.customFields, domain.CustomFieldEntityItem, &projectID, item.CustomFields)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":   err.Error(),
+				"item_id": item.ID,
+			}).Warn("Invalid project item custom fields")
+			return err
+		}
+		item.CustomFields = checked
+	}
+
+	// A new parent, or a move to another project, has to keep the item
+	// within the hierarchy of a single project.
+	parentChanged := slices.Contains(fields, "parent_item_id") || (len(fields) == 0 && item.ParentItemID != nil)
+	if parentChanged || item.ProjectID != uuid.Nil {
+		if current == nil {
+			var err error
+			current, err = s.repo.GetByID(ctx, item.ID)
+			if err != nil {
+				return err
+			}
+		}
+		if err := s.checkHierarchy(ctx, item, current, parentChanged); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":          err.Error(),
+				"item_id":        item.ID,
+				"parent_item_id": item.ParentItemID,
+			}).Warn("Invalid project item parent")
+			return err
+		}
+	}
+
+	var previous *domain.ProjectItem
+	completing := s.chat != nil && item.Status == domain.ProjectItemStatusCompleted
+	if (item.AssignedTo != nil && s.notifier != nil) || completing {
+		previous = current
+		if previous == nil {
+			previous, _ = s.repo.GetByID(ctx, item.ID)
+		}
+	}
+
+	if err := validateProjectItemEnums(item.Status, item.Priority); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"item_id":  item.ID,
+			"status":   item.Status,
+			"priority": item.Priority,
+		}).Warn("Invalid project item status or priority")
+		return err
+	}
+
+	item.UpdatedAt = s.clock.Now()
+
+	err := s.repo.Update(ctx, item, fields...)
 	if err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error":   err.Error(),
@@ -156,6 +448,35 @@ func (s *ProjectItemService) UpdateProjectItem(ctx context.Context, item *domain
 		"project_id": item.ProjectID,
 	}).Info("Project item updated successfully")
 
+	if current != nil {
+		if item.ProjectID == uuid.Nil {
+			item.ProjectID = current.ProjectID
+		}
+		if item.CustomFields == nil {
+			item.CustomFields = current.CustomFields
+		}
+	}
+
+	s.notify(ctx, item, domain.ChangeEventUpdated)
+	if completing && previous != nil && previous.Status != domain.ProjectItemStatusCompleted {
+		completed := *item
+		if completed.ProjectID == uuid.Nil {
+			completed.ProjectID = previous.ProjectID
+		}
+		if completed.Name == "" {
+			completed.Name = previous.Name
+		}
+		s.chat.ProjectItemCompleted(ctx, &completed)
+	}
+	if previous != nil && item.AssignedTo != nil && s.notifier != nil && (previous.AssignedTo == nil || *previous.AssignedTo != *item.AssignedTo) {
+		assigned := *previous
+		assigned.AssignedTo = item.AssignedTo
+		if item.Name != "" {
+			assigned.Name = item.Name
+		}
+		s.notifier.ProjectItemAssigned(ctx, &assigned)
+	}
+
 	return nil
 }
 
@@ -164,6 +485,11 @@ func (s *ProjectItemService) DeleteProjectItem(ctx context.Context, id uuid.UUID
 		"item_id": id,
 	}).Info("Deleting project item")
 
+	var item *domain.ProjectItem
+	if s.notifier != nil {
+		item, _ = s.repo.GetByID(ctx, id)
+	}
+
 	err := s.repo.Delete(ctx, id)
 	if err != nil {
 		s.logger.WithFields(logrus.Fields{
@@ -177,6 +503,10 @@ func (s *ProjectItemService) DeleteProjectItem(ctx context.Context, id uuid.UUID
 		"item_id": id,
 	}).Info("Project item deleted successfully")
 
+	if item != nil {
+		s.notify(ctx, item, domain.ChangeEventDeleted)
+	}
+
 	return nil
 }
 
@@ -193,6 +523,7 @@ func (s *ProjectItemService) GetProjectItemsByProjectID(ctx context.Context, pro
 		}).Error("Failed to get project items by project ID from repository")
 		return nil, err
 	}
+	rollUpHours(items)
 
 	s.logger.WithFields(logrus.Fields{
 		"project_id": projectID,
@@ -202,6 +533,151 @@ func (s *ProjectItemService) GetProjectItemsByProjectID(ctx context.Context, pro
 	return items, nil
 }
 
+// ListProjectItemChildren returns the direct sub-tasks of an item.
+func (s *ProjectItemService) ListProjectItemChildren(ctx context.Context, id uuid.UUID) ([]domain.ProjectItem, error) {
+	s.logger.WithFields(logrus.Fields{
+		"item_id": id,
+	}).Debug("Listing project item children")
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+		}).Warn("Project item not found for children")
+		return nil, err
+	}
+
+	children, err := s.repo.ListChildren(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+		}).Error("Failed to list project item children from repository")
+		return nil, err
+	}
+
+	return children, nil
+}
+
+// checkHierarchy checks the parent item will have once updated with item,
+// when it changes or the item moves to another project. An item that moves
+// to another project cannot take its sub-tasks along.
+func (s *ProjectItemService) checkHierarchy(ctx context.Context, item, current *domain.ProjectItem, parentChanged bool) error {
+	projectID := item.ProjectID
+	if projectID == uuid.Nil {
+		projectID = current.ProjectID
+	}
+	moved := projectID != current.ProjectID
+	if !parentChanged && !moved {
+		return nil
+	}
+
+	parentID := current.ParentItemID
+	if parentChanged {
+		parentID = item.ParentItemID
+	}
+	if err := s.checkParent(ctx, item.ID, projectID, parentID); err != nil {
+		return err
+	}
+
+	if moved {
+		children, err := s.repo.ListChildren(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+		if len(children) > 0 {
+			return domain.ErrParentItemProject
+		}
+	}
+	return nil
+}
+
+// checkParent checks that parentID, when set, is an item of the project
+// that is neither id itself nor one of its sub-tasks, walking up from the
+// parent so that no cycle can form.
+func (s *ProjectItemService) checkParent(ctx context.Context, id, projectID uuid.UUID, parentID *uuid.UUID) error {
+	if parentID == nil {
+		return nil
+	}
+
+	parent, err := s.repo.GetByID(ctx, *parentID)
+	if errors.Is(err, domain.ErrProjectItemNotFound) {
+		return domain.ErrParentItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if parent.ProjectID != projectID {
+		return domain.ErrParentItemProject
+	}
+
+	seen := map[uuid.UUID]bool{}
+	for ancestor := parent; ; {
+		if ancestor.ID == id {
+			return domain.ErrParentItemCycle
+		}
+		if ancestor.ParentItemID == nil || seen[ancestor.ID] {
+			return nil
+		}
+		seen[ancestor.ID] = true
+		ancestor, err = s.repo.GetByID(ctx, *ancestor.ParentItemID)
+		if errors.Is(err, domain.ErrProjectItemNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// rollUpHours sets the Rollup of each item to its hours plus those of all
+// its sub-tasks among items. Items whose parent is not in items are treated
+// as top-level ones.
+func rollUpHours(items []domain.ProjectItem) {
+	index := make(map[uuid.UUID]int, len(items))
+	for i := range items {
+		index[items[i].ID] = i
+	}
+	children := make(map[uuid.UUID][]int, len(items))
+	for i := range items {
+		if parent := items[i].ParentItemID; parent != nil {
+			if _, ok := index[*parent]; ok {
+				children[*parent] = append(children[*parent], i)
+			}
+		}
+	}
+
+	var visit func(i int, path map[int]bool) domain.ProjectItemRollup
+	visit = func(i int, path map[int]bool) domain.ProjectItemRollup {
+		if items[i].Rollup != nil {
+			return *items[i].Rollup
+		}
+		rollup := domain.ProjectItemRollup{}
+		if items[i].EstimatedHours != nil {
+			rollup.EstimatedHours = *items[i].EstimatedHours
+		}
+		if items[i].ActualHours != nil {
+			rollup.ActualHours = *items[i].ActualHours
+		}
+		path[i] = true
+		for _, child := range children[items[i].ID] {
+			if path[child] {
+				continue
+			}
+			sub := visit(child, path)
+			rollup.EstimatedHours += sub.EstimatedHours
+			rollup.ActualHours += sub.ActualHours
+			rollup.SubTasks += sub.SubTasks + 1
+		}
+		delete(path, i)
+		items[i].Rollup = &rollup
+		return rollup
+	}
+	for i := range items {
+		visit(i, map[int]bool{})
+	}
+}
+
 func (s *ProjectItemService) GetProjectItemsByAssignedTo(ctx context.Context, assignedTo uuid.UUID) ([]domain.ProjectItem, error) {
 	s.logger.WithFields(logrus.Fields{
 		"assigned_to": assignedTo,
@@ -223,3 +699,203 @@ func (s *ProjectItemService) GetProjectItemsByAssignedTo(ctx context.Context, as
 
 	return items, nil
 }
+
+// TransitionProjectItem moves an item along the status workflow on behalf of
+// actorID. Watchers are told of the change, and chat channels when it
+// completes the item.
+func (s *ProjectItemService) TransitionProjectItem(ctx context.Context, id uuid.UUID, status domain.ProjectItemStatus, actorID uuid.UUID) (*domain.ProjectItem, error) {
+	s.logger.WithFields(logrus.Fields{
+		"item_id":  id,
+		"status":   status,
+		"actor_id": actorID,
+	}).Info("Transitioning project item")
+
+	if err := status.Validate(); err != nil {
+		return nil, err
+	}
+
+	item, err := s.repo.Transition(ctx, id, status, actorID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+			"status":  status,
+		}).Warn("Failed to transition project item")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"item_id": id,
+		"status":  status,
+	}).Info("Project item transitioned successfully")
+
+	s.notify(ctx, item, domain.ChangeEventUpdated)
+	if s.chat != nil && status == domain.ProjectItemStatusCompleted {
+		s.chat.ProjectItemCompleted(ctx, item)
+	}
+
+	return item, nil
+}
+
+// GetProjectBoard returns the project's items matching filter in one column
+// per status of statuses, or of every status when it is empty, each paged
+// and sorted by pagination on its own. Columns follow the workflow order.
+func (s *ProjectItemService) GetProjectBoard(ctx context.Context, projectID uuid.UUID, filter domain.ProjectItemParams, statuses []domain.ProjectItemStatus, pagination domain.Pagination) (*domain.ProjectBoard, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"statuses":   statuses,
+		"limit":      pagination.Limit,
+		"offset":     pagination.Offset,
+		"sort":       pagination.Sort,
+	}).Debug("Getting project board")
+
+	board := &domain.ProjectBoard{
+		ProjectID: projectID,
+		Limit:     pagination.Limit,
+		Offset:    pagination.Offset,
+		Columns:   []domain.BoardColumn{},
+	}
+	filter.ProjectID = &projectID
+	for _, status := range domain.ProjectItemStatuses {
+		if len(statuses) > 0 && !slices.Contains(statuses, status) {
+			continue
+		}
+		filter.Status = status
+		items, err := s.repo.List(ctx, filter, pagination)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":      err.Error(),
+				"project_id": projectID,
+				"status":     status,
+			}).Error("Failed to list board column from repository")
+			return nil, err
+		}
+		total, err := s.repo.Count(ctx, filter)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":      err.Error(),
+				"project_id": projectID,
+				"status":     status,
+			}).Error("Failed to count board column in repository")
+			return nil, err
+		}
+		if items == nil {
+			items = []domain.ProjectItem{}
+		}
+		board.Columns = append(board.Columns, domain.BoardColumn{Status: status, Items: items, Total: total})
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"columns":    len(board.Columns),
+	}).Info("Project board retrieved successfully")
+
+	return board, nil
+}
+
+// MoveProjectItemOnBoard moves an item of the project to another position of
+// its board, in its own column or another one, on behalf of actorID. A change
+// of column follows the status workflow like TransitionProjectItem and tells
+// watchers; a reorder within a column does not.
+func (s *ProjectItemService) MoveProjectItemOnBoard(ctx context.Context, projectID, id uuid.UUID, move domain.BoardMove, actorID uuid.UUID) (*domain.ProjectItem, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"item_id":    id,
+		"status":     move.Status,
+		"position":   move.Position,
+		"actor_id":   actorID,
+	}).Info("Moving project item on board")
+
+	if move.Status != "" {
+		if err := move.Status.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// The previous status tells a change of column, which watchers hear
+	// of, from a reorder.
+	var previous *domain.ProjectItem
+	if move.Status != "" && (s.notifier != nil || s.chat != nil) {
+		var err error
+		previous, err = s.repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item, err := s.repo.MoveOnBoard(ctx, projectID, id, move, actorID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"item_id":  id,
+			"status":   move.Status,
+			"position": move.Position,
+		}).Warn("Failed to move project item on board")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"item_id":  id,
+		"status":   item.Status,
+		"position": move.Position,
+	}).Info("Project item moved on board successfully")
+
+	if previous != nil && previous.Status != item.Status {
+		s.notify(ctx, item, domain.ChangeEventUpdated)
+		if s.chat != nil && item.Status == domain.ProjectItemStatusCompleted {
+			s.chat.ProjectItemCompleted(ctx, item)
+		}
+	}
+
+	return item, nil
+}
+
+func (s *ProjectItemService) ListProjectItemTransitions(ctx context.Context, id uuid.UUID) ([]domain.ProjectItemTransition, error) {
+	s.logger.WithFields(logrus.Fields{
+		"item_id": id,
+	}).Debug("Listing project item transitions")
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+		}).Warn("Project item not found for transition history")
+		return nil, err
+	}
+
+	transitions, err := s.repo.ListTransitions(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+		}).Error("Failed to list project item transitions from repository")
+		return nil, err
+	}
+
+	return transitions, nil
+}
+
+func (s *ProjectItemService) ListProjectItemAssignments(ctx context.Context, id uuid.UUID) ([]domain.ProjectItemAssignment, error) {
+	s.logger.WithFields(logrus.Fields{
+		"item_id": id,
+	}).Debug("Listing project item assignments")
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+		}).Warn("Project item not found for assignment history")
+		return nil, err
+	}
+
+	assignments, err := s.repo.ListAssignments(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+		}).Error("Failed to list project item assignments from repository")
+		return nil, err
+	}
+
+	return assignments, nil
+}
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/edumes/golang-api-rest/internal/domain"
@@ -11,18 +12,92 @@ import (
 )
 
 type ProjectService struct {
-	repo   domain.ProjectRepository
-	logger *logrus.Logger
+	repo         domain.ProjectRepository
+	logger       *logrus.Logger
+	clock        domain.Clock
+	ids          domain.IDGenerator
+	notifier     domain.ChangeNotifier
+	chat         domain.ChatNotifier
+	progressMode string
+	customFields domain.CustomFieldRepository
 }
 
 func NewProjectService(repo domain.ProjectRepository) *ProjectService {
 	return &ProjectService{
-		repo:   repo,
-		logger: logrus.New(),
+		repo:         repo,
+		logger:       logrus.New(),
+		clock:        domain.SystemClock{},
+		ids:          domain.UUIDv7Generator{},
+		progressMode: domain.ProjectProgressByCount,
 	}
 }
 
-func (s *ProjectService) CreateProject(ctx context.Context, name, description, status string, startDate, endDate *time.Time, budget *float64, ownerID uuid.UUID) (*domain.Project, error) {
+func (s *ProjectService) WithClock(clock domain.Clock) *ProjectService {
+	s.clock = clock
+	return s
+}
+
+func (s *ProjectService) WithIDGenerator(ids domain.IDGenerator) *ProjectService {
+	s.ids = ids
+	return s
+}
+
+// WithNotifier sets where project changes are fanned out to watchers.
+func (s *ProjectService) WithNotifier(notifier domain.ChangeNotifier) *ProjectService {
+	s.notifier = notifier
+	return s
+}
+
+// WithChatNotifier sets where new projects are announced to chat channels.
+func (s *ProjectService) WithChatNotifier(chat domain.ChatNotifier) *ProjectService {
+	s.chat = chat
+	return s
+}
+
+// WithProgressMode selects the progress formula, domain.ProjectProgressByCount
+// or domain.ProjectProgressByHours.
+func (s *ProjectService) WithProgressMode(mode string) *ProjectService {
+	s.progressMode = mode
+	return s
+}
+
+// WithCustomFields sets where the project custom field definitions are read
+// from when validating values.
+func (s *ProjectService) WithCustomFields(repo domain.CustomFieldRepository) *ProjectService {
+	s.customFields = repo
+	return s
+}
+
+// fillProgress sets Progress on projects. A failure only leaves it unset.
+func (s *ProjectService) fillProgress(ctx context.Context, projects ...*domain.Project) {
+	ids := make([]uuid.UUID, len(projects))
+	for i, project := range projects {
+		ids[i] = project.ID
+	}
+
+	progress, err := s.repo.Progress(ctx, ids, s.progressMode)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"projects": len(projects),
+		}).Warn("Failed to compute project progress")
+		return
+	}
+
+	for _, project := range projects {
+		if value, ok := progress[project.ID]; ok {
+			project.Progress = &value
+		}
+	}
+}
+
+func (s *ProjectService) notify(ctx context.Context, project *domain.Project, event string) {
+	if s.notifier != nil {
+		s.notifier.ProjectChanged(ctx, project, event)
+	}
+}
+
+func (s *ProjectService) CreateProject(ctx context.Context, name, description string, status domain.ProjectStatus, startDate, endDate *time.Time, budget *float64, ownerID uuid.UUID, customFields domain.CustomFieldValues) (*domain.Project, error) {
 	s.logger.WithFields(logrus.Fields{
 		"name":     name,
 		"status":   status,
@@ -35,20 +110,48 @@ func (s *ProjectService) CreateProject(ctx context.Context, name, description, s
 	}
 
 	if status == "" {
-		status = "active"
+		status = domain.ProjectStatusActive
+	}
+
+	if err := status.Validate(); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"status": status,
+		}).Warn("Invalid project status")
+		return nil, err
+	}
+
+	var projectBudget *domain.Money
+	if budget != nil {
+		amount, err := domain.NewMoney(*budget)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"budget": *budget,
+			}).Warn("Invalid project budget")
+			return nil, fmt.Errorf("invalid budget: %w", err)
+		}
+		projectBudget = &amount
+	}
+
+	customFields, err := checkCustomFields(ctx, s.customFields, domain.CustomFieldEntityProject, nil, customFields)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Warn("Invalid project custom fields")
+		return nil, err
 	}
 
 	project := &domain.Project{
-		ID:          uuid.New(),
-		Name:        name,
-		Description: description,
-		Status:      status,
-		StartDate:   startDate,
-		EndDate:     endDate,
-		Budget:      budget,
-		OwnerID:     ownerID,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:           s.ids.NewID(),
+		Name:         name,
+		Description:  description,
+		Status:       status,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		Budget:       projectBudget,
+		OwnerID:      ownerID,
+		CustomFields: customFields,
+		CreatedAt:    s.clock.Now(),
+		UpdatedAt:    s.clock.Now(),
 	}
 
 	s.logger.WithFields(logrus.Fields{
@@ -72,6 +175,10 @@ func (s *ProjectService) CreateProject(ctx context.Context, name, description, s
 		"owner_id":   project.OwnerID,
 	}).Info("Project created successfully")
 
+	if s.chat != nil {
+		s.chat.ProjectCreated(ctx, project)
+	}
+
 	return project, nil
 }
 
@@ -95,6 +202,8 @@ func (s *ProjectService) GetProjectByID(ctx context.Context, id uuid.UUID) (*dom
 		"owner_id":   project.OwnerID,
 	}).Debug("Project retrieved successfully")
 
+	s.fillProgress(ctx, project)
+
 	return project, nil
 }
 
@@ -119,19 +228,94 @@ func (s *ProjectService) ListProjects(ctx context.Context, filter domain.Project
 		"count": len(projects),
 	}).Info("Projects listed successfully")
 
+	s.fillProgress(ctx, projectPointers(projects)...)
+
 	return projects, nil
 }
 
+// CountProjects returns how many projects match filter, for the totals of a
+// paginated list.
+func (s *ProjectService) CountProjects(ctx context.Context, filter domain.ProjectParams) (int64, error) {
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count projects in repository")
+		return 0, err
+	}
+
+	return total, nil
+}
+
 func (s *ProjectService) UpdateProject(ctx context.Context, project *domain.Project) error {
+	return s.updateProject(ctx, project, nil)
+}
+
+// PatchProject stores fields of project, a project with a merge patch
+// applied, leaving every other column as it is.
+func (s *ProjectService) PatchProject(ctx context.Context, project *domain.Project, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	if project.Name == "" {
+		s.logger.WithFields(logrus.Fields{
+			"project_id": project.ID,
+		}).Warn("Patched project name is empty")
+		return errors.New("project name is required")
+	}
+	return s.updateProject(ctx, project, fields)
+}
+
+func (s *ProjectService) updateProject(ctx context.Context, project *domain.Project, fields []string) error {
 	s.logger.WithFields(logrus.Fields{
 		"project_id": project.ID,
 		"name":       project.Name,
 		"status":     project.Status,
+		"fields":     fields,
 	}).Info("Updating project")
 
-	project.UpdatedAt = time.Now()
+	existing, err := s.repo.GetByID(ctx, project.ID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": project.ID,
+		}).Warn("Project not found for update")
+		return err
+	}
+
+	if existing.ArchivedAt != nil {
+		s.logger.WithFields(logrus.Fields{
+			"project_id": project.ID,
+		}).Warn("Refusing to update archived project")
+		return domain.ErrProjectArchived
+	}
+
+	if project.Status == "" {
+		project.Status = existing.Status
+	}
+	if err := project.Status.Validate(); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"project_id": project.ID,
+			"status":     project.Status,
+		}).Warn("Invalid project status")
+		return err
+	}
+
+	// Omitted custom fields keep their values; a given object replaces them.
+	if project.CustomFields != nil {
+		project.CustomFields, err = checkCustomFields(ctx, s.customFields, domain.CustomFieldEntityProject, nil, project.CustomFields)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":      err.Error(),
+				"project_id": project.ID,
+			}).Warn("Invalid project custom fields")
+			return err
+		}
+	}
+
+	project.UpdatedAt = s.clock.Now()
 
-	err := s.repo.Update(ctx, project)
+	err = s.repo.Update(ctx, project, fields...)
 	if err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
@@ -145,6 +329,13 @@ func (s *ProjectService) UpdateProject(ctx context.Context, project *domain.Proj
 		"name":       project.Name,
 	}).Info("Project updated successfully")
 
+	if project.CustomFields == nil {
+		project.CustomFields = existing.CustomFields
+	}
+	s.fillProgress(ctx, project)
+
+	s.notify(ctx, project, domain.ChangeEventUpdated)
+
 	return nil
 }
 
@@ -153,6 +344,11 @@ func (s *ProjectService) DeleteProject(ctx context.Context, id uuid.UUID) error
 		"project_id": id,
 	}).Info("Deleting project")
 
+	var project *domain.Project
+	if s.notifier != nil {
+		project, _ = s.repo.GetByID(ctx, id)
+	}
+
 	err := s.repo.Delete(ctx, id)
 	if err != nil {
 		s.logger.WithFields(logrus.Fields{
@@ -166,9 +362,66 @@ func (s *ProjectService) DeleteProject(ctx context.Context, id uuid.UUID) error
 		"project_id": id,
 	}).Info("Project deleted successfully")
 
+	if project != nil {
+		s.notify(ctx, project, domain.ChangeEventDeleted)
+	}
+
 	return nil
 }
 
+func (s *ProjectService) ArchiveProject(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	now := s.clock.Now()
+	return s.setArchived(ctx, id, &now)
+}
+
+func (s *ProjectService) UnarchiveProject(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	return s.setArchived(ctx, id, nil)
+}
+
+func (s *ProjectService) setArchived(ctx context.Context, id uuid.UUID, archivedAt *time.Time) (*domain.Project, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id": id,
+		"archive":    archivedAt != nil,
+	}).Info("Changing project archived state")
+
+	project, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": id,
+		}).Warn("Project not found for archive state change")
+		return nil, err
+	}
+
+	if (project.ArchivedAt != nil) == (archivedAt != nil) {
+		s.logger.WithFields(logrus.Fields{
+			"project_id": id,
+		}).Debug("Project archived state already up to date")
+		return project, nil
+	}
+
+	if err := s.repo.SetArchived(ctx, id, archivedAt); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": id,
+		}).Error("Failed to change project archived state in repository")
+		return nil, err
+	}
+
+	project.ArchivedAt = archivedAt
+	project.UpdatedAt = s.clock.Now()
+	s.fillProgress(ctx, project)
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id":  id,
+		"archived_at": archivedAt,
+	}).Info("Project archived state changed successfully")
+
+	s.notify(ctx, project, domain.ChangeEventUpdated)
+
+	return project, nil
+}
+
 func (s *ProjectService) GetProjectsByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]domain.Project, error) {
 	s.logger.WithFields(logrus.Fields{
 		"owner_id": ownerID,
@@ -188,5 +441,15 @@ func (s *ProjectService) GetProjectsByOwnerID(ctx context.Context, ownerID uuid.
 		"count":    len(projects),
 	}).Info("Projects retrieved successfully by owner ID")
 
+	s.fillProgress(ctx, projectPointers(projects)...)
+
 	return projects, nil
 }
+
+func projectPointers(projects []domain.Project) []*domain.Project {
+	pointers := make([]*domain.Project, len(projects))
+	for i := range projects {
+		pointers[i] = &projects[i]
+	}
+	return pointers
+}
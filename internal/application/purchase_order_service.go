@@ -0,0 +1,298 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type PurchaseOrderService struct {
+	repo          domain.PurchaseOrderRepository
+	productRepo   domain.ProductRepository
+	warehouseRepo domain.WarehouseRepository
+	logger        *logrus.Logger
+	clock         domain.Clock
+	ids           domain.IDGenerator
+}
+
+func NewPurchaseOrderService(repo domain.PurchaseOrderRepository, productRepo domain.ProductRepository, warehouseRepo domain.WarehouseRepository) *PurchaseOrderService {
+	return &PurchaseOrderService{
+		repo:          repo,
+		productRepo:   productRepo,
+		warehouseRepo: warehouseRepo,
+		logger:        logrus.New(),
+		clock:         domain.SystemClock{},
+		ids:           domain.UUIDv7Generator{},
+	}
+}
+
+func (s *PurchaseOrderService) WithClock(clock domain.Clock) *PurchaseOrderService {
+	s.clock = clock
+	return s
+}
+
+func (s *PurchaseOrderService) WithIDGenerator(ids domain.IDGenerator) *PurchaseOrderService {
+	s.ids = ids
+	return s
+}
+
+func (s *PurchaseOrderService) CreatePurchaseOrder(ctx context.Context, order *domain.PurchaseOrder) (*domain.PurchaseOrder, error) {
+	s.logger.WithFields(logrus.Fields{
+		"supplier":   order.Supplier,
+		"created_by": order.CreatedBy,
+		"lines":      len(order.Lines),
+	}).Info("Creating new purchase order")
+
+	if err := s.validatePurchaseOrder(ctx, order); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	order.ID = s.ids.NewID()
+	order.Status = domain.PurchaseOrderStatusDraft
+	order.SubmittedAt = nil
+	order.ReceivedAt = nil
+	order.ReceivedBy = nil
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	order.DeletedAt = nil
+	s.assignLines(order)
+
+	if err := s.repo.Create(ctx, order); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"supplier": order.Supplier,
+		}).Error("Failed to create purchase order in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+		"supplier":          order.Supplier,
+	}).Info("Purchase order created successfully")
+
+	return order, nil
+}
+
+func (s *PurchaseOrderService) GetPurchaseOrderByID(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error) {
+	s.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+	}).Debug("Getting purchase order by ID")
+
+	order, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+		}).Warn("Purchase order not found by ID")
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (s *PurchaseOrderService) ListPurchaseOrders(ctx context.Context, filter domain.PurchaseOrderParams, pagination domain.Pagination) ([]domain.PurchaseOrder, error) {
+	s.logger.WithFields(logrus.Fields{
+		"filter_supplier": filter.Supplier,
+		"filter_status":   filter.Status,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
+	}).Debug("Listing purchase orders")
+
+	orders, err := s.repo.List(ctx, filter, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list purchase orders from repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"count": len(orders),
+	}).Info("Purchase orders listed successfully")
+
+	return orders, nil
+}
+
+// UpdatePurchaseOrder replaces the supplier, notes, receiving warehouse and
+// lines of a draft order.
+func (s *PurchaseOrderService) UpdatePurchaseOrder(ctx context.Context, order *domain.PurchaseOrder) (*domain.PurchaseOrder, error) {
+	s.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+		"lines":             len(order.Lines),
+	}).Info("Updating purchase order")
+
+	existingOrder, err := s.repo.GetByID(ctx, order.ID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": order.ID,
+		}).Warn("Purchase order not found for update")
+		return nil, err
+	}
+	if existingOrder.Status != domain.PurchaseOrderStatusDraft {
+		s.logger.WithFields(logrus.Fields{
+			"purchase_order_id": order.ID,
+			"status":            existingOrder.Status,
+		}).Warn("Only draft purchase orders can be edited")
+		return nil, domain.ErrPurchaseOrderStatus
+	}
+
+	if err := s.validatePurchaseOrder(ctx, order); err != nil {
+		return nil, err
+	}
+
+	order.Status = existingOrder.Status
+	order.CreatedBy = existingOrder.CreatedBy
+	order.CreatedAt = existingOrder.CreatedAt
+	order.UpdatedAt = s.clock.Now()
+	s.assignLines(order)
+
+	if err := s.repo.Update(ctx, order); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": order.ID,
+		}).Error("Failed to update purchase order in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+	}).Info("Purchase order updated successfully")
+
+	return order, nil
+}
+
+func (s *PurchaseOrderService) DeletePurchaseOrder(ctx context.Context, id uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+	}).Info("Deleting purchase order")
+
+	order, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+		}).Warn("Purchase order not found for deletion")
+		return err
+	}
+	if order.Status != domain.PurchaseOrderStatusDraft {
+		s.logger.WithFields(logrus.Fields{
+			"purchase_order_id": id,
+			"status":            order.Status,
+		}).Warn("Only draft purchase orders can be deleted")
+		return domain.ErrPurchaseOrderStatus
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+		}).Error("Failed to delete purchase order in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+	}).Info("Purchase order deleted successfully")
+
+	return nil
+}
+
+func (s *PurchaseOrderService) SubmitPurchaseOrder(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error) {
+	s.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+	}).Info("Submitting purchase order")
+
+	if err := s.repo.Submit(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+		}).Warn("Failed to submit purchase order")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+	}).Info("Purchase order submitted successfully")
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// ReceivePurchaseOrder books every line of a submitted order into the stock
+// ledger under the purchase reason, with actorID as the author.
+func (s *PurchaseOrderService) ReceivePurchaseOrder(ctx context.Context, id, actorID uuid.UUID) (*domain.PurchaseOrder, error) {
+	s.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+		"actor_id":          actorID,
+	}).Info("Receiving purchase order")
+
+	order, err := s.repo.Receive(ctx, id, actorID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+		}).Warn("Failed to receive purchase order")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+		"lines":             len(order.Lines),
+	}).Info("Purchase order received successfully")
+
+	return order, nil
+}
+
+func (s *PurchaseOrderService) validatePurchaseOrder(ctx context.Context, order *domain.PurchaseOrder) error {
+	order.Supplier = strings.TrimSpace(order.Supplier)
+	if order.Supplier == "" {
+		return errors.New("supplier is required")
+	}
+	if len(order.Lines) == 0 {
+		return errors.New("purchase order must contain at least one line")
+	}
+
+	if order.WarehouseID != nil {
+		if _, err := s.warehouseRepo.GetByID(ctx, *order.WarehouseID); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err.Error(),
+				"warehouse_id": *order.WarehouseID,
+			}).Warn("Warehouse not found for purchase order")
+			return err
+		}
+	}
+
+	for _, line := range order.Lines {
+		if line.Quantity <= 0 {
+			return errors.New("line quantity must be greater than zero")
+		}
+		if line.UnitCost < 0 {
+			return errors.New("line unit cost must not be negative")
+		}
+		product, err := s.productRepo.GetByID(ctx, line.ProductID)
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":      err.Error(),
+				"product_id": line.ProductID,
+			}).Warn("Product not found for purchase order line")
+			return err
+		}
+		if product.ArchivedAt != nil {
+			return domain.ErrProductArchived
+		}
+	}
+
+	return nil
+}
+
+func (s *PurchaseOrderService) assignLines(order *domain.PurchaseOrder) {
+	for i := range order.Lines {
+		order.Lines[i].ID = s.ids.NewID()
+		order.Lines[i].PurchaseOrderID = order.ID
+	}
+}
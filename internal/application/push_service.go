@@ -0,0 +1,270 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// pushTitles are the titles of pushed notifications by event; other events
+// are changes to something the user watches.
+var pushTitles = map[string]string{
+	domain.ChangeEventAssigned: "New assignment",
+	domain.ChangeEventDueSoon:  "Due soon",
+}
+
+const pushChangeTitle = "Project update"
+
+type PushService struct {
+	repo    domain.DeviceRepository
+	senders map[domain.PushPlatform]domain.PushSender
+	logger  *logrus.Logger
+	clock   domain.Clock
+	ids     domain.IDGenerator
+}
+
+func NewPushService(repo domain.DeviceRepository) *PushService {
+	return &PushService{
+		repo:    repo,
+		senders: make(map[domain.PushPlatform]domain.PushSender),
+		logger:  logrus.New(),
+		clock:   domain.SystemClock{},
+		ids:     domain.UUIDv7Generator{},
+	}
+}
+
+func (s *PushService) WithClock(clock domain.Clock) *PushService {
+	s.clock = clock
+	return s
+}
+
+func (s *PushService) WithIDGenerator(ids domain.IDGenerator) *PushService {
+	s.ids = ids
+	return s
+}
+
+// WithSender delivers the pushes of platform through sender. Devices of a
+// platform without a sender can register but receive nothing.
+func (s *PushService) WithSender(platform domain.PushPlatform, sender domain.PushSender) *PushService {
+	s.senders[platform] = sender
+	return s
+}
+
+// RegisterDevice registers token to userID, or refreshes it when it is
+// already registered, so apps can call it on every start.
+func (s *PushService) RegisterDevice(ctx context.Context, userID uuid.UUID, platform domain.PushPlatform, token string) (*domain.Device, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id":  userID,
+		"platform": platform,
+	}).Info("Registering device")
+
+	if err := platform.Validate(); err != nil {
+		return nil, err
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, errors.New("token is required")
+	}
+	if len(token) > domain.MaxPushTokenLength {
+		return nil, errors.New("token is too long")
+	}
+
+	now := s.clock.Now()
+	device := &domain.Device{
+		ID:         s.ids.NewID(),
+		UserID:     userID,
+		Platform:   platform,
+		Token:      token,
+		LastSeenAt: now,
+		CreatedAt:  now,
+	}
+	if err := s.repo.Register(ctx, device); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to register device in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"device_id": device.ID,
+		"user_id":   userID,
+	}).Info("Device registered successfully")
+
+	return device, nil
+}
+
+func (s *PushService) ListDevices(ctx context.Context, userID uuid.UUID) ([]domain.Device, error) {
+	devices, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list devices from repository")
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+func (s *PushService) UnregisterDevice(ctx context.Context, userID, id uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"device_id": id,
+		"user_id":   userID,
+	}).Info("Unregistering device")
+
+	return s.repo.Delete(ctx, userID, id)
+}
+
+func (s *PushService) GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreferences, error) {
+	preferences, err := s.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if preferences == nil {
+		preferences = domain.DefaultNotificationPreferences(userID)
+	}
+	return preferences, nil
+}
+
+func (s *PushService) UpdateNotificationPreferences(ctx context.Context, preferences *domain.NotificationPreferences) (*domain.NotificationPreferences, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id":       preferences.UserID,
+		"push_assigned": preferences.PushAssigned,
+		"push_due_soon": preferences.PushDueSoon,
+		"push_changes":  preferences.PushChanges,
+	}).Info("Updating notification preferences")
+
+	preferences.UpdatedAt = s.clock.Now()
+	if err := s.repo.SavePreferences(ctx, preferences); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": preferences.UserID,
+		}).Error("Failed to save notification preferences in repository")
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// Dispatch pushes each notification to the devices of its recipient, unless
+// the recipient turned pushes of its event off. Tokens the provider rejects
+// as no longer valid are unregistered.
+func (s *PushService) Dispatch(ctx context.Context, notifications []domain.Notification) {
+	if len(notifications) == 0 || len(s.senders) == 0 {
+		return
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(notifications))
+	seen := make(map[uuid.UUID]bool, len(notifications))
+	for _, notification := range notifications {
+		if !seen[notification.UserID] {
+			seen[notification.UserID] = true
+			userIDs = append(userIDs, notification.UserID)
+		}
+	}
+
+	saved, err := s.repo.ListPreferences(ctx, userIDs)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to load notification preferences for push")
+		return
+	}
+	preferences := make(map[uuid.UUID]*domain.NotificationPreferences, len(saved))
+	for i := range saved {
+		preferences[saved[i].UserID] = &saved[i]
+	}
+
+	devices, err := s.repo.ListByUsers(ctx, userIDs)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to load devices for push")
+		return
+	}
+	byUser := make(map[uuid.UUID][]domain.Device, len(userIDs))
+	for _, device := range devices {
+		byUser[device.UserID] = append(byUser[device.UserID], device)
+	}
+
+	sent, failed := 0, 0
+	for _, notification := range notifications {
+		prefs, ok := preferences[notification.UserID]
+		if !ok {
+			prefs = domain.DefaultNotificationPreferences(notification.UserID)
+		}
+		if !prefs.Pushes(notification.Event) {
+			continue
+		}
+
+		message := pushMessage(notification)
+		for _, device := range byUser[notification.UserID] {
+			sender, ok := s.senders[device.Platform]
+			if !ok {
+				continue
+			}
+			if err := sender.Send(ctx, device.Token, message); err != nil {
+				failed++
+				s.pushFailed(ctx, device, notification, err)
+				continue
+			}
+			sent++
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"notifications": len(notifications),
+		"sent":          sent,
+		"failed":        failed,
+	}).Info("Push notifications dispatched")
+}
+
+func (s *PushService) pushFailed(ctx context.Context, device domain.Device, notification domain.Notification, err error) {
+	s.logger.WithFields(logrus.Fields{
+		"error":           err.Error(),
+		"device_id":       device.ID,
+		"platform":        device.Platform,
+		"notification_id": notification.ID,
+	}).Warn("Failed to push notification")
+
+	if !errors.Is(err, domain.ErrPushTokenInvalid) {
+		return
+	}
+	if err := s.repo.DeleteByToken(ctx, device.Token); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"device_id": device.ID,
+		}).Error("Failed to unregister device with invalid token")
+		return
+	}
+	s.logger.WithFields(logrus.Fields{
+		"device_id": device.ID,
+		"user_id":   device.UserID,
+	}).Info("Device with invalid token unregistered")
+}
+
+// pushMessage shows the notification's message under a title for its event
+// and hands the app what it needs to open the target.
+func pushMessage(notification domain.Notification) domain.PushMessage {
+	title, ok := pushTitles[notification.Event]
+	if !ok {
+		title = pushChangeTitle
+	}
+	return domain.PushMessage{
+		ID:    notification.ID,
+		Title: title,
+		Body:  notification.Message,
+		Data: map[string]string{
+			"notification_id": notification.ID.String(),
+			"event":           notification.Event,
+			"target_type":     notification.TargetType,
+			"target_id":       notification.TargetID.String(),
+			"project_id":      notification.ProjectID.String(),
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+)
+
+// reportTable is a report flattened to text cells, ready to be written as
+// CSV or laid out in a PDF.
+type reportTable struct {
+	Header []string
+	Rows   [][]string
+}
+
+func itemsReportTable(rows []domain.ItemsReportRow) reportTable {
+	table := reportTable{Header: []string{"group", "bucket", "items", "estimated_hours", "actual_hours"}}
+	for _, row := range rows {
+		table.Rows = append(table.Rows, []string{
+			reportGroupCell(row.Group), reportBucketCell(row.Bucket),
+			strconv.FormatInt(row.Items, 10), reportAmountCell(row.EstimatedHours), reportAmountCell(row.ActualHours),
+		})
+	}
+	return table
+}
+
+func stockReportTable(rows []domain.StockReportRow) reportTable {
+	table := reportTable{Header: []string{"group", "bucket", "products", "stock", "stock_value", "stock_cost"}}
+	for _, row := range rows {
+		table.Rows = append(table.Rows, []string{
+			reportGroupCell(row.Group), reportBucketCell(row.Bucket),
+			strconv.FormatInt(row.Products, 10), strconv.FormatInt(row.Stock, 10),
+			reportAmountCell(row.StockValue), reportAmountCell(row.StockCost),
+		})
+	}
+	return table
+}
+
+func projectsReportTable(rows []domain.ProjectsReportRow) reportTable {
+	table := reportTable{Header: []string{"group", "bucket", "projects", "budget", "spent", "remaining"}}
+	for _, row := range rows {
+		table.Rows = append(table.Rows, []string{
+			reportGroupCell(row.Group), reportBucketCell(row.Bucket),
+			strconv.FormatInt(row.Projects, 10), reportAmountCell(row.Budget),
+			reportAmountCell(row.Spent), reportAmountCell(row.Remaining),
+		})
+	}
+	return table
+}
+
+func reportGroupCell(group *string) string {
+	if group == nil {
+		return ""
+	}
+	return *group
+}
+
+func reportBucketCell(bucket *time.Time) string {
+	if bucket == nil {
+		return ""
+	}
+	return bucket.Format(time.DateOnly)
+}
+
+func reportAmountCell(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func renderReportCSV(table reportTable) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(table.Header); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(table.Rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderReportPDF lays the table out in columns as wide as their longest
+// cell, under title and the generation time.
+func renderReportPDF(title string, generatedAt time.Time, table reportTable) []byte {
+	widths := make([]int, len(table.Header))
+	for _, row := range append([][]string{table.Header}, table.Rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+	line := func(cells []string) string {
+		padded := make([]string, len(cells))
+		for i, cell := range cells {
+			padded[i] = fmt.Sprintf("%-*s", widths[i], cell)
+		}
+		return strings.TrimRight(strings.Join(padded, " | "), " ")
+	}
+
+	lines := []string{title, "Generated at " + generatedAt.Format(time.DateTime), "", line(table.Header)}
+	for _, row := range table.Rows {
+		lines = append(lines, line(row))
+	}
+	if len(table.Rows) == 0 {
+		lines = append(lines, "No records.")
+	}
+	return writeTextPDF(lines)
+}
@@ -0,0 +1,128 @@
+package application
+
+import (
+	"context"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+type ReportService struct {
+	repo   domain.ReportRepository
+	logger *logrus.Logger
+}
+
+func NewReportService(repo domain.ReportRepository) *ReportService {
+	return &ReportService{
+		repo:   repo,
+		logger: logrus.New(),
+	}
+}
+
+// ItemsReport aggregates project items, by status unless another group is
+// requested.
+func (s *ReportService) ItemsReport(ctx context.Context, params domain.ReportParams) ([]domain.ItemsReportRow, error) {
+	if err := validateReport(domain.ReportItemsByStatus, &params); err != nil {
+		return nil, err
+	}
+
+	s.logReport("items", params)
+	rows, err := s.repo.ItemsReport(ctx, params)
+	if err != nil {
+		s.logReportError("items", err)
+		return nil, err
+	}
+	return emptyIfNil(rows), nil
+}
+
+// StockReport aggregates product stock, by category unless another group is
+// requested.
+func (s *ReportService) StockReport(ctx context.Context, params domain.ReportParams) ([]domain.StockReportRow, error) {
+	if err := validateReport(domain.ReportStockByCategory, &params); err != nil {
+		return nil, err
+	}
+
+	s.logReport("stock", params)
+	rows, err := s.repo.StockReport(ctx, params)
+	if err != nil {
+		s.logReportError("stock", err)
+		return nil, err
+	}
+	return emptyIfNil(rows), nil
+}
+
+// ProjectsReport aggregates project budgets and expenses, by status unless
+// another group is requested.
+func (s *ReportService) ProjectsReport(ctx context.Context, params domain.ReportParams) ([]domain.ProjectsReportRow, error) {
+	if err := validateReport(domain.ReportProjectsBudget, &params); err != nil {
+		return nil, err
+	}
+
+	s.logReport("projects", params)
+	rows, err := s.repo.ProjectsReport(ctx, params)
+	if err != nil {
+		s.logReportError("projects", err)
+		return nil, err
+	}
+	return emptyIfNil(rows), nil
+}
+
+func (s *ReportService) logReport(report string, params domain.ReportParams) {
+	s.logger.WithFields(logrus.Fields{
+		"report":   report,
+		"group_by": params.GroupBy,
+		"interval": params.Interval,
+		"from":     params.From,
+		"to":       params.To,
+	}).Debug("Computing report")
+}
+
+func (s *ReportService) logReportError(report string, err error) {
+	s.logger.WithFields(logrus.Fields{
+		"error":  err.Error(),
+		"report": report,
+	}).Error("Failed to compute report in repository")
+}
+
+// validateReport checks params against what report accepts, defaulting the
+// group.
+func validateReport(report domain.ReportType, params *domain.ReportParams) error {
+	groups := map[domain.ReportType][]string{
+		domain.ReportItemsByStatus:   domain.ItemReportGroups,
+		domain.ReportStockByCategory: domain.StockReportGroups,
+		domain.ReportProjectsBudget:  domain.ProjectReportGroups,
+	}[report]
+	if err := params.ValidateGroupBy(groups); err != nil {
+		return err
+	}
+	if params.Interval != "" {
+		if err := params.Interval.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if params.Status != "" {
+		var err error
+		if report == domain.ReportProjectsBudget {
+			err = domain.ProjectStatus(params.Status).Validate()
+		} else {
+			err = domain.ProjectItemStatus(params.Status).Validate()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if params.Priority != "" {
+		if err := domain.ProjectItemPriority(params.Priority).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func emptyIfNil[T any](rows []T) []T {
+	if rows == nil {
+		return []T{}
+	}
+	return rows
+}
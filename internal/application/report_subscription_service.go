@@ -0,0 +1,534 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// reportSchedulerBatch caps the subscriptions delivered per scheduler tick;
+// the rest are picked up on the next one.
+const reportSchedulerBatch = 50
+
+type ReportSubscriptionService struct {
+	repo     domain.ReportSubscriptionRepository
+	reports  *ReportService
+	mailer   domain.Mailer
+	renderer domain.EmailRenderer
+	users    domain.UserRepository
+	logger   *logrus.Logger
+	clock    domain.Clock
+	ids      domain.IDGenerator
+}
+
+func NewReportSubscriptionService(repo domain.ReportSubscriptionRepository, reports *ReportService) *ReportSubscriptionService {
+	return &ReportSubscriptionService{
+		repo:    repo,
+		reports: reports,
+		logger:  logrus.New(),
+		clock:   domain.SystemClock{},
+		ids:     domain.UUIDv7Generator{},
+	}
+}
+
+func (s *ReportSubscriptionService) WithClock(clock domain.Clock) *ReportSubscriptionService {
+	s.clock = clock
+	return s
+}
+
+func (s *ReportSubscriptionService) WithIDGenerator(ids domain.IDGenerator) *ReportSubscriptionService {
+	s.ids = ids
+	return s
+}
+
+// WithMailer sets how reports are emailed and the renderer that writes the
+// messages. Without them every delivery is recorded as failed.
+func (s *ReportSubscriptionService) WithMailer(mailer domain.Mailer, renderer domain.EmailRenderer) *ReportSubscriptionService {
+	s.mailer = mailer
+	s.renderer = renderer
+	return s
+}
+
+// WithUsers lets deliveries be written in the locale of the subscription's
+// owner. Without it they use domain.DefaultLocale.
+func (s *ReportSubscriptionService) WithUsers(users domain.UserRepository) *ReportSubscriptionService {
+	s.users = users
+	return s
+}
+
+func (s *ReportSubscriptionService) CreateReportSubscription(ctx context.Context, subscription *domain.ReportSubscription, actorID uuid.UUID) (*domain.ReportSubscription, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": actorID,
+		"report":  subscription.Report,
+		"name":    subscription.Name,
+	}).Info("Creating report subscription")
+
+	if err := subscription.Report.Validate(); err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	if err := normalizeReportSubscription(subscription, now); err != nil {
+		return nil, err
+	}
+
+	subscription.ID = s.ids.NewID()
+	subscription.UserID = actorID
+	subscription.LastRunAt = nil
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+	subscription.DeletedAt = nil
+
+	if err := s.repo.Create(ctx, subscription); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": actorID,
+		}).Error("Failed to create report subscription in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"subscription_id": subscription.ID,
+		"next_run_at":     subscription.NextRunAt,
+	}).Info("Report subscription created successfully")
+
+	return subscription, nil
+}
+
+// GetReportSubscription returns a subscription owned by actorID. Other
+// users' subscriptions are reported as not found.
+func (s *ReportSubscriptionService) GetReportSubscription(ctx context.Context, id, actorID uuid.UUID) (*domain.ReportSubscription, error) {
+	s.logger.WithFields(logrus.Fields{
+		"subscription_id": id,
+		"user_id":         actorID,
+	}).Debug("Getting report subscription")
+
+	subscription, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.UserID != actorID {
+		s.logger.WithFields(logrus.Fields{
+			"subscription_id": id,
+			"user_id":         actorID,
+		}).Warn("Report subscription hidden from non-owner")
+		return nil, domain.ErrReportSubscriptionNotFound
+	}
+
+	return subscription, nil
+}
+
+func (s *ReportSubscriptionService) ListReportSubscriptions(ctx context.Context, params domain.ReportSubscriptionParams, pagination domain.Pagination) ([]domain.ReportSubscription, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": params.UserID,
+		"report":  params.Report,
+	}).Debug("Listing report subscriptions")
+
+	if params.Report != "" {
+		if err := params.Report.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	subscriptions, err := s.repo.List(ctx, params, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": params.UserID,
+		}).Error("Failed to list report subscriptions from repository")
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// UpdateReportSubscription replaces everything but the report type of a
+// subscription owned by actorID. The next run is recomputed from now.
+func (s *ReportSubscriptionService) UpdateReportSubscription(ctx context.Context, subscription *domain.ReportSubscription, actorID uuid.UUID) (*domain.ReportSubscription, error) {
+	s.logger.WithFields(logrus.Fields{
+		"subscription_id": subscription.ID,
+		"user_id":         actorID,
+	}).Info("Updating report subscription")
+
+	existing, err := s.GetReportSubscription(ctx, subscription.ID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.Name = subscription.Name
+	existing.Format = subscription.Format
+	existing.Filters = subscription.Filters
+	existing.Schedule = subscription.Schedule
+	existing.Recipients = subscription.Recipients
+	existing.Active = subscription.Active
+	now := s.clock.Now()
+	if err := normalizeReportSubscription(existing, now); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = now
+
+	if err := s.repo.Update(ctx, existing); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": subscription.ID,
+		}).Error("Failed to update report subscription in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"subscription_id": existing.ID,
+		"next_run_at":     existing.NextRunAt,
+	}).Info("Report subscription updated successfully")
+
+	return existing, nil
+}
+
+func (s *ReportSubscriptionService) DeleteReportSubscription(ctx context.Context, id, actorID uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"subscription_id": id,
+		"user_id":         actorID,
+	}).Info("Deleting report subscription")
+
+	if _, err := s.GetReportSubscription(ctx, id, actorID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": id,
+		}).Error("Failed to delete report subscription in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"subscription_id": id,
+	}).Info("Report subscription deleted successfully")
+
+	return nil
+}
+
+// ListReportDeliveries returns the delivery history of a subscription owned
+// by actorID, newest first.
+func (s *ReportSubscriptionService) ListReportDeliveries(ctx context.Context, id, actorID uuid.UUID, pagination domain.Pagination) ([]domain.ReportDelivery, error) {
+	if _, err := s.GetReportSubscription(ctx, id, actorID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, id, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": id,
+		}).Error("Failed to list report deliveries from repository")
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// RunScheduler delivers due subscriptions every interval until ctx is done.
+func (s *ReportSubscriptionService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.WithFields(logrus.Fields{
+		"interval": interval,
+	}).Info("Report scheduler started")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunDue(ctx); err != nil && ctx.Err() == nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Report scheduler run failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Report scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue delivers the subscriptions whose next run has come and returns how
+// many it delivered. Each run is claimed first, so instances sharing the
+// database never send the same report twice. A subscription that missed
+// several runs, for instance while the server was down, is delivered once
+// and then follows its schedule from now on.
+func (s *ReportSubscriptionService) RunDue(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	subscriptions, err := s.repo.ListDue(ctx, now, reportSchedulerBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for i := range subscriptions {
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+		subscription := &subscriptions[i]
+		due := *subscription.NextRunAt
+
+		var next *time.Time
+		if schedule, err := domain.ParseCronSchedule(subscription.Schedule); err == nil {
+			if t := schedule.Next(now); !t.IsZero() {
+				next = &t
+			}
+		}
+
+		claimed, err := s.repo.Claim(ctx, subscription.ID, due, next, now)
+		if err != nil {
+			return delivered, err
+		}
+		if !claimed {
+			continue
+		}
+
+		s.deliver(ctx, subscription, due)
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+// deliver renders and emails one run of subscription and records the
+// outcome in its history.
+func (s *ReportSubscriptionService) deliver(ctx context.Context, subscription *domain.ReportSubscription, due time.Time) {
+	now := s.clock.Now()
+	delivery := &domain.ReportDelivery{
+		ID:             s.ids.NewID(),
+		SubscriptionID: subscription.ID,
+		Status:         domain.ReportDeliverySent,
+		Format:         subscription.Format,
+		Recipients:     subscription.Recipients,
+		ScheduledFor:   due,
+		CreatedAt:      now,
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, domain.ReportDeliveryTimeout)
+	defer cancel()
+	rows, err := s.send(sendCtx, subscription, now)
+	delivery.Rows = rows
+	if err != nil {
+		delivery.Status = domain.ReportDeliveryFailed
+		delivery.Error = err.Error()
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": subscription.ID,
+		}).Error("Failed to deliver scheduled report")
+	} else {
+		s.logger.WithFields(logrus.Fields{
+			"subscription_id": subscription.ID,
+			"recipients":      len(subscription.Recipients),
+			"rows":            rows,
+		}).Info("Scheduled report delivered")
+	}
+
+	if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": subscription.ID,
+		}).Error("Failed to record report delivery")
+	}
+}
+
+func (s *ReportSubscriptionService) send(ctx context.Context, subscription *domain.ReportSubscription, now time.Time) (int, error) {
+	if s.mailer == nil || s.renderer == nil {
+		return 0, errors.New("email is not configured")
+	}
+
+	params, err := reportFilterParams(subscription.Report, subscription.Filters)
+	if err != nil {
+		return 0, err
+	}
+	table, err := s.reportTable(ctx, subscription.Report, params)
+	if err != nil {
+		return 0, err
+	}
+
+	title := fmt.Sprintf("%s (%s report)", subscription.Name, subscription.Report)
+	attachment := domain.EmailAttachment{
+		Filename: fmt.Sprintf("%s-%s.%s", subscription.Report, now.Format("20060102-1504"), subscription.Format),
+	}
+	switch subscription.Format {
+	case domain.ReportFormatPDF:
+		attachment.ContentType = "application/pdf"
+		attachment.Data = renderReportPDF(title, now, table)
+	default:
+		attachment.ContentType = "text/csv"
+		if attachment.Data, err = renderReportCSV(table); err != nil {
+			return 0, err
+		}
+	}
+
+	content, err := s.renderer.Render(domain.EmailTemplateReportDelivery, s.ownerLocale(ctx, subscription), domain.ReportDeliveryEmail{
+		Name:        subscription.Name,
+		Report:      subscription.Report,
+		Format:      subscription.Format,
+		GeneratedAt: now,
+		Rows:        len(table.Rows),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	email := &domain.Email{
+		To:          subscription.Recipients,
+		Subject:     content.Subject,
+		Body:        content.Text,
+		HTMLBody:    content.HTML,
+		Attachments: []domain.EmailAttachment{attachment},
+	}
+	return len(table.Rows), s.mailer.Send(ctx, email)
+}
+
+// ownerLocale is the locale of the subscription's owner, or the default one
+// when the owner cannot be looked up.
+func (s *ReportSubscriptionService) ownerLocale(ctx context.Context, subscription *domain.ReportSubscription) domain.Locale {
+	if s.users == nil {
+		return domain.DefaultLocale
+	}
+	owner, err := s.users.GetByID(ctx, subscription.UserID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": subscription.ID,
+			"user_id":         subscription.UserID,
+		}).Warn("Failed to look up report subscription owner, using default locale")
+		return domain.DefaultLocale
+	}
+	return owner.Locale
+}
+
+func (s *ReportSubscriptionService) reportTable(ctx context.Context, report domain.ReportType, params domain.ReportParams) (reportTable, error) {
+	switch report {
+	case domain.ReportItemsByStatus:
+		rows, err := s.reports.ItemsReport(ctx, params)
+		return itemsReportTable(rows), err
+	case domain.ReportStockByCategory:
+		rows, err := s.reports.StockReport(ctx, params)
+		return stockReportTable(rows), err
+	case domain.ReportProjectsBudget:
+		rows, err := s.reports.ProjectsReport(ctx, params)
+		return projectsReportTable(rows), err
+	}
+	return reportTable{}, report.Validate()
+}
+
+// normalizeReportSubscription validates a subscription against its report
+// type and schedules its next run after now.
+func normalizeReportSubscription(subscription *domain.ReportSubscription, now time.Time) error {
+	subscription.Name = strings.TrimSpace(subscription.Name)
+	if subscription.Name == "" {
+		return errors.New("report subscription name is required")
+	}
+
+	if subscription.Format == "" {
+		subscription.Format = domain.ReportFormatCSV
+	}
+	if err := subscription.Format.Validate(); err != nil {
+		return err
+	}
+
+	filters := make(domain.StringMap, len(subscription.Filters))
+	for key, value := range subscription.Filters {
+		key = strings.TrimSpace(key)
+		if !slices.Contains(domain.ReportFilterKeys[subscription.Report], key) {
+			return fmt.Errorf("the %s report has no %q filter", subscription.Report, key)
+		}
+		filters[key] = strings.TrimSpace(value)
+	}
+	subscription.Filters = filters
+	if _, err := reportFilterParams(subscription.Report, filters); err != nil {
+		return err
+	}
+
+	recipients, err := normalizeRecipients(subscription.Recipients)
+	if err != nil {
+		return err
+	}
+	subscription.Recipients = recipients
+
+	subscription.Schedule = strings.TrimSpace(subscription.Schedule)
+	schedule, err := domain.ParseCronSchedule(subscription.Schedule)
+	if err != nil {
+		return err
+	}
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return fmt.Errorf("cron schedule %q never fires", subscription.Schedule)
+	}
+	subscription.NextRunAt = nil
+	if subscription.Active {
+		subscription.NextRunAt = &next
+	}
+	return nil
+}
+
+func normalizeRecipients(recipients []string) (domain.StringList, error) {
+	normalized := domain.StringList{}
+	for _, recipient := range recipients {
+		address, err := mail.ParseAddress(strings.TrimSpace(recipient))
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q", recipient)
+		}
+		email := strings.ToLower(address.Address)
+		if !slices.Contains(normalized, email) {
+			normalized = append(normalized, email)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, errors.New("report subscription needs at least one recipient")
+	}
+	if len(normalized) > domain.MaxReportRecipients {
+		return nil, fmt.Errorf("report subscription cannot have more than %d recipients", domain.MaxReportRecipients)
+	}
+	return normalized, nil
+}
+
+// reportFilterParams turns stored filters into the parameters of the report,
+// validated as the report endpoint would.
+func reportFilterParams(report domain.ReportType, filters domain.StringMap) (domain.ReportParams, error) {
+	params := domain.ReportParams{
+		GroupBy:  filters["group_by"],
+		Interval: domain.ReportInterval(filters["interval"]),
+		Status:   filters["status"],
+		Priority: filters["priority"],
+		Category: filters["category"],
+	}
+
+	ids := map[string]**uuid.UUID{
+		"project_id":  &params.ProjectID,
+		"assigned_to": &params.AssignedTo,
+		"owner_id":    &params.OwnerID,
+	}
+	for key, target := range ids {
+		if raw := filters[key]; raw != "" {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return params, fmt.Errorf("invalid %s filter", key)
+			}
+			*target = &id
+		}
+	}
+	if raw := filters["include_archived"]; raw != "" {
+		archived, err := strconv.ParseBool(raw)
+		if err != nil {
+			return params, errors.New("invalid include_archived filter")
+		}
+		params.IncludeArchived = archived
+	}
+
+	return params, validateReport(report, &params)
+}
@@ -0,0 +1,413 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type RetentionService struct {
+	repo   domain.RetentionRepository
+	logger *logrus.Logger
+	clock  domain.Clock
+	ids    domain.IDGenerator
+}
+
+func NewRetentionService(repo domain.RetentionRepository) *RetentionService {
+	return &RetentionService{
+		repo:   repo,
+		logger: logrus.New(),
+		clock:  domain.SystemClock{},
+		ids:    domain.UUIDv7Generator{},
+	}
+}
+
+func (s *RetentionService) WithClock(clock domain.Clock) *RetentionService {
+	s.clock = clock
+	return s
+}
+
+func (s *RetentionService) WithIDGenerator(ids domain.IDGenerator) *RetentionService {
+	s.ids = ids
+	return s
+}
+
+func (s *RetentionService) CreateRetentionRule(ctx context.Context, rule *domain.RetentionRule, actorID uuid.UUID) (*domain.RetentionRule, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id":     actorID,
+		"entity":      rule.Entity,
+		"action":      rule.Action,
+		"retain_days": rule.RetainDays,
+	}).Info("Creating retention rule")
+
+	if err := rule.Entity.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateRetentionRule(rule); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	rule.ID = s.ids.NewID()
+	rule.CreatedBy = actorID
+	rule.LastRunAt = nil
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+	rule.DeletedAt = nil
+
+	if err := s.repo.CreateRule(ctx, rule); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"entity": rule.Entity,
+		}).Error("Failed to create retention rule in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"rule_id": rule.ID,
+		"entity":  rule.Entity,
+	}).Info("Retention rule created successfully")
+
+	return rule, nil
+}
+
+func (s *RetentionService) GetRetentionRule(ctx context.Context, id uuid.UUID) (*domain.RetentionRule, error) {
+	s.logger.WithFields(logrus.Fields{
+		"rule_id": id,
+	}).Debug("Getting retention rule")
+
+	return s.repo.GetRule(ctx, id)
+}
+
+func (s *RetentionService) ListRetentionRules(ctx context.Context) ([]domain.RetentionRule, error) {
+	s.logger.Debug("Listing retention rules")
+
+	rules, err := s.repo.ListRules(ctx)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list retention rules from repository")
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// UpdateRetentionRule replaces the action, period and enabled flag of a rule.
+// Its entity cannot change.
+func (s *RetentionService) UpdateRetentionRule(ctx context.Context, rule *domain.RetentionRule) (*domain.RetentionRule, error) {
+	s.logger.WithFields(logrus.Fields{
+		"rule_id": rule.ID,
+	}).Info("Updating retention rule")
+
+	existing, err := s.repo.GetRule(ctx, rule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.Action = rule.Action
+	existing.RetainDays = rule.RetainDays
+	existing.Enabled = rule.Enabled
+	if err := validateRetentionRule(existing); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = s.clock.Now()
+
+	if err := s.repo.UpdateRule(ctx, existing); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"rule_id": rule.ID,
+		}).Error("Failed to update retention rule in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"rule_id": existing.ID,
+	}).Info("Retention rule updated successfully")
+
+	return existing, nil
+}
+
+func (s *RetentionService) DeleteRetentionRule(ctx context.Context, id uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"rule_id": id,
+	}).Info("Deleting retention rule")
+
+	if err := s.repo.DeleteRule(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"rule_id": id,
+		}).Error("Failed to delete retention rule in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"rule_id": id,
+	}).Info("Retention rule deleted successfully")
+
+	return nil
+}
+
+// RunRetention evaluates every enabled rule right away, or a single one when
+// ruleID is set, and returns the recorded runs. A dry run only reports how
+// many records each rule would affect. Unlike the scheduler, manual runs do
+// not move the rules' last run.
+func (s *RetentionService) RunRetention(ctx context.Context, ruleID *uuid.UUID, dryRun bool, actorID uuid.UUID) ([]domain.RetentionRun, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": actorID,
+		"rule_id": ruleID,
+		"dry_run": dryRun,
+	}).Info("Running retention rules")
+
+	var rules []domain.RetentionRule
+	if ruleID != nil {
+		rule, err := s.repo.GetRule(ctx, *ruleID)
+		if err != nil {
+			return nil, err
+		}
+		rules = []domain.RetentionRule{*rule}
+	} else {
+		all, err := s.repo.ListRules(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range all {
+			if rule.Enabled {
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	runs := []domain.RetentionRun{}
+	for i := range rules {
+		if ctx.Err() != nil {
+			return runs, ctx.Err()
+		}
+		runs = append(runs, s.apply(ctx, &rules[i], dryRun, &actorID))
+	}
+
+	return runs, nil
+}
+
+// RunScheduler applies the enabled rules every interval until ctx is done.
+func (s *RetentionService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.WithFields(logrus.Fields{
+		"interval": interval,
+	}).Info("Retention scheduler started")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunDue(ctx, interval); err != nil && ctx.Err() == nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Retention scheduler run failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Retention scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue applies the enabled rules that have not run within interval and
+// returns how many it applied. Each run is claimed first, so instances
+// sharing the database never apply the same rule twice per interval.
+func (s *RetentionService) RunDue(ctx context.Context, interval time.Duration) (int, error) {
+	rules, err := s.repo.ListRules(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.clock.Now()
+	applied := 0
+	for i := range rules {
+		if ctx.Err() != nil {
+			return applied, ctx.Err()
+		}
+		rule := &rules[i]
+		if !rule.Enabled || (rule.LastRunAt != nil && now.Sub(*rule.LastRunAt) < interval) {
+			continue
+		}
+
+		claimed, err := s.repo.ClaimRule(ctx, rule.ID, rule.LastRunAt, now)
+		if err != nil {
+			return applied, err
+		}
+		if !claimed {
+			continue
+		}
+
+		s.apply(ctx, rule, false, nil)
+		applied++
+	}
+
+	return applied, nil
+}
+
+// apply evaluates one rule and records the run, failed or not.
+func (s *RetentionService) apply(ctx context.Context, rule *domain.RetentionRule, dryRun bool, actorID *uuid.UUID) domain.RetentionRun {
+	started := s.clock.Now()
+	run := domain.RetentionRun{
+		ID:          s.ids.NewID(),
+		RuleID:      rule.ID,
+		Entity:      rule.Entity,
+		Action:      rule.Action,
+		DryRun:      dryRun,
+		Cutoff:      rule.Cutoff(started),
+		TriggeredBy: actorID,
+		StartedAt:   started,
+	}
+
+	affected, err := s.repo.Apply(ctx, rule, run.Cutoff, dryRun)
+	run.Affected = affected
+	run.FinishedAt = s.clock.Now()
+	if err != nil {
+		run.Error = err.Error()
+		s.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"rule_id":  rule.ID,
+			"entity":   rule.Entity,
+			"affected": affected,
+		}).Error("Failed to apply retention rule")
+	} else {
+		s.logger.WithFields(logrus.Fields{
+			"rule_id":  rule.ID,
+			"entity":   rule.Entity,
+			"action":   rule.Action,
+			"dry_run":  dryRun,
+			"affected": affected,
+		}).Info("Retention rule applied")
+	}
+
+	if err := s.repo.CreateRun(ctx, &run); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"rule_id": rule.ID,
+		}).Error("Failed to record retention run")
+	}
+
+	return run
+}
+
+func (s *RetentionService) ListRetentionRuns(ctx context.Context, params domain.RetentionRunParams, pagination domain.Pagination) ([]domain.RetentionRun, error) {
+	s.logger.WithFields(logrus.Fields{
+		"rule_id": params.RuleID,
+		"dry_run": params.DryRun,
+	}).Debug("Listing retention runs")
+
+	runs, err := s.repo.ListRuns(ctx, params, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list retention runs from repository")
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// RetentionMetrics totals the runs and affected records per entity,
+// leaving dry runs out.
+func (s *RetentionService) RetentionMetrics(ctx context.Context) ([]domain.RetentionMetric, error) {
+	metrics, err := s.repo.Metrics(ctx)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to compute retention metrics from repository")
+		return nil, err
+	}
+
+	return emptyIfNil(metrics), nil
+}
+
+func (s *RetentionService) ListArchivedRecords(ctx context.Context, params domain.ArchivedRecordParams, pagination domain.Pagination) ([]domain.ArchivedRecord, error) {
+	s.logger.WithFields(logrus.Fields{
+		"entity":     params.Entity,
+		"project_id": params.ProjectID,
+	}).Debug("Listing archived records")
+
+	if params.Entity != "" {
+		if err := params.Entity.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	records, err := s.repo.ListArchived(ctx, params, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list archived records from repository")
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (s *RetentionService) GetArchivedRecord(ctx context.Context, entity domain.RetentionEntity, id uuid.UUID) (*domain.ArchivedRecord, error) {
+	s.logger.WithFields(logrus.Fields{
+		"entity":    entity,
+		"record_id": id,
+	}).Debug("Getting archived record")
+
+	if err := entity.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetArchived(ctx, entity, id)
+}
+
+// RestoreArchivedRecord moves an archived record back into its table. An
+// item or stock adjustment whose project or product is gone is refused
+// with ErrArchivedRecordOrphaned. An archive rule may pick the record up
+// again on its next run unless it changes in the meantime.
+func (s *RetentionService) RestoreArchivedRecord(ctx context.Context, entity domain.RetentionEntity, id uuid.UUID, actorID uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"user_id":   actorID,
+		"entity":    entity,
+		"record_id": id,
+	}).Info("Restoring archived record")
+
+	if err := entity.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.repo.Restore(ctx, entity, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"entity":    entity,
+			"record_id": id,
+		}).Error("Failed to restore archived record in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"entity":    entity,
+		"record_id": id,
+	}).Info("Archived record restored successfully")
+
+	return nil
+}
+
+// validateRetentionRule defaults the action to the one the entity supports
+// and checks the retention period.
+func validateRetentionRule(rule *domain.RetentionRule) error {
+	if rule.Action == "" {
+		rule.Action = domain.RetentionEntityActions[rule.Entity][0]
+	}
+	if err := rule.Action.ValidateFor(rule.Entity); err != nil {
+		return err
+	}
+	if rule.RetainDays < domain.MinRetentionDays || rule.RetainDays > domain.MaxRetentionDays {
+		return fmt.Errorf("retain_days must be between %d and %d", domain.MinRetentionDays, domain.MaxRetentionDays)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// savedFilterReservedParams cannot be stored in a saved filter's query:
+// pagination belongs to each request and the sort has its own field.
+var savedFilterReservedParams = []string{"limit", "offset", "sort", "saved_filter"}
+
+type SavedFilterService struct {
+	repo   domain.SavedFilterRepository
+	logger *logrus.Logger
+	clock  domain.Clock
+	ids    domain.IDGenerator
+}
+
+func NewSavedFilterService(repo domain.SavedFilterRepository) *SavedFilterService {
+	return &SavedFilterService{
+		repo:   repo,
+		logger: logrus.New(),
+		clock:  domain.SystemClock{},
+		ids:    domain.UUIDv7Generator{},
+	}
+}
+
+func (s *SavedFilterService) WithClock(clock domain.Clock) *SavedFilterService {
+	s.clock = clock
+	return s
+}
+
+func (s *SavedFilterService) WithIDGenerator(ids domain.IDGenerator) *SavedFilterService {
+	s.ids = ids
+	return s
+}
+
+func (s *SavedFilterService) CreateSavedFilter(ctx context.Context, filter *domain.SavedFilter, actorID uuid.UUID) (*domain.SavedFilter, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": actorID,
+		"entity":  filter.Entity,
+		"name":    filter.Name,
+	}).Info("Creating saved filter")
+
+	if err := filter.Entity.Validate(); err != nil {
+		return nil, err
+	}
+	if err := normalizeSavedFilter(filter); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	filter.ID = s.ids.NewID()
+	filter.UserID = actorID
+	filter.CreatedAt = now
+	filter.UpdatedAt = now
+	filter.DeletedAt = nil
+
+	if err := s.repo.Create(ctx, filter); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": actorID,
+		}).Error("Failed to create saved filter in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"saved_filter_id": filter.ID,
+		"user_id":         actorID,
+	}).Info("Saved filter created successfully")
+
+	return filter, nil
+}
+
+// GetSavedFilter returns a filter owned by actorID or shared with everyone.
+// Other users' private filters are reported as not found.
+func (s *SavedFilterService) GetSavedFilter(ctx context.Context, id, actorID uuid.UUID) (*domain.SavedFilter, error) {
+	s.logger.WithFields(logrus.Fields{
+		"saved_filter_id": id,
+		"user_id":         actorID,
+	}).Debug("Getting saved filter")
+
+	filter, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if filter.UserID != actorID && !filter.Shared {
+		s.logger.WithFields(logrus.Fields{
+			"saved_filter_id": id,
+			"user_id":         actorID,
+		}).Warn("Saved filter hidden from non-owner")
+		return nil, domain.ErrSavedFilterNotFound
+	}
+
+	return filter, nil
+}
+
+func (s *SavedFilterService) ListSavedFilters(ctx context.Context, params domain.SavedFilterParams, pagination domain.Pagination) ([]domain.SavedFilter, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": params.UserID,
+		"entity":  params.Entity,
+	}).Debug("Listing saved filters")
+
+	filters, err := s.repo.List(ctx, params, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": params.UserID,
+		}).Error("Failed to list saved filters from repository")
+		return nil, err
+	}
+
+	return filters, nil
+}
+
+// UpdateSavedFilter replaces the name, query, sort and sharing of a filter
+// owned by actorID. The entity cannot change.
+func (s *SavedFilterService) UpdateSavedFilter(ctx context.Context, filter *domain.SavedFilter, actorID uuid.UUID) (*domain.SavedFilter, error) {
+	s.logger.WithFields(logrus.Fields{
+		"saved_filter_id": filter.ID,
+		"user_id":         actorID,
+	}).Info("Updating saved filter")
+
+	existing, err := s.owned(ctx, filter.ID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.Name = filter.Name
+	existing.Query = filter.Query
+	existing.Sort = filter.Sort
+	existing.Shared = filter.Shared
+	if err := normalizeSavedFilter(existing); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = s.clock.Now()
+
+	if err := s.repo.Update(ctx, existing); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"saved_filter_id": filter.ID,
+		}).Error("Failed to update saved filter in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"saved_filter_id": existing.ID,
+	}).Info("Saved filter updated successfully")
+
+	return existing, nil
+}
+
+func (s *SavedFilterService) DeleteSavedFilter(ctx context.Context, id, actorID uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"saved_filter_id": id,
+		"user_id":         actorID,
+	}).Info("Deleting saved filter")
+
+	if _, err := s.owned(ctx, id, actorID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"saved_filter_id": id,
+		}).Error("Failed to delete saved filter in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"saved_filter_id": id,
+	}).Info("Saved filter deleted successfully")
+
+	return nil
+}
+
+func (s *SavedFilterService) owned(ctx context.Context, id, actorID uuid.UUID) (*domain.SavedFilter, error) {
+	filter, err := s.GetSavedFilter(ctx, id, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if filter.UserID != actorID {
+		return nil, domain.ErrSavedFilterForbidden
+	}
+	return filter, nil
+}
+
+func normalizeSavedFilter(filter *domain.SavedFilter) error {
+	filter.Name = strings.TrimSpace(filter.Name)
+	if filter.Name == "" {
+		return errors.New("saved filter name is required")
+	}
+	filter.Sort = strings.TrimSpace(filter.Sort)
+	if filter.Sort != "" {
+		if _, err := filter.Entity.Sort().Parse(filter.Sort); err != nil {
+			return err
+		}
+	}
+
+	query := make(domain.StringMap, len(filter.Query))
+	for key, value := range filter.Query {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return errors.New("saved filter query parameters need a name")
+		}
+		for _, reserved := range savedFilterReservedParams {
+			if key == reserved {
+				return fmt.Errorf("saved filter query cannot set %q", key)
+			}
+		}
+		query[key] = value
+	}
+	filter.Query = query
+	return nil
+}
@@ -0,0 +1,165 @@
+package application
+
+import (
+	"context"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type StockAdjustmentService struct {
+	repo          domain.StockAdjustmentRepository
+	productRepo   domain.ProductRepository
+	warehouseRepo domain.WarehouseRepository
+	logger        *logrus.Logger
+	clock         domain.Clock
+	ids           domain.IDGenerator
+}
+
+func NewStockAdjustmentService(repo domain.StockAdjustmentRepository, productRepo domain.ProductRepository, warehouseRepo domain.WarehouseRepository) *StockAdjustmentService {
+	return &StockAdjustmentService{
+		repo:          repo,
+		productRepo:   productRepo,
+		warehouseRepo: warehouseRepo,
+		logger:        logrus.New(),
+		clock:         domain.SystemClock{},
+		ids:           domain.UUIDv7Generator{},
+	}
+}
+
+func (s *StockAdjustmentService) WithClock(clock domain.Clock) *StockAdjustmentService {
+	s.clock = clock
+	return s
+}
+
+func (s *StockAdjustmentService) WithIDGenerator(ids domain.IDGenerator) *StockAdjustmentService {
+	s.ids = ids
+	return s
+}
+
+func (s *StockAdjustmentService) AdjustStock(ctx context.Context, productID, actorID uuid.UUID, warehouseID *uuid.UUID, quantity int, reason, note string) (*domain.StockAdjustment, error) {
+	s.logger.WithFields(logrus.Fields{
+		"product_id":   productID,
+		"actor_id":     actorID,
+		"warehouse_id": warehouseID,
+		"quantity":     quantity,
+		"reason":       reason,
+	}).Info("Adjusting product stock")
+
+	if err := domain.ValidateStockAdjustment(reason, quantity); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"product_id": productID,
+			"quantity":   quantity,
+			"reason":     reason,
+		}).Warn("Invalid stock adjustment")
+		return nil, err
+	}
+
+	if warehouseID != nil {
+		if _, err := s.warehouseRepo.GetByID(ctx, *warehouseID); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err.Error(),
+				"warehouse_id": *warehouseID,
+			}).Warn("Warehouse not found for stock adjustment")
+			return nil, err
+		}
+	}
+
+	adjustment := &domain.StockAdjustment{
+		ID:          s.ids.NewID(),
+		ProductID:   productID,
+		WarehouseID: warehouseID,
+		Quantity:    quantity,
+		Reason:      reason,
+		Note:        note,
+		ActorID:     actorID,
+		CreatedAt:   s.clock.Now(),
+	}
+
+	if err := s.repo.Apply(ctx, adjustment); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+			"quantity":   quantity,
+		}).Warn("Failed to apply stock adjustment in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"adjustment_id": adjustment.ID,
+		"product_id":    productID,
+		"old_stock":     adjustment.StockBefore,
+		"new_stock":     adjustment.StockAfter,
+	}).Info("Product stock adjusted successfully")
+
+	return adjustment, nil
+}
+
+func (s *StockAdjustmentService) ListStockAdjustments(ctx context.Context, productID uuid.UUID, pagination domain.Pagination) ([]domain.StockAdjustment, error) {
+	s.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+		"limit":      pagination.Limit,
+		"offset":     pagination.Offset,
+	}).Debug("Listing stock adjustments")
+
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Warn("Product not found for stock adjustment history")
+		return nil, err
+	}
+
+	adjustments, err := s.repo.ListByProduct(ctx, productID, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Error("Failed to list stock adjustments from repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+		"count":      len(adjustments),
+	}).Info("Stock adjustments listed successfully")
+
+	return adjustments, nil
+}
+
+// ListStockMovements returns the stock adjustments and warehouse transfers of
+// a product as one history.
+func (s *StockAdjustmentService) ListStockMovements(ctx context.Context, productID uuid.UUID, filter domain.StockMovementParams, pagination domain.Pagination) ([]domain.StockMovement, error) {
+	s.logger.WithFields(logrus.Fields{
+		"product_id":          productID,
+		"filter_reason":       filter.Reason,
+		"filter_warehouse_id": filter.WarehouseID,
+		"limit":               pagination.Limit,
+		"offset":              pagination.Offset,
+	}).Debug("Listing stock movements")
+
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Warn("Product not found for stock movement history")
+		return nil, err
+	}
+
+	movements, err := s.repo.ListMovements(ctx, productID, filter, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Error("Failed to list stock movements from repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+		"count":      len(movements),
+	}).Info("Stock movements listed successfully")
+
+	return movements, nil
+}
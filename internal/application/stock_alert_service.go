@@ -0,0 +1,177 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// StockAlertService announces products that drop to their reorder level to
+// the active admins, as notifications, and to the chat channels subscribed
+// to low stock.
+type StockAlertService struct {
+	productRepo      domain.ProductRepository
+	userRepo         domain.UserRepository
+	notificationRepo domain.NotificationRepository
+	dispatcher       domain.NotificationDispatcher
+	chat             domain.ChatNotifier
+	lowStock         int
+	logger           *logrus.Logger
+	clock            domain.Clock
+	ids              domain.IDGenerator
+}
+
+func NewStockAlertService(productRepo domain.ProductRepository, userRepo domain.UserRepository, notificationRepo domain.NotificationRepository) *StockAlertService {
+	return &StockAlertService{
+		productRepo:      productRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		lowStock:         domain.DefaultLowStockThreshold,
+		logger:           logrus.New(),
+		clock:            domain.SystemClock{},
+		ids:              domain.UUIDv7Generator{},
+	}
+}
+
+func (s *StockAlertService) WithClock(clock domain.Clock) *StockAlertService {
+	s.clock = clock
+	return s
+}
+
+func (s *StockAlertService) WithIDGenerator(ids domain.IDGenerator) *StockAlertService {
+	s.ids = ids
+	return s
+}
+
+// WithDispatcher also delivers every stored notification through
+// dispatcher, such as push, in the background.
+func (s *StockAlertService) WithDispatcher(dispatcher domain.NotificationDispatcher) *StockAlertService {
+	s.dispatcher = dispatcher
+	return s
+}
+
+// WithChatNotifier also announces low products to chat channels.
+func (s *StockAlertService) WithChatNotifier(chat domain.ChatNotifier) *StockAlertService {
+	s.chat = chat
+	return s
+}
+
+// WithLowStockThreshold sets the level of the products without a reorder
+// level of their own.
+func (s *StockAlertService) WithLowStockThreshold(threshold int) *StockAlertService {
+	s.lowStock = threshold
+	return s
+}
+
+// RunScheduler checks for low products every interval until ctx is done.
+func (s *StockAlertService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.WithFields(logrus.Fields{
+		"interval":  interval,
+		"threshold": s.lowStock,
+	}).Info("Low stock checker started")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.CheckLowStock(ctx); err != nil && ctx.Err() == nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Low stock check failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Low stock checker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckLowStock announces the products that dropped to their level since
+// the last check and returns how many it announced. A product is announced
+// again only after its stock has been back above the level.
+func (s *StockAlertService) CheckLowStock(ctx context.Context) (int, error) {
+	var admins []domain.User
+	announced := 0
+	for {
+		products, err := s.productRepo.ClaimLowStockAlerts(ctx, s.lowStock, s.clock.Now(), domain.LowStockAlertBatch)
+		if err != nil {
+			return announced, err
+		}
+		if len(products) == 0 {
+			break
+		}
+
+		if admins == nil {
+			admins, err = s.userRepo.List(ctx, domain.Params{Role: domain.RoleAdmin, ActiveOnly: true}, domain.Pagination{})
+			if err != nil {
+				return announced, err
+			}
+		}
+		if err := s.announce(ctx, products, admins); err != nil {
+			return announced, err
+		}
+		announced += len(products)
+
+		if len(products) < domain.LowStockAlertBatch {
+			break
+		}
+	}
+
+	if announced > 0 {
+		s.logger.WithFields(logrus.Fields{
+			"products": announced,
+		}).Info("Low stock alerts sent")
+	}
+
+	return announced, nil
+}
+
+func (s *StockAlertService) announce(ctx context.Context, products []domain.Product, admins []domain.User) error {
+	now := s.clock.Now()
+	notifications := make([]domain.Notification, 0, len(products)*len(admins))
+	for i := range products {
+		product := &products[i]
+		level := product.LowStockLevel(s.lowStock)
+		for _, admin := range admins {
+			notifications = append(notifications, domain.Notification{
+				ID:         s.ids.NewID(),
+				UserID:     admin.ID,
+				TargetType: domain.NotificationTargetProduct,
+				TargetID:   product.ID,
+				Event:      domain.ChangeEventLowStock,
+				Message:    fmt.Sprintf("Product %q is low on stock: %d left, reorder level %d", product.Name, product.Stock, level),
+				CreatedAt:  now,
+			})
+		}
+		if s.chat != nil {
+			s.chat.ProductLowStock(ctx, product, level)
+		}
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+	if err := s.notificationRepo.CreateBatch(ctx, notifications); err != nil {
+		return err
+	}
+	s.dispatch(notifications)
+	return nil
+}
+
+// dispatch hands stored notifications to the dispatcher in the background.
+func (s *StockAlertService) dispatch(notifications []domain.Notification) {
+	if s.dispatcher == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), domain.PushDispatchTimeout)
+		defer cancel()
+		s.dispatcher.Dispatch(ctx, notifications)
+	}()
+}
@@ -0,0 +1,376 @@
+package application
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// refreshTokenBytes is the entropy of a refresh token.
+const refreshTokenBytes = 32
+
+// mfaChallengeAudience marks MFA challenge tokens, which are signed with a
+// key derived from the JWT secret so they are never taken as access tokens.
+const mfaChallengeAudience = "mfa-challenge"
+
+type TokenService struct {
+	secret        []byte
+	ttl           time.Duration
+	refreshTokens domain.RefreshTokenRepository
+	refreshTTL    time.Duration
+	revocations   domain.RevokedTokenRepository
+	logger        *logrus.Logger
+	clock         domain.Clock
+	ids           domain.IDGenerator
+}
+
+func NewTokenService(secret string, ttl time.Duration) *TokenService {
+	return &TokenService{
+		secret: []byte(secret),
+		ttl:    ttl,
+		logger: logrus.New(),
+		clock:  domain.SystemClock{},
+		ids:    domain.UUIDv7Generator{},
+	}
+}
+
+func (s *TokenService) WithClock(clock domain.Clock) *TokenService {
+	s.clock = clock
+	return s
+}
+
+func (s *TokenService) WithIDGenerator(ids domain.IDGenerator) *TokenService {
+	s.ids = ids
+	return s
+}
+
+// WithRefreshTokens stores refresh tokens in repo and keeps them usable for
+// ttl. Without it, or with a ttl of zero, only access tokens are issued.
+func (s *TokenService) WithRefreshTokens(repo domain.RefreshTokenRepository, ttl time.Duration) *TokenService {
+	s.refreshTokens = repo
+	s.refreshTTL = ttl
+	return s
+}
+
+// WithRevocations lets access tokens be revoked before they expire, by
+// keeping the revoked ones in repo. Without it, RevokeAccessToken does
+// nothing.
+func (s *TokenService) WithRevocations(repo domain.RevokedTokenRepository) *TokenService {
+	s.revocations = repo
+	return s
+}
+
+func (s *TokenService) IssueAccessToken(user *domain.User) (string, time.Time, error) {
+	return s.IssueToken(user, s.ttl, nil)
+}
+
+func (s *TokenService) IssueToken(user *domain.User, ttl time.Duration, scopes []string) (string, time.Time, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+		"ttl":     ttl,
+		"scopes":  scopes,
+	}).Debug("Issuing JWT token")
+
+	if len(s.secret) == 0 {
+		s.logger.Error("JWT signing secret is not configured")
+		return "", time.Time{}, errors.New("jwt secret is not configured")
+	}
+
+	if ttl <= 0 {
+		s.logger.WithFields(logrus.Fields{
+			"ttl": ttl,
+		}).Warn("Invalid token TTL")
+		return "", time.Time{}, errors.New("token ttl must be greater than zero")
+	}
+
+	for _, scope := range scopes {
+		if !domain.ValidScope(scope) {
+			s.logger.WithFields(logrus.Fields{
+				"scope": scope,
+			}).Warn("Invalid token scope")
+			return "", time.Time{}, errors.New("invalid scope: " + scope)
+		}
+	}
+
+	now := s.clock.Now()
+	expiresAt := now.Add(ttl)
+	claims := jwt.MapClaims{
+		"sub":   user.ID.String(),
+		"email": user.Email,
+		"role":  user.Role,
+		"iat":   now.Unix(),
+		"exp":   expiresAt.Unix(),
+	}
+	if len(scopes) > 0 {
+		claims["scopes"] = scopes
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenStr, err := token.SignedString(s.secret)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Error("Failed to sign JWT token")
+		return "", time.Time{}, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":    user.ID,
+		"expires_at": expiresAt,
+	}).Debug("JWT token issued successfully")
+
+	return tokenStr, expiresAt, nil
+}
+
+// IssueMFAChallenge returns a token proving user gave the right password,
+// which RedeemMFAChallenge exchanges for the user's ID within
+// domain.MFAChallengeTTL. It grants no access by itself.
+func (s *TokenService) IssueMFAChallenge(user *domain.User) (string, time.Time, error) {
+	if len(s.secret) == 0 {
+		s.logger.Error("JWT signing secret is not configured")
+		return "", time.Time{}, errors.New("jwt secret is not configured")
+	}
+
+	now := s.clock.Now()
+	expiresAt := now.Add(domain.MFAChallengeTTL)
+	claims := jwt.RegisteredClaims{
+		Subject:   user.ID.String(),
+		Audience:  jwt.ClaimStrings{mfaChallengeAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.mfaChallengeKey())
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Error("Failed to sign MFA challenge")
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// RedeemMFAChallenge returns the user a challenge from IssueMFAChallenge was
+// issued to, or domain.ErrMFAChallengeInvalid when it is malformed or
+// expired.
+func (s *TokenService) RedeemMFAChallenge(token string) (uuid.UUID, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return s.mfaChallengeKey(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
+	if err != nil || !claims.VerifyAudience(mfaChallengeAudience, true) || claims.ExpiresAt == nil ||
+		!s.clock.Now().Before(claims.ExpiresAt.Time) {
+		return uuid.Nil, domain.ErrMFAChallengeInvalid
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, domain.ErrMFAChallengeInvalid
+	}
+	return userID, nil
+}
+
+func (s *TokenService) mfaChallengeKey() []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(mfaChallengeAudience))
+	return mac.Sum(nil)
+}
+
+// IssueRefreshToken creates a refresh token for userID. The token is only
+// ever returned here.
+func (s *TokenService) IssueRefreshToken(ctx context.Context, userID uuid.UUID) (*domain.IssuedRefreshToken, error) {
+	if s.refreshTokens == nil || s.refreshTTL <= 0 {
+		return nil, domain.ErrRefreshTokensDisabled
+	}
+
+	secret := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	now := s.clock.Now()
+	record := &domain.RefreshToken{
+		ID:        s.ids.NewID(),
+		UserID:    userID,
+		TokenHash: tokenHash(token),
+		ExpiresAt: now.Add(s.refreshTTL),
+		CreatedAt: now,
+	}
+	if err := s.refreshTokens.Create(ctx, record); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to save refresh token in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":    userID,
+		"expires_at": record.ExpiresAt,
+	}).Debug("Refresh token issued successfully")
+
+	return &domain.IssuedRefreshToken{Token: token, ExpiresAt: record.ExpiresAt}, nil
+}
+
+// RedeemRefreshToken revokes token and returns the user it was issued to,
+// who should get a new pair of tokens. Unknown, expired and revoked tokens
+// are reported as domain.ErrRefreshTokenInvalid; a revoked one being used
+// again also revokes every other token of its user, since it must have
+// leaked.
+func (s *TokenService) RedeemRefreshToken(ctx context.Context, token string) (uuid.UUID, error) {
+	if s.refreshTokens == nil {
+		return uuid.Nil, domain.ErrRefreshTokensDisabled
+	}
+
+	record, err := s.refreshTokens.GetByHash(ctx, tokenHash(token))
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	now := s.clock.Now()
+	if record.RevokedAt != nil {
+		revoked, err := s.refreshTokens.RevokeAllForUser(ctx, record.UserID, now)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		s.logger.WithFields(logrus.Fields{
+			"user_id":  record.UserID,
+			"token_id": record.ID,
+			"revoked":  revoked,
+		}).Warn("Revoked refresh token reused, revoking all tokens of the user")
+		return uuid.Nil, domain.ErrRefreshTokenInvalid
+	}
+	if !now.Before(record.ExpiresAt) {
+		return uuid.Nil, domain.ErrRefreshTokenInvalid
+	}
+
+	if err := s.refreshTokens.Revoke(ctx, record.ID, now); err != nil {
+		return uuid.Nil, err
+	}
+
+	return record.UserID, nil
+}
+
+// RevokeRefreshToken signs a client out by revoking its refresh token.
+// Unknown and already revoked tokens are not an error.
+func (s *TokenService) RevokeRefreshToken(ctx context.Context, token string) error {
+	if s.refreshTokens == nil {
+		return nil
+	}
+
+	record, err := s.refreshTokens.GetByHash(ctx, tokenHash(token))
+	if errors.Is(err, domain.ErrRefreshTokenInvalid) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.refreshTokens.Revoke(ctx, record.ID, s.clock.Now())
+	if err != nil && !errors.Is(err, domain.ErrRefreshTokenInvalid) {
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":  record.UserID,
+		"token_id": record.ID,
+	}).Info("Refresh token revoked")
+
+	return nil
+}
+
+// RevokeUserRefreshTokens signs userID out of every client.
+func (s *TokenService) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
+	if s.refreshTokens == nil {
+		return nil
+	}
+
+	revoked, err := s.refreshTokens.RevokeAllForUser(ctx, userID, s.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"revoked": revoked,
+	}).Info("Refresh tokens of user revoked")
+
+	return nil
+}
+
+// RevokeAccessToken rejects token from now on, until it expires. Tokens that
+// are malformed, signed with another secret or already expired cannot be
+// used anyway and are ignored.
+func (s *TokenService) RevokeAccessToken(ctx context.Context, token string) error {
+	if s.revocations == nil {
+		return nil
+	}
+
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
+	if err != nil || claims.ExpiresAt == nil {
+		s.logger.Debug("Ignoring revocation of an unusable access token")
+		return nil
+	}
+
+	now := s.clock.Now()
+	userID, _ := uuid.Parse(claims.Subject)
+	if err := s.revocations.Revoke(ctx, &domain.RevokedToken{
+		TokenHash: tokenHash(token),
+		UserID:    userID,
+		ExpiresAt: claims.ExpiresAt.Time,
+		RevokedAt: now,
+	}); err != nil {
+		return err
+	}
+
+	// Entries of expired tokens have nothing left to block, so revoking
+	// doubles as the cleanup.
+	purged, err := s.revocations.DeleteExpired(ctx, now)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Warn("Failed to delete expired revoked tokens, continuing")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":    userID,
+		"expires_at": claims.ExpiresAt.Time,
+		"purged":     purged,
+	}).Info("Access token revoked")
+
+	return nil
+}
+
+// IsAccessTokenRevoked reports whether token was revoked by
+// RevokeAccessToken.
+func (s *TokenService) IsAccessTokenRevoked(ctx context.Context, token string) (bool, error) {
+	if s.revocations == nil {
+		return false, nil
+	}
+	return s.revocations.IsRevoked(ctx, tokenHash(token))
+}
+
+// tokenHash is how refresh tokens and revoked access tokens are stored
+// and looked up.
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,273 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type UserExportService struct {
+	repo   domain.UserExportRepository
+	ttl    time.Duration
+	logger *logrus.Logger
+	clock  domain.Clock
+	ids    domain.IDGenerator
+}
+
+func NewUserExportService(repo domain.UserExportRepository) *UserExportService {
+	return &UserExportService{
+		repo:   repo,
+		ttl:    domain.DefaultUserExportTTL,
+		logger: logrus.New(),
+		clock:  domain.SystemClock{},
+		ids:    domain.UUIDv7Generator{},
+	}
+}
+
+func (s *UserExportService) WithClock(clock domain.Clock) *UserExportService {
+	s.clock = clock
+	return s
+}
+
+func (s *UserExportService) WithIDGenerator(ids domain.IDGenerator) *UserExportService {
+	s.ids = ids
+	return s
+}
+
+// WithTTL sets how long finished exports stay downloadable.
+func (s *UserExportService) WithTTL(ttl time.Duration) *UserExportService {
+	s.ttl = ttl
+	return s
+}
+
+// RequestUserExport queues an archive of everything stored about userID and
+// returns it pending. Only one export per user is assembled at a time.
+func (s *UserExportService) RequestUserExport(ctx context.Context, userID uuid.UUID) (*domain.UserExport, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+	}).Info("Requesting user data export")
+
+	now := s.clock.Now()
+	pending, err := s.repo.HasPending(ctx, userID, now.Add(-domain.UserExportTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		s.logger.WithFields(logrus.Fields{
+			"user_id": userID,
+		}).Warn("User data export already in progress")
+		return nil, domain.ErrUserExportInProgress
+	}
+
+	export := &domain.UserExport{
+		ID:        s.ids.NewID(),
+		UserID:    userID,
+		Status:    domain.UserExportStatusPending,
+		CreatedAt: now,
+	}
+	if err := s.repo.Create(ctx, export); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to store pending user export")
+		return nil, err
+	}
+
+	go s.generate(export.ID, userID)
+
+	s.logger.WithFields(logrus.Fields{
+		"export_id": export.ID,
+		"user_id":   userID,
+	}).Info("User data export queued")
+
+	return export, nil
+}
+
+// GetUserExport returns an export requested by userID. Other users' exports
+// are reported as not found.
+func (s *UserExportService) GetUserExport(ctx context.Context, id, userID uuid.UUID) (*domain.UserExport, error) {
+	s.logger.WithFields(logrus.Fields{
+		"export_id": id,
+		"user_id":   userID,
+	}).Debug("Getting user export")
+
+	export, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if export.UserID != userID {
+		s.logger.WithFields(logrus.Fields{
+			"export_id": id,
+			"user_id":   userID,
+		}).Warn("User export hidden from non-owner")
+		return nil, domain.ErrUserExportNotFound
+	}
+
+	return export, nil
+}
+
+func (s *UserExportService) ListUserExports(ctx context.Context, userID uuid.UUID, pagination domain.Pagination) ([]domain.UserExport, error) {
+	exports, err := s.repo.List(ctx, userID, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list user exports from repository")
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+// DownloadUserExport returns a finished, unexpired export requested by
+// userID, with its archive.
+func (s *UserExportService) DownloadUserExport(ctx context.Context, id, userID uuid.UUID) (*domain.UserExport, error) {
+	export, err := s.GetUserExport(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != domain.UserExportStatusReady {
+		return export, domain.ErrUserExportNotReady
+	}
+	if export.Expired(s.clock.Now()) {
+		return export, domain.ErrUserExportExpired
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"export_id": id,
+		"user_id":   userID,
+		"bytes":     len(export.Content),
+	}).Info("User data export downloaded")
+
+	return export, nil
+}
+
+// RunCleanup deletes expired exports every interval until ctx is done.
+func (s *UserExportService) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.repo.DeleteExpired(ctx, s.clock.Now())
+		if err != nil && ctx.Err() == nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Failed to delete expired user exports")
+		} else if deleted > 0 {
+			s.logger.WithFields(logrus.Fields{
+				"deleted": deleted,
+			}).Info("Expired user exports deleted")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// generate assembles a queued export outside the request that asked for it.
+func (s *UserExportService) generate(exportID, userID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), domain.UserExportTimeout)
+	defer cancel()
+
+	content, err := s.archive(ctx, userID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"export_id": exportID,
+			"user_id":   userID,
+		}).Error("Background user data export failed")
+		if err := s.repo.Fail(ctx, exportID, err.Error(), s.clock.Now()); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":     err.Error(),
+				"export_id": exportID,
+			}).Error("Failed to mark user export as failed")
+		}
+		return
+	}
+
+	completedAt := s.clock.Now()
+	if err := s.repo.Complete(ctx, exportID, content, completedAt, completedAt.Add(s.ttl)); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"export_id": exportID,
+		}).Error("Failed to store user export")
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"export_id": exportID,
+		"user_id":   userID,
+		"bytes":     len(content),
+	}).Info("Background user data export completed")
+}
+
+// archive zips the user's records, one JSON file per kind of record, next to
+// a manifest counting them.
+func (s *UserExportService) archive(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	data, err := s.repo.CollectUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		name    string
+		records interface{}
+		count   int
+	}{
+		{"profile.json", data.Profile, 1},
+		{"projects.json", emptyIfNil(data.Projects), len(data.Projects)},
+		{"project_items.json", emptyIfNil(data.ProjectItems), len(data.ProjectItems)},
+		{"assignments.json", emptyIfNil(data.Assignments), len(data.Assignments)},
+		{"expenses.json", emptyIfNil(data.Expenses), len(data.Expenses)},
+		{"stock_adjustments.json", emptyIfNil(data.StockAdjustments), len(data.StockAdjustments)},
+		{"purchase_orders.json", emptyIfNil(data.PurchaseOrders), len(data.PurchaseOrders)},
+		{"orders.json", emptyIfNil(data.Orders), len(data.Orders)},
+		{"watches.json", emptyIfNil(data.Watches), len(data.Watches)},
+		{"notifications.json", emptyIfNil(data.Notifications), len(data.Notifications)},
+		{"devices.json", emptyIfNil(data.Devices), len(data.Devices)},
+		{"saved_filters.json", emptyIfNil(data.SavedFilters), len(data.SavedFilters)},
+		{"report_subscriptions.json", emptyIfNil(data.ReportSubscriptions), len(data.ReportSubscriptions)},
+	}
+
+	manifest := struct {
+		UserID      uuid.UUID      `json:"user_id"`
+		GeneratedAt time.Time      `json:"generated_at"`
+		Files       map[string]int `json:"files"`
+	}{UserID: userID, GeneratedAt: s.clock.Now(), Files: map[string]int{}}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		manifest.Files[file.name] = file.count
+		if err := writeZipJSON(archive, file.name, file.records, manifest.GeneratedAt); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeZipJSON(archive, "manifest.json", manifest, manifest.GeneratedAt); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeZipJSON(archive *zip.Writer, name string, value interface{}, modified time.Time) error {
+	w, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
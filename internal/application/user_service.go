@@ -2,7 +2,12 @@ package application
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -12,29 +17,119 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	// passwordResetTokenBytes is the entropy of a password reset token.
+	passwordResetTokenBytes = 32
+	// oauthStateBytes is the entropy of the state of an OAuth sign-in.
+	oauthStateBytes = 32
+)
+
 type UserService struct {
-	repo   domain.UserRepository
-	logger *logrus.Logger
+	repo           domain.UserRepository
+	logger         *logrus.Logger
+	clock          domain.Clock
+	ids            domain.IDGenerator
+	passwordMaxAge time.Duration
+	deletionGrace  time.Duration
+	resets         domain.PasswordResetRepository
+	mailer         domain.Mailer
+	renderer       domain.EmailRenderer
+	resetTTL       time.Duration
+	resetURL       string
+	identities     domain.UserIdentityRepository
+	oauthClients   map[domain.OAuthProvider]domain.OAuthClient
+	failedLogins   domain.FailedLoginRepository
+	lockout        domain.LockoutPolicy
 }
 
 func NewUserService(repo domain.UserRepository) *UserService {
 	return &UserService{
-		repo:   repo,
-		logger: logrus.New(),
+		repo:          repo,
+		logger:        logrus.New(),
+		clock:         domain.SystemClock{},
+		ids:           domain.UUIDv7Generator{},
+		deletionGrace: domain.DefaultAccountDeletionGrace,
 	}
 }
 
+func (s *UserService) WithClock(clock domain.Clock) *UserService {
+	s.clock = clock
+	return s
+}
+
+func (s *UserService) WithIDGenerator(ids domain.IDGenerator) *UserService {
+	s.ids = ids
+	return s
+}
+
+// WithPasswordMaxAge makes passwords expire once they are maxAge old. Zero,
+// the default, disables expiry.
+func (s *UserService) WithPasswordMaxAge(maxAge time.Duration) *UserService {
+	s.passwordMaxAge = maxAge
+	return s
+}
+
+// WithDeletionGrace sets how long a deleted account can still be restored
+// before its personal data is erased.
+func (s *UserService) WithDeletionGrace(grace time.Duration) *UserService {
+	s.deletionGrace = grace
+	return s
+}
+
+// WithPasswordResets lets users who forgot their password get a reset
+// token by email, rendered with renderer and sent through mailer. Tokens
+// work for ttl. When resetURL is set the email links to it with the token
+// in the "token" query parameter instead of giving the bare token.
+func (s *UserService) WithPasswordResets(repo domain.PasswordResetRepository, mailer domain.Mailer, renderer domain.EmailRenderer, ttl time.Duration, resetURL string) *UserService {
+	s.resets = repo
+	s.mailer = mailer
+	s.renderer = renderer
+	s.resetTTL = ttl
+	s.resetURL = resetURL
+	return s
+}
+
+// WithOAuth lets users sign in through the providers in clients. Accounts
+// at a provider are linked to local users in identities.
+func (s *UserService) WithOAuth(identities domain.UserIdentityRepository, clients map[domain.OAuthProvider]domain.OAuthClient) *UserService {
+	s.identities = identities
+	s.oauthClients = clients
+	return s
+}
+
+// WithLockout records failed logins in repo and locks accounts, or
+// throttles addresses, as policy says. Without it failed logins are not
+// tracked.
+func (s *UserService) WithLockout(repo domain.FailedLoginRepository, policy domain.LockoutPolicy) *UserService {
+	s.failedLogins = repo
+	s.lockout = policy
+	return s
+}
+
 func (s *UserService) CreateUser(ctx context.Context, name, email, password string) (*domain.User, error) {
+	return s.CreateUserWithRole(ctx, name, email, password, domain.RoleUser)
+}
+
+func (s *UserService) CreateUserWithRole(ctx context.Context, name, email, password, role string) (*domain.User, error) {
 	s.logger.WithFields(logrus.Fields{
 		"email": email,
 		"name":  name,
+		"role":  role,
 	}).Info("Creating new user")
 
-	if !strings.Contains(email, "@") {
+	if role != domain.RoleAdmin && role != domain.RoleUser {
+		s.logger.WithFields(logrus.Fields{
+			"role": role,
+		}).Warn("Invalid user role")
+		return nil, errors.New("invalid role")
+	}
+
+	address, err := domain.ParseEmailAddress(email)
+	if err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"email": email,
 		}).Warn("Invalid email format")
-		return nil, errors.New("invalid email")
+		return nil, err
 	}
 
 	if len(password) < 6 {
@@ -53,13 +148,18 @@ func (s *UserService) CreateUser(ctx context.Context, name, email, password stri
 		return nil, err
 	}
 
+	now := s.clock.Now()
 	user := &domain.User{
-		ID:           uuid.New(),
-		Name:         name,
-		Email:        email,
-		PasswordHash: string(hash),
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		ID:                s.ids.NewID(),
+		Name:              name,
+		Email:             address,
+		PasswordHash:      string(hash),
+		Role:              role,
+		Locale:            domain.DefaultLocale,
+		Active:            true,
+		PasswordChangedAt: &now,
+		CreatedAt:         now,
+		UpdatedAt:         now,
 	}
 
 	s.logger.WithFields(logrus.Fields{
@@ -130,15 +230,61 @@ func (s *UserService) ListUsers(ctx context.Context, filter domain.Params, pagin
 	return users, nil
 }
 
+// CountUsers returns how many users match filter, for the totals of a
+// paginated list.
+func (s *UserService) CountUsers(ctx context.Context, filter domain.Params) (int64, error) {
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count users in repository")
+		return 0, err
+	}
+
+	return total, nil
+}
+
 func (s *UserService) UpdateUser(ctx context.Context, user *domain.User) error {
+	return s.updateUser(ctx, user, nil)
+}
+
+// PatchUser stores fields of user, a user with a merge patch applied,
+// leaving every other column as it is. Name and email cannot be cleared.
+func (s *UserService) PatchUser(ctx context.Context, user *domain.User, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	var err error
+	switch {
+	case strings.TrimSpace(user.Name) == "":
+		err = errors.New("name is required")
+	case user.Email == "":
+		err = errors.New("email is required")
+	case user.Role != domain.RoleAdmin && user.Role != domain.RoleUser:
+		err = errors.New("invalid role")
+	default:
+		err = user.Locale.Validate()
+	}
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Warn("Invalid patched user")
+		return err
+	}
+	return s.updateUser(ctx, user, fields)
+}
+
+func (s *UserService) updateUser(ctx context.Context, user *domain.User, fields []string) error {
 	s.logger.WithFields(logrus.Fields{
 		"user_id": user.ID,
 		"email":   user.Email,
+		"fields":  fields,
 	}).Info("Updating user")
 
-	user.UpdatedAt = time.Now()
+	user.UpdatedAt = s.clock.Now()
 
-	err := s.repo.Update(ctx, user)
+	err := s.repo.Update(ctx, user, fields...)
 	if err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error":   err.Error(),
@@ -155,6 +301,26 @@ func (s *UserService) UpdateUser(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// UpdateProfile changes what users may change about their own account: the
+// name and, unless locale is empty, the language of their emails. Email,
+// role, password and status are left alone.
+func (s *UserService) UpdateProfile(ctx context.Context, id uuid.UUID, name string, locale domain.Locale) (*domain.User, error) {
+	user, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Name = name
+	if locale != "" {
+		user.Locale = locale
+	}
+	if err := s.UpdateUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
 func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	s.logger.WithFields(logrus.Fields{
 		"user_id": id,
@@ -228,3 +394,854 @@ func (s *UserService) CheckPassword(user *domain.User, password string) bool {
 
 	return isValid
 }
+
+// CheckSignIn returns domain.ErrAccountDeleted when the account has been
+// deleted and domain.ErrUserInactive when it is deactivated or still
+// suspended.
+func (s *UserService) CheckSignIn(user *domain.User) error {
+	if user.DeletedAt != nil {
+		s.logger.WithFields(logrus.Fields{
+			"user_id":    user.ID,
+			"deleted_at": user.DeletedAt,
+		}).Warn("Deleted user attempted to sign in")
+		return domain.ErrAccountDeleted
+	}
+	if !user.CanSignIn(s.clock.Now()) {
+		s.logger.WithFields(logrus.Fields{
+			"user_id":         user.ID,
+			"active":          user.Active,
+			"suspended_until": user.SuspendedUntil,
+		}).Warn("Inactive user attempted to sign in")
+		return domain.ErrUserInactive
+	}
+	return nil
+}
+
+// CheckPasswordAge returns domain.ErrPasswordExpired when the configured
+// password max age has passed since the user's last password change.
+func (s *UserService) CheckPasswordAge(user *domain.User) error {
+	if user.PasswordExpired(s.clock.Now(), s.passwordMaxAge) {
+		s.logger.WithFields(logrus.Fields{
+			"user_id":             user.ID,
+			"password_changed_at": user.PasswordChangedAt,
+			"max_age":             s.passwordMaxAge,
+		}).Warn("User password expired")
+		return domain.ErrPasswordExpired
+	}
+	return nil
+}
+
+// ChangePassword replaces the user's password. The new password has to
+// differ from the current one so an expired password cannot be renewed as is.
+func (s *UserService) ChangePassword(ctx context.Context, user *domain.User, newPassword string) error {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+	}).Info("Changing user password")
+
+	if err := s.checkNewPassword(user, newPassword); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to hash password")
+		return err
+	}
+
+	now := s.clock.Now()
+	if err := s.repo.SetPassword(ctx, user.ID, string(hash), now); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Error("Failed to change password in repository")
+		return err
+	}
+
+	user.PasswordHash = string(hash)
+	user.PasswordChangedAt = &now
+	user.UpdatedAt = now
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+	}).Info("User password changed successfully")
+
+	return nil
+}
+
+// checkNewPassword rejects passwords that are too short or the same as the
+// current one.
+func (s *UserService) checkNewPassword(user *domain.User, newPassword string) error {
+	if len(newPassword) < 6 {
+		s.logger.WithFields(logrus.Fields{
+			"password_length": len(newPassword),
+		}).Warn("Password too short")
+		return errors.New("password too short")
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(newPassword)) == nil {
+		s.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+		}).Warn("New password matches the current one")
+		return errors.New("new password must differ from the current password")
+	}
+
+	return nil
+}
+
+// RequestPasswordReset emails a reset token to the account with email. To
+// avoid telling which emails have accounts, nothing happens and no error is
+// returned when there is no such account, when it cannot sign in, or when a
+// token was sent to it less than domain.PasswordResetCooldown ago.
+func (s *UserService) RequestPasswordReset(ctx context.Context, email string) error {
+	if s.resets == nil || s.mailer == nil || s.renderer == nil {
+		return domain.ErrPasswordResetDisabled
+	}
+
+	user, err := s.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil
+	}
+	if err := s.CheckSignIn(user); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+		}).Warn("Password reset requested for an inactive account, ignoring")
+		return nil
+	}
+
+	now := s.clock.Now()
+	last, err := s.resets.LastCreatedAt(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if last != nil && now.Sub(*last) < domain.PasswordResetCooldown {
+		s.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+		}).Warn("Password reset requested again too soon, ignoring")
+		return nil
+	}
+
+	token, err := randomToken(passwordResetTokenBytes)
+	if err != nil {
+		return fmt.Errorf("generate password reset token: %w", err)
+	}
+
+	reset := &domain.PasswordResetToken{
+		ID:        s.ids.NewID(),
+		UserID:    user.ID,
+		TokenHash: tokenHash(token),
+		ExpiresAt: now.Add(s.resetTTL),
+		CreatedAt: now,
+	}
+	if err := s.resets.Create(ctx, reset); err != nil {
+		return err
+	}
+
+	data := domain.PasswordResetEmail{Name: user.Name, Token: token, ExpiresAt: reset.ExpiresAt}
+	if s.resetURL != "" {
+		link, err := url.Parse(s.resetURL)
+		if err != nil {
+			return fmt.Errorf("parse password reset url: %w", err)
+		}
+		query := link.Query()
+		query.Set("token", token)
+		link.RawQuery = query.Encode()
+		data.URL = link.String()
+	}
+
+	content, err := s.renderer.Render(domain.EmailTemplatePasswordReset, user.Locale, data)
+	if err != nil {
+		return err
+	}
+	if err := s.mailer.Send(ctx, &domain.Email{
+		To:       []string{string(user.Email)},
+		Subject:  content.Subject,
+		Body:     content.Text,
+		HTMLBody: content.HTML,
+	}); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Error("Failed to send password reset email")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":    user.ID,
+		"expires_at": reset.ExpiresAt,
+	}).Info("Password reset email sent")
+
+	return nil
+}
+
+// ResetPassword sets the password of the account token was issued for and
+// returns the account. The token cannot be used again afterwards.
+func (s *UserService) ResetPassword(ctx context.Context, token, newPassword string) (*domain.User, error) {
+	if s.resets == nil {
+		return nil, domain.ErrPasswordResetDisabled
+	}
+
+	reset, err := s.resets.GetByHash(ctx, tokenHash(token))
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	if reset.UsedAt != nil || !now.Before(reset.ExpiresAt) {
+		return nil, domain.ErrPasswordResetTokenInvalid
+	}
+
+	user, err := s.repo.GetAccount(ctx, reset.UserID)
+	if err != nil {
+		return nil, domain.ErrPasswordResetTokenInvalid
+	}
+	if err := s.CheckSignIn(user); err != nil {
+		return nil, err
+	}
+	// Checked before the token is spent, so a rejected password can be
+	// retried with the same token.
+	if err := s.checkNewPassword(user, newPassword); err != nil {
+		return nil, err
+	}
+
+	if err := s.resets.MarkUsed(ctx, reset.ID, now); err != nil {
+		return nil, err
+	}
+	if err := s.ChangePassword(ctx, user, newPassword); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+	}).Info("Password reset successfully")
+
+	return user, nil
+}
+
+// OAuthLoginURL returns where to send a user to sign in with provider,
+// along with the state the provider will bring back. The caller keeps the
+// state on the user's side, for SignInWithOAuth to compare.
+func (s *UserService) OAuthLoginURL(provider domain.OAuthProvider) (string, string, error) {
+	client, ok := s.oauthClients[provider]
+	if !ok {
+		return "", "", domain.ErrOAuthProviderDisabled
+	}
+
+	state, err := randomToken(oauthStateBytes)
+	if err != nil {
+		return "", "", fmt.Errorf("generate oauth state: %w", err)
+	}
+
+	return client.AuthCodeURL(state), state, nil
+}
+
+// SignInWithOAuth finishes a sign-in with provider and returns the user it
+// is for. state is what the provider brought back and expectedState what
+// OAuthLoginURL gave this user; they must match, or the callback may have
+// been started by someone else. The provider account is linked to the user
+// with its verified email, who is created when there is none.
+func (s *UserService) SignInWithOAuth(ctx context.Context, provider domain.OAuthProvider, code, state, expectedState string) (*domain.User, error) {
+	client, ok := s.oauthClients[provider]
+	if !ok || s.identities == nil {
+		return nil, domain.ErrOAuthProviderDisabled
+	}
+	if expectedState == "" || subtle.ConstantTimeCompare([]byte(state), []byte(expectedState)) != 1 {
+		return nil, domain.ErrOAuthStateInvalid
+	}
+
+	profile, err := client.Exchange(ctx, code)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"provider": provider,
+		}).Warn("OAuth code exchange failed")
+		return nil, err
+	}
+
+	identity, err := s.identities.GetBySubject(ctx, provider, profile.Subject)
+	if err == nil {
+		return s.repo.GetAccount(ctx, identity.UserID)
+	}
+	if !errors.Is(err, domain.ErrUserIdentityNotFound) {
+		return nil, err
+	}
+
+	if profile.Email == "" || !profile.EmailVerified {
+		s.logger.WithFields(logrus.Fields{
+			"provider": provider,
+		}).Warn("OAuth account without a verified email")
+		return nil, domain.ErrOAuthEmailUnverified
+	}
+
+	user, err := s.GetUserByEmail(ctx, profile.Email)
+	if err != nil {
+		user, err = s.createOAuthUser(ctx, profile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.identities.Create(ctx, &domain.UserIdentity{
+		ID:        s.ids.NewID(),
+		UserID:    user.ID,
+		Provider:  provider,
+		Subject:   profile.Subject,
+		Email:     profile.Email,
+		CreatedAt: s.clock.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":  user.ID,
+		"provider": provider,
+	}).Info("OAuth account linked to user")
+
+	return user, nil
+}
+
+// createOAuthUser creates the user of a provider account. Its password is
+// random and never told, so signing in with a password needs a reset
+// first.
+func (s *UserService) createOAuthUser(ctx context.Context, profile *domain.OAuthProfile) (*domain.User, error) {
+	password, err := randomToken(passwordResetTokenBytes)
+	if err != nil {
+		return nil, fmt.Errorf("generate password: %w", err)
+	}
+
+	name := profile.Name
+	if name == "" {
+		name, _, _ = strings.Cut(profile.Email, "@")
+	}
+
+	return s.CreateUser(ctx, name, profile.Email, password)
+}
+
+// randomToken returns n random bytes, base64url encoded.
+func randomToken(n int) (string, error) {
+	secret := make([]byte, n)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// EnrollMFA gives the user a new TOTP secret to add to an authenticator
+// app. Codes are only asked for at login once ConfirmMFA accepted one.
+func (s *UserService) EnrollMFA(ctx context.Context, id uuid.UUID) (*domain.MFAEnrollment, error) {
+	user, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := domain.NewTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("generate totp secret: %w", err)
+	}
+	if err := s.repo.SetMFA(ctx, user.ID, secret, false, s.clock.Now()); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Error("Failed to save MFA secret in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+	}).Info("MFA enrollment started")
+
+	return &domain.MFAEnrollment{
+		Secret: secret,
+		URI:    domain.TOTPURI(string(user.Email), secret),
+	}, nil
+}
+
+// ConfirmMFA turns two-factor authentication on once code shows the
+// enrolled secret made it into the user's authenticator app.
+func (s *UserService) ConfirmMFA(ctx context.Context, id uuid.UUID, code string) (*domain.User, error) {
+	user, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+	if user.MFASecret == "" {
+		return nil, domain.ErrMFANotEnrolled
+	}
+	if !domain.ValidTOTP(user.MFASecret, code, s.clock.Now()) {
+		return nil, domain.ErrMFACodeInvalid
+	}
+
+	if err := s.setMFA(ctx, user, user.MFASecret, true); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DisableMFA turns two-factor authentication off and forgets the secret.
+// It takes a current code so a stolen session alone cannot do it.
+func (s *UserService) DisableMFA(ctx context.Context, id uuid.UUID, code string) (*domain.User, error) {
+	user, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MFAEnabled {
+		return nil, domain.ErrMFANotEnrolled
+	}
+	if err := s.CheckMFACode(user, code); err != nil {
+		return nil, err
+	}
+
+	if err := s.setMFA(ctx, user, "", false); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// CheckMFACode returns domain.ErrMFACodeInvalid unless code is the current
+// TOTP of the user. No code is valid for users without a secret.
+func (s *UserService) CheckMFACode(user *domain.User, code string) error {
+	if !domain.ValidTOTP(user.MFASecret, code, s.clock.Now()) {
+		s.logger.WithFields(logrus.Fields{
+			"user_id": user.ID,
+		}).Warn("Invalid two-factor code")
+		return domain.ErrMFACodeInvalid
+	}
+	return nil
+}
+
+func (s *UserService) setMFA(ctx context.Context, user *domain.User, secret string, enabled bool) error {
+	now := s.clock.Now()
+	if err := s.repo.SetMFA(ctx, user.ID, secret, enabled, now); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Error("Failed to update MFA in repository")
+		return err
+	}
+
+	user.MFASecret = secret
+	user.MFAEnabled = enabled
+	user.UpdatedAt = now
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":     user.ID,
+		"mfa_enabled": enabled,
+	}).Info("User MFA updated")
+
+	return nil
+}
+
+// DeactivateUser switches the account off. With suspendedUntil set the
+// account is suspended until then instead of deactivated indefinitely.
+func (s *UserService) DeactivateUser(ctx context.Context, id uuid.UUID, suspendedUntil *time.Time) (*domain.User, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id":         id,
+		"suspended_until": suspendedUntil,
+	}).Info("Deactivating user")
+
+	if suspendedUntil != nil && !suspendedUntil.After(s.clock.Now()) {
+		s.logger.WithFields(logrus.Fields{
+			"user_id":         id,
+			"suspended_until": suspendedUntil,
+		}).Warn("Suspension end is not in the future")
+		return nil, errors.New("suspended_until must be in the future")
+	}
+
+	if err := s.repo.SetStatus(ctx, id, suspendedUntil != nil, suspendedUntil); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": id,
+		}).Error("Failed to deactivate user in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Info("User deactivated successfully")
+
+	return s.GetUserByID(ctx, id)
+}
+
+func (s *UserService) ReactivateUser(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Info("Reactivating user")
+
+	if err := s.repo.SetStatus(ctx, id, true, nil); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": id,
+		}).Error("Failed to reactivate user in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Info("User reactivated successfully")
+
+	return s.GetUserByID(ctx, id)
+}
+
+// RecordLogin counts a successful login, which also clears the failed
+// logins counting towards a lockout.
+func (s *UserService) RecordLogin(ctx context.Context, user *domain.User) error {
+	now := s.clock.Now()
+	if err := s.repo.RecordLogin(ctx, user.ID, now); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Error("Failed to record login in repository")
+		return err
+	}
+	if s.failedLogins != nil {
+		if err := s.failedLogins.ClearForUser(ctx, user.ID, now); err != nil {
+			return err
+		}
+	}
+
+	user.LastLoginAt = &now
+	user.LoginCount++
+	return nil
+}
+
+// CheckLoginThrottle returns domain.ErrTooManyLoginAttempts when ip made
+// the policy's IPLimit failed logins within its window.
+func (s *UserService) CheckLoginThrottle(ctx context.Context, ip string) error {
+	if s.failedLogins == nil || s.lockout.IPLimit <= 0 {
+		return nil
+	}
+
+	count, err := s.failedLogins.CountForIP(ctx, ip, s.clock.Now().Add(-s.lockout.Window))
+	if err != nil {
+		return err
+	}
+	if count >= int64(s.lockout.IPLimit) {
+		s.logger.WithFields(logrus.Fields{
+			"ip":       ip,
+			"failures": count,
+		}).Warn("Login refused - too many failures from address")
+		return domain.ErrTooManyLoginAttempts
+	}
+	return nil
+}
+
+// CheckLockout returns domain.ErrAccountLocked while failed logins keep the
+// account locked.
+func (s *UserService) CheckLockout(user *domain.User) error {
+	if user.LockedUntil != nil && s.clock.Now().Before(*user.LockedUntil) {
+		s.logger.WithFields(logrus.Fields{
+			"user_id":      user.ID,
+			"locked_until": user.LockedUntil,
+		}).Warn("Locked user attempted to sign in")
+		return domain.ErrAccountLocked
+	}
+	return nil
+}
+
+// RecordLoginFailure records a wrong password or code sent from ip for
+// email, whose account is user, or nil when there is none. When the failure
+// reaches the policy's threshold the account is locked, its failures are
+// cleared so counting starts over once the lock ends, and
+// domain.ErrAccountLocked is returned.
+func (s *UserService) RecordLoginFailure(ctx context.Context, email string, user *domain.User, ip string) error {
+	if s.failedLogins == nil {
+		return nil
+	}
+
+	now := s.clock.Now()
+	failure := &domain.FailedLogin{
+		ID:        s.ids.NewID(),
+		Email:     email,
+		IP:        ip,
+		CreatedAt: now,
+	}
+	if user != nil {
+		failure.UserID = &user.ID
+	}
+	if err := s.failedLogins.Create(ctx, failure); err != nil {
+		return err
+	}
+
+	// Failures past their retention have nothing left to count towards,
+	// so recording doubles as the cleanup.
+	if _, err := s.failedLogins.DeleteBefore(ctx, now.Add(-domain.FailedLoginRetention)); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Warn("Failed to delete old failed logins, continuing")
+	}
+
+	if user == nil || s.lockout.Threshold <= 0 {
+		return nil
+	}
+
+	count, err := s.failedLogins.CountForUser(ctx, user.ID, now.Add(-s.lockout.Window))
+	if err != nil {
+		return err
+	}
+	if count < int64(s.lockout.Threshold) {
+		return nil
+	}
+
+	lockedUntil := now.Add(s.lockout.Duration)
+	if err := s.repo.SetLockedUntil(ctx, user.ID, &lockedUntil); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": user.ID,
+		}).Error("Failed to lock user in repository")
+		return err
+	}
+	if err := s.failedLogins.ClearForUser(ctx, user.ID, now); err != nil {
+		return err
+	}
+	user.LockedUntil = &lockedUntil
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":      user.ID,
+		"failures":     count,
+		"locked_until": lockedUntil,
+	}).Warn("User locked after too many failed logins")
+
+	return domain.ErrAccountLocked
+}
+
+// UnlockUser ends the lockout of the account before it expires and clears
+// its failed logins.
+func (s *UserService) UnlockUser(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Info("Unlocking user")
+
+	if err := s.repo.SetLockedUntil(ctx, id, nil); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": id,
+		}).Error("Failed to unlock user in repository")
+		return nil, err
+	}
+	if s.failedLogins != nil {
+		if err := s.failedLogins.ClearForUser(ctx, id, s.clock.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Info("User unlocked successfully")
+
+	return s.GetUserByID(ctx, id)
+}
+
+// ListStaleUsers lists accounts nobody has logged into for at least days
+// days. Accounts that never logged in count from their creation.
+func (s *UserService) ListStaleUsers(ctx context.Context, days int, pagination domain.Pagination) ([]domain.User, error) {
+	s.logger.WithFields(logrus.Fields{
+		"days":   days,
+		"limit":  pagination.Limit,
+		"offset": pagination.Offset,
+	}).Debug("Listing stale users")
+
+	if days < 1 || days > domain.MaxStaleDays {
+		return nil, fmt.Errorf("days must be between 1 and %d", domain.MaxStaleDays)
+	}
+
+	cutoff := s.clock.Now().AddDate(0, 0, -days)
+	return s.ListUsers(ctx, domain.Params{LastSeenBefore: &cutoff}, pagination)
+}
+
+// SuggestUsers lists the users an assignment picker can offer for query:
+// only accounts that can sign in, best matches first.
+func (s *UserService) SuggestUsers(ctx context.Context, query string, limit int) ([]domain.Suggestion, error) {
+	s.logger.WithFields(logrus.Fields{
+		"query": query,
+		"limit": limit,
+	}).Debug("Suggesting users")
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []domain.Suggestion{}, nil
+	}
+	return s.repo.Suggest(ctx, query, s.clock.Now(), limit)
+}
+
+func (s *UserService) PromoteToAdmin(ctx context.Context, user *domain.User) error {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": user.ID,
+		"email":   user.Email,
+	}).Info("Promoting user to admin")
+
+	user.Role = domain.RoleAdmin
+	return s.UpdateUser(ctx, user)
+}
+
+// EnsureAdmin creates the given admin account only when no admin exists yet,
+// which makes it safe to call on every boot.
+func (s *UserService) EnsureAdmin(ctx context.Context, name, email, password string) (*domain.User, bool, error) {
+	s.logger.WithFields(logrus.Fields{
+		"email": email,
+	}).Debug("Checking for existing admin users")
+
+	admins, err := s.repo.List(ctx, domain.Params{Role: domain.RoleAdmin}, domain.Pagination{Limit: 1})
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list admin users from repository")
+		return nil, false, err
+	}
+
+	if len(admins) > 0 {
+		s.logger.WithFields(logrus.Fields{
+			"user_id": admins[0].ID,
+		}).Debug("Admin user already exists, skipping bootstrap")
+		return &admins[0], false, nil
+	}
+
+	if existing, err := s.GetUserByEmail(ctx, email); err == nil {
+		if err := s.PromoteToAdmin(ctx, existing); err != nil {
+			return nil, false, err
+		}
+		return existing, true, nil
+	}
+
+	user, err := s.CreateUserWithRole(ctx, name, email, password, domain.RoleAdmin)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return user, true, nil
+}
+
+// GetAccount returns the user even when the account has been deleted, which
+// is what credential checks need to see.
+func (s *UserService) GetAccount(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, err := s.repo.GetAccount(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": id,
+		}).Debug("Account not found in repository")
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// GetDeletedAccountByEmail returns a deleted account whose personal data has
+// not been erased yet.
+func (s *UserService) GetDeletedAccountByEmail(ctx context.Context, email string) (*domain.User, error) {
+	user, err := s.repo.GetDeletedByEmail(ctx, email)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"email": email,
+		}).Warn("Deleted account not found by email")
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// DeleteAccount deletes the account at once, which revokes its tokens and
+// password, and schedules its personal data for erasure when the deletion
+// grace period ends. Until then the account can be restored.
+func (s *UserService) DeleteAccount(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	now := s.clock.Now()
+	eraseAfter := now.Add(s.deletionGrace)
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":     id,
+		"erase_after": eraseAfter,
+	}).Info("Deleting account")
+
+	if err := s.repo.RequestDeletion(ctx, id, now, eraseAfter); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": id,
+		}).Error("Failed to delete account in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id":     id,
+		"erase_after": eraseAfter,
+	}).Info("Account deleted, erasure scheduled")
+
+	return s.GetAccount(ctx, id)
+}
+
+// RestoreAccount undoes DeleteAccount while the grace period lasts. It
+// returns domain.ErrAccountNotRestorable once the account has been erased,
+// the period is over or the account was not deleted through DeleteAccount.
+func (s *UserService) RestoreAccount(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Info("Restoring account")
+
+	if err := s.repo.Restore(ctx, id, s.clock.Now()); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": id,
+		}).Warn("Failed to restore account")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Info("Account restored successfully")
+
+	return s.GetUserByID(ctx, id)
+}
+
+// EraseDeletedAccounts anonymizes the accounts whose grace period is over and
+// drops their private records, returning how many were erased. Records shared
+// with other users keep pointing at the anonymized account.
+func (s *UserService) EraseDeletedAccounts(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	ids, err := s.repo.ListErasable(ctx, now, domain.AccountErasureBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	erased := 0
+	for _, id := range ids {
+		if err := s.repo.Erase(ctx, id, now); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":   err.Error(),
+				"user_id": id,
+			}).Error("Failed to erase deleted account")
+			continue
+		}
+		erased++
+	}
+
+	return erased, nil
+}
+
+// RunErasure erases deleted accounts every interval until ctx is done.
+func (s *UserService) RunErasure(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		erased, err := s.EraseDeletedAccounts(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Failed to list deleted accounts to erase")
+		} else if erased > 0 {
+			s.logger.WithFields(logrus.Fields{
+				"erased": erased,
+			}).Info("Deleted accounts erased")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
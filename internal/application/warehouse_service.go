@@ -0,0 +1,307 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type WarehouseService struct {
+	repo        domain.WarehouseRepository
+	productRepo domain.ProductRepository
+	logger      *logrus.Logger
+	clock       domain.Clock
+	ids         domain.IDGenerator
+}
+
+func NewWarehouseService(repo domain.WarehouseRepository, productRepo domain.ProductRepository) *WarehouseService {
+	return &WarehouseService{
+		repo:        repo,
+		productRepo: productRepo,
+		logger:      logrus.New(),
+		clock:       domain.SystemClock{},
+		ids:         domain.UUIDv7Generator{},
+	}
+}
+
+func (s *WarehouseService) WithClock(clock domain.Clock) *WarehouseService {
+	s.clock = clock
+	return s
+}
+
+func (s *WarehouseService) WithIDGenerator(ids domain.IDGenerator) *WarehouseService {
+	s.ids = ids
+	return s
+}
+
+func (s *WarehouseService) CreateWarehouse(ctx context.Context, warehouse *domain.Warehouse) (*domain.Warehouse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"code": warehouse.Code,
+		"name": warehouse.Name,
+	}).Info("Creating new warehouse")
+
+	warehouse.Code = strings.ToUpper(strings.TrimSpace(warehouse.Code))
+	if warehouse.Code == "" {
+		return nil, errors.New("warehouse code is required")
+	}
+
+	existingWarehouse, err := s.repo.GetByCode(ctx, warehouse.Code)
+	if err == nil && existingWarehouse != nil {
+		s.logger.WithFields(logrus.Fields{
+			"code": warehouse.Code,
+		}).Warn("Warehouse code already exists")
+		return nil, errors.New("warehouse code already exists")
+	}
+
+	now := s.clock.Now()
+	warehouse.ID = s.ids.NewID()
+	warehouse.CreatedAt = now
+	warehouse.UpdatedAt = now
+	warehouse.DeletedAt = nil
+
+	if err := s.repo.Create(ctx, warehouse); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"code":  warehouse.Code,
+		}).Error("Failed to create warehouse in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+		"code":         warehouse.Code,
+	}).Info("Warehouse created successfully")
+
+	return warehouse, nil
+}
+
+func (s *WarehouseService) GetWarehouseByID(ctx context.Context, id uuid.UUID) (*domain.Warehouse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"warehouse_id": id,
+	}).Debug("Getting warehouse by ID")
+
+	warehouse, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+		}).Warn("Warehouse not found by ID")
+		return nil, err
+	}
+
+	return warehouse, nil
+}
+
+func (s *WarehouseService) ListWarehouses(ctx context.Context, filter domain.WarehouseParams, pagination domain.Pagination) ([]domain.Warehouse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"filter_code": filter.Code,
+		"filter_name": filter.Name,
+		"limit":       pagination.Limit,
+		"offset":      pagination.Offset,
+	}).Debug("Listing warehouses")
+
+	warehouses, err := s.repo.List(ctx, filter, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list warehouses from repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"count": len(warehouses),
+	}).Info("Warehouses listed successfully")
+
+	return warehouses, nil
+}
+
+func (s *WarehouseService) UpdateWarehouse(ctx context.Context, warehouse *domain.Warehouse) error {
+	s.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+		"code":         warehouse.Code,
+	}).Info("Updating warehouse")
+
+	warehouse.Code = strings.ToUpper(strings.TrimSpace(warehouse.Code))
+	if warehouse.Code == "" {
+		return errors.New("warehouse code is required")
+	}
+
+	existingWarehouse, err := s.repo.GetByID(ctx, warehouse.ID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": warehouse.ID,
+		}).Warn("Warehouse not found for update")
+		return err
+	}
+
+	if warehouse.Code != existingWarehouse.Code {
+		if other, err := s.repo.GetByCode(ctx, warehouse.Code); err == nil && other != nil {
+			s.logger.WithFields(logrus.Fields{
+				"code": warehouse.Code,
+			}).Warn("Warehouse code already exists")
+			return errors.New("warehouse code already exists")
+		}
+	}
+
+	warehouse.CreatedAt = existingWarehouse.CreatedAt
+	warehouse.UpdatedAt = s.clock.Now()
+
+	if err := s.repo.Update(ctx, warehouse); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": warehouse.ID,
+		}).Error("Failed to update warehouse in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+	}).Info("Warehouse updated successfully")
+
+	return nil
+}
+
+// DeleteWarehouse refuses to remove a location that still holds stock; it
+// has to be transferred out first so the product totals stay consistent.
+func (s *WarehouseService) DeleteWarehouse(ctx context.Context, id uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"warehouse_id": id,
+	}).Info("Deleting warehouse")
+
+	stock, err := s.repo.ListStock(ctx, id, domain.Pagination{Limit: 1})
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+		}).Error("Failed to check warehouse stock before delete")
+		return err
+	}
+	if len(stock) > 0 {
+		s.logger.WithFields(logrus.Fields{
+			"warehouse_id": id,
+		}).Warn("Warehouse still holds stock")
+		return domain.ErrWarehouseNotEmpty
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+		}).Error("Failed to delete warehouse in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"warehouse_id": id,
+	}).Info("Warehouse deleted successfully")
+
+	return nil
+}
+
+func (s *WarehouseService) ListWarehouseStock(ctx context.Context, id uuid.UUID, pagination domain.Pagination) ([]domain.WarehouseStock, error) {
+	s.logger.WithFields(logrus.Fields{
+		"warehouse_id": id,
+		"limit":        pagination.Limit,
+		"offset":       pagination.Offset,
+	}).Debug("Listing warehouse stock")
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+		}).Warn("Warehouse not found for stock listing")
+		return nil, err
+	}
+
+	stock, err := s.repo.ListStock(ctx, id, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+		}).Error("Failed to list warehouse stock from repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"warehouse_id": id,
+		"count":        len(stock),
+	}).Info("Warehouse stock listed successfully")
+
+	return stock, nil
+}
+
+// TransferStock moves quantity of a product between locations without
+// changing its total. A nil side is the product's unallocated stock.
+func (s *WarehouseService) TransferStock(ctx context.Context, productID, actorID uuid.UUID, from, to *uuid.UUID, quantity int, note string) (*domain.StockTransfer, error) {
+	s.logger.WithFields(logrus.Fields{
+		"product_id":        productID,
+		"actor_id":          actorID,
+		"from_warehouse_id": from,
+		"to_warehouse_id":   to,
+		"quantity":          quantity,
+	}).Info("Transferring product stock")
+
+	if quantity <= 0 {
+		return nil, errors.New("quantity must be greater than zero")
+	}
+	if from == nil && to == nil {
+		return nil, errors.New("at least one of from_warehouse_id or to_warehouse_id is required")
+	}
+	if from != nil && to != nil && *from == *to {
+		return nil, errors.New("source and destination warehouse must differ")
+	}
+
+	for _, id := range []*uuid.UUID{from, to} {
+		if id == nil {
+			continue
+		}
+		if _, err := s.repo.GetByID(ctx, *id); err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error":        err.Error(),
+				"warehouse_id": *id,
+			}).Warn("Warehouse not found for stock transfer")
+			return nil, err
+		}
+	}
+
+	if _, err := s.productRepo.GetByID(ctx, productID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Warn("Product not found for stock transfer")
+		return nil, err
+	}
+
+	transfer := &domain.StockTransfer{
+		ID:              s.ids.NewID(),
+		ProductID:       productID,
+		FromWarehouseID: from,
+		ToWarehouseID:   to,
+		Quantity:        quantity,
+		Note:            note,
+		ActorID:         actorID,
+		CreatedAt:       s.clock.Now(),
+	}
+
+	if err := s.repo.Transfer(ctx, transfer); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+			"quantity":   quantity,
+		}).Warn("Failed to transfer stock in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"transfer_id": transfer.ID,
+		"product_id":  productID,
+		"quantity":    quantity,
+	}).Info("Product stock transferred successfully")
+
+	return transfer, nil
+}
@@ -0,0 +1,393 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// dueReminderBatch caps the due date reminders sent per scheduler tick; the
+// rest go out on the next ticks.
+const dueReminderBatch = 200
+
+type WatchService struct {
+	repo             domain.WatchRepository
+	notificationRepo domain.NotificationRepository
+	projectRepo      domain.ProjectRepository
+	itemRepo         domain.ProjectItemRepository
+	dispatcher       domain.NotificationDispatcher
+	dueSoonWindow    time.Duration
+	logger           *logrus.Logger
+	clock            domain.Clock
+	ids              domain.IDGenerator
+}
+
+func NewWatchService(repo domain.WatchRepository, notificationRepo domain.NotificationRepository, projectRepo domain.ProjectRepository, itemRepo domain.ProjectItemRepository) *WatchService {
+	return &WatchService{
+		repo:             repo,
+		notificationRepo: notificationRepo,
+		projectRepo:      projectRepo,
+		itemRepo:         itemRepo,
+		dueSoonWindow:    domain.DefaultDueSoonWindow,
+		logger:           logrus.New(),
+		clock:            domain.SystemClock{},
+		ids:              domain.UUIDv7Generator{},
+	}
+}
+
+func (s *WatchService) WithClock(clock domain.Clock) *WatchService {
+	s.clock = clock
+	return s
+}
+
+func (s *WatchService) WithIDGenerator(ids domain.IDGenerator) *WatchService {
+	s.ids = ids
+	return s
+}
+
+// WithDispatcher also delivers every stored notification through
+// dispatcher, such as push, in the background.
+func (s *WatchService) WithDispatcher(dispatcher domain.NotificationDispatcher) *WatchService {
+	s.dispatcher = dispatcher
+	return s
+}
+
+// WithDueSoonWindow sets how long before its due date an item's assignee is
+// reminded of it.
+func (s *WatchService) WithDueSoonWindow(window time.Duration) *WatchService {
+	s.dueSoonWindow = window
+	return s
+}
+
+func (s *WatchService) Watch(ctx context.Context, targetType string, targetID, userID uuid.UUID) (*domain.Watch, error) {
+	s.logger.WithFields(logrus.Fields{
+		"target_type": targetType,
+		"target_id":   targetID,
+		"user_id":     userID,
+	}).Info("Watching target")
+
+	if err := s.ensureTarget(ctx, targetType, targetID); err != nil {
+		return nil, err
+	}
+
+	watch := &domain.Watch{
+		UserID:     userID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		CreatedAt:  s.clock.Now(),
+	}
+
+	if err := s.repo.Watch(ctx, watch); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"target_type": targetType,
+			"target_id":   targetID,
+		}).Error("Failed to create watch in repository")
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"target_type": targetType,
+		"target_id":   targetID,
+		"user_id":     userID,
+	}).Info("Target watched successfully")
+
+	return watch, nil
+}
+
+func (s *WatchService) Unwatch(ctx context.Context, targetType string, targetID, userID uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"target_type": targetType,
+		"target_id":   targetID,
+		"user_id":     userID,
+	}).Info("Unwatching target")
+
+	if err := s.repo.Unwatch(ctx, userID, targetType, targetID); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"target_type": targetType,
+			"target_id":   targetID,
+		}).Error("Failed to delete watch in repository")
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"target_type": targetType,
+		"target_id":   targetID,
+		"user_id":     userID,
+	}).Info("Target unwatched successfully")
+
+	return nil
+}
+
+func (s *WatchService) ListWatchers(ctx context.Context, targetType string, targetID uuid.UUID, pagination domain.Pagination) ([]domain.Watch, error) {
+	s.logger.WithFields(logrus.Fields{
+		"target_type": targetType,
+		"target_id":   targetID,
+		"limit":       pagination.Limit,
+		"offset":      pagination.Offset,
+	}).Debug("Listing watchers")
+
+	if err := s.ensureTarget(ctx, targetType, targetID); err != nil {
+		return nil, err
+	}
+
+	watches, err := s.repo.ListWatchers(ctx, targetType, targetID, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"target_type": targetType,
+			"target_id":   targetID,
+		}).Error("Failed to list watchers from repository")
+		return nil, err
+	}
+
+	return watches, nil
+}
+
+func (s *WatchService) ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination domain.Pagination) ([]domain.Notification, error) {
+	s.logger.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"unread_only": unreadOnly,
+		"limit":       pagination.Limit,
+		"offset":      pagination.Offset,
+	}).Debug("Listing notifications")
+
+	notifications, err := s.notificationRepo.ListByUser(ctx, userID, unreadOnly, pagination)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list notifications from repository")
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (s *WatchService) MarkNotificationRead(ctx context.Context, userID, id uuid.UUID) error {
+	s.logger.WithFields(logrus.Fields{
+		"user_id":         userID,
+		"notification_id": id,
+	}).Info("Marking notification as read")
+
+	if err := s.notificationRepo.MarkRead(ctx, userID, id, s.clock.Now()); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"notification_id": id,
+		}).Warn("Failed to mark notification as read")
+		return err
+	}
+
+	return nil
+}
+
+// ProjectChanged notifies the watchers of the project.
+func (s *WatchService) ProjectChanged(ctx context.Context, project *domain.Project, event string) {
+	s.fanOut(ctx, project.ID, nil, nil, domain.WatchTargetProject, project.ID,
+		event, fmt.Sprintf("Project %q was %s", project.Name, event))
+}
+
+// ProjectItemChanged notifies the watchers of the item, the watchers of its
+// project and the item's assignee.
+func (s *WatchService) ProjectItemChanged(ctx context.Context, item *domain.ProjectItem, event string) {
+	s.fanOut(ctx, item.ProjectID, &item.ID, item.AssignedTo, domain.WatchTargetProjectItem, item.ID,
+		event, fmt.Sprintf("Project item %q was %s", item.Name, event))
+}
+
+// ProjectItemAssigned tells the item's new assignee that the item is theirs.
+func (s *WatchService) ProjectItemAssigned(ctx context.Context, item *domain.ProjectItem) {
+	if item.AssignedTo == nil {
+		return
+	}
+
+	notification := domain.Notification{
+		ID:         s.ids.NewID(),
+		UserID:     *item.AssignedTo,
+		TargetType: domain.WatchTargetProjectItem,
+		TargetID:   item.ID,
+		ProjectID:  item.ProjectID,
+		Event:      domain.ChangeEventAssigned,
+		Message:    fmt.Sprintf("You were assigned to project item %q", item.Name),
+		CreatedAt:  s.clock.Now(),
+	}
+
+	if err := s.notificationRepo.CreateBatch(ctx, []domain.Notification{notification}); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"item_id":     item.ID,
+			"assigned_to": item.AssignedTo,
+		}).Error("Failed to store assignment notification")
+		return
+	}
+	s.dispatch([]domain.Notification{notification})
+
+	s.logger.WithFields(logrus.Fields{
+		"item_id":     item.ID,
+		"assigned_to": item.AssignedTo,
+	}).Info("Assignment notification sent")
+}
+
+// ProjectItemCommented notifies the watchers of the item, the watchers of
+// its project and the item's assignee of a new comment.
+func (s *WatchService) ProjectItemCommented(ctx context.Context, item *domain.ProjectItem, comment *domain.Comment) {
+	s.fanOut(ctx, item.ProjectID, &item.ID, item.AssignedTo, domain.WatchTargetProjectItem, item.ID,
+		domain.ChangeEventCommented, fmt.Sprintf("New comment on project item %q", item.Name))
+}
+
+func (s *WatchService) fanOut(ctx context.Context, projectID uuid.UUID, itemID, assignee *uuid.UUID, targetType string, targetID uuid.UUID, event, message string) {
+	userIDs, err := s.repo.SubscriberIDs(ctx, projectID, itemID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"target_type": targetType,
+			"target_id":   targetID,
+		}).Error("Failed to resolve watchers for notification")
+		return
+	}
+	if assignee != nil {
+		userIDs = append(userIDs, *assignee)
+	}
+
+	now := s.clock.Now()
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	notifications := make([]domain.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		notifications = append(notifications, domain.Notification{
+			ID:         s.ids.NewID(),
+			UserID:     userID,
+			TargetType: targetType,
+			TargetID:   targetID,
+			ProjectID:  projectID,
+			Event:      event,
+			Message:    message,
+			CreatedAt:  now,
+		})
+	}
+
+	if err := s.notificationRepo.CreateBatch(ctx, notifications); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"target_type": targetType,
+			"target_id":   targetID,
+		}).Error("Failed to store notifications")
+		return
+	}
+	s.dispatch(notifications)
+
+	s.logger.WithFields(logrus.Fields{
+		"target_type": targetType,
+		"target_id":   targetID,
+		"event":       event,
+		"recipients":  len(notifications),
+	}).Info("Change notifications sent")
+}
+
+// RunScheduler reminds assignees of their items due soon every interval
+// until ctx is done.
+func (s *WatchService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.WithFields(logrus.Fields{
+		"interval": interval,
+		"window":   s.dueSoonWindow,
+	}).Info("Due date reminder scheduler started")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RemindDueSoon(ctx); err != nil && ctx.Err() == nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Due date reminder run failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Due date reminder scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RemindDueSoon notifies the assignees of open items due within the window
+// and returns how many it reminded. Each assignee is reminded once per due
+// date; moving the date later earns a new reminder.
+func (s *WatchService) RemindDueSoon(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	items, err := s.notificationRepo.ListDueSoon(ctx, now, s.dueSoonWindow, dueReminderBatch)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	notifications := make([]domain.Notification, 0, len(items))
+	for _, item := range items {
+		notifications = append(notifications, domain.Notification{
+			ID:         s.ids.NewID(),
+			UserID:     *item.AssignedTo,
+			TargetType: domain.WatchTargetProjectItem,
+			TargetID:   item.ID,
+			ProjectID:  item.ProjectID,
+			Event:      domain.ChangeEventDueSoon,
+			Message:    fmt.Sprintf("Project item %q is due on %s", item.Name, item.DueDate.In(time.Local).Format("2006-01-02 15:04 MST")),
+			CreatedAt:  now,
+		})
+	}
+
+	if err := s.notificationRepo.CreateBatch(ctx, notifications); err != nil {
+		return 0, err
+	}
+	s.dispatch(notifications)
+
+	s.logger.WithFields(logrus.Fields{
+		"reminded": len(notifications),
+	}).Info("Due date reminders sent")
+
+	return len(notifications), nil
+}
+
+// dispatch hands stored notifications to the dispatcher without holding up
+// the change that caused them.
+func (s *WatchService) dispatch(notifications []domain.Notification) {
+	if s.dispatcher == nil || len(notifications) == 0 {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), domain.PushDispatchTimeout)
+		defer cancel()
+		s.dispatcher.Dispatch(ctx, notifications)
+	}()
+}
+
+func (s *WatchService) ensureTarget(ctx context.Context, targetType string, targetID uuid.UUID) error {
+	var err error
+	switch targetType {
+	case domain.WatchTargetProject:
+		_, err = s.projectRepo.GetByID(ctx, targetID)
+	case domain.WatchTargetProjectItem:
+		_, err = s.itemRepo.GetByID(ctx, targetID)
+	default:
+		return errors.New("invalid watch target type")
+	}
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"target_type": targetType,
+			"target_id":   targetID,
+		}).Warn("Watch target not found")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	fakeFirstNames = []string{"Ana", "Bruno", "Carla", "Daniel", "Eduarda", "Felipe", "Gabriela", "Heitor", "Isabela", "João", "Larissa", "Marcos", "Natália", "Otávio", "Paula", "Rafael", "Sofia", "Thiago", "Valentina", "Vinícius"}
+	fakeLastNames  = []string{"Almeida", "Barbosa", "Carvalho", "Costa", "Ferreira", "Gomes", "Lima", "Martins", "Moreira", "Oliveira", "Pereira", "Ribeiro", "Rocha", "Santos", "Silva", "Souza"}
+)
+
+func newAnonymizeCommand() *cobra.Command {
+	var password string
+	var batchSize int
+	var confirm bool
+
+	cmd := &cobra.Command{
+		Use:   "anonymize",
+		Short: "Replace user PII with deterministic fake data in a non-production database",
+		Long:  "Replace every user's name, email and password hash with fake data derived from the user ID, so a restored production dump can be used in staging. The same ID always maps to the same fake identity. Refuses to run when APP_ENV is production.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.App.Env == "production" {
+				return errors.New("refusing to anonymize a database while APP_ENV is production")
+			}
+			if !confirm {
+				return errors.New("anonymize rewrites every user row; pass --yes to confirm")
+			}
+			if batchSize <= 0 {
+				return errors.New("batch size must be greater than zero")
+			}
+
+			hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+			if err != nil {
+				return err
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+
+			logger.WithFields(logrus.Fields{
+				"db_host": cfg.Database.Host,
+				"db_name": cfg.Database.Name,
+			}).Info("Starting user anonymization")
+
+			count, err := anonymizeUsers(db, string(hash), batchSize)
+			if err != nil {
+				return err
+			}
+
+			logger.WithFields(logrus.Fields{
+				"count": count,
+			}).Info("User anonymization completed successfully")
+			fmt.Fprintf(cmd.OutOrStdout(), "anonymized %d users\n", count)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&password, "password", "password123", "Password every anonymized user can log in with")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 1000, "Number of users rewritten per transaction")
+	cmd.Flags().BoolVar(&confirm, "yes", false, "Confirm that every user row should be rewritten")
+
+	return cmd
+}
+
+func anonymizeUsers(db *gorm.DB, passwordHash string, batchSize int) (int, error) {
+	count := 0
+	var users []domain.User
+
+	err := db.Model(&domain.User{}).Select("id").FindInBatches(&users, batchSize, func(_ *gorm.DB, batch int) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			for _, user := range users {
+				name, email := fakeIdentity(user.ID.String())
+				if err := tx.Model(&domain.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+					"name":          name,
+					"email":         email,
+					"password_hash": passwordHash,
+				}).Error; err != nil {
+					return err
+				}
+			}
+			count += len(users)
+			return nil
+		})
+	}).Error
+
+	return count, err
+}
+
+func fakeIdentity(seed string) (string, string) {
+	sum := sha256.Sum256([]byte(seed))
+	first := fakeFirstNames[binary.BigEndian.Uint32(sum[0:4])%uint32(len(fakeFirstNames))]
+	last := fakeLastNames[binary.BigEndian.Uint32(sum[4:8])%uint32(len(fakeLastNames))]
+	suffix := hex.EncodeToString(sum[8:14])
+
+	name := first + " " + last
+	email := fmt.Sprintf("%s.%s.%s@example.com", asciiLower(first), asciiLower(last), suffix)
+	return name, email
+}
+
+func asciiLower(s string) string {
+	replacer := strings.NewReplacer("á", "a", "ã", "a", "é", "e", "í", "i", "ó", "o", "ô", "o", "ú", "u", "ç", "c")
+	return replacer.Replace(strings.ToLower(s))
+}
@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type benchmark struct {
+	name string
+	fn   func(b *testing.B)
+}
+
+type benchResult struct {
+	Name        string `json:"name"`
+	N           int    `json:"n"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	AllocsPerOp int64  `json:"allocs_per_op"`
+	BytesPerOp  int64  `json:"bytes_per_op"`
+}
+
+func newBenchCommand() *cobra.Command {
+	var suite, save, baseline string
+	var rows int
+	var maxRegression float64
+
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Benchmark repository list queries and JSON serialization",
+		Long:  "Run benchmarks for repository list queries under different filter combinations (against the configured database, ideally filled with `loadgen`) and for JSON serialization of large result sets. Results can be saved and compared with a baseline; the command fails when any benchmark is slower than the baseline by more than --max-regression percent.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var benchmarks []benchmark
+			switch suite {
+			case "json":
+				benchmarks = jsonBenchmarks(rows)
+			case "repo", "all":
+				db, err := openDatabase()
+				if err != nil {
+					return err
+				}
+				db = db.Session(&gorm.Session{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+				benchmarks = repositoryBenchmarks(db)
+				if suite == "all" {
+					benchmarks = append(benchmarks, jsonBenchmarks(rows)...)
+				}
+			default:
+				return fmt.Errorf("invalid suite %q (expected repo, json or all)", suite)
+			}
+
+			var previous map[string]benchResult
+			if baseline != "" {
+				data, err := os.ReadFile(baseline)
+				if err != nil {
+					return err
+				}
+				var results []benchResult
+				if err := json.Unmarshal(data, &results); err != nil {
+					return fmt.Errorf("failed to parse baseline %s: %w", baseline, err)
+				}
+				previous = make(map[string]benchResult, len(results))
+				for _, r := range results {
+					previous[r.Name] = r
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			results := make([]benchResult, 0, len(benchmarks))
+			regressions := 0
+			for _, bm := range benchmarks {
+				r := testing.Benchmark(bm.fn)
+				if r.N == 0 {
+					return fmt.Errorf("benchmark %s failed", bm.name)
+				}
+				result := benchResult{Name: bm.name, N: r.N, NsPerOp: r.NsPerOp(), AllocsPerOp: r.AllocsPerOp(), BytesPerOp: r.AllocedBytesPerOp()}
+				results = append(results, result)
+
+				line := fmt.Sprintf("%-45s %10d %14d ns/op %12d B/op %10d allocs/op", bm.name, r.N, result.NsPerOp, result.BytesPerOp, result.AllocsPerOp)
+				if prev, ok := previous[bm.name]; ok && prev.NsPerOp > 0 {
+					delta := (float64(result.NsPerOp) - float64(prev.NsPerOp)) / float64(prev.NsPerOp) * 100
+					line += fmt.Sprintf("  %+6.1f%%", delta)
+					if delta > maxRegression {
+						regressions++
+						line += "  REGRESSION"
+					}
+				}
+				fmt.Fprintln(out, line)
+			}
+
+			if save != "" {
+				data, err := json.MarshalIndent(results, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(save, data, 0o644); err != nil {
+					return err
+				}
+			}
+
+			if regressions > 0 {
+				return fmt.Errorf("%d benchmarks regressed by more than %.0f%%", regressions, maxRegression)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&suite, "suite", "all", "Benchmarks to run (repo, json, all)")
+	cmd.Flags().IntVar(&rows, "rows", 10000, "Rows serialized by the JSON benchmarks")
+	cmd.Flags().StringVar(&save, "save", "", "Write the results as JSON to this file")
+	cmd.Flags().StringVar(&baseline, "baseline", "", "Compare against results previously written with --save")
+	cmd.Flags().Float64Var(&maxRegression, "max-regression", 20, "Maximum allowed slowdown versus the baseline, in percent")
+
+	return cmd
+}
+
+func repositoryBenchmarks(db *gorm.DB) []benchmark {
+	ctx := context.Background()
+	products := infrastructure.NewPostgresProductRepository(db)
+	projects := infrastructure.NewPostgresProjectRepository(db)
+	items := infrastructure.NewPostgresProjectItemRepository(db)
+	users := infrastructure.NewPostgresUserRepository(db)
+
+	page := domain.Pagination{Limit: 20, Sort: "created_at desc"}
+	deepPage := domain.Pagination{Limit: 20, Offset: 10000, Sort: "created_at desc"}
+	priceFrom, priceTo := 10.0, 500.0
+	stockFrom := 1
+	hoursFrom := 4.0
+	monthAgo := time.Now().AddDate(0, -1, 0)
+	now := time.Now()
+
+	return []benchmark{
+		{"Products/List/NoFilter", listBench(ctx, func(ctx context.Context) error {
+			_, err := products.List(ctx, domain.ProductParams{}, page)
+			return err
+		})},
+		{"Products/List/DeepOffset", listBench(ctx, func(ctx context.Context) error {
+			_, err := products.List(ctx, domain.ProductParams{}, deepPage)
+			return err
+		})},
+		{"Products/List/Category", listBench(ctx, func(ctx context.Context) error {
+			_, err := products.List(ctx, domain.ProductParams{Category: "Books"}, page)
+			return err
+		})},
+		{"Products/List/NameLike", listBench(ctx, func(ctx context.Context) error {
+			_, err := products.List(ctx, domain.ProductParams{Name: "item 12"}, page)
+			return err
+		})},
+		{"Products/List/PriceAndStockRange", listBench(ctx, func(ctx context.Context) error {
+			_, err := products.List(ctx, domain.ProductParams{PriceFrom: &priceFrom, PriceTo: &priceTo, StockFrom: &stockFrom}, page)
+			return err
+		})},
+		{"Products/List/CreatedAtRange", listBench(ctx, func(ctx context.Context) error {
+			_, err := products.List(ctx, domain.ProductParams{CreatedAtFrom: &monthAgo, CreatedAtTo: &now}, page)
+			return err
+		})},
+		{"Users/List/EmailLike", listBench(ctx, func(ctx context.Context) error {
+			_, err := users.List(ctx, domain.Params{Email: "load-"}, page)
+			return err
+		})},
+		{"Projects/List/Status", listBench(ctx, func(ctx context.Context) error {
+			_, err := projects.List(ctx, domain.ProjectParams{Status: "active"}, page)
+			return err
+		})},
+		{"ProjectItems/List/StatusPriority", listBench(ctx, func(ctx context.Context) error {
+			_, err := items.List(ctx, domain.ProjectItemParams{Status: "pending", Priority: "high"}, page)
+			return err
+		})},
+		{"ProjectItems/List/DueDateAndHours", listBench(ctx, func(ctx context.Context) error {
+			_, err := items.List(ctx, domain.ProjectItemParams{DueDateFrom: &monthAgo, DueDateTo: &now, EstimatedHoursFrom: &hoursFrom}, page)
+			return err
+		})},
+	}
+}
+
+func listBench(ctx context.Context, list func(ctx context.Context) error) func(b *testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := list(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func jsonBenchmarks(rows int) []benchmark {
+	now := time.Now()
+	hours := 6.5
+	budget := domain.Money(25000)
+
+	products := make([]domain.Product, rows)
+	projects := make([]domain.Project, rows)
+	items := make([]domain.ProjectItem, rows)
+	for i := 0; i < rows; i++ {
+		products[i] = domain.Product{ID: uuid.New(), Name: fmt.Sprintf("Product %d", i), Description: "Benchmark product", Price: 19.9, Stock: i % 100, Category: "Books", SKU: domain.SKU(fmt.Sprintf("BENCH-%08d", i)), CreatedAt: now, UpdatedAt: now}
+		projects[i] = domain.Project{ID: uuid.New(), Name: fmt.Sprintf("Project %d", i), Status: "active", StartDate: &now, EndDate: &now, Budget: &budget, OwnerID: uuid.New(), CreatedAt: now, UpdatedAt: now}
+		items[i] = domain.ProjectItem{ID: uuid.New(), ProjectID: uuid.New(), Name: fmt.Sprintf("Task %d", i), Status: "pending", Priority: "medium", EstimatedHours: &hours, DueDate: &now, CreatedAt: now, UpdatedAt: now}
+	}
+
+	return []benchmark{
+		{fmt.Sprintf("JSON/Products/%d", rows), jsonBench(products)},
+		{fmt.Sprintf("JSON/Projects/%d", rows), jsonBench(projects)},
+		{fmt.Sprintf("JSON/ProjectItems/%d", rows), jsonBench(items)},
+	}
+}
+
+func jsonBench(v interface{}) func(b *testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,875 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/api"
+	"github.com/edumes/golang-api-rest/internal/application"
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/edumes/golang-api-rest/internal/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+)
+
+const contractSecret = "contract-check-signing-secret-0123456789"
+
+type swaggerSpec struct {
+	Paths       map[string]map[string]swaggerOperation `json:"paths"`
+	Definitions map[string]swaggerSchema               `json:"definitions"`
+}
+
+type swaggerOperation struct {
+	Parameters []swaggerParameter         `json:"parameters"`
+	Responses  map[string]swaggerResponse `json:"responses"`
+	Security   []map[string][]string      `json:"security"`
+}
+
+type swaggerParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Type     string         `json:"type"`
+	Required bool           `json:"required"`
+	Schema   *swaggerSchema `json:"schema"`
+}
+
+type swaggerResponse struct {
+	Schema *swaggerSchema `json:"schema"`
+}
+
+type swaggerSchema struct {
+	Ref        string                   `json:"$ref"`
+	Type       string                   `json:"type"`
+	Items      *swaggerSchema           `json:"items"`
+	Properties map[string]swaggerSchema `json:"properties"`
+	Enum       []interface{}            `json:"enum"`
+	AllOf      []swaggerSchema          `json:"allOf"`
+}
+
+func newContractCommand() *cobra.Command {
+	var specFile string
+
+	cmd := &cobra.Command{
+		Use:   "contract",
+		Short: "Check every route against the swagger spec and exit non-zero on drift",
+		Long:  "Build the router on top of mocked services, exercise every documented operation through httptest and compare status codes and response shapes with docs/swagger.json. Routes missing from either side are reported too. No database is needed, so it can run in CI.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(specFile)
+			if err != nil {
+				return err
+			}
+			var spec swaggerSpec
+			if err := json.Unmarshal(data, &spec); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", specFile, err)
+			}
+
+			gin.SetMode(gin.ReleaseMode)
+			viper.Set("APP_JWT_SECRET", contractSecret)
+			tokenService := application.NewTokenService(contractSecret, time.Hour).WithRefreshTokens(contractRefreshTokenRepository(), time.Hour).WithRevocations(contractRevokedTokenRepository())
+
+			router := api.NewRouter().WithFaultInjection(contractFaultService()).WithOutboundStats(infrastructure.NewHTTPClient(infrastructure.DefaultHTTPClientConfig()))
+			router.SetupRoutes(contractUserService(), tokenService, contractProductService(), contractCategoryService(), contractAttachmentService(), contractProjectService(), contractProjectItemService(), contractCommentService(), contractCouponService(), contractStockAdjustmentService(), contractWarehouseService(), contractPurchaseOrderService(), contractOrderService(), contractExpenseService(), contractWatchService(), contractProjectExportService(), contractCustomFieldService(), contractSavedFilterService(), contractReportService(), contractDashboardService(), contractReportSubscriptionService(), contractRetentionService(), contractUserExportService(), contractPolicyService(), contractEmailTemplateService(), contractPushService(), contractChatService(), contractCalendarService(), contractExchangeRateService())
+
+			token, _, err := tokenService.IssueAccessToken(&contractUser)
+			if err != nil {
+				return err
+			}
+			contractMFAChallenge, _, err = tokenService.IssueMFAChallenge(&contractUser)
+			if err != nil {
+				return err
+			}
+
+			failures := checkContract(&spec, router, token)
+			for _, failure := range failures {
+				fmt.Fprintln(cmd.ErrOrStderr(), "FAIL", failure)
+			}
+			if len(failures) > 0 {
+				return fmt.Errorf("%d contract violations against %s", len(failures), specFile)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "all routes match %s\n", specFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&specFile, "spec", "docs/swagger.json", "Swagger 2.0 spec to validate against")
+
+	return cmd
+}
+
+func checkContract(spec *swaggerSpec, router *api.Router, token string) []string {
+	var failures []string
+
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		if !strings.HasPrefix(route.Path, api.APIVersion) {
+			continue
+		}
+		key := route.Method + " " + ginToSwaggerPath(route.Path)
+		registered[key] = true
+		if _, ok := spec.Paths[ginToSwaggerPath(route.Path)][strings.ToLower(route.Method)]; !ok {
+			failures = append(failures, key+": route is registered but not documented")
+		}
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		for method, op := range spec.Paths[path] {
+			key := strings.ToUpper(method) + " " + path
+			if !registered[key] {
+				failures = append(failures, key+": documented but not registered")
+				continue
+			}
+			failures = append(failures, checkOperation(spec, router, token, strings.ToUpper(method), path, op)...)
+		}
+	}
+
+	return failures
+}
+
+func checkOperation(spec *swaggerSpec, router *api.Router, token, method, path string, op swaggerOperation) []string {
+	key := method + " " + path
+	var failures []string
+
+	target := path
+	var body []byte
+	contentType := "application/json"
+	query := url.Values{}
+	var form []swaggerParameter
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			target = strings.ReplaceAll(target, "{"+param.Name+"}", contractPathValue(param.Name))
+		case "query":
+			if param.Required {
+				query.Set(param.Name, contractQueryValue(param))
+			}
+		case "body":
+			if param.Schema != nil {
+				body, _ = json.Marshal(contractExample(spec, *param.Schema, ""))
+			}
+		case "formData":
+			form = append(form, param)
+		}
+	}
+	if len(form) > 0 {
+		body, contentType = contractForm(form)
+	}
+
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	if len(op.Security) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.GetEngine().ServeHTTP(rec, req)
+
+	status := strconv.Itoa(rec.Code)
+	resp, documented := op.Responses[status]
+	if !documented {
+		return append(failures, fmt.Sprintf("%s: returned undocumented status %s (body %s)", key, status, strings.TrimSpace(rec.Body.String())))
+	}
+	// Redirects count as success: they are how some routes, like OAuth
+	// sign-in, answer.
+	if rec.Code >= 400 {
+		failures = append(failures, fmt.Sprintf("%s: expected a success status, got %s (body %s)", key, status, strings.TrimSpace(rec.Body.String())))
+	}
+
+	// File responses are binary downloads with nothing to validate.
+	if resp.Schema != nil && resp.Schema.Type != "file" {
+		var payload interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+			return append(failures, fmt.Sprintf("%s: response is not valid JSON: %v", key, err))
+		}
+		for _, problem := range validateAgainstSchema(spec, *resp.Schema, payload, "$") {
+			failures = append(failures, key+": "+problem)
+		}
+	}
+
+	if len(op.Security) > 0 {
+		unauth := httptest.NewRequest(method, target, bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		router.GetEngine().ServeHTTP(rec, unauth)
+		if _, ok := op.Responses[strconv.Itoa(rec.Code)]; !ok {
+			failures = append(failures, fmt.Sprintf("%s: unauthenticated request returned undocumented status %d", key, rec.Code))
+		}
+	}
+
+	return failures
+}
+
+func validateAgainstSchema(spec *swaggerSpec, schema swaggerSchema, value interface{}, at string) []string {
+	schema = resolveSchema(spec, schema)
+	if value == nil {
+		return nil
+	}
+
+	var problems []string
+	switch schema.Type {
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected array, got %T", at, value)}
+		}
+		if schema.Items != nil {
+			for i, item := range items {
+				problems = append(problems, validateAgainstSchema(spec, *schema.Items, item, fmt.Sprintf("%s[%d]", at, i))...)
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected object, got %T", at, value)}
+		}
+		if len(schema.Properties) == 0 {
+			return nil
+		}
+		for name, prop := range schema.Properties {
+			field, present := object[name]
+			if !present {
+				problems = append(problems, fmt.Sprintf("%s.%s: documented field missing from response", at, name))
+				continue
+			}
+			problems = append(problems, validateAgainstSchema(spec, prop, field, at+"."+name)...)
+		}
+		for name := range object {
+			if _, ok := schema.Properties[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s: field not documented in spec", at, name))
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			problems = append(problems, fmt.Sprintf("%s: expected string, got %T", at, value))
+		}
+	case "number", "integer":
+		if _, ok := value.(float64); !ok {
+			problems = append(problems, fmt.Sprintf("%s: expected %s, got %T", at, schema.Type, value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			problems = append(problems, fmt.Sprintf("%s: expected boolean, got %T", at, value))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+func resolveSchema(spec *swaggerSpec, schema swaggerSchema) swaggerSchema {
+	// swag wraps a documented struct field's $ref in a single-element allOf.
+	if schema.Ref == "" && len(schema.AllOf) == 1 {
+		schema = schema.AllOf[0]
+	}
+	if schema.Ref != "" {
+		return spec.Definitions[strings.TrimPrefix(schema.Ref, "#/definitions/")]
+	}
+	return schema
+}
+
+func contractExample(spec *swaggerSpec, schema swaggerSchema, name string) interface{} {
+	schema = resolveSchema(spec, schema)
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+	switch schema.Type {
+	case "object":
+		example := make(map[string]interface{}, len(schema.Properties))
+		for prop, propSchema := range schema.Properties {
+			example[prop] = contractExample(spec, propSchema, prop)
+		}
+		return example
+	case "array":
+		if schema.Items == nil {
+			return []interface{}{}
+		}
+		return []interface{}{contractExample(spec, *schema.Items, name)}
+	case "integer":
+		return 5
+	case "number":
+		return 19.9
+	case "boolean":
+		return true
+	}
+
+	switch {
+	case name == "id" || strings.HasSuffix(name, "_id") || name == "assigned_to":
+		return uuid.NewString()
+	case name == "date" || strings.HasSuffix(name, "_at") || strings.HasSuffix(name, "_date") || strings.HasSuffix(name, "_until") || strings.HasSuffix(name, "_after"):
+		return time.Now().UTC().Format(time.RFC3339)
+	case strings.HasSuffix(name, "_url"):
+		return "https://example.com/" + name
+	case name == "email":
+		return "contract@example.com"
+	case name == "code":
+		return "123456"
+	case name == "challenge_token":
+		return contractMFAChallenge
+	case name == "password":
+		return "contract-password"
+	default:
+		return "contract-" + name
+	}
+}
+
+// contractForm encodes params as a multipart form. Files are a one-row
+// product CSV; services are mocked, so image uploads take it as well.
+func contractForm(params []swaggerParameter) ([]byte, string) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, param := range params {
+		if param.Type == "file" {
+			part, _ := w.CreateFormFile(param.Name, param.Name+".csv")
+			fmt.Fprintf(part, "sku,name,price\n%s,%s,%.2f\n", contractProduct.SKU, contractProduct.Name, contractProduct.Price.Float64())
+			continue
+		}
+		_ = w.WriteField(param.Name, "contract")
+	}
+	_ = w.Close()
+	return buf.Bytes(), w.FormDataContentType()
+}
+
+func contractQueryValue(param swaggerParameter) string {
+	if param.Type == "integer" {
+		return strconv.FormatInt(contractNow.Unix(), 10)
+	}
+	return "contract"
+}
+
+func contractPathValue(name string) string {
+	switch name {
+	case "sku":
+		return contractProduct.SKU.String()
+	case "code":
+		return *contractProduct.Barcode
+	case "provider":
+		return string(domain.OAuthGoogle)
+	}
+	return uuid.NewString()
+}
+
+func ginToSwaggerPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+var (
+	contractNow        = time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+	contractHours      = 8.0
+	contractBudget     = domain.Money(15000)
+	contractAssignee   = uuid.New()
+	contractParentItem = uuid.New()
+	contractBarcode    = "4006381333931"
+	contractCost       = domain.Money(12.5)
+	contractReorder    = 10
+
+	// contractMFAChallenge is issued once the token service exists, for
+	// the two-factor verification body.
+	contractMFAChallenge string
+
+	contractUser = domain.User{ID: uuid.New(), Name: "Contract User", Email: "contract@example.com", Role: domain.RoleAdmin, Active: true, LastLoginAt: &contractNow, LoginCount: 3, CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractWarehouse = domain.Warehouse{ID: uuid.New(), Code: "MAIN", Name: "Main Warehouse", Address: "Sample", CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractCategory = domain.Category{ID: uuid.New(), Name: "Books", CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractProduct = domain.Product{ID: uuid.New(), Name: "Contract Product", Description: "Sample", Price: 19.9, CostPrice: &contractCost, Stock: 5, ReorderLevel: &contractReorder, CategoryID: &contractCategory.ID, Category: contractCategory.Name, SKU: "CONTRACT-SKU", Barcode: &contractBarcode, Availability: domain.NewProductAvailability(5, []domain.WarehouseStockLevel{{WarehouseID: contractWarehouse.ID, WarehouseCode: contractWarehouse.Code, Quantity: 3}}), CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractProductImport = domain.ProductImport{ID: uuid.New(), Status: domain.ProductImportStatusCompleted, Size: 64, Progress: 100, Total: 2, Imported: 1, Failed: 1, Errors: domain.ImportRowErrors{{Line: 3, Key: "BAD SKU", Error: "invalid price: abc"}}, RequestedBy: contractUser.ID, CreatedAt: contractNow, CompletedAt: &contractNow}
+
+	contractImage = []byte("GIF89a")
+
+	contractAttachment = domain.Attachment{ID: uuid.New(), OwnerType: domain.AttachmentOwnerProduct, OwnerID: contractProduct.ID, Key: "products/contract.gif", FileName: "contract.gif", ContentType: "image/gif", Size: int64(len(contractImage)), UploadedBy: contractUser.ID, CreatedAt: contractNow, URL: "https://files.example.com/products/contract.gif?signature=contract", URLExpiresAt: &contractNow}
+
+	contractProject = domain.Project{ID: uuid.New(), Name: "Contract Project", Description: "Sample", Status: "active", StartDate: &contractNow, EndDate: &contractNow, Budget: &contractBudget, OwnerID: contractUser.ID, CustomFields: domain.CustomFieldValues{"region": "south"}, CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractMaxUses = 100
+
+	contractCoupon = domain.Coupon{ID: uuid.New(), Code: "CONTRACT10", Description: "Sample", Type: domain.CouponTypePercentage, Value: 10, Category: "Books", ProductID: &contractProduct.ID, StartsAt: &contractNow, EndsAt: &contractNow, MaxUses: &contractMaxUses, UsedCount: 1, CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractCartQuote = domain.CartQuote{CouponCode: contractCoupon.Code, Lines: []domain.CartQuoteLine{{ProductID: contractProduct.ID, SKU: contractProduct.SKU, Name: contractProduct.Name, Category: contractProduct.Category, Quantity: 2, UnitPrice: contractProduct.Price, LineTotal: 39.8, Eligible: true}}, Subtotal: 39.8, Discount: 3.98, Total: 35.82}
+
+	contractStockAdjustment = domain.StockAdjustment{ID: uuid.New(), ProductID: contractProduct.ID, WarehouseID: &contractWarehouse.ID, Quantity: -2, Reason: domain.StockReasonDamage, Note: "Sample", ActorID: contractUser.ID, StockBefore: 7, StockAfter: 5, CreatedAt: contractNow}
+
+	contractWarehouseStock = domain.WarehouseStock{WarehouseID: contractWarehouse.ID, ProductID: contractProduct.ID, Quantity: 3, UpdatedAt: contractNow}
+
+	contractStockTransfer = domain.StockTransfer{ID: uuid.New(), ProductID: contractProduct.ID, ToWarehouseID: &contractWarehouse.ID, Quantity: 3, Note: "Sample", ActorID: contractUser.ID, CreatedAt: contractNow}
+
+	contractStockMovement = domain.StockMovement{ID: contractStockAdjustment.ID, ProductID: contractProduct.ID, Reason: contractStockAdjustment.Reason, Quantity: contractStockAdjustment.Quantity, FromWarehouseID: &contractWarehouse.ID, StockBefore: &contractStockAdjustment.StockBefore, StockAfter: &contractStockAdjustment.StockAfter, Note: "Sample", ActorID: contractUser.ID, CreatedAt: contractNow}
+
+	contractPurchaseOrderID = uuid.New()
+
+	contractPurchaseOrder = domain.PurchaseOrder{ID: contractPurchaseOrderID, Supplier: "Contract Supplier", Status: domain.PurchaseOrderStatusSubmitted, WarehouseID: &contractWarehouse.ID, Notes: "Sample", CreatedBy: contractUser.ID, Lines: []domain.PurchaseOrderLine{{ID: uuid.New(), PurchaseOrderID: contractPurchaseOrderID, ProductID: contractProduct.ID, Quantity: 10, UnitCost: 12.5}}, SubmittedAt: &contractNow, CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractOrderID = uuid.New()
+
+	contractOrder = domain.Order{ID: contractOrderID, UserID: contractUser.ID, Status: domain.OrderStatusPaid, WarehouseID: &contractWarehouse.ID, Notes: "Sample", Subtotal: 39.8, Total: 39.8, Items: []domain.OrderItem{{ID: uuid.New(), OrderID: contractOrderID, ProductID: contractProduct.ID, Quantity: 2, UnitPrice: contractProduct.Price}}, PaidAt: &contractNow, CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractExpense = domain.Expense{ID: uuid.New(), ProjectID: contractProject.ID, Amount: 1200, Category: "travel", Description: "Sample", Date: contractNow, ReceiptURL: "https://example.com/receipt.pdf", CreatedBy: contractUser.ID, CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractSpent       = domain.Money(1200)
+	contractRemaining   = contractBudget - contractSpent
+	contractConsumption = contractSpent.Float64() / contractBudget.Float64()
+
+	contractProjectStats = domain.ProjectBudgetStats{ProjectID: contractProject.ID, Budget: &contractBudget, Spent: contractSpent, Remaining: &contractRemaining, Consumption: &contractConsumption, Categories: []domain.ExpenseCategoryTotal{{Category: "travel", Amount: contractSpent, Count: 1}}, Warnings: []string{}}
+
+	contractAssignment = domain.ProjectItemAssignment{ID: uuid.New(), ItemID: contractProjectItem.ID, UserID: contractAssignee, AssignedAt: contractNow, UnassignedAt: &contractNow}
+
+	contractWatch = domain.Watch{UserID: contractUser.ID, TargetType: domain.WatchTargetProject, TargetID: contractProject.ID, CreatedAt: contractNow}
+
+	contractNotification = domain.Notification{ID: uuid.New(), UserID: contractUser.ID, TargetType: domain.WatchTargetProject, TargetID: contractProject.ID, ProjectID: contractProject.ID, Event: domain.ChangeEventUpdated, Message: "Project \"Contract Project\" was updated", ReadAt: &contractNow, CreatedAt: contractNow}
+
+	contractHoursRollup = domain.HoursRollup{Estimated: contractHours, Actual: contractHours, Items: 1, ByStatus: []domain.HoursByStatus{{Status: "pending", Estimated: contractHours, Actual: contractHours, Items: 1}}, ByAssignee: []domain.HoursByAssignee{{AssignedTo: &contractAssignee, Estimated: contractHours, Actual: contractHours, Items: 1}}, ByWeek: []domain.HoursByWeek{{Week: &contractNow, Estimated: contractHours, Actual: contractHours, Items: 1}}}
+	contractCustomField = domain.CustomFieldDefinition{ID: uuid.New(), Entity: domain.CustomFieldEntityItem, ProjectID: &contractProject.ID, Key: "severity", Label: "Severity", Type: domain.CustomFieldSelect, Options: domain.StringList{"minor", "major"}, Required: true, CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractSavedFilter = domain.SavedFilter{ID: uuid.New(), UserID: contractUser.ID, Name: "Open high priority", Entity: domain.SavedFilterProjectItem, Query: domain.StringMap{"status": "pending", "priority": "high"}, Sort: "due_date asc", Shared: true, CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractReportSubscription = domain.ReportSubscription{ID: uuid.New(), UserID: contractUser.ID, Name: "Weekly stock", Report: domain.ReportStockByCategory, Format: domain.ReportFormatCSV, Filters: domain.StringMap{"group_by": "warehouse"}, Schedule: "0 8 * * 1", Recipients: domain.StringList{"contract@example.com"}, Active: true, NextRunAt: &contractNow, LastRunAt: &contractNow, CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractReportDelivery = domain.ReportDelivery{ID: uuid.New(), SubscriptionID: contractReportSubscription.ID, Status: domain.ReportDeliverySent, Format: domain.ReportFormatCSV, Recipients: domain.StringList{"contract@example.com"}, Rows: 2, ScheduledFor: contractNow, CreatedAt: contractNow}
+
+	contractRetentionRule = domain.RetentionRule{ID: uuid.New(), Entity: domain.RetentionNotification, Action: domain.RetentionPurge, RetainDays: 365, Enabled: true, LastRunAt: &contractNow, CreatedBy: contractUser.ID, CreatedAt: contractNow, UpdatedAt: contractNow}
+
+	contractRetentionRun = domain.RetentionRun{ID: uuid.New(), RuleID: contractRetentionRule.ID, Entity: domain.RetentionNotification, Action: domain.RetentionPurge, DryRun: true, Cutoff: contractNow.AddDate(-1, 0, 0), Affected: 42, TriggeredBy: &contractUser.ID, StartedAt: contractNow, FinishedAt: contractNow}
+
+	contractArchivedRecord = domain.ArchivedRecord{Entity: domain.RetentionProjectItem, RecordID: uuid.New(), ProjectID: &contractProject.ID, RuleID: &contractRetentionRule.ID, Payload: []byte(`{"project_item":{"name":"Contract item","status":"completed"},"assignments":[]}`), ArchivedAt: contractNow}
+
+	contractUserExport = domain.UserExport{ID: uuid.New(), UserID: contractUser.ID, Status: domain.UserExportStatusReady, Content: []byte("PK"), Size: 2, CreatedAt: contractNow, CompletedAt: &contractNow, ExpiresAt: &contractNow}
+
+	contractPolicy           = domain.PolicyDocument{ID: uuid.New(), Kind: domain.PolicyTermsOfService, Version: "2026-01", Title: "Terms of Service", Content: "Sample terms", PublishedBy: contractUser.ID, PublishedAt: contractNow}
+	contractPolicyAcceptance = domain.PolicyAcceptance{ID: uuid.New(), UserID: contractUser.ID, DocumentID: contractPolicy.ID, Kind: contractPolicy.Kind, Version: contractPolicy.Version, IPAddress: "203.0.113.7", AcceptedAt: contractNow}
+
+	contractProjectItem = domain.ProjectItem{ID: uuid.New(), ProjectID: contractProject.ID, Name: "Contract Item", Description: "Sample", Status: "pending", Priority: "medium", EstimatedHours: &contractHours, ActualHours: &contractHours, DueDate: &contractNow, AssignedTo: &contractAssignee, ParentItemID: &contractParentItem, CustomFields: domain.CustomFieldValues{"story_points": 3.0}, CreatedAt: contractNow, UpdatedAt: contractNow, Rollup: &domain.ProjectItemRollup{EstimatedHours: contractHours, ActualHours: contractHours}}
+	contractBoard       = domain.ProjectBoard{ProjectID: contractProject.ID, Limit: 20, Columns: []domain.BoardColumn{{Status: domain.ProjectItemStatusPending, Items: []domain.ProjectItem{contractProjectItem}, Total: 1}}}
+	contractTransition  = domain.ProjectItemTransition{ID: uuid.New(), ItemID: contractProjectItem.ID, FromStatus: domain.ProjectItemStatusPending, ToStatus: domain.ProjectItemStatusInProgress, ActorID: &contractUser.ID, TransitionedAt: contractNow}
+	contractComment     = domain.Comment{ID: uuid.New(), ItemID: contractProjectItem.ID, AuthorID: contractUser.ID, Body: "Looks good", CreatedAt: contractNow}
+)
+
+func anyArgs(n int) []interface{} {
+	args := make([]interface{}, n)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
+}
+
+func contractRefreshTokenRepository() *mocks.RefreshTokenRepository {
+	m := &mocks.RefreshTokenRepository{}
+	m.On("Create", anyArgs(2)...).Return(nil)
+	m.On("GetByHash", anyArgs(2)...).Return(&domain.RefreshToken{ID: uuid.New(), UserID: contractUser.ID, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	m.On("Revoke", anyArgs(3)...).Return(nil)
+	m.On("RevokeAllForUser", anyArgs(3)...).Return(int64(0), nil)
+	return m
+}
+
+func contractRevokedTokenRepository() *mocks.RevokedTokenRepository {
+	m := &mocks.RevokedTokenRepository{}
+	m.On("Revoke", anyArgs(2)...).Return(nil)
+	m.On("IsRevoked", anyArgs(2)...).Return(false, nil)
+	m.On("DeleteExpired", anyArgs(2)...).Return(int64(0), nil)
+	return m
+}
+
+func contractUserService() *mocks.UserService {
+	m := &mocks.UserService{}
+	m.On("CreateUser", anyArgs(4)...).Return(&contractUser, nil)
+	m.On("GetUserByID", anyArgs(2)...).Return(&contractUser, nil)
+	m.On("GetUserByEmail", anyArgs(2)...).Return(&contractUser, nil)
+	m.On("ListUsers", anyArgs(3)...).Return([]domain.User{contractUser}, nil)
+	m.On("CountUsers", anyArgs(2)...).Return(int64(1), nil)
+	m.On("UpdateUser", anyArgs(2)...).Return(nil)
+	m.On("PatchUser", anyArgs(3)...).Return(nil)
+	m.On("UpdateProfile", anyArgs(4)...).Return(&contractUser, nil)
+	m.On("DeleteUser", anyArgs(2)...).Return(nil)
+	m.On("CheckPassword", anyArgs(2)...).Return(true)
+	m.On("CheckSignIn", anyArgs(1)...).Return(nil)
+	m.On("DeactivateUser", anyArgs(3)...).Return(&contractUser, nil)
+	m.On("ReactivateUser", anyArgs(2)...).Return(&contractUser, nil)
+	m.On("RecordLogin", anyArgs(2)...).Return(nil)
+	m.On("CheckPasswordAge", anyArgs(1)...).Return(nil)
+	m.On("ChangePassword", anyArgs(3)...).Return(nil)
+	m.On("ListStaleUsers", anyArgs(3)...).Return([]domain.User{contractUser}, nil)
+	m.On("GetAccount", anyArgs(2)...).Return(&contractUser, nil)
+	m.On("GetDeletedAccountByEmail", anyArgs(2)...).Return(&contractUser, nil)
+	m.On("DeleteAccount", anyArgs(2)...).Return(&contractUser, nil)
+	m.On("RestoreAccount", anyArgs(2)...).Return(&contractUser, nil)
+	m.On("SuggestUsers", anyArgs(3)...).Return([]domain.Suggestion{{ID: contractUser.ID, Label: contractUser.Name}}, nil)
+	m.On("RequestPasswordReset", anyArgs(2)...).Return(nil)
+	m.On("ResetPassword", anyArgs(3)...).Return(&contractUser, nil)
+	m.On("OAuthLoginURL", anyArgs(1)...).Return("https://accounts.google.com/o/oauth2/v2/auth?state=contract", "contract", nil)
+	m.On("SignInWithOAuth", anyArgs(5)...).Return(&contractUser, nil)
+	m.On("EnrollMFA", anyArgs(2)...).Return(&domain.MFAEnrollment{Secret: "JBSWY3DPEHPK3PXP", URI: "otpauth://totp/contract?secret=JBSWY3DPEHPK3PXP"}, nil)
+	m.On("ConfirmMFA", anyArgs(3)...).Return(&contractUser, nil)
+	m.On("DisableMFA", anyArgs(3)...).Return(&contractUser, nil)
+	m.On("CheckMFACode", anyArgs(2)...).Return(nil)
+	m.On("CheckLoginThrottle", anyArgs(2)...).Return(nil)
+	m.On("CheckLockout", anyArgs(1)...).Return(nil)
+	m.On("RecordLoginFailure", anyArgs(4)...).Return(nil)
+	m.On("UnlockUser", anyArgs(2)...).Return(&contractUser, nil)
+	return m
+}
+
+func contractProductService() *mocks.ProductService {
+	m := &mocks.ProductService{}
+	m.On("CreateProduct", anyArgs(9)...).Return(&contractProduct, nil)
+	m.On("GetProductByID", anyArgs(2)...).Return(&contractProduct, nil)
+	m.On("GetProductBySKU", anyArgs(2)...).Return(&contractProduct, nil)
+	m.On("GetProductByBarcode", anyArgs(2)...).Return(&contractProduct, nil)
+	m.On("ListProducts", anyArgs(3)...).Return([]domain.Product{contractProduct}, nil)
+	m.On("CountProducts", anyArgs(2)...).Return(int64(1), nil)
+	m.On("UpdateProduct", anyArgs(2)...).Return(nil)
+	m.On("PatchProduct", anyArgs(3)...).Return(nil)
+	m.On("DeleteProduct", anyArgs(2)...).Return(nil)
+	m.On("ArchiveProduct", anyArgs(2)...).Return(&contractProduct, nil)
+	m.On("UnarchiveProduct", anyArgs(2)...).Return(&contractProduct, nil)
+	m.On("RelatedProducts", anyArgs(3)...).Return([]domain.Product{contractProduct}, nil)
+	m.On("ListLowStockProducts", anyArgs(2)...).Return([]domain.Product{contractProduct}, nil)
+	m.On("SuggestProducts", anyArgs(3)...).Return([]domain.Suggestion{{ID: contractProduct.ID, Label: contractProduct.Name}}, nil)
+	m.On("SearchProducts", anyArgs(3)...).Return([]domain.ProductSearchHit{{Product: contractProduct, Rank: 0.5, Highlights: domain.ProductHighlights{Name: "<mark>Contract</mark> Product", Description: "Sample"}}}, nil)
+	m.On("CountProductSearch", anyArgs(2)...).Return(int64(1), nil)
+	m.On("ImportProductCSV", anyArgs(4)...).Return(&domain.ImportResult{Total: 2, Imported: 1, Failed: 1, Errors: []domain.ImportRowError{{Line: 3, Key: "BAD SKU", Error: "invalid price: abc"}}}, nil)
+	m.On("StartProductImport", anyArgs(3)...).Return(&contractProductImport, nil)
+	m.On("GetProductImport", anyArgs(2)...).Return(&contractProductImport, nil)
+	return m
+}
+
+func contractCategoryService() *mocks.CategoryService {
+	m := &mocks.CategoryService{}
+	m.On("CreateCategory", anyArgs(2)...).Return(&contractCategory, nil)
+	m.On("GetCategoryByID", anyArgs(2)...).Return(&contractCategory, nil)
+	m.On("ListCategories", anyArgs(3)...).Return([]domain.Category{contractCategory}, nil)
+	m.On("UpdateCategory", anyArgs(2)...).Return(&contractCategory, nil)
+	m.On("DeleteCategory", anyArgs(2)...).Return(nil)
+	return m
+}
+
+func contractAttachmentService() *mocks.AttachmentService {
+	m := &mocks.AttachmentService{}
+	m.On("UploadProductImage", anyArgs(5)...).Return(&contractAttachment, nil)
+	m.On("ListProductImages", anyArgs(2)...).Return([]domain.Attachment{contractAttachment}, nil)
+	m.On("DeleteProductImage", anyArgs(3)...).Return(nil)
+	m.On("OpenFile", anyArgs(4)...).Return(&contractAttachment, func(context.Context, string, time.Time, string) io.ReadCloser {
+		return io.NopCloser(bytes.NewReader(contractImage))
+	}, nil)
+	return m
+}
+
+func contractProjectService() *mocks.ProjectService {
+	m := &mocks.ProjectService{}
+	m.On("CreateProject", anyArgs(9)...).Return(&contractProject, nil)
+	m.On("GetProjectByID", anyArgs(2)...).Return(&contractProject, nil)
+	m.On("ListProjects", anyArgs(3)...).Return([]domain.Project{contractProject}, nil)
+	m.On("CountProjects", anyArgs(2)...).Return(int64(1), nil)
+	m.On("UpdateProject", anyArgs(2)...).Return(nil)
+	m.On("PatchProject", anyArgs(3)...).Return(nil)
+	m.On("DeleteProject", anyArgs(2)...).Return(nil)
+	m.On("ArchiveProject", anyArgs(2)...).Return(&contractProject, nil)
+	m.On("UnarchiveProject", anyArgs(2)...).Return(&contractProject, nil)
+	return m
+}
+
+func contractProjectItemService() *mocks.ProjectItemService {
+	m := &mocks.ProjectItemService{}
+	m.On("CreateProjectItem", anyArgs(12)...).Return(&contractProjectItem, nil)
+	m.On("GetProjectItemByID", anyArgs(2)...).Return(&contractProjectItem, nil)
+	m.On("ListProjectItems", anyArgs(3)...).Return([]domain.ProjectItem{contractProjectItem}, nil)
+	m.On("CountProjectItems", anyArgs(2)...).Return(int64(1), nil)
+	m.On("UpdateProjectItem", anyArgs(2)...).Return(nil)
+	m.On("PatchProjectItem", anyArgs(3)...).Return(nil)
+	m.On("DeleteProjectItem", anyArgs(2)...).Return(nil)
+	m.On("GetProjectItemsByProjectID", anyArgs(2)...).Return([]domain.ProjectItem{contractProjectItem}, nil)
+	m.On("ListOverdueProjectItems", anyArgs(3)...).Return([]domain.ProjectItem{contractProjectItem}, nil)
+	m.On("ListUpcomingProjectItems", anyArgs(4)...).Return([]domain.ProjectItem{contractProjectItem}, nil)
+	m.On("ListProjectItemAssignments", anyArgs(2)...).Return([]domain.ProjectItemAssignment{contractAssignment}, nil)
+	m.On("GetHoursRollup", anyArgs(2)...).Return(&contractHoursRollup, nil)
+	m.On("TransitionProjectItem", anyArgs(4)...).Return(&contractProjectItem, nil)
+	m.On("ListProjectItemTransitions", anyArgs(2)...).Return([]domain.ProjectItemTransition{contractTransition}, nil)
+	m.On("ListProjectItemChildren", anyArgs(2)...).Return([]domain.ProjectItem{contractProjectItem}, nil)
+	m.On("GetProjectBoard", anyArgs(5)...).Return(&contractBoard, nil)
+	m.On("MoveProjectItemOnBoard", anyArgs(5)...).Return(&contractProjectItem, nil)
+	return m
+}
+
+func contractCommentService() *mocks.CommentService {
+	m := &mocks.CommentService{}
+	m.On("CreateComment", anyArgs(4)...).Return(&contractComment, nil)
+	m.On("ListComments", anyArgs(3)...).Return([]domain.Comment{contractComment}, nil)
+	m.On("CountComments", anyArgs(2)...).Return(int64(1), nil)
+	m.On("DeleteComment", anyArgs(5)...).Return(nil)
+	return m
+}
+
+func contractCouponService() *mocks.CouponService {
+	m := &mocks.CouponService{}
+	m.On("CreateCoupon", anyArgs(2)...).Return(&contractCoupon, nil)
+	m.On("GetCouponByID", anyArgs(2)...).Return(&contractCoupon, nil)
+	m.On("ListCoupons", anyArgs(3)...).Return([]domain.Coupon{contractCoupon}, nil)
+	m.On("UpdateCoupon", anyArgs(2)...).Return(nil)
+	m.On("DeleteCoupon", anyArgs(2)...).Return(nil)
+	m.On("QuoteCart", anyArgs(3)...).Return(&contractCartQuote, nil)
+	m.On("RedeemCoupon", anyArgs(3)...).Return(&contractCartQuote, nil)
+	return m
+}
+
+func contractStockAdjustmentService() *mocks.StockAdjustmentService {
+	m := &mocks.StockAdjustmentService{}
+	m.On("AdjustStock", anyArgs(7)...).Return(&contractStockAdjustment, nil)
+	m.On("ListStockAdjustments", anyArgs(3)...).Return([]domain.StockAdjustment{contractStockAdjustment}, nil)
+	m.On("ListStockMovements", anyArgs(4)...).Return([]domain.StockMovement{contractStockMovement}, nil)
+	return m
+}
+
+func contractWarehouseService() *mocks.WarehouseService {
+	m := &mocks.WarehouseService{}
+	m.On("CreateWarehouse", anyArgs(2)...).Return(&contractWarehouse, nil)
+	m.On("GetWarehouseByID", anyArgs(2)...).Return(&contractWarehouse, nil)
+	m.On("ListWarehouses", anyArgs(3)...).Return([]domain.Warehouse{contractWarehouse}, nil)
+	m.On("UpdateWarehouse", anyArgs(2)...).Return(nil)
+	m.On("DeleteWarehouse", anyArgs(2)...).Return(nil)
+	m.On("ListWarehouseStock", anyArgs(3)...).Return([]domain.WarehouseStock{contractWarehouseStock}, nil)
+	m.On("TransferStock", anyArgs(7)...).Return(&contractStockTransfer, nil)
+	return m
+}
+
+func contractPurchaseOrderService() *mocks.PurchaseOrderService {
+	m := &mocks.PurchaseOrderService{}
+	m.On("CreatePurchaseOrder", anyArgs(2)...).Return(&contractPurchaseOrder, nil)
+	m.On("GetPurchaseOrderByID", anyArgs(2)...).Return(&contractPurchaseOrder, nil)
+	m.On("ListPurchaseOrders", anyArgs(3)...).Return([]domain.PurchaseOrder{contractPurchaseOrder}, nil)
+	m.On("UpdatePurchaseOrder", anyArgs(2)...).Return(&contractPurchaseOrder, nil)
+	m.On("DeletePurchaseOrder", anyArgs(2)...).Return(nil)
+	m.On("SubmitPurchaseOrder", anyArgs(2)...).Return(&contractPurchaseOrder, nil)
+	m.On("ReceivePurchaseOrder", anyArgs(3)...).Return(&contractPurchaseOrder, nil)
+	return m
+}
+
+func contractOrderService() *mocks.OrderService {
+	m := &mocks.OrderService{}
+	m.On("CreateOrder", anyArgs(2)...).Return(&contractOrder, nil)
+	m.On("GetOrder", anyArgs(4)...).Return(&contractOrder, nil)
+	m.On("ListOrders", anyArgs(3)...).Return([]domain.Order{contractOrder}, nil)
+	m.On("UpdateOrder", anyArgs(4)...).Return(&contractOrder, nil)
+	m.On("DeleteOrder", anyArgs(4)...).Return(nil)
+	m.On("UpdateOrderStatus", anyArgs(5)...).Return(&contractOrder, nil)
+	return m
+}
+
+func contractExpenseService() *mocks.ExpenseService {
+	m := &mocks.ExpenseService{}
+	m.On("CreateExpense", anyArgs(2)...).Return(&contractExpense, nil)
+	m.On("GetExpense", anyArgs(3)...).Return(&contractExpense, nil)
+	m.On("ListExpenses", anyArgs(4)...).Return([]domain.Expense{contractExpense}, nil)
+	m.On("UpdateExpense", anyArgs(2)...).Return(nil)
+	m.On("DeleteExpense", anyArgs(3)...).Return(nil)
+	m.On("GetProjectStats", anyArgs(2)...).Return(&contractProjectStats, nil)
+	return m
+}
+
+func contractProjectExportService() *mocks.ProjectExportService {
+	snapshot, _ := json.Marshal(domain.ProjectSnapshot{
+		Project:     contractProject,
+		Members:     []domain.ProjectMember{{UserID: contractUser.ID, Name: contractUser.Name, Email: contractUser.Email, Role: domain.ProjectMemberOwner}},
+		Items:       []domain.ProjectItem{contractProjectItem},
+		Hours:       domain.ProjectHours{Estimated: contractHours, Actual: contractHours},
+		Expenses:    []domain.Expense{contractExpense},
+		Stats:       &contractProjectStats,
+		GeneratedAt: contractNow,
+	})
+	export := domain.ProjectExport{ID: uuid.New(), ProjectID: contractProject.ID, Format: domain.ProjectExportFormatJSON, Status: domain.ProjectExportStatusReady, Content: snapshot, RequestedBy: contractUser.ID, CreatedAt: contractNow, CompletedAt: &contractNow}
+
+	m := &mocks.ProjectExportService{}
+	m.On("ExportProject", anyArgs(4)...).Return(&export, nil)
+	m.On("GetProjectExport", anyArgs(2)...).Return(&export, nil)
+	return m
+}
+
+func contractCustomFieldService() *mocks.CustomFieldService {
+	m := &mocks.CustomFieldService{}
+	m.On("CreateCustomField", anyArgs(4)...).Return(&contractCustomField, nil)
+	m.On("GetCustomField", anyArgs(2)...).Return(&contractCustomField, nil)
+	m.On("ListCustomFields", anyArgs(2)...).Return([]domain.CustomFieldDefinition{contractCustomField}, nil)
+	m.On("UpdateCustomField", anyArgs(4)...).Return(&contractCustomField, nil)
+	m.On("DeleteCustomField", anyArgs(4)...).Return(nil)
+	return m
+}
+
+func contractSavedFilterService() *mocks.SavedFilterService {
+	m := &mocks.SavedFilterService{}
+	m.On("CreateSavedFilter", anyArgs(3)...).Return(&contractSavedFilter, nil)
+	m.On("GetSavedFilter", anyArgs(3)...).Return(&contractSavedFilter, nil)
+	m.On("ListSavedFilters", anyArgs(3)...).Return([]domain.SavedFilter{contractSavedFilter}, nil)
+	m.On("UpdateSavedFilter", anyArgs(3)...).Return(&contractSavedFilter, nil)
+	m.On("DeleteSavedFilter", anyArgs(3)...).Return(nil)
+	return m
+}
+
+func contractReportService() *mocks.ReportService {
+	pending, active := "pending", "active"
+	m := &mocks.ReportService{}
+	m.On("ItemsReport", anyArgs(2)...).Return([]domain.ItemsReportRow{{Group: &pending, Bucket: &contractNow, Items: 1, EstimatedHours: contractHours, ActualHours: contractHours}}, nil)
+	m.On("StockReport", anyArgs(2)...).Return([]domain.StockReportRow{{Group: &contractProduct.Category, Bucket: &contractNow, Products: 1, Stock: 5, StockValue: 99.5, StockCost: 62.5}}, nil)
+	m.On("ProjectsReport", anyArgs(2)...).Return([]domain.ProjectsReportRow{{Group: &active, Bucket: &contractNow, Projects: 1, Budget: contractBudget.Float64(), Spent: contractSpent.Float64(), Remaining: contractRemaining.Float64()}}, nil)
+	return m
+}
+
+func contractDashboardService() *mocks.DashboardService {
+	m := &mocks.DashboardService{}
+	m.On("GetDashboard", anyArgs(2)...).Return(&domain.Dashboard{
+		AssignedItems:     []domain.ProjectItem{contractProjectItem},
+		Items:             domain.DashboardItemCounts{Open: 3, Overdue: 1, DueToday: 1},
+		RecentActivity:    []domain.Notification{contractNotification},
+		OwnedProjects:     []domain.ProjectStatusCount{{Status: contractProject.Status, Projects: 1}},
+		LowStock:          []domain.Product{contractProduct},
+		LowStockThreshold: domain.DefaultLowStockThreshold,
+		GeneratedAt:       contractNow,
+	}, nil)
+	return m
+}
+
+func contractReportSubscriptionService() *mocks.ReportSubscriptionService {
+	m := &mocks.ReportSubscriptionService{}
+	m.On("CreateReportSubscription", anyArgs(3)...).Return(&contractReportSubscription, nil)
+	m.On("GetReportSubscription", anyArgs(3)...).Return(&contractReportSubscription, nil)
+	m.On("ListReportSubscriptions", anyArgs(3)...).Return([]domain.ReportSubscription{contractReportSubscription}, nil)
+	m.On("UpdateReportSubscription", anyArgs(3)...).Return(&contractReportSubscription, nil)
+	m.On("DeleteReportSubscription", anyArgs(3)...).Return(nil)
+	m.On("ListReportDeliveries", anyArgs(4)...).Return([]domain.ReportDelivery{contractReportDelivery}, nil)
+	return m
+}
+
+func contractRetentionService() *mocks.RetentionService {
+	m := &mocks.RetentionService{}
+	m.On("CreateRetentionRule", anyArgs(3)...).Return(&contractRetentionRule, nil)
+	m.On("GetRetentionRule", anyArgs(2)...).Return(&contractRetentionRule, nil)
+	m.On("ListRetentionRules", anyArgs(1)...).Return([]domain.RetentionRule{contractRetentionRule}, nil)
+	m.On("UpdateRetentionRule", anyArgs(2)...).Return(&contractRetentionRule, nil)
+	m.On("DeleteRetentionRule", anyArgs(2)...).Return(nil)
+	m.On("RunRetention", anyArgs(4)...).Return([]domain.RetentionRun{contractRetentionRun}, nil)
+	m.On("ListRetentionRuns", anyArgs(3)...).Return([]domain.RetentionRun{contractRetentionRun}, nil)
+	m.On("ListArchivedRecords", anyArgs(3)...).Return([]domain.ArchivedRecord{contractArchivedRecord}, nil)
+	m.On("GetArchivedRecord", anyArgs(3)...).Return(&contractArchivedRecord, nil)
+	m.On("RestoreArchivedRecord", anyArgs(4)...).Return(nil)
+	m.On("RetentionMetrics", anyArgs(1)...).Return([]domain.RetentionMetric{{Entity: domain.RetentionNotification, Action: domain.RetentionPurge, Runs: 3, Failed: 1, Affected: 120, LastRunAt: &contractNow}}, nil)
+	return m
+}
+
+func contractUserExportService() *mocks.UserExportService {
+	m := &mocks.UserExportService{}
+	m.On("RequestUserExport", anyArgs(2)...).Return(&contractUserExport, nil)
+	m.On("GetUserExport", anyArgs(3)...).Return(&contractUserExport, nil)
+	m.On("ListUserExports", anyArgs(3)...).Return([]domain.UserExport{contractUserExport}, nil)
+	m.On("DownloadUserExport", anyArgs(3)...).Return(&contractUserExport, nil)
+	return m
+}
+
+func contractPolicyService() *mocks.PolicyService {
+	m := &mocks.PolicyService{}
+	m.On("PublishPolicyDocument", anyArgs(3)...).Return(&contractPolicy, nil)
+	m.On("GetPolicyDocument", anyArgs(2)...).Return(&contractPolicy, nil)
+	m.On("ListPolicyDocuments", anyArgs(3)...).Return([]domain.PolicyDocument{contractPolicy}, nil)
+	m.On("CurrentPolicyDocuments", anyArgs(1)...).Return([]domain.PolicyDocument{contractPolicy}, nil)
+	m.On("AcceptPolicyDocument", anyArgs(4)...).Return(&contractPolicyAcceptance, nil)
+	m.On("GetPolicyStatus", anyArgs(2)...).Return(&domain.PolicyStatus{Accepted: []domain.PolicyAcceptance{contractPolicyAcceptance}, Pending: []domain.PolicyDocument{contractPolicy}}, nil)
+	m.On("ListPolicyAcceptances", anyArgs(3)...).Return([]domain.PolicyAcceptance{contractPolicyAcceptance}, nil)
+	m.On("CheckPolicyAcceptance", anyArgs(2)...).Return(nil, nil)
+	return m
+}
+
+func contractEmailTemplateService() *mocks.EmailTemplateService {
+	m := &mocks.EmailTemplateService{}
+	m.On("ListEmailTemplates").Return([]domain.EmailTemplateInfo{{Name: domain.EmailTemplateReportDelivery, Locales: domain.Locales}})
+	m.On("PreviewEmailTemplate", anyArgs(2)...).Return(&domain.EmailContent{Subject: "Weekly stock", Text: "Your report is attached.\n", HTML: "<p>Your report is attached.</p>"}, nil)
+	return m
+}
+
+func contractPushService() *mocks.PushService {
+	device := domain.Device{ID: uuid.New(), UserID: contractUser.ID, Platform: domain.PushPlatformFCM, Token: "contract-token", LastSeenAt: contractNow, CreatedAt: contractNow}
+	m := &mocks.PushService{}
+	m.On("RegisterDevice", anyArgs(4)...).Return(&device, nil)
+	m.On("ListDevices", anyArgs(2)...).Return([]domain.Device{device}, nil)
+	m.On("UnregisterDevice", anyArgs(3)...).Return(nil)
+	m.On("GetNotificationPreferences", anyArgs(2)...).Return(domain.DefaultNotificationPreferences(contractUser.ID), nil)
+	m.On("UpdateNotificationPreferences", anyArgs(2)...).Return(domain.DefaultNotificationPreferences(contractUser.ID), nil)
+	return m
+}
+
+func contractChatService() *mocks.ChatService {
+	projectID := contractProject.ID
+	connector := domain.ChatConnector{ID: uuid.New(), Name: "Launch channel", Provider: domain.ChatProviderSlack, WebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX", Events: domain.StringList{string(domain.ChatEventProjectItemCompleted)}, ProjectID: &projectID, Active: true, CreatedBy: contractUser.ID, CreatedAt: contractNow, UpdatedAt: contractNow}
+	m := &mocks.ChatService{}
+	m.On("CreateChatConnector", anyArgs(3)...).Return(&connector, nil)
+	m.On("GetChatConnector", anyArgs(2)...).Return(&connector, nil)
+	m.On("ListChatConnectors", anyArgs(3)...).Return([]domain.ChatConnector{connector}, nil)
+	m.On("UpdateChatConnector", anyArgs(2)...).Return(&connector, nil)
+	m.On("DeleteChatConnector", anyArgs(2)...).Return(nil)
+	m.On("TestChatConnector", anyArgs(2)...).Return(&domain.ChatTestResult{Delivered: true, SentAt: contractNow}, nil)
+	return m
+}
+
+func contractCalendarService() *mocks.CalendarService {
+	m := &mocks.CalendarService{}
+	m.On("CreateCalendarFeed", anyArgs(2)...).Return(&domain.CalendarFeedToken{Token: "calendar-token", CreatedAt: contractNow}, nil)
+	m.On("GetCalendarFeed", anyArgs(2)...).Return(&domain.CalendarFeed{UserID: contractUser.ID, CreatedAt: contractNow, LastUsedAt: &contractNow}, nil)
+	m.On("DeleteCalendarFeed", anyArgs(2)...).Return(nil)
+	m.On("RenderCalendarFeed", anyArgs(2)...).Return([]byte("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nEND:VCALENDAR\r\n"), nil)
+	return m
+}
+
+func contractExchangeRateService() *mocks.ExchangeRateService {
+	rate := domain.ExchangeRate{Base: domain.DefaultCurrency, Currency: "EUR", Rate: 0.92, Provider: domain.ExchangeRateFrankfurter, EffectiveAt: contractNow, FetchedAt: contractNow}
+	sync := domain.ExchangeRateSync{ID: uuid.New(), Provider: domain.ExchangeRateFrankfurter, Base: domain.DefaultCurrency, Currencies: 1, TriggeredBy: &contractUser.ID, StartedAt: contractNow, FinishedAt: contractNow}
+	m := &mocks.ExchangeRateService{}
+	m.On("ListExchangeRates", anyArgs(1)...).Return([]domain.ExchangeRate{rate}, nil)
+	m.On("RefreshExchangeRates", anyArgs(2)...).Return(&sync, nil)
+	m.On("ListExchangeRateSyncs", anyArgs(2)...).Return([]domain.ExchangeRateSync{sync}, nil)
+	m.On("ExchangeRateMetrics", anyArgs(1)...).Return(&domain.ExchangeRateMetrics{Base: domain.DefaultCurrency, Provider: domain.ExchangeRateFrankfurter, Currencies: 1, OldestFetchedAt: &contractNow, LastSyncAt: &contractNow, LastSuccessAt: &contractNow}, nil)
+	return m
+}
+
+func contractWatchService() *mocks.WatchService {
+	m := &mocks.WatchService{}
+	m.On("Watch", anyArgs(4)...).Return(&contractWatch, nil)
+	m.On("Unwatch", anyArgs(4)...).Return(nil)
+	m.On("ListWatchers", anyArgs(4)...).Return([]domain.Watch{contractWatch}, nil)
+	m.On("ListNotifications", anyArgs(4)...).Return([]domain.Notification{contractNotification}, nil)
+	m.On("MarkNotificationRead", anyArgs(3)...).Return(nil)
+	return m
+}
+
+func contractFaultService() *mocks.FaultService {
+	rule := domain.FaultRule{ID: uuid.New(), Method: "GET", Path: "/v1/products*", LatencyMS: 200, ErrorRate: 0.1, ErrorStatus: domain.DefaultFaultErrorStatus, ExpiresAt: &contractNow, CreatedBy: contractUser.ID, CreatedAt: contractNow}
+	m := &mocks.FaultService{}
+	m.On("CreateFaultRule", anyArgs(3)...).Return(&rule, nil)
+	m.On("ListFaultRules", anyArgs(1)...).Return([]domain.FaultRule{rule}, nil)
+	m.On("DeleteFaultRule", anyArgs(2)...).Return(nil)
+	m.On("ClearFaultRules", anyArgs(1)...).Return(1, nil)
+	m.On("Fault", anyArgs(2)...).Return(domain.Fault{}, false)
+	return m
+}
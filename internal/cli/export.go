@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+// exportSort pages by id so that each batch can start after the last id of
+// the previous one instead of skipping an ever larger offset.
+const exportSort = "id asc"
+
+type exportSource[T any] struct {
+	columns []domain.ExportColumn[T]
+	list    func(ctx context.Context, pagination domain.Pagination) ([]T, error)
+	id      func(record T) uuid.UUID
+}
+
+func newExportCommand() *cobra.Command {
+	var entity, format, output string
+	var batchSize int
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export users, products, projects or project items as JSON, NDJSON or CSV",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "json" && format != "ndjson" && format != "csv" {
+				return fmt.Errorf("invalid format %q (expected json, ndjson or csv)", format)
+			}
+			if batchSize <= 0 {
+				return fmt.Errorf("batch size must be greater than zero")
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+
+			var w io.Writer = cmd.OutOrStdout()
+			if output != "" && output != "-" {
+				file, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				w = file
+			}
+
+			logger.WithFields(logrus.Fields{
+				"entity": entity,
+				"format": format,
+				"output": output,
+			}).Info("Starting export")
+
+			count, err := exportEntity(cmd.Context(), db, entity, format, batchSize, w)
+			if err != nil {
+				return err
+			}
+
+			logger.WithFields(logrus.Fields{
+				"entity": entity,
+				"count":  count,
+			}).Info("Export completed successfully")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&entity, "entity", "products", "Entity to export (users, products, projects, project-items)")
+	cmd.Flags().StringVar(&format, "format", "json", "Output format (json, ndjson, csv)")
+	cmd.Flags().StringVarP(&output, "output", "o", "-", "Output file (- for stdout)")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 500, "Number of rows fetched per query")
+
+	return cmd
+}
+
+func exportEntity(ctx context.Context, db *gorm.DB, entity, format string, batchSize int, w io.Writer) (int, error) {
+	switch entity {
+	case "users":
+		repo := infrastructure.NewPostgresUserRepository(db)
+		return runExport(ctx, exportSource[domain.User]{
+			columns: domain.UserExportColumns,
+			list: func(ctx context.Context, pagination domain.Pagination) ([]domain.User, error) {
+				return repo.List(ctx, domain.Params{}, pagination)
+			},
+			id: func(u domain.User) uuid.UUID { return u.ID },
+		}, format, batchSize, w)
+	case "products":
+		repo := infrastructure.NewPostgresProductRepository(db)
+		return runExport(ctx, exportSource[domain.Product]{
+			columns: domain.ProductExportColumns,
+			list: func(ctx context.Context, pagination domain.Pagination) ([]domain.Product, error) {
+				return repo.List(ctx, domain.ProductParams{IncludeArchived: true}, pagination)
+			},
+			id: func(p domain.Product) uuid.UUID { return p.ID },
+		}, format, batchSize, w)
+	case "projects":
+		repo := infrastructure.NewPostgresProjectRepository(db)
+		return runExport(ctx, exportSource[domain.Project]{
+			columns: domain.ProjectExportColumns,
+			list: func(ctx context.Context, pagination domain.Pagination) ([]domain.Project, error) {
+				return repo.List(ctx, domain.ProjectParams{}, pagination)
+			},
+			id: func(p domain.Project) uuid.UUID { return p.ID },
+		}, format, batchSize, w)
+	case "project-items":
+		repo := infrastructure.NewPostgresProjectItemRepository(db)
+		return runExport(ctx, exportSource[domain.ProjectItem]{
+			columns: domain.ProjectItemExportColumns,
+			list: func(ctx context.Context, pagination domain.Pagination) ([]domain.ProjectItem, error) {
+				return repo.List(ctx, domain.ProjectItemParams{}, pagination)
+			},
+			id: func(i domain.ProjectItem) uuid.UUID { return i.ID },
+		}, format, batchSize, w)
+	default:
+		return 0, fmt.Errorf("invalid entity %q (expected users, products, projects or project-items)", entity)
+	}
+}
+
+func runExport[T any](ctx context.Context, src exportSource[T], format string, batchSize int, w io.Writer) (int, error) {
+	var csvWriter *csv.Writer
+	if format == "csv" {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(domain.ExportHeader(src.columns)); err != nil {
+			return 0, err
+		}
+	} else if format == "json" {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return 0, err
+		}
+	}
+
+	count := 0
+	pagination := domain.Pagination{Limit: batchSize, Sort: exportSort}
+	for {
+		records, err := src.list(ctx, pagination)
+		if err != nil {
+			return count, err
+		}
+
+		for _, record := range records {
+			if csvWriter != nil {
+				if err := csvWriter.Write(domain.ExportRow(src.columns, record)); err != nil {
+					return count, err
+				}
+			} else {
+				data, err := json.Marshal(record)
+				if err != nil {
+					return count, err
+				}
+				if format == "ndjson" {
+					data = append(data, '\n')
+				} else if count > 0 {
+					if _, err := io.WriteString(w, ","); err != nil {
+						return count, err
+					}
+				}
+				if _, err := w.Write(data); err != nil {
+					return count, err
+				}
+			}
+			count++
+		}
+
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return count, err
+			}
+		}
+
+		if len(records) < batchSize {
+			break
+		}
+		last := src.id(records[len(records)-1])
+		pagination.AfterID = &last
+	}
+
+	if format == "json" {
+		if _, err := io.WriteString(w, "]\n"); err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func newHealthcheckCommand() *cobra.Command {
+	var url string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "healthcheck",
+		Short: "Probe the readiness endpoint and exit 0 when the server is ready",
+		Long:  "Probe the readiness endpoint of a running server and exit 0 when it answers 200, 1 otherwise. Intended for Docker HEALTHCHECK and Kubernetes exec probes in images without curl.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if url == "" {
+				url = "http://127.0.0.1:" + cfg.Server.Port + api.HealthReady
+			}
+
+			client := &http.Client{Timeout: timeout}
+			resp, err := client.Get(url)
+			if err != nil {
+				return fmt.Errorf("health check failed: %w", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("health check failed: %s returned %d", url, resp.StatusCode)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "ok")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&url, "url", "", "Readiness URL to probe (defaults to http://127.0.0.1:$APP_PORT/health/ready)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 3*time.Second, "Maximum time to wait for the response")
+
+	return cmd
+}
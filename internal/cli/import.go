@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/edumes/golang-api-rest/internal/application"
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func newImportCommand() *cobra.Command {
+	var entity, file, errorsFile string
+	var batchSize int
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import entities from a CSV file, upserting by natural key",
+		Long:  "Import entities from a CSV file. Rows are validated, upserted by their natural key (SKU for products) in one transaction per batch, and rejected rows are written to an error report.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if entity != "products" {
+				return fmt.Errorf("invalid entity %q (only products can be imported)", entity)
+			}
+			if file == "" {
+				return errors.New("--file is required")
+			}
+
+			in, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+
+			logger.WithFields(logrus.Fields{
+				"entity": entity,
+				"file":   file,
+			}).Info("Starting import")
+
+			productService := application.NewProductService(infrastructure.NewPostgresProductRepository(db))
+			result, err := productService.ImportProductCSV(cmd.Context(), in, batchSize, uuid.Nil)
+			if err != nil {
+				return fmt.Errorf("failed to import %s: %w", file, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "imported %d of %d rows (%d failed)\n", result.Imported, result.Total, result.Failed)
+
+			if result.Failed == 0 {
+				return nil
+			}
+			if err := writeImportErrors(errorsFile, result.Errors); err != nil {
+				return err
+			}
+			return fmt.Errorf("%d rows failed to import, see %s", result.Failed, errorsFile)
+		},
+	}
+
+	cmd.Flags().StringVar(&entity, "entity", "products", "Entity to import (products)")
+	cmd.Flags().StringVar(&file, "file", "", "CSV file to import (header row required)")
+	cmd.Flags().StringVar(&errorsFile, "errors-file", "import-errors.csv", "File that receives the rejected rows")
+	cmd.Flags().IntVar(&batchSize, "batch-size", domain.ProductImportBatchSize, "Number of rows upserted per transaction")
+
+	return cmd
+}
+
+func writeImportErrors(path string, rowErrors []domain.ImportRowError) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"line", "key", "error"}); err != nil {
+		return err
+	}
+	for _, rowErr := range rowErrors {
+		if err := w.Write([]string{strconv.Itoa(rowErr.Line), rowErr.Key, rowErr.Error}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,263 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+var (
+	loadgenCategories     = []string{"Electronics", "Books", "Home", "Garden", "Toys", "Sports", "Clothing", "Food"}
+	loadgenProjectStatus  = []domain.ProjectStatus{"active", "active", "active", "completed", "on_hold"}
+	loadgenItemStatus     = []domain.ProjectItemStatus{"pending", "pending", "in_progress", "completed"}
+	loadgenItemPriorities = []domain.ProjectItemPriority{"low", "medium", "medium", "high"}
+)
+
+func newLoadgenCommand() *cobra.Command {
+	var users, products, projects, items string
+	var batchSize int
+	var seed uint64
+
+	cmd := &cobra.Command{
+		Use:     "loadgen",
+		Short:   "Bulk-insert large synthetic datasets for performance testing",
+		Long:    "Bulk-insert synthetic users, products, projects and project items with multi-row batched INSERTs. Counts accept k and m suffixes (10k, 1m). Every run uses a unique tag in emails and SKUs so runs can be stacked.",
+		Example: "  golang-api-rest loadgen --users 10k --products 1m --items 5m",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.App.Env == "production" {
+				return errors.New("refusing to generate load-test data while APP_ENV is production")
+			}
+			if batchSize <= 0 {
+				return errors.New("batch size must be greater than zero")
+			}
+
+			counts := make(map[string]int, 4)
+			for name, value := range map[string]string{"users": users, "products": products, "projects": projects, "items": items} {
+				n, err := parseCount(value)
+				if err != nil {
+					return fmt.Errorf("invalid --%s: %w", name, err)
+				}
+				counts[name] = n
+			}
+			if counts["projects"] > 0 && counts["users"] == 0 {
+				return errors.New("--projects requires --users to assign owners")
+			}
+			if counts["items"] > 0 && counts["projects"] == 0 {
+				return errors.New("--items requires --projects")
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			db = db.Session(&gorm.Session{Logger: gormlogger.Default.LogMode(gormlogger.Warn)})
+
+			gen := &loadGenerator{
+				db:  db,
+				rng: rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)),
+				tag: uuid.NewString()[:8],
+			}
+
+			hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+			if err != nil {
+				return err
+			}
+
+			start := time.Now()
+			userIDs, err := gen.users(counts["users"], batchSize, string(hash))
+			if err != nil {
+				return err
+			}
+			if err := gen.products(counts["products"], batchSize); err != nil {
+				return err
+			}
+			projectIDs, err := gen.projects(counts["projects"], batchSize, userIDs)
+			if err != nil {
+				return err
+			}
+			if err := gen.items(counts["items"], batchSize, projectIDs, userIDs); err != nil {
+				return err
+			}
+
+			logger.WithFields(logrus.Fields{
+				"tag":      gen.tag,
+				"users":    counts["users"],
+				"products": counts["products"],
+				"projects": counts["projects"],
+				"items":    counts["items"],
+				"elapsed":  time.Since(start).String(),
+			}).Info("Load-test data generated successfully")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&users, "users", "0", "Number of users to insert")
+	cmd.Flags().StringVar(&products, "products", "0", "Number of products to insert")
+	cmd.Flags().StringVar(&projects, "projects", "0", "Number of projects to insert (owners drawn from the generated users)")
+	cmd.Flags().StringVar(&items, "items", "0", "Number of project items to insert (spread across the generated projects)")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 1000, "Rows per multi-row INSERT statement")
+	cmd.Flags().Uint64Var(&seed, "seed", 1, "Random seed for reproducible datasets")
+
+	return cmd
+}
+
+func parseCount(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(value, "k"):
+		multiplier, value = 1_000, strings.TrimSuffix(value, "k")
+	case strings.HasSuffix(value, "m"):
+		multiplier, value = 1_000_000, strings.TrimSuffix(value, "m")
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q is not a non-negative count", value)
+	}
+	return int(n * float64(multiplier)), nil
+}
+
+type loadGenerator struct {
+	db  *gorm.DB
+	rng *rand.Rand
+	tag string
+}
+
+func (g *loadGenerator) insert(entity string, total, batchSize int, build func(offset, size int) interface{}) error {
+	for offset := 0; offset < total; offset += batchSize {
+		size := min(batchSize, total-offset)
+		if err := g.db.Create(build(offset, size)).Error; err != nil {
+			return fmt.Errorf("failed to insert %s batch at offset %d: %w", entity, offset, err)
+		}
+
+		logger.WithFields(logrus.Fields{
+			"entity":   entity,
+			"inserted": offset + size,
+			"total":    total,
+		}).Debug("Load-test batch inserted")
+	}
+	return nil
+}
+
+func (g *loadGenerator) randomTime(within time.Duration) time.Time {
+	return time.Now().Add(-time.Duration(g.rng.Int64N(int64(within))))
+}
+
+func (g *loadGenerator) users(total, batchSize int, passwordHash string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, total)
+	err := g.insert("users", total, batchSize, func(offset, size int) interface{} {
+		batch := make([]domain.User, size)
+		for i := range batch {
+			n := offset + i
+			createdAt := g.randomTime(365 * 24 * time.Hour)
+			first := fakeFirstNames[g.rng.IntN(len(fakeFirstNames))]
+			last := fakeLastNames[g.rng.IntN(len(fakeLastNames))]
+			batch[i] = domain.User{
+				ID:           uuid.New(),
+				Name:         first + " " + last,
+				Email:        domain.EmailAddress(fmt.Sprintf("load-%s-%d@example.com", g.tag, n)),
+				PasswordHash: passwordHash,
+				Role:         domain.RoleUser,
+				CreatedAt:    createdAt,
+				UpdatedAt:    createdAt,
+			}
+			ids = append(ids, batch[i].ID)
+		}
+		return &batch
+	})
+	return ids, err
+}
+
+func (g *loadGenerator) products(total, batchSize int) error {
+	return g.insert("products", total, batchSize, func(offset, size int) interface{} {
+		batch := make([]domain.Product, size)
+		for i := range batch {
+			n := offset + i
+			createdAt := g.randomTime(365 * 24 * time.Hour)
+			category := loadgenCategories[g.rng.IntN(len(loadgenCategories))]
+			batch[i] = domain.Product{
+				ID:          uuid.New(),
+				Name:        fmt.Sprintf("%s item %d", category, n),
+				Description: "Load-test product " + g.tag,
+				Price:       domain.Money(100+g.rng.IntN(99_900)) / 100,
+				Stock:       g.rng.IntN(1_000),
+				Category:    category,
+				SKU:         domain.SKU(fmt.Sprintf("LOAD-%s-%09d", g.tag, n)),
+				CreatedAt:   createdAt,
+				UpdatedAt:   createdAt,
+			}
+		}
+		return &batch
+	})
+}
+
+func (g *loadGenerator) projects(total, batchSize int, ownerIDs []uuid.UUID) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, total)
+	err := g.insert("projects", total, batchSize, func(offset, size int) interface{} {
+		batch := make([]domain.Project, size)
+		for i := range batch {
+			n := offset + i
+			createdAt := g.randomTime(365 * 24 * time.Hour)
+			start := createdAt.Add(time.Duration(g.rng.IntN(30)) * 24 * time.Hour)
+			end := start.Add(time.Duration(30+g.rng.IntN(335)) * 24 * time.Hour)
+			budget := domain.Money(1_000 + g.rng.IntN(500_000))
+			batch[i] = domain.Project{
+				ID:          uuid.New(),
+				Name:        fmt.Sprintf("Load project %s-%d", g.tag, n),
+				Description: "Load-test project",
+				Status:      loadgenProjectStatus[g.rng.IntN(len(loadgenProjectStatus))],
+				StartDate:   &start,
+				EndDate:     &end,
+				Budget:      &budget,
+				OwnerID:     ownerIDs[g.rng.IntN(len(ownerIDs))],
+				CreatedAt:   createdAt,
+				UpdatedAt:   createdAt,
+			}
+			ids = append(ids, batch[i].ID)
+		}
+		return &batch
+	})
+	return ids, err
+}
+
+func (g *loadGenerator) items(total, batchSize int, projectIDs, userIDs []uuid.UUID) error {
+	return g.insert("project items", total, batchSize, func(offset, size int) interface{} {
+		batch := make([]domain.ProjectItem, size)
+		for i := range batch {
+			n := offset + i
+			createdAt := g.randomTime(365 * 24 * time.Hour)
+			due := createdAt.Add(time.Duration(1+g.rng.IntN(90)) * 24 * time.Hour)
+			estimated := float64(1 + g.rng.IntN(80))
+			item := domain.ProjectItem{
+				ID:             uuid.New(),
+				ProjectID:      projectIDs[g.rng.IntN(len(projectIDs))],
+				Name:           fmt.Sprintf("Task %d", n),
+				Description:    "Load-test project item",
+				Status:         loadgenItemStatus[g.rng.IntN(len(loadgenItemStatus))],
+				Priority:       loadgenItemPriorities[g.rng.IntN(len(loadgenItemPriorities))],
+				EstimatedHours: &estimated,
+				DueDate:        &due,
+				CreatedAt:      createdAt,
+				UpdatedAt:      createdAt,
+			}
+			if len(userIDs) > 0 && g.rng.IntN(4) > 0 {
+				assignee := userIDs[g.rng.IntN(len(userIDs))]
+				item.AssignedTo = &assignee
+			}
+			batch[i] = item
+		}
+		return &batch
+	})
+}
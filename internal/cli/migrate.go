@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/spf13/cobra"
+)
+
+func newMigrateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply database schema migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+
+			logger.Info("Running database migrations")
+			if err := infrastructure.RunMigrations(db); err != nil {
+				return err
+			}
+
+			logger.Info("Database migrations completed successfully")
+			return nil
+		},
+	}
+}
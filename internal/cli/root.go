@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/edumes/golang-api-rest/internal/config"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var (
+	envFile     string
+	printConfig bool
+	cfg         *config.Config
+	logger      = infrastructure.GetColoredLogger()
+)
+
+func NewRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "golang-api-rest",
+		Short:         "Golang API REST server and operational tooling",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return loadConfig()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	root.PersistentFlags().StringVar(&envFile, "env-file", ".env", "Path to the .env file to load before reading environment variables")
+	root.PersistentFlags().BoolVar(&printConfig, "print-config", false, "Print the effective configuration as YAML (secrets masked) and exit")
+
+	root.AddCommand(
+		newServeCommand(),
+		newSeedCommand(),
+		newMigrateCommand(),
+		newExportCommand(),
+		newImportCommand(),
+		newConfigCommand(),
+		newUserCommand(),
+		newTokenCommand(),
+		newHealthcheckCommand(),
+		newRoutesCommand(),
+		newAnonymizeCommand(),
+		newLoadgenCommand(),
+		newContractCommand(),
+		newSmokeCommand(),
+		newBenchCommand(),
+	)
+
+	return root
+}
+
+func Execute() {
+	if err := NewRootCommand().Execute(); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Command failed")
+		os.Exit(1)
+	}
+}
+
+func loadConfig() error {
+	loaded, err := config.LoadFile(envFile)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"env_file": envFile,
+		}).Debug("Failed to read .env file, using environment variables")
+	}
+	cfg = loaded
+
+	if printConfig {
+		out, err := cfg.YAML()
+		if err != nil {
+			return fmt.Errorf("failed to render configuration: %w", err)
+		}
+		fmt.Print(string(out))
+		os.Exit(0)
+	}
+
+	loc, err := cfg.App.ApplyTimezone()
+	if err != nil {
+		return err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"env":      cfg.App.Env,
+		"timezone": loc.String(),
+	}).Debug("Configuration loaded successfully")
+
+	return nil
+}
+
+func openDatabase() (*gorm.DB, error) {
+	logger.WithFields(logrus.Fields{
+		"db_host": cfg.Database.Host,
+		"db_port": cfg.Database.Port,
+		"db_name": cfg.Database.Name,
+	}).Info("Initializing database connection")
+
+	db, err := infrastructure.NewPostgresDB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	logger.Info("Database connection established successfully")
+	return db, nil
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/edumes/golang-api-rest/internal/api"
+	"github.com/edumes/golang-api-rest/internal/application"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
+)
+
+func newRoutesCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "routes",
+		Short: "List every registered route with its auth requirement and handler",
+		Long:  "Build the router without a database connection and list every registered method and path, whether it requires a bearer token, and the handler serving it.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "table" && format != "json" {
+				return fmt.Errorf("invalid format %q (expected table or json)", format)
+			}
+
+			gin.SetMode(gin.ReleaseMode)
+
+			router := api.NewRouter()
+			if cfg.Server.FaultInjection {
+				router.WithFaultInjection(application.NewFaultService())
+			}
+			router.SetupRoutes(
+				application.NewUserService(nil),
+				application.NewTokenService(cfg.JWT.Secret, cfg.JWT.TTL),
+				application.NewProductService(nil),
+				application.NewCategoryService(nil),
+				application.NewAttachmentService(nil, nil, nil),
+				application.NewProjectService(nil),
+				application.NewProjectItemService(nil),
+				application.NewCommentService(nil, nil),
+				application.NewCouponService(nil, nil),
+				application.NewStockAdjustmentService(nil, nil, nil),
+				application.NewWarehouseService(nil, nil),
+				application.NewPurchaseOrderService(nil, nil, nil),
+				application.NewOrderService(nil, nil, nil),
+				application.NewExpenseService(nil, nil),
+				application.NewWatchService(nil, nil, nil, nil),
+				application.NewProjectExportService(nil, nil, nil, nil, nil),
+				application.NewCustomFieldService(nil, nil),
+				application.NewSavedFilterService(nil),
+				application.NewReportService(nil),
+				application.NewDashboardService(nil, nil, nil, nil),
+				application.NewReportSubscriptionService(nil, nil),
+				application.NewRetentionService(nil),
+				application.NewUserExportService(nil),
+				application.NewPolicyService(nil),
+				application.NewEmailTemplateService(nil),
+				application.NewPushService(nil),
+				application.NewChatService(nil, nil),
+				application.NewCalendarService(nil, nil),
+				application.NewExchangeRateService(nil),
+			)
+			routes := router.Routes()
+
+			if format == "json" {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(routes)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "METHOD\tPATH\tAUTH\tHANDLER")
+			for _, route := range routes {
+				auth := "public"
+				if route.AuthRequired {
+					auth = "bearer"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", route.Method, route.Path, auth, route.Handler)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "table", "Output format (table, json)")
+
+	return cmd
+}
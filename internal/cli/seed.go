@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/seeds"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func newSeedCommand() *cobra.Command {
+	var seedType string
+
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Populate the database with initial data",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+
+			ids, err := domain.NewIDGenerator(cfg.App.IDVersion)
+			if err != nil {
+				return fmt.Errorf("invalid APP_ID_VERSION: %w", err)
+			}
+			seeder := seeds.NewSeeder(db).WithIDGenerator(ids)
+			ctx := context.Background()
+
+			logger.WithFields(logrus.Fields{
+				"seed_type": seedType,
+			}).Info("Running seeds")
+
+			switch seedType {
+			case "all":
+				err = seeder.RunAll(ctx)
+			case "users":
+				err = seeder.RunUsers(ctx)
+			case "projects":
+				err = seeder.RunProjects(ctx)
+			case "project-items":
+				err = seeder.RunProjectItems(ctx)
+			default:
+				return fmt.Errorf("invalid seed type %q (expected all, users, projects or project-items)", seedType)
+			}
+			if err != nil {
+				return err
+			}
+
+			logger.Info("Seeds completed successfully")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&seedType, "type", "all", "Type of seed to run (all, users, projects, project-items)")
+
+	return cmd
+}
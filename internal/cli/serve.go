@@ -0,0 +1,419 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/edumes/golang-api-rest/internal/api"
+	"github.com/edumes/golang-api-rest/internal/application"
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func newServeCommand() *cobra.Command {
+	var skipMigrations bool
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Start the HTTP API server",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(skipMigrations)
+		},
+	}
+
+	cmd.Flags().BoolVar(&skipMigrations, "skip-migrations", false, "Do not run database migrations on startup")
+
+	return cmd
+}
+
+func runServe(skipMigrations bool) error {
+	logger.Info("Starting Golang API REST application")
+
+	logger.Info("Configuring application logging")
+	logrus.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	logrus.SetLevel(logrus.DebugLevel)
+	logrus.AddHook(infrastructure.NewRedactionHook())
+
+	gin.SetMode(gin.ReleaseMode)
+	logger.Info("Gin mode set to release")
+
+	db, err := openDatabase()
+	if err != nil {
+		return err
+	}
+
+	if !skipMigrations {
+		logger.Info("Running database migrations")
+		if err := infrastructure.RunMigrations(db); err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Failed to run database migrations")
+			return err
+		}
+		logger.Info("Database migrations completed successfully")
+	}
+
+	if err := domain.ValidateSKUPattern(cfg.Product.SKUPattern); err != nil {
+		return fmt.Errorf("invalid PRODUCT_SKU_PATTERN: %w", err)
+	}
+	ids, err := domain.NewIDGenerator(cfg.App.IDVersion)
+	if err != nil {
+		return fmt.Errorf("invalid APP_ID_VERSION: %w", err)
+	}
+
+	logger.Info("Initializing repositories and services")
+	userRepo := infrastructure.NewPostgresUserRepository(db)
+	userService := application.NewUserService(userRepo).
+		WithIDGenerator(ids).
+		WithPasswordMaxAge(cfg.Auth.PasswordMaxAge).
+		WithDeletionGrace(cfg.Retention.AccountDeletionGrace).
+		WithLockout(infrastructure.NewPostgresFailedLoginRepository(db), domain.LockoutPolicy{
+			Threshold: cfg.Auth.LockoutThreshold,
+			Window:    cfg.Auth.LockoutWindow,
+			Duration:  cfg.Auth.LockoutDuration,
+			IPLimit:   cfg.Auth.LockoutIPLimit,
+		})
+	tokenService := application.NewTokenService(cfg.JWT.Secret, cfg.JWT.TTL).
+		WithIDGenerator(ids).
+		WithRefreshTokens(infrastructure.NewPostgresRefreshTokenRepository(db), cfg.JWT.RefreshTTL).
+		WithRevocations(infrastructure.NewPostgresRevokedTokenRepository(db))
+
+	// Every integration calls out through the same client, so its retries,
+	// circuits and counters see all the traffic to a host.
+	httpClient := infrastructure.NewHTTPClient(infrastructure.DefaultHTTPClientConfig())
+
+	productRepo := infrastructure.NewPostgresProductRepository(db).WithIDGenerator(ids)
+	var relatedProducts domain.RelatedProductsStrategy = infrastructure.NewPostgresSimilarProducts(db)
+	if cfg.Product.RelatedCacheTTL > 0 {
+		relatedProducts = infrastructure.NewCachedRelatedProducts(relatedProducts, cfg.Product.RelatedCacheTTL, cfg.Cache.MaxEntries)
+	}
+	categoryRepo := infrastructure.NewPostgresCategoryRepository(db)
+	categoryService := application.NewCategoryService(categoryRepo).WithIDGenerator(ids)
+	productService := application.NewProductService(productRepo).WithIDGenerator(ids).WithCategories(categoryRepo).WithSKUPattern(cfg.Product.SKUPattern).WithLowStockThreshold(cfg.Product.LowStockThreshold).WithRelatedProducts(relatedProducts).WithImports(infrastructure.NewPostgresProductImportRepository(db))
+	var fileStorage domain.FileStorage
+	switch cfg.Storage.Driver {
+	case domain.FileStorageS3:
+		fileStorage, err = infrastructure.NewS3FileStorage(httpClient, cfg.Storage.S3Endpoint, cfg.Storage.S3Region, cfg.Storage.S3Bucket, cfg.Storage.S3AccessKeyID, cfg.Storage.S3SecretAccessKey, cfg.Storage.S3PathStyle)
+	default:
+		signingKey := cfg.Storage.SigningKey
+		if signingKey == "" {
+			signingKey = cfg.JWT.Secret
+		}
+		fileStorage, err = infrastructure.NewLocalFileStorage(cfg.Storage.LocalDir, cfg.Storage.PublicURL+api.APIVersion+api.FilesPrefix, []byte(signingKey))
+	}
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"driver": cfg.Storage.Driver,
+		}).Error("Failed to set up file storage")
+		return err
+	}
+	attachmentService := application.NewAttachmentService(infrastructure.NewPostgresAttachmentRepository(db), productRepo, fileStorage).WithIDGenerator(ids).WithMaxImageSize(cfg.Storage.MaxImageSize).WithSignedURLTTL(cfg.Storage.SignedURLTTL)
+
+	projectRepo := infrastructure.NewPostgresProjectRepository(db)
+	projectItemRepo := infrastructure.NewPostgresProjectItemRepository(db).WithIDGenerator(ids)
+	customFieldRepo := infrastructure.NewPostgresCustomFieldRepository(db)
+	customFieldService := application.NewCustomFieldService(customFieldRepo, projectRepo).WithIDGenerator(ids)
+
+	watchRepo := infrastructure.NewPostgresWatchRepository(db)
+	notificationRepo := infrastructure.NewPostgresNotificationRepository(db)
+	pushService := application.NewPushService(infrastructure.NewPostgresDeviceRepository(db)).WithIDGenerator(ids)
+	if cfg.Push.FCMCredentialsFile != "" {
+		sender, err := infrastructure.NewFCMSender(cfg.Push.FCMCredentialsFile, httpClient)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Failed to set up FCM push")
+			return err
+		}
+		pushService.WithSender(domain.PushPlatformFCM, sender)
+	}
+	if cfg.Push.APNSKeyFile != "" {
+		sender, err := infrastructure.NewAPNSSender(infrastructure.APNSConfig{
+			KeyFile:    cfg.Push.APNSKeyFile,
+			KeyID:      cfg.Push.APNSKeyID,
+			TeamID:     cfg.Push.APNSTeamID,
+			Topic:      cfg.Push.APNSTopic,
+			Production: cfg.Push.APNSProduction,
+		}, httpClient)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Failed to set up APNS push")
+			return err
+		}
+		pushService.WithSender(domain.PushPlatformAPNS, sender)
+	}
+	watchService := application.NewWatchService(watchRepo, notificationRepo, projectRepo, projectItemRepo).WithIDGenerator(ids).WithDispatcher(pushService).WithDueSoonWindow(cfg.Push.DueSoonWindow)
+
+	chatService := application.NewChatService(infrastructure.NewPostgresChatConnectorRepository(db), projectRepo).WithIDGenerator(ids).WithPoster(infrastructure.NewWebhookChatPoster(httpClient))
+
+	projectService := application.NewProjectService(projectRepo).WithIDGenerator(ids).WithNotifier(watchService).WithChatNotifier(chatService).WithProgressMode(cfg.Project.ProgressMode).WithCustomFields(customFieldRepo)
+
+	expenseRepo := infrastructure.NewPostgresExpenseRepository(db)
+	expenseService := application.NewExpenseService(expenseRepo, projectRepo).WithIDGenerator(ids)
+
+	projectItemService := application.NewProjectItemService(projectItemRepo).WithIDGenerator(ids).WithNotifier(watchService).WithChatNotifier(chatService).WithCustomFields(customFieldRepo)
+	commentService := application.NewCommentService(infrastructure.NewPostgresCommentRepository(db), projectItemRepo).WithIDGenerator(ids).WithNotifier(watchService)
+
+	couponRepo := infrastructure.NewPostgresCouponRepository(db)
+	couponService := application.NewCouponService(couponRepo, productRepo).WithIDGenerator(ids)
+
+	warehouseRepo := infrastructure.NewPostgresWarehouseRepository(db)
+	warehouseService := application.NewWarehouseService(warehouseRepo, productRepo).WithIDGenerator(ids)
+
+	stockAdjustmentRepo := infrastructure.NewPostgresStockAdjustmentRepository(db)
+	stockAdjustmentService := application.NewStockAdjustmentService(stockAdjustmentRepo, productRepo, warehouseRepo).WithIDGenerator(ids)
+	stockAlertService := application.NewStockAlertService(productRepo, userRepo, notificationRepo).WithIDGenerator(ids).WithDispatcher(pushService).WithChatNotifier(chatService).WithLowStockThreshold(cfg.Product.LowStockThreshold)
+
+	purchaseOrderRepo := infrastructure.NewPostgresPurchaseOrderRepository(db).WithIDGenerator(ids)
+	purchaseOrderService := application.NewPurchaseOrderService(purchaseOrderRepo, productRepo, warehouseRepo).WithIDGenerator(ids)
+	orderRepo := infrastructure.NewPostgresOrderRepository(db).WithIDGenerator(ids)
+	orderService := application.NewOrderService(orderRepo, productRepo, warehouseRepo).WithCoupons(couponRepo).WithIDGenerator(ids)
+	projectExportRepo := infrastructure.NewPostgresProjectExportRepository(db)
+	projectExportService := application.NewProjectExportService(projectExportRepo, projectRepo, projectItemRepo, expenseRepo, userRepo).WithIDGenerator(ids)
+	savedFilterRepo := infrastructure.NewPostgresSavedFilterRepository(db)
+	savedFilterService := application.NewSavedFilterService(savedFilterRepo).WithIDGenerator(ids)
+	reportService := application.NewReportService(infrastructure.NewPostgresReportRepository(db))
+	dashboardService := application.NewDashboardService(infrastructure.NewPostgresDashboardRepository(db), projectItemRepo, notificationRepo, productRepo).WithLowStockThreshold(cfg.Product.LowStockThreshold)
+	emailRenderer, err := infrastructure.NewTemplateEmailRenderer()
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to load email templates")
+		return err
+	}
+	emailTemplateService := application.NewEmailTemplateService(emailRenderer)
+	reportSubscriptionService := application.NewReportSubscriptionService(infrastructure.NewPostgresReportSubscriptionRepository(db), reportService).WithIDGenerator(ids).WithUsers(userRepo)
+	if cfg.Mail.SMTPHost != "" {
+		mailer := infrastructure.NewSMTPMailer(cfg.Mail.SMTPHost, cfg.Mail.SMTPPort, cfg.Mail.Username, cfg.Mail.Password, cfg.Mail.From)
+		reportSubscriptionService.WithMailer(mailer, emailRenderer)
+		userService.WithPasswordResets(infrastructure.NewPostgresPasswordResetRepository(db), mailer, emailRenderer, cfg.Auth.PasswordResetTTL, cfg.Auth.PasswordResetURL)
+	} else {
+		logger.Warn("SMTP_HOST is not set, password reset is disabled")
+	}
+	retentionService := application.NewRetentionService(infrastructure.NewPostgresRetentionRepository(db)).WithIDGenerator(ids)
+	userExportService := application.NewUserExportService(infrastructure.NewPostgresUserExportRepository(db)).WithIDGenerator(ids).WithTTL(cfg.Retention.UserExportTTL)
+	policyService := application.NewPolicyService(infrastructure.NewPostgresPolicyRepository(db)).WithIDGenerator(ids).WithEnforcement(cfg.Policy.Enforce)
+	calendarService := application.NewCalendarService(infrastructure.NewPostgresCalendarRepository(db), userRepo)
+	exchangeRateService := application.NewExchangeRateService(infrastructure.NewPostgresExchangeRateRepository(db)).WithIDGenerator(ids).WithBase(cfg.Currency.Base).WithStaleAfter(cfg.Currency.StaleAfter)
+	if cfg.Currency.RateProvider != "" {
+		provider := domain.ExchangeRateProvider(cfg.Currency.RateProvider)
+		fetcher, err := infrastructure.NewHTTPExchangeRateFetcher(httpClient, provider, cfg.Currency.RateURL, cfg.Currency.RateAPIKey)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Failed to set up exchange rate provider")
+			return err
+		}
+		exchangeRateService.WithFetcher(provider, fetcher)
+	}
+	if len(cfg.OAuth.Providers) > 0 {
+		oauthClients := make(map[domain.OAuthProvider]domain.OAuthClient)
+		for name, settings := range cfg.OAuth.Providers {
+			provider := domain.OAuthProvider(name)
+			client, err := infrastructure.NewHTTPOAuthClient(httpClient, provider, settings.ClientID, settings.ClientSecret, cfg.OAuth.RedirectBaseURL+api.OAuthCallbackPath(provider))
+			if err != nil {
+				logger.WithFields(logrus.Fields{
+					"error": err.Error(),
+				}).Error("Failed to set up OAuth provider")
+				return err
+			}
+			oauthClients[provider] = client
+		}
+		userService.WithOAuth(infrastructure.NewPostgresUserIdentityRepository(db), oauthClients)
+	}
+	logger.Info("Repositories and services initialized successfully")
+
+	if cfg.Bootstrap.AdminEmail != "" {
+		admin, created, err := userService.EnsureAdmin(context.Background(), cfg.Bootstrap.AdminName, cfg.Bootstrap.AdminEmail, cfg.Bootstrap.AdminPassword)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Failed to bootstrap admin user")
+			return err
+		}
+		if created {
+			logger.WithFields(logrus.Fields{
+				"user_id": admin.ID,
+				"email":   admin.Email,
+			}).Info("Bootstrap admin user created")
+		}
+	}
+
+	logger.Info("Setting up application router")
+	router, err := api.NewRouter().WithMaxPageSize(cfg.Server.MaxPageSize).WithTrustedProxies(cfg.Server.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("invalid SERVER_TRUSTED_PROXIES: %w", err)
+	}
+	if cfg.Cache.TTL > 0 {
+		var cache domain.ResponseCache = infrastructure.NewMemoryResponseCache(cfg.Cache.MaxEntries)
+		if cfg.Cache.Store == domain.ResponseCacheStoreRedis {
+			redisCache, err := infrastructure.NewRedisResponseCache(cfg.Cache.RedisURL)
+			if err != nil {
+				return err
+			}
+			if err := redisCache.Ping(context.Background()); err != nil {
+				logger.WithFields(logrus.Fields{
+					"error": err.Error(),
+				}).Warn("Redis is not reachable, responses are not cached until it is")
+			}
+			defer redisCache.Close()
+			cache = redisCache
+		}
+		logger.WithFields(logrus.Fields{
+			"ttl":         cfg.Cache.TTL,
+			"max_entries": cfg.Cache.MaxEntries,
+			"store":       cfg.Cache.Store,
+		}).Info("Response cache enabled")
+		router.WithResponseCache(cache, cfg.Cache.TTL)
+	}
+	if cfg.RateLimit.IPPerMinute > 0 || cfg.RateLimit.UserPerMinute > 0 {
+		var store domain.RateLimitStore = infrastructure.NewMemoryRateLimitStore()
+		if cfg.RateLimit.Store == domain.RateLimitStoreRedis {
+			redisStore, err := infrastructure.NewRedisRateLimitStore(cfg.RateLimit.RedisURL)
+			if err != nil {
+				return err
+			}
+			if err := redisStore.Ping(context.Background()); err != nil {
+				logger.WithFields(logrus.Fields{
+					"error": err.Error(),
+				}).Warn("Redis is not reachable, requests go through unlimited until it is")
+			}
+			defer redisStore.Close()
+			store = redisStore
+		}
+		logger.WithFields(logrus.Fields{
+			"store":           cfg.RateLimit.Store,
+			"ip_per_minute":   cfg.RateLimit.IPPerMinute,
+			"user_per_minute": cfg.RateLimit.UserPerMinute,
+		}).Info("Rate limiting enabled")
+		router.WithRateLimit(store,
+			domain.RateLimit{PerMinute: cfg.RateLimit.IPPerMinute, Burst: cfg.RateLimit.IPBurst},
+			domain.RateLimit{PerMinute: cfg.RateLimit.UserPerMinute, Burst: cfg.RateLimit.UserBurst})
+	}
+	if cfg.Idempotency.TTL > 0 {
+		var store domain.IdempotencyStore = infrastructure.NewPostgresIdempotencyStore(db)
+		if cfg.Idempotency.Store == domain.IdempotencyStoreRedis {
+			redisStore, err := infrastructure.NewRedisIdempotencyStore(cfg.Idempotency.RedisURL)
+			if err != nil {
+				return err
+			}
+			if err := redisStore.Ping(context.Background()); err != nil {
+				logger.WithFields(logrus.Fields{
+					"error": err.Error(),
+				}).Warn("Redis is not reachable, Idempotency-Key is ignored until it is")
+			}
+			defer redisStore.Close()
+			store = redisStore
+		}
+		logger.WithFields(logrus.Fields{
+			"store": cfg.Idempotency.Store,
+			"ttl":   cfg.Idempotency.TTL,
+		}).Info("Idempotency keys enabled")
+		router.WithIdempotency(store, cfg.Idempotency.TTL)
+	}
+	if cfg.Server.FaultInjection {
+		if cfg.App.Env == "production" {
+			return errors.New("refusing to enable fault injection while APP_ENV is production")
+		}
+		logger.Warn("Fault injection enabled; admins can make routes slow or fail through /v1/admin/faults")
+		router.WithFaultInjection(application.NewFaultService().WithIDGenerator(ids))
+	}
+	router.WithOutboundStats(httpClient)
+	router.SetupRoutes(userService, tokenService, productService, categoryService, attachmentService, projectService, projectItemService, commentService, couponService, stockAdjustmentService, warehouseService, purchaseOrderService, orderService, expenseService, watchService, projectExportService, customFieldService, savedFilterService, reportService, dashboardService, reportSubscriptionService, retentionService, userExportService, policyService, emailTemplateService, pushService, chatService, calendarService, exchangeRateService)
+	r := router.GetEngine()
+	logger.Info("Router setup completed")
+
+	port := cfg.Server.Port
+
+	logger.WithFields(logrus.Fields{
+		"port":                port,
+		"read_timeout":        cfg.Server.ReadTimeout,
+		"read_header_timeout": cfg.Server.ReadHeaderTimeout,
+		"write_timeout":       cfg.Server.WriteTimeout,
+		"idle_timeout":        cfg.Server.IdleTimeout,
+		"max_header_bytes":    cfg.Server.MaxHeaderBytes,
+		"keep_alive":          cfg.Server.KeepAlive,
+		"h2c":                 cfg.Server.H2C,
+	}).Info("Starting HTTP server")
+
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(cfg.Server.H2C)
+
+	srv := &http.Server{
+		Addr:              ":" + port,
+		Handler:           r,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+		Protocols:         protocols,
+	}
+	srv.SetKeepAlivesEnabled(cfg.Server.KeepAlive)
+
+	go func() {
+		logger.Info("HTTP server starting")
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Fatal("HTTP server failed to start")
+		}
+	}()
+
+	logger.Info("HTTP server started successfully")
+
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	if cfg.Report.SchedulerInterval > 0 {
+		if cfg.Mail.SMTPHost == "" {
+			logger.Warn("SMTP_HOST is not set, scheduled report deliveries will be recorded as failed")
+		}
+		go reportSubscriptionService.RunScheduler(schedulerCtx, cfg.Report.SchedulerInterval)
+	}
+	if cfg.Retention.Interval > 0 {
+		go retentionService.RunScheduler(schedulerCtx, cfg.Retention.Interval)
+	}
+	if cfg.Push.DueReminderInterval > 0 {
+		go watchService.RunScheduler(schedulerCtx, cfg.Push.DueReminderInterval)
+	}
+	if cfg.Product.LowStockCheckInterval > 0 {
+		go stockAlertService.RunScheduler(schedulerCtx, cfg.Product.LowStockCheckInterval)
+	}
+	if cfg.Currency.RateProvider != "" && cfg.Currency.SyncInterval > 0 {
+		go exchangeRateService.RunScheduler(schedulerCtx, cfg.Currency.SyncInterval)
+	}
+	go userExportService.RunCleanup(schedulerCtx, domain.UserExportCleanupInterval)
+	go userService.RunErasure(schedulerCtx, domain.AccountErasureInterval)
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("Shutdown signal received, starting shutdown")
+	stopScheduler()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	logger.Info("Shutting down HTTP server")
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Server forced to shutdown")
+		return err
+	}
+
+	logger.Info("Server exited")
+	return nil
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edumes/golang-api-rest/pkg/client"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+type smokeStep struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func newSmokeCommand() *cobra.Command {
+	var baseURL, email, password string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "smoke",
+		Short: "Run a scripted end-to-end check against a live deployment",
+		Long:  "Run a scripted sequence against a live deployment: readiness, admin login, user registration and login, category and product create/list/delete, project and item create/delete. Each step is reported as PASS, FAIL or SKIP and the command exits non-zero if any step fails, so it can gate a deploy. Everything it creates is deleted again.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if email == "" || password == "" {
+				return errors.New("--email and --password of an existing account are required")
+			}
+
+			admin := client.New(baseURL, client.WithRetries(2, 500*time.Millisecond))
+			tag := uuid.NewString()[:8]
+
+			var user *client.User
+			var category *client.Category
+			var product *client.Product
+			var project *client.Project
+			var item *client.ProjectItem
+			userEmail := "smoke-" + tag + "@example.com"
+			userPassword := "smoke-" + uuid.NewString()
+
+			steps := []smokeStep{
+				{"readiness", func(ctx context.Context) error {
+					return admin.Ready(ctx)
+				}},
+				{"login", func(ctx context.Context) error {
+					_, err := admin.Login(ctx, email, password)
+					return err
+				}},
+				{"register user", func(ctx context.Context) error {
+					var err error
+					user, err = admin.Users.Create(ctx, client.CreateUserRequest{Name: "Smoke " + tag, Email: userEmail, Password: userPassword})
+					return err
+				}},
+				{"login as new user", func(ctx context.Context) error {
+					_, err := client.New(baseURL).Login(ctx, userEmail, userPassword)
+					return err
+				}},
+				{"create category", func(ctx context.Context) error {
+					var err error
+					category, err = admin.Categories.Create(ctx, client.CategoryRequest{Name: "Smoke " + tag})
+					return err
+				}},
+				{"create product", func(ctx context.Context) error {
+					var err error
+					product, err = admin.Products.Create(ctx, client.CreateProductRequest{Name: "Smoke product " + tag, Price: 9.99, Stock: 3, CategoryID: &category.ID, SKU: "SMOKE-" + tag})
+					return err
+				}},
+				{"list products with filters", func(ctx context.Context) error {
+					products, err := admin.Products.List(ctx, client.ListOptions{Limit: 10, Filters: map[string]string{"sku": product.SKU, "category_id": category.ID.String()}})
+					if err != nil {
+						return err
+					}
+					for _, p := range products {
+						if p.ID == product.ID {
+							return nil
+						}
+					}
+					return fmt.Errorf("product %s not returned by filtered list", product.ID)
+				}},
+				{"create project", func(ctx context.Context) error {
+					var err error
+					project, err = admin.Projects.Create(ctx, client.CreateProjectRequest{Name: "Smoke project " + tag, OwnerID: user.ID})
+					return err
+				}},
+				{"create project item", func(ctx context.Context) error {
+					var err error
+					item, err = admin.ProjectItems.Create(ctx, client.CreateProjectItemRequest{ProjectID: project.ID, Name: "Smoke item " + tag, AssignedTo: &user.ID})
+					return err
+				}},
+				{"list project items", func(ctx context.Context) error {
+					items, err := admin.ProjectItems.ListByProject(ctx, project.ID)
+					if err != nil {
+						return err
+					}
+					if len(items) != 1 || items[0].ID != item.ID {
+						return fmt.Errorf("expected item %s for project %s, got %d items", item.ID, project.ID, len(items))
+					}
+					return nil
+				}},
+			}
+
+			cleanup := []smokeStep{
+				{"delete project item", func(ctx context.Context) error {
+					return deleteIfCreated(item != nil, func() error { return admin.ProjectItems.Delete(ctx, item.ID) })
+				}},
+				{"delete project", func(ctx context.Context) error {
+					return deleteIfCreated(project != nil, func() error { return admin.Projects.Delete(ctx, project.ID) })
+				}},
+				{"delete product", func(ctx context.Context) error {
+					return deleteIfCreated(product != nil, func() error { return admin.Products.Delete(ctx, product.ID) })
+				}},
+				{"delete category", func(ctx context.Context) error {
+					return deleteIfCreated(category != nil, func() error { return admin.Categories.Delete(ctx, category.ID) })
+				}},
+				{"delete user", func(ctx context.Context) error {
+					return deleteIfCreated(user != nil, func() error { return admin.Users.Delete(ctx, user.ID) })
+				}},
+			}
+
+			out := cmd.OutOrStdout()
+			failed := 0
+			report := func(step smokeStep, skip bool) bool {
+				if skip {
+					fmt.Fprintf(out, "SKIP  %s\n", step.name)
+					return false
+				}
+				ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+				defer cancel()
+
+				start := time.Now()
+				err := step.run(ctx)
+				if errors.Is(err, errNothingToDelete) {
+					fmt.Fprintf(out, "SKIP  %s\n", step.name)
+					return true
+				}
+				if err != nil {
+					failed++
+					fmt.Fprintf(out, "FAIL  %s (%s): %v\n", step.name, time.Since(start).Round(time.Millisecond), err)
+					return false
+				}
+				fmt.Fprintf(out, "PASS  %s (%s)\n", step.name, time.Since(start).Round(time.Millisecond))
+				return true
+			}
+
+			ok := true
+			for _, step := range steps {
+				ok = report(step, !ok) && ok
+			}
+			for _, step := range cleanup {
+				report(step, false)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d smoke steps failed against %s", failed, baseURL)
+			}
+			fmt.Fprintf(out, "all smoke steps passed against %s\n", baseURL)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&baseURL, "base-url", "http://localhost:8080", "Base URL of the deployment under test")
+	cmd.Flags().StringVar(&email, "email", "", "Email of an existing account allowed to create users")
+	cmd.Flags().StringVar(&password, "password", "", "Password of that account")
+	cmd.Flags().DurationVar(&timeout, "step-timeout", 10*time.Second, "Timeout applied to each step")
+
+	return cmd
+}
+
+var errNothingToDelete = errors.New("nothing to delete")
+
+func deleteIfCreated(created bool, del func() error) error {
+	if !created {
+		return errNothingToDelete
+	}
+	return del()
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/application"
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/edumes/golang-api-rest/internal/infrastructure"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func newUserCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage user accounts",
+	}
+
+	cmd.AddCommand(newCreateAdminCommand())
+
+	return cmd
+}
+
+func newCreateAdminCommand() *cobra.Command {
+	var name, email, password string
+	var promote bool
+
+	cmd := &cobra.Command{
+		Use:   "create-admin",
+		Short: "Create a user with the admin role",
+		Long:  "Create a user with the admin role. When --password is omitted it is read from the first line of stdin, keeping it out of the shell history.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if email == "" {
+				return errors.New("--email is required")
+			}
+
+			if password == "" {
+				line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil && line == "" {
+					return errors.New("--password is required (or pass it on stdin)")
+				}
+				password = strings.TrimRight(line, "\r\n")
+			}
+
+			db, err := openDatabase()
+			if err != nil {
+				return err
+			}
+			if err := infrastructure.RunMigrations(db); err != nil {
+				return err
+			}
+
+			userService := application.NewUserService(infrastructure.NewPostgresUserRepository(db))
+			ctx := cmd.Context()
+
+			if existing, err := userService.GetUserByEmail(ctx, email); err == nil {
+				if existing.Role == domain.RoleAdmin {
+					fmt.Fprintf(cmd.OutOrStdout(), "user %s is already an admin (id %s)\n", existing.Email, existing.ID)
+					return nil
+				}
+				if !promote {
+					return fmt.Errorf("user %s already exists; use --promote to grant the admin role", email)
+				}
+				if err := userService.PromoteToAdmin(ctx, existing); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "promoted %s to admin (id %s)\n", existing.Email, existing.ID)
+				return nil
+			}
+
+			user, err := userService.CreateUserWithRole(ctx, name, email, password, domain.RoleAdmin)
+			if err != nil {
+				return err
+			}
+
+			logger.WithFields(logrus.Fields{
+				"user_id": user.ID,
+				"email":   user.Email,
+			}).Info("Admin user created successfully")
+			fmt.Fprintf(cmd.OutOrStdout(), "created admin %s (id %s)\n", user.Email, user.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "Administrator", "Display name of the admin user")
+	cmd.Flags().StringVar(&email, "email", "", "Email address of the admin user")
+	cmd.Flags().StringVar(&password, "password", "", "Password of the admin user (read from stdin when omitted)")
+	cmd.Flags().BoolVar(&promote, "promote", false, "Grant the admin role if a user with this email already exists")
+
+	return cmd
+}
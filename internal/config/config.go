@@ -0,0 +1,769 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const redactedValue = "********"
+
+type Config struct {
+	App         AppConfig         `yaml:"app"`
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	JWT         JWTConfig         `yaml:"jwt"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Bootstrap   BootstrapConfig   `yaml:"bootstrap"`
+	Product     ProductConfig     `yaml:"product"`
+	Project     ProjectConfig     `yaml:"project"`
+	Mail        MailConfig        `yaml:"mail"`
+	Report      ReportConfig      `yaml:"report"`
+	Retention   RetentionConfig   `yaml:"retention"`
+	Policy      PolicyConfig      `yaml:"policy"`
+	Cache       CacheConfig       `yaml:"cache"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	Push        PushConfig        `yaml:"push"`
+	Currency    CurrencyConfig    `yaml:"currency"`
+	OAuth       OAuthConfig       `yaml:"oauth"`
+	Storage     StorageConfig     `yaml:"storage"`
+}
+
+type AppConfig struct {
+	Env       string `yaml:"env"`
+	Timezone  string `yaml:"timezone"`
+	IDVersion string `yaml:"id_version"`
+}
+
+type ServerConfig struct {
+	Port              string        `yaml:"port"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes"`
+	KeepAlive         bool          `yaml:"keep_alive"`
+	H2C               bool          `yaml:"h2c"`
+	// MaxPageSize is the largest limit list endpoints accept.
+	MaxPageSize int `yaml:"max_page_size"`
+	// FaultInjection exposes the admin routes that make other routes slow
+	// or fail on purpose, for resilience testing. It is refused in
+	// production.
+	FaultInjection bool `yaml:"fault_injection"`
+	// TrustedProxies are the addresses or CIDR ranges of the proxies
+	// allowed to name the client address in X-Forwarded-For and X-Real-IP.
+	// None are trusted by default, so the address is the peer's.
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password" secret:"true"`
+	Name     string `yaml:"name"`
+	SSLMode  string `yaml:"sslmode"`
+}
+
+// JWTConfig holds the token lifetimes. A zero RefreshTTL disables refresh
+// tokens.
+type JWTConfig struct {
+	Secret     string        `yaml:"secret" secret:"true"`
+	TTL        time.Duration `yaml:"ttl"`
+	RefreshTTL time.Duration `yaml:"refresh_ttl"`
+}
+
+// AuthConfig holds the login policy. A zero PasswordMaxAge disables password
+// expiry. PasswordResetURL is the page of the client where reset tokens are
+// used; without it reset emails carry the bare token. LockoutThreshold
+// failed logins of an account within LockoutWindow lock it for
+// LockoutDuration, and LockoutIPLimit failed logins from one address within
+// the window throttle it; zero turns either off.
+type AuthConfig struct {
+	PasswordMaxAge   time.Duration `yaml:"password_max_age"`
+	PasswordResetTTL time.Duration `yaml:"password_reset_ttl"`
+	PasswordResetURL string        `yaml:"password_reset_url"`
+	LockoutThreshold int           `yaml:"lockout_threshold"`
+	LockoutWindow    time.Duration `yaml:"lockout_window"`
+	LockoutDuration  time.Duration `yaml:"lockout_duration"`
+	LockoutIPLimit   int           `yaml:"lockout_ip_limit"`
+}
+
+type BootstrapConfig struct {
+	AdminName     string `yaml:"admin_name"`
+	AdminEmail    string `yaml:"admin_email"`
+	AdminPassword string `yaml:"admin_password" secret:"true"`
+}
+
+type ProductConfig struct {
+	SKUPattern string `yaml:"sku_pattern"`
+	// LowStockThreshold is the stock level at or below which products
+	// without a reorder level of their own are low on stock.
+	LowStockThreshold int `yaml:"low_stock_threshold"`
+	// LowStockCheckInterval is how often products that dropped to their
+	// level are announced; zero disables the checker.
+	LowStockCheckInterval time.Duration `yaml:"low_stock_check_interval"`
+	// RelatedCacheTTL is how long related product recommendations are
+	// reused; zero disables the cache.
+	RelatedCacheTTL time.Duration `yaml:"related_cache_ttl"`
+}
+
+type ProjectConfig struct {
+	ProgressMode string `yaml:"progress_mode"`
+}
+
+// MailConfig points at the SMTP server outgoing email is sent through. An
+// empty SMTPHost disables email.
+type MailConfig struct {
+	SMTPHost string `yaml:"smtp_host"`
+	SMTPPort string `yaml:"smtp_port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password" secret:"true"`
+	From     string `yaml:"from"`
+}
+
+// ReportConfig controls scheduled report delivery. SchedulerInterval is how
+// often due subscriptions are looked for; zero disables the scheduler.
+type ReportConfig struct {
+	SchedulerInterval time.Duration `yaml:"scheduler_interval"`
+}
+
+// RetentionConfig controls the retention job. Interval is how often each
+// enabled rule is applied; zero disables the job, leaving manual runs.
+// UserExportTTL is how long a user's data export stays downloadable and
+// AccountDeletionGrace how long a deleted account can be restored.
+type RetentionConfig struct {
+	Interval             time.Duration `yaml:"interval"`
+	UserExportTTL        time.Duration `yaml:"user_export_ttl"`
+	AccountDeletionGrace time.Duration `yaml:"account_deletion_grace"`
+}
+
+// PolicyConfig controls the terms of service. With Enforce set, users are
+// refused with 426 until they accept the latest version of every policy.
+type PolicyConfig struct {
+	Enforce bool `yaml:"enforce"`
+}
+
+// PushConfig controls mobile push notifications. FCM is enabled by a
+// service account key file and APNS by a .p8 signing key with its key ID,
+// team ID and the app's bundle ID as topic; without either, notifications
+// stay in the in-app inbox. DueReminderInterval is how often items due
+// within DueSoonWindow are looked for; zero disables the reminders.
+type PushConfig struct {
+	FCMCredentialsFile  string        `yaml:"fcm_credentials_file"`
+	APNSKeyFile         string        `yaml:"apns_key_file"`
+	APNSKeyID           string        `yaml:"apns_key_id"`
+	APNSTeamID          string        `yaml:"apns_team_id"`
+	APNSTopic           string        `yaml:"apns_topic"`
+	APNSProduction      bool          `yaml:"apns_production"`
+	DueSoonWindow       time.Duration `yaml:"due_soon_window"`
+	DueReminderInterval time.Duration `yaml:"due_reminder_interval"`
+}
+
+// CurrencyConfig controls the exchange rate job. Base is the store currency
+// prices are kept in. An empty RateProvider disables the job; RateURL
+// overrides the provider's public endpoint, for instance to point at a
+// self-hosted Frankfurter. SyncInterval is how often the rates are synced,
+// zero leaving manual refreshes only, and StaleAfter how old they may get
+// before the metrics flag them.
+type CurrencyConfig struct {
+	Base         string        `yaml:"base"`
+	RateProvider string        `yaml:"rate_provider"`
+	RateURL      string        `yaml:"rate_url"`
+	RateAPIKey   string        `yaml:"rate_api_key" secret:"true"`
+	SyncInterval time.Duration `yaml:"sync_interval"`
+	StaleAfter   time.Duration `yaml:"stale_after"`
+}
+
+// OAuthConfig enables sign-in with the providers in Providers, keyed by
+// domain.OAuthProvider; a provider is listed when its client ID is set.
+// RedirectBaseURL is the public URL of the API, which providers send users
+// back to.
+type OAuthConfig struct {
+	RedirectBaseURL string                         `yaml:"redirect_base_url"`
+	Providers       map[string]OAuthProviderConfig `yaml:"providers"`
+}
+
+// OAuthProviderConfig holds the credentials of the app registered with a
+// provider.
+type OAuthProviderConfig struct {
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret" secret:"true"`
+}
+
+// StorageConfig chooses where uploaded files are kept. Driver "local" writes
+// them under LocalDir and serves them from the API itself, at download
+// links signed with SigningKey (APP_JWT_SECRET when empty) that start with
+// PublicURL, the API's public URL, or with the origin of the request when
+// it is empty. Driver "s3" keeps them in S3Bucket of Amazon S3 or of the
+// S3-compatible service at S3Endpoint, and download links are presigned
+// URLs of the bucket. SignedURLTTL is how long a download link works and
+// MaxImageSize the largest image accepted, in bytes.
+type StorageConfig struct {
+	Driver            string        `yaml:"driver"`
+	LocalDir          string        `yaml:"local_dir"`
+	PublicURL         string        `yaml:"public_url"`
+	SigningKey        string        `yaml:"signing_key" secret:"true"`
+	S3Endpoint        string        `yaml:"s3_endpoint"`
+	S3Region          string        `yaml:"s3_region"`
+	S3Bucket          string        `yaml:"s3_bucket"`
+	S3AccessKeyID     string        `yaml:"s3_access_key_id"`
+	S3SecretAccessKey string        `yaml:"s3_secret_access_key" secret:"true"`
+	S3PathStyle       bool          `yaml:"s3_path_style"`
+	SignedURLTTL      time.Duration `yaml:"signed_url_ttl"`
+	MaxImageSize      int64         `yaml:"max_image_size"`
+}
+
+// CacheConfig controls the GET response cache. A zero TTL disables it.
+// Store is "memory", where MaxEntries bounds how many responses each
+// instance keeps, or "redis", shared by every instance in the server at
+// RedisURL.
+type CacheConfig struct {
+	TTL        time.Duration `yaml:"ttl"`
+	MaxEntries int           `yaml:"max_entries"`
+	Store      string        `yaml:"store"`
+	RedisURL   string        `yaml:"redis_url" secret:"true"`
+}
+
+// RateLimitConfig limits the requests to /v1 per address and per signed-in
+// user, as token buckets refilled at the per-minute rate and holding the
+// burst. A zero rate turns that limit off. Store is "memory", counting per
+// instance, or "redis", counting across instances in the server at
+// RedisURL.
+type RateLimitConfig struct {
+	IPPerMinute   int    `yaml:"ip_per_minute"`
+	IPBurst       int    `yaml:"ip_burst"`
+	UserPerMinute int    `yaml:"user_per_minute"`
+	UserBurst     int    `yaml:"user_burst"`
+	Store         string `yaml:"store"`
+	RedisURL      string `yaml:"redis_url" secret:"true"`
+}
+
+// IdempotencyConfig controls the Idempotency-Key header of POSTs. Responses
+// are replayed to retries for TTL, and a zero TTL turns the header off.
+// Store is "postgres", the application database, or "redis", the server at
+// RedisURL.
+type IdempotencyConfig struct {
+	TTL      time.Duration `yaml:"ttl"`
+	Store    string        `yaml:"store"`
+	RedisURL string        `yaml:"redis_url" secret:"true"`
+}
+
+func Load() (*Config, error) {
+	return LoadFile(".env")
+}
+
+func LoadFile(path string) (*Config, error) {
+	viper.SetConfigFile(path)
+	err := viper.ReadInConfig()
+	viper.AutomaticEnv()
+
+	return FromViper(), err
+}
+
+func FromViper() *Config {
+	viper.SetDefault("APP_ENV", "development")
+	viper.SetDefault("APP_PORT", "8080")
+	viper.SetDefault("APP_TIMEZONE", "UTC")
+	viper.SetDefault("APP_ID_VERSION", domain.DefaultIDVersion)
+	viper.SetDefault("SERVER_READ_TIMEOUT", "15s")
+	viper.SetDefault("SERVER_READ_HEADER_TIMEOUT", "5s")
+	viper.SetDefault("SERVER_WRITE_TIMEOUT", "30s")
+	viper.SetDefault("SERVER_IDLE_TIMEOUT", "60s")
+	viper.SetDefault("SERVER_SHUTDOWN_TIMEOUT", "10s")
+	viper.SetDefault("SERVER_MAX_HEADER_BYTES", 1<<20)
+	viper.SetDefault("SERVER_KEEP_ALIVE", true)
+	viper.SetDefault("SERVER_H2C", false)
+	viper.SetDefault("SERVER_MAX_PAGE_SIZE", domain.DefaultMaxPageSize)
+	viper.SetDefault("SERVER_FAULT_INJECTION", false)
+	viper.SetDefault("APP_JWT_TTL", "24h")
+	viper.SetDefault("APP_JWT_REFRESH_TTL", domain.DefaultRefreshTokenTTL.String())
+	viper.SetDefault("AUTH_PASSWORD_MAX_AGE", "0")
+	viper.SetDefault("AUTH_PASSWORD_RESET_TTL", domain.DefaultPasswordResetTTL.String())
+	viper.SetDefault("AUTH_LOCKOUT_THRESHOLD", domain.DefaultLockoutThreshold)
+	viper.SetDefault("AUTH_LOCKOUT_WINDOW", domain.DefaultLockoutWindow.String())
+	viper.SetDefault("AUTH_LOCKOUT_DURATION", domain.DefaultLockoutDuration.String())
+	viper.SetDefault("AUTH_LOCKOUT_IP_LIMIT", domain.DefaultLoginIPLimit)
+	viper.SetDefault("BOOTSTRAP_ADMIN_NAME", "Administrator")
+	viper.SetDefault("PRODUCT_SKU_PATTERN", domain.DefaultSKUPattern)
+	viper.SetDefault("PRODUCT_LOW_STOCK_THRESHOLD", domain.DefaultLowStockThreshold)
+	viper.SetDefault("PRODUCT_LOW_STOCK_CHECK_INTERVAL", "5m")
+	viper.SetDefault("PRODUCT_RELATED_CACHE_TTL", domain.DefaultRelatedProductsCacheTTL.String())
+	viper.SetDefault("PROJECT_PROGRESS_MODE", domain.ProjectProgressByCount)
+	viper.SetDefault("SMTP_PORT", "587")
+	viper.SetDefault("REPORT_SCHEDULER_INTERVAL", "1m")
+	viper.SetDefault("RETENTION_INTERVAL", "24h")
+	viper.SetDefault("USER_EXPORT_TTL", domain.DefaultUserExportTTL.String())
+	viper.SetDefault("ACCOUNT_DELETION_GRACE", domain.DefaultAccountDeletionGrace.String())
+	viper.SetDefault("POLICY_ENFORCE", false)
+	viper.SetDefault("CACHE_TTL", "0s")
+	viper.SetDefault("CACHE_MAX_ENTRIES", 10000)
+	viper.SetDefault("CACHE_STORE", domain.ResponseCacheStoreMemory)
+	viper.SetDefault("RATE_LIMIT_IP_PER_MINUTE", domain.DefaultRateLimitIPPerMinute)
+	viper.SetDefault("RATE_LIMIT_IP_BURST", domain.DefaultRateLimitIPBurst)
+	viper.SetDefault("RATE_LIMIT_USER_PER_MINUTE", domain.DefaultRateLimitUserPerMinute)
+	viper.SetDefault("RATE_LIMIT_USER_BURST", domain.DefaultRateLimitUserBurst)
+	viper.SetDefault("RATE_LIMIT_STORE", domain.RateLimitStoreMemory)
+	viper.SetDefault("IDEMPOTENCY_TTL", domain.DefaultIdempotencyTTL.String())
+	viper.SetDefault("IDEMPOTENCY_STORE", domain.IdempotencyStorePostgres)
+	viper.SetDefault("PUSH_APNS_PRODUCTION", false)
+	viper.SetDefault("PUSH_DUE_SOON_WINDOW", domain.DefaultDueSoonWindow.String())
+	viper.SetDefault("PUSH_DUE_REMINDER_INTERVAL", "15m")
+	viper.SetDefault("CURRENCY_BASE", domain.DefaultCurrency)
+	viper.SetDefault("EXCHANGE_RATE_SYNC_INTERVAL", "1h")
+	viper.SetDefault("EXCHANGE_RATE_STALE_AFTER", domain.DefaultExchangeRateStaleAfter.String())
+	viper.SetDefault("STORAGE_DRIVER", domain.FileStorageLocal)
+	viper.SetDefault("STORAGE_LOCAL_DIR", "uploads")
+	viper.SetDefault("STORAGE_S3_REGION", "us-east-1")
+	viper.SetDefault("STORAGE_S3_PATH_STYLE", false)
+	viper.SetDefault("STORAGE_SIGNED_URL_TTL", domain.DefaultSignedURLTTL.String())
+	viper.SetDefault("STORAGE_MAX_IMAGE_SIZE", domain.DefaultMaxImageSize)
+
+	return &Config{
+		App: AppConfig{
+			Env:       viper.GetString("APP_ENV"),
+			Timezone:  viper.GetString("APP_TIMEZONE"),
+			IDVersion: viper.GetString("APP_ID_VERSION"),
+		},
+		Server: ServerConfig{
+			Port:              viper.GetString("APP_PORT"),
+			ReadTimeout:       viper.GetDuration("SERVER_READ_TIMEOUT"),
+			ReadHeaderTimeout: viper.GetDuration("SERVER_READ_HEADER_TIMEOUT"),
+			WriteTimeout:      viper.GetDuration("SERVER_WRITE_TIMEOUT"),
+			IdleTimeout:       viper.GetDuration("SERVER_IDLE_TIMEOUT"),
+			ShutdownTimeout:   viper.GetDuration("SERVER_SHUTDOWN_TIMEOUT"),
+			MaxHeaderBytes:    viper.GetInt("SERVER_MAX_HEADER_BYTES"),
+			KeepAlive:         viper.GetBool("SERVER_KEEP_ALIVE"),
+			H2C:               viper.GetBool("SERVER_H2C"),
+			MaxPageSize:       viper.GetInt("SERVER_MAX_PAGE_SIZE"),
+			FaultInjection:    viper.GetBool("SERVER_FAULT_INJECTION"),
+			TrustedProxies:    splitList(viper.GetString("SERVER_TRUSTED_PROXIES")),
+		},
+		Database: DatabaseConfig{
+			Host:     viper.GetString("DB_HOST"),
+			Port:     viper.GetString("DB_PORT"),
+			User:     viper.GetString("DB_USER"),
+			Password: viper.GetString("DB_PASSWORD"),
+			Name:     viper.GetString("DB_NAME"),
+			SSLMode:  viper.GetString("DB_SSLMODE"),
+		},
+		JWT: JWTConfig{
+			Secret:     viper.GetString("APP_JWT_SECRET"),
+			TTL:        viper.GetDuration("APP_JWT_TTL"),
+			RefreshTTL: viper.GetDuration("APP_JWT_REFRESH_TTL"),
+		},
+		Auth: AuthConfig{
+			PasswordMaxAge:   viper.GetDuration("AUTH_PASSWORD_MAX_AGE"),
+			PasswordResetTTL: viper.GetDuration("AUTH_PASSWORD_RESET_TTL"),
+			PasswordResetURL: viper.GetString("AUTH_PASSWORD_RESET_URL"),
+			LockoutThreshold: viper.GetInt("AUTH_LOCKOUT_THRESHOLD"),
+			LockoutWindow:    viper.GetDuration("AUTH_LOCKOUT_WINDOW"),
+			LockoutDuration:  viper.GetDuration("AUTH_LOCKOUT_DURATION"),
+			LockoutIPLimit:   viper.GetInt("AUTH_LOCKOUT_IP_LIMIT"),
+		},
+		Bootstrap: BootstrapConfig{
+			AdminName:     viper.GetString("BOOTSTRAP_ADMIN_NAME"),
+			AdminEmail:    viper.GetString("BOOTSTRAP_ADMIN_EMAIL"),
+			AdminPassword: viper.GetString("BOOTSTRAP_ADMIN_PASSWORD"),
+		},
+		Product: ProductConfig{
+			SKUPattern:            viper.GetString("PRODUCT_SKU_PATTERN"),
+			LowStockThreshold:     viper.GetInt("PRODUCT_LOW_STOCK_THRESHOLD"),
+			LowStockCheckInterval: viper.GetDuration("PRODUCT_LOW_STOCK_CHECK_INTERVAL"),
+			RelatedCacheTTL:       viper.GetDuration("PRODUCT_RELATED_CACHE_TTL"),
+		},
+		Project: ProjectConfig{
+			ProgressMode: viper.GetString("PROJECT_PROGRESS_MODE"),
+		},
+		Mail: MailConfig{
+			SMTPHost: viper.GetString("SMTP_HOST"),
+			SMTPPort: viper.GetString("SMTP_PORT"),
+			Username: viper.GetString("SMTP_USERNAME"),
+			Password: viper.GetString("SMTP_PASSWORD"),
+			From:     viper.GetString("SMTP_FROM"),
+		},
+		Report: ReportConfig{
+			SchedulerInterval: viper.GetDuration("REPORT_SCHEDULER_INTERVAL"),
+		},
+		Retention: RetentionConfig{
+			Interval:             viper.GetDuration("RETENTION_INTERVAL"),
+			UserExportTTL:        viper.GetDuration("USER_EXPORT_TTL"),
+			AccountDeletionGrace: viper.GetDuration("ACCOUNT_DELETION_GRACE"),
+		},
+		Policy: PolicyConfig{
+			Enforce: viper.GetBool("POLICY_ENFORCE"),
+		},
+		Cache: CacheConfig{
+			TTL:        viper.GetDuration("CACHE_TTL"),
+			MaxEntries: viper.GetInt("CACHE_MAX_ENTRIES"),
+			Store:      viper.GetString("CACHE_STORE"),
+			RedisURL:   viper.GetString("REDIS_URL"),
+		},
+		RateLimit: RateLimitConfig{
+			IPPerMinute:   viper.GetInt("RATE_LIMIT_IP_PER_MINUTE"),
+			IPBurst:       viper.GetInt("RATE_LIMIT_IP_BURST"),
+			UserPerMinute: viper.GetInt("RATE_LIMIT_USER_PER_MINUTE"),
+			UserBurst:     viper.GetInt("RATE_LIMIT_USER_BURST"),
+			Store:         viper.GetString("RATE_LIMIT_STORE"),
+			RedisURL:      viper.GetString("REDIS_URL"),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:      viper.GetDuration("IDEMPOTENCY_TTL"),
+			Store:    viper.GetString("IDEMPOTENCY_STORE"),
+			RedisURL: viper.GetString("REDIS_URL"),
+		},
+		Push: PushConfig{
+			FCMCredentialsFile:  viper.GetString("PUSH_FCM_CREDENTIALS_FILE"),
+			APNSKeyFile:         viper.GetString("PUSH_APNS_KEY_FILE"),
+			APNSKeyID:           viper.GetString("PUSH_APNS_KEY_ID"),
+			APNSTeamID:          viper.GetString("PUSH_APNS_TEAM_ID"),
+			APNSTopic:           viper.GetString("PUSH_APNS_TOPIC"),
+			APNSProduction:      viper.GetBool("PUSH_APNS_PRODUCTION"),
+			DueSoonWindow:       viper.GetDuration("PUSH_DUE_SOON_WINDOW"),
+			DueReminderInterval: viper.GetDuration("PUSH_DUE_REMINDER_INTERVAL"),
+		},
+		Currency: CurrencyConfig{
+			Base:         viper.GetString("CURRENCY_BASE"),
+			RateProvider: viper.GetString("EXCHANGE_RATE_PROVIDER"),
+			RateURL:      viper.GetString("EXCHANGE_RATE_URL"),
+			RateAPIKey:   viper.GetString("EXCHANGE_RATE_API_KEY"),
+			SyncInterval: viper.GetDuration("EXCHANGE_RATE_SYNC_INTERVAL"),
+			StaleAfter:   viper.GetDuration("EXCHANGE_RATE_STALE_AFTER"),
+		},
+		OAuth: OAuthConfig{
+			RedirectBaseURL: strings.TrimSuffix(viper.GetString("OAUTH_REDIRECT_BASE_URL"), "/"),
+			Providers:       loadOAuthProviders(),
+		},
+		Storage: StorageConfig{
+			Driver:            viper.GetString("STORAGE_DRIVER"),
+			LocalDir:          viper.GetString("STORAGE_LOCAL_DIR"),
+			PublicURL:         strings.TrimSuffix(viper.GetString("STORAGE_PUBLIC_URL"), "/"),
+			SigningKey:        viper.GetString("STORAGE_SIGNING_KEY"),
+			S3Endpoint:        viper.GetString("STORAGE_S3_ENDPOINT"),
+			S3Region:          viper.GetString("STORAGE_S3_REGION"),
+			S3Bucket:          viper.GetString("STORAGE_S3_BUCKET"),
+			S3AccessKeyID:     viper.GetString("STORAGE_S3_ACCESS_KEY_ID"),
+			S3SecretAccessKey: viper.GetString("STORAGE_S3_SECRET_ACCESS_KEY"),
+			S3PathStyle:       viper.GetBool("STORAGE_S3_PATH_STYLE"),
+			SignedURLTTL:      viper.GetDuration("STORAGE_SIGNED_URL_TTL"),
+			MaxImageSize:      viper.GetInt64("STORAGE_MAX_IMAGE_SIZE"),
+		},
+	}
+}
+
+// loadOAuthProviders reads OAUTH_<PROVIDER>_CLIENT_ID and
+// OAUTH_<PROVIDER>_CLIENT_SECRET for every provider.
+func loadOAuthProviders() map[string]OAuthProviderConfig {
+	providers := make(map[string]OAuthProviderConfig)
+	for _, provider := range domain.OAuthProviders {
+		prefix := "OAUTH_" + strings.ToUpper(string(provider))
+		clientID := viper.GetString(prefix + "_CLIENT_ID")
+		if clientID == "" {
+			continue
+		}
+		providers[string(provider)] = OAuthProviderConfig{
+			ClientID:     clientID,
+			ClientSecret: viper.GetString(prefix + "_CLIENT_SECRET"),
+		}
+	}
+	return providers
+}
+
+func (c Config) Validate() error {
+	var errs []error
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port <= 0 || port > 65535 {
+		errs = append(errs, fmt.Errorf("APP_PORT must be a valid TCP port, got %q", c.Server.Port))
+	}
+	if _, err := time.LoadLocation(c.App.Timezone); err != nil {
+		errs = append(errs, fmt.Errorf("APP_TIMEZONE %q is not a valid IANA timezone", c.App.Timezone))
+	}
+	if err := domain.ValidateIDVersion(c.App.IDVersion); err != nil {
+		errs = append(errs, fmt.Errorf("APP_ID_VERSION: %w", err))
+	}
+	if c.Server.ReadHeaderTimeout <= 0 {
+		errs = append(errs, errors.New("SERVER_READ_HEADER_TIMEOUT must be greater than zero"))
+	}
+	if c.Server.MaxHeaderBytes <= 0 {
+		errs = append(errs, errors.New("SERVER_MAX_HEADER_BYTES must be greater than zero"))
+	}
+	if c.Server.MaxPageSize <= 0 {
+		errs = append(errs, errors.New("SERVER_MAX_PAGE_SIZE must be greater than zero"))
+	}
+	if c.Server.FaultInjection && c.App.Env == "production" {
+		errs = append(errs, errors.New("SERVER_FAULT_INJECTION must not be enabled in production"))
+	}
+	for _, proxy := range c.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				errs = append(errs, fmt.Errorf("SERVER_TRUSTED_PROXIES: %q is not an IP address or CIDR range", proxy))
+			}
+		}
+	}
+	if c.Database.Host == "" {
+		errs = append(errs, errors.New("DB_HOST is required"))
+	}
+	if c.Database.Name == "" {
+		errs = append(errs, errors.New("DB_NAME is required"))
+	}
+	if c.Database.User == "" {
+		errs = append(errs, errors.New("DB_USER is required"))
+	}
+	if c.JWT.Secret == "" {
+		errs = append(errs, errors.New("APP_JWT_SECRET is required"))
+	} else if c.App.Env == "production" && len(c.JWT.Secret) < 32 {
+		errs = append(errs, errors.New("APP_JWT_SECRET must be at least 32 characters in production"))
+	}
+	if c.JWT.RefreshTTL < 0 {
+		errs = append(errs, errors.New("APP_JWT_REFRESH_TTL must not be negative"))
+	}
+	if c.Auth.PasswordMaxAge < 0 {
+		errs = append(errs, errors.New("AUTH_PASSWORD_MAX_AGE must not be negative"))
+	}
+	if c.Auth.PasswordResetTTL <= 0 {
+		errs = append(errs, errors.New("AUTH_PASSWORD_RESET_TTL must be positive"))
+	}
+	if c.Auth.PasswordResetURL != "" {
+		if u, err := url.Parse(c.Auth.PasswordResetURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("AUTH_PASSWORD_RESET_URL must be an absolute URL, got %q", c.Auth.PasswordResetURL))
+		}
+	}
+	if c.Auth.LockoutThreshold < 0 {
+		errs = append(errs, errors.New("AUTH_LOCKOUT_THRESHOLD must not be negative"))
+	}
+	if c.Auth.LockoutIPLimit < 0 {
+		errs = append(errs, errors.New("AUTH_LOCKOUT_IP_LIMIT must not be negative"))
+	}
+	if (c.Auth.LockoutThreshold > 0 || c.Auth.LockoutIPLimit > 0) && c.Auth.LockoutWindow <= 0 {
+		errs = append(errs, errors.New("AUTH_LOCKOUT_WINDOW must be positive when lockouts are enabled"))
+	}
+	if c.Auth.LockoutThreshold > 0 && c.Auth.LockoutDuration <= 0 {
+		errs = append(errs, errors.New("AUTH_LOCKOUT_DURATION must be positive when AUTH_LOCKOUT_THRESHOLD is set"))
+	}
+
+	if (c.Bootstrap.AdminEmail == "") != (c.Bootstrap.AdminPassword == "") {
+		errs = append(errs, errors.New("BOOTSTRAP_ADMIN_EMAIL and BOOTSTRAP_ADMIN_PASSWORD must be set together"))
+	}
+	if err := domain.ValidateSKUPattern(c.Product.SKUPattern); err != nil {
+		errs = append(errs, fmt.Errorf("PRODUCT_SKU_PATTERN: %w", err))
+	}
+	if c.Product.LowStockThreshold < 0 {
+		errs = append(errs, errors.New("PRODUCT_LOW_STOCK_THRESHOLD must not be negative"))
+	}
+	if c.Product.LowStockCheckInterval < 0 {
+		errs = append(errs, errors.New("PRODUCT_LOW_STOCK_CHECK_INTERVAL must not be negative"))
+	}
+	if c.Product.RelatedCacheTTL < 0 {
+		errs = append(errs, errors.New("PRODUCT_RELATED_CACHE_TTL must not be negative"))
+	}
+	if err := domain.ValidateProjectProgressMode(c.Project.ProgressMode); err != nil {
+		errs = append(errs, fmt.Errorf("PROJECT_PROGRESS_MODE: %w", err))
+	}
+	if c.Mail.SMTPHost != "" {
+		if port, err := strconv.Atoi(c.Mail.SMTPPort); err != nil || port <= 0 || port > 65535 {
+			errs = append(errs, fmt.Errorf("SMTP_PORT must be a valid TCP port, got %q", c.Mail.SMTPPort))
+		}
+		if _, err := mail.ParseAddress(c.Mail.From); err != nil {
+			errs = append(errs, errors.New("SMTP_FROM must be a valid email address when SMTP_HOST is set"))
+		}
+	}
+	if c.Report.SchedulerInterval < 0 {
+		errs = append(errs, errors.New("REPORT_SCHEDULER_INTERVAL must not be negative"))
+	}
+	if c.Retention.Interval < 0 {
+		errs = append(errs, errors.New("RETENTION_INTERVAL must not be negative"))
+	}
+	if c.Retention.UserExportTTL <= 0 {
+		errs = append(errs, errors.New("USER_EXPORT_TTL must be positive"))
+	}
+	if c.Retention.AccountDeletionGrace <= 0 {
+		errs = append(errs, errors.New("ACCOUNT_DELETION_GRACE must be positive"))
+	}
+	if c.Cache.TTL < 0 {
+		errs = append(errs, errors.New("CACHE_TTL must not be negative"))
+	}
+	if c.Cache.MaxEntries <= 0 {
+		errs = append(errs, errors.New("CACHE_MAX_ENTRIES must be positive"))
+	}
+	switch c.Cache.Store {
+	case domain.ResponseCacheStoreMemory:
+	case domain.ResponseCacheStoreRedis:
+		if c.Cache.RedisURL == "" {
+			errs = append(errs, errors.New("REDIS_URL is required when CACHE_STORE is redis"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("CACHE_STORE must be %q or %q, got %q", domain.ResponseCacheStoreMemory, domain.ResponseCacheStoreRedis, c.Cache.Store))
+	}
+	if c.RateLimit.IPPerMinute < 0 || c.RateLimit.UserPerMinute < 0 {
+		errs = append(errs, errors.New("RATE_LIMIT_IP_PER_MINUTE and RATE_LIMIT_USER_PER_MINUTE must not be negative"))
+	}
+	if c.RateLimit.IPPerMinute > 0 && c.RateLimit.IPBurst <= 0 {
+		errs = append(errs, errors.New("RATE_LIMIT_IP_BURST must be positive when RATE_LIMIT_IP_PER_MINUTE is set"))
+	}
+	if c.RateLimit.UserPerMinute > 0 && c.RateLimit.UserBurst <= 0 {
+		errs = append(errs, errors.New("RATE_LIMIT_USER_BURST must be positive when RATE_LIMIT_USER_PER_MINUTE is set"))
+	}
+	switch c.RateLimit.Store {
+	case domain.RateLimitStoreMemory:
+	case domain.RateLimitStoreRedis:
+		if c.RateLimit.RedisURL == "" {
+			errs = append(errs, errors.New("REDIS_URL is required when RATE_LIMIT_STORE is redis"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_STORE must be %q or %q, got %q", domain.RateLimitStoreMemory, domain.RateLimitStoreRedis, c.RateLimit.Store))
+	}
+	if c.Idempotency.TTL < 0 {
+		errs = append(errs, errors.New("IDEMPOTENCY_TTL must not be negative"))
+	}
+	switch c.Idempotency.Store {
+	case domain.IdempotencyStorePostgres:
+	case domain.IdempotencyStoreRedis:
+		if c.Idempotency.RedisURL == "" {
+			errs = append(errs, errors.New("REDIS_URL is required when IDEMPOTENCY_STORE is redis"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("IDEMPOTENCY_STORE must be %q or %q, got %q", domain.IdempotencyStorePostgres, domain.IdempotencyStoreRedis, c.Idempotency.Store))
+	}
+	if c.Push.APNSKeyFile != "" && (c.Push.APNSKeyID == "" || c.Push.APNSTeamID == "" || c.Push.APNSTopic == "") {
+		errs = append(errs, errors.New("PUSH_APNS_KEY_ID, PUSH_APNS_TEAM_ID and PUSH_APNS_TOPIC are required when PUSH_APNS_KEY_FILE is set"))
+	}
+	if c.Push.DueSoonWindow <= 0 {
+		errs = append(errs, errors.New("PUSH_DUE_SOON_WINDOW must be positive"))
+	}
+	if c.Push.DueReminderInterval < 0 {
+		errs = append(errs, errors.New("PUSH_DUE_REMINDER_INTERVAL must not be negative"))
+	}
+	if err := domain.ValidateCurrencyCode(c.Currency.Base); err != nil {
+		errs = append(errs, fmt.Errorf("CURRENCY_BASE: %w", err))
+	}
+	if c.Currency.RateProvider != "" {
+		provider := domain.ExchangeRateProvider(c.Currency.RateProvider)
+		if err := provider.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("EXCHANGE_RATE_PROVIDER: %w", err))
+		}
+		if provider == domain.ExchangeRateOpenExchangeRates && c.Currency.RateAPIKey == "" {
+			errs = append(errs, errors.New("EXCHANGE_RATE_API_KEY is required when EXCHANGE_RATE_PROVIDER is openexchangerates"))
+		}
+	}
+	if c.Currency.SyncInterval < 0 {
+		errs = append(errs, errors.New("EXCHANGE_RATE_SYNC_INTERVAL must not be negative"))
+	}
+	if c.Currency.StaleAfter <= 0 {
+		errs = append(errs, errors.New("EXCHANGE_RATE_STALE_AFTER must be positive"))
+	}
+	for _, provider := range domain.OAuthProviders {
+		if settings, ok := c.OAuth.Providers[string(provider)]; ok && settings.ClientSecret == "" {
+			name := strings.ToUpper(string(provider))
+			errs = append(errs, fmt.Errorf("OAUTH_%s_CLIENT_SECRET is required when OAUTH_%s_CLIENT_ID is set", name, name))
+		}
+	}
+	if len(c.OAuth.Providers) > 0 {
+		if u, err := url.Parse(c.OAuth.RedirectBaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, errors.New("OAUTH_REDIRECT_BASE_URL must be an absolute URL when an OAuth provider is set"))
+		}
+	}
+
+	switch c.Storage.Driver {
+	case domain.FileStorageLocal:
+		if c.Storage.LocalDir == "" {
+			errs = append(errs, errors.New("STORAGE_LOCAL_DIR is required when STORAGE_DRIVER is local"))
+		}
+		if c.Storage.PublicURL != "" {
+			if u, err := url.Parse(c.Storage.PublicURL); err != nil || u.Scheme == "" || u.Host == "" {
+				errs = append(errs, fmt.Errorf("STORAGE_PUBLIC_URL must be an absolute URL, got %q", c.Storage.PublicURL))
+			}
+		}
+	case domain.FileStorageS3:
+		if c.Storage.S3Bucket == "" || c.Storage.S3Region == "" || c.Storage.S3AccessKeyID == "" || c.Storage.S3SecretAccessKey == "" {
+			errs = append(errs, errors.New("STORAGE_S3_BUCKET, STORAGE_S3_REGION, STORAGE_S3_ACCESS_KEY_ID and STORAGE_S3_SECRET_ACCESS_KEY are required when STORAGE_DRIVER is s3"))
+		}
+		if c.Storage.S3Endpoint != "" {
+			if u, err := url.Parse(c.Storage.S3Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+				errs = append(errs, fmt.Errorf("STORAGE_S3_ENDPOINT must be an absolute URL, got %q", c.Storage.S3Endpoint))
+			}
+		}
+	default:
+		errs = append(errs, fmt.Errorf("STORAGE_DRIVER must be %q or %q, got %q", domain.FileStorageLocal, domain.FileStorageS3, c.Storage.Driver))
+	}
+	if c.Storage.SignedURLTTL <= 0 || c.Storage.SignedURLTTL > domain.MaxSignedURLTTL {
+		errs = append(errs, fmt.Errorf("STORAGE_SIGNED_URL_TTL must be positive and at most %s", domain.MaxSignedURLTTL))
+	}
+	if c.Storage.MaxImageSize <= 0 {
+		errs = append(errs, errors.New("STORAGE_MAX_IMAGE_SIZE must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ApplyTimezone makes the configured timezone the process-wide local time,
+// so time.Now(), date-only filters and JSON timestamps share one offset.
+func (c AppConfig) ApplyTimezone() (*time.Location, error) {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid APP_TIMEZONE %q: %w", c.Timezone, err)
+	}
+	time.Local = loc
+	return loc, nil
+}
+
+// Redacted returns a copy of the configuration with every field tagged
+// `secret:"true"` masked, so it can be printed or logged safely.
+func (c Config) Redacted() Config {
+	v := reflect.ValueOf(&c).Elem()
+	redact(v)
+	return c
+}
+
+func (c Config) YAML() ([]byte, error) {
+	return yaml.Marshal(c.Redacted())
+}
+
+func redact(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Struct:
+			redact(field)
+		case reflect.Map:
+			if field.IsNil() || field.Type().Elem().Kind() != reflect.Struct {
+				continue
+			}
+			copied := reflect.MakeMapWithSize(field.Type(), field.Len())
+			for _, key := range field.MapKeys() {
+				elem := reflect.New(field.Type().Elem()).Elem()
+				elem.Set(field.MapIndex(key))
+				redact(elem)
+				copied.SetMapIndex(key, elem)
+			}
+			field.Set(copied)
+		case reflect.String:
+			if t.Field(i).Tag.Get("secret") == "true" && field.String() != "" {
+				field.SetString(redactedValue)
+			}
+		}
+	}
+}
+
+// splitList splits a comma separated setting, dropping blanks.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
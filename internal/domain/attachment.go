@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FileStorageLocal = "local"
+	FileStorageS3    = "s3"
+)
+
+const (
+	// AttachmentOwnerProduct is the owner type of product images.
+	AttachmentOwnerProduct = "product"
+
+	// DefaultMaxImageSize is the largest image accepted, in bytes.
+	DefaultMaxImageSize = 5 << 20
+	// DefaultSignedURLTTL is how long a download link works.
+	DefaultSignedURLTTL = 15 * time.Minute
+	// MaxSignedURLTTL is the longest a download link may work, the limit S3
+	// puts on presigned URLs.
+	MaxSignedURLTTL = 7 * 24 * time.Hour
+)
+
+// ImageExtensions maps the image types accepted as product images to the
+// extension their files are stored with.
+var ImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrFileNotFound       = errors.New("file not found")
+	ErrEmptyFile          = errors.New("file is empty")
+	ErrFileTooLarge       = errors.New("file is too large")
+	// ErrUnsupportedFileType is returned when an upload's content, sniffed
+	// from its first bytes, is not one of the accepted types.
+	ErrUnsupportedFileType = errors.New("unsupported file type, use JPEG, PNG, GIF or WebP")
+	// ErrInvalidFileSignature is returned for download links whose
+	// signature does not match or that have expired.
+	ErrInvalidFileSignature = errors.New("download link is invalid or has expired")
+)
+
+// Attachment is a file uploaded for a record, such as a product image. The
+// file itself is kept in a FileStorage under Key. URL is a signed download
+// link filled in when the attachment is returned; it stops working at
+// URLExpiresAt.
+type Attachment struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	OwnerType    string     `json:"owner_type" gorm:"index:idx_attachments_owner"`
+	OwnerID      uuid.UUID  `json:"owner_id" gorm:"type:uuid;index:idx_attachments_owner"`
+	Key          string     `json:"-" gorm:"uniqueIndex"`
+	FileName     string     `json:"file_name"`
+	ContentType  string     `json:"content_type"`
+	Size         int64      `json:"size"`
+	UploadedBy   uuid.UUID  `json:"uploaded_by" gorm:"type:uuid"`
+	CreatedAt    time.Time  `json:"created_at"`
+	URL          string     `json:"url" gorm:"-"`
+	URLExpiresAt *time.Time `json:"url_expires_at,omitempty" gorm:"-"`
+}
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *Attachment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Attachment, error)
+	GetByKey(ctx context.Context, key string) (*Attachment, error)
+	// List returns the attachments of one record, oldest first.
+	List(ctx context.Context, ownerType string, ownerID uuid.UUID) ([]Attachment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// FileStorage keeps uploaded files by key. Keys are slash-separated paths
+// such as "products/<id>/<file>".
+type FileStorage interface {
+	Put(ctx context.Context, key, contentType string, content []byte) error
+	// Open returns ErrFileNotFound when there is no file under key.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the file under key; a missing file is not an error.
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a link that downloads the file without credentials
+	// until expiresAt. It may be relative to the API's own origin.
+	SignedURL(ctx context.Context, key string, expiresAt time.Time) (string, error)
+}
+
+// SignedURLVerifier is implemented by the FileStorages whose download links
+// are served by the API itself rather than by the storage service.
+type SignedURLVerifier interface {
+	// VerifySignedURL returns ErrInvalidFileSignature unless signature is
+	// the one SignedURL gave key for expiresAt and expiresAt has not passed.
+	VerifySignedURL(key string, expiresAt time.Time, signature string) error
+}
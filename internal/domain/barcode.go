@@ -0,0 +1,36 @@
+package domain
+
+import "errors"
+
+var ErrInvalidBarcode = errors.New("invalid barcode: expected EAN-8, UPC-A or EAN-13 with a valid check digit")
+
+// ValidBarcode reports whether code is a well-formed EAN-8, UPC-A (12 digits)
+// or EAN-13 barcode with a correct check digit. All three use the GS1 mod-10
+// scheme: digits are weighted 3 and 1 alternately starting from the right,
+// excluding the check digit itself.
+func ValidBarcode(code string) bool {
+	switch len(code) {
+	case 8, 12, 13:
+	default:
+		return false
+	}
+
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		c := code[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		digit := int(c - '0')
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	check := code[len(code)-1]
+	if check < '0' || check > '9' {
+		return false
+	}
+	return (10-sum%10)%10 == int(check-'0')
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Response cache stores the cached GET responses can be kept in.
+const (
+	ResponseCacheStoreMemory = "memory"
+	ResponseCacheStoreRedis  = "redis"
+)
+
+// CachedResponse is a stored GET response, replayed as is on a cache hit.
+type CachedResponse struct {
+	Status      int
+	ContentType string
+	ETag        string
+	Body        []byte
+}
+
+// ResponseCache stores responses per key. Keys embed the generation of the
+// resource they were read from, so bumping the generation with Invalidate
+// makes every response cached for that resource unreachable at once.
+type ResponseCache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, response *CachedResponse, ttl time.Duration)
+	Generation(resource string) uint64
+	Invalidate(resources ...string)
+}
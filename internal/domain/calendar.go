@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// CalendarPastWindow is how far back the calendar feed reaches, so
+	// subscribers keep recent history without the feed growing forever.
+	CalendarPastWindow = 90 * 24 * time.Hour
+
+	// MaxCalendarEvents caps the events of one kind in a feed.
+	MaxCalendarEvents = 1000
+)
+
+var ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+
+// CalendarFeed holds the secret that authenticates a user's calendar feed
+// URL. Calendar apps cannot send an Authorization header, so the token goes
+// in the URL; only its SHA-256 hash is stored. Each user has at most one
+// feed and rotating it invalidates the previous URL.
+type CalendarFeed struct {
+	UserID     uuid.UUID  `json:"-" gorm:"type:uuid;primaryKey"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+// CalendarFeedToken is returned once, when a feed is created or rotated.
+type CalendarFeedToken struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CalendarEvent is one entry of a calendar feed. AllDay events cover the
+// whole local day of Start; the others are points in time.
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	AllDay      bool
+	UpdatedAt   time.Time
+}
+
+type CalendarRepository interface {
+	// SaveFeed creates the user's feed or replaces its token.
+	SaveFeed(ctx context.Context, feed *CalendarFeed) error
+	GetFeed(ctx context.Context, userID uuid.UUID) (*CalendarFeed, error)
+	GetFeedByTokenHash(ctx context.Context, tokenHash string) (*CalendarFeed, error)
+	DeleteFeed(ctx context.Context, userID uuid.UUID) error
+	TouchFeed(ctx context.Context, userID uuid.UUID, at time.Time) error
+	// ListAssignedDue returns the open items assigned to userID due at or
+	// after from, soonest first.
+	ListAssignedDue(ctx context.Context, userID uuid.UUID, from time.Time, limit int) ([]ProjectItem, error)
+	// ListMilestones returns the unarchived projects userID owns or has
+	// items assigned in that start or end at or after from.
+	ListMilestones(ctx context.Context, userID uuid.UUID, from time.Time, limit int) ([]Project, error)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	// ErrUnknownCategory is returned when a product names a category, by
+	// category_id or by name, that does not exist.
+	ErrUnknownCategory = errors.New("unknown category")
+	// ErrAmbiguousCategory is returned when a product names its category by
+	// a name that more than one category has; category_id must be used.
+	ErrAmbiguousCategory = errors.New("more than one category has this name, use category_id")
+	ErrCategoryNameTaken = errors.New("a category with this name already exists under the same parent")
+	ErrCategoryCycle     = errors.New("a category cannot be moved under itself or one of its descendants")
+	// ErrCategoryInUse is returned when deleting a category that still has
+	// subcategories or products.
+	ErrCategoryInUse = errors.New("category still has subcategories or products")
+)
+
+// Category groups products. Categories form a tree through ParentID; a nil
+// ParentID is a top-level category. Names are unique among siblings, case
+// insensitively.
+type Category struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	Name      string     `json:"name"`
+	ParentID  *uuid.UUID `json:"parent_id" gorm:"type:uuid;index"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at" gorm:"index"`
+}
+
+// CategoryParams filters a category list. Roots lists top-level categories
+// only; ParentID lists the direct children of a category.
+type CategoryParams struct {
+	Name     string
+	ParentID *uuid.UUID
+	Roots    bool
+}
+
+// CategorySort is what GET /v1/categories can sort by.
+var CategorySort = SortSpec{Fields: sortColumns("name", "created_at", "updated_at")}
+
+type CategoryRepository interface {
+	// Create stores a category and returns ErrCategoryNameTaken when a
+	// sibling already has its name.
+	Create(ctx context.Context, category *Category) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Category, error)
+	// FindByName returns every category called name, case insensitively.
+	FindByName(ctx context.Context, name string) ([]Category, error)
+	List(ctx context.Context, filter CategoryParams, pagination Pagination) ([]Category, error)
+	// Update renames or moves a category, returning ErrCategoryCycle when
+	// the new parent is the category or one of its descendants, and copies
+	// a new name onto the category's products.
+	Update(ctx context.Context, category *Category) error
+	// Delete returns ErrCategoryInUse while the category has subcategories
+	// or products.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChatProvider names the chat service a connector posts to through an
+// incoming webhook.
+type ChatProvider string
+
+const (
+	ChatProviderSlack ChatProvider = "slack"
+	ChatProviderTeams ChatProvider = "teams"
+)
+
+var ChatProviders = []ChatProvider{ChatProviderSlack, ChatProviderTeams}
+
+func (p ChatProvider) Validate() error {
+	return validateEnum("provider", p, ChatProviders)
+}
+
+// ChatEvent names an event connectors can be subscribed to.
+type ChatEvent string
+
+const (
+	ChatEventProjectCreated       ChatEvent = "project_created"
+	ChatEventProjectItemCompleted ChatEvent = "project_item_completed"
+	ChatEventProductLowStock      ChatEvent = "product_low_stock"
+)
+
+var ChatEvents = []ChatEvent{ChatEventProjectCreated, ChatEventProjectItemCompleted, ChatEventProductLowStock}
+
+func (e ChatEvent) Validate() error {
+	return validateEnum("event", e, ChatEvents)
+}
+
+// ChatWebhookHosts lists the hosts each provider serves incoming webhooks
+// from, so connectors cannot be pointed at arbitrary URLs. Entries starting
+// with a dot match any subdomain.
+var ChatWebhookHosts = map[ChatProvider][]string{
+	ChatProviderSlack: {"hooks.slack.com"},
+	ChatProviderTeams: {".webhook.office.com", ".logic.azure.com", ".environment.api.powerplatform.com"},
+}
+
+// ChatDeliveryTimeout bounds posting one event to its connectors.
+const ChatDeliveryTimeout = 30 * time.Second
+
+var ErrChatConnectorNotFound = errors.New("chat connector not found")
+
+// ChatConnector posts the Events it is subscribed to into the channel behind
+// a Slack or Microsoft Teams incoming webhook. A connector with a ProjectID
+// only hears about that project, which is how projects are mapped to their
+// own channels; one without hears about every project and about products.
+type ChatConnector struct {
+	ID         uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	Name       string       `json:"name"`
+	Provider   ChatProvider `json:"provider"`
+	WebhookURL string       `json:"webhook_url"`
+	Events     StringList   `json:"events" gorm:"type:jsonb"`
+	ProjectID  *uuid.UUID   `json:"project_id" gorm:"type:uuid;index"`
+	Active     bool         `json:"active"`
+	CreatedBy  uuid.UUID    `json:"created_by" gorm:"type:uuid"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+	DeletedAt  *time.Time   `json:"deleted_at" gorm:"index"`
+}
+
+type ChatConnectorParams struct {
+	Provider  ChatProvider
+	ProjectID *uuid.UUID
+}
+
+// ChatTestResult is the outcome of posting a test message through a
+// connector.
+type ChatTestResult struct {
+	Delivered bool      `json:"delivered"`
+	Error     string    `json:"error"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+// ChatMessage is a provider neutral message: a title, a line of text and
+// labelled facts shown beneath it.
+type ChatMessage struct {
+	Title  string
+	Text   string
+	Fields []ChatField
+}
+
+type ChatField struct {
+	Name  string
+	Value string
+}
+
+// ChatPoster formats a message for a provider and posts it to a webhook.
+type ChatPoster interface {
+	Post(ctx context.Context, provider ChatProvider, webhookURL string, message ChatMessage) error
+}
+
+// ChatNotifier announces events to the chat connectors subscribed to them.
+// Delivery is best effort and happens in the background: it never fails or
+// slows down the change that raised the event.
+type ChatNotifier interface {
+	ProjectCreated(ctx context.Context, project *Project)
+	ProjectItemCompleted(ctx context.Context, item *ProjectItem)
+	ProductLowStock(ctx context.Context, product *Product, threshold int)
+}
+
+type ChatConnectorRepository interface {
+	Create(ctx context.Context, connector *ChatConnector) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ChatConnector, error)
+	List(ctx context.Context, params ChatConnectorParams, pagination Pagination) ([]ChatConnector, error)
+	Update(ctx context.Context, connector *ChatConnector) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ListSubscribed returns the active connectors subscribed to event that
+	// cover projectID: the ones mapped to it and the ones without a project.
+	// A nil projectID only matches connectors without a project.
+	ListSubscribed(ctx context.Context, event ChatEvent, projectID *uuid.UUID) ([]ChatConnector, error)
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrCommentForbidden = errors.New("only the author or an admin can delete a comment")
+	ErrEmptyComment     = errors.New("comment body is required")
+)
+
+// Comment is a message posted on a project item for its team to discuss.
+// Deleted comments are kept with DeletedAt set and no longer listed.
+type Comment struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ItemID    uuid.UUID  `json:"item_id" gorm:"type:uuid;index"`
+	AuthorID  uuid.UUID  `json:"author_id" gorm:"type:uuid"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at" gorm:"index"`
+}
+
+// CommentSort is what an item's comment list can sort by.
+var CommentSort = SortSpec{Fields: sortColumns("created_at")}
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment *Comment) error
+	// GetByID returns a comment that is not deleted, or ErrCommentNotFound.
+	GetByID(ctx context.Context, id uuid.UUID) (*Comment, error)
+	List(ctx context.Context, itemID uuid.UUID, pagination Pagination) ([]Comment, error)
+	// Count returns how many comments List matches for itemID, ignoring
+	// pagination.
+	Count(ctx context.Context, itemID uuid.UUID) (int64, error)
+	// Delete soft deletes a comment, returning ErrCommentNotFound when it
+	// is already gone.
+	Delete(ctx context.Context, id uuid.UUID, deletedAt time.Time) error
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CouponTypePercentage = "percentage"
+	CouponTypeFixed      = "fixed"
+)
+
+var ErrCouponUsageLimitReached = errors.New("coupon usage limit reached")
+
+type Coupon struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	Code        string     `json:"code" gorm:"uniqueIndex"`
+	Description string     `json:"description"`
+	Type        string     `json:"type"`
+	Value       float64    `json:"value"`
+	Category    string     `json:"category"`
+	ProductID   *uuid.UUID `json:"product_id" gorm:"type:uuid"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	MaxUses     *int       `json:"max_uses"`
+	UsedCount   int        `json:"used_count"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at" gorm:"index"`
+}
+
+type CouponParams struct {
+	Code      string
+	Type      string
+	Category  string
+	ProductID *uuid.UUID
+	ValidAt   *time.Time
+}
+
+// CouponSort is what GET /v1/coupons can sort by.
+var CouponSort = SortSpec{Fields: sortColumns("code", "type", "value", "category", "starts_at", "ends_at", "used_count", "created_at", "updated_at")}
+
+type CartLine struct {
+	ProductID uuid.UUID `json:"product_id" binding:"required"`
+	Quantity  int       `json:"quantity" binding:"required,gt=0"`
+}
+
+type CartQuoteLine struct {
+	ProductID uuid.UUID `json:"product_id"`
+	SKU       SKU       `json:"sku"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	Quantity  int       `json:"quantity"`
+	UnitPrice Money     `json:"unit_price"`
+	LineTotal Money     `json:"line_total"`
+	Eligible  bool      `json:"eligible"`
+}
+
+type CartQuote struct {
+	CouponCode string          `json:"coupon_code"`
+	Lines      []CartQuoteLine `json:"lines"`
+	Subtotal   Money           `json:"subtotal"`
+	Discount   Money           `json:"discount"`
+	Total      Money           `json:"total"`
+}
+
+type CouponRepository interface {
+	Create(ctx context.Context, coupon *Coupon) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Coupon, error)
+	GetByCode(ctx context.Context, code string) (*Coupon, error)
+	List(ctx context.Context, filter CouponParams, pagination Pagination) ([]Coupon, error)
+	Update(ctx context.Context, coupon *Coupon) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	IncrementUsage(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears bounds the search for the next run, so schedules that can
+// never fire, such as "0 0 30 2 *", end instead of looping forever.
+const cronSearchYears = 5
+
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// CronSchedule is a parsed five-field cron expression (minute, hour, day of
+// month, month, day of week). Each field accepts *, values, ranges, lists
+// and steps; the @hourly, @daily, @weekly, @monthly and @yearly shorthands
+// are also understood. As in cron, when both day fields are restricted a day
+// matching either of them fires.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[spec]; ok {
+		spec = macro
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("cron schedule %q must have 5 fields", expr)
+	}
+
+	sets := make([]uint64, len(cronFields))
+	for i, field := range cronFields {
+		set, err := parseCronField(parts[i], field)
+		if err != nil {
+			return nil, fmt.Errorf("cron schedule %q: %w", expr, err)
+		}
+		sets[i] = set
+	}
+	// Sunday may be written as 7.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] = sets[4]&^(1<<7) | 1
+	}
+
+	return &CronSchedule{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}, nil
+}
+
+func parseCronField(spec string, field cronField) (uint64, error) {
+	max := field.max
+	if field.name == "day of week" {
+		max = 7
+	}
+
+	var set uint64
+	for _, part := range strings.Split(spec, ",") {
+		rangeSpec, stepSpec, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepSpec)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid %s step %q", field.name, stepSpec)
+			}
+			step = n
+		}
+
+		low, high := field.min, max
+		switch {
+		case rangeSpec == "*":
+			if !hasStep {
+				high = field.max
+			}
+		case strings.Contains(rangeSpec, "-"):
+			from, to, _ := strings.Cut(rangeSpec, "-")
+			var err error
+			if low, err = cronValue(from, field, max); err != nil {
+				return 0, err
+			}
+			if high, err = cronValue(to, field, max); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid %s range %q", field.name, rangeSpec)
+			}
+		default:
+			value, err := cronValue(rangeSpec, field, max)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			if !hasStep {
+				high = value
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+func cronValue(spec string, field cronField, max int) (int, error) {
+	value, err := strconv.Atoi(spec)
+	if err != nil || value < field.min || value > max {
+		return 0, fmt.Errorf("invalid %s %q: must be between %d and %d", field.name, spec, field.min, max)
+	}
+	return value, nil
+}
+
+// Next returns the first minute after after that the schedule fires, in
+// after's location, or the zero time when it never fires.
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		year, month, day := t.Date()
+		switch {
+		case s.month&(1<<uint(month)) == 0:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(year, month, day, t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CustomFieldType string
+
+const (
+	CustomFieldText   CustomFieldType = "text"
+	CustomFieldNumber CustomFieldType = "number"
+	CustomFieldDate   CustomFieldType = "date"
+	CustomFieldSelect CustomFieldType = "select"
+)
+
+var CustomFieldTypes = []CustomFieldType{CustomFieldText, CustomFieldNumber, CustomFieldDate, CustomFieldSelect}
+
+func (t CustomFieldType) Validate() error {
+	return validateEnum("type", t, CustomFieldTypes)
+}
+
+// CustomFieldEntity is what a definition's values are attached to.
+type CustomFieldEntity string
+
+const (
+	CustomFieldEntityProject CustomFieldEntity = "project"
+	CustomFieldEntityItem    CustomFieldEntity = "item"
+)
+
+var CustomFieldEntities = []CustomFieldEntity{CustomFieldEntityProject, CustomFieldEntityItem}
+
+func (e CustomFieldEntity) Validate() error {
+	return validateEnum("entity", e, CustomFieldEntities)
+}
+
+var (
+	ErrCustomFieldNotFound = errors.New("custom field not found")
+	ErrCustomFieldKeyTaken = errors.New("custom field key already in use")
+	// ErrCustomFieldForbidden is returned when someone other than an admin
+	// or the owner of the definition's project changes it.
+	ErrCustomFieldForbidden = errors.New("not allowed to manage this custom field")
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// ValidCustomFieldKey reports whether key can name a custom field: lower
+// case letters, digits and underscores, starting with a letter.
+func ValidCustomFieldKey(key string) bool {
+	return customFieldKeyPattern.MatchString(key)
+}
+
+// CustomFieldDefinition declares a user-defined field. Definitions without a
+// ProjectID apply everywhere; item definitions may instead be scoped to the
+// items of one project. A key is unique among the definitions that apply to
+// the same records. Options lists the choices of a select field.
+type CustomFieldDefinition struct {
+	ID        uuid.UUID         `json:"id" gorm:"type:uuid;primaryKey"`
+	Entity    CustomFieldEntity `json:"entity" gorm:"index"`
+	ProjectID *uuid.UUID        `json:"project_id" gorm:"type:uuid;index"`
+	Key       string            `json:"key"`
+	Label     string            `json:"label"`
+	Type      CustomFieldType   `json:"type"`
+	Options   StringList        `json:"options" gorm:"type:jsonb"`
+	Required  bool              `json:"required"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	DeletedAt *time.Time        `json:"deleted_at" gorm:"index"`
+}
+
+// CustomFieldParams filters definitions. By default those applying to
+// ProjectID are returned, global ones included; ExactScope keeps only the
+// ones defined at exactly that scope, the global ones when ProjectID is nil.
+type CustomFieldParams struct {
+	Entity     CustomFieldEntity
+	ProjectID  *uuid.UUID
+	ExactScope bool
+}
+
+// CustomFieldValues holds a record's custom field values by key, stored as
+// JSONB. Text and select values are strings, numbers are float64 and dates
+// are YYYY-MM-DD strings.
+type CustomFieldValues map[string]interface{}
+
+func (v CustomFieldValues) Value() (driver.Value, error) {
+	if v == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(map[string]interface{}(v))
+	return string(data), err
+}
+
+func (v *CustomFieldValues) Scan(src interface{}) error {
+	return scanJSON(src, v)
+}
+
+// StringList is a list of strings stored as a JSONB array.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(l))
+	return string(data), err
+}
+
+func (l *StringList) Scan(src interface{}) error {
+	return scanJSON(src, l)
+}
+
+func scanJSON(src interface{}, dest interface{}) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(data, dest)
+	case string:
+		return json.Unmarshal([]byte(data), dest)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON", src)
+	}
+}
+
+type CustomFieldRepository interface {
+	// Create stores the definition and returns ErrCustomFieldKeyTaken when
+	// its key clashes with a definition applying to the same records.
+	Create(ctx context.Context, definition *CustomFieldDefinition) error
+	GetByID(ctx context.Context, id uuid.UUID) (*CustomFieldDefinition, error)
+	List(ctx context.Context, filter CustomFieldParams) ([]CustomFieldDefinition, error)
+	// Update changes the label, options and required flag only.
+	Update(ctx context.Context, definition *CustomFieldDefinition) error
+	// Delete removes the definition and its values from every record.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultLowStockThreshold is the stock level at or below which products
+// are flagged when none is configured.
+const DefaultLowStockThreshold = 5
+
+// DashboardListLimit caps each list on the dashboard.
+const DashboardListLimit = 10
+
+// Dashboard gathers what a user's landing screen shows in one payload.
+// AssignedItems holds the user's open items soonest due first, and Items
+// counts all of them. RecentActivity is the user's latest notifications and
+// OwnedProjects counts the projects the user owns by status. LowStock lists
+// the active products at or below their reorder level, or LowStockThreshold
+// when they have none, lowest first.
+type Dashboard struct {
+	AssignedItems     []ProjectItem        `json:"assigned_items"`
+	Items             DashboardItemCounts  `json:"items"`
+	RecentActivity    []Notification       `json:"recent_activity"`
+	OwnedProjects     []ProjectStatusCount `json:"owned_projects"`
+	LowStock          []Product            `json:"low_stock"`
+	LowStockThreshold int                  `json:"low_stock_threshold"`
+	GeneratedAt       time.Time            `json:"generated_at"`
+}
+
+// DashboardItemCounts counts the open items assigned to a user. Overdue
+// items were due before today; DueToday ones are due today.
+type DashboardItemCounts struct {
+	Open     int64 `json:"open"`
+	Overdue  int64 `json:"overdue"`
+	DueToday int64 `json:"due_today"`
+}
+
+type ProjectStatusCount struct {
+	Status   ProjectStatus `json:"status"`
+	Projects int64         `json:"projects"`
+}
+
+// DashboardRepository computes the dashboard counts in the database.
+type DashboardRepository interface {
+	// ItemCounts counts userID's open items, with today starting at today.
+	ItemCounts(ctx context.Context, userID uuid.UUID, today time.Time) (*DashboardItemCounts, error)
+	// ProjectStatusCounts counts the active projects ownerID owns by status.
+	ProjectStatusCounts(ctx context.Context, ownerID uuid.UUID) ([]ProjectStatusCount, error)
+}
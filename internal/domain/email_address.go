@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"net/mail"
+	"strings"
+)
+
+var ErrInvalidEmail = errors.New("invalid email")
+
+// EmailAddress is a bare address such as "ana@example.com". Values built by
+// ParseEmailAddress or decoded from JSON are always well formed.
+type EmailAddress string
+
+// ParseEmailAddress trims raw and checks that it is a single address without
+// a display name.
+func ParseEmailAddress(raw string) (EmailAddress, error) {
+	raw = strings.TrimSpace(raw)
+	address, err := mail.ParseAddress(raw)
+	if err != nil || address.Address != raw {
+		return "", ErrInvalidEmail
+	}
+	return EmailAddress(raw), nil
+}
+
+func (e EmailAddress) String() string {
+	return string(e)
+}
+
+// UnmarshalJSON rejects malformed addresses. An empty string decodes to the
+// zero value so optional fields can be left blank.
+func (e *EmailAddress) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if strings.TrimSpace(raw) == "" {
+		*e = ""
+		return nil
+	}
+	address, err := ParseEmailAddress(raw)
+	if err != nil {
+		return err
+	}
+	*e = address
+	return nil
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Locale is the language emails are written in for a user.
+type Locale string
+
+const (
+	LocaleEnglish    Locale = "en"
+	LocalePortuguese Locale = "pt-BR"
+
+	DefaultLocale = LocaleEnglish
+)
+
+var Locales = []Locale{LocaleEnglish, LocalePortuguese}
+
+func (l Locale) Validate() error {
+	return validateEnum("locale", l, Locales)
+}
+
+// EmailTemplate names the template of one kind of email. Every template is
+// available in every Locale.
+type EmailTemplate string
+
+const (
+	EmailTemplateReportDelivery EmailTemplate = "report_delivery"
+	EmailTemplatePasswordReset  EmailTemplate = "password_reset"
+)
+
+var EmailTemplates = []EmailTemplate{EmailTemplateReportDelivery, EmailTemplatePasswordReset}
+
+func (t EmailTemplate) Validate() error {
+	return validateEnum("template", t, EmailTemplates)
+}
+
+var ErrEmailTemplateNotFound = errors.New("email template not found")
+
+// EmailContent is a rendered email: its subject and the same message as
+// plain text and HTML.
+type EmailContent struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html"`
+}
+
+// EmailRenderer renders an email from the template and the data it expects,
+// such as ReportDeliveryEmail for EmailTemplateReportDelivery.
+type EmailRenderer interface {
+	Render(template EmailTemplate, locale Locale, data any) (*EmailContent, error)
+}
+
+// ReportDeliveryEmail is the data of EmailTemplateReportDelivery.
+type ReportDeliveryEmail struct {
+	Name        string
+	Report      ReportType
+	Format      ReportFormat
+	GeneratedAt time.Time
+	Rows        int
+}
+
+// EmailTemplateInfo describes a template and the locales it can be
+// previewed in.
+type EmailTemplateInfo struct {
+	Name    EmailTemplate `json:"name"`
+	Locales []Locale      `json:"locales"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidValueError reports a value outside an enumerated set such as
+// ProjectStatuses, along with the values that are allowed.
+type InvalidValueError struct {
+	Field   string
+	Value   string
+	Allowed []string
+}
+
+func (e *InvalidValueError) Error() string {
+	return fmt.Sprintf("invalid %s %q: must be one of %s", e.Field, e.Value, strings.Join(e.Allowed, ", "))
+}
+
+func validateEnum[T ~string](field string, value T, allowed []T) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+
+	values := make([]string, len(allowed))
+	for i, a := range allowed {
+		values[i] = string(a)
+	}
+	return &InvalidValueError{Field: field, Value: string(value), Allowed: values}
+}
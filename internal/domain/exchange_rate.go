@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExchangeRateProvider names the service exchange rates are fetched from.
+type ExchangeRateProvider string
+
+const (
+	// ExchangeRateFrankfurter serves the European Central Bank reference
+	// rates, published once per working day. It needs no API key.
+	ExchangeRateFrankfurter ExchangeRateProvider = "frankfurter"
+	// ExchangeRateOpenExchangeRates needs an app ID; bases other than USD
+	// need a paid plan.
+	ExchangeRateOpenExchangeRates ExchangeRateProvider = "openexchangerates"
+)
+
+var ExchangeRateProviders = []ExchangeRateProvider{ExchangeRateFrankfurter, ExchangeRateOpenExchangeRates}
+
+func (p ExchangeRateProvider) Validate() error {
+	return validateEnum("provider", p, ExchangeRateProviders)
+}
+
+const (
+	// DefaultCurrency is the store currency unless configured otherwise.
+	DefaultCurrency = "USD"
+
+	// DefaultExchangeRateStaleAfter is how old the rates may get before
+	// they are reported as stale.
+	DefaultExchangeRateStaleAfter = 48 * time.Hour
+
+	// ExchangeRateSyncTimeout bounds fetching and storing one set of rates.
+	ExchangeRateSyncTimeout = 30 * time.Second
+)
+
+// ErrExchangeRatesDisabled is returned when no provider is configured.
+var ErrExchangeRatesDisabled = errors.New("exchange rate provider is not configured")
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ValidateCurrencyCode checks that code looks like an ISO 4217 code.
+func ValidateCurrencyCode(code string) error {
+	if !currencyCodePattern.MatchString(code) {
+		return fmt.Errorf("currency %q must be a three-letter ISO 4217 code", code)
+	}
+	return nil
+}
+
+// ExchangeRate is how many units of Currency one unit of Base buys, as last
+// fetched. EffectiveAt is when the provider published the rate and
+// FetchedAt when it was last stored.
+type ExchangeRate struct {
+	Base        string               `json:"base" gorm:"primaryKey"`
+	Currency    string               `json:"currency" gorm:"primaryKey"`
+	Rate        float64              `json:"rate"`
+	Provider    ExchangeRateProvider `json:"provider"`
+	EffectiveAt time.Time            `json:"effective_at"`
+	FetchedAt   time.Time            `json:"fetched_at"`
+}
+
+// ExchangeRateQuote is one answer from a provider: the rates of Base into
+// every currency it knows, as of EffectiveAt.
+type ExchangeRateQuote struct {
+	Base        string
+	Rates       map[string]float64
+	EffectiveAt time.Time
+}
+
+// ExchangeRateFetcher asks a provider for the latest rates of base.
+type ExchangeRateFetcher interface {
+	Fetch(ctx context.Context, base string) (*ExchangeRateQuote, error)
+}
+
+// ExchangeRateSync records one attempt to refresh the rates. TriggeredBy is
+// nil for scheduled syncs and Error is empty when it succeeded.
+type ExchangeRateSync struct {
+	ID          uuid.UUID            `json:"id" gorm:"type:uuid;primaryKey"`
+	Provider    ExchangeRateProvider `json:"provider"`
+	Base        string               `json:"base"`
+	Currencies  int                  `json:"currencies"`
+	Error       string               `json:"error"`
+	TriggeredBy *uuid.UUID           `json:"triggered_by" gorm:"type:uuid"`
+	StartedAt   time.Time            `json:"started_at" gorm:"index"`
+	FinishedAt  time.Time            `json:"finished_at"`
+}
+
+// ExchangeRateMetrics tells how fresh the stored rates are. AgeSeconds is
+// the age of the oldest stored rate, and Stale is set when it exceeds the
+// configured limit or no rates were ever fetched.
+type ExchangeRateMetrics struct {
+	Base                string               `json:"base"`
+	Provider            ExchangeRateProvider `json:"provider"`
+	Currencies          int64                `json:"currencies"`
+	OldestFetchedAt     *time.Time           `json:"oldest_fetched_at"`
+	AgeSeconds          int64                `json:"age_seconds"`
+	Stale               bool                 `json:"stale"`
+	LastSyncAt          *time.Time           `json:"last_sync_at"`
+	LastSuccessAt       *time.Time           `json:"last_success_at"`
+	LastError           string               `json:"last_error"`
+	ConsecutiveFailures int64                `json:"consecutive_failures"`
+}
+
+type ExchangeRateRepository interface {
+	// SaveRates inserts the rates or replaces the stored ones of the same
+	// base and currency.
+	SaveRates(ctx context.Context, rates []ExchangeRate) error
+	ListRates(ctx context.Context, base string) ([]ExchangeRate, error)
+	CreateSync(ctx context.Context, sync *ExchangeRateSync) error
+	ListSyncs(ctx context.Context, pagination Pagination) ([]ExchangeRateSync, error)
+	// Metrics fills in the stored rate and sync figures of base, leaving
+	// the age and staleness to the caller.
+	Metrics(ctx context.Context, base string) (*ExchangeRateMetrics, error)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BudgetWarningThreshold is the share of a project's budget after which the
+// stats endpoint starts warning that the budget is nearly consumed.
+const BudgetWarningThreshold = 0.8
+
+// Expense is money spent on a project. ReceiptURL points at the stored receipt
+// (scan, PDF or invoice link); the API does not host the file itself.
+type Expense struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ProjectID   uuid.UUID  `json:"project_id" gorm:"type:uuid;index"`
+	Amount      Money      `json:"amount"`
+	Category    string     `json:"category" gorm:"index"`
+	Description string     `json:"description"`
+	Date        time.Time  `json:"date"`
+	ReceiptURL  string     `json:"receipt_url"`
+	CreatedBy   uuid.UUID  `json:"created_by" gorm:"type:uuid"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at" gorm:"index"`
+}
+
+type ExpenseParams struct {
+	Category string
+	DateFrom *time.Time
+	DateTo   *time.Time
+}
+
+// ExpenseSort is what a project's expense list can sort by.
+var ExpenseSort = SortSpec{Fields: sortColumns("amount", "category", "date", "created_at", "updated_at")}
+
+type ExpenseCategoryTotal struct {
+	Category string `json:"category"`
+	Amount   Money  `json:"amount"`
+	Count    int64  `json:"count"`
+}
+
+// ProjectBudgetStats compares a project's expenses with its budget. Remaining
+// and Consumption are nil when the project has no budget.
+type ProjectBudgetStats struct {
+	ProjectID   uuid.UUID              `json:"project_id"`
+	Budget      *Money                 `json:"budget"`
+	Spent       Money                  `json:"spent"`
+	Remaining   *Money                 `json:"remaining"`
+	Consumption *float64               `json:"consumption"`
+	OverBudget  bool                   `json:"over_budget"`
+	Categories  []ExpenseCategoryTotal `json:"categories"`
+	Warnings    []string               `json:"warnings"`
+}
+
+type ExpenseRepository interface {
+	Create(ctx context.Context, expense *Expense) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Expense, error)
+	List(ctx context.Context, projectID uuid.UUID, filter ExpenseParams, pagination Pagination) ([]Expense, error)
+	Update(ctx context.Context, expense *Expense) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	TotalsByCategory(ctx context.Context, projectID uuid.UUID) ([]ExpenseCategoryTotal, error)
+}
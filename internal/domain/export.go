@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// ExportColumn is one column of a CSV or spreadsheet export of T. Value
+// renders the cell as text; Numeric columns hold a number or nothing, so
+// spreadsheets can store them as numbers.
+type ExportColumn[T any] struct {
+	Name    string
+	Numeric bool
+	Value   func(T) string
+}
+
+// ExportHeader returns the names of columns, in order.
+func ExportHeader[T any](columns []ExportColumn[T]) []string {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+	}
+	return header
+}
+
+// ExportRow renders record as one cell per column.
+func ExportRow[T any](columns []ExportColumn[T], record T) []string {
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		row[i] = column.Value(record)
+	}
+	return row
+}
+
+var UserExportColumns = []ExportColumn[User]{
+	{Name: "id", Value: func(u User) string { return u.ID.String() }},
+	{Name: "name", Value: func(u User) string { return u.Name }},
+	{Name: "email", Value: func(u User) string { return u.Email.String() }},
+	{Name: "created_at", Value: func(u User) string { return exportTime(&u.CreatedAt) }},
+	{Name: "updated_at", Value: func(u User) string { return exportTime(&u.UpdatedAt) }},
+}
+
+var ProductExportColumns = []ExportColumn[Product]{
+	{Name: "id", Value: func(p Product) string { return p.ID.String() }},
+	{Name: "sku", Value: func(p Product) string { return p.SKU.String() }},
+	{Name: "barcode", Value: func(p Product) string {
+		if p.Barcode == nil {
+			return ""
+		}
+		return *p.Barcode
+	}},
+	{Name: "name", Value: func(p Product) string { return p.Name }},
+	{Name: "description", Value: func(p Product) string { return p.Description }},
+	{Name: "category", Value: func(p Product) string { return p.Category }},
+	{Name: "price", Numeric: true, Value: func(p Product) string { return exportAmount(&p.Price) }},
+	{Name: "cost_price", Numeric: true, Value: func(p Product) string { return exportAmount(p.CostPrice) }},
+	{Name: "stock", Numeric: true, Value: func(p Product) string { return strconv.Itoa(p.Stock) }},
+	{Name: "archived_at", Value: func(p Product) string { return exportTime(p.ArchivedAt) }},
+	{Name: "created_at", Value: func(p Product) string { return exportTime(&p.CreatedAt) }},
+	{Name: "updated_at", Value: func(p Product) string { return exportTime(&p.UpdatedAt) }},
+}
+
+var ProjectExportColumns = []ExportColumn[Project]{
+	{Name: "id", Value: func(p Project) string { return p.ID.String() }},
+	{Name: "name", Value: func(p Project) string { return p.Name }},
+	{Name: "description", Value: func(p Project) string { return p.Description }},
+	{Name: "status", Value: func(p Project) string { return string(p.Status) }},
+	{Name: "start_date", Value: func(p Project) string { return exportTime(p.StartDate) }},
+	{Name: "end_date", Value: func(p Project) string { return exportTime(p.EndDate) }},
+	{Name: "budget", Numeric: true, Value: func(p Project) string { return exportAmount(p.Budget) }},
+	{Name: "owner_id", Value: func(p Project) string { return p.OwnerID.String() }},
+	{Name: "created_at", Value: func(p Project) string { return exportTime(&p.CreatedAt) }},
+	{Name: "updated_at", Value: func(p Project) string { return exportTime(&p.UpdatedAt) }},
+}
+
+var ProjectItemExportColumns = []ExportColumn[ProjectItem]{
+	{Name: "id", Value: func(i ProjectItem) string { return i.ID.String() }},
+	{Name: "project_id", Value: func(i ProjectItem) string { return i.ProjectID.String() }},
+	{Name: "name", Value: func(i ProjectItem) string { return i.Name }},
+	{Name: "description", Value: func(i ProjectItem) string { return i.Description }},
+	{Name: "status", Value: func(i ProjectItem) string { return string(i.Status) }},
+	{Name: "priority", Value: func(i ProjectItem) string { return string(i.Priority) }},
+	{Name: "estimated_hours", Numeric: true, Value: func(i ProjectItem) string { return exportAmount(i.EstimatedHours) }},
+	{Name: "actual_hours", Numeric: true, Value: func(i ProjectItem) string { return exportAmount(i.ActualHours) }},
+	{Name: "due_date", Value: func(i ProjectItem) string { return exportTime(i.DueDate) }},
+	{Name: "assigned_to", Value: func(i ProjectItem) string {
+		if i.AssignedTo == nil {
+			return ""
+		}
+		return i.AssignedTo.String()
+	}},
+	{Name: "created_at", Value: func(i ProjectItem) string { return exportTime(&i.CreatedAt) }},
+	{Name: "updated_at", Value: func(i ProjectItem) string { return exportTime(&i.UpdatedAt) }},
+}
+
+func exportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func exportAmount[T ~float64](f *T) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(float64(*f), 'f', 2, 64)
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultLockoutThreshold, DefaultLockoutWindow and
+	// DefaultLockoutDuration lock an account for 15 minutes after 5 failed
+	// logins within 15 minutes.
+	DefaultLockoutThreshold = 5
+	DefaultLockoutWindow    = 15 * time.Minute
+	DefaultLockoutDuration  = 15 * time.Minute
+
+	// DefaultLoginIPLimit is how many failed logins one address may make
+	// within the lockout window, whatever the accounts tried.
+	DefaultLoginIPLimit = 20
+
+	// FailedLoginRetention is how long failed logins are kept for review
+	// after they stop counting towards a lockout.
+	FailedLoginRetention = 30 * 24 * time.Hour
+)
+
+var (
+	ErrAccountLocked        = errors.New("account is locked after too many failed logins")
+	ErrTooManyLoginAttempts = errors.New("too many failed logins from this address")
+)
+
+// LockoutPolicy decides when failed logins lock an account or throttle an
+// address. A zero Threshold or IPLimit turns that check off.
+type LockoutPolicy struct {
+	// Threshold failures of one account within Window lock it for
+	// Duration.
+	Threshold int
+	Window    time.Duration
+	Duration  time.Duration
+	// IPLimit failures from one address within Window refuse its further
+	// logins until the oldest of them leaves the window.
+	IPLimit int
+}
+
+// FailedLogin is a login refused for a wrong password or code. UserID is
+// nil when no account uses Email. ClearedAt is set once the failure stops
+// counting towards a lockout: after a successful login, when it locked the
+// account, or when an admin unlocked it.
+type FailedLogin struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	UserID    *uuid.UUID `gorm:"type:uuid;index"`
+	Email     string
+	IP        string    `gorm:"index"`
+	CreatedAt time.Time `gorm:"index"`
+	ClearedAt *time.Time
+}
+
+type FailedLoginRepository interface {
+	Create(ctx context.Context, failure *FailedLogin) error
+	// CountForUser counts the failures of userID since since that were not
+	// cleared.
+	CountForUser(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error)
+	// CountForIP counts the failures from ip since since, cleared ones
+	// included.
+	CountForIP(ctx context.Context, ip string, since time.Time) (int64, error)
+	ClearForUser(ctx context.Context, userID uuid.UUID, at time.Time) error
+	// DeleteBefore deletes the failures older than before.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultFaultErrorStatus is the status injected errors get unless the
+	// rule says otherwise.
+	DefaultFaultErrorStatus = 503
+
+	// MaxFaultLatency bounds the delay a rule can add, jitter included.
+	MaxFaultLatency = time.Minute
+)
+
+var ErrFaultRuleNotFound = errors.New("fault rule not found")
+
+// FaultRule makes the API misbehave on purpose for the requests it matches,
+// so clients can check their timeouts, retries and circuit breakers against
+// it. Every matching request is delayed by LatencyMS plus up to JitterMS;
+// then DropRate of them have their connection closed without an answer and
+// ErrorRate of them get ErrorStatus instead of reaching the handler.
+//
+// Method matches any method when empty. Path is a route as registered, e.g.
+// /v1/products/:id, or a prefix of one ending in *; empty matches every
+// route. The rule stops applying at ExpiresAt, when set.
+type FaultRule struct {
+	ID          uuid.UUID  `json:"id"`
+	Method      string     `json:"method"`
+	Path        string     `json:"path"`
+	LatencyMS   int        `json:"latency_ms"`
+	JitterMS    int        `json:"jitter_ms"`
+	ErrorRate   float64    `json:"error_rate"`
+	ErrorStatus int        `json:"error_status"`
+	DropRate    float64    `json:"drop_rate"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	CreatedBy   uuid.UUID  `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Matches reports whether the rule applies to a request for route at now.
+func (r *FaultRule) Matches(method, route string, now time.Time) bool {
+	if r.ExpiresAt != nil && !now.Before(*r.ExpiresAt) {
+		return false
+	}
+	if r.Method != "" && !strings.EqualFold(r.Method, method) {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Path, "*"); ok {
+		return strings.HasPrefix(route, prefix)
+	}
+	return r.Path == "" || r.Path == route
+}
+
+// Fault is what to do to one request: wait Latency, then close the
+// connection when Drop is set, or answer Status when it is not zero.
+type Fault struct {
+	RuleID  uuid.UUID
+	Latency time.Duration
+	Drop    bool
+	Status  int
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	IDVersion4 = "v4"
+	IDVersion7 = "v7"
+
+	DefaultIDVersion = IDVersion7
+)
+
+// IDGenerator hands out primary keys for new records. Services and seeds use
+// UUIDv7Generator by default: its IDs start with a millisecond timestamp, so
+// rows created one after another land next to each other in the primary key
+// index instead of at random pages.
+type IDGenerator interface {
+	NewID() uuid.UUID
+}
+
+type UUIDv7Generator struct{}
+
+func (UUIDv7Generator) NewID() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
+
+type UUIDv4Generator struct{}
+
+func (UUIDv4Generator) NewID() uuid.UUID {
+	return uuid.New()
+}
+
+func ValidateIDVersion(version string) error {
+	if version != IDVersion4 && version != IDVersion7 {
+		return fmt.Errorf("id version must be %q or %q, got %q", IDVersion4, IDVersion7, version)
+	}
+	return nil
+}
+
+// NewIDGenerator returns the generator for version, IDVersion4 or IDVersion7.
+func NewIDGenerator(version string) (IDGenerator, error) {
+	if err := ValidateIDVersion(version); err != nil {
+		return nil, err
+	}
+	if version == IDVersion4 {
+		return UUIDv4Generator{}, nil
+	}
+	return UUIDv7Generator{}, nil
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Idempotency stores the responses of Idempotency-Key requests can be kept
+// in.
+const (
+	IdempotencyStorePostgres = "postgres"
+	IdempotencyStoreRedis    = "redis"
+)
+
+const (
+	// DefaultIdempotencyTTL is how long the response to an Idempotency-Key
+	// request is replayed to retries.
+	DefaultIdempotencyTTL = 24 * time.Hour
+	// IdempotencyLockTTL bounds how long a key stays claimed by a request
+	// that never finished, e.g. because the instance serving it died.
+	IdempotencyLockTTL = 5 * time.Minute
+	// IdempotencyCleanupInterval is how often expired keys are deleted from
+	// stores that do not expire them on their own.
+	IdempotencyCleanupInterval = time.Hour
+	// MaxIdempotencyKeyLength bounds the Idempotency-Key header.
+	MaxIdempotencyKeyLength = 255
+	// MaxIdempotentBodyBytes bounds the bodies of Idempotency-Key requests,
+	// which are read whole to be hashed.
+	MaxIdempotentBodyBytes = 1 << 20
+)
+
+var (
+	// ErrIdempotencyKeyInUse is returned for a retry that arrives while the
+	// first request with its key is still running.
+	ErrIdempotencyKeyInUse = errors.New("a request with this idempotency key is still in progress")
+	// ErrIdempotencyKeyReused is returned when a key comes back with a
+	// different request than the one it was first used for.
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was already used for a different request")
+	ErrInvalidIdempotencyKey = errors.New("idempotency key must be 1 to 255 characters")
+)
+
+// IdempotencyRecord is what is kept for an Idempotency-Key: the hash of the
+// request that first used it and, once that request finished, its
+// response. A zero Status means the request is still running.
+type IdempotencyRecord struct {
+	Key         string `gorm:"primaryKey"`
+	RequestHash string `gorm:"not null"`
+	Status      int    `gorm:"not null;default:0"`
+	ContentType string `gorm:"not null;default:''"`
+	Location    string `gorm:"not null;default:''"`
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time `gorm:"index"`
+}
+
+// Completed reports whether the record holds a response to replay.
+func (r *IdempotencyRecord) Completed() bool {
+	return r.Status != 0
+}
+
+// IdempotencyStore keeps IdempotencyRecords by key.
+type IdempotencyStore interface {
+	// Claim stores a running record for key and requestHash, unless a
+	// record that has not expired at now already holds key. It returns
+	// true when the key was claimed, or the record holding it otherwise.
+	Claim(ctx context.Context, key, requestHash string, now time.Time) (*IdempotencyRecord, bool, error)
+	// Complete stores the response of the request that claimed key, kept
+	// until expiresAt.
+	Complete(ctx context.Context, record *IdempotencyRecord) error
+	// Release drops the claim on key so a retry runs the request again.
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "context"
+
+// Email is a plain-text message with optional file attachments. When
+// HTMLBody is set it is sent as an alternative to Body.
+type Email struct {
+	To          []string
+	Subject     string
+	Body        string
+	HTMLBody    string
+	Attachments []EmailAttachment
+}
+
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+type Mailer interface {
+	Send(ctx context.Context, email *Email) error
+}
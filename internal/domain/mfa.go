@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// MFAIssuer names the service in authenticator apps.
+	MFAIssuer = "Golang API REST"
+
+	// MFAChallengeTTL is how long after the password is checked the code
+	// can be sent.
+	MFAChallengeTTL = 5 * time.Minute
+
+	// totpStep, totpDigits and totpSecretBytes follow the defaults of RFC
+	// 6238 that authenticator apps assume: 30-second steps, six digits and
+	// a 160-bit SHA-1 key.
+	totpStep        = 30 * time.Second
+	totpDigits      = 6
+	totpSecretBytes = 20
+	// totpSkew is how many steps before or after the current one a code
+	// is still accepted, for clocks that drift.
+	totpSkew = 1
+)
+
+var (
+	ErrMFACodeInvalid      = errors.New("invalid two-factor code")
+	ErrMFAChallengeInvalid = errors.New("invalid or expired two-factor challenge")
+	ErrMFAAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolled      = errors.New("two-factor authentication is not set up")
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFAEnrollment is returned once, when a TOTP secret is generated. URI is
+// the otpauth:// link authenticator apps read from a QR code.
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_url"`
+}
+
+// NewTOTPSecret returns a random TOTP secret, base32 encoded the way
+// authenticator apps expect it.
+func NewTOTPSecret() (string, error) {
+	key := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(key), nil
+}
+
+// TOTPURI is the otpauth:// link of secret for account.
+func TOTPURI(account, secret string) string {
+	query := url.Values{
+		"secret": {secret},
+		"issuer": {MFAIssuer},
+	}
+	return "otpauth://totp/" + url.PathEscape(MFAIssuer+":"+account) + "?" + query.Encode()
+}
+
+// ValidTOTP reports whether code is the TOTP of secret at now or one step
+// around it.
+func ValidTOTP(secret, code string, now time.Time) bool {
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil || len(key) == 0 || len(code) != totpDigits {
+		return false
+	}
+
+	step := now.Unix() / int64(totpStep/time.Second)
+	for offset := int64(-totpSkew); offset <= totpSkew; offset++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step+offset)), []byte(code)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// totpCode is the HOTP value of RFC 4226 for counter.
+func totpCode(key []byte, counter int64) string {
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
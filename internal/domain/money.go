@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+)
+
+var ErrInvalidMoney = errors.New("amount must be a finite, non-negative number")
+
+// Money is an amount in the store currency, kept to whole cents. It is
+// stored and serialized as a plain number.
+type Money float64
+
+// NewMoney rounds amount to cents, rejecting negative and non-finite values.
+func NewMoney(amount float64) (Money, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) || amount < 0 {
+		return 0, ErrInvalidMoney
+	}
+	return RoundMoney(amount), nil
+}
+
+// RoundMoney rounds a computed amount, such as a discount, to cents.
+func RoundMoney(amount float64) Money {
+	return Money(math.Round(amount*100) / 100)
+}
+
+// Times is the amount for quantity units, rounded to cents.
+func (m Money) Times(quantity int) Money {
+	return RoundMoney(float64(m) * float64(quantity))
+}
+
+func (m Money) Float64() float64 {
+	return float64(m)
+}
+
+// UnmarshalJSON applies NewMoney to the decoded number.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var amount float64
+	if err := json.Unmarshal(data, &amount); err != nil {
+		return err
+	}
+	money, err := NewMoney(amount)
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OAuthProvider names an identity provider users can sign in with instead
+// of a password.
+type OAuthProvider string
+
+const (
+	OAuthGoogle OAuthProvider = "google"
+	OAuthGitHub OAuthProvider = "github"
+)
+
+var OAuthProviders = []OAuthProvider{OAuthGoogle, OAuthGitHub}
+
+func (p OAuthProvider) Validate() error {
+	return validateEnum("provider", p, OAuthProviders)
+}
+
+var (
+	ErrOAuthProviderDisabled = errors.New("sign-in with this provider is not enabled")
+	ErrOAuthStateInvalid     = errors.New("invalid or expired oauth state")
+	ErrOAuthCodeInvalid      = errors.New("the provider refused the authorization code")
+	ErrOAuthEmailUnverified  = errors.New("the provider account has no verified email")
+	ErrUserIdentityNotFound  = errors.New("user identity not found")
+)
+
+// OAuthProfile is what a provider tells about the account that signed in.
+// Subject is the provider's stable ID of the account; the email may change.
+type OAuthProfile struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// OAuthClient runs the authorization code flow with one provider.
+type OAuthClient interface {
+	// AuthCodeURL is where users are sent to sign in. The provider sends
+	// them back to the redirect URL with a code and state.
+	AuthCodeURL(state string) string
+	// Exchange trades the code for the profile of the account that signed
+	// in. It returns ErrOAuthCodeInvalid when the provider refuses the code.
+	Exchange(ctx context.Context, code string) (*OAuthProfile, error)
+}
+
+// UserIdentity links a user to an account of a provider, so later sign-ins
+// find the user even if the email at the provider changed.
+type UserIdentity struct {
+	ID        uuid.UUID     `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID     `json:"user_id" gorm:"type:uuid;index"`
+	Provider  OAuthProvider `json:"provider" gorm:"uniqueIndex:idx_user_identities_provider_subject"`
+	Subject   string        `json:"subject" gorm:"uniqueIndex:idx_user_identities_provider_subject"`
+	Email     string        `json:"email"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type UserIdentityRepository interface {
+	Create(ctx context.Context, identity *UserIdentity) error
+	// GetBySubject returns ErrUserIdentityNotFound when no user is linked
+	// to the account.
+	GetBySubject(ctx context.Context, provider OAuthProvider, subject string) (*UserIdentity, error)
+}
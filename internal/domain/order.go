@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusShipped   = "shipped"
+	OrderStatusDelivered = "delivered"
+	OrderStatusCancelled = "cancelled"
+)
+
+var OrderStatuses = []string{OrderStatusPending, OrderStatusPaid, OrderStatusShipped, OrderStatusDelivered, OrderStatusCancelled}
+
+func ValidateOrderStatus(status string) error {
+	return validateEnum("status", status, OrderStatuses)
+}
+
+var (
+	ErrOrderNotFound = errors.New("order not found")
+	ErrOrderStatus   = errors.New("order is not in the required status")
+	// ErrOrderForbidden is returned when a user other than an admin moves an
+	// order to a status only the store can set, such as shipped.
+	ErrOrderForbidden = errors.New("not allowed to change the status of this order")
+)
+
+// orderTransitions lists the statuses each order status can move to.
+// Delivered and cancelled are final.
+var orderTransitions = map[string][]string{
+	OrderStatusPending: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:    {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped: {OrderStatusDelivered},
+}
+
+// CanTransitionOrder reports whether an order in status from may move to
+// status to.
+func CanTransitionOrder(from, to string) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Order is a purchase by a user. Placing it takes its items out of stock,
+// through sale stock adjustments into the ledger, in the same transaction
+// that stores it; cancelling it puts them back. Items are priced from the
+// product when the order is placed or edited, and can only change while it
+// is pending. WarehouseID, when set, is the warehouse the order ships from.
+// A coupon given when the order is placed is redeemed with it and takes
+// Discount off the Subtotal of its items; it stays with the order, and is
+// applied again, when the items are edited.
+type Order struct {
+	ID          uuid.UUID   `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID      uuid.UUID   `json:"user_id" gorm:"type:uuid;index"`
+	Status      string      `json:"status" gorm:"index"`
+	WarehouseID *uuid.UUID  `json:"warehouse_id" gorm:"type:uuid"`
+	Notes       string      `json:"notes"`
+	CouponID    *uuid.UUID  `json:"coupon_id" gorm:"type:uuid"`
+	CouponCode  string      `json:"coupon_code"`
+	Subtotal    Money       `json:"subtotal"`
+	Discount    Money       `json:"discount"`
+	Total       Money       `json:"total"`
+	Items       []OrderItem `json:"items" gorm:"foreignKey:OrderID"`
+	PaidAt      *time.Time  `json:"paid_at"`
+	ShippedAt   *time.Time  `json:"shipped_at"`
+	DeliveredAt *time.Time  `json:"delivered_at"`
+	CancelledAt *time.Time  `json:"cancelled_at"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	DeletedAt   *time.Time  `json:"deleted_at" gorm:"index"`
+}
+
+type OrderItem struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	OrderID   uuid.UUID `json:"order_id" gorm:"type:uuid;index"`
+	ProductID uuid.UUID `json:"product_id" gorm:"type:uuid" binding:"required"`
+	Quantity  int       `json:"quantity" binding:"required,gt=0"`
+	UnitPrice Money     `json:"unit_price"`
+}
+
+// OrderParams filters an order list. A non-nil UserID restricts it to the
+// orders of that user.
+type OrderParams struct {
+	UserID *uuid.UUID
+	Status string
+}
+
+// OrderSort is what GET /v1/orders can sort by.
+var OrderSort = SortSpec{Fields: sortColumns("status", "total", "paid_at", "shipped_at", "delivered_at", "cancelled_at", "created_at", "updated_at")}
+
+type OrderRepository interface {
+	// Create stores an order and applies one sale stock adjustment per item,
+	// all in one transaction, so it fails with ErrInsufficientStock without
+	// leaving anything behind when an item is out of stock. The order's
+	// coupon, if any, is redeemed in the same transaction, failing with
+	// ErrCouponUsageLimitReached when it has no uses left.
+	Create(ctx context.Context, order *Order) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Order, error)
+	List(ctx context.Context, filter OrderParams, pagination Pagination) ([]Order, error)
+	// Update replaces the notes and items of a pending order, moving stock by
+	// the difference between the old and new items, and returns
+	// ErrOrderStatus once it has left pending.
+	Update(ctx context.Context, order *Order, actorID uuid.UUID) error
+	// Delete soft deletes an order that has not shipped, putting its items
+	// back into stock unless it was cancelled, and returns ErrOrderStatus for
+	// shipped and delivered orders.
+	Delete(ctx context.Context, id, actorID uuid.UUID) error
+	// UpdateStatus moves an order to status and returns ErrOrderStatus when
+	// CanTransitionOrder does not allow it. Cancelling puts the items back
+	// into stock in the same transaction.
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string, actorID uuid.UUID) (*Order, error)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultPasswordResetTTL is how long a reset link works unless
+	// AUTH_PASSWORD_RESET_TTL says otherwise.
+	DefaultPasswordResetTTL = time.Hour
+
+	// PasswordResetCooldown is how long after a reset email no other is sent
+	// to the same account, so the endpoint cannot be used to flood inboxes.
+	PasswordResetCooldown = time.Minute
+)
+
+var (
+	ErrPasswordResetTokenInvalid = errors.New("invalid or expired password reset token")
+	ErrPasswordResetDisabled     = errors.New("password reset is not available")
+)
+
+// PasswordResetToken lets the owner of an account who forgot the password
+// choose a new one. The token is sent by email and only its SHA-256 hash is
+// stored. It works once, until ExpiresAt, and requesting a new one voids
+// the earlier ones.
+type PasswordResetToken struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;index"`
+	TokenHash string    `gorm:"uniqueIndex"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// PasswordResetEmail is the data of EmailTemplatePasswordReset. URL is empty
+// when no AUTH_PASSWORD_RESET_URL is configured, and the token is given
+// alone.
+type PasswordResetEmail struct {
+	Name      string
+	Token     string
+	URL       string
+	ExpiresAt time.Time
+}
+
+type PasswordResetRepository interface {
+	// Create stores the token and voids the unused ones of the same user.
+	Create(ctx context.Context, token *PasswordResetToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+	// LastCreatedAt returns when the latest token of userID was created,
+	// or nil when there is none.
+	LastCreatedAt(ctx context.Context, userID uuid.UUID) (*time.Time, error)
+	// MarkUsed returns ErrPasswordResetTokenInvalid when the token was
+	// already used, so it cannot be redeemed twice.
+	MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// ErrInvalidPatch is returned for a merge patch that is not a JSON object or
+// whose result does not decode into the entity.
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// PatchSpec lists the fields of an entity a JSON Merge Patch may change. The
+// names are the entity's JSON field names, which are also its columns.
+type PatchSpec struct {
+	Fields []string
+}
+
+// ApplyMergePatch applies patch, a JSON Merge Patch (RFC 7396), to current
+// and returns the patched copy along with the fields the patch names, which
+// are the only ones to store. A null member clears its field and nested
+// objects, such as custom fields, are merged key by key. Naming a field
+// outside spec is an *InvalidValueError.
+func ApplyMergePatch[T any](spec PatchSpec, current *T, patch []byte) (*T, []string, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &members); err != nil || members == nil {
+		return nil, nil, fmt.Errorf("%w: the body must be a JSON object", ErrInvalidPatch)
+	}
+
+	fields := make([]string, 0, len(members))
+	for name := range members {
+		if !slices.Contains(spec.Fields, name) {
+			allowed := slices.Clone(spec.Fields)
+			sort.Strings(allowed)
+			return nil, nil, &InvalidValueError{Field: "patch field", Value: name, Allowed: allowed}
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+
+	document, err := json.Marshal(current)
+	if err != nil {
+		return nil, nil, err
+	}
+	var target any
+	if err := json.Unmarshal(document, &target); err != nil {
+		return nil, nil, err
+	}
+	var changes any
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidPatch, err.Error())
+	}
+	merged, err := json.Marshal(mergePatch(target, changes))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	patched := new(T)
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	if err := decoder.Decode(patched); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, nil, fmt.Errorf("%w: %s must be %s", ErrInvalidPatch, typeErr.Field, typeErr.Type)
+		}
+		return nil, nil, err
+	}
+	return patched, fields, nil
+}
+
+// mergePatch is the MergePatch algorithm of RFC 7396 over decoded JSON.
+func mergePatch(target, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]any)
+	if !ok {
+		object = map[string]any{}
+	}
+	for name, value := range changes {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+		object[name] = mergePatch(object[name], value)
+	}
+	return object
+}
@@ -0,0 +1,16 @@
+package domain
+
+// RolePermissions grants each role what it may do, in the
+// "<action>:<resource>" form of token scopes. Routes guarded by a
+// permission refuse roles that are not granted it; unguarded routes are
+// open to every signed-in user.
+var RolePermissions = map[string][]string{
+	RoleAdmin: {ScopeAll},
+	RoleUser:  {ScopeActionRead + ":" + ScopeAll},
+}
+
+// RoleAllows reports whether role may perform action on resource. Unknown
+// roles may do nothing.
+func RoleAllows(role, action, resource string) bool {
+	return ScopesAllow(RolePermissions[role], action, resource)
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PolicyKind names a family of documents users have to agree to. Each kind
+// is versioned on its own.
+type PolicyKind string
+
+const (
+	PolicyTermsOfService PolicyKind = "terms_of_service"
+	PolicyPrivacy        PolicyKind = "privacy_policy"
+)
+
+var PolicyKinds = []PolicyKind{PolicyTermsOfService, PolicyPrivacy}
+
+func (k PolicyKind) Validate() error {
+	return validateEnum("kind", k, PolicyKinds)
+}
+
+const MaxPolicyVersionLength = 50
+
+var (
+	ErrPolicyDocumentNotFound = errors.New("policy document not found")
+	// ErrPolicyVersionExists is returned when the kind already has a
+	// document with that version.
+	ErrPolicyVersionExists = errors.New("policy version already exists")
+	// ErrPolicyDocumentSuperseded is returned when accepting a document that
+	// is no longer the latest version of its kind.
+	ErrPolicyDocumentSuperseded = errors.New("policy document has been superseded")
+	// ErrPolicyAcceptanceRequired is returned while enforcement is on and the
+	// user has not accepted the latest version of every kind.
+	ErrPolicyAcceptanceRequired = errors.New("the latest policy documents must be accepted")
+)
+
+// PolicyDocument is one published version of a policy. The most recently
+// published document of a kind is the one users have to accept; documents
+// are never edited, a change is published as a new version.
+type PolicyDocument struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	Kind        PolicyKind `json:"kind" gorm:"uniqueIndex:idx_policy_documents_kind_version"`
+	Version     string     `json:"version" gorm:"uniqueIndex:idx_policy_documents_kind_version"`
+	Title       string     `json:"title"`
+	Content     string     `json:"content"`
+	PublishedBy uuid.UUID  `json:"published_by" gorm:"type:uuid"`
+	PublishedAt time.Time  `json:"published_at"`
+}
+
+// PolicyAcceptance records that a user agreed to a document. Kind and
+// Version are copied from the document so the record reads on its own.
+type PolicyAcceptance struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;uniqueIndex:idx_policy_acceptances_user_document"`
+	DocumentID uuid.UUID  `json:"document_id" gorm:"type:uuid;uniqueIndex:idx_policy_acceptances_user_document;index"`
+	Kind       PolicyKind `json:"kind"`
+	Version    string     `json:"version"`
+	IPAddress  string     `json:"ip_address"`
+	AcceptedAt time.Time  `json:"accepted_at"`
+}
+
+// PolicyStatus is where a user stands with the current documents: what they
+// accepted and which latest versions still wait for them.
+type PolicyStatus struct {
+	Accepted []PolicyAcceptance `json:"accepted"`
+	Pending  []PolicyDocument   `json:"pending"`
+}
+
+type PolicyRepository interface {
+	// CreateDocument stores a new version, returning ErrPolicyVersionExists
+	// when the kind already has it.
+	CreateDocument(ctx context.Context, document *PolicyDocument) error
+	GetDocument(ctx context.Context, id uuid.UUID) (*PolicyDocument, error)
+	// ListDocuments returns every version, newest first, optionally of one
+	// kind only.
+	ListDocuments(ctx context.Context, kind PolicyKind, pagination Pagination) ([]PolicyDocument, error)
+	// CurrentDocuments returns the latest version of each kind.
+	CurrentDocuments(ctx context.Context) ([]PolicyDocument, error)
+	// PendingDocuments returns the latest versions the user has not
+	// accepted. Subjects that are not stored users have nothing pending.
+	PendingDocuments(ctx context.Context, userID uuid.UUID) ([]PolicyDocument, error)
+	// Accept records the acceptance unless the user already accepted the
+	// document, in which case the existing record is loaded into it.
+	Accept(ctx context.Context, acceptance *PolicyAcceptance) error
+	// ListAcceptances returns the user's acceptances, newest first.
+	ListAcceptances(ctx context.Context, userID uuid.UUID) ([]PolicyAcceptance, error)
+	// ListDocumentAcceptances returns who accepted the document, newest
+	// first.
+	ListDocumentAcceptances(ctx context.Context, documentID uuid.UUID, pagination Pagination) ([]PolicyAcceptance, error)
+}
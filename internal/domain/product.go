@@ -2,27 +2,54 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Product is an item in the catalog. CategoryID is the product's category;
+// Category is a copy of that category's name, never set on its own: product
+// writes fill it in from CategoryID and renaming a category rewrites it on
+// every product in the category. It stays because SKU patterns, facets,
+// reports, related products, coupon restrictions and the ?category= filter
+// of existing clients all match by name, and reading it from the row spares
+// them a join with the category tree.
 type Product struct {
 	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
 	Name        string     `json:"name"`
 	Description string     `json:"description"`
-	Price       float64    `json:"price"`
+	Price       Money      `json:"price"`
+	CostPrice   *Money     `json:"cost_price"`
 	Stock       int        `json:"stock"`
+	CategoryID  *uuid.UUID `json:"category_id" gorm:"type:uuid;index"`
 	Category    string     `json:"category"`
-	SKU         string     `json:"sku" gorm:"uniqueIndex"`
+	SKU         SKU        `json:"sku" gorm:"uniqueIndex"`
+	Barcode     *string    `json:"barcode" gorm:"uniqueIndex"`
+	ArchivedAt  *time.Time `json:"archived_at" gorm:"index"`
+	Version     int        `json:"version" gorm:"not null;default:1"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	DeletedAt   *time.Time `json:"deleted_at" gorm:"index"`
+
+	// ReorderLevel is the stock level at or below which the product is low
+	// on stock; nil falls back to the configured threshold.
+	// LowStockAlertedAt is set once the low stock checker has announced the
+	// product and cleared when its stock is back above the level.
+	ReorderLevel      *int       `json:"reorder_level"`
+	LowStockAlertedAt *time.Time `json:"-"`
+
+	// Availability breaks Stock down per warehouse. It is only filled in when
+	// a single product is fetched.
+	Availability *ProductAvailability `json:"availability,omitempty" gorm:"-"`
 }
 
+// ProductParams filters a product list. CategoryID matches the products of a
+// category and of all its descendants.
 type ProductParams struct {
 	Name          string
 	Category      string
+	CategoryID    *uuid.UUID
 	SKU           string
 	PriceFrom     *float64
 	PriceTo       *float64
@@ -30,14 +57,121 @@ type ProductParams struct {
 	StockTo       *int
 	CreatedAtFrom *time.Time
 	CreatedAtTo   *time.Time
+	// IncludeArchived also returns archived products, which are hidden by
+	// default.
+	IncludeArchived bool
+}
+
+// ProductSort is what GET /v1/products can sort by.
+var ProductSort = SortSpec{Fields: sortColumns("name", "price", "cost_price", "stock", "category", "sku", "created_at", "updated_at")}
+
+// ProductPatch is what PATCH /v1/products/{id} can change. Stock, cost price
+// and the archived state have endpoints of their own.
+var ProductPatch = PatchSpec{Fields: []string{"name", "description", "category_id", "category", "sku", "barcode", "price", "reorder_level"}}
+
+const (
+	ProductFacetCategory = "category"
+	ProductFacetPrice    = "price"
+	ProductFacetStock    = "stock"
+)
+
+const (
+	// NotificationTargetProduct is the target type of notifications about a
+	// product.
+	NotificationTargetProduct = "product"
+	// ChangeEventLowStock is the event of the notifications sent to admins
+	// when a product drops to its reorder level.
+	ChangeEventLowStock = "low_stock"
+	// LowStockAlertBatch is how many low products one pass of the low stock
+	// checker claims at a time.
+	LowStockAlertBatch = 100
+)
+
+// LowStockLevel is the stock level at or below which p is low on stock:
+// its ReorderLevel, or threshold when it has none.
+func (p *Product) LowStockLevel(threshold int) int {
+	if p.ReorderLevel != nil {
+		return *p.ReorderLevel
+	}
+	return threshold
+}
+
+var (
+	ErrUnknownFacet    = errors.New("unknown facet")
+	ErrProductArchived = errors.New("product is archived")
+	// ErrProductDeleted is returned when stock is moved for a product that
+	// has been deleted, such as returning the items of an older order.
+	ErrProductDeleted = errors.New("product is deleted")
+)
+
+// ProductPriceRangeBounds are the upper bounds of the price facet buckets. The
+// last bucket is open ended.
+var ProductPriceRangeBounds = []float64{25, 50, 100, 250, 500, 1000}
+
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type PriceRangeBucket struct {
+	From  float64  `json:"from"`
+	To    *float64 `json:"to"`
+	Count int64    `json:"count"`
+}
+
+type StockAvailability struct {
+	InStock    int64 `json:"in_stock"`
+	OutOfStock int64 `json:"out_of_stock"`
+}
+
+type ProductFacets struct {
+	Categories []FacetBucket      `json:"categories,omitempty"`
+	Price      []PriceRangeBucket `json:"price,omitempty"`
+	Stock      *StockAvailability `json:"stock,omitempty"`
 }
 
 type ProductRepository interface {
 	Create(ctx context.Context, product *Product) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Product, error)
 	GetBySKU(ctx context.Context, sku string) (*Product, error)
+	GetByBarcode(ctx context.Context, barcode string) (*Product, error)
 	List(ctx context.Context, filter ProductParams, pagination Pagination) ([]Product, error)
-	Update(ctx context.Context, product *Product) error
+	// Count returns how many records List matches for filter, ignoring
+	// pagination.
+	Count(ctx context.Context, filter ProductParams) (int64, error)
+	// Update stores the non-zero fields of product, or with fields exactly
+	// those columns, zero or not, and updated_at. It only applies while the
+	// stored version is product.Version, unless that is zero, and moves it up by one;
+	// otherwise it returns ErrVersionConflict.
+	Update(ctx context.Context, product *Product, fields ...string) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	UpdateStock(ctx context.Context, id uuid.UUID, quantity int) error
+	SetArchived(ctx context.Context, id uuid.UUID, archivedAt *time.Time) error
+	// UpsertBySKU inserts products, or updates the stored product with the
+	// same SKU. Updates always overwrite name and price, and of the
+	// optional description, category and stock only those named in
+	// columns, so a file leaving a column out keeps the stored values.
+	// Stock is reached through import stock adjustments recorded for
+	// actorID, so it fails like a stock adjustment would.
+	UpsertBySKU(ctx context.Context, products []Product, columns []string, actorID uuid.UUID) error
+	Facets(ctx context.Context, filter ProductParams, facets []string) (*ProductFacets, error)
+	NextSKUSequence(ctx context.Context, prefix string) (int64, error)
+	StockLevels(ctx context.Context, productID uuid.UUID) ([]WarehouseStockLevel, error)
+	// Suggest returns up to limit unarchived products whose name contains
+	// query or whose SKU starts with it, best matches first.
+	Suggest(ctx context.Context, query string, limit int) ([]Suggestion, error)
+	// Search returns the unarchived products whose name, description or
+	// SKU match query, written like a web search ("quoted phrases", or,
+	// -excluded words), best ranked first.
+	Search(ctx context.Context, query string, pagination Pagination) ([]ProductSearchHit, error)
+	// CountSearch returns how many products Search matches for query,
+	// ignoring pagination.
+	CountSearch(ctx context.Context, query string) (int64, error)
+	// ListLowStock returns the unarchived products at or below their reorder
+	// level, or threshold when they have none.
+	ListLowStock(ctx context.Context, threshold int, pagination Pagination) ([]Product, error)
+	// ClaimLowStockAlerts clears LowStockAlertedAt on products back above
+	// their level, then sets it to now on up to limit low products that do
+	// not have it yet and returns those, so each drop is announced once
+	// even with several checkers running.
+	ClaimLowStockAlerts(ctx context.Context, threshold int, now time.Time, limit int) ([]Product, error)
 }
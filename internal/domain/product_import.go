@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ProductImportStatusPending   = "pending"
+	ProductImportStatusCompleted = "completed"
+	ProductImportStatusFailed    = "failed"
+)
+
+const (
+	// ProductImportBatchSize is how many rows are upserted per transaction.
+	ProductImportBatchSize = 500
+	// ProductImportMaxBytes caps a CSV file imported in the background,
+	// since it is kept until the import finishes.
+	ProductImportMaxBytes = 32 << 20
+	// ProductImportMaxErrors caps the row errors stored with a background
+	// import. Failed still counts every rejected row.
+	ProductImportMaxErrors = 1000
+	// ProductImportTimeout bounds a background import.
+	ProductImportTimeout = 30 * time.Minute
+)
+
+var (
+	ErrProductImportNotFound = errors.New("product import not found")
+	// ErrInvalidImportFile is returned when a CSV file cannot be imported at
+	// all, such as when its header misses a required column.
+	ErrInvalidImportFile = errors.New("invalid import file")
+)
+
+// ImportResult counts the rows of an import and lists the rejected ones.
+type ImportResult struct {
+	Total    int              `json:"total"`
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// Reject counts the row on line as failed with message.
+func (r *ImportResult) Reject(line int, key, message string) {
+	r.Failed++
+	r.Errors = append(r.Errors, ImportRowError{Line: line, Key: key, Error: message})
+}
+
+// ImportRowError is a CSV row that was not imported. Key is the row's
+// natural key (the SKU for products) when it could be read.
+type ImportRowError struct {
+	Line  int    `json:"line"`
+	Key   string `json:"key"`
+	Error string `json:"error"`
+}
+
+type ImportRowErrors []ImportRowError
+
+func (e ImportRowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]ImportRowError(e))
+	return string(data), err
+}
+
+func (e *ImportRowErrors) Scan(src interface{}) error {
+	return scanJSON(src, e)
+}
+
+// ProductImport is a product CSV file imported in the background. The
+// counters and errors are updated after every batch so clients can poll the
+// progress; Progress is the percentage of the file read so far. Content is
+// dropped once the import finishes.
+type ProductImport struct {
+	ID          uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
+	Status      string          `json:"status"`
+	Error       string          `json:"error"`
+	Content     []byte          `json:"-"`
+	Size        int             `json:"size"`
+	Progress    int             `json:"progress"`
+	Total       int             `json:"total"`
+	Imported    int             `json:"imported"`
+	Failed      int             `json:"failed"`
+	Errors      ImportRowErrors `json:"errors" gorm:"type:jsonb;not null;default:'[]'"`
+	RequestedBy uuid.UUID       `json:"requested_by" gorm:"type:uuid;index"`
+	CreatedAt   time.Time       `json:"created_at"`
+	CompletedAt *time.Time      `json:"completed_at"`
+}
+
+type ProductImportRepository interface {
+	Create(ctx context.Context, productImport *ProductImport) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ProductImport, error)
+	// UpdateProgress stores the progress, counters and errors of a pending
+	// import.
+	UpdateProgress(ctx context.Context, productImport *ProductImport) error
+	// Complete stores the final counters and errors and drops the content.
+	Complete(ctx context.Context, productImport *ProductImport, completedAt time.Time) error
+	Fail(ctx context.Context, id uuid.UUID, reason string, completedAt time.Time) error
+}
@@ -0,0 +1,22 @@
+package domain
+
+// ProductSearchConfig is the text search configuration of the products'
+// search_vector column and of the queries matched against it. "simple"
+// lowercases words without stemming them, so names in any language and SKUs
+// match as typed.
+const ProductSearchConfig = "simple"
+
+// ProductSearchHit is a product matching a full-text search. Hits are
+// ordered by Rank, higher first.
+type ProductSearchHit struct {
+	Product    Product           `json:"product"`
+	Rank       float64           `json:"rank"`
+	Highlights ProductHighlights `json:"highlights"`
+}
+
+// ProductHighlights are the name and a snippet of the description of a
+// search hit, HTML-escaped, with the words that matched in <mark> tags.
+type ProductHighlights struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
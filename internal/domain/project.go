@@ -2,28 +2,71 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var ErrProjectArchived = errors.New("project is archived")
+
+type ProjectStatus string
+
+const (
+	ProjectStatusActive    ProjectStatus = "active"
+	ProjectStatusOnHold    ProjectStatus = "on_hold"
+	ProjectStatusCompleted ProjectStatus = "completed"
+	ProjectStatusCancelled ProjectStatus = "cancelled"
+)
+
+var ProjectStatuses = []ProjectStatus{ProjectStatusActive, ProjectStatusOnHold, ProjectStatusCompleted, ProjectStatusCancelled}
+
+func (s ProjectStatus) Validate() error {
+	return validateEnum("status", s, ProjectStatuses)
+}
+
+// Project progress formulas. By count, progress is the share of completed
+// items; by hours, the share of estimated hours on completed items.
+// Cancelled items are left out of both.
+const (
+	ProjectProgressByCount = "count"
+	ProjectProgressByHours = "hours"
+)
+
+func ValidateProjectProgressMode(mode string) error {
+	if mode != ProjectProgressByCount && mode != ProjectProgressByHours {
+		return fmt.Errorf("progress mode must be %q or %q, got %q", ProjectProgressByCount, ProjectProgressByHours, mode)
+	}
+	return nil
+}
+
+// Project.ArchivedAt freezes a project: its details and items become read-only
+// and it is hidden from default listings, but unlike DeletedAt it stays
+// available for reporting. Progress is not stored; it is a percentage
+// computed from the items when the project is read, nil when there is
+// nothing to measure it by.
 type Project struct {
-	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Status      string     `json:"status"`
-	StartDate   *time.Time `json:"start_date"`
-	EndDate     *time.Time `json:"end_date"`
-	Budget      *float64   `json:"budget"`
-	OwnerID     uuid.UUID  `json:"owner_id"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at" gorm:"index"`
+	ID           uuid.UUID         `json:"id" gorm:"type:uuid;primaryKey"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	Status       ProjectStatus     `json:"status" binding:"omitempty,enum"`
+	StartDate    *time.Time        `json:"start_date"`
+	EndDate      *time.Time        `json:"end_date"`
+	Budget       *Money            `json:"budget"`
+	OwnerID      uuid.UUID         `json:"owner_id"`
+	ArchivedAt   *time.Time        `json:"archived_at" gorm:"index"`
+	Progress     *float64          `json:"progress" gorm:"-"`
+	CustomFields CustomFieldValues `json:"custom_fields" gorm:"type:jsonb;not null;default:'{}'"`
+	Version      int               `json:"version" gorm:"not null;default:1"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	DeletedAt    *time.Time        `json:"deleted_at" gorm:"index"`
 }
 
 type ProjectParams struct {
 	Name          string
-	Status        string
+	Status        ProjectStatus
 	OwnerID       *uuid.UUID
 	StartDateFrom *time.Time
 	StartDateTo   *time.Time
@@ -33,13 +76,33 @@ type ProjectParams struct {
 	BudgetTo      *float64
 	CreatedAtFrom *time.Time
 	CreatedAtTo   *time.Time
+	// IncludeArchived also returns archived projects, which are hidden by
+	// default.
+	IncludeArchived bool
+	// CustomFields matches custom field values by key, compared as text.
+	CustomFields map[string]string
 }
 
+// ProjectSort is what GET /v1/projects can sort by.
+var ProjectSort = SortSpec{Fields: sortColumns("name", "status", "start_date", "end_date", "budget", "created_at", "updated_at")}
+
+// ProjectPatch is what PATCH /v1/projects/{id} can change.
+var ProjectPatch = PatchSpec{Fields: []string{"name", "description", "status", "start_date", "end_date", "budget", "owner_id", "custom_fields"}}
+
 type ProjectRepository interface {
 	Create(ctx context.Context, project *Project) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Project, error)
 	List(ctx context.Context, filter ProjectParams, pagination Pagination) ([]Project, error)
-	Update(ctx context.Context, project *Project) error
+	// Count returns how many records List matches for filter, ignoring
+	// pagination.
+	Count(ctx context.Context, filter ProjectParams) (int64, error)
+	// Update stores the non-zero fields of project, or with fields exactly
+	// those columns, zero or not, and updated_at. It only applies while the
+	// stored version is project.Version, unless that is zero, and moves it up by one;
+	// otherwise it returns ErrVersionConflict.
+	Update(ctx context.Context, project *Project, fields ...string) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]Project, error)
+	SetArchived(ctx context.Context, id uuid.UUID, archivedAt *time.Time) error
+	Progress(ctx context.Context, ids []uuid.UUID, mode string) (map[uuid.UUID]float64, error)
 }
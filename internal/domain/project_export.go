@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ProjectExportFormatJSON = "json"
+	ProjectExportFormatPDF  = "pdf"
+)
+
+const (
+	ProjectExportStatusPending = "pending"
+	ProjectExportStatusReady   = "ready"
+	ProjectExportStatusFailed  = "failed"
+)
+
+// ProjectExportSyncItemLimit is the largest item count exported inline.
+// Bigger projects are exported in the background and fetched later.
+const ProjectExportSyncItemLimit = 200
+
+// ProjectExportTimeout bounds a background export.
+const ProjectExportTimeout = 5 * time.Minute
+
+var (
+	ErrProjectExportNotFound = errors.New("project export not found")
+	ErrProjectExportNotReady = errors.New("project export is not ready")
+)
+
+const (
+	ProjectMemberOwner    = "owner"
+	ProjectMemberAssignee = "assignee"
+)
+
+// ProjectMember is a user involved in a project: its owner or the assignee
+// of one of its items.
+type ProjectMember struct {
+	UserID uuid.UUID    `json:"user_id"`
+	Name   string       `json:"name"`
+	Email  EmailAddress `json:"email"`
+	Role   string       `json:"role"`
+}
+
+// ProjectHours sums the estimated and actual hours booked on the items.
+type ProjectHours struct {
+	Estimated float64 `json:"estimated"`
+	Actual    float64 `json:"actual"`
+}
+
+// ProjectSnapshot is everything an export contains about a project at
+// GeneratedAt.
+type ProjectSnapshot struct {
+	Project     Project             `json:"project"`
+	Members     []ProjectMember     `json:"members"`
+	Items       []ProjectItem       `json:"items"`
+	Hours       ProjectHours        `json:"hours"`
+	Expenses    []Expense           `json:"expenses"`
+	Stats       *ProjectBudgetStats `json:"stats"`
+	GeneratedAt time.Time           `json:"generated_at"`
+}
+
+// ProjectExport is a rendered snapshot. Small projects are rendered inline;
+// large ones are stored pending and filled in by a background job.
+type ProjectExport struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ProjectID   uuid.UUID  `json:"project_id" gorm:"type:uuid;index"`
+	Format      string     `json:"format"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error"`
+	Content     []byte     `json:"-"`
+	RequestedBy uuid.UUID  `json:"requested_by" gorm:"type:uuid"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+type ProjectExportRepository interface {
+	Create(ctx context.Context, export *ProjectExport) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ProjectExport, error)
+	Complete(ctx context.Context, id uuid.UUID, content []byte, completedAt time.Time) error
+	Fail(ctx context.Context, id uuid.UUID, reason string, completedAt time.Time) error
+}
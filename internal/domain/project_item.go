@@ -2,32 +2,138 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrProjectItemNotFound = errors.New("project item not found")
+	// ErrProjectItemTransition is returned when an item is moved to a status
+	// CanTransitionProjectItem does not allow from its current one.
+	ErrProjectItemTransition = errors.New("project item cannot move to that status")
+	ErrParentItemNotFound    = errors.New("parent item not found")
+	ErrParentItemProject     = errors.New("a sub-task must belong to the same project as its parent item")
+	ErrParentItemCycle       = errors.New("an item cannot be a sub-task of itself or of its own sub-tasks")
+)
+
+type ProjectItemStatus string
+
+const (
+	ProjectItemStatusPending    ProjectItemStatus = "pending"
+	ProjectItemStatusInProgress ProjectItemStatus = "in_progress"
+	ProjectItemStatusBlocked    ProjectItemStatus = "blocked"
+	ProjectItemStatusReview     ProjectItemStatus = "review"
+	ProjectItemStatusCompleted  ProjectItemStatus = "completed"
+	ProjectItemStatusCancelled  ProjectItemStatus = "cancelled"
+)
+
+var ProjectItemStatuses = []ProjectItemStatus{ProjectItemStatusPending, ProjectItemStatusInProgress, ProjectItemStatusBlocked, ProjectItemStatusReview, ProjectItemStatusCompleted, ProjectItemStatusCancelled}
+
+func (s ProjectItemStatus) Validate() error {
+	return validateEnum("status", s, ProjectItemStatuses)
+}
+
+// projectItemTransitions lists the statuses each item status can move to.
+// Completed items can be reopened and cancelled ones restored to pending.
+var projectItemTransitions = map[ProjectItemStatus][]ProjectItemStatus{
+	ProjectItemStatusPending:    {ProjectItemStatusInProgress, ProjectItemStatusBlocked, ProjectItemStatusCancelled},
+	ProjectItemStatusInProgress: {ProjectItemStatusPending, ProjectItemStatusBlocked, ProjectItemStatusReview, ProjectItemStatusCompleted, ProjectItemStatusCancelled},
+	ProjectItemStatusBlocked:    {ProjectItemStatusPending, ProjectItemStatusInProgress, ProjectItemStatusCancelled},
+	ProjectItemStatusReview:     {ProjectItemStatusInProgress, ProjectItemStatusCompleted, ProjectItemStatusCancelled},
+	ProjectItemStatusCompleted:  {ProjectItemStatusInProgress},
+	ProjectItemStatusCancelled:  {ProjectItemStatusPending},
+}
+
+// CanTransitionProjectItem reports whether an item in status from may move
+// to status to.
+func CanTransitionProjectItem(from, to ProjectItemStatus) bool {
+	return slices.Contains(projectItemTransitions[from], to)
+}
+
+// ClosedProjectItemStatuses are the statuses of items that no longer count as
+// open work, so they are never reported as overdue or upcoming.
+var ClosedProjectItemStatuses = []ProjectItemStatus{ProjectItemStatusCompleted, ProjectItemStatusCancelled}
+
+type ProjectItemPriority string
+
+const (
+	ProjectItemPriorityLow    ProjectItemPriority = "low"
+	ProjectItemPriorityMedium ProjectItemPriority = "medium"
+	ProjectItemPriorityHigh   ProjectItemPriority = "high"
+)
+
+var ProjectItemPriorities = []ProjectItemPriority{ProjectItemPriorityLow, ProjectItemPriorityMedium, ProjectItemPriorityHigh}
+
+func (p ProjectItemPriority) Validate() error {
+	return validateEnum("priority", p, ProjectItemPriorities)
+}
+
+// MaxUpcomingDays bounds the look-ahead window of the upcoming items view.
+const MaxUpcomingDays = 90
+
+// ProjectItem is a task of a project. ParentItemID makes it a sub-task of
+// another item of the same project; Rollup is only filled in by the
+// items-by-project view. Rank orders the item within its status column of
+// the project board and only changes through the board.
 type ProjectItem struct {
-	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
-	ProjectID      uuid.UUID  `json:"project_id"`
-	Name           string     `json:"name"`
-	Description    string     `json:"description"`
-	Status         string     `json:"status"`
-	Priority       string     `json:"priority"`
-	EstimatedHours *float64   `json:"estimated_hours"`
-	ActualHours    *float64   `json:"actual_hours"`
-	DueDate        *time.Time `json:"due_date"`
-	AssignedTo     *uuid.UUID `json:"assigned_to"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
-	DeletedAt      *time.Time `json:"deleted_at" gorm:"index"`
+	ID             uuid.UUID           `json:"id" gorm:"type:uuid;primaryKey"`
+	ProjectID      uuid.UUID           `json:"project_id"`
+	Name           string              `json:"name"`
+	Description    string              `json:"description"`
+	Status         ProjectItemStatus   `json:"status" binding:"omitempty,enum"`
+	Priority       ProjectItemPriority `json:"priority" binding:"omitempty,enum"`
+	EstimatedHours *float64            `json:"estimated_hours"`
+	ActualHours    *float64            `json:"actual_hours"`
+	DueDate        *time.Time          `json:"due_date"`
+	AssignedTo     *uuid.UUID          `json:"assigned_to"`
+	ParentItemID   *uuid.UUID          `json:"parent_item_id" gorm:"type:uuid;index"`
+	CustomFields   CustomFieldValues   `json:"custom_fields" gorm:"type:jsonb;not null;default:'{}'"`
+	Rank           float64             `json:"-" gorm:"not null;default:0"`
+	Version        int                 `json:"version" gorm:"not null;default:1"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	DeletedAt      *time.Time          `json:"deleted_at" gorm:"index"`
+	Rollup         *ProjectItemRollup  `json:"rollup,omitempty" gorm:"-"`
+}
+
+// ProjectItemRollup sums the hours of an item and of all its sub-tasks,
+// nested ones included. Missing hours count as zero.
+type ProjectItemRollup struct {
+	EstimatedHours float64 `json:"estimated_hours"`
+	ActualHours    float64 `json:"actual_hours"`
+	SubTasks       int     `json:"sub_tasks"`
+}
+
+// ProjectItemAssignment is one period during which a user held a project
+// item. UnassignedAt is nil for the current assignee.
+type ProjectItemAssignment struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ItemID       uuid.UUID  `json:"item_id" gorm:"type:uuid;index"`
+	UserID       uuid.UUID  `json:"user_id" gorm:"type:uuid"`
+	AssignedAt   time.Time  `json:"assigned_at"`
+	UnassignedAt *time.Time `json:"unassigned_at"`
+}
+
+// ProjectItemTransition records one status change of a project item.
+// ActorID is nil when the status was changed by editing the item rather
+// than through POST /v1/project-items/{id}/transition.
+type ProjectItemTransition struct {
+	ID             uuid.UUID         `json:"id" gorm:"type:uuid;primaryKey"`
+	ItemID         uuid.UUID         `json:"item_id" gorm:"type:uuid;index"`
+	FromStatus     ProjectItemStatus `json:"from_status"`
+	ToStatus       ProjectItemStatus `json:"to_status"`
+	ActorID        *uuid.UUID        `json:"actor_id" gorm:"type:uuid"`
+	TransitionedAt time.Time         `json:"transitioned_at"`
 }
 
 type ProjectItemParams struct {
 	ProjectID          *uuid.UUID
 	Name               string
-	Status             string
-	Priority           string
+	Status             ProjectItemStatus
+	Priority           ProjectItemPriority
 	AssignedTo         *uuid.UUID
 	DueDateFrom        *time.Time
 	DueDateTo          *time.Time
@@ -37,14 +143,121 @@ type ProjectItemParams struct {
 	ActualHoursTo      *float64
 	CreatedAtFrom      *time.Time
 	CreatedAtTo        *time.Time
+	OpenOnly           bool
+	// CustomFields matches custom field values by key, compared as text.
+	CustomFields map[string]string
+}
+
+// ProjectItemSort is what the project item lists can sort by.
+var ProjectItemSort = SortSpec{Fields: sortColumns("name", "status", "priority", "estimated_hours", "actual_hours", "due_date", "created_at", "updated_at")}
+
+// ProjectItemBoardSort is what the columns of a project board can sort by.
+// Board order is rank.
+var ProjectItemBoardSort = SortSpec{Fields: sortColumns("rank", "name", "priority", "due_date", "created_at", "updated_at")}
+
+// ProjectItemPatch is what PATCH /v1/project-items/{id} can change.
+var ProjectItemPatch = PatchSpec{Fields: []string{"project_id", "name", "description", "status", "priority", "estimated_hours", "actual_hours", "due_date", "assigned_to", "parent_item_id", "custom_fields"}}
+
+// HoursByStatus, HoursByAssignee and HoursByWeek sum the estimated and actual
+// hours of the items in one group of an HoursRollup.
+type HoursByStatus struct {
+	Status    ProjectItemStatus `json:"status"`
+	Estimated float64           `json:"estimated"`
+	Actual    float64           `json:"actual"`
+	Items     int64             `json:"items"`
+}
+
+// HoursByAssignee.AssignedTo is nil for unassigned items.
+type HoursByAssignee struct {
+	AssignedTo *uuid.UUID `json:"assigned_to"`
+	Estimated  float64    `json:"estimated"`
+	Actual     float64    `json:"actual"`
+	Items      int64      `json:"items"`
+}
+
+// HoursByWeek.Week is the Monday starting the week the items are due in, or
+// nil for items without a due date.
+type HoursByWeek struct {
+	Week      *time.Time `json:"week"`
+	Estimated float64    `json:"estimated"`
+	Actual    float64    `json:"actual"`
+	Items     int64      `json:"items"`
+}
+
+// HoursRollup totals the hours of the items in a scope and breaks them down
+// by status, assignee and due week.
+type HoursRollup struct {
+	Estimated  float64           `json:"estimated"`
+	Actual     float64           `json:"actual"`
+	Items      int64             `json:"items"`
+	ByStatus   []HoursByStatus   `json:"by_status"`
+	ByAssignee []HoursByAssignee `json:"by_assignee"`
+	ByWeek     []HoursByWeek     `json:"by_week"`
+}
+
+// BoardRankStep is the gap left between the ranks of items added one after
+// another to a board column. An item moved between two others takes the
+// midpoint of their ranks.
+const BoardRankStep = 1024
+
+// BoardColumn is one status column of a project board: a page of its items
+// and how many items the whole column holds.
+type BoardColumn struct {
+	Status ProjectItemStatus `json:"status"`
+	Items  []ProjectItem     `json:"items"`
+	Total  int64             `json:"total"`
+}
+
+// ProjectBoard lays a project's items out in one column per status, in
+// workflow order. Limit and Offset page every column alike.
+type ProjectBoard struct {
+	ProjectID uuid.UUID     `json:"project_id"`
+	Limit     int           `json:"limit"`
+	Offset    int           `json:"offset"`
+	Columns   []BoardColumn `json:"columns"`
+}
+
+// BoardMove places an item at Position, counted from zero, in the board
+// column of Status. An empty Status keeps the item in its column.
+type BoardMove struct {
+	Status   ProjectItemStatus
+	Position int
 }
 
 type ProjectItemRepository interface {
 	Create(ctx context.Context, item *ProjectItem) error
 	GetByID(ctx context.Context, id uuid.UUID) (*ProjectItem, error)
 	List(ctx context.Context, filter ProjectItemParams, pagination Pagination) ([]ProjectItem, error)
-	Update(ctx context.Context, item *ProjectItem) error
+	// Count returns how many records List matches for filter, ignoring
+	// pagination.
+	Count(ctx context.Context, filter ProjectItemParams) (int64, error)
+	// Update stores the non-zero fields of item, or with fields exactly
+	// those columns, zero or not, and updated_at. It only applies while the
+	// stored version is item.Version, unless that is zero, and moves it up by one;
+	// otherwise it returns ErrVersionConflict. A status change must be one
+	// CanTransitionProjectItem allows, or ErrProjectItemTransition is
+	// returned, and is recorded as a transition without an actor. An item
+	// changing status or project goes to the bottom of its new board column.
+	Update(ctx context.Context, item *ProjectItem, fields ...string) error
+	// Transition moves an item to status, at the bottom of its board column,
+	// on behalf of actorID and records the change, returning the updated
+	// item, or ErrProjectItemTransition when CanTransitionProjectItem does
+	// not allow it.
+	Transition(ctx context.Context, id uuid.UUID, status ProjectItemStatus, actorID uuid.UUID) (*ProjectItem, error)
+	// MoveOnBoard moves an item of projectID to a column and position of the
+	// board on behalf of actorID, returning the updated item. A change of
+	// column is a status change: it must be one CanTransitionProjectItem
+	// allows, or ErrProjectItemTransition is returned, and is recorded as a
+	// transition. Items of other projects are reported as not found.
+	MoveOnBoard(ctx context.Context, projectID, id uuid.UUID, move BoardMove, actorID uuid.UUID) (*ProjectItem, error)
+	// Delete soft deletes an item, moving its sub-tasks up to its own parent.
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]ProjectItem, error)
+	// ListChildren returns the direct sub-tasks of an item, oldest first.
+	ListChildren(ctx context.Context, parentID uuid.UUID) ([]ProjectItem, error)
 	GetByAssignedTo(ctx context.Context, assignedTo uuid.UUID) ([]ProjectItem, error)
+	ListAssignments(ctx context.Context, itemID uuid.UUID) ([]ProjectItemAssignment, error)
+	// ListTransitions returns the status changes of an item, newest first.
+	ListTransitions(ctx context.Context, itemID uuid.UUID) ([]ProjectItemTransition, error)
+	HoursRollup(ctx context.Context, filter ProjectItemParams) (*HoursRollup, error)
 }
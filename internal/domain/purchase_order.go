@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PurchaseOrderStatusDraft     = "draft"
+	PurchaseOrderStatusSubmitted = "submitted"
+	PurchaseOrderStatusReceived  = "received"
+)
+
+var ErrPurchaseOrderStatus = errors.New("purchase order is not in the required status")
+
+// PurchaseOrder restocks products from a supplier. It is editable while in
+// draft, frozen once submitted, and receiving it books every line into the
+// stock ledger. WarehouseID, when set, is where the goods are received.
+type PurchaseOrder struct {
+	ID          uuid.UUID           `json:"id" gorm:"type:uuid;primaryKey"`
+	Supplier    string              `json:"supplier"`
+	Status      string              `json:"status" gorm:"index"`
+	WarehouseID *uuid.UUID          `json:"warehouse_id" gorm:"type:uuid"`
+	Notes       string              `json:"notes"`
+	CreatedBy   uuid.UUID           `json:"created_by" gorm:"type:uuid"`
+	Lines       []PurchaseOrderLine `json:"lines" gorm:"foreignKey:PurchaseOrderID"`
+	SubmittedAt *time.Time          `json:"submitted_at"`
+	ReceivedAt  *time.Time          `json:"received_at"`
+	ReceivedBy  *uuid.UUID          `json:"received_by" gorm:"type:uuid"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	DeletedAt   *time.Time          `json:"deleted_at" gorm:"index"`
+}
+
+type PurchaseOrderLine struct {
+	ID              uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	PurchaseOrderID uuid.UUID `json:"purchase_order_id" gorm:"type:uuid;index"`
+	ProductID       uuid.UUID `json:"product_id" gorm:"type:uuid" binding:"required"`
+	Quantity        int       `json:"quantity" binding:"required,gt=0"`
+	UnitCost        Money     `json:"unit_cost" binding:"gte=0"`
+}
+
+type PurchaseOrderParams struct {
+	Supplier string
+	Status   string
+}
+
+// PurchaseOrderSort is what GET /v1/purchase-orders can sort by.
+var PurchaseOrderSort = SortSpec{Fields: sortColumns("supplier", "status", "submitted_at", "received_at", "created_at", "updated_at")}
+
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, order *PurchaseOrder) error
+	GetByID(ctx context.Context, id uuid.UUID) (*PurchaseOrder, error)
+	List(ctx context.Context, filter PurchaseOrderParams, pagination Pagination) ([]PurchaseOrder, error)
+	// Update replaces the header and lines of a draft order and returns
+	// ErrPurchaseOrderStatus once it has left draft.
+	Update(ctx context.Context, order *PurchaseOrder) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Submit moves a draft order to submitted and returns
+	// ErrPurchaseOrderStatus when it is not a draft.
+	Submit(ctx context.Context, id uuid.UUID) error
+	// Receive locks a submitted order, applies one purchase stock adjustment
+	// per line, records each line's unit cost as the product's cost price and
+	// marks the order received, all in one transaction.
+	Receive(ctx context.Context, id, actorID uuid.UUID) (*PurchaseOrder, error)
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PushPlatform names the service a device receives push notifications
+// through: Firebase Cloud Messaging for Android and web clients, or the
+// Apple Push Notification service for iOS.
+type PushPlatform string
+
+const (
+	PushPlatformFCM  PushPlatform = "fcm"
+	PushPlatformAPNS PushPlatform = "apns"
+)
+
+var PushPlatforms = []PushPlatform{PushPlatformFCM, PushPlatformAPNS}
+
+func (p PushPlatform) Validate() error {
+	return validateEnum("platform", p, PushPlatforms)
+}
+
+// ChangeEventDueSoon is the event of the reminders sent to the assignee of
+// an open project item shortly before it is due.
+const ChangeEventDueSoon = "due_soon"
+
+const (
+	// MaxDevicesPerUser caps the devices registered to one user; registering
+	// another drops the one seen least recently.
+	MaxDevicesPerUser = 10
+
+	// MaxPushTokenLength bounds the tokens devices register. FCM tokens are
+	// around 160 characters and APNS tokens 64.
+	MaxPushTokenLength = 4096
+
+	// PushDispatchTimeout bounds pushing one batch of notifications.
+	PushDispatchTimeout = 30 * time.Second
+
+	// DefaultDueSoonWindow is how long before its due date an item's
+	// assignee is reminded of it.
+	DefaultDueSoonWindow = 24 * time.Hour
+)
+
+var (
+	ErrDeviceNotFound = errors.New("device not found")
+
+	// ErrPushTokenInvalid is returned by a PushSender when the provider no
+	// longer accepts a token, for instance because the app was uninstalled.
+	// The device is then unregistered.
+	ErrPushTokenInvalid = errors.New("push token is no longer valid")
+)
+
+// Device is a mobile or web client registered to receive push
+// notifications for a user. A token belongs to one user at a time: when
+// another user registers it, for instance after signing in on a shared
+// device, it moves to them.
+type Device struct {
+	ID         uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID    `json:"user_id" gorm:"type:uuid;index"`
+	Platform   PushPlatform `json:"platform"`
+	Token      string       `json:"token" gorm:"uniqueIndex"`
+	LastSeenAt time.Time    `json:"last_seen_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// NotificationPreferences picks which notifications are pushed to a user's
+// devices. Every notification still lands in the in-app inbox. Users who
+// never saved preferences get DefaultNotificationPreferences.
+type NotificationPreferences struct {
+	UserID       uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	PushAssigned bool      `json:"push_assigned"`
+	PushDueSoon  bool      `json:"push_due_soon"`
+	PushChanges  bool      `json:"push_changes"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// DefaultNotificationPreferences pushes assignments and due date reminders
+// but not the changes of watched projects and items, which can be many.
+func DefaultNotificationPreferences(userID uuid.UUID) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:       userID,
+		PushAssigned: true,
+		PushDueSoon:  true,
+	}
+}
+
+// Pushes reports whether notifications of event are pushed.
+func (p *NotificationPreferences) Pushes(event string) bool {
+	switch event {
+	case ChangeEventAssigned:
+		return p.PushAssigned
+	case ChangeEventDueSoon:
+		return p.PushDueSoon
+	default:
+		return p.PushChanges
+	}
+}
+
+// PushMessage is a notification as shown by the device. Data is passed to
+// the app untouched.
+type PushMessage struct {
+	ID    uuid.UUID
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// PushSender delivers messages through one PushPlatform.
+type PushSender interface {
+	Send(ctx context.Context, token string, message PushMessage) error
+}
+
+// NotificationDispatcher delivers stored notifications over channels other
+// than the in-app inbox. Like the fan-out that stores them, delivery is best
+// effort.
+type NotificationDispatcher interface {
+	Dispatch(ctx context.Context, notifications []Notification)
+}
+
+type DeviceRepository interface {
+	// Register stores device, or moves its token to device.UserID and
+	// refreshes it when the token is already registered, then drops the
+	// user's devices beyond MaxDevicesPerUser.
+	Register(ctx context.Context, device *Device) error
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]Device, error)
+	ListByUsers(ctx context.Context, userIDs []uuid.UUID) ([]Device, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	DeleteByToken(ctx context.Context, token string) error
+	// GetPreferences returns nil without an error for users who never saved
+	// their preferences.
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*NotificationPreferences, error)
+	ListPreferences(ctx context.Context, userIDs []uuid.UUID) ([]NotificationPreferences, error)
+	SavePreferences(ctx context.Context, preferences *NotificationPreferences) error
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Rate limit stores the limiter can keep its buckets in.
+const (
+	RateLimitStoreMemory = "memory"
+	RateLimitStoreRedis  = "redis"
+)
+
+// Defaults of RATE_LIMIT_IP_PER_MINUTE, RATE_LIMIT_USER_PER_MINUTE and their
+// bursts.
+const (
+	DefaultRateLimitIPPerMinute   = 300
+	DefaultRateLimitIPBurst       = 60
+	DefaultRateLimitUserPerMinute = 600
+	DefaultRateLimitUserBurst     = 120
+)
+
+var ErrRateLimited = errors.New("rate limit exceeded, slow down")
+
+// RateLimit is a token bucket: a client may send Burst requests at once,
+// and the bucket refills at PerMinute requests a minute. A zero PerMinute
+// means no limit.
+type RateLimit struct {
+	PerMinute int
+	Burst     int
+}
+
+// Refill is how long the bucket takes to regain one token.
+func (l RateLimit) Refill() time.Duration {
+	return time.Minute / time.Duration(l.PerMinute)
+}
+
+// RateLimitDecision is the outcome of taking a token. RetryAfter is how
+// long until a token is back when the request was refused.
+type RateLimitDecision struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// RateLimitStore keeps token buckets by key.
+type RateLimitStore interface {
+	// Take removes a token from the bucket of key at now, creating the
+	// bucket full when there is none.
+	Take(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitDecision, error)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultRefreshTokenTTL is how long a refresh token stays usable unless
+// APP_JWT_REFRESH_TTL says otherwise.
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+var (
+	ErrRefreshTokenInvalid   = errors.New("invalid refresh token")
+	ErrRefreshTokensDisabled = errors.New("refresh tokens are not enabled")
+)
+
+// RefreshToken lets a client get a new access token without sending the
+// credentials again. Only the SHA-256 hash of the token is stored. Every
+// refresh revokes the token used and issues a new one; presenting a revoked
+// token again means it leaked, so all tokens of the user are revoked.
+type RefreshToken struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	TokenHash string     `json:"-" gorm:"uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IssuedRefreshToken is returned once, when the token is created.
+type IssuedRefreshToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	// Revoke marks the token revoked at at. It returns
+	// ErrRefreshTokenInvalid when the token is unknown or already revoked,
+	// so two concurrent refreshes cannot both use it.
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	// RevokeAllForUser revokes every live token of userID and returns how
+	// many there were.
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID, at time.Time) (int64, error)
+}
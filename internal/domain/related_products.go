@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Limits of the related products endpoint.
+const (
+	DefaultRelatedProductsLimit = 8
+	MaxRelatedProductsLimit     = 24
+)
+
+// RelatedPriceBand is how far from a product's price, as a fraction of it,
+// another product may be priced to count as similar.
+const RelatedPriceBand = 0.25
+
+// DefaultRelatedProductsCacheTTL is how long recommendations are reused
+// unless configured otherwise.
+const DefaultRelatedProductsCacheTTL = 5 * time.Minute
+
+// RelatedProductsStrategy picks up to limit products to recommend next to
+// product, best first. Implementations leave out product itself and archived
+// products. The first one compares category and price; once customer orders
+// exist, one based on products bought together can replace or complement it.
+type RelatedProductsStrategy interface {
+	Related(ctx context.Context, product *Product, limit int) ([]Product, error)
+}
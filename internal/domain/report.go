@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportInterval buckets report rows by the records' creation date.
+type ReportInterval string
+
+const (
+	ReportIntervalDay   ReportInterval = "day"
+	ReportIntervalWeek  ReportInterval = "week"
+	ReportIntervalMonth ReportInterval = "month"
+	ReportIntervalYear  ReportInterval = "year"
+)
+
+var ReportIntervals = []ReportInterval{ReportIntervalDay, ReportIntervalWeek, ReportIntervalMonth, ReportIntervalYear}
+
+func (i ReportInterval) Validate() error {
+	return validateEnum("interval", i, ReportIntervals)
+}
+
+// The dimensions each report can be grouped by. The first one is the
+// default.
+var (
+	ItemReportGroups    = []string{"status", "priority", "assigned_to", "project_id"}
+	StockReportGroups   = []string{"category", "warehouse"}
+	ProjectReportGroups = []string{"status", "owner_id"}
+)
+
+// ReportParams selects and groups the records a report aggregates. From and
+// To bound the creation date; the remaining filters apply to the reports
+// whose records have them.
+type ReportParams struct {
+	GroupBy         string
+	Interval        ReportInterval
+	From            *time.Time
+	To              *time.Time
+	ProjectID       *uuid.UUID
+	AssignedTo      *uuid.UUID
+	OwnerID         *uuid.UUID
+	Status          string
+	Priority        string
+	Category        string
+	IncludeArchived bool
+}
+
+// ValidateGroupBy checks GroupBy against groups, defaulting it to the first.
+func (p *ReportParams) ValidateGroupBy(groups []string) error {
+	if p.GroupBy == "" {
+		p.GroupBy = groups[0]
+		return nil
+	}
+	return validateEnum("group_by", p.GroupBy, groups)
+}
+
+// ItemsReportRow counts project items and sums their hours. Like the other
+// report rows, Group holds the value of the grouped dimension, nil for
+// records without one such as unassigned items, and Bucket the start of the
+// date bucket when an interval was requested.
+type ItemsReportRow struct {
+	Group          *string    `json:"group" gorm:"column:grp"`
+	Bucket         *time.Time `json:"bucket,omitempty"`
+	Items          int64      `json:"items"`
+	EstimatedHours float64    `json:"estimated_hours"`
+	ActualHours    float64    `json:"actual_hours"`
+}
+
+// StockReportRow sums stock and its value at sale and cost price. Grouped
+// by warehouse it counts the stock held in each warehouse, so products
+// without warehouse stock are left out.
+type StockReportRow struct {
+	Group      *string    `json:"group" gorm:"column:grp"`
+	Bucket     *time.Time `json:"bucket,omitempty"`
+	Products   int64      `json:"products"`
+	Stock      int64      `json:"stock"`
+	StockValue float64    `json:"stock_value"`
+	StockCost  float64    `json:"stock_cost"`
+}
+
+// ProjectsReportRow sums the budgets and the expenses of the projects.
+// Remaining only covers the projects that have a budget.
+type ProjectsReportRow struct {
+	Group     *string    `json:"group" gorm:"column:grp"`
+	Bucket    *time.Time `json:"bucket,omitempty"`
+	Projects  int64      `json:"projects"`
+	Budget    float64    `json:"budget"`
+	Spent     float64    `json:"spent"`
+	Remaining float64    `json:"remaining"`
+}
+
+// ReportRepository computes the reports in the database. Params must have
+// been validated.
+type ReportRepository interface {
+	ItemsReport(ctx context.Context, params ReportParams) ([]ItemsReportRow, error)
+	StockReport(ctx context.Context, params ReportParams) ([]StockReportRow, error)
+	ProjectsReport(ctx context.Context, params ReportParams) ([]ProjectsReportRow, error)
+}
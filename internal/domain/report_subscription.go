@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportType names the report a subscription delivers, matching the path of
+// its endpoint under /v1/reports.
+type ReportType string
+
+const (
+	ReportItemsByStatus   ReportType = "items-by-status"
+	ReportStockByCategory ReportType = "stock-by-category"
+	ReportProjectsBudget  ReportType = "projects-budget"
+)
+
+var ReportTypes = []ReportType{ReportItemsByStatus, ReportStockByCategory, ReportProjectsBudget}
+
+func (t ReportType) Validate() error {
+	return validateEnum("report", t, ReportTypes)
+}
+
+// ReportFilterKeys lists the filters each report type accepts, named as the
+// query parameters of its endpoint. The date range is left out: every
+// delivery covers the records existing when it runs.
+var ReportFilterKeys = map[ReportType][]string{
+	ReportItemsByStatus:   {"group_by", "interval", "project_id", "assigned_to", "status", "priority"},
+	ReportStockByCategory: {"group_by", "interval", "category", "include_archived"},
+	ReportProjectsBudget:  {"group_by", "interval", "owner_id", "status", "include_archived"},
+}
+
+type ReportFormat string
+
+const (
+	ReportFormatCSV ReportFormat = "csv"
+	ReportFormatPDF ReportFormat = "pdf"
+)
+
+var ReportFormats = []ReportFormat{ReportFormatCSV, ReportFormatPDF}
+
+func (f ReportFormat) Validate() error {
+	return validateEnum("format", f, ReportFormats)
+}
+
+const (
+	ReportDeliverySent   = "sent"
+	ReportDeliveryFailed = "failed"
+)
+
+// MaxReportRecipients caps the recipients of one subscription.
+const MaxReportRecipients = 20
+
+// ReportDeliveryTimeout bounds rendering and emailing one delivery.
+const ReportDeliveryTimeout = 2 * time.Minute
+
+var ErrReportSubscriptionNotFound = errors.New("report subscription not found")
+
+// ReportSubscription emails a report to Recipients whenever Schedule, a cron
+// expression in the application timezone, fires. Subscriptions are private
+// to the user who created them. NextRunAt is nil while the subscription is
+// inactive.
+type ReportSubscription struct {
+	ID         uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID    `json:"user_id" gorm:"type:uuid;index"`
+	Name       string       `json:"name"`
+	Report     ReportType   `json:"report"`
+	Format     ReportFormat `json:"format"`
+	Filters    StringMap    `json:"filters" gorm:"type:jsonb"`
+	Schedule   string       `json:"schedule"`
+	Recipients StringList   `json:"recipients" gorm:"type:jsonb"`
+	Active     bool         `json:"active"`
+	NextRunAt  *time.Time   `json:"next_run_at" gorm:"index"`
+	LastRunAt  *time.Time   `json:"last_run_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+	DeletedAt  *time.Time   `json:"deleted_at" gorm:"index"`
+}
+
+// ReportDelivery records one run of a subscription. Failed deliveries keep
+// the error; the subscription still moves on to its next run.
+type ReportDelivery struct {
+	ID             uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	SubscriptionID uuid.UUID    `json:"subscription_id" gorm:"type:uuid;index"`
+	Status         string       `json:"status"`
+	Format         ReportFormat `json:"format"`
+	Recipients     StringList   `json:"recipients" gorm:"type:jsonb"`
+	Rows           int          `json:"rows"`
+	Error          string       `json:"error"`
+	ScheduledFor   time.Time    `json:"scheduled_for"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+type ReportSubscriptionParams struct {
+	UserID uuid.UUID
+	Report ReportType
+}
+
+type ReportSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *ReportSubscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ReportSubscription, error)
+	List(ctx context.Context, params ReportSubscriptionParams, pagination Pagination) ([]ReportSubscription, error)
+	Update(ctx context.Context, subscription *ReportSubscription) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ListDue returns up to limit active subscriptions whose next run is at
+	// or before now, earliest first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]ReportSubscription, error)
+	// Claim moves a subscription due at due on to next, recording ranAt as
+	// its last run. It reports false when another worker got there first.
+	Claim(ctx context.Context, id uuid.UUID, due time.Time, next *time.Time, ranAt time.Time) (bool, error)
+	CreateDelivery(ctx context.Context, delivery *ReportDelivery) error
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, pagination Pagination) ([]ReportDelivery, error)
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionEntity names the records a retention rule applies to.
+type RetentionEntity string
+
+const (
+	RetentionNotification    RetentionEntity = "notification"
+	RetentionReportDelivery  RetentionEntity = "report_delivery"
+	RetentionProjectExport   RetentionEntity = "project_export"
+	RetentionStockAdjustment RetentionEntity = "stock_adjustment"
+	RetentionUser            RetentionEntity = "user"
+	RetentionProject         RetentionEntity = "project"
+	RetentionProjectItem     RetentionEntity = "project_item"
+)
+
+var RetentionEntities = []RetentionEntity{RetentionNotification, RetentionReportDelivery, RetentionProjectExport, RetentionStockAdjustment, RetentionUser, RetentionProject, RetentionProjectItem}
+
+func (e RetentionEntity) Validate() error {
+	return validateEnum("entity", e, RetentionEntities)
+}
+
+type RetentionAction string
+
+const (
+	// RetentionPurge deletes the records for good.
+	RetentionPurge RetentionAction = "purge"
+	// RetentionAnonymize strips personal data but keeps the record, so what
+	// references it stays intact.
+	RetentionAnonymize RetentionAction = "anonymize"
+	// RetentionArchive moves the records out of their table into the
+	// archive, where admins can still read them or put them back.
+	RetentionArchive RetentionAction = "archive"
+)
+
+// RetentionEntityActions lists the actions each entity supports, the first
+// one being the default. History records are purged once older than the
+// retention period; users are anonymized once they have not logged in for
+// it, admins excepted. Completed projects and items are archived once they
+// have not changed for it.
+var RetentionEntityActions = map[RetentionEntity][]RetentionAction{
+	RetentionNotification:    {RetentionPurge},
+	RetentionReportDelivery:  {RetentionPurge},
+	RetentionProjectExport:   {RetentionPurge},
+	RetentionStockAdjustment: {RetentionPurge, RetentionArchive},
+	RetentionUser:            {RetentionAnonymize},
+	RetentionProject:         {RetentionArchive},
+	RetentionProjectItem:     {RetentionArchive},
+}
+
+func (a RetentionAction) ValidateFor(entity RetentionEntity) error {
+	return validateEnum("action", a, RetentionEntityActions[entity])
+}
+
+const (
+	MinRetentionDays = 1
+	MaxRetentionDays = 36500
+)
+
+// RetentionBatchSize bounds the rows purged or anonymized per statement, so
+// a large backlog does not hold locks for long.
+const RetentionBatchSize = 1000
+
+var (
+	ErrRetentionRuleNotFound = errors.New("retention rule not found")
+	// ErrRetentionRuleExists is returned when the entity already has a rule.
+	ErrRetentionRuleExists    = errors.New("entity already has a retention rule")
+	ErrArchivedRecordNotFound = errors.New("archived record not found")
+	// ErrArchivedRecordOrphaned is returned when restoring a record whose
+	// project or product is no longer there, e.g. because it was archived
+	// too; that one has to be restored first.
+	ErrArchivedRecordOrphaned = errors.New("archived record's parent no longer exists")
+)
+
+// RetentionRule purges or anonymizes the records of Entity that are older
+// than RetainDays. Each entity has at most one rule.
+type RetentionRule struct {
+	ID         uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
+	Entity     RetentionEntity `json:"entity"`
+	Action     RetentionAction `json:"action"`
+	RetainDays int             `json:"retain_days"`
+	Enabled    bool            `json:"enabled"`
+	LastRunAt  *time.Time      `json:"last_run_at"`
+	CreatedBy  uuid.UUID       `json:"created_by" gorm:"type:uuid"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  *time.Time      `json:"deleted_at" gorm:"index"`
+}
+
+// Cutoff is the moment before which records fall under the rule at now.
+func (r *RetentionRule) Cutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -r.RetainDays)
+}
+
+// RetentionRun records one evaluation of a rule. A dry run only counts the
+// records that would be affected. TriggeredBy is nil for scheduled runs.
+type RetentionRun struct {
+	ID          uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
+	RuleID      uuid.UUID       `json:"rule_id" gorm:"type:uuid;index"`
+	Entity      RetentionEntity `json:"entity"`
+	Action      RetentionAction `json:"action"`
+	DryRun      bool            `json:"dry_run"`
+	Cutoff      time.Time       `json:"cutoff"`
+	Affected    int64           `json:"affected"`
+	Error       string          `json:"error"`
+	TriggeredBy *uuid.UUID      `json:"triggered_by" gorm:"type:uuid"`
+	StartedAt   time.Time       `json:"started_at"`
+	FinishedAt  time.Time       `json:"finished_at"`
+}
+
+type RetentionRunParams struct {
+	RuleID *uuid.UUID
+	DryRun *bool
+}
+
+// RetentionMetric totals the records a rule's entity has lost to retention,
+// counting real runs only.
+type RetentionMetric struct {
+	Entity    RetentionEntity `json:"entity"`
+	Action    RetentionAction `json:"action"`
+	Runs      int64           `json:"runs"`
+	Failed    int64           `json:"failed"`
+	Affected  int64           `json:"affected"`
+	LastRunAt *time.Time      `json:"last_run_at"`
+}
+
+// ArchivedRecord is a record an archive rule moved out of its table.
+// Payload holds the row as JSON under the entity's name together with the
+// rows that go with it: a project keeps its items, their assignment
+// history and its expenses, and an item its assignment history. ProjectID
+// is set for projects and items.
+type ArchivedRecord struct {
+	Entity     RetentionEntity `json:"entity" gorm:"primaryKey"`
+	RecordID   uuid.UUID       `json:"record_id" gorm:"type:uuid;primaryKey"`
+	ProjectID  *uuid.UUID      `json:"project_id" gorm:"type:uuid;index"`
+	RuleID     *uuid.UUID      `json:"rule_id" gorm:"type:uuid"`
+	Payload    json.RawMessage `json:"payload" gorm:"type:jsonb" swaggertype:"object"`
+	ArchivedAt time.Time       `json:"archived_at" gorm:"index"`
+}
+
+type ArchivedRecordParams struct {
+	Entity       RetentionEntity
+	ProjectID    *uuid.UUID
+	ArchivedFrom *time.Time
+	ArchivedTo   *time.Time
+}
+
+type RetentionRepository interface {
+	// CreateRule stores the rule and returns ErrRetentionRuleExists when
+	// its entity already has one.
+	CreateRule(ctx context.Context, rule *RetentionRule) error
+	GetRule(ctx context.Context, id uuid.UUID) (*RetentionRule, error)
+	ListRules(ctx context.Context) ([]RetentionRule, error)
+	UpdateRule(ctx context.Context, rule *RetentionRule) error
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	// ClaimRule records ranAt as the rule's last run, provided it was last
+	// run at previous, and reports false when another worker got there first.
+	ClaimRule(ctx context.Context, id uuid.UUID, previous *time.Time, ranAt time.Time) (bool, error)
+	// Apply purges, anonymizes or archives the rule's records older than
+	// cutoff, or only counts them on a dry run, and returns how many were
+	// affected.
+	Apply(ctx context.Context, rule *RetentionRule, cutoff time.Time, dryRun bool) (int64, error)
+	CreateRun(ctx context.Context, run *RetentionRun) error
+	ListRuns(ctx context.Context, params RetentionRunParams, pagination Pagination) ([]RetentionRun, error)
+	Metrics(ctx context.Context) ([]RetentionMetric, error)
+	ListArchived(ctx context.Context, params ArchivedRecordParams, pagination Pagination) ([]ArchivedRecord, error)
+	GetArchived(ctx context.Context, entity RetentionEntity, id uuid.UUID) (*ArchivedRecord, error)
+	// Restore puts an archived record and the rows archived with it back
+	// into their tables and drops it from the archive.
+	Restore(ctx context.Context, entity RetentionEntity, id uuid.UUID) error
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevokedToken blocks an access token before it expires, after its owner
+// logged out. Only the SHA-256 hash of the token is stored, and the entry
+// is useless once ExpiresAt passes since the token is rejected anyway.
+type RevokedToken struct {
+	TokenHash string    `gorm:"primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid"`
+	ExpiresAt time.Time `gorm:"index"`
+	RevokedAt time.Time
+}
+
+type RevokedTokenRepository interface {
+	// Revoke stores the entry; revoking a token twice is not an error.
+	Revoke(ctx context.Context, token *RevokedToken) error
+	IsRevoked(ctx context.Context, tokenHash string) (bool, error)
+	// DeleteExpired forgets the entries of tokens that expired before now.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedFilterEntity names the list endpoint a saved filter runs against.
+type SavedFilterEntity string
+
+const (
+	SavedFilterProduct       SavedFilterEntity = "product"
+	SavedFilterProject       SavedFilterEntity = "project"
+	SavedFilterProjectItem   SavedFilterEntity = "project_item"
+	SavedFilterUser          SavedFilterEntity = "user"
+	SavedFilterCoupon        SavedFilterEntity = "coupon"
+	SavedFilterPurchaseOrder SavedFilterEntity = "purchase_order"
+)
+
+var SavedFilterEntities = []SavedFilterEntity{SavedFilterProduct, SavedFilterProject, SavedFilterProjectItem, SavedFilterUser, SavedFilterCoupon, SavedFilterPurchaseOrder}
+
+func (e SavedFilterEntity) Validate() error {
+	return validateEnum("entity", e, SavedFilterEntities)
+}
+
+// Sort is what the entity's list endpoint can sort by.
+func (e SavedFilterEntity) Sort() SortSpec {
+	switch e {
+	case SavedFilterProduct:
+		return ProductSort
+	case SavedFilterProject:
+		return ProjectSort
+	case SavedFilterProjectItem:
+		return ProjectItemSort
+	case SavedFilterUser:
+		return UserSort
+	case SavedFilterCoupon:
+		return CouponSort
+	case SavedFilterPurchaseOrder:
+		return PurchaseOrderSort
+	}
+	return SortSpec{}
+}
+
+var (
+	ErrSavedFilterNotFound = errors.New("saved filter not found")
+	// ErrSavedFilterForbidden is returned when a user changes a filter shared
+	// with them by someone else.
+	ErrSavedFilterForbidden = errors.New("only the owner can change a saved filter")
+)
+
+// SavedFilter is a named set of list query parameters and a sort order that
+// can be applied to the entity's list endpoint by ID. Shared filters are
+// visible to every user but can only be changed by their owner.
+type SavedFilter struct {
+	ID        uuid.UUID         `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID         `json:"user_id" gorm:"type:uuid;index"`
+	Name      string            `json:"name"`
+	Entity    SavedFilterEntity `json:"entity"`
+	Query     StringMap         `json:"query" gorm:"type:jsonb"`
+	Sort      string            `json:"sort"`
+	Shared    bool              `json:"shared"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	DeletedAt *time.Time        `json:"deleted_at" gorm:"index"`
+}
+
+// SavedFilterParams selects the filters visible to UserID: their own and
+// the shared ones.
+type SavedFilterParams struct {
+	UserID uuid.UUID
+	Entity SavedFilterEntity
+}
+
+// StringMap is a string to string map stored as a JSONB object.
+type StringMap map[string]string
+
+func (m StringMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(map[string]string(m))
+	return string(data), err
+}
+
+func (m *StringMap) Scan(src interface{}) error {
+	return scanJSON(src, m)
+}
+
+type SavedFilterRepository interface {
+	Create(ctx context.Context, filter *SavedFilter) error
+	GetByID(ctx context.Context, id uuid.UUID) (*SavedFilter, error)
+	List(ctx context.Context, params SavedFilterParams, pagination Pagination) ([]SavedFilter, error)
+	Update(ctx context.Context, filter *SavedFilter) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "strings"
+
+const (
+	ScopeActionRead  = "read"
+	ScopeActionWrite = "write"
+	ScopeAll         = "*"
+)
+
+// ScopesAllow reports whether a token restricted to scopes may perform action
+// on resource. Scopes have the form "<action>:<resource>" where either part may
+// be "*", and write access implies read access.
+func ScopesAllow(scopes []string, action, resource string) bool {
+	for _, scope := range scopes {
+		if scope == ScopeAll {
+			return true
+		}
+
+		scopeAction, scopeResource, ok := strings.Cut(scope, ":")
+		if !ok {
+			continue
+		}
+
+		actionMatches := scopeAction == ScopeAll || scopeAction == action || (scopeAction == ScopeActionWrite && action == ScopeActionRead)
+		resourceMatches := scopeResource == ScopeAll || scopeResource == resource
+		if actionMatches && resourceMatches {
+			return true
+		}
+	}
+	return false
+}
+
+func ValidScope(scope string) bool {
+	if scope == ScopeAll {
+		return true
+	}
+	action, resource, ok := strings.Cut(scope, ":")
+	if !ok || resource == "" {
+		return false
+	}
+	return action == ScopeAll || action == ScopeActionRead || action == ScopeActionWrite
+}
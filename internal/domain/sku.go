@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const DefaultSKUPattern = "{CAT}-{SEQ}"
+
+const defaultSKUSequenceWidth = 6
+
+const MaxSKULength = 64
+
+var skuTokenPattern = regexp.MustCompile(`\{([A-Z]+)(?::(\d+))?\}`)
+
+var skuFormat = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// SKUSequence holds the last number handed out for one SKU prefix. Rows are
+// incremented atomically so concurrent product creation never reuses a value.
+type SKUSequence struct {
+	Prefix    string `gorm:"primaryKey"`
+	LastValue int64
+}
+
+// SKU identifies a product. Values built by ParseSKU or decoded from JSON
+// are always well formed.
+type SKU string
+
+// ParseSKU trims raw and checks that it starts with a letter or digit,
+// continues with letters, digits, '.', '_', '/' or '-' and is at most
+// MaxSKULength long.
+func ParseSKU(raw string) (SKU, error) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) > MaxSKULength || !skuFormat.MatchString(raw) {
+		return "", fmt.Errorf("sku %q must be up to %d letters, digits, '.', '_', '/' or '-', starting with a letter or digit", raw, MaxSKULength)
+	}
+	return SKU(raw), nil
+}
+
+func (s SKU) String() string {
+	return string(s)
+}
+
+// UnmarshalJSON rejects malformed SKUs. An empty string decodes to the zero
+// value, which asks for a generated SKU on creation.
+func (s *SKU) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if strings.TrimSpace(raw) == "" {
+		*s = ""
+		return nil
+	}
+	sku, err := ParseSKU(raw)
+	if err != nil {
+		return err
+	}
+	*s = sku
+	return nil
+}
+
+// ValidateSKUPattern checks that pattern only uses the supported tokens
+// ({CAT}, {SEQ}, {SEQ:n} and {YYYY}) and contains exactly one sequence.
+func ValidateSKUPattern(pattern string) error {
+	sequences := 0
+	for _, match := range skuTokenPattern.FindAllStringSubmatch(pattern, -1) {
+		switch match[1] {
+		case "SEQ":
+			sequences++
+			if match[2] != "" {
+				if width, _ := strconv.Atoi(match[2]); width < 1 || width > 18 {
+					return fmt.Errorf("sku pattern %q: sequence width must be between 1 and 18", pattern)
+				}
+			}
+		case "CAT", "YYYY":
+			if match[2] != "" {
+				return fmt.Errorf("sku pattern %q: {%s} does not take a width", pattern, match[1])
+			}
+		default:
+			return fmt.Errorf("sku pattern %q: unknown token {%s}", pattern, match[1])
+		}
+	}
+	if sequences != 1 {
+		return fmt.Errorf("sku pattern %q must contain exactly one {SEQ} token", pattern)
+	}
+	return nil
+}
+
+// SKUCategoryCode abbreviates a category to the first three letters or digits,
+// upper-cased, falling back to GEN for empty categories.
+func SKUCategoryCode(category string) string {
+	var code strings.Builder
+	for _, r := range strings.ToUpper(category) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			code.WriteRune(r)
+			if code.Len() == 3 {
+				break
+			}
+		}
+	}
+	if code.Len() == 0 {
+		return "GEN"
+	}
+	return code.String()
+}
+
+// RenderSKU expands pattern for category using seq as the sequence value.
+func RenderSKU(pattern, category string, seq int64, now time.Time) SKU {
+	return SKU(skuTokenPattern.ReplaceAllStringFunc(pattern, func(token string) string {
+		match := skuTokenPattern.FindStringSubmatch(token)
+		switch match[1] {
+		case "CAT":
+			return SKUCategoryCode(category)
+		case "YYYY":
+			return strconv.Itoa(now.Year())
+		case "SEQ":
+			width := defaultSKUSequenceWidth
+			if match[2] != "" {
+				width, _ = strconv.Atoi(match[2])
+			}
+			return fmt.Sprintf("%0*d", width, seq)
+		}
+		return token
+	}))
+}
+
+// SKUSequencePrefix is the key sequences are counted under: the pattern with
+// every token except {SEQ} expanded, so each category (and year, when the
+// pattern includes one) numbers its products independently.
+func SKUSequencePrefix(pattern, category string, now time.Time) string {
+	return skuTokenPattern.ReplaceAllStringFunc(pattern, func(token string) string {
+		if strings.HasPrefix(token, "{SEQ") {
+			return "{SEQ}"
+		}
+		return RenderSKU(token, category, 0, now).String()
+	})
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortSpec is what a list endpoint accepts in its sort parameter. Fields
+// maps the field names clients use to the columns they order by; Key is the
+// unique column appended as a tie-breaker so pagination stays stable, id
+// when empty.
+type SortSpec struct {
+	Fields map[string]string
+	Key    string
+}
+
+// Parse turns a "field [asc|desc]" sort value into an ORDER BY clause that
+// only names whitelisted columns, so it can be handed to the database as is.
+// An unknown field or direction is an *InvalidValueError.
+func (s SortSpec) Parse(value string) (string, error) {
+	parts := strings.Fields(strings.ToLower(value))
+	if len(parts) == 0 || len(parts) > 2 {
+		return "", &InvalidValueError{Field: "sort", Value: value, Allowed: s.names()}
+	}
+
+	field, direction := parts[0], "asc"
+	if len(parts) == 2 {
+		direction = parts[1]
+	}
+	if direction != "asc" && direction != "desc" {
+		return "", &InvalidValueError{Field: "sort direction", Value: direction, Allowed: []string{"asc", "desc"}}
+	}
+
+	column, ok := s.Fields[field]
+	if !ok {
+		return "", &InvalidValueError{Field: "sort", Value: field, Allowed: s.names()}
+	}
+
+	key := s.Key
+	if key == "" {
+		key = "id"
+	}
+	if column == key {
+		return fmt.Sprintf("%s %s", column, direction), nil
+	}
+	return fmt.Sprintf("%s %s NULLS LAST, %s %s", column, direction, key, direction), nil
+}
+
+func (s SortSpec) names() []string {
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortColumns is a SortSpec field map whose field names are the column
+// names themselves.
+func sortColumns(columns ...string) map[string]string {
+	fields := make(map[string]string, len(columns))
+	for _, column := range columns {
+		fields[column] = column
+	}
+	return fields
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StockReasonDamage  = "damage"
+	StockReasonRecount = "recount"
+	StockReasonSale    = "sale"
+	StockReasonReturn  = "return"
+	// StockReasonPurchase is only written when a purchase order is received.
+	StockReasonPurchase = "purchase"
+	// StockReasonImport is only written when a product import sets the
+	// stock of a product.
+	StockReasonImport = "import"
+	// StockReasonTransfer only appears in stock movements, for stock moved
+	// between warehouses.
+	StockReasonTransfer = "transfer"
+)
+
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// StockAdjustment is one entry of a product's stock ledger. Quantity is the
+// signed change that was applied; StockBefore and StockAfter record the
+// product's total level around it so the history can be audited without
+// replaying it. When WarehouseID is set the change is also applied to that
+// warehouse's level.
+type StockAdjustment struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ProductID   uuid.UUID  `json:"product_id" gorm:"type:uuid;index"`
+	WarehouseID *uuid.UUID `json:"warehouse_id" gorm:"type:uuid"`
+	Quantity    int        `json:"quantity"`
+	Reason      string     `json:"reason"`
+	Note        string     `json:"note"`
+	ActorID     uuid.UUID  `json:"actor_id" gorm:"type:uuid"`
+	StockBefore int        `json:"stock_before"`
+	StockAfter  int        `json:"stock_after"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// StockAdjustmentSort is what a product's stock adjustment list can sort by.
+var StockAdjustmentSort = SortSpec{Fields: sortColumns("quantity", "reason", "created_at")}
+
+// ValidateStockAdjustment checks the reason code and that the sign of quantity
+// fits it: damage and sale remove stock, return and purchase add it, recount
+// may go either way.
+func ValidateStockAdjustment(reason string, quantity int) error {
+	if quantity == 0 {
+		return errors.New("quantity must not be zero")
+	}
+	switch reason {
+	case StockReasonDamage, StockReasonSale:
+		if quantity > 0 {
+			return errors.New(reason + " adjustments must have a negative quantity")
+		}
+	case StockReasonReturn, StockReasonPurchase:
+		if quantity < 0 {
+			return errors.New(reason + " adjustments must have a positive quantity")
+		}
+	case StockReasonRecount:
+	default:
+		return errors.New("reason must be one of damage, recount, sale, return, purchase")
+	}
+	return nil
+}
+
+// StockMovement is one entry of a product's movement history: a stock
+// adjustment, which changes the product total, or a transfer between
+// warehouses, which does not. Quantity is the signed change to the total
+// for adjustments and the quantity moved for transfers; StockBefore and
+// StockAfter are only set for adjustments. FromWarehouseID and
+// ToWarehouseID name the locations stock left and entered, if any.
+type StockMovement struct {
+	ID              uuid.UUID  `json:"id"`
+	ProductID       uuid.UUID  `json:"product_id"`
+	Reason          string     `json:"reason"`
+	Quantity        int        `json:"quantity"`
+	FromWarehouseID *uuid.UUID `json:"from_warehouse_id"`
+	ToWarehouseID   *uuid.UUID `json:"to_warehouse_id"`
+	StockBefore     *int       `json:"stock_before"`
+	StockAfter      *int       `json:"stock_after"`
+	Note            string     `json:"note"`
+	ActorID         uuid.UUID  `json:"actor_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// StockMovementParams filters a product's movement history. WarehouseID
+// matches movements into or out of that warehouse.
+type StockMovementParams struct {
+	Reason      string
+	WarehouseID *uuid.UUID
+}
+
+// StockMovementSort is what a product's stock movement list can sort by.
+var StockMovementSort = SortSpec{Fields: sortColumns("quantity", "reason", "created_at")}
+
+type StockAdjustmentRepository interface {
+	// Apply locks the product, applies adj.Quantity to its stock (and to the
+	// warehouse level when adj.WarehouseID is set) and records adj with the
+	// resulting levels, all in one transaction.
+	Apply(ctx context.Context, adj *StockAdjustment) error
+	ListByProduct(ctx context.Context, productID uuid.UUID, pagination Pagination) ([]StockAdjustment, error)
+	// ListMovements returns the adjustments and warehouse transfers of a
+	// product as one history.
+	ListMovements(ctx context.Context, productID uuid.UUID, filter StockMovementParams, pagination Pagination) ([]StockMovement, error)
+}
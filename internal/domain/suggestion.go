@@ -0,0 +1,16 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Suggestion limits for the typeahead endpoints.
+const (
+	DefaultSuggestionLimit = 10
+	MaxSuggestionLimit     = 25
+)
+
+// Suggestion is one entry of a typeahead list: just enough for a picker to
+// show and select a record.
+type Suggestion struct {
+	ID    uuid.UUID `json:"id"`
+	Label string    `json:"label"`
+}
@@ -2,38 +2,190 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Account statuses an admin can filter users by.
+const (
+	UserStatusActive      = "active"
+	UserStatusSuspended   = "suspended"
+	UserStatusDeactivated = "deactivated"
+	UserStatusDeleted     = "deleted"
+)
+
+// MaxStaleDays bounds the inactivity window of the stale-account report.
+const MaxStaleDays = 3650
+
+// DefaultAccountDeletionGrace is how long a deleted account can be restored
+// before its personal data is erased.
+const DefaultAccountDeletionGrace = 30 * 24 * time.Hour
+
+// AccountErasureInterval is how often accounts past their grace period are
+// looked for.
+const AccountErasureInterval = time.Hour
+
+// AccountErasureBatchSize bounds the accounts erased per pass.
+const AccountErasureBatchSize = 100
+
+var (
+	ErrUserInactive    = errors.New("account is deactivated or suspended")
+	ErrPasswordExpired = errors.New("password expired")
+	ErrAccountDeleted  = errors.New("account has been deleted")
+	// ErrAccountNotFound is returned by GetAccount when no account, deleted
+	// or not, has the ID.
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrEmailTaken is returned when another account, deleted ones
+	// included, already uses the email.
+	ErrEmailTaken = errors.New("email already registered")
+	// ErrAccountNotRestorable is returned for accounts that were not deleted
+	// on request or whose grace period is over.
+	ErrAccountNotRestorable = errors.New("account cannot be restored")
+)
+
+// User accounts can be switched off in two ways: Active false deactivates the
+// account until an admin reactivates it, while SuspendedUntil blocks it only
+// until that instant.
 type User struct {
-	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
-	Name         string     `json:"name"`
-	Email        string     `json:"email" gorm:"uniqueIndex"`
-	PasswordHash string     `json:"-"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
-	DeletedAt    *time.Time `json:"deleted_at" gorm:"index"`
+	ID           uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey"`
+	Name         string       `json:"name"`
+	Email        EmailAddress `json:"email" gorm:"uniqueIndex"`
+	PasswordHash string       `json:"-"`
+	Role         string       `json:"role" gorm:"not null;default:user;index"`
+	// Locale is the language of the emails sent to the user.
+	Locale         Locale     `json:"locale" gorm:"not null;default:en" binding:"omitempty,enum"`
+	Active         bool       `json:"active" gorm:"not null;default:true"`
+	SuspendedUntil *time.Time `json:"suspended_until"`
+	LastLoginAt    *time.Time `json:"last_login_at"`
+	LoginCount     int        `json:"login_count" gorm:"not null;default:0"`
+	// PasswordChangedAt is nil for accounts created before it was tracked;
+	// their password age counts from CreatedAt.
+	PasswordChangedAt *time.Time `json:"password_changed_at"`
+	// MFAEnabled asks for a TOTP code after the password. MFASecret is set
+	// at enrollment and only enables MFA once a code from it is confirmed.
+	MFAEnabled bool   `json:"mfa_enabled" gorm:"not null;default:false"`
+	MFASecret  string `json:"-" gorm:"not null;default:''"`
+	// LockedUntil is set when failed logins locked the account; password
+	// logins are refused until then.
+	LockedUntil *time.Time `json:"locked_until"`
+	// AnonymizedAt is set once a retention rule or an account deletion has
+	// replaced the account's personal data.
+	AnonymizedAt *time.Time `json:"anonymized_at"`
+	// EraseAfter is set when the account's deletion was requested. Until then
+	// the account can be restored; afterwards its personal data is erased.
+	EraseAfter *time.Time `json:"erase_after" gorm:"index"`
+	Version    int        `json:"version" gorm:"not null;default:1"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at" gorm:"index"`
+}
+
+// CanSignIn reports whether the account may log in and use its tokens at now.
+func (u *User) CanSignIn(now time.Time) bool {
+	return u.DeletedAt == nil && u.Active && (u.SuspendedUntil == nil || !now.Before(*u.SuspendedUntil))
+}
+
+// PasswordExpired reports whether the password is at least maxAge old at
+// now. A zero maxAge disables expiry.
+func (u *User) PasswordExpired(now time.Time, maxAge time.Duration) bool {
+	if maxAge <= 0 {
+		return false
+	}
+	changedAt := u.CreatedAt
+	if u.PasswordChangedAt != nil {
+		changedAt = *u.PasswordChangedAt
+	}
+	return !now.Before(changedAt.Add(maxAge))
 }
 
 type Params struct {
 	Name          string
 	Email         string
+	Role          string
 	CreatedAtFrom *time.Time
 	CreatedAtTo   *time.Time
+	ActiveOnly    bool
+	// LastSeenBefore matches accounts whose last login, or creation when they
+	// never logged in, is before the given time.
+	LastSeenBefore *time.Time
+	LastLoginFrom  *time.Time
+	LastLoginTo    *time.Time
+	// Status is one of the UserStatus constants. UserStatusDeleted implies
+	// IncludeDeleted.
+	Status         string
+	IncludeDeleted bool
 }
 
+// UserSort is what GET /v1/users can sort by.
+var UserSort = SortSpec{Fields: sortColumns("name", "email", "role", "created_at", "updated_at", "last_login_at")}
+
+// AdminUserSort is what GET /v1/admin/users can sort by, which includes the
+// columns only admins see.
+var AdminUserSort = SortSpec{Fields: sortColumns("name", "email", "role", "created_at", "updated_at", "last_login_at", "login_count", "deleted_at")}
+
+// UserPatch is what PATCH /v1/users/{id} can change.
+var UserPatch = PatchSpec{Fields: []string{"name", "email", "role", "locale"}}
+
+// DefaultMaxPageSize is the largest limit list endpoints accept unless
+// configured otherwise.
+const DefaultMaxPageSize = 100
+
+// Pagination selects a page of a list. AfterID pages by key instead of by
+// offset: only rows whose id sorts after it are returned, which stays fast
+// however deep the page. The user, product, project and project item
+// repositories honor it; sort by id when setting it.
 type Pagination struct {
-	Limit  int
-	Offset int
-	Sort   string
+	Limit   int
+	Offset  int
+	Sort    string
+	AfterID *uuid.UUID
 }
 
 type UserRepository interface {
+	// Create stores the user, returning ErrEmailTaken when the email is in
+	// use.
 	Create(ctx context.Context, user *User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
 	List(ctx context.Context, filter Params, pagination Pagination) ([]User, error)
-	Update(ctx context.Context, user *User) error
+	// Count returns how many records List matches for filter, ignoring
+	// pagination.
+	Count(ctx context.Context, filter Params) (int64, error)
+	// Update stores the non-zero fields of user, or with fields exactly
+	// those columns, zero or not, and updated_at. It only applies while the
+	// stored version is user.Version, unless that is zero, and moves it up by one;
+	// otherwise it returns ErrVersionConflict.
+	Update(ctx context.Context, user *User, fields ...string) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	SetStatus(ctx context.Context, id uuid.UUID, active bool, suspendedUntil *time.Time) error
+	RecordLogin(ctx context.Context, id uuid.UUID, at time.Time) error
+	SetPassword(ctx context.Context, id uuid.UUID, passwordHash string, changedAt time.Time) error
+	// SetMFA stores the TOTP secret of the user and whether codes are
+	// required at login.
+	SetMFA(ctx context.Context, id uuid.UUID, secret string, enabled bool, at time.Time) error
+	SetLockedUntil(ctx context.Context, id uuid.UUID, lockedUntil *time.Time) error
+	// GetAccount returns the user even when soft deleted.
+	GetAccount(ctx context.Context, id uuid.UUID) (*User, error)
+	// GetDeletedByEmail returns the soft deleted, not yet anonymized account
+	// registered with email.
+	GetDeletedByEmail(ctx context.Context, email string) (*User, error)
+	// RequestDeletion soft deletes the account and schedules its erasure.
+	RequestDeletion(ctx context.Context, id uuid.UUID, deletedAt, eraseAfter time.Time) error
+	// Restore undoes a requested deletion, provided its erasure is still
+	// after now.
+	Restore(ctx context.Context, id uuid.UUID, now time.Time) error
+	// ListErasable returns up to limit accounts whose erasure is due at now.
+	ListErasable(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+	// Suggest returns up to limit users who can sign in at now and whose
+	// name contains query or whose email starts with it, best matches first.
+	Suggest(ctx context.Context, query string, now time.Time, limit int) ([]Suggestion, error)
+	// Erase replaces the account's personal data with placeholders and
+	// deletes the records private to it, keeping what others reference.
+	Erase(ctx context.Context, id uuid.UUID, at time.Time) error
 }
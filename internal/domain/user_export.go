@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserExportStatusPending = "pending"
+	UserExportStatusReady   = "ready"
+	UserExportStatusFailed  = "failed"
+)
+
+const (
+	// DefaultUserExportTTL is how long a finished export stays downloadable.
+	DefaultUserExportTTL = 7 * 24 * time.Hour
+	// UserExportTimeout bounds assembling one archive.
+	UserExportTimeout = 5 * time.Minute
+	// UserExportCleanupInterval is how often expired archives are deleted.
+	UserExportCleanupInterval = time.Hour
+)
+
+var (
+	ErrUserExportNotFound = errors.New("user export not found")
+	ErrUserExportNotReady = errors.New("user export is not ready")
+	ErrUserExportExpired  = errors.New("user export has expired")
+	// ErrUserExportInProgress is returned when the user already has an
+	// export being assembled.
+	ErrUserExportInProgress = errors.New("a user export is already in progress")
+)
+
+// UserExport is a zip archive of everything stored about a user, assembled
+// in the background at their request. Content is dropped once the export
+// expires.
+type UserExport struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error"`
+	Content     []byte     `json:"-"`
+	Size        int        `json:"size"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+// Expired reports whether the export can no longer be downloaded at now.
+func (e *UserExport) Expired(now time.Time) bool {
+	return e.ExpiresAt != nil && !now.Before(*e.ExpiresAt)
+}
+
+// UserData is every record tied to a user: the ones they own, are assigned
+// or created, and their own settings and inbox. Each field becomes one file
+// of the export archive.
+type UserData struct {
+	Profile             User                    `json:"profile"`
+	Projects            []Project               `json:"projects"`
+	ProjectItems        []ProjectItem           `json:"project_items"`
+	Assignments         []ProjectItemAssignment `json:"assignments"`
+	Expenses            []Expense               `json:"expenses"`
+	StockAdjustments    []StockAdjustment       `json:"stock_adjustments"`
+	PurchaseOrders      []PurchaseOrder         `json:"purchase_orders"`
+	Orders              []Order                 `json:"orders"`
+	Watches             []Watch                 `json:"watches"`
+	Notifications       []Notification          `json:"notifications"`
+	Devices             []Device                `json:"devices"`
+	SavedFilters        []SavedFilter           `json:"saved_filters"`
+	ReportSubscriptions []ReportSubscription    `json:"report_subscriptions"`
+}
+
+type UserExportRepository interface {
+	Create(ctx context.Context, export *UserExport) error
+	GetByID(ctx context.Context, id uuid.UUID) (*UserExport, error)
+	// List returns the user's exports, newest first.
+	List(ctx context.Context, userID uuid.UUID, pagination Pagination) ([]UserExport, error)
+	// HasPending reports whether an export the user requested after since
+	// is still being assembled.
+	HasPending(ctx context.Context, userID uuid.UUID, since time.Time) (bool, error)
+	Complete(ctx context.Context, id uuid.UUID, content []byte, completedAt, expiresAt time.Time) error
+	Fail(ctx context.Context, id uuid.UUID, reason string, completedAt time.Time) error
+	// DeleteExpired removes the exports that expired before now and returns
+	// how many there were.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+	// CollectUserData loads every record tied to the user.
+	CollectUserData(ctx context.Context, userID uuid.UUID) (*UserData, error)
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "strings"
+
+// FieldError is one request field that failed validation. Allowed lists the
+// accepted values when the field is an enum.
+type FieldError struct {
+	Field   string   `json:"field"`
+	Message string   `json:"message"`
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+// ValidationErrors collects every invalid field of a request, so clients can
+// fix them all at once instead of one round trip per field.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, field := range e {
+		messages[i] = field.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Has reports whether field is among the invalid fields.
+func (e ValidationErrors) Has(field string) bool {
+	for _, err := range e {
+		if err.Field == field {
+			return true
+		}
+	}
+	return false
+}
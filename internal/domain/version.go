@@ -0,0 +1,9 @@
+package domain
+
+import "errors"
+
+// ErrVersionConflict is returned when an update names a version other than
+// the stored one: someone else changed the record since it was read, and
+// writing over it would lose their change. Users, products, projects and
+// project items carry a Version that every update moves up by one.
+var ErrVersionConflict = errors.New("record was changed since it was read")
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInsufficientWarehouseStock = errors.New("insufficient stock in source location")
+	ErrWarehouseNotEmpty          = errors.New("warehouse still holds stock")
+)
+
+type Warehouse struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	Code      string     `json:"code" gorm:"uniqueIndex"`
+	Name      string     `json:"name"`
+	Address   string     `json:"address"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at" gorm:"index"`
+}
+
+type WarehouseParams struct {
+	Code string
+	Name string
+}
+
+// WarehouseSort is what GET /v1/warehouses can sort by.
+var WarehouseSort = SortSpec{Fields: sortColumns("code", "name", "created_at", "updated_at")}
+
+// WarehouseStockSort is what a warehouse's stock list can sort by. Rows are
+// keyed by product within a warehouse.
+var WarehouseStockSort = SortSpec{Fields: sortColumns("product_id", "quantity", "updated_at"), Key: "product_id"}
+
+// WarehouseStock is the quantity of one product held at one warehouse. The
+// product's Stock is the total across locations; whatever is not assigned to
+// a warehouse is reported as unallocated.
+type WarehouseStock struct {
+	WarehouseID uuid.UUID `json:"warehouse_id" gorm:"type:uuid;primaryKey"`
+	ProductID   uuid.UUID `json:"product_id" gorm:"type:uuid;primaryKey;index"`
+	Quantity    int       `json:"quantity"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type WarehouseStockLevel struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseCode string    `json:"warehouse_code"`
+	Quantity      int       `json:"quantity"`
+}
+
+type ProductAvailability struct {
+	Total       int                   `json:"total"`
+	Unallocated int                   `json:"unallocated"`
+	Warehouses  []WarehouseStockLevel `json:"warehouses"`
+}
+
+func NewProductAvailability(total int, levels []WarehouseStockLevel) *ProductAvailability {
+	if levels == nil {
+		levels = []WarehouseStockLevel{}
+	}
+	unallocated := total
+	for _, level := range levels {
+		unallocated -= level.Quantity
+	}
+	return &ProductAvailability{
+		Total:       total,
+		Unallocated: unallocated,
+		Warehouses:  levels,
+	}
+}
+
+// StockTransfer moves quantity of a product between two locations. A nil
+// warehouse on either side stands for the unallocated pool, so transfers are
+// also how existing stock is first assigned to a warehouse.
+type StockTransfer struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ProductID       uuid.UUID  `json:"product_id" gorm:"type:uuid;index"`
+	FromWarehouseID *uuid.UUID `json:"from_warehouse_id" gorm:"type:uuid"`
+	ToWarehouseID   *uuid.UUID `json:"to_warehouse_id" gorm:"type:uuid"`
+	Quantity        int        `json:"quantity"`
+	Note            string     `json:"note"`
+	ActorID         uuid.UUID  `json:"actor_id" gorm:"type:uuid"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type WarehouseRepository interface {
+	Create(ctx context.Context, warehouse *Warehouse) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Warehouse, error)
+	GetByCode(ctx context.Context, code string) (*Warehouse, error)
+	List(ctx context.Context, filter WarehouseParams, pagination Pagination) ([]Warehouse, error)
+	Update(ctx context.Context, warehouse *Warehouse) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListStock(ctx context.Context, warehouseID uuid.UUID, pagination Pagination) ([]WarehouseStock, error)
+	// Transfer locks the product, moves stock between the two locations and
+	// records the transfer in one transaction.
+	Transfer(ctx context.Context, transfer *StockTransfer) error
+}
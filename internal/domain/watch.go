@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WatchTargetProject     = "project"
+	WatchTargetProjectItem = "project_item"
+)
+
+const (
+	ChangeEventCreated  = "created"
+	ChangeEventUpdated  = "updated"
+	ChangeEventDeleted  = "deleted"
+	ChangeEventAssigned = "assigned"
+	// ChangeEventCommented is a new comment on a project item.
+	ChangeEventCommented = "commented"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// Watch subscribes a user to the changes of a project or project item.
+type Watch struct {
+	UserID     uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	TargetType string    `json:"target_type" gorm:"primaryKey"`
+	TargetID   uuid.UUID `json:"target_id" gorm:"type:uuid;primaryKey;index"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Notification is an in-app message delivered to a watcher when something it
+// follows changes. ProjectID is always set so item notifications can be
+// grouped by project.
+type Notification struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	TargetType string     `json:"target_type"`
+	TargetID   uuid.UUID  `json:"target_id" gorm:"type:uuid"`
+	ProjectID  uuid.UUID  `json:"project_id" gorm:"type:uuid"`
+	Event      string     `json:"event"`
+	Message    string     `json:"message"`
+	ReadAt     *time.Time `json:"read_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ChangeNotifier fans project and project item changes out to their watchers.
+// Delivery is best effort: a failed fan-out never fails the change itself.
+type ChangeNotifier interface {
+	ProjectChanged(ctx context.Context, project *Project, event string)
+	ProjectItemChanged(ctx context.Context, item *ProjectItem, event string)
+	ProjectItemAssigned(ctx context.Context, item *ProjectItem)
+	ProjectItemCommented(ctx context.Context, item *ProjectItem, comment *Comment)
+}
+
+type WatchRepository interface {
+	Watch(ctx context.Context, watch *Watch) error
+	Unwatch(ctx context.Context, userID uuid.UUID, targetType string, targetID uuid.UUID) error
+	ListWatchers(ctx context.Context, targetType string, targetID uuid.UUID, pagination Pagination) ([]Watch, error)
+	SubscriberIDs(ctx context.Context, projectID uuid.UUID, itemID *uuid.UUID) ([]uuid.UUID, error)
+}
+
+type NotificationRepository interface {
+	CreateBatch(ctx context.Context, notifications []Notification) error
+	ListByUser(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination Pagination) ([]Notification, error)
+	MarkRead(ctx context.Context, userID, id uuid.UUID, readAt time.Time) error
+	// ListDueSoon returns up to limit open, assigned items due after now and
+	// within window whose assignee has not been sent a ChangeEventDueSoon
+	// notification for the current due date, earliest due first.
+	ListDueSoon(ctx context.Context, now time.Time, window time.Duration, limit int) ([]ProjectItem, error)
+}
@@ -0,0 +1,160 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	apnsProductionHost = "https://api.push.apple.com"
+	apnsSandboxHost    = "https://api.sandbox.push.apple.com"
+
+	// apnsTokenTTL is how long a provider token is reused. Apple rejects
+	// tokens older than an hour and refreshing more than every 20 minutes.
+	apnsTokenTTL = 50 * time.Minute
+)
+
+// APNSConfig identifies the signing key and app pushes are sent for. Topic
+// is the app's bundle ID; Production picks the production gateway over the
+// sandbox used by development builds.
+type APNSConfig struct {
+	KeyFile    string
+	KeyID      string
+	TeamID     string
+	Topic      string
+	Production bool
+}
+
+// APNSSender pushes through the Apple Push Notification service with
+// token-based authentication, signing provider tokens with a .p8 key.
+type APNSSender struct {
+	config APNSConfig
+	host   string
+	signer any
+	client *HTTPClient
+	logger *logrus.Logger
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+func NewAPNSSender(config APNSConfig, client *HTTPClient) (*APNSSender, error) {
+	data, err := os.ReadFile(config.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read APNS key: %w", err)
+	}
+	key, err := jwt.ParseECPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse APNS key: %w", err)
+	}
+
+	host := apnsSandboxHost
+	if config.Production {
+		host = apnsProductionHost
+	}
+	return &APNSSender{
+		config: config,
+		host:   host,
+		signer: key,
+		client: client,
+		logger: WithRedaction(logrus.New()),
+	}, nil
+}
+
+func (s *APNSSender) Send(ctx context.Context, token string, message domain.PushMessage) error {
+	providerToken, err := s.providerToken()
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"aps": map[string]any{
+			"alert": map[string]string{
+				"title": message.Title,
+				"body":  message.Body,
+			},
+			"sound": "default",
+		},
+	}
+	for key, value := range message.Data {
+		if key != "aps" {
+			payload[key] = value
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.host+"/3/device/"+token, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "bearer "+providerToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apns-topic", s.config.Topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-id", message.ID.String())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	var failure struct {
+		Reason string `json:"reason"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&failure)
+	if resp.StatusCode == http.StatusGone || failure.Reason == "BadDeviceToken" || failure.Reason == "Unregistered" {
+		return domain.ErrPushTokenInvalid
+	}
+	return fmt.Errorf("apns send failed with status %d: %s", resp.StatusCode, failure.Reason)
+}
+
+// providerToken returns the current provider token, signing a new one once
+// it is apnsTokenTTL old.
+func (s *APNSSender) providerToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.token != "" && now.Sub(s.issuedAt) < apnsTokenTTL {
+		return s.token, nil
+	}
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": s.config.TeamID,
+		"iat": now.Unix(),
+	})
+	jwtToken.Header["kid"] = s.config.KeyID
+	signed, err := jwtToken.SignedString(s.signer)
+	if err != nil {
+		return "", fmt.Errorf("sign APNS provider token: %w", err)
+	}
+
+	s.token = signed
+	s.issuedAt = now
+	s.logger.WithFields(logrus.Fields{
+		"key_id": s.config.KeyID,
+	}).Debug("APNS provider token refreshed")
+
+	return s.token, nil
+}
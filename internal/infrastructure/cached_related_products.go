@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+)
+
+type relatedEntry struct {
+	products  []domain.Product
+	expiresAt time.Time
+}
+
+// CachedRelatedProducts reuses the recommendations of another strategy for
+// ttl, holding at most maxEntries. Entries are keyed by the product's
+// UpdatedAt too, so editing a product refreshes its own recommendations at
+// once; changes to the recommended products show up when entries expire.
+// Like MemoryResponseCache, it is only coherent within one instance.
+type CachedRelatedProducts struct {
+	strategy   domain.RelatedProductsStrategy
+	ttl        time.Duration
+	maxEntries int
+	clock      domain.Clock
+
+	mu      sync.Mutex
+	entries map[string]relatedEntry
+}
+
+func NewCachedRelatedProducts(strategy domain.RelatedProductsStrategy, ttl time.Duration, maxEntries int) *CachedRelatedProducts {
+	return &CachedRelatedProducts{
+		strategy:   strategy,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		clock:      domain.SystemClock{},
+		entries:    make(map[string]relatedEntry),
+	}
+}
+
+func (c *CachedRelatedProducts) WithClock(clock domain.Clock) *CachedRelatedProducts {
+	c.clock = clock
+	return c
+}
+
+func (c *CachedRelatedProducts) Related(ctx context.Context, product *domain.Product, limit int) ([]domain.Product, error) {
+	key := fmt.Sprintf("%s:%d:%d", product.ID, product.UpdatedAt.UnixNano(), limit)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.clock.Now().Before(entry.expiresAt) {
+		return entry.products, nil
+	}
+
+	products, err := c.strategy.Related(ctx, product, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < c.maxEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = relatedEntry{products: products, expiresAt: now.Add(c.ttl)}
+	return products, nil
+}
@@ -0,0 +1,141 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// slackEscaper escapes the characters Slack's mrkdwn treats as markup.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// WebhookChatPoster posts messages to Slack and Microsoft Teams incoming
+// webhooks, as Block Kit blocks and Adaptive Cards respectively.
+type WebhookChatPoster struct {
+	client *HTTPClient
+	logger *logrus.Logger
+}
+
+func NewWebhookChatPoster(client *HTTPClient) *WebhookChatPoster {
+	return &WebhookChatPoster{
+		client: client,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (p *WebhookChatPoster) Post(ctx context.Context, provider domain.ChatProvider, webhookURL string, message domain.ChatMessage) error {
+	var payload any
+	switch provider {
+	case domain.ChatProviderSlack:
+		payload = slackPayload(message)
+	case domain.ChatProviderTeams:
+		payload = teamsPayload(message)
+	default:
+		return provider.Validate()
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s webhook failed with status %d: %s", provider, resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	p.logger.WithFields(logrus.Fields{
+		"provider": provider,
+		"title":    message.Title,
+	}).Debug("Chat message posted")
+
+	return nil
+}
+
+// slackPayload lays a message out as a header, a text section and a section
+// of fields. Text is kept as the notification fallback.
+func slackPayload(message domain.ChatMessage) map[string]any {
+	blocks := []map[string]any{
+		{
+			"type": "header",
+			"text": map[string]any{"type": "plain_text", "text": message.Title},
+		},
+	}
+	if message.Text != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": slackEscaper.Replace(message.Text)},
+		})
+	}
+	if len(message.Fields) > 0 {
+		fields := make([]map[string]any, 0, len(message.Fields))
+		for _, field := range message.Fields {
+			fields = append(fields, map[string]any{
+				"type": "mrkdwn",
+				"text": "*" + slackEscaper.Replace(field.Name) + "*\n" + slackEscaper.Replace(field.Value),
+			})
+		}
+		blocks = append(blocks, map[string]any{"type": "section", "fields": fields})
+	}
+
+	fallback := message.Title
+	if message.Text != "" {
+		fallback += ": " + message.Text
+	}
+	return map[string]any{
+		"text":   slackEscaper.Replace(fallback),
+		"blocks": blocks,
+	}
+}
+
+// teamsPayload wraps an Adaptive Card with the title, the text and a fact
+// set of the fields in the message envelope Teams webhooks accept.
+func teamsPayload(message domain.ChatMessage) map[string]any {
+	body := []map[string]any{
+		{"type": "TextBlock", "text": message.Title, "weight": "Bolder", "size": "Medium", "wrap": true},
+	}
+	if message.Text != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": message.Text, "wrap": true})
+	}
+	if len(message.Fields) > 0 {
+		facts := make([]map[string]string, 0, len(message.Fields))
+		for _, field := range message.Fields {
+			facts = append(facts, map[string]string{"title": field.Name, "value": field.Value})
+		}
+		body = append(body, map[string]any{"type": "FactSet", "facts": facts})
+	}
+
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]any{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+				},
+			},
+		},
+	}
+}
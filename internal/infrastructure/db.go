@@ -2,27 +2,37 @@ package infrastructure
 
 import (
 	"fmt"
+	"slices"
 
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
 func NewPostgresDB() (*gorm.DB, error) {
-	log := logrus.New()
+	log := WithRedaction(logrus.New())
 
 	log.Info("Initializing PostgreSQL database connection")
 
+	timezone := viper.GetString("APP_TIMEZONE")
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
 	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=%s",
 		viper.GetString("DB_HOST"),
 		viper.GetString("DB_PORT"),
 		viper.GetString("DB_USER"),
 		viper.GetString("DB_PASSWORD"),
 		viper.GetString("DB_NAME"),
 		viper.GetString("DB_SSLMODE"),
+		timezone,
 	)
 
 	log.WithFields(logrus.Fields{
@@ -31,6 +41,7 @@ func NewPostgresDB() (*gorm.DB, error) {
 		"user":     viper.GetString("DB_USER"),
 		"database": viper.GetString("DB_NAME"),
 		"sslmode":  viper.GetString("DB_SSLMODE"),
+		"timezone": timezone,
 	}).Debug("Database connection parameters")
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
@@ -65,3 +76,83 @@ func NewPostgresDB() (*gorm.DB, error) {
 
 	return db, nil
 }
+
+// trigramIndexes speed up the substring matches of the typeahead
+// suggestions. They need the pg_trgm extension; see migration 029.
+var trigramIndexes = []string{
+	"CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING gin (name gin_trgm_ops)",
+	"CREATE INDEX IF NOT EXISTS idx_products_sku_trgm ON products USING gin (sku gin_trgm_ops)",
+	"CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING gin (name gin_trgm_ops)",
+	"CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING gin (email gin_trgm_ops)",
+}
+
+func RunMigrations(db *gorm.DB) error {
+	if err := db.AutoMigrate(&domain.User{}, &domain.Category{}, &domain.Product{}, &domain.Project{}, &domain.ProjectItem{}, &domain.ProjectItemAssignment{}, &domain.ProjectItemTransition{}, &domain.Coupon{}, &domain.SKUSequence{}, &domain.StockAdjustment{}, &domain.Warehouse{}, &domain.WarehouseStock{}, &domain.StockTransfer{}, &domain.PurchaseOrder{}, &domain.PurchaseOrderLine{}, &domain.Expense{}, &domain.Watch{}, &domain.Notification{}, &domain.ProjectExport{}, &domain.CustomFieldDefinition{}, &domain.SavedFilter{}, &domain.ReportSubscription{}, &domain.ReportDelivery{}, &domain.RetentionRule{}, &domain.RetentionRun{}, &domain.UserExport{}, &domain.PolicyDocument{}, &domain.PolicyAcceptance{}, &domain.Device{}, &domain.NotificationPreferences{}, &domain.ChatConnector{}, &domain.CalendarFeed{}, &domain.ExchangeRate{}, &domain.ExchangeRateSync{}, &domain.ArchivedRecord{}, &domain.RefreshToken{}, &domain.RevokedToken{}, &domain.PasswordResetToken{}, &domain.UserIdentity{}, &domain.FailedLogin{}, &domain.ProductImport{}, &domain.IdempotencyRecord{}, &domain.Order{}, &domain.OrderItem{}, &domain.Attachment{}, &domain.Comment{}); err != nil {
+		return err
+	}
+
+	for _, statement := range productSearchStatements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	// Suggestions still work without the indexes, only slower, so a
+	// database role that may not create extensions is not fatal.
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		WithRedaction(logrus.New()).WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Warn("pg_trgm extension unavailable, skipping trigram indexes")
+		return nil
+	}
+	for _, statement := range trigramIndexes {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectFields narrows an Updates call to fields, updated_at and version,
+// writing them even when zero. Without fields Updates keeps its usual behaviour of
+// writing only the non-zero ones.
+func selectFields(db *gorm.DB, fields []string) *gorm.DB {
+	if len(fields) == 0 {
+		return db
+	}
+	return db.Select(append(slices.Clone(fields), "updated_at", "version"))
+}
+
+// updateVersioned runs update, an Updates of model, on the row with id only
+// while its stored version is still *version, the one the caller read, and
+// moves *version up by one for the update to write. A row at another version
+// is left alone and domain.ErrVersionConflict returned. A zero *version
+// means the caller read none, so the update applies on top of the stored
+// version, locked until it is written.
+func updateVersioned(db *gorm.DB, model any, id uuid.UUID, version *int, update func(*gorm.DB) *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		read := *version
+		expected := read
+		if expected == 0 {
+			var stored struct{ Version int }
+			if err := tx.Model(model).Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("version").
+				Where("id = ?", id).
+				Take(&stored).Error; err != nil {
+				return err
+			}
+			expected = stored.Version
+		}
+
+		*version = expected + 1
+		result := update(tx.Model(model).Where("version = ?", expected))
+		if result.Error == nil && result.RowsAffected == 0 {
+			result.Error = domain.ErrVersionConflict
+		}
+		if result.Error != nil {
+			*version = read
+			return result.Error
+		}
+		return nil
+	})
+}
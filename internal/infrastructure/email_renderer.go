@@ -0,0 +1,91 @@
+package infrastructure
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+)
+
+//go:embed templates/email
+var emailTemplateFiles embed.FS
+
+const emailTemplateDir = "templates/email/"
+
+type emailTemplateKey struct {
+	template domain.EmailTemplate
+	locale   domain.Locale
+}
+
+type emailTemplates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// TemplateEmailRenderer renders the email templates embedded from
+// templates/email. Every message is wrapped in layout.txt and layout.html;
+// messages.<locale>.tmpl holds the strings they share, such as the footer,
+// and each template has a <name>.<locale>.txt file defining "subject" and
+// "body" and a <name>.<locale>.html file defining the HTML "body".
+type TemplateEmailRenderer struct {
+	templates map[emailTemplateKey]emailTemplates
+}
+
+// NewTemplateEmailRenderer parses every template in every locale, so a
+// missing or broken file stops the server at startup rather than a delivery.
+func NewTemplateEmailRenderer() (*TemplateEmailRenderer, error) {
+	r := &TemplateEmailRenderer{templates: make(map[emailTemplateKey]emailTemplates)}
+	for _, template := range domain.EmailTemplates {
+		for _, locale := range domain.Locales {
+			messages := emailTemplateDir + "messages." + string(locale) + ".tmpl"
+			name := emailTemplateDir + string(template) + "." + string(locale)
+
+			text, err := texttemplate.ParseFS(emailTemplateFiles, emailTemplateDir+"layout.txt", messages, name+".txt")
+			if err != nil {
+				return nil, fmt.Errorf("parse %s text email template: %w", name, err)
+			}
+			html, err := htmltemplate.ParseFS(emailTemplateFiles, emailTemplateDir+"layout.html", messages, name+".html")
+			if err != nil {
+				return nil, fmt.Errorf("parse %s html email template: %w", name, err)
+			}
+			if text.Lookup("subject") == nil {
+				return nil, fmt.Errorf("email template %s.txt defines no subject", name)
+			}
+			r.templates[emailTemplateKey{template, locale}] = emailTemplates{text: text, html: html}
+		}
+	}
+	return r, nil
+}
+
+// Render falls back to domain.DefaultLocale for unknown locales, such as
+// those of accounts created before locales existed.
+func (r *TemplateEmailRenderer) Render(template domain.EmailTemplate, locale domain.Locale, data any) (*domain.EmailContent, error) {
+	if locale.Validate() != nil {
+		locale = domain.DefaultLocale
+	}
+	templates, ok := r.templates[emailTemplateKey{template, locale}]
+	if !ok {
+		return nil, domain.ErrEmailTemplateNotFound
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := templates.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("render %s email subject: %w", template, err)
+	}
+	if err := templates.text.ExecuteTemplate(&text, "layout.txt", data); err != nil {
+		return nil, fmt.Errorf("render %s text email: %w", template, err)
+	}
+	if err := templates.html.ExecuteTemplate(&html, "layout.html", data); err != nil {
+		return nil, fmt.Errorf("render %s html email: %w", template, err)
+	}
+
+	return &domain.EmailContent{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
@@ -0,0 +1,122 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	FrankfurterURL       = "https://api.frankfurter.dev/v1/latest"
+	OpenExchangeRatesURL = "https://openexchangerates.org/api/latest.json"
+)
+
+// HTTPExchangeRateFetcher reads the latest rates from Frankfurter or Open
+// Exchange Rates. Both answer with a base and a map of rates; they differ in
+// how the base is asked for and how the publication time is given.
+type HTTPExchangeRateFetcher struct {
+	client   *HTTPClient
+	provider domain.ExchangeRateProvider
+	endpoint string
+	apiKey   string
+	logger   *logrus.Logger
+}
+
+// NewHTTPExchangeRateFetcher fetches from provider. An empty endpoint uses
+// the provider's public API; apiKey is the Open Exchange Rates app ID.
+func NewHTTPExchangeRateFetcher(client *HTTPClient, provider domain.ExchangeRateProvider, endpoint, apiKey string) (*HTTPExchangeRateFetcher, error) {
+	if err := provider.Validate(); err != nil {
+		return nil, err
+	}
+	if endpoint == "" {
+		endpoint = FrankfurterURL
+		if provider == domain.ExchangeRateOpenExchangeRates {
+			endpoint = OpenExchangeRatesURL
+		}
+	}
+	if provider == domain.ExchangeRateOpenExchangeRates && apiKey == "" {
+		return nil, fmt.Errorf("%s needs an API key", provider)
+	}
+
+	return &HTTPExchangeRateFetcher{
+		client:   client,
+		provider: provider,
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		logger:   WithRedaction(logrus.New()),
+	}, nil
+}
+
+// exchangeRateResponse covers both providers: Frankfurter dates its rates
+// with a day and Open Exchange Rates with a Unix timestamp.
+type exchangeRateResponse struct {
+	Base      string             `json:"base"`
+	Date      string             `json:"date"`
+	Timestamp int64              `json:"timestamp"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+func (f *HTTPExchangeRateFetcher) Fetch(ctx context.Context, base string) (*domain.ExchangeRateQuote, error) {
+	query := url.Values{"base": {base}}
+	if f.provider == domain.ExchangeRateOpenExchangeRates {
+		query.Set("app_id", f.apiKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		// The request URL carries the app ID, so only the cause is kept.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("%s request failed: %w", f.provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return nil, fmt.Errorf("%s failed with status %d: %s", f.provider, resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	var body exchangeRateResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%s returned an invalid response: %w", f.provider, err)
+	}
+	if body.Base != base {
+		return nil, fmt.Errorf("%s returned rates for %q instead of %q", f.provider, body.Base, base)
+	}
+
+	quote := &domain.ExchangeRateQuote{Base: body.Base, Rates: body.Rates}
+	switch {
+	case body.Timestamp > 0:
+		quote.EffectiveAt = time.Unix(body.Timestamp, 0)
+	case body.Date != "":
+		quote.EffectiveAt, err = time.Parse(time.DateOnly, body.Date)
+		if err != nil {
+			return nil, fmt.Errorf("%s returned an invalid date %q", f.provider, body.Date)
+		}
+	}
+
+	f.logger.WithFields(logrus.Fields{
+		"provider":   f.provider,
+		"base":       base,
+		"currencies": len(body.Rates),
+	}).Debug("Exchange rates fetched")
+
+	return quote, nil
+}
@@ -0,0 +1,199 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	fcmScope    = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmEndpoint = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+)
+
+// fcmServiceAccount holds the fields of a Google service account key file
+// needed to sign access token requests.
+type fcmServiceAccount struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// FCMSender pushes through the Firebase Cloud Messaging HTTP v1 API,
+// authenticating as a service account. Access tokens are reused until a
+// minute before they expire.
+type FCMSender struct {
+	account fcmServiceAccount
+	signer  any
+	client  *HTTPClient
+	logger  *logrus.Logger
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewFCMSender reads the service account key file downloaded from the
+// Firebase console.
+func NewFCMSender(credentialsFile string, client *HTTPClient) (*FCMSender, error) {
+	data, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("read FCM credentials: %w", err)
+	}
+	var account fcmServiceAccount
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf("parse FCM credentials: %w", err)
+	}
+	if account.ProjectID == "" || account.ClientEmail == "" || account.TokenURI == "" {
+		return nil, errors.New("FCM credentials must have project_id, client_email and token_uri")
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse FCM private key: %w", err)
+	}
+
+	return &FCMSender{
+		account: account,
+		signer:  key,
+		client:  client,
+		logger:  WithRedaction(logrus.New()),
+	}, nil
+}
+
+func (s *FCMSender) Send(ctx context.Context, token string, message domain.PushMessage) error {
+	accessToken, err := s.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"message": map[string]any{
+			"token": token,
+			"notification": map[string]string{
+				"title": message.Title,
+				"body":  message.Body,
+			},
+			"data": message.Data,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(fcmEndpoint, s.account.ProjectID), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return fcmError(resp)
+}
+
+// fcmError reads the error of a failed send. UNREGISTERED means the app was
+// uninstalled or the token expired, so the token is reported invalid.
+func fcmError(resp *http.Response) error {
+	var payload struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+			Details []struct {
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&payload)
+
+	for _, detail := range payload.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return domain.ErrPushTokenInvalid
+		}
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return domain.ErrPushTokenInvalid
+	}
+	return fmt.Errorf("fcm send failed with status %d: %s %s", resp.StatusCode, payload.Error.Status, payload.Error.Message)
+}
+
+// token returns a cached access token or exchanges a signed assertion for a
+// new one.
+func (s *FCMSender) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.accessToken != "" && now.Before(s.expiresAt.Add(-time.Minute)) {
+		return s.accessToken, nil
+	}
+
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   s.account.ClientEmail,
+		"scope": fcmScope,
+		"aud":   s.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(s.signer)
+	if err != nil {
+		return "", fmt.Errorf("sign FCM token request: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var grant struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fcm token request failed with status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&grant); err != nil {
+		return "", fmt.Errorf("decode FCM access token: %w", err)
+	}
+
+	s.accessToken = grant.AccessToken
+	s.expiresAt = now.Add(time.Duration(grant.ExpiresIn) * time.Second)
+	s.logger.WithFields(logrus.Fields{
+		"project_id": s.account.ProjectID,
+		"expires_at": s.expiresAt,
+	}).Debug("FCM access token refreshed")
+
+	return s.accessToken, nil
+}
@@ -0,0 +1,258 @@
+package infrastructure
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCircuitOpen is returned without sending the request while a host's
+// circuit is open.
+var ErrCircuitOpen = errors.New("circuit open: host is failing")
+
+// HTTPClientConfig tunes HTTPClient. Retries back off exponentially from
+// RetryWait up to MaxRetryWait with full jitter. After BreakerThreshold
+// consecutive failures a host's circuit opens for BreakerCooldown, then one
+// trial request decides whether it closes again.
+type HTTPClientConfig struct {
+	Timeout          time.Duration
+	MaxRetries       int
+	RetryWait        time.Duration
+	MaxRetryWait     time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+func DefaultHTTPClientConfig() HTTPClientConfig {
+	return HTTPClientConfig{
+		Timeout:          10 * time.Second,
+		MaxRetries:       3,
+		RetryWait:        200 * time.Millisecond,
+		MaxRetryWait:     5 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+	}
+}
+
+// HTTPHostStats counts the outbound calls made to one host.
+type HTTPHostStats struct {
+	Host        string    `json:"host"`
+	Requests    int64     `json:"requests"`
+	Retries     int64     `json:"retries"`
+	Failures    int64     `json:"failures"`
+	Rejected    int64     `json:"rejected"`
+	CircuitOpen bool      `json:"circuit_open"`
+	LastFailure time.Time `json:"last_failure"`
+}
+
+type hostState struct {
+	stats     HTTPHostStats
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+// HTTPClient is the shared client for calls to third-party services. It
+// retries transient failures of requests that are safe to repeat, breaks
+// the circuit to hosts that keep failing and counts calls per host. Build
+// one per process and hand it to every integration, so the counters and
+// circuits cover all calls to a host. Trace context is deliberately not
+// propagated: apart from logging the X-Trace-Id a caller sends, the API has
+// no tracing, and third parties have no use for that header.
+type HTTPClient struct {
+	client *http.Client
+	config HTTPClientConfig
+	logger *logrus.Logger
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+func NewHTTPClient(config HTTPClientConfig) *HTTPClient {
+	return &HTTPClient{
+		client: &http.Client{Timeout: config.Timeout},
+		config: config,
+		logger: WithRedaction(logrus.New()),
+		hosts:  make(map[string]*hostState),
+	}
+}
+
+// Do sends req like http.Client.Do. Requests are retried on network errors
+// and 429, 502, 503 and 504 responses when their method is idempotent or
+// they carry an Idempotency-Key header, and their body can be replayed.
+// A Retry-After header in seconds overrides the backoff.
+func (c *HTTPClient) Do(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	retries := 0
+	if replayable(req) {
+		retries = c.config.MaxRetries
+	}
+
+	wait := c.config.RetryWait
+	for attempt := 0; ; attempt++ {
+		if !c.allow(host) {
+			c.logger.WithFields(logrus.Fields{
+				"host":   host,
+				"method": req.Method,
+			}).Warn("Outbound request rejected by open circuit")
+			return nil, ErrCircuitOpen
+		}
+
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		start := time.Now()
+		resp, err := c.client.Do(req)
+		failed := err != nil || retryableStatus(resp.StatusCode)
+		c.record(host, failed)
+
+		c.logger.WithFields(logrus.Fields{
+			"host":        host,
+			"method":      req.Method,
+			"attempt":     attempt + 1,
+			"status":      statusOf(resp),
+			"duration_ms": time.Since(start).Milliseconds(),
+		}).Debug("Outbound request finished")
+
+		if !failed || attempt >= retries {
+			return resp, err
+		}
+
+		delay := jitter(wait)
+		if resp != nil {
+			if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && seconds >= 0 {
+				delay = time.Duration(seconds) * time.Second
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		c.countRetry(host)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+		wait = min(wait*2, c.config.MaxRetryWait)
+	}
+}
+
+// Stats returns the counters of every host called so far, ordered by host.
+func (c *HTTPClient) Stats() []HTTPHostStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	stats := make([]HTTPHostStats, 0, len(c.hosts))
+	for _, state := range c.hosts {
+		snapshot := state.stats
+		snapshot.CircuitOpen = now.Before(state.openUntil)
+		stats = append(stats, snapshot)
+	}
+	slices.SortFunc(stats, func(a, b HTTPHostStats) int {
+		return strings.Compare(a.Host, b.Host)
+	})
+	return stats
+}
+
+// allow reports whether a request to host may go out. Once the cooldown of
+// an open circuit is over a single trial request is let through.
+func (c *HTTPClient) allow(host string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state := c.host(host)
+	if state.openUntil.IsZero() {
+		return true
+	}
+	if time.Now().Before(state.openUntil) || state.trial {
+		state.stats.Rejected++
+		return false
+	}
+	state.trial = true
+	return true
+}
+
+func (c *HTTPClient) record(host string, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state := c.host(host)
+	state.stats.Requests++
+	state.trial = false
+	if !failed {
+		state.failures = 0
+		state.openUntil = time.Time{}
+		return
+	}
+
+	state.stats.Failures++
+	state.stats.LastFailure = time.Now()
+	state.failures++
+	if state.failures >= c.config.BreakerThreshold {
+		state.openUntil = time.Now().Add(c.config.BreakerCooldown)
+		c.logger.WithFields(logrus.Fields{
+			"host":     host,
+			"failures": state.failures,
+			"cooldown": c.config.BreakerCooldown,
+		}).Warn("Outbound circuit opened")
+	}
+}
+
+func (c *HTTPClient) countRetry(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.host(host).stats.Retries++
+}
+
+func (c *HTTPClient) host(host string) *hostState {
+	state, ok := c.hosts[host]
+	if !ok {
+		state = &hostState{stats: HTTPHostStats{Host: host}}
+		c.hosts[host] = state
+	}
+	return state
+}
+
+func replayable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+// jitter picks a delay uniformly up to wait so retrying callers spread out.
+func jitter(wait time.Duration) time.Duration {
+	if wait <= 0 {
+		return 0
+	}
+	return rand.N(wait) + 1
+}
+
+func statusOf(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
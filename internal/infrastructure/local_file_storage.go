@@ -0,0 +1,152 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// LocalFileStorage keeps files under a directory of the local disk. Its
+// download links point back at the API, at urlPrefix followed by the key,
+// and carry an HMAC-SHA256 of the key and expiry made with secret.
+type LocalFileStorage struct {
+	dir       string
+	urlPrefix string
+	secret    []byte
+	logger    *logrus.Logger
+	clock     domain.Clock
+}
+
+// NewLocalFileStorage stores files under dir, creating it when missing.
+// urlPrefix is where the API serves downloads, such as
+// "https://api.example.com/v1/files/"; it may be a bare path.
+func NewLocalFileStorage(dir, urlPrefix string, secret []byte) (*LocalFileStorage, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("local file storage needs a signing key")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create storage directory: %w", err)
+	}
+
+	return &LocalFileStorage{
+		dir:       dir,
+		urlPrefix: urlPrefix,
+		secret:    secret,
+		logger:    WithRedaction(logrus.New()),
+		clock:     domain.SystemClock{},
+	}, nil
+}
+
+func (s *LocalFileStorage) WithClock(clock domain.Clock) *LocalFileStorage {
+	s.clock = clock
+	return s
+}
+
+// Put writes to a temporary file first, so a failed upload never leaves a
+// partial file under key.
+func (s *LocalFileStorage) Put(ctx context.Context, key, contentType string, content []byte) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"key":  key,
+		"size": len(content),
+	}).Debug("File stored on local disk")
+
+	return nil
+}
+
+func (s *LocalFileStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.ErrFileNotFound
+	}
+	return file, err
+}
+
+func (s *LocalFileStorage) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalFileStorage) SignedURL(ctx context.Context, key string, expiresAt time.Time) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	query := url.Values{
+		"expires":   {expires},
+		"signature": {s.sign(key, expires)},
+	}
+	return s.urlPrefix + key + "?" + query.Encode(), nil
+}
+
+func (s *LocalFileStorage) VerifySignedURL(key string, expiresAt time.Time, signature string) error {
+	expected := s.sign(key, strconv.FormatInt(expiresAt.Unix(), 10))
+	if !hmac.Equal([]byte(expected), []byte(signature)) || !s.clock.Now().Before(expiresAt) {
+		return domain.ErrInvalidFileSignature
+	}
+	return nil
+}
+
+func (s *LocalFileStorage) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path maps key to a file under the storage directory, refusing keys that
+// would escape it.
+func (s *LocalFileStorage) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return "", fmt.Errorf("invalid file key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,83 @@
+package infrastructure
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const redactedLogValue = "[REDACTED]"
+
+var defaultSensitiveKeys = []string{
+	"password",
+	"passwd",
+	"token",
+	"authorization",
+	"secret",
+	"api_key",
+	"apikey",
+	"cookie",
+	"credential",
+}
+
+type RedactionHook struct {
+	sensitiveKeys []string
+}
+
+func NewRedactionHook(extraKeys ...string) *RedactionHook {
+	keys := make([]string, 0, len(defaultSensitiveKeys)+len(extraKeys))
+	keys = append(keys, defaultSensitiveKeys...)
+	for _, key := range extraKeys {
+		keys = append(keys, strings.ToLower(key))
+	}
+	return &RedactionHook{sensitiveKeys: keys}
+}
+
+func (h *RedactionHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *RedactionHook) Fire(entry *logrus.Entry) error {
+	for key, value := range entry.Data {
+		if h.isSensitive(key) {
+			entry.Data[key] = redactedLogValue
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			entry.Data[key] = h.redactMap(nested)
+		}
+	}
+	return nil
+}
+
+func (h *RedactionHook) redactMap(values map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		switch {
+		case h.isSensitive(key):
+			redacted[key] = redactedLogValue
+		default:
+			if nested, ok := value.(map[string]interface{}); ok {
+				redacted[key] = h.redactMap(nested)
+			} else {
+				redacted[key] = value
+			}
+		}
+	}
+	return redacted
+}
+
+func (h *RedactionHook) isSensitive(key string) bool {
+	lower := strings.ToLower(key)
+	for _, sensitive := range h.sensitiveKeys {
+		if strings.Contains(lower, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func WithRedaction(logger *logrus.Logger) *logrus.Logger {
+	logger.AddHook(NewRedactionHook())
+	return logger
+}
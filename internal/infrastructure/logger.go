@@ -88,6 +88,7 @@ func (f *ColoredFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 
 func NewLogger(config LoggerConfig) *logrus.Logger {
 	logger := logrus.New()
+	logger.AddHook(NewRedactionHook())
 
 	level, err := logrus.ParseLevel(config.Level)
 	if err != nil {
@@ -0,0 +1,74 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+)
+
+// rateLimitSweepInterval is how often buckets that refilled completely,
+// and so hold nothing a new bucket would not, are dropped.
+const rateLimitSweepInterval = time.Minute
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	limit   domain.RateLimit
+}
+
+// refill brings the bucket up to date at now.
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated)
+	if elapsed > 0 {
+		b.tokens = min(float64(b.limit.Burst), b.tokens+float64(elapsed)/float64(b.limit.Refill()))
+		b.updated = now
+	}
+}
+
+// MemoryRateLimitStore keeps buckets in process memory. Each instance
+// counts on its own, so behind a load balancer clients get the limit once
+// per instance; use RedisRateLimitStore there.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: make(map[string]*tokenBucket)}
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= rateLimitSweepInterval {
+		s.sweep(now)
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok || bucket.limit != limit {
+		bucket = &tokenBucket{tokens: float64(limit.Burst), updated: now, limit: limit}
+		s.buckets[key] = bucket
+	}
+	bucket.refill(now)
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) * float64(limit.Refill()))
+		return domain.RateLimitDecision{RetryAfter: wait}, nil
+	}
+	bucket.tokens--
+	return domain.RateLimitDecision{Allowed: true, Remaining: int(bucket.tokens)}, nil
+}
+
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	for key, bucket := range s.buckets {
+		bucket.refill(now)
+		if bucket.tokens >= float64(bucket.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"sync"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+)
+
+type cachedEntry struct {
+	response  *domain.CachedResponse
+	expiresAt time.Time
+}
+
+// MemoryResponseCache keeps responses in process memory, holding at most
+// maxEntries. It is only coherent within one instance: writes served by
+// another instance are not seen until entries expire.
+type MemoryResponseCache struct {
+	mu          sync.Mutex
+	entries     map[string]cachedEntry
+	generations map[string]uint64
+	maxEntries  int
+	clock       domain.Clock
+}
+
+func NewMemoryResponseCache(maxEntries int) *MemoryResponseCache {
+	return &MemoryResponseCache{
+		entries:     make(map[string]cachedEntry),
+		generations: make(map[string]uint64),
+		maxEntries:  maxEntries,
+		clock:       domain.SystemClock{},
+	}
+}
+
+func (c *MemoryResponseCache) WithClock(clock domain.Clock) *MemoryResponseCache {
+	c.clock = clock
+	return c
+}
+
+func (c *MemoryResponseCache) Get(key string) (*domain.CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.clock.Now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.response, true
+}
+
+// Set stores the response for ttl. When the cache is full expired entries
+// are dropped first, then arbitrary ones.
+func (c *MemoryResponseCache) Set(key string, response *domain.CachedResponse, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < c.maxEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cachedEntry{response: response, expiresAt: now.Add(ttl)}
+}
+
+func (c *MemoryResponseCache) Generation(resource string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generations[resource]
+}
+
+func (c *MemoryResponseCache) Invalidate(resources ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, resource := range resources {
+		c.generations[resource]++
+	}
+}
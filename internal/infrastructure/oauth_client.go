@@ -0,0 +1,241 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	GoogleAuthURL     = "https://accounts.google.com/o/oauth2/v2/auth"
+	GoogleTokenURL    = "https://oauth2.googleapis.com/token"
+	GoogleUserInfoURL = "https://openidconnect.googleapis.com/v1/userinfo"
+
+	GitHubAuthURL  = "https://github.com/login/oauth/authorize"
+	GitHubTokenURL = "https://github.com/login/oauth/access_token"
+	GitHubAPIURL   = "https://api.github.com"
+)
+
+// HTTPOAuthClient runs the authorization code flow with Google or GitHub.
+// Both trade the code for an access token the same way; Google then gives
+// the profile through its OpenID Connect userinfo endpoint, while GitHub
+// needs the user and the user's emails fetched separately.
+type HTTPOAuthClient struct {
+	client       *HTTPClient
+	provider     domain.OAuthProvider
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	logger       *logrus.Logger
+}
+
+// NewHTTPOAuthClient signs users in with provider using the credentials of
+// the app registered there. redirectURL must be the callback URL registered
+// with the app.
+func NewHTTPOAuthClient(client *HTTPClient, provider domain.OAuthProvider, clientID, clientSecret, redirectURL string) (*HTTPOAuthClient, error) {
+	if err := provider.Validate(); err != nil {
+		return nil, err
+	}
+	if clientID == "" || clientSecret == "" {
+		return nil, fmt.Errorf("%s needs a client ID and secret", provider)
+	}
+
+	return &HTTPOAuthClient{
+		client:       client,
+		provider:     provider,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		logger:       WithRedaction(logrus.New()),
+	}, nil
+}
+
+func (o *HTTPOAuthClient) AuthCodeURL(state string) string {
+	query := url.Values{
+		"client_id":    {o.clientID},
+		"redirect_uri": {o.redirectURL},
+		"state":        {state},
+	}
+	if o.provider == domain.OAuthGoogle {
+		query.Set("response_type", "code")
+		query.Set("scope", "openid email profile")
+		query.Set("prompt", "select_account")
+		return GoogleAuthURL + "?" + query.Encode()
+	}
+	query.Set("scope", "read:user user:email")
+	return GitHubAuthURL + "?" + query.Encode()
+}
+
+func (o *HTTPOAuthClient) Exchange(ctx context.Context, code string) (*domain.OAuthProfile, error) {
+	accessToken, err := o.accessToken(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile *domain.OAuthProfile
+	if o.provider == domain.OAuthGoogle {
+		profile, err = o.googleProfile(ctx, accessToken)
+	} else {
+		profile, err = o.gitHubProfile(ctx, accessToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	o.logger.WithFields(logrus.Fields{
+		"provider":       o.provider,
+		"email_verified": profile.EmailVerified,
+	}).Debug("OAuth code exchanged")
+
+	return profile, nil
+}
+
+type oauthTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// accessToken trades code for an access token. GitHub reports a refused
+// code with 200 and an error field, Google with 400.
+func (o *HTTPOAuthClient) accessToken(ctx context.Context, code string) (string, error) {
+	endpoint := GoogleTokenURL
+	if o.provider == domain.OAuthGitHub {
+		endpoint = GitHubTokenURL
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {o.redirectURL},
+		"client_id":     {o.clientID},
+		"client_secret": {o.clientSecret},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s token request failed: %w", o.provider, err)
+	}
+	defer resp.Body.Close()
+
+	var body oauthTokenResponse
+	decodeErr := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body)
+	switch {
+	case body.Error != "" && resp.StatusCode < 500:
+		return "", fmt.Errorf("%s: %w: %s", o.provider, domain.ErrOAuthCodeInvalid, body.Error)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return "", fmt.Errorf("%s token request failed with status %d", o.provider, resp.StatusCode)
+	case decodeErr != nil:
+		return "", fmt.Errorf("%s returned an invalid token response: %w", o.provider, decodeErr)
+	case body.AccessToken == "":
+		return "", fmt.Errorf("%s returned no access token", o.provider)
+	}
+
+	return body.AccessToken, nil
+}
+
+type googleUserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+func (o *HTTPOAuthClient) googleProfile(ctx context.Context, accessToken string) (*domain.OAuthProfile, error) {
+	var info googleUserInfo
+	if err := o.getJSON(ctx, GoogleUserInfoURL, accessToken, &info); err != nil {
+		return nil, err
+	}
+	if info.Subject == "" {
+		return nil, errors.New("google returned a profile without a subject")
+	}
+
+	return &domain.OAuthProfile{
+		Subject:       info.Subject,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		Name:          info.Name,
+	}, nil
+}
+
+type gitHubUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+type gitHubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// gitHubProfile reads the account and its primary email, which the user
+// endpoint leaves out when the user keeps it private.
+func (o *HTTPOAuthClient) gitHubProfile(ctx context.Context, accessToken string) (*domain.OAuthProfile, error) {
+	var user gitHubUser
+	if err := o.getJSON(ctx, GitHubAPIURL+"/user", accessToken, &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, errors.New("github returned a profile without an id")
+	}
+
+	var emails []gitHubEmail
+	if err := o.getJSON(ctx, GitHubAPIURL+"/user/emails", accessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	profile := &domain.OAuthProfile{Subject: strconv.FormatInt(user.ID, 10), Name: user.Name}
+	if profile.Name == "" {
+		profile.Name = user.Login
+	}
+	for _, email := range emails {
+		if email.Primary {
+			profile.Email = email.Email
+			profile.EmailVerified = email.Verified
+		}
+	}
+
+	return profile, nil
+}
+
+func (o *HTTPOAuthClient) getJSON(ctx context.Context, endpoint, accessToken string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s profile request failed: %w", o.provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("%s profile request failed with status %d: %s", o.provider, resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out); err != nil {
+		return fmt.Errorf("%s returned an invalid profile: %w", o.provider, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresAttachmentRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresAttachmentRepository(db *gorm.DB) *PostgresAttachmentRepository {
+	return &PostgresAttachmentRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresAttachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) error {
+	r.logger.WithFields(logrus.Fields{
+		"attachment_id": attachment.ID,
+		"owner_type":    attachment.OwnerType,
+		"owner_id":      attachment.OwnerID,
+	}).Debug("Creating attachment in database")
+
+	if err := r.db.WithContext(ctx).Create(attachment).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":         err.Error(),
+			"attachment_id": attachment.ID,
+		}).Error("Failed to create attachment in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresAttachmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Attachment, error) {
+	return r.get(ctx, "id = ?", id)
+}
+
+func (r *PostgresAttachmentRepository) GetByKey(ctx context.Context, key string) (*domain.Attachment, error) {
+	return r.get(ctx, "key = ?", key)
+}
+
+func (r *PostgresAttachmentRepository) get(ctx context.Context, query string, arg interface{}) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	err := r.db.WithContext(ctx).First(&attachment, query, arg).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to get attachment from database")
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (r *PostgresAttachmentRepository) List(ctx context.Context, ownerType string, ownerID uuid.UUID) ([]domain.Attachment, error) {
+	r.logger.WithFields(logrus.Fields{
+		"owner_type": ownerType,
+		"owner_id":   ownerID,
+	}).Debug("Listing attachments from database")
+
+	var attachments []domain.Attachment
+	err := r.db.WithContext(ctx).
+		Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+		Order("created_at, id").
+		Find(&attachments).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"owner_id": ownerID,
+		}).Error("Failed to list attachments from database")
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r *PostgresAttachmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"attachment_id": id,
+	}).Debug("Deleting attachment from database")
+
+	result := r.db.WithContext(ctx).Delete(&domain.Attachment{}, "id = ?", id)
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":         result.Error.Error(),
+			"attachment_id": id,
+		}).Error("Failed to delete attachment from database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+
+	return nil
+}
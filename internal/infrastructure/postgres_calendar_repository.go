@@ -0,0 +1,157 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresCalendarRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresCalendarRepository(db *gorm.DB) *PostgresCalendarRepository {
+	return &PostgresCalendarRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresCalendarRepository) SaveFeed(ctx context.Context, feed *domain.CalendarFeed) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": feed.UserID,
+	}).Debug("Saving calendar feed in database")
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at", "last_used_at"}),
+	}).Create(feed).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": feed.UserID,
+		}).Error("Failed to save calendar feed in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresCalendarRepository) GetFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	return r.getFeed(ctx, "user_id = ?", userID)
+}
+
+func (r *PostgresCalendarRepository) GetFeedByTokenHash(ctx context.Context, tokenHash string) (*domain.CalendarFeed, error) {
+	return r.getFeed(ctx, "token_hash = ?", tokenHash)
+}
+
+func (r *PostgresCalendarRepository) getFeed(ctx context.Context, query string, arg any) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	err := r.db.WithContext(ctx).Where(query, arg).Take(&feed).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to get calendar feed from database")
+		return nil, err
+	}
+
+	return &feed, nil
+}
+
+func (r *PostgresCalendarRepository) DeleteFeed(ctx context.Context, userID uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+	}).Debug("Deleting calendar feed from database")
+
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.CalendarFeed{})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error.Error(),
+			"user_id": userID,
+		}).Error("Failed to delete calendar feed from database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrCalendarFeedNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresCalendarRepository) TouchFeed(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	err := r.db.WithContext(ctx).Model(&domain.CalendarFeed{}).
+		Where("user_id = ?", userID).
+		Update("last_used_at", at).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to record calendar feed use in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresCalendarRepository) ListAssignedDue(ctx context.Context, userID uuid.UUID, from time.Time, limit int) ([]domain.ProjectItem, error) {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"from":    from,
+	}).Debug("Listing assigned due items for calendar from database")
+
+	var items []domain.ProjectItem
+	err := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL AND assigned_to = ?", userID).
+		Where("status NOT IN ?", domain.ClosedProjectItemStatuses).
+		Where("due_date >= ?", from).
+		Order("due_date, id").
+		Limit(limit).
+		Find(&items).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list assigned due items from database")
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *PostgresCalendarRepository) ListMilestones(ctx context.Context, userID uuid.UUID, from time.Time, limit int) ([]domain.Project, error) {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"from":    from,
+	}).Debug("Listing project milestones for calendar from database")
+
+	assigned := r.db.Model(&domain.ProjectItem{}).Select("project_id").
+		Where("deleted_at IS NULL AND assigned_to = ?", userID)
+
+	var projects []domain.Project
+	err := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL AND archived_at IS NULL").
+		Where("owner_id = ? OR id IN (?)", userID, assigned).
+		Where("start_date >= ? OR end_date >= ?", from, from).
+		Order("COALESCE(end_date, start_date), id").
+		Limit(limit).
+		Find(&projects).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list project milestones from database")
+		return nil, err
+	}
+
+	return projects, nil
+}
@@ -0,0 +1,309 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// categorySubtreeSQL selects the ID of a category and of all its
+// descendants. UNION rather than UNION ALL stops at rows already seen, so
+// even a corrupted tree cannot make it loop.
+const categorySubtreeSQL = `WITH RECURSIVE subtree AS (
+	SELECT id FROM categories WHERE id = ? AND deleted_at IS NULL
+	UNION
+	SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id WHERE c.deleted_at IS NULL
+) SELECT id FROM subtree`
+
+type PostgresCategoryRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+}
+
+func NewPostgresCategoryRepository(db *gorm.DB) *PostgresCategoryRepository {
+	return &PostgresCategoryRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+	}
+}
+
+func (r *PostgresCategoryRepository) WithClock(clock domain.Clock) *PostgresCategoryRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresCategoryRepository) Create(ctx context.Context, category *domain.Category) error {
+	r.logger.WithFields(logrus.Fields{
+		"category_id": category.ID,
+		"name":        category.Name,
+		"parent_id":   category.ParentID,
+	}).Debug("Creating category in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCategoryName(tx, category); err != nil {
+			return err
+		}
+		return tx.Create(category).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"category_id": category.ID,
+		}).Error("Failed to create category in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"category_id": category.ID,
+	}).Debug("Category created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresCategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Category, error) {
+	r.logger.WithFields(logrus.Fields{
+		"category_id": id,
+	}).Debug("Getting category by ID from database")
+
+	var category domain.Category
+	err := r.db.WithContext(ctx).First(&category, "id = ? AND deleted_at IS NULL", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"category_id": id,
+		}).Warn("Category not found in database")
+		return nil, domain.ErrCategoryNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"category_id": id,
+		}).Error("Failed to get category from database")
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *PostgresCategoryRepository) FindByName(ctx context.Context, name string) ([]domain.Category, error) {
+	r.logger.WithFields(logrus.Fields{
+		"name": name,
+	}).Debug("Finding categories by name in database")
+
+	var categories []domain.Category
+	err := r.db.WithContext(ctx).
+		Where("LOWER(name) = LOWER(?) AND deleted_at IS NULL", name).
+		Order("created_at").
+		Find(&categories).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"name":  name,
+		}).Error("Failed to find categories by name in database")
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *PostgresCategoryRepository) List(ctx context.Context, filter domain.CategoryParams, pagination domain.Pagination) ([]domain.Category, error) {
+	r.logger.WithFields(logrus.Fields{
+		"filter_name":      filter.Name,
+		"filter_parent_id": filter.ParentID,
+		"filter_roots":     filter.Roots,
+		"limit":            pagination.Limit,
+		"offset":           pagination.Offset,
+		"sort":             pagination.Sort,
+	}).Debug("Listing categories from database with filters")
+
+	var categories []domain.Category
+	db := r.db.WithContext(ctx).Model(&domain.Category{})
+
+	if filter.Name != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_name": filter.Name,
+		}).Debug("Applying name filter")
+		db = db.Where("name ILIKE ?", "%"+filter.Name+"%")
+	}
+
+	if filter.ParentID != nil {
+		r.logger.WithFields(logrus.Fields{
+			"filter_parent_id": *filter.ParentID,
+		}).Debug("Applying parent filter")
+		db = db.Where("parent_id = ?", *filter.ParentID)
+	} else if filter.Roots {
+		r.logger.Debug("Applying top-level filter")
+		db = db.Where("parent_id IS NULL")
+	}
+
+	db = db.Where("deleted_at IS NULL")
+
+	if pagination.Sort != "" {
+		r.logger.WithFields(logrus.Fields{
+			"sort": pagination.Sort,
+		}).Debug("Applying sort")
+		db = db.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"limit": pagination.Limit,
+		}).Debug("Applying limit")
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"offset": pagination.Offset,
+		}).Debug("Applying offset")
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&categories).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list categories from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(categories),
+	}).Debug("Categories listed successfully from database")
+
+	return categories, nil
+}
+
+func (r *PostgresCategoryRepository) Update(ctx context.Context, category *domain.Category) error {
+	r.logger.WithFields(logrus.Fields{
+		"category_id": category.ID,
+		"name":        category.Name,
+		"parent_id":   category.ParentID,
+	}).Debug("Updating category in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current domain.Category
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "id = ? AND deleted_at IS NULL", category.ID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrCategoryNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if category.ParentID != nil {
+			var inSubtree int64
+			if err := tx.Raw("SELECT COUNT(*) FROM ("+categorySubtreeSQL+") subtree WHERE id = ?", category.ID, *category.ParentID).
+				Scan(&inSubtree).Error; err != nil {
+				return err
+			}
+			if inSubtree > 0 {
+				return domain.ErrCategoryCycle
+			}
+		}
+		if err := checkCategoryName(tx, category); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&domain.Category{}).Where("id = ?", category.ID).Updates(map[string]interface{}{
+			"name":       category.Name,
+			"parent_id":  category.ParentID,
+			"updated_at": category.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		if current.Name == category.Name {
+			return nil
+		}
+		return tx.Model(&domain.Product{}).Where("category_id = ?", category.ID).Updates(map[string]interface{}{
+			"category":   category.Name,
+			"updated_at": category.UpdatedAt,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"category_id": category.ID,
+		}).Error("Failed to update category in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"category_id": category.ID,
+	}).Debug("Category updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresCategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"category_id": id,
+	}).Debug("Soft deleting category in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category domain.Category
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&category, "id = ? AND deleted_at IS NULL", id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrCategoryNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var children, products int64
+		if err := tx.Model(&domain.Category{}).Where("parent_id = ? AND deleted_at IS NULL", id).Count(&children).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Product{}).Where("category_id = ? AND deleted_at IS NULL", id).Count(&products).Error; err != nil {
+			return err
+		}
+		if children > 0 || products > 0 {
+			return domain.ErrCategoryInUse
+		}
+
+		return tx.Model(&domain.Category{}).Where("id = ?", id).Update("deleted_at", r.clock.Now()).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"category_id": id,
+		}).Error("Failed to delete category from database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"category_id": id,
+	}).Debug("Category soft deleted successfully in database")
+
+	return nil
+}
+
+// checkCategoryName returns ErrCategoryNameTaken when another category
+// under the same parent has category's name.
+func checkCategoryName(tx *gorm.DB, category *domain.Category) error {
+	db := tx.Model(&domain.Category{}).
+		Where("LOWER(name) = LOWER(?) AND id <> ? AND deleted_at IS NULL", category.Name, category.ID)
+	if category.ParentID != nil {
+		db = db.Where("parent_id = ?", *category.ParentID)
+	} else {
+		db = db.Where("parent_id IS NULL")
+	}
+
+	var taken int64
+	if err := db.Count(&taken).Error; err != nil {
+		return err
+	}
+	if taken > 0 {
+		return domain.ErrCategoryNameTaken
+	}
+	return nil
+}
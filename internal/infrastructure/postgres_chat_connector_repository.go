@@ -0,0 +1,195 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresChatConnectorRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+}
+
+func NewPostgresChatConnectorRepository(db *gorm.DB) *PostgresChatConnectorRepository {
+	return &PostgresChatConnectorRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+	}
+}
+
+func (r *PostgresChatConnectorRepository) WithClock(clock domain.Clock) *PostgresChatConnectorRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresChatConnectorRepository) Create(ctx context.Context, connector *domain.ChatConnector) error {
+	r.logger.WithFields(logrus.Fields{
+		"connector_id": connector.ID,
+		"provider":     connector.Provider,
+	}).Debug("Creating chat connector in database")
+
+	if err := r.db.WithContext(ctx).Create(connector).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"connector_id": connector.ID,
+		}).Error("Failed to create chat connector in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"connector_id": connector.ID,
+	}).Debug("Chat connector created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresChatConnectorRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ChatConnector, error) {
+	r.logger.WithFields(logrus.Fields{
+		"connector_id": id,
+	}).Debug("Getting chat connector by ID from database")
+
+	var connector domain.ChatConnector
+	err := r.db.WithContext(ctx).First(&connector, "id = ? AND deleted_at IS NULL", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"connector_id": id,
+		}).Warn("Chat connector not found in database")
+		return nil, domain.ErrChatConnectorNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"connector_id": id,
+		}).Error("Failed to get chat connector from database")
+		return nil, err
+	}
+
+	return &connector, nil
+}
+
+func (r *PostgresChatConnectorRepository) List(ctx context.Context, params domain.ChatConnectorParams, pagination domain.Pagination) ([]domain.ChatConnector, error) {
+	r.logger.WithFields(logrus.Fields{
+		"provider":   params.Provider,
+		"project_id": params.ProjectID,
+		"limit":      pagination.Limit,
+		"offset":     pagination.Offset,
+	}).Debug("Listing chat connectors from database")
+
+	db := r.db.WithContext(ctx).Where("deleted_at IS NULL")
+	if params.Provider != "" {
+		db = db.Where("provider = ?", params.Provider)
+	}
+	if params.ProjectID != nil {
+		db = db.Where("project_id = ?", *params.ProjectID)
+	}
+	db = db.Order("name, id")
+
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	var connectors []domain.ChatConnector
+	if err := db.Find(&connectors).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list chat connectors from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(connectors),
+	}).Debug("Chat connectors listed successfully from database")
+
+	return connectors, nil
+}
+
+func (r *PostgresChatConnectorRepository) Update(ctx context.Context, connector *domain.ChatConnector) error {
+	r.logger.WithFields(logrus.Fields{
+		"connector_id": connector.ID,
+	}).Debug("Updating chat connector in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.ChatConnector{}).
+		Where("id = ? AND deleted_at IS NULL", connector.ID).
+		Updates(map[string]interface{}{
+			"name":        connector.Name,
+			"webhook_url": connector.WebhookURL,
+			"events":      connector.Events,
+			"project_id":  connector.ProjectID,
+			"active":      connector.Active,
+			"updated_at":  connector.UpdatedAt,
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":        result.Error.Error(),
+			"connector_id": connector.ID,
+		}).Error("Failed to update chat connector in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrChatConnectorNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"connector_id": connector.ID,
+	}).Debug("Chat connector updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresChatConnectorRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"connector_id": id,
+	}).Debug("Deleting chat connector in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.ChatConnector{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", r.clock.Now())
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":        result.Error.Error(),
+			"connector_id": id,
+		}).Error("Failed to delete chat connector in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrChatConnectorNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"connector_id": id,
+	}).Debug("Chat connector deleted successfully in database")
+
+	return nil
+}
+
+func (r *PostgresChatConnectorRepository) ListSubscribed(ctx context.Context, event domain.ChatEvent, projectID *uuid.UUID) ([]domain.ChatConnector, error) {
+	db := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL AND active").
+		Where("events @> ?::jsonb", domain.StringList{string(event)})
+	if projectID != nil {
+		db = db.Where("project_id IS NULL OR project_id = ?", *projectID)
+	} else {
+		db = db.Where("project_id IS NULL")
+	}
+
+	var connectors []domain.ChatConnector
+	if err := db.Order("id").Find(&connectors).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"event": event,
+		}).Error("Failed to list subscribed chat connectors from database")
+		return nil, err
+	}
+
+	return connectors, nil
+}
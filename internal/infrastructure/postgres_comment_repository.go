@@ -0,0 +1,126 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresCommentRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresCommentRepository(db *gorm.DB) *PostgresCommentRepository {
+	return &PostgresCommentRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresCommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	r.logger.WithFields(logrus.Fields{
+		"comment_id": comment.ID,
+		"item_id":    comment.ItemID,
+		"author_id":  comment.AuthorID,
+	}).Debug("Creating comment in database")
+
+	if err := r.db.WithContext(ctx).Create(comment).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"comment_id": comment.ID,
+		}).Error("Failed to create comment in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresCommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	var comment domain.Comment
+	err := r.db.WithContext(ctx).First(&comment, "id = ? AND deleted_at IS NULL", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrCommentNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"comment_id": id,
+		}).Error("Failed to get comment from database")
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (r *PostgresCommentRepository) List(ctx context.Context, itemID uuid.UUID, pagination domain.Pagination) ([]domain.Comment, error) {
+	r.logger.WithFields(logrus.Fields{
+		"item_id": itemID,
+		"limit":   pagination.Limit,
+		"offset":  pagination.Offset,
+		"sort":    pagination.Sort,
+	}).Debug("Listing comments from database")
+
+	var comments []domain.Comment
+	db := r.db.WithContext(ctx).Where("item_id = ? AND deleted_at IS NULL", itemID)
+	if pagination.Sort != "" {
+		db = db.Order(pagination.Sort)
+	}
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+	if err := db.Find(&comments).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": itemID,
+		}).Error("Failed to list comments from database")
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *PostgresCommentRepository) Count(ctx context.Context, itemID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&domain.Comment{}).
+		Where("item_id = ? AND deleted_at IS NULL", itemID).
+		Count(&total).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": itemID,
+		}).Error("Failed to count comments in database")
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *PostgresCommentRepository) Delete(ctx context.Context, id uuid.UUID, deletedAt time.Time) error {
+	r.logger.WithFields(logrus.Fields{
+		"comment_id": id,
+	}).Debug("Soft deleting comment in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.Comment{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", deletedAt)
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      result.Error.Error(),
+			"comment_id": id,
+		}).Error("Failed to soft delete comment in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresCouponRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+}
+
+func NewPostgresCouponRepository(db *gorm.DB) *PostgresCouponRepository {
+	return &PostgresCouponRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+	}
+}
+
+func (r *PostgresCouponRepository) WithClock(clock domain.Clock) *PostgresCouponRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresCouponRepository) Create(ctx context.Context, coupon *domain.Coupon) error {
+	r.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+		"type":      coupon.Type,
+		"value":     coupon.Value,
+	}).Debug("Creating coupon in database")
+
+	err := r.db.WithContext(ctx).Create(coupon).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": coupon.ID,
+			"code":      coupon.Code,
+		}).Error("Failed to create coupon in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+	}).Debug("Coupon created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresCouponRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Coupon, error) {
+	r.logger.WithFields(logrus.Fields{
+		"coupon_id": id,
+	}).Debug("Getting coupon by ID from database")
+
+	var coupon domain.Coupon
+	err := r.db.WithContext(ctx).First(&coupon, "id = ? AND deleted_at IS NULL", id).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": id,
+		}).Warn("Coupon not found in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+	}).Debug("Coupon retrieved successfully from database")
+
+	return &coupon, nil
+}
+
+func (r *PostgresCouponRepository) GetByCode(ctx context.Context, code string) (*domain.Coupon, error) {
+	r.logger.WithFields(logrus.Fields{
+		"code": code,
+	}).Debug("Getting coupon by code from database")
+
+	var coupon domain.Coupon
+	err := r.db.WithContext(ctx).First(&coupon, "code = ? AND deleted_at IS NULL", code).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"code":  code,
+		}).Warn("Coupon not found by code in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+	}).Debug("Coupon retrieved successfully by code from database")
+
+	return &coupon, nil
+}
+
+func (r *PostgresCouponRepository) List(ctx context.Context, filter domain.CouponParams, pagination domain.Pagination) ([]domain.Coupon, error) {
+	r.logger.WithFields(logrus.Fields{
+		"filter_code":     filter.Code,
+		"filter_type":     filter.Type,
+		"filter_category": filter.Category,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
+		"sort":            pagination.Sort,
+	}).Debug("Listing coupons from database with filters")
+
+	var coupons []domain.Coupon
+	db := r.db.WithContext(ctx).Model(&domain.Coupon{})
+
+	if filter.Code != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_code": filter.Code,
+		}).Debug("Applying code filter")
+		db = db.Where("code ILIKE ?", "%"+filter.Code+"%")
+	}
+
+	if filter.Type != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_type": filter.Type,
+		}).Debug("Applying type filter")
+		db = db.Where("type = ?", filter.Type)
+	}
+
+	if filter.Category != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_category": filter.Category,
+		}).Debug("Applying category filter")
+		db = db.Where("category ILIKE ?", "%"+filter.Category+"%")
+	}
+
+	if filter.ProductID != nil {
+		r.logger.WithFields(logrus.Fields{
+			"filter_product_id": filter.ProductID,
+		}).Debug("Applying product_id filter")
+		db = db.Where("product_id = ?", *filter.ProductID)
+	}
+
+	if filter.ValidAt != nil {
+		r.logger.WithFields(logrus.Fields{
+			"valid_at": filter.ValidAt,
+		}).Debug("Applying valid_at filter")
+		db = db.Where("(starts_at IS NULL OR starts_at <= ?) AND (ends_at IS NULL OR ends_at >= ?)", *filter.ValidAt, *filter.ValidAt).
+			Where("max_uses IS NULL OR used_count < max_uses")
+	}
+
+	db = db.Where("deleted_at IS NULL")
+
+	if pagination.Sort != "" {
+		r.logger.WithFields(logrus.Fields{
+			"sort": pagination.Sort,
+		}).Debug("Applying sort")
+		db = db.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"limit": pagination.Limit,
+		}).Debug("Applying limit")
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"offset": pagination.Offset,
+		}).Debug("Applying offset")
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&coupons).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list coupons from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(coupons),
+	}).Debug("Coupons listed successfully from database")
+
+	return coupons, nil
+}
+
+func (r *PostgresCouponRepository) Update(ctx context.Context, coupon *domain.Coupon) error {
+	r.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+	}).Debug("Updating coupon in database")
+
+	err := r.db.WithContext(ctx).Model(coupon).Updates(coupon).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": coupon.ID,
+		}).Error("Failed to update coupon in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"coupon_id": coupon.ID,
+		"code":      coupon.Code,
+	}).Debug("Coupon updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresCouponRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"coupon_id": id,
+	}).Debug("Soft deleting coupon in database")
+
+	err := r.db.WithContext(ctx).Model(&domain.Coupon{}).Where("id = ?", id).Update("deleted_at", r.clock.Now()).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": id,
+		}).Error("Failed to soft delete coupon in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"coupon_id": id,
+	}).Debug("Coupon soft deleted successfully in database")
+
+	return nil
+}
+
+// IncrementUsage consumes one use of the coupon.
+func (r *PostgresCouponRepository) IncrementUsage(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"coupon_id": id,
+	}).Debug("Incrementing coupon usage in database")
+
+	err := redeemCoupon(r.db.WithContext(ctx), id, r.clock.Now())
+	if errors.Is(err, domain.ErrCouponUsageLimitReached) {
+		r.logger.WithFields(logrus.Fields{
+			"coupon_id": id,
+		}).Warn("Coupon usage limit reached")
+		return err
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"coupon_id": id,
+		}).Error("Failed to increment coupon usage in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"coupon_id": id,
+	}).Debug("Coupon usage incremented successfully in database")
+
+	return nil
+}
+
+// redeemCoupon consumes one use of the coupon. The limit is checked in the
+// same statement so concurrent redemptions cannot exceed max_uses.
+func redeemCoupon(tx *gorm.DB, id uuid.UUID, now time.Time) error {
+	result := tx.Model(&domain.Coupon{}).
+		Where("id = ? AND deleted_at IS NULL AND (max_uses IS NULL OR used_count < max_uses)", id).
+		Updates(map[string]interface{}{
+			"used_count": gorm.Expr("used_count + 1"),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrCouponUsageLimitReached
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresCustomFieldRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+}
+
+func NewPostgresCustomFieldRepository(db *gorm.DB) *PostgresCustomFieldRepository {
+	return &PostgresCustomFieldRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+	}
+}
+
+func (r *PostgresCustomFieldRepository) WithClock(clock domain.Clock) *PostgresCustomFieldRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresCustomFieldRepository) Create(ctx context.Context, definition *domain.CustomFieldDefinition) error {
+	r.logger.WithFields(logrus.Fields{
+		"custom_field_id": definition.ID,
+		"entity":          definition.Entity,
+		"project_id":      definition.ProjectID,
+		"key":             definition.Key,
+	}).Debug("Creating custom field in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A global key clashes with every project's keys and a project key
+		// with the global ones, which no unique index can express, so
+		// creations of the same key are serialised instead.
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", string(definition.Entity)+":"+definition.Key).Error; err != nil {
+			return err
+		}
+
+		clash := tx.Model(&domain.CustomFieldDefinition{}).
+			Where("entity = ? AND key = ? AND deleted_at IS NULL", definition.Entity, definition.Key)
+		if definition.ProjectID != nil {
+			clash = clash.Where("project_id IS NULL OR project_id = ?", *definition.ProjectID)
+		}
+		var count int64
+		if err := clash.Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return domain.ErrCustomFieldKeyTaken
+		}
+
+		return tx.Create(definition).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"key":   definition.Key,
+		}).Error("Failed to create custom field in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"custom_field_id": definition.ID,
+	}).Debug("Custom field created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresCustomFieldRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CustomFieldDefinition, error) {
+	r.logger.WithFields(logrus.Fields{
+		"custom_field_id": id,
+	}).Debug("Getting custom field by ID from database")
+
+	var definition domain.CustomFieldDefinition
+	err := r.db.WithContext(ctx).First(&definition, "id = ? AND deleted_at IS NULL", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"custom_field_id": id,
+		}).Warn("Custom field not found in database")
+		return nil, domain.ErrCustomFieldNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"custom_field_id": id,
+		}).Error("Failed to get custom field from database")
+		return nil, err
+	}
+
+	return &definition, nil
+}
+
+func (r *PostgresCustomFieldRepository) List(ctx context.Context, filter domain.CustomFieldParams) ([]domain.CustomFieldDefinition, error) {
+	r.logger.WithFields(logrus.Fields{
+		"entity":      filter.Entity,
+		"project_id":  filter.ProjectID,
+		"exact_scope": filter.ExactScope,
+	}).Debug("Listing custom fields from database")
+
+	db := r.db.WithContext(ctx).Model(&domain.CustomFieldDefinition{}).Where("deleted_at IS NULL")
+	if filter.Entity != "" {
+		db = db.Where("entity = ?", filter.Entity)
+	}
+	switch {
+	case filter.ProjectID != nil && filter.ExactScope:
+		db = db.Where("project_id = ?", *filter.ProjectID)
+	case filter.ProjectID != nil:
+		db = db.Where("project_id IS NULL OR project_id = ?", *filter.ProjectID)
+	case filter.ExactScope:
+		db = db.Where("project_id IS NULL")
+	}
+
+	var definitions []domain.CustomFieldDefinition
+	if err := db.Order("entity, project_id NULLS FIRST, key").Find(&definitions).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list custom fields from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(definitions),
+	}).Debug("Custom fields listed successfully from database")
+
+	return definitions, nil
+}
+
+func (r *PostgresCustomFieldRepository) Update(ctx context.Context, definition *domain.CustomFieldDefinition) error {
+	r.logger.WithFields(logrus.Fields{
+		"custom_field_id": definition.ID,
+	}).Debug("Updating custom field in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.CustomFieldDefinition{}).
+		Where("id = ? AND deleted_at IS NULL", definition.ID).
+		Updates(map[string]interface{}{
+			"label":      definition.Label,
+			"options":    definition.Options,
+			"required":   definition.Required,
+			"updated_at": definition.UpdatedAt,
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           result.Error.Error(),
+			"custom_field_id": definition.ID,
+		}).Error("Failed to update custom field in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrCustomFieldNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"custom_field_id": definition.ID,
+	}).Debug("Custom field updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresCustomFieldRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"custom_field_id": id,
+	}).Debug("Deleting custom field in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var definition domain.CustomFieldDefinition
+		if err := tx.First(&definition, "id = ? AND deleted_at IS NULL", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrCustomFieldNotFound
+			}
+			return err
+		}
+
+		if err := tx.Model(&definition).Update("deleted_at", r.clock.Now()).Error; err != nil {
+			return err
+		}
+
+		return stripCustomFieldValues(tx, &definition)
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"custom_field_id": id,
+		}).Error("Failed to delete custom field in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"custom_field_id": id,
+	}).Debug("Custom field deleted successfully in database")
+
+	return nil
+}
+
+// stripCustomFieldValues removes a deleted definition's key from the records
+// it applied to.
+func stripCustomFieldValues(tx *gorm.DB, definition *domain.CustomFieldDefinition) error {
+	db := tx.Model(&domain.ProjectItem{})
+	if definition.Entity == domain.CustomFieldEntityProject {
+		db = tx.Model(&domain.Project{})
+	} else if definition.ProjectID != nil {
+		db = db.Where("project_id = ?", *definition.ProjectID)
+	}
+
+	return db.Where("jsonb_exists(custom_fields, ?)", definition.Key).
+		Update("custom_fields", gorm.Expr("custom_fields - ?", definition.Key)).Error
+}
+
+// whereCustomFields keeps the records whose custom field values equal the
+// given ones as text, so numbers match in their JSON form ("5", "2.5").
+func whereCustomFields(db *gorm.DB, values map[string]string) *gorm.DB {
+	for key, value := range values {
+		db = db.Where("custom_fields ->> ? = ?", key, value)
+	}
+	return db
+}
@@ -0,0 +1,73 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresDashboardRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresDashboardRepository(db *gorm.DB) *PostgresDashboardRepository {
+	return &PostgresDashboardRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresDashboardRepository) ItemCounts(ctx context.Context, userID uuid.UUID, today time.Time) (*domain.DashboardItemCounts, error) {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"today":   today,
+	}).Debug("Counting dashboard items in database")
+
+	tomorrow := today.AddDate(0, 0, 1)
+
+	var counts domain.DashboardItemCounts
+	err := r.db.WithContext(ctx).Model(&domain.ProjectItem{}).
+		Select("COUNT(*) AS open, "+
+			"COUNT(*) FILTER (WHERE due_date < ?) AS overdue, "+
+			"COUNT(*) FILTER (WHERE due_date >= ? AND due_date < ?) AS due_today", today, today, tomorrow).
+		Where("assigned_to = ? AND deleted_at IS NULL", userID).
+		Where("status NOT IN ?", domain.ClosedProjectItemStatuses).
+		Scan(&counts).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to count dashboard items in database")
+		return nil, err
+	}
+
+	return &counts, nil
+}
+
+func (r *PostgresDashboardRepository) ProjectStatusCounts(ctx context.Context, ownerID uuid.UUID) ([]domain.ProjectStatusCount, error) {
+	r.logger.WithFields(logrus.Fields{
+		"owner_id": ownerID,
+	}).Debug("Counting owned projects by status in database")
+
+	var counts []domain.ProjectStatusCount
+	err := r.db.WithContext(ctx).Model(&domain.Project{}).
+		Select("status, COUNT(*) AS projects").
+		Where("owner_id = ? AND deleted_at IS NULL AND archived_at IS NULL", ownerID).
+		Group("status").
+		Order("status").
+		Scan(&counts).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"owner_id": ownerID,
+		}).Error("Failed to count owned projects in database")
+		return nil, err
+	}
+
+	return counts, nil
+}
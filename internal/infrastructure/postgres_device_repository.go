@@ -0,0 +1,192 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresDeviceRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresDeviceRepository(db *gorm.DB) *PostgresDeviceRepository {
+	return &PostgresDeviceRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresDeviceRepository) Register(ctx context.Context, device *domain.Device) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":  device.UserID,
+		"platform": device.Platform,
+	}).Debug("Registering device in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing domain.Device
+		err := tx.Where("token = ?", device.Token).Take(&existing).Error
+		switch {
+		case err == nil:
+			device.ID = existing.ID
+			device.CreatedAt = existing.CreatedAt
+			if err := tx.Model(&existing).Updates(map[string]any{
+				"user_id":      device.UserID,
+				"platform":     device.Platform,
+				"last_seen_at": device.LastSeenAt,
+			}).Error; err != nil {
+				return err
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := tx.Create(device).Error; err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		keep := tx.Model(&domain.Device{}).Select("id").
+			Where("user_id = ?", device.UserID).
+			Order("last_seen_at DESC, id").
+			Limit(domain.MaxDevicesPerUser)
+		return tx.Where("user_id = ? AND id NOT IN (?)", device.UserID, keep).Delete(&domain.Device{}).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": device.UserID,
+		}).Error("Failed to register device in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"device_id": device.ID,
+		"user_id":   device.UserID,
+	}).Debug("Device registered successfully in database")
+
+	return nil
+}
+
+func (r *PostgresDeviceRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Device, error) {
+	return r.ListByUsers(ctx, []uuid.UUID{userID})
+}
+
+func (r *PostgresDeviceRepository) ListByUsers(ctx context.Context, userIDs []uuid.UUID) ([]domain.Device, error) {
+	r.logger.WithFields(logrus.Fields{
+		"users": len(userIDs),
+	}).Debug("Listing devices from database")
+
+	var devices []domain.Device
+	if len(userIDs) == 0 {
+		return devices, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("user_id IN ?", userIDs).Order("last_seen_at DESC, id").Find(&devices).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"users": len(userIDs),
+		}).Error("Failed to list devices from database")
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+func (r *PostgresDeviceRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"device_id": id,
+		"user_id":   userID,
+	}).Debug("Deleting device from database")
+
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&domain.Device{})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     result.Error.Error(),
+			"device_id": id,
+		}).Error("Failed to delete device from database")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithFields(logrus.Fields{
+			"device_id": id,
+			"user_id":   userID,
+		}).Warn("Device not found for user")
+		return domain.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresDeviceRepository) DeleteByToken(ctx context.Context, token string) error {
+	r.logger.Debug("Deleting device by token from database")
+
+	if err := r.db.WithContext(ctx).Where("token = ?", token).Delete(&domain.Device{}).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to delete device by token from database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresDeviceRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreferences, error) {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+	}).Debug("Getting notification preferences from database")
+
+	var preferences domain.NotificationPreferences
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Take(&preferences).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to get notification preferences from database")
+		return nil, err
+	}
+
+	return &preferences, nil
+}
+
+func (r *PostgresDeviceRepository) ListPreferences(ctx context.Context, userIDs []uuid.UUID) ([]domain.NotificationPreferences, error) {
+	var preferences []domain.NotificationPreferences
+	if len(userIDs) == 0 {
+		return preferences, nil
+	}
+
+	if err := r.db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&preferences).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"users": len(userIDs),
+		}).Error("Failed to list notification preferences from database")
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (r *PostgresDeviceRepository) SavePreferences(ctx context.Context, preferences *domain.NotificationPreferences) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": preferences.UserID,
+	}).Debug("Saving notification preferences in database")
+
+	if err := r.db.WithContext(ctx).Save(preferences).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": preferences.UserID,
+		}).Error("Failed to save notification preferences in database")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresExchangeRateRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresExchangeRateRepository(db *gorm.DB) *PostgresExchangeRateRepository {
+	return &PostgresExchangeRateRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresExchangeRateRepository) SaveRates(ctx context.Context, rates []domain.ExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"base":       rates[0].Base,
+		"currencies": len(rates),
+	}).Debug("Saving exchange rates in database")
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "base"}, {Name: "currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "provider", "effective_at", "fetched_at"}),
+	}).Create(&rates).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"base":  rates[0].Base,
+		}).Error("Failed to save exchange rates in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresExchangeRateRepository) ListRates(ctx context.Context, base string) ([]domain.ExchangeRate, error) {
+	var rates []domain.ExchangeRate
+	err := r.db.WithContext(ctx).Where("base = ?", base).Order("currency").Find(&rates).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"base":  base,
+		}).Error("Failed to list exchange rates from database")
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (r *PostgresExchangeRateRepository) CreateSync(ctx context.Context, sync *domain.ExchangeRateSync) error {
+	if err := r.db.WithContext(ctx).Create(sync).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"provider": sync.Provider,
+		}).Error("Failed to record exchange rate sync in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresExchangeRateRepository) ListSyncs(ctx context.Context, pagination domain.Pagination) ([]domain.ExchangeRateSync, error) {
+	db := r.db.WithContext(ctx).Order("started_at DESC, id")
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	var syncs []domain.ExchangeRateSync
+	if err := db.Find(&syncs).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list exchange rate syncs from database")
+		return nil, err
+	}
+
+	return syncs, nil
+}
+
+func (r *PostgresExchangeRateRepository) Metrics(ctx context.Context, base string) (*domain.ExchangeRateMetrics, error) {
+	var rates struct {
+		Currencies      int64
+		OldestFetchedAt *time.Time
+	}
+	err := r.db.WithContext(ctx).Model(&domain.ExchangeRate{}).
+		Select("COUNT(*) AS currencies, MIN(fetched_at) AS oldest_fetched_at").
+		Where("base = ?", base).
+		Scan(&rates).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to compute exchange rate metrics in database")
+		return nil, err
+	}
+
+	var syncs struct {
+		LastSyncAt          *time.Time
+		LastSuccessAt       *time.Time
+		ConsecutiveFailures int64
+	}
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT MAX(started_at) AS last_sync_at,
+			MAX(started_at) FILTER (WHERE error = '') AS last_success_at,
+			COUNT(*) FILTER (WHERE error <> '' AND started_at > COALESCE(
+				(SELECT MAX(started_at) FROM exchange_rate_syncs WHERE error = '' AND base = ?), '-infinity')) AS consecutive_failures
+		FROM exchange_rate_syncs
+		WHERE base = ?`, base, base).
+		Scan(&syncs).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to compute exchange rate sync metrics in database")
+		return nil, err
+	}
+
+	metrics := &domain.ExchangeRateMetrics{
+		Base:                base,
+		Currencies:          rates.Currencies,
+		OldestFetchedAt:     rates.OldestFetchedAt,
+		LastSyncAt:          syncs.LastSyncAt,
+		LastSuccessAt:       syncs.LastSuccessAt,
+		ConsecutiveFailures: syncs.ConsecutiveFailures,
+	}
+	if syncs.LastSyncAt != nil {
+		var last domain.ExchangeRateSync
+		err := r.db.WithContext(ctx).Where("base = ?", base).Order("started_at DESC, id").Take(&last).Error
+		if err != nil {
+			r.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Failed to get last exchange rate sync from database")
+			return nil, err
+		}
+		metrics.LastError = last.Error
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,219 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresExpenseRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+}
+
+func NewPostgresExpenseRepository(db *gorm.DB) *PostgresExpenseRepository {
+	return &PostgresExpenseRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+	}
+}
+
+func (r *PostgresExpenseRepository) WithClock(clock domain.Clock) *PostgresExpenseRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresExpenseRepository) Create(ctx context.Context, expense *domain.Expense) error {
+	r.logger.WithFields(logrus.Fields{
+		"expense_id": expense.ID,
+		"project_id": expense.ProjectID,
+		"amount":     expense.Amount,
+	}).Debug("Creating expense in database")
+
+	err := r.db.WithContext(ctx).Create(expense).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"expense_id": expense.ID,
+			"project_id": expense.ProjectID,
+		}).Error("Failed to create expense in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"expense_id": expense.ID,
+		"project_id": expense.ProjectID,
+	}).Debug("Expense created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresExpenseRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Expense, error) {
+	r.logger.WithFields(logrus.Fields{
+		"expense_id": id,
+	}).Debug("Getting expense by ID from database")
+
+	var expense domain.Expense
+	err := r.db.WithContext(ctx).First(&expense, "id = ? AND deleted_at IS NULL", id).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"expense_id": id,
+		}).Warn("Expense not found in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"expense_id": expense.ID,
+		"project_id": expense.ProjectID,
+	}).Debug("Expense retrieved successfully from database")
+
+	return &expense, nil
+}
+
+func (r *PostgresExpenseRepository) List(ctx context.Context, projectID uuid.UUID, filter domain.ExpenseParams, pagination domain.Pagination) ([]domain.Expense, error) {
+	r.logger.WithFields(logrus.Fields{
+		"project_id":      projectID,
+		"filter_category": filter.Category,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
+		"sort":            pagination.Sort,
+	}).Debug("Listing expenses from database with filters")
+
+	var expenses []domain.Expense
+	db := r.db.WithContext(ctx).Model(&domain.Expense{}).Where("project_id = ?", projectID)
+
+	if filter.Category != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_category": filter.Category,
+		}).Debug("Applying category filter")
+		db = db.Where("category = ?", filter.Category)
+	}
+
+	if filter.DateFrom != nil {
+		r.logger.WithFields(logrus.Fields{
+			"filter_date_from": filter.DateFrom,
+		}).Debug("Applying date from filter")
+		db = db.Where("date >= ?", filter.DateFrom)
+	}
+
+	if filter.DateTo != nil {
+		r.logger.WithFields(logrus.Fields{
+			"filter_date_to": filter.DateTo,
+		}).Debug("Applying date to filter")
+		db = db.Where("date <= ?", filter.DateTo)
+	}
+
+	db = db.Where("deleted_at IS NULL")
+
+	if pagination.Sort != "" {
+		r.logger.WithFields(logrus.Fields{
+			"sort": pagination.Sort,
+		}).Debug("Applying sort")
+		db = db.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"limit": pagination.Limit,
+		}).Debug("Applying limit")
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"offset": pagination.Offset,
+		}).Debug("Applying offset")
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&expenses).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list expenses from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(expenses),
+	}).Debug("Expenses listed successfully from database")
+
+	return expenses, nil
+}
+
+func (r *PostgresExpenseRepository) Update(ctx context.Context, expense *domain.Expense) error {
+	r.logger.WithFields(logrus.Fields{
+		"expense_id": expense.ID,
+		"project_id": expense.ProjectID,
+	}).Debug("Updating expense in database")
+
+	err := r.db.WithContext(ctx).Model(expense).Updates(expense).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"expense_id": expense.ID,
+		}).Error("Failed to update expense in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"expense_id": expense.ID,
+		"project_id": expense.ProjectID,
+	}).Debug("Expense updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresExpenseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"expense_id": id,
+	}).Debug("Soft deleting expense in database")
+
+	err := r.db.WithContext(ctx).Model(&domain.Expense{}).Where("id = ?", id).Update("deleted_at", r.clock.Now()).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"expense_id": id,
+		}).Error("Failed to soft delete expense in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"expense_id": id,
+	}).Debug("Expense soft deleted successfully in database")
+
+	return nil
+}
+
+func (r *PostgresExpenseRepository) TotalsByCategory(ctx context.Context, projectID uuid.UUID) ([]domain.ExpenseCategoryTotal, error) {
+	r.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+	}).Debug("Summing project expenses by category in database")
+
+	var totals []domain.ExpenseCategoryTotal
+	err := r.db.WithContext(ctx).Model(&domain.Expense{}).
+		Select("category, SUM(amount) AS amount, COUNT(*) AS count").
+		Where("project_id = ? AND deleted_at IS NULL", projectID).
+		Group("category").
+		Order("amount DESC, category").
+		Scan(&totals).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+		}).Error("Failed to sum project expenses in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"categories": len(totals),
+	}).Debug("Project expenses summed successfully in database")
+
+	return totals, nil
+}
@@ -0,0 +1,95 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresFailedLoginRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresFailedLoginRepository(db *gorm.DB) *PostgresFailedLoginRepository {
+	return &PostgresFailedLoginRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresFailedLoginRepository) Create(ctx context.Context, failure *domain.FailedLogin) error {
+	if err := r.db.WithContext(ctx).Create(failure).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": failure.UserID,
+			"ip":      failure.IP,
+		}).Error("Failed to create failed login in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresFailedLoginRepository) CountForUser(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.FailedLogin{}).
+		Where("user_id = ? AND created_at >= ? AND cleared_at IS NULL", userID, since).
+		Count(&count).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to count failed logins of user in database")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *PostgresFailedLoginRepository) CountForIP(ctx context.Context, ip string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.FailedLogin{}).
+		Where("ip = ? AND created_at >= ?", ip, since).
+		Count(&count).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"ip":    ip,
+		}).Error("Failed to count failed logins of address in database")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *PostgresFailedLoginRepository) ClearForUser(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	err := r.db.WithContext(ctx).Model(&domain.FailedLogin{}).
+		Where("user_id = ? AND cleared_at IS NULL", userID).
+		Update("cleared_at", at).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to clear failed logins of user in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresFailedLoginRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&domain.FailedLogin{})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": result.Error.Error(),
+		}).Error("Failed to delete old failed logins from database")
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
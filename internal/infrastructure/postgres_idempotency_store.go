@@ -0,0 +1,128 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostgresIdempotencyStore keeps Idempotency-Key records in the database,
+// shared by every instance. Expired records are taken over by the next
+// request with their key and deleted every IdempotencyCleanupInterval.
+type PostgresIdempotencyStore struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresIdempotencyStore(db *gorm.DB) *PostgresIdempotencyStore {
+	return &PostgresIdempotencyStore{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (s *PostgresIdempotencyStore) Claim(ctx context.Context, key, requestHash string, now time.Time) (*domain.IdempotencyRecord, bool, error) {
+	s.sweep(ctx, now)
+
+	// A record whose key expired is overwritten in the same statement, so
+	// two requests racing for it cannot both claim it.
+	record := &domain.IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(domain.IdempotencyLockTTL),
+	}
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"request_hash", "status", "content_type", "location", "body", "created_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "idempotency_records.expires_at <= ?", Vars: []interface{}{now}},
+		}},
+	}).Create(record)
+	if result.Error != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": result.Error.Error(),
+		}).Error("Failed to claim idempotency key in database")
+		return nil, false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return nil, true, nil
+	}
+
+	var existing domain.IdempotencyRecord
+	if err := s.db.WithContext(ctx).First(&existing, "key = ?", key).Error; err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to get idempotency key from database")
+		return nil, false, err
+	}
+
+	return &existing, false, nil
+}
+
+func (s *PostgresIdempotencyStore) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	err := s.db.WithContext(ctx).Model(&domain.IdempotencyRecord{}).
+		Where("key = ?", record.Key).
+		Updates(map[string]interface{}{
+			"status":       record.Status,
+			"content_type": record.ContentType,
+			"location":     record.Location,
+			"body":         record.Body,
+			"expires_at":   record.ExpiresAt,
+		}).Error
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"status": record.Status,
+		}).Error("Failed to store idempotent response in database")
+		return err
+	}
+
+	return nil
+}
+
+func (s *PostgresIdempotencyStore) Release(ctx context.Context, key string) error {
+	err := s.db.WithContext(ctx).Where("key = ? AND status = 0", key).Delete(&domain.IdempotencyRecord{}).Error
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to release idempotency key in database")
+		return err
+	}
+
+	return nil
+}
+
+// sweep deletes expired records at most once per
+// IdempotencyCleanupInterval. Failures are only logged; expired records are
+// taken over by Claim anyway.
+func (s *PostgresIdempotencyStore) sweep(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastSweep) < domain.IdempotencyCleanupInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSweep = now
+	s.mu.Unlock()
+
+	result := s.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&domain.IdempotencyRecord{})
+	if result.Error != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": result.Error.Error(),
+		}).Warn("Failed to delete expired idempotency keys from database")
+		return
+	}
+	if result.RowsAffected > 0 {
+		s.logger.WithFields(logrus.Fields{
+			"deleted": result.RowsAffected,
+		}).Debug("Expired idempotency keys deleted")
+	}
+}
@@ -0,0 +1,153 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresNotificationRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresNotificationRepository(db *gorm.DB) *PostgresNotificationRepository {
+	return &PostgresNotificationRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresNotificationRepository) CreateBatch(ctx context.Context, notifications []domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(notifications),
+	}).Debug("Creating notifications in database")
+
+	err := r.db.WithContext(ctx).Create(&notifications).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"count": len(notifications),
+		}).Error("Failed to create notifications in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(notifications),
+	}).Debug("Notifications created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresNotificationRepository) ListByUser(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination domain.Pagination) ([]domain.Notification, error) {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"unread_only": unreadOnly,
+		"limit":       pagination.Limit,
+		"offset":      pagination.Offset,
+	}).Debug("Listing notifications from database")
+
+	var notifications []domain.Notification
+	db := r.db.WithContext(ctx).Where("user_id = ?", userID)
+
+	if unreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+
+	db = db.Order("created_at DESC, id")
+
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&notifications).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list notifications from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+		"count":   len(notifications),
+	}).Debug("Notifications listed successfully from database")
+
+	return notifications, nil
+}
+
+func (r *PostgresNotificationRepository) MarkRead(ctx context.Context, userID, id uuid.UUID, readAt time.Time) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":         userID,
+		"notification_id": id,
+	}).Debug("Marking notification as read in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", readAt))
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           result.Error.Error(),
+			"notification_id": id,
+		}).Error("Failed to mark notification as read in database")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithFields(logrus.Fields{
+			"user_id":         userID,
+			"notification_id": id,
+		}).Warn("Notification not found for user")
+		return domain.ErrNotificationNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"notification_id": id,
+	}).Debug("Notification marked as read successfully in database")
+
+	return nil
+}
+
+func (r *PostgresNotificationRepository) ListDueSoon(ctx context.Context, now time.Time, window time.Duration, limit int) ([]domain.ProjectItem, error) {
+	r.logger.WithFields(logrus.Fields{
+		"window": window,
+		"limit":  limit,
+	}).Debug("Listing project items due soon from database")
+
+	// A reminder counts for the current due date when it was sent within
+	// the window before it, so moving the date later earns a new one.
+	reminded := r.db.Model(&domain.Notification{}).Select("1").
+		Where("notifications.event = ?", domain.ChangeEventDueSoon).
+		Where("notifications.target_id = project_items.id AND notifications.user_id = project_items.assigned_to").
+		Where("notifications.created_at >= project_items.due_date - make_interval(secs => ?)", window.Seconds())
+
+	var items []domain.ProjectItem
+	err := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL AND assigned_to IS NOT NULL").
+		Where("status NOT IN ?", domain.ClosedProjectItemStatuses).
+		Where("due_date > ? AND due_date <= ?", now, now.Add(window)).
+		Where("NOT EXISTS (?)", reminded).
+		Order("due_date, id").
+		Limit(limit).
+		Find(&items).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list project items due soon from database")
+		return nil, err
+	}
+
+	return items, nil
+}
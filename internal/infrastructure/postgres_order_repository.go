@@ -0,0 +1,391 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresOrderRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+	ids    domain.IDGenerator
+}
+
+func NewPostgresOrderRepository(db *gorm.DB) *PostgresOrderRepository {
+	return &PostgresOrderRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+		ids:    domain.UUIDv7Generator{},
+	}
+}
+
+func (r *PostgresOrderRepository) WithClock(clock domain.Clock) *PostgresOrderRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresOrderRepository) WithIDGenerator(ids domain.IDGenerator) *PostgresOrderRepository {
+	r.ids = ids
+	return r
+}
+
+func (r *PostgresOrderRepository) Create(ctx context.Context, order *domain.Order) error {
+	r.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+		"user_id":  order.UserID,
+		"items":    len(order.Items),
+	}).Debug("Creating order in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if order.CouponID != nil {
+			if err := redeemCoupon(tx, *order.CouponID, order.CreatedAt); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(order).Error; err != nil {
+			return err
+		}
+		return r.moveStock(tx, order, orderStockDelta(nil, order.Items), order.UserID, order.CreatedAt)
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"order_id": order.ID,
+		}).Error("Failed to create order in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+	}).Debug("Order created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
+	r.logger.WithFields(logrus.Fields{
+		"order_id": id,
+	}).Debug("Getting order by ID from database")
+
+	var order domain.Order
+	err := r.db.WithContext(ctx).Preload("Items").First(&order, "id = ? AND deleted_at IS NULL", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"order_id": id,
+		}).Warn("Order not found in database")
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"order_id": id,
+		}).Error("Failed to get order from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+		"status":   order.Status,
+	}).Debug("Order retrieved successfully from database")
+
+	return &order, nil
+}
+
+func (r *PostgresOrderRepository) List(ctx context.Context, filter domain.OrderParams, pagination domain.Pagination) ([]domain.Order, error) {
+	r.logger.WithFields(logrus.Fields{
+		"filter_user_id": filter.UserID,
+		"filter_status":  filter.Status,
+		"limit":          pagination.Limit,
+		"offset":         pagination.Offset,
+		"sort":           pagination.Sort,
+	}).Debug("Listing orders from database with filters")
+
+	var orders []domain.Order
+	db := r.db.WithContext(ctx).Model(&domain.Order{}).Preload("Items")
+
+	if filter.UserID != nil {
+		r.logger.WithFields(logrus.Fields{
+			"filter_user_id": *filter.UserID,
+		}).Debug("Applying user filter")
+		db = db.Where("user_id = ?", *filter.UserID)
+	}
+
+	if filter.Status != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_status": filter.Status,
+		}).Debug("Applying status filter")
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	db = db.Where("deleted_at IS NULL")
+
+	if pagination.Sort != "" {
+		r.logger.WithFields(logrus.Fields{
+			"sort": pagination.Sort,
+		}).Debug("Applying sort")
+		db = db.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"limit": pagination.Limit,
+		}).Debug("Applying limit")
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"offset": pagination.Offset,
+		}).Debug("Applying offset")
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&orders).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list orders from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(orders),
+	}).Debug("Orders listed successfully from database")
+
+	return orders, nil
+}
+
+func (r *PostgresOrderRepository) Update(ctx context.Context, order *domain.Order, actorID uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+		"items":    len(order.Items),
+		"actor_id": actorID,
+	}).Debug("Updating order in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockOrder(tx, order.ID)
+		if err != nil {
+			return err
+		}
+		if current.Status != domain.OrderStatusPending {
+			return domain.ErrOrderStatus
+		}
+
+		if err := tx.Model(&domain.Order{}).Where("id = ?", order.ID).Updates(map[string]interface{}{
+			"notes":      order.Notes,
+			"subtotal":   order.Subtotal,
+			"discount":   order.Discount,
+			"total":      order.Total,
+			"updated_at": order.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("order_id = ?", order.ID).Delete(&domain.OrderItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&order.Items).Error; err != nil {
+			return err
+		}
+
+		return r.moveStock(tx, current, orderStockDelta(current.Items, order.Items), actorID, order.UpdatedAt)
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"order_id": order.ID,
+		}).Error("Failed to update order in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"order_id": order.ID,
+	}).Debug("Order updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresOrderRepository) Delete(ctx context.Context, id, actorID uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"order_id": id,
+		"actor_id": actorID,
+	}).Debug("Soft deleting order in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockOrder(tx, id)
+		if err != nil {
+			return err
+		}
+
+		now := r.clock.Now()
+		switch current.Status {
+		case domain.OrderStatusShipped, domain.OrderStatusDelivered:
+			return domain.ErrOrderStatus
+		case domain.OrderStatusPending, domain.OrderStatusPaid:
+			if err := r.moveStock(tx, current, orderStockDelta(current.Items, nil), actorID, now); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&domain.Order{}).Where("id = ?", id).Update("deleted_at", now).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"order_id": id,
+		}).Error("Failed to delete order from database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"order_id": id,
+	}).Debug("Order soft deleted successfully in database")
+
+	return nil
+}
+
+func (r *PostgresOrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string, actorID uuid.UUID) (*domain.Order, error) {
+	r.logger.WithFields(logrus.Fields{
+		"order_id": id,
+		"status":   status,
+		"actor_id": actorID,
+	}).Debug("Updating order status in database")
+
+	var order *domain.Order
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockOrder(tx, id)
+		if err != nil {
+			return err
+		}
+		if !domain.CanTransitionOrder(current.Status, status) {
+			return domain.ErrOrderStatus
+		}
+
+		now := r.clock.Now()
+		updates := map[string]interface{}{
+			"status":     status,
+			"updated_at": now,
+		}
+		switch status {
+		case domain.OrderStatusPaid:
+			current.PaidAt = &now
+			updates["paid_at"] = now
+		case domain.OrderStatusShipped:
+			current.ShippedAt = &now
+			updates["shipped_at"] = now
+		case domain.OrderStatusDelivered:
+			current.DeliveredAt = &now
+			updates["delivered_at"] = now
+		case domain.OrderStatusCancelled:
+			current.CancelledAt = &now
+			updates["cancelled_at"] = now
+			if err := r.moveStock(tx, current, orderStockDelta(current.Items, nil), actorID, now); err != nil {
+				return err
+			}
+		}
+		current.Status = status
+		current.UpdatedAt = now
+		order = current
+
+		return tx.Model(&domain.Order{}).Where("id = ?", id).Updates(updates).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"order_id": id,
+			"status":   status,
+		}).Error("Failed to update order status in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"order_id": id,
+		"status":   status,
+	}).Debug("Order status updated successfully in database")
+
+	return order, nil
+}
+
+// moveStock applies delta, the stock change per product, as sale and return
+// adjustments against the order's warehouse. Products are taken in ID order
+// so two orders sharing products always lock them in the same order.
+// Returns go through for archived products; a deleted product fails with
+// domain.ErrProductDeleted.
+func (r *PostgresOrderRepository) moveStock(tx *gorm.DB, order *domain.Order, delta map[uuid.UUID]int, actorID uuid.UUID, now time.Time) error {
+	productIDs := make([]uuid.UUID, 0, len(delta))
+	for productID, quantity := range delta {
+		if quantity != 0 {
+			productIDs = append(productIDs, productID)
+		}
+	}
+	sort.Slice(productIDs, func(i, j int) bool {
+		return productIDs[i].String() < productIDs[j].String()
+	})
+
+	for _, productID := range productIDs {
+		reason := domain.StockReasonSale
+		if delta[productID] > 0 {
+			reason = domain.StockReasonReturn
+		}
+		adj := &domain.StockAdjustment{
+			ID:          r.ids.NewID(),
+			ProductID:   productID,
+			WarehouseID: order.WarehouseID,
+			Quantity:    delta[productID],
+			Reason:      reason,
+			Note:        "order " + order.ID.String(),
+			ActorID:     actorID,
+			CreatedAt:   now,
+		}
+		apply := applyStockAdjustment
+		if reason == domain.StockReasonReturn {
+			apply = returnStock
+		}
+		if err := apply(tx, adj, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lockOrder reads an order and its items, locking the order row until the
+// transaction ends.
+func lockOrder(tx *gorm.DB, id uuid.UUID) (*domain.Order, error) {
+	var order domain.Order
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&order, "id = ? AND deleted_at IS NULL", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Where("order_id = ?", id).Order("id").Find(&order.Items).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// orderStockDelta is the stock change per product of replacing the items
+// before with after: what before took is put back and what after needs is
+// taken out.
+func orderStockDelta(before, after []domain.OrderItem) map[uuid.UUID]int {
+	delta := make(map[uuid.UUID]int)
+	for _, item := range before {
+		delta[item.ProductID] += item.Quantity
+	}
+	for _, item := range after {
+		delta[item.ProductID] -= item.Quantity
+	}
+	return delta
+}
@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresPasswordResetRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresPasswordResetRepository(db *gorm.DB) *PostgresPasswordResetRepository {
+	return &PostgresPasswordResetRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresPasswordResetRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": token.UserID,
+	}).Debug("Creating password reset token in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", token.CreatedAt).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": token.UserID,
+		}).Error("Failed to create password reset token in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresPasswordResetRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).Take(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrPasswordResetTokenInvalid
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to get password reset token from database")
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *PostgresPasswordResetRepository) LastCreatedAt(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	var tokens []domain.PasswordResetToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&tokens).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to get latest password reset token from database")
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	return &tokens[0].CreatedAt, nil
+}
+
+func (r *PostgresPasswordResetRepository) MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result := r.db.WithContext(ctx).Model(&domain.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    result.Error.Error(),
+			"token_id": id,
+		}).Error("Failed to mark password reset token used in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPasswordResetTokenInvalid
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// currentPolicyDocuments selects the latest published version of each kind.
+const currentPolicyDocuments = "SELECT DISTINCT ON (kind) * FROM policy_documents ORDER BY kind, published_at DESC, id"
+
+type PostgresPolicyRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresPolicyRepository(db *gorm.DB) *PostgresPolicyRepository {
+	return &PostgresPolicyRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresPolicyRepository) CreateDocument(ctx context.Context, document *domain.PolicyDocument) error {
+	r.logger.WithFields(logrus.Fields{
+		"document_id": document.ID,
+		"kind":        document.Kind,
+		"version":     document.Version,
+	}).Debug("Creating policy document in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialising publications per kind lets the count report a clash as
+		// ErrPolicyVersionExists before the unique index trips.
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "policy:"+string(document.Kind)).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&domain.PolicyDocument{}).
+			Where("kind = ? AND version = ?", document.Kind, document.Version).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return domain.ErrPolicyVersionExists
+		}
+
+		return tx.Create(document).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"kind":    document.Kind,
+			"version": document.Version,
+		}).Error("Failed to create policy document in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresPolicyRepository) GetDocument(ctx context.Context, id uuid.UUID) (*domain.PolicyDocument, error) {
+	var document domain.PolicyDocument
+	err := r.db.WithContext(ctx).First(&document, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"document_id": id,
+		}).Warn("Policy document not found in database")
+		return nil, domain.ErrPolicyDocumentNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"document_id": id,
+		}).Error("Failed to get policy document from database")
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+func (r *PostgresPolicyRepository) ListDocuments(ctx context.Context, kind domain.PolicyKind, pagination domain.Pagination) ([]domain.PolicyDocument, error) {
+	db := r.db.WithContext(ctx)
+	if kind != "" {
+		db = db.Where("kind = ?", kind)
+	}
+	db = db.Order("published_at DESC, id")
+
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	var documents []domain.PolicyDocument
+	if err := db.Find(&documents).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"kind":  kind,
+		}).Error("Failed to list policy documents from database")
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+func (r *PostgresPolicyRepository) CurrentDocuments(ctx context.Context) ([]domain.PolicyDocument, error) {
+	var documents []domain.PolicyDocument
+	if err := r.db.WithContext(ctx).Raw(currentPolicyDocuments).Scan(&documents).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to load current policy documents from database")
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+func (r *PostgresPolicyRepository) PendingDocuments(ctx context.Context, userID uuid.UUID) ([]domain.PolicyDocument, error) {
+	var documents []domain.PolicyDocument
+	err := r.db.WithContext(ctx).Raw(
+		"SELECT * FROM ("+currentPolicyDocuments+") current"+
+			" WHERE EXISTS (SELECT 1 FROM users WHERE users.id = ?)"+
+			" AND NOT EXISTS (SELECT 1 FROM policy_acceptances a WHERE a.document_id = current.id AND a.user_id = ?)"+
+			" ORDER BY kind",
+		userID, userID,
+	).Scan(&documents).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to load pending policy documents from database")
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+func (r *PostgresPolicyRepository) Accept(ctx context.Context, acceptance *domain.PolicyAcceptance) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":     acceptance.UserID,
+		"document_id": acceptance.DocumentID,
+	}).Debug("Recording policy acceptance in database")
+
+	db := r.db.WithContext(ctx)
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "document_id"}},
+		DoNothing: true,
+	}).Create(acceptance)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result = db.First(acceptance, "user_id = ? AND document_id = ?", acceptance.UserID, acceptance.DocumentID)
+	}
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":       result.Error.Error(),
+			"user_id":     acceptance.UserID,
+			"document_id": acceptance.DocumentID,
+		}).Error("Failed to record policy acceptance in database")
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *PostgresPolicyRepository) ListAcceptances(ctx context.Context, userID uuid.UUID) ([]domain.PolicyAcceptance, error) {
+	var acceptances []domain.PolicyAcceptance
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("accepted_at DESC, id").
+		Find(&acceptances).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list policy acceptances from database")
+		return nil, err
+	}
+
+	return acceptances, nil
+}
+
+func (r *PostgresPolicyRepository) ListDocumentAcceptances(ctx context.Context, documentID uuid.UUID, pagination domain.Pagination) ([]domain.PolicyAcceptance, error) {
+	db := r.db.WithContext(ctx).
+		Where("document_id = ?", documentID).
+		Order("accepted_at DESC, id")
+
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	var acceptances []domain.PolicyAcceptance
+	if err := db.Find(&acceptances).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"document_id": documentID,
+		}).Error("Failed to list policy document acceptances from database")
+		return nil, err
+	}
+
+	return acceptances, nil
+}
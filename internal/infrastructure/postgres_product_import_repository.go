@@ -0,0 +1,121 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresProductImportRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresProductImportRepository(db *gorm.DB) *PostgresProductImportRepository {
+	return &PostgresProductImportRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresProductImportRepository) Create(ctx context.Context, productImport *domain.ProductImport) error {
+	r.logger.WithFields(logrus.Fields{
+		"import_id": productImport.ID,
+		"size":      productImport.Size,
+	}).Debug("Creating product import in database")
+
+	if err := r.db.WithContext(ctx).Create(productImport).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"import_id": productImport.ID,
+		}).Error("Failed to create product import in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"import_id": productImport.ID,
+	}).Debug("Product import created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresProductImportRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ProductImport, error) {
+	r.logger.WithFields(logrus.Fields{
+		"import_id": id,
+	}).Debug("Getting product import by ID from database")
+
+	var productImport domain.ProductImport
+	err := r.db.WithContext(ctx).Omit("content").First(&productImport, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"import_id": id,
+		}).Warn("Product import not found in database")
+		return nil, domain.ErrProductImportNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"import_id": id,
+		}).Error("Failed to get product import from database")
+		return nil, err
+	}
+
+	return &productImport, nil
+}
+
+func (r *PostgresProductImportRepository) UpdateProgress(ctx context.Context, productImport *domain.ProductImport) error {
+	return r.update(ctx, productImport.ID, productImportUpdates(productImport))
+}
+
+func (r *PostgresProductImportRepository) Complete(ctx context.Context, productImport *domain.ProductImport, completedAt time.Time) error {
+	updates := productImportUpdates(productImport)
+	updates["status"] = domain.ProductImportStatusCompleted
+	updates["content"] = nil
+	updates["completed_at"] = completedAt
+	return r.update(ctx, productImport.ID, updates)
+}
+
+func (r *PostgresProductImportRepository) Fail(ctx context.Context, id uuid.UUID, reason string, completedAt time.Time) error {
+	return r.update(ctx, id, map[string]interface{}{
+		"status":       domain.ProductImportStatusFailed,
+		"error":        reason,
+		"content":      nil,
+		"completed_at": completedAt,
+	})
+}
+
+func productImportUpdates(productImport *domain.ProductImport) map[string]interface{} {
+	return map[string]interface{}{
+		"progress": productImport.Progress,
+		"total":    productImport.Total,
+		"imported": productImport.Imported,
+		"failed":   productImport.Failed,
+		"errors":   productImport.Errors,
+	}
+}
+
+// update changes a pending import; finished ones are left as they are.
+func (r *PostgresProductImportRepository) update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	r.logger.WithFields(logrus.Fields{
+		"import_id": id,
+		"status":    updates["status"],
+	}).Debug("Updating product import in database")
+
+	err := r.db.WithContext(ctx).Model(&domain.ProductImport{}).
+		Where("id = ? AND status = ?", id, domain.ProductImportStatusPending).
+		Updates(updates).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"import_id": id,
+		}).Error("Failed to update product import in database")
+		return err
+	}
+
+	return nil
+}
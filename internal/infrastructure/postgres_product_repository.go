@@ -2,26 +2,43 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/edumes/golang-api-rest/internal/domain"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresProductRepository struct {
 	db     *gorm.DB
 	logger *logrus.Logger
+	clock  domain.Clock
+	ids    domain.IDGenerator
 }
 
 func NewPostgresProductRepository(db *gorm.DB) *PostgresProductRepository {
 	return &PostgresProductRepository{
 		db:     db,
-		logger: logrus.New(),
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+		ids:    domain.UUIDv7Generator{},
 	}
 }
 
+func (r *PostgresProductRepository) WithClock(clock domain.Clock) *PostgresProductRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresProductRepository) WithIDGenerator(ids domain.IDGenerator) *PostgresProductRepository {
+	r.ids = ids
+	return r
+}
+
 func (r *PostgresProductRepository) Create(ctx context.Context, product *domain.Product) error {
 	r.logger.WithFields(logrus.Fields{
 		"product_id": product.ID,
@@ -95,84 +112,249 @@ func (r *PostgresProductRepository) GetBySKU(ctx context.Context, sku string) (*
 	return &product, nil
 }
 
-func (r *PostgresProductRepository) List(ctx context.Context, filter domain.ProductParams, pagination domain.Pagination) ([]domain.Product, error) {
+// lowStockCondition matches products at or below their reorder level, or
+// the threshold given as its argument when they have none.
+const lowStockCondition = "stock <= COALESCE(reorder_level, ?)"
+
+func (r *PostgresProductRepository) ListLowStock(ctx context.Context, threshold int, pagination domain.Pagination) ([]domain.Product, error) {
 	r.logger.WithFields(logrus.Fields{
-		"filter_name":     filter.Name,
-		"filter_category": filter.Category,
-		"filter_sku":      filter.SKU,
-		"limit":           pagination.Limit,
-		"offset":          pagination.Offset,
-		"sort":            pagination.Sort,
-	}).Debug("Listing products from database with filters")
+		"threshold": threshold,
+		"limit":     pagination.Limit,
+		"offset":    pagination.Offset,
+		"sort":      pagination.Sort,
+	}).Debug("Listing low stock products from database")
 
-	var products []domain.Product
-	db := r.db.WithContext(ctx).Model(&domain.Product{})
+	db := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL AND archived_at IS NULL").
+		Where(lowStockCondition, threshold)
 
-	if filter.Name != "" {
-		r.logger.WithFields(logrus.Fields{
-			"filter_name": filter.Name,
-		}).Debug("Applying name filter")
-		db = db.Where("name ILIKE ?", "%"+filter.Name+"%")
+	if pagination.Sort != "" {
+		db = db.Order(pagination.Sort)
 	}
 
-	if filter.Category != "" {
-		r.logger.WithFields(logrus.Fields{
-			"filter_category": filter.Category,
-		}).Debug("Applying category filter")
-		db = db.Where("category ILIKE ?", "%"+filter.Category+"%")
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
 	}
 
-	if filter.SKU != "" {
-		r.logger.WithFields(logrus.Fields{
-			"filter_sku": filter.SKU,
-		}).Debug("Applying SKU filter")
-		db = db.Where("sku ILIKE ?", "%"+filter.SKU+"%")
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
 	}
 
-	if filter.PriceFrom != nil {
+	var products []domain.Product
+	if err := db.Find(&products).Error; err != nil {
 		r.logger.WithFields(logrus.Fields{
-			"price_from": *filter.PriceFrom,
-		}).Debug("Applying price_from filter")
-		db = db.Where("price >= ?", *filter.PriceFrom)
+			"error": err.Error(),
+		}).Error("Failed to list low stock products from database")
+		return nil, err
 	}
 
-	if filter.PriceTo != nil {
+	r.logger.WithFields(logrus.Fields{
+		"count": len(products),
+	}).Debug("Low stock products listed successfully from database")
+
+	return products, nil
+}
+
+func (r *PostgresProductRepository) ClaimLowStockAlerts(ctx context.Context, threshold int, now time.Time, limit int) ([]domain.Product, error) {
+	r.logger.WithFields(logrus.Fields{
+		"threshold": threshold,
+		"limit":     limit,
+	}).Debug("Claiming low stock alerts in database")
+
+	var products []domain.Product
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// UpdateColumn leaves updated_at alone: the flag is bookkeeping of
+		// the checker, not a change to the product.
+		if err := tx.Model(&domain.Product{}).
+			Where("low_stock_alerted_at IS NOT NULL").
+			Where("NOT ("+lowStockCondition+")", threshold).
+			UpdateColumn("low_stock_alerted_at", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("deleted_at IS NULL AND archived_at IS NULL AND low_stock_alerted_at IS NULL").
+			Where(lowStockCondition, threshold).
+			Order("stock, id").
+			Limit(limit).
+			Find(&products).Error; err != nil {
+			return err
+		}
+		if len(products) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(products))
+		for i := range products {
+			ids[i] = products[i].ID
+			products[i].LowStockAlertedAt = &now
+		}
+		return tx.Model(&domain.Product{}).Where("id IN ?", ids).UpdateColumn("low_stock_alerted_at", now).Error
+	})
+	if err != nil {
 		r.logger.WithFields(logrus.Fields{
-			"price_to": *filter.PriceTo,
-		}).Debug("Applying price_to filter")
-		db = db.Where("price <= ?", *filter.PriceTo)
+			"error": err.Error(),
+		}).Error("Failed to claim low stock alerts in database")
+		return nil, err
 	}
 
-	if filter.StockFrom != nil {
+	r.logger.WithFields(logrus.Fields{
+		"count": len(products),
+	}).Debug("Low stock alerts claimed successfully in database")
+
+	return products, nil
+}
+
+func (r *PostgresProductRepository) Suggest(ctx context.Context, query string, limit int) ([]domain.Suggestion, error) {
+	r.logger.WithFields(logrus.Fields{
+		"query": query,
+		"limit": limit,
+	}).Debug("Suggesting products from database")
+
+	pattern := likeEscaper.Replace(query)
+	suggestions := []domain.Suggestion{}
+	err := r.db.WithContext(ctx).Model(&domain.Product{}).
+		Select("id, name AS label").
+		Where("deleted_at IS NULL AND archived_at IS NULL").
+		Where("name ILIKE ? OR sku ILIKE ?", "%"+pattern+"%", pattern+"%").
+		Order(suggestionOrder("name", query)).
+		Limit(limit).
+		Scan(&suggestions).Error
+	if err != nil {
 		r.logger.WithFields(logrus.Fields{
-			"stock_from": *filter.StockFrom,
-		}).Debug("Applying stock_from filter")
-		db = db.Where("stock >= ?", *filter.StockFrom)
+			"error": err.Error(),
+			"query": query,
+		}).Error("Failed to suggest products from database")
+		return nil, err
 	}
 
-	if filter.StockTo != nil {
+	r.logger.WithFields(logrus.Fields{
+		"query": query,
+		"count": len(suggestions),
+	}).Debug("Products suggested successfully from database")
+
+	return suggestions, nil
+}
+
+// productSearchRow is a product read by Search with its rank and the
+// ts_headline results.
+type productSearchRow struct {
+	domain.Product
+	Rank                 float64
+	NameHighlight        string
+	DescriptionHighlight string
+}
+
+func (r *PostgresProductRepository) Search(ctx context.Context, query string, pagination domain.Pagination) ([]domain.ProductSearchHit, error) {
+	r.logger.WithFields(logrus.Fields{
+		"query":  query,
+		"limit":  pagination.Limit,
+		"offset": pagination.Offset,
+	}).Debug("Searching products in database")
+
+	var rows []productSearchRow
+	db := r.searchScope(ctx, query).
+		Select("products.*, ts_rank_cd(products.search_vector, search_query, 32) AS rank, "+
+			"ts_headline(?::regconfig, products.name, search_query, ?) AS name_highlight, "+
+			"ts_headline(?::regconfig, products.description, search_query, ?) AS description_highlight",
+			domain.ProductSearchConfig, nameHeadlineOptions, domain.ProductSearchConfig, descriptionHeadlineOptions).
+		Order("rank DESC, products.id")
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+	if err := db.Scan(&rows).Error; err != nil {
 		r.logger.WithFields(logrus.Fields{
-			"stock_to": *filter.StockTo,
-		}).Debug("Applying stock_to filter")
-		db = db.Where("stock <= ?", *filter.StockTo)
+			"error": err.Error(),
+			"query": query,
+		}).Error("Failed to search products in database")
+		return nil, err
 	}
 
-	if filter.CreatedAtFrom != nil {
+	hits := make([]domain.ProductSearchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = domain.ProductSearchHit{
+			Product: row.Product,
+			Rank:    row.Rank,
+			Highlights: domain.ProductHighlights{
+				Name:        highlight(row.NameHighlight),
+				Description: highlight(row.DescriptionHighlight),
+			},
+		}
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"query": query,
+		"count": len(hits),
+	}).Debug("Products searched successfully in database")
+
+	return hits, nil
+}
+
+func (r *PostgresProductRepository) CountSearch(ctx context.Context, query string) (int64, error) {
+	var total int64
+	if err := r.searchScope(ctx, query).Count(&total).Error; err != nil {
 		r.logger.WithFields(logrus.Fields{
-			"created_at_from": filter.CreatedAtFrom,
-		}).Debug("Applying created_at_from filter")
-		db = db.Where("created_at >= ?", *filter.CreatedAtFrom)
+			"error": err.Error(),
+			"query": query,
+		}).Error("Failed to count product search in database")
+		return 0, err
 	}
+	return total, nil
+}
 
-	if filter.CreatedAtTo != nil {
+// searchScope selects the live, unarchived products matching query, parsed
+// once as search_query for the rank and headlines to reuse.
+func (r *PostgresProductRepository) searchScope(ctx context.Context, query string) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("products, websearch_to_tsquery(?::regconfig, ?) AS search_query", domain.ProductSearchConfig, query).
+		Where("products.search_vector @@ search_query").
+		Where("products.deleted_at IS NULL AND products.archived_at IS NULL")
+}
+
+func (r *PostgresProductRepository) GetByBarcode(ctx context.Context, barcode string) (*domain.Product, error) {
+	r.logger.WithFields(logrus.Fields{
+		"barcode": barcode,
+	}).Debug("Getting product by barcode from database")
+
+	var product domain.Product
+	err := r.db.WithContext(ctx).First(&product, "barcode = ? AND deleted_at IS NULL", barcode).Error
+	if err != nil {
 		r.logger.WithFields(logrus.Fields{
-			"created_at_to": filter.CreatedAtTo,
-		}).Debug("Applying created_at_to filter")
-		db = db.Where("created_at <= ?", *filter.CreatedAtTo)
+			"error":   err.Error(),
+			"barcode": barcode,
+		}).Warn("Product not found by barcode in database")
+		return nil, err
 	}
 
+	r.logger.WithFields(logrus.Fields{
+		"product_id": product.ID,
+		"barcode":    barcode,
+	}).Debug("Product retrieved successfully by barcode from database")
+
+	return &product, nil
+}
+
+func (r *PostgresProductRepository) List(ctx context.Context, filter domain.ProductParams, pagination domain.Pagination) ([]domain.Product, error) {
+	r.logger.WithFields(logrus.Fields{
+		"filter_name":     filter.Name,
+		"filter_category": filter.Category,
+		"filter_sku":      filter.SKU,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
+		"sort":            pagination.Sort,
+	}).Debug("Listing products from database with filters")
+
+	var products []domain.Product
+	db := r.applyFilter(r.db.WithContext(ctx).Model(&domain.Product{}), filter)
 	db = db.Where("deleted_at IS NULL")
 
+	if pagination.AfterID != nil {
+		db = db.Where("id > ?", *pagination.AfterID)
+	}
+
 	if pagination.Sort != "" {
 		r.logger.WithFields(logrus.Fields{
 			"sort": pagination.Sort,
@@ -208,7 +390,22 @@ func (r *PostgresProductRepository) List(ctx context.Context, filter domain.Prod
 	return products, nil
 }
 
-func (r *PostgresProductRepository) Update(ctx context.Context, product *domain.Product) error {
+func (r *PostgresProductRepository) Count(ctx context.Context, filter domain.ProductParams) (int64, error) {
+	var total int64
+	err := r.applyFilter(r.db.WithContext(ctx).Model(&domain.Product{}), filter).
+		Where("deleted_at IS NULL").
+		Count(&total).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count products in database")
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *PostgresProductRepository) Update(ctx context.Context, product *domain.Product, fields ...string) error {
 	r.logger.WithFields(logrus.Fields{
 		"product_id": product.ID,
 		"sku":        product.SKU,
@@ -217,7 +414,12 @@ func (r *PostgresProductRepository) Update(ctx context.Context, product *domain.
 		"stock":      product.Stock,
 	}).Debug("Updating product in database")
 
-	err := r.db.WithContext(ctx).Model(product).Updates(product).Error
+	// Stock, the archived state and the cost price have their own atomic
+	// writes; saving the values read before this update would undo any that
+	// ran in between.
+	err := updateVersioned(r.db.WithContext(ctx), product, product.ID, &product.Version, func(db *gorm.DB) *gorm.DB {
+		return selectFields(db, fields).Omit("stock", "archived_at", "cost_price").Updates(product)
+	})
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
@@ -239,7 +441,7 @@ func (r *PostgresProductRepository) Delete(ctx context.Context, id uuid.UUID) er
 		"product_id": id,
 	}).Debug("Soft deleting product in database")
 
-	err := r.db.WithContext(ctx).Model(&domain.Product{}).Where("id = ?", id).Update("deleted_at", time.Now()).Error
+	err := r.db.WithContext(ctx).Model(&domain.Product{}).Where("id = ?", id).Update("deleted_at", r.clock.Now()).Error
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
@@ -255,25 +457,356 @@ func (r *PostgresProductRepository) Delete(ctx context.Context, id uuid.UUID) er
 	return nil
 }
 
-func (r *PostgresProductRepository) UpdateStock(ctx context.Context, id uuid.UUID, quantity int) error {
+func (r *PostgresProductRepository) SetArchived(ctx context.Context, id uuid.UUID, archivedAt *time.Time) error {
 	r.logger.WithFields(logrus.Fields{
-		"product_id": id,
-		"quantity":   quantity,
-	}).Debug("Updating product stock in database")
-
-	err := r.db.WithContext(ctx).Model(&domain.Product{}).Where("id = ?", id).Update("stock", quantity).Error
+		"product_id":  id,
+		"archived_at": archivedAt,
+	}).Debug("Updating product archived state in database")
+
+	err := r.db.WithContext(ctx).Model(&domain.Product{}).Where("id = ? AND deleted_at IS NULL", id).Updates(map[string]interface{}{
+		"archived_at": archivedAt,
+		"updated_at":  r.clock.Now(),
+	}).Error
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
 			"product_id": id,
-		}).Error("Failed to update product stock in database")
+		}).Error("Failed to update product archived state in database")
 		return err
 	}
 
 	r.logger.WithFields(logrus.Fields{
 		"product_id": id,
-		"new_stock":  quantity,
-	}).Debug("Product stock updated successfully in database")
+	}).Debug("Product archived state updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresProductRepository) NextSKUSequence(ctx context.Context, prefix string) (int64, error) {
+	r.logger.WithFields(logrus.Fields{
+		"prefix": prefix,
+	}).Debug("Allocating next SKU sequence value in database")
+
+	var next int64
+	err := r.db.WithContext(ctx).Raw(
+		"INSERT INTO sku_sequences (prefix, last_value) VALUES (?, 1) "+
+			"ON CONFLICT (prefix) DO UPDATE SET last_value = sku_sequences.last_value + 1 "+
+			"RETURNING last_value",
+		prefix,
+	).Scan(&next).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"prefix": prefix,
+		}).Error("Failed to allocate SKU sequence value in database")
+		return 0, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"prefix": prefix,
+		"value":  next,
+	}).Debug("SKU sequence value allocated successfully in database")
+
+	return next, nil
+}
+
+func (r *PostgresProductRepository) StockLevels(ctx context.Context, productID uuid.UUID) ([]domain.WarehouseStockLevel, error) {
+	r.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+	}).Debug("Loading product stock levels from database")
+
+	var levels []domain.WarehouseStockLevel
+	err := r.db.WithContext(ctx).Table("warehouse_stocks").
+		Select("warehouse_stocks.warehouse_id, warehouses.code AS warehouse_code, warehouse_stocks.quantity").
+		Joins("JOIN warehouses ON warehouses.id = warehouse_stocks.warehouse_id").
+		Where("warehouse_stocks.product_id = ? AND warehouse_stocks.quantity <> 0", productID).
+		Order("warehouses.code").
+		Scan(&levels).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Error("Failed to load product stock levels from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+		"count":      len(levels),
+	}).Debug("Product stock levels loaded successfully from database")
+
+	return levels, nil
+}
+
+func (r *PostgresProductRepository) UpsertBySKU(ctx context.Context, products []domain.Product, columns []string, actorID uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"count":    len(products),
+		"columns":  columns,
+		"actor_id": actorID,
+	}).Debug("Upserting products by SKU in database")
+
+	overwrite := []string{"name", "price", "updated_at", "deleted_at"}
+	setStock := false
+	for _, column := range columns {
+		switch column {
+		case "description":
+			overwrite = append(overwrite, column)
+		case "category":
+			overwrite = append(overwrite, "category_id", "category")
+		case "stock":
+			setStock = true
+		}
+	}
+
+	// Stock is never written by the upsert: new products start empty and
+	// every product is then moved to the stock of the file through the
+	// ledger, which also keeps it above what warehouses hold.
+	rows := make([]domain.Product, len(products))
+	stock := make(map[domain.SKU]int, len(products))
+	skus := make([]domain.SKU, len(products))
+	for i, product := range products {
+		stock[product.SKU] = product.Stock
+		skus[i] = product.SKU
+		product.Stock = 0
+		rows[i] = product
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := append(clause.AssignmentColumns(overwrite),
+			clause.Assignment{Column: clause.Column{Name: "version"}, Value: gorm.Expr("products.version + 1")})
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "sku"}},
+			DoUpdates: updates,
+		}).Create(&rows).Error; err != nil {
+			return err
+		}
+		if !setStock {
+			return nil
+		}
+
+		var stored []domain.Product
+		if err := tx.Select("id", "sku", "stock").Where("sku IN ?", skus).Order("id").Find(&stored).Error; err != nil {
+			return err
+		}
+
+		now := r.clock.Now()
+		for _, product := range stored {
+			quantity := stock[product.SKU] - product.Stock
+			if quantity == 0 {
+				continue
+			}
+			adj := &domain.StockAdjustment{
+				ID:        r.ids.NewID(),
+				ProductID: product.ID,
+				Quantity:  quantity,
+				Reason:    domain.StockReasonImport,
+				Note:      "product import",
+				ActorID:   actorID,
+				CreatedAt: now,
+			}
+			if err := applyStockAdjustment(tx, adj, now); err != nil {
+				return fmt.Errorf("sku %s: %w", product.SKU, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"count": len(products),
+		}).Error("Failed to upsert products in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(products),
+	}).Debug("Products upserted successfully in database")
 
 	return nil
 }
+
+func (r *PostgresProductRepository) applyFilter(db *gorm.DB, filter domain.ProductParams) *gorm.DB {
+
+	if filter.Name != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_name": filter.Name,
+		}).Debug("Applying name filter")
+		db = db.Where("name ILIKE ?", "%"+filter.Name+"%")
+	}
+
+	if filter.Category != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_category": filter.Category,
+		}).Debug("Applying category filter")
+		db = db.Where("category ILIKE ?", "%"+filter.Category+"%")
+	}
+
+	if filter.CategoryID != nil {
+		r.logger.WithFields(logrus.Fields{
+			"filter_category_id": *filter.CategoryID,
+		}).Debug("Applying category subtree filter")
+		db = db.Where("category_id IN (?)", gorm.Expr(categorySubtreeSQL, *filter.CategoryID))
+	}
+
+	if filter.SKU != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_sku": filter.SKU,
+		}).Debug("Applying SKU filter")
+		db = db.Where("sku ILIKE ?", "%"+filter.SKU+"%")
+	}
+
+	if filter.PriceFrom != nil {
+		r.logger.WithFields(logrus.Fields{
+			"price_from": *filter.PriceFrom,
+		}).Debug("Applying price_from filter")
+		db = db.Where("price >= ?", *filter.PriceFrom)
+	}
+
+	if filter.PriceTo != nil {
+		r.logger.WithFields(logrus.Fields{
+			"price_to": *filter.PriceTo,
+		}).Debug("Applying price_to filter")
+		db = db.Where("price <= ?", *filter.PriceTo)
+	}
+
+	if filter.StockFrom != nil {
+		r.logger.WithFields(logrus.Fields{
+			"stock_from": *filter.StockFrom,
+		}).Debug("Applying stock_from filter")
+		db = db.Where("stock >= ?", *filter.StockFrom)
+	}
+
+	if filter.StockTo != nil {
+		r.logger.WithFields(logrus.Fields{
+			"stock_to": *filter.StockTo,
+		}).Debug("Applying stock_to filter")
+		db = db.Where("stock <= ?", *filter.StockTo)
+	}
+
+	if filter.CreatedAtFrom != nil {
+		r.logger.WithFields(logrus.Fields{
+			"created_at_from": filter.CreatedAtFrom,
+		}).Debug("Applying created_at_from filter")
+		db = db.Where("created_at >= ?", *filter.CreatedAtFrom)
+	}
+
+	if filter.CreatedAtTo != nil {
+		r.logger.WithFields(logrus.Fields{
+			"created_at_to": filter.CreatedAtTo,
+		}).Debug("Applying created_at_to filter")
+		db = db.Where("created_at <= ?", *filter.CreatedAtTo)
+	}
+
+	if !filter.IncludeArchived {
+		db = db.Where("archived_at IS NULL")
+	}
+
+	return db
+}
+
+// Facets counts the products matching filter per category, price range and
+// stock availability. Each facet ignores its own filter so the counts describe
+// the alternatives a user could switch to.
+func (r *PostgresProductRepository) Facets(ctx context.Context, filter domain.ProductParams, facets []string) (*domain.ProductFacets, error) {
+	r.logger.WithFields(logrus.Fields{
+		"facets":          facets,
+		"filter_name":     filter.Name,
+		"filter_category": filter.Category,
+	}).Debug("Computing product facets in database")
+
+	result := &domain.ProductFacets{}
+	for _, facet := range facets {
+		var err error
+		switch facet {
+		case domain.ProductFacetCategory:
+			scoped := filter
+			scoped.Category, scoped.CategoryID = "", nil
+			result.Categories, err = r.categoryFacet(ctx, scoped)
+		case domain.ProductFacetPrice:
+			scoped := filter
+			scoped.PriceFrom, scoped.PriceTo = nil, nil
+			result.Price, err = r.priceFacet(ctx, scoped)
+		case domain.ProductFacetStock:
+			scoped := filter
+			scoped.StockFrom, scoped.StockTo = nil, nil
+			result.Stock, err = r.stockFacet(ctx, scoped)
+		}
+		if err != nil {
+			r.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+				"facet": facet,
+			}).Error("Failed to compute product facet in database")
+			return nil, err
+		}
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"facets": facets,
+	}).Debug("Product facets computed successfully in database")
+
+	return result, nil
+}
+
+func (r *PostgresProductRepository) categoryFacet(ctx context.Context, filter domain.ProductParams) ([]domain.FacetBucket, error) {
+	buckets := []domain.FacetBucket{}
+	err := r.applyFilter(r.db.WithContext(ctx).Model(&domain.Product{}), filter).
+		Where("deleted_at IS NULL").
+		Select("COALESCE(category, '') AS value, COUNT(*) AS count").
+		Group("COALESCE(category, '')").
+		Order("count DESC, value ASC").
+		Scan(&buckets).Error
+	return buckets, err
+}
+
+func (r *PostgresProductRepository) priceFacet(ctx context.Context, filter domain.ProductParams) ([]domain.PriceRangeBucket, error) {
+	bounds := domain.ProductPriceRangeBounds
+
+	var bucketExpr strings.Builder
+	args := make([]interface{}, 0, len(bounds))
+	bucketExpr.WriteString("CASE")
+	for i, bound := range bounds {
+		fmt.Fprintf(&bucketExpr, " WHEN price < ? THEN %d", i)
+		args = append(args, bound)
+	}
+	fmt.Fprintf(&bucketExpr, " ELSE %d END", len(bounds))
+
+	var rows []struct {
+		Bucket int
+		Count  int64
+	}
+	err := r.applyFilter(r.db.WithContext(ctx).Model(&domain.Product{}), filter).
+		Where("deleted_at IS NULL").
+		Select(bucketExpr.String()+" AS bucket, COUNT(*) AS count", args...).
+		Group("bucket").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]domain.PriceRangeBucket, len(bounds)+1)
+	from := 0.0
+	for i := range buckets {
+		buckets[i].From = from
+		if i < len(bounds) {
+			to := bounds[i]
+			buckets[i].To = &to
+			from = to
+		}
+	}
+	for _, row := range rows {
+		buckets[row.Bucket].Count = row.Count
+	}
+	return buckets, nil
+}
+
+func (r *PostgresProductRepository) stockFacet(ctx context.Context, filter domain.ProductParams) (*domain.StockAvailability, error) {
+	var stock domain.StockAvailability
+	err := r.applyFilter(r.db.WithContext(ctx).Model(&domain.Product{}), filter).
+		Where("deleted_at IS NULL").
+		Select("COUNT(*) FILTER (WHERE stock > 0) AS in_stock, COUNT(*) FILTER (WHERE stock <= 0) AS out_of_stock").
+		Scan(&stock).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stock, nil
+}
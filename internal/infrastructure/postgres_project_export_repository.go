@@ -0,0 +1,112 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresProjectExportRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresProjectExportRepository(db *gorm.DB) *PostgresProjectExportRepository {
+	return &PostgresProjectExportRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresProjectExportRepository) Create(ctx context.Context, export *domain.ProjectExport) error {
+	r.logger.WithFields(logrus.Fields{
+		"export_id":  export.ID,
+		"project_id": export.ProjectID,
+		"format":     export.Format,
+	}).Debug("Creating project export in database")
+
+	if err := r.db.WithContext(ctx).Create(export).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"export_id":  export.ID,
+			"project_id": export.ProjectID,
+		}).Error("Failed to create project export in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"export_id": export.ID,
+	}).Debug("Project export created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresProjectExportRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ProjectExport, error) {
+	r.logger.WithFields(logrus.Fields{
+		"export_id": id,
+	}).Debug("Getting project export by ID from database")
+
+	var export domain.ProjectExport
+	err := r.db.WithContext(ctx).First(&export, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"export_id": id,
+		}).Warn("Project export not found in database")
+		return nil, domain.ErrProjectExportNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"export_id": id,
+		}).Error("Failed to get project export from database")
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+func (r *PostgresProjectExportRepository) Complete(ctx context.Context, id uuid.UUID, content []byte, completedAt time.Time) error {
+	return r.finish(ctx, id, map[string]interface{}{
+		"status":       domain.ProjectExportStatusReady,
+		"content":      content,
+		"completed_at": completedAt,
+	})
+}
+
+func (r *PostgresProjectExportRepository) Fail(ctx context.Context, id uuid.UUID, reason string, completedAt time.Time) error {
+	return r.finish(ctx, id, map[string]interface{}{
+		"status":       domain.ProjectExportStatusFailed,
+		"error":        reason,
+		"completed_at": completedAt,
+	})
+}
+
+func (r *PostgresProjectExportRepository) finish(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	r.logger.WithFields(logrus.Fields{
+		"export_id": id,
+		"status":    updates["status"],
+	}).Debug("Finishing project export in database")
+
+	err := r.db.WithContext(ctx).Model(&domain.ProjectExport{}).
+		Where("id = ? AND status = ?", id, domain.ProjectExportStatusPending).
+		Updates(updates).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"export_id": id,
+		}).Error("Failed to finish project export in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"export_id": id,
+		"status":    updates["status"],
+	}).Debug("Project export finished successfully in database")
+
+	return nil
+}
@@ -2,26 +2,43 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/edumes/golang-api-rest/internal/domain"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresProjectItemRepository struct {
 	db     *gorm.DB
 	logger *logrus.Logger
+	clock  domain.Clock
+	ids    domain.IDGenerator
 }
 
 func NewPostgresProjectItemRepository(db *gorm.DB) *PostgresProjectItemRepository {
 	return &PostgresProjectItemRepository{
 		db:     db,
-		logger: logrus.New(),
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+		ids:    domain.UUIDv7Generator{},
 	}
 }
 
+func (r *PostgresProjectItemRepository) WithClock(clock domain.Clock) *PostgresProjectItemRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresProjectItemRepository) WithIDGenerator(ids domain.IDGenerator) *PostgresProjectItemRepository {
+	r.ids = ids
+	return r
+}
+
 func (r *PostgresProjectItemRepository) Create(ctx context.Context, item *domain.ProjectItem) error {
 	r.logger.WithFields(logrus.Fields{
 		"item_id":    item.ID,
@@ -29,7 +46,26 @@ func (r *PostgresProjectItemRepository) Create(ctx context.Context, item *domain
 		"project_id": item.ProjectID,
 	}).Debug("Creating project item in database")
 
-	err := r.db.WithContext(ctx).Create(item).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureProjectWritable(tx, item.ProjectID); err != nil {
+			return err
+		}
+		if err := lockBoardColumn(tx, item.ProjectID, item.Status); err != nil {
+			return err
+		}
+		rank, err := lastBoardRank(tx, item.ProjectID, item.Status, item.ID)
+		if err != nil {
+			return err
+		}
+		item.Rank = rank + domain.BoardRankStep
+		if err := tx.Create(item).Error; err != nil {
+			return err
+		}
+		if item.AssignedTo != nil {
+			return r.recordAssignment(tx, item.ID, *item.AssignedTo, item.CreatedAt)
+		}
+		return nil
+	})
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
@@ -56,6 +92,9 @@ func (r *PostgresProjectItemRepository) GetByID(ctx context.Context, id uuid.UUI
 
 	var item domain.ProjectItem
 	err := r.db.WithContext(ctx).First(&item, "id = ? AND deleted_at IS NULL", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = domain.ErrProjectItemNotFound
+	}
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":   err.Error(),
@@ -84,8 +123,62 @@ func (r *PostgresProjectItemRepository) List(ctx context.Context, filter domain.
 	}).Debug("Listing project items from database with filters")
 
 	var items []domain.ProjectItem
-	db := r.db.WithContext(ctx).Model(&domain.ProjectItem{})
+	db := r.applyFilter(r.db.WithContext(ctx).Model(&domain.ProjectItem{}), filter)
+
+	if pagination.AfterID != nil {
+		db = db.Where("id > ?", *pagination.AfterID)
+	}
+
+	if pagination.Sort != "" {
+		r.logger.WithFields(logrus.Fields{
+			"sort": pagination.Sort,
+		}).Debug("Applying sort")
+		db = db.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"limit": pagination.Limit,
+		}).Debug("Applying limit")
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"offset": pagination.Offset,
+		}).Debug("Applying offset")
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&items).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list project items from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(items),
+	}).Debug("Project items listed successfully from database")
+
+	return items, nil
+}
+
+func (r *PostgresProjectItemRepository) Count(ctx context.Context, filter domain.ProjectItemParams) (int64, error) {
+	var total int64
+	err := r.applyFilter(r.db.WithContext(ctx).Model(&domain.ProjectItem{}), filter).
+		Count(&total).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count project items in database")
+		return 0, err
+	}
+
+	return total, nil
+}
 
+func (r *PostgresProjectItemRepository) applyFilter(db *gorm.DB, filter domain.ProjectItemParams) *gorm.DB {
 	if filter.ProjectID != nil {
 		r.logger.WithFields(logrus.Fields{
 			"filter_project_id": filter.ProjectID,
@@ -177,44 +270,24 @@ func (r *PostgresProjectItemRepository) List(ctx context.Context, filter domain.
 		db = db.Where("created_at <= ?", *filter.CreatedAtTo)
 	}
 
-	db = db.Where("deleted_at IS NULL")
-
-	if pagination.Sort != "" {
-		r.logger.WithFields(logrus.Fields{
-			"sort": pagination.Sort,
-		}).Debug("Applying sort")
-		db = db.Order(pagination.Sort)
-	}
-
-	if pagination.Limit > 0 {
-		r.logger.WithFields(logrus.Fields{
-			"limit": pagination.Limit,
-		}).Debug("Applying limit")
-		db = db.Limit(pagination.Limit)
-	}
-
-	if pagination.Offset > 0 {
-		r.logger.WithFields(logrus.Fields{
-			"offset": pagination.Offset,
-		}).Debug("Applying offset")
-		db = db.Offset(pagination.Offset)
+	if filter.OpenOnly {
+		r.logger.Debug("Excluding closed project items")
+		db = db.Where("status NOT IN ?", domain.ClosedProjectItemStatuses)
 	}
 
-	if err := db.Find(&items).Error; err != nil {
+	if len(filter.CustomFields) > 0 {
 		r.logger.WithFields(logrus.Fields{
-			"error": err.Error(),
-		}).Error("Failed to list project items from database")
-		return nil, err
+			"custom_fields": filter.CustomFields,
+		}).Debug("Applying custom field filters")
+		db = whereCustomFields(db, filter.CustomFields)
 	}
 
-	r.logger.WithFields(logrus.Fields{
-		"count": len(items),
-	}).Debug("Project items listed successfully from database")
+	db = db.Where("deleted_at IS NULL")
 
-	return items, nil
+	return db
 }
 
-func (r *PostgresProjectItemRepository) Update(ctx context.Context, item *domain.ProjectItem) error {
+func (r *PostgresProjectItemRepository) Update(ctx context.Context, item *domain.ProjectItem, fields ...string) error {
 	r.logger.WithFields(logrus.Fields{
 		"item_id":    item.ID,
 		"name":       item.Name,
@@ -222,7 +295,94 @@ func (r *PostgresProjectItemRepository) Update(ctx context.Context, item *domain
 		"project_id": item.ProjectID,
 	}).Debug("Updating project item in database")
 
-	err := r.db.WithContext(ctx).Model(item).Updates(item).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The board column is locked before the item row, in the order
+		// every board write takes them, so it is found from an unlocked
+		// read and checked again once the row is locked.
+		var unlocked domain.ProjectItem
+		if err := tx.Select("project_id", "status").
+			First(&unlocked, "id = ? AND deleted_at IS NULL", item.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrProjectItemNotFound
+			}
+			return err
+		}
+		projectID, status, moved := boardDestination(item, &unlocked, fields)
+		if moved {
+			if err := lockBoardColumn(tx, projectID, status); err != nil {
+				return err
+			}
+		}
+
+		var previous domain.ProjectItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("project_id", "status", "assigned_to").
+			First(&previous, "id = ? AND deleted_at IS NULL", item.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrProjectItemNotFound
+			}
+			return err
+		}
+		if lockedProjectID, lockedStatus, lockedMove := boardDestination(item, &previous, fields); lockedMove != moved ||
+			moved && (lockedProjectID != projectID || lockedStatus != status) {
+			// The item changed columns between the two reads.
+			return domain.ErrVersionConflict
+		}
+		if err := ensureProjectWritable(tx, previous.ProjectID); err != nil {
+			return err
+		}
+		if item.ProjectID != uuid.Nil && item.ProjectID != previous.ProjectID {
+			if err := ensureProjectWritable(tx, item.ProjectID); err != nil {
+				return err
+			}
+		}
+
+		statusChanged := item.Status != "" && item.Status != previous.Status && (len(fields) == 0 || slices.Contains(fields, "status"))
+		if statusChanged && !domain.CanTransitionProjectItem(previous.Status, item.Status) {
+			return domain.ErrProjectItemTransition
+		}
+
+		if err := updateVersioned(tx, item, item.ID, &item.Version, func(db *gorm.DB) *gorm.DB {
+			return selectFields(db, fields).Updates(item)
+		}); err != nil {
+			return err
+		}
+
+		if statusChanged {
+			r.logger.WithFields(logrus.Fields{
+				"item_id": item.ID,
+				"from":    previous.Status,
+				"to":      item.Status,
+			}).Debug("Recording project item transition")
+			if err := r.recordTransition(tx, item.ID, previous.Status, item.Status, nil); err != nil {
+				return err
+			}
+		}
+
+		if moved {
+			if err := moveToBoardBottom(tx, item, projectID, status); err != nil {
+				return err
+			}
+		}
+
+		if item.AssignedTo == nil && previous.AssignedTo != nil && slices.Contains(fields, "assigned_to") {
+			r.logger.WithFields(logrus.Fields{
+				"item_id": item.ID,
+			}).Debug("Closing project item assignment")
+			return tx.Model(&domain.ProjectItemAssignment{}).
+				Where("item_id = ? AND unassigned_at IS NULL", item.ID).
+				Update("unassigned_at", r.clock.Now()).Error
+		}
+
+		if item.AssignedTo != nil && (previous.AssignedTo == nil || *previous.AssignedTo != *item.AssignedTo) {
+			r.logger.WithFields(logrus.Fields{
+				"item_id":     item.ID,
+				"assigned_to": item.AssignedTo,
+			}).Debug("Recording project item assignment")
+			return r.recordAssignment(tx, item.ID, *item.AssignedTo, r.clock.Now())
+		}
+		return nil
+	})
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":   err.Error(),
@@ -245,7 +405,25 @@ func (r *PostgresProjectItemRepository) Delete(ctx context.Context, id uuid.UUID
 		"item_id": id,
 	}).Debug("Soft deleting project item in database")
 
-	err := r.db.WithContext(ctx).Model(&domain.ProjectItem{}).Where("id = ?", id).Update("deleted_at", time.Now()).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item domain.ProjectItem
+		err := tx.Select("project_id", "parent_item_id").First(&item, "id = ? AND deleted_at IS NULL", id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := ensureProjectWritable(tx, item.ProjectID); err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.ProjectItem{}).
+			Where("parent_item_id = ? AND deleted_at IS NULL", id).
+			Update("parent_item_id", item.ParentItemID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.ProjectItem{}).Where("id = ?", id).Update("deleted_at", r.clock.Now()).Error
+	})
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":   err.Error(),
@@ -284,6 +462,27 @@ func (r *PostgresProjectItemRepository) GetByProjectID(ctx context.Context, proj
 	return items, nil
 }
 
+func (r *PostgresProjectItemRepository) ListChildren(ctx context.Context, parentID uuid.UUID) ([]domain.ProjectItem, error) {
+	r.logger.WithFields(logrus.Fields{
+		"parent_item_id": parentID,
+	}).Debug("Listing project item children from database")
+
+	var items []domain.ProjectItem
+	err := r.db.WithContext(ctx).
+		Where("parent_item_id = ? AND deleted_at IS NULL", parentID).
+		Order("created_at, id").
+		Find(&items).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":          err.Error(),
+			"parent_item_id": parentID,
+		}).Error("Failed to list project item children from database")
+		return nil, err
+	}
+
+	return items, nil
+}
+
 func (r *PostgresProjectItemRepository) GetByAssignedTo(ctx context.Context, assignedTo uuid.UUID) ([]domain.ProjectItem, error) {
 	r.logger.WithFields(logrus.Fields{
 		"assigned_to": assignedTo,
@@ -306,3 +505,407 @@ func (r *PostgresProjectItemRepository) GetByAssignedTo(ctx context.Context, ass
 
 	return items, nil
 }
+
+func (r *PostgresProjectItemRepository) ListAssignments(ctx context.Context, itemID uuid.UUID) ([]domain.ProjectItemAssignment, error) {
+	r.logger.WithFields(logrus.Fields{
+		"item_id": itemID,
+	}).Debug("Listing project item assignments from database")
+
+	var assignments []domain.ProjectItemAssignment
+	err := r.db.WithContext(ctx).Where("item_id = ?", itemID).Order("assigned_at DESC, id").Find(&assignments).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": itemID,
+		}).Error("Failed to list project item assignments from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"item_id": itemID,
+		"count":   len(assignments),
+	}).Debug("Project item assignments listed successfully from database")
+
+	return assignments, nil
+}
+
+func (r *PostgresProjectItemRepository) Transition(ctx context.Context, id uuid.UUID, status domain.ProjectItemStatus, actorID uuid.UUID) (*domain.ProjectItem, error) {
+	r.logger.WithFields(logrus.Fields{
+		"item_id":  id,
+		"status":   status,
+		"actor_id": actorID,
+	}).Debug("Transitioning project item in database")
+
+	var item domain.ProjectItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The target column is locked before the item row, as in every
+		// board write; its project comes from an unlocked read.
+		var unlocked domain.ProjectItem
+		if err := tx.Select("project_id").
+			First(&unlocked, "id = ? AND deleted_at IS NULL", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrProjectItemNotFound
+			}
+			return err
+		}
+		if err := lockBoardColumn(tx, unlocked.ProjectID, status); err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&item, "id = ? AND deleted_at IS NULL", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrProjectItemNotFound
+			}
+			return err
+		}
+		if item.ProjectID != unlocked.ProjectID {
+			return domain.ErrVersionConflict
+		}
+		if err := ensureProjectWritable(tx, item.ProjectID); err != nil {
+			return err
+		}
+		if !domain.CanTransitionProjectItem(item.Status, status) {
+			return domain.ErrProjectItemTransition
+		}
+
+		rank, err := lastBoardRank(tx, item.ProjectID, status, id)
+		if err != nil {
+			return err
+		}
+
+		from := item.Status
+		item.Status = status
+		item.Rank = rank + domain.BoardRankStep
+		item.UpdatedAt = r.clock.Now()
+		item.Version++
+		if err := tx.Model(&domain.ProjectItem{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":     item.Status,
+			"rank":       item.Rank,
+			"updated_at": item.UpdatedAt,
+			"version":    item.Version,
+		}).Error; err != nil {
+			return err
+		}
+		return r.recordTransition(tx, id, from, status, &actorID)
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+			"status":  status,
+		}).Warn("Failed to transition project item in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"item_id": id,
+		"status":  status,
+	}).Debug("Project item transitioned successfully in database")
+
+	return &item, nil
+}
+
+// MoveOnBoard ranks the item between the items that will surround it at
+// move.Position, taking the midpoint of their ranks. When repeated moves
+// have left no room between them, the column is ranked afresh first. The
+// target column is locked before the item, so a move that re-ranks the
+// column never waits on the row of an item moving within it.
+func (r *PostgresProjectItemRepository) MoveOnBoard(ctx context.Context, projectID, id uuid.UUID, move domain.BoardMove, actorID uuid.UUID) (*domain.ProjectItem, error) {
+	r.logger.WithFields(logrus.Fields{
+		"item_id":    id,
+		"project_id": projectID,
+		"status":     move.Status,
+		"position":   move.Position,
+		"actor_id":   actorID,
+	}).Debug("Moving project item on board in database")
+
+	var item domain.ProjectItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		status := move.Status
+		if status == "" {
+			var current domain.ProjectItem
+			if err := tx.Select("status").
+				First(&current, "id = ? AND project_id = ? AND deleted_at IS NULL", id, projectID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return domain.ErrProjectItemNotFound
+				}
+				return err
+			}
+			status = current.Status
+		}
+		if err := lockBoardColumn(tx, projectID, status); err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&item, "id = ? AND project_id = ? AND deleted_at IS NULL", id, projectID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrProjectItemNotFound
+			}
+			return err
+		}
+		if err := ensureProjectWritable(tx, projectID); err != nil {
+			return err
+		}
+
+		from := item.Status
+		if move.Status == "" && from != status {
+			// The item changed columns between the two reads.
+			return domain.ErrVersionConflict
+		}
+		if status != from && !domain.CanTransitionProjectItem(from, status) {
+			return domain.ErrProjectItemTransition
+		}
+
+		rank, ok, err := boardRankAt(tx, projectID, status, id, move.Position)
+		if err == nil && !ok {
+			r.logger.WithFields(logrus.Fields{
+				"project_id": projectID,
+				"status":     status,
+			}).Debug("Re-ranking board column")
+			if err = rerankBoardColumn(tx, projectID, status, id); err == nil {
+				rank, _, err = boardRankAt(tx, projectID, status, id, move.Position)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		item.Status = status
+		item.Rank = rank
+		item.UpdatedAt = r.clock.Now()
+		item.Version++
+		if err := tx.Model(&domain.ProjectItem{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":     item.Status,
+			"rank":       item.Rank,
+			"updated_at": item.UpdatedAt,
+			"version":    item.Version,
+		}).Error; err != nil {
+			return err
+		}
+		if status != from {
+			return r.recordTransition(tx, id, from, status, &actorID)
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": id,
+			"status":  move.Status,
+		}).Warn("Failed to move project item on board in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"item_id":  id,
+		"status":   item.Status,
+		"position": move.Position,
+	}).Debug("Project item moved on board successfully in database")
+
+	return &item, nil
+}
+
+// boardOrder is the order of the items of a board column.
+const boardOrder = "rank, id"
+
+// minBoardRankGap is the smallest gap between two ranks that a move still
+// splits; below it the column is re-ranked.
+const minBoardRankGap = 1e-6
+
+// lockBoardColumn serializes the writes that rank items in the column of
+// status in the project's board until the transaction ends, so concurrent
+// moves never compute the same rank or re-rank under each other. It is
+// always taken before the row of the item being placed: a re-rank holding
+// the column updates the rows in it, so a writer holding one of those rows
+// while waiting for the column would deadlock with it.
+func lockBoardColumn(tx *gorm.DB, projectID uuid.UUID, status domain.ProjectItemStatus) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "board:"+projectID.String()+":"+string(status)).Error
+}
+
+// boardColumn scopes a query to the column of status in the project's
+// board, leaving out the item being placed.
+func boardColumn(tx *gorm.DB, projectID uuid.UUID, status domain.ProjectItemStatus, exceptID uuid.UUID) *gorm.DB {
+	return tx.Model(&domain.ProjectItem{}).
+		Where("project_id = ? AND status = ? AND deleted_at IS NULL AND id <> ?", projectID, status, exceptID)
+}
+
+// lastBoardRank returns the highest rank in a board column, or zero for an
+// empty one.
+func lastBoardRank(tx *gorm.DB, projectID uuid.UUID, status domain.ProjectItemStatus, exceptID uuid.UUID) (float64, error) {
+	var rank float64
+	err := boardColumn(tx, projectID, status, exceptID).Select("COALESCE(MAX(rank), 0)").Scan(&rank).Error
+	return rank, err
+}
+
+// boardRankAt returns the rank that puts an item at position in a board
+// column, counted without the item itself. A position past the end puts it
+// last. ok is false when the neighbours are too close to rank between.
+func boardRankAt(tx *gorm.DB, projectID uuid.UUID, status domain.ProjectItemStatus, id uuid.UUID, position int) (rank float64, ok bool, err error) {
+	var neighbours []float64
+	query := boardColumn(tx, projectID, status, id).Order(boardOrder)
+	if position == 0 {
+		err = query.Limit(1).Pluck("rank", &neighbours).Error
+		if err != nil || len(neighbours) == 0 {
+			return domain.BoardRankStep, true, err
+		}
+		return neighbours[0] - domain.BoardRankStep, true, nil
+	}
+
+	err = query.Offset(position-1).Limit(2).Pluck("rank", &neighbours).Error
+	if err != nil {
+		return 0, false, err
+	}
+	switch len(neighbours) {
+	case 0:
+		last, err := lastBoardRank(tx, projectID, status, id)
+		return last + domain.BoardRankStep, true, err
+	case 1:
+		return neighbours[0] + domain.BoardRankStep, true, nil
+	}
+	if neighbours[1]-neighbours[0] < minBoardRankGap {
+		return 0, false, nil
+	}
+	return neighbours[0] + (neighbours[1]-neighbours[0])/2, true, nil
+}
+
+// rerankBoardColumn spreads the ranks of a board column BoardRankStep
+// apart, keeping their order.
+func rerankBoardColumn(tx *gorm.DB, projectID uuid.UUID, status domain.ProjectItemStatus, exceptID uuid.UUID) error {
+	return tx.Exec(`UPDATE project_items AS p SET rank = o.position * ?
+		FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY `+boardOrder+`) AS position FROM project_items
+			WHERE project_id = ? AND status = ? AND deleted_at IS NULL AND id <> ?) AS o
+		WHERE p.id = o.id`, domain.BoardRankStep, projectID, status, exceptID).Error
+}
+
+// boardDestination returns the board column an update of fields moves the
+// item to from previous, and false when it stays in its column.
+func boardDestination(item, previous *domain.ProjectItem, fields []string) (uuid.UUID, domain.ProjectItemStatus, bool) {
+	projectID, status := previous.ProjectID, previous.Status
+	if item.ProjectID != uuid.Nil && item.ProjectID != previous.ProjectID && (len(fields) == 0 || slices.Contains(fields, "project_id")) {
+		projectID = item.ProjectID
+	}
+	if item.Status != "" && item.Status != previous.Status && (len(fields) == 0 || slices.Contains(fields, "status")) {
+		status = item.Status
+	}
+	return projectID, status, projectID != previous.ProjectID || status != previous.Status
+}
+
+// moveToBoardBottom ranks the item last in the board column of status in
+// projectID, where an edit has just moved it. The caller holds the lock of
+// that column.
+func moveToBoardBottom(tx *gorm.DB, item *domain.ProjectItem, projectID uuid.UUID, status domain.ProjectItemStatus) error {
+	rank, err := lastBoardRank(tx, projectID, status, item.ID)
+	if err != nil {
+		return err
+	}
+	item.Rank = rank + domain.BoardRankStep
+	return tx.Model(&domain.ProjectItem{}).Where("id = ?", item.ID).UpdateColumn("rank", item.Rank).Error
+}
+
+func (r *PostgresProjectItemRepository) ListTransitions(ctx context.Context, itemID uuid.UUID) ([]domain.ProjectItemTransition, error) {
+	r.logger.WithFields(logrus.Fields{
+		"item_id": itemID,
+	}).Debug("Listing project item transitions from database")
+
+	var transitions []domain.ProjectItemTransition
+	err := r.db.WithContext(ctx).Where("item_id = ?", itemID).Order("transitioned_at DESC, id DESC").Find(&transitions).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"item_id": itemID,
+		}).Error("Failed to list project item transitions from database")
+		return nil, err
+	}
+
+	return transitions, nil
+}
+
+// recordTransition stores a status change of the item made now by actorID,
+// or by an edit of the item when actorID is nil.
+func (r *PostgresProjectItemRepository) recordTransition(tx *gorm.DB, itemID uuid.UUID, from, to domain.ProjectItemStatus, actorID *uuid.UUID) error {
+	return tx.Create(&domain.ProjectItemTransition{
+		ID:             r.ids.NewID(),
+		ItemID:         itemID,
+		FromStatus:     from,
+		ToStatus:       to,
+		ActorID:        actorID,
+		TransitionedAt: r.clock.Now(),
+	}).Error
+}
+
+// recordAssignment closes the item's open assignment, if any, and opens a new
+// one for userID starting at at.
+func (r *PostgresProjectItemRepository) recordAssignment(tx *gorm.DB, itemID, userID uuid.UUID, at time.Time) error {
+	if err := tx.Model(&domain.ProjectItemAssignment{}).
+		Where("item_id = ? AND unassigned_at IS NULL", itemID).
+		Update("unassigned_at", at).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&domain.ProjectItemAssignment{
+		ID:         r.ids.NewID(),
+		ItemID:     itemID,
+		UserID:     userID,
+		AssignedAt: at,
+	}).Error
+}
+
+// HoursRollup sums item hours grouped by status, assignee and due week. Only
+// the project, assignee and due date filters apply.
+func (r *PostgresProjectItemRepository) HoursRollup(ctx context.Context, filter domain.ProjectItemParams) (*domain.HoursRollup, error) {
+	r.logger.WithFields(logrus.Fields{
+		"project_id":    filter.ProjectID,
+		"assigned_to":   filter.AssignedTo,
+		"due_date_from": filter.DueDateFrom,
+		"due_date_to":   filter.DueDateTo,
+	}).Debug("Summing project item hours in database")
+
+	scope := func() *gorm.DB {
+		db := r.db.WithContext(ctx).Model(&domain.ProjectItem{}).Where("deleted_at IS NULL")
+		if filter.ProjectID != nil {
+			db = db.Where("project_id = ?", *filter.ProjectID)
+		}
+		if filter.AssignedTo != nil {
+			db = db.Where("assigned_to = ?", *filter.AssignedTo)
+		}
+		if filter.DueDateFrom != nil {
+			db = db.Where("due_date >= ?", *filter.DueDateFrom)
+		}
+		if filter.DueDateTo != nil {
+			db = db.Where("due_date <= ?", *filter.DueDateTo)
+		}
+		return db
+	}
+	const sums = "COALESCE(SUM(estimated_hours), 0) AS estimated, COALESCE(SUM(actual_hours), 0) AS actual, COUNT(*) AS items"
+
+	var rollup domain.HoursRollup
+	err := scope().Select("status, " + sums).Group("status").Order("status").Scan(&rollup.ByStatus).Error
+	if err == nil {
+		err = scope().Select("assigned_to, " + sums).Group("assigned_to").Order("assigned_to NULLS LAST").Scan(&rollup.ByAssignee).Error
+	}
+	if err == nil {
+		err = scope().Select("date_trunc('week', due_date) AS week, " + sums).Group("week").Order("week NULLS LAST").Scan(&rollup.ByWeek).Error
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": filter.ProjectID,
+		}).Error("Failed to sum project item hours in database")
+		return nil, err
+	}
+
+	for _, status := range rollup.ByStatus {
+		rollup.Estimated += status.Estimated
+		rollup.Actual += status.Actual
+		rollup.Items += status.Items
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"items": rollup.Items,
+		"weeks": len(rollup.ByWeek),
+	}).Debug("Project item hours summed successfully in database")
+
+	return &rollup, nil
+}
@@ -2,26 +2,35 @@ package infrastructure
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"github.com/edumes/golang-api-rest/internal/domain"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresProjectRepository struct {
 	db     *gorm.DB
 	logger *logrus.Logger
+	clock  domain.Clock
 }
 
 func NewPostgresProjectRepository(db *gorm.DB) *PostgresProjectRepository {
 	return &PostgresProjectRepository{
 		db:     db,
-		logger: logrus.New(),
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
 	}
 }
 
+func (r *PostgresProjectRepository) WithClock(clock domain.Clock) *PostgresProjectRepository {
+	r.clock = clock
+	return r
+}
+
 func (r *PostgresProjectRepository) Create(ctx context.Context, project *domain.Project) error {
 	r.logger.WithFields(logrus.Fields{
 		"project_id": project.ID,
@@ -80,8 +89,62 @@ func (r *PostgresProjectRepository) List(ctx context.Context, filter domain.Proj
 	}).Debug("Listing projects from database with filters")
 
 	var projects []domain.Project
-	db := r.db.WithContext(ctx).Model(&domain.Project{})
+	db := r.applyFilter(r.db.WithContext(ctx).Model(&domain.Project{}), filter)
+
+	if pagination.AfterID != nil {
+		db = db.Where("id > ?", *pagination.AfterID)
+	}
+
+	if pagination.Sort != "" {
+		r.logger.WithFields(logrus.Fields{
+			"sort": pagination.Sort,
+		}).Debug("Applying sort")
+		db = db.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"limit": pagination.Limit,
+		}).Debug("Applying limit")
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"offset": pagination.Offset,
+		}).Debug("Applying offset")
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&projects).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list projects from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(projects),
+	}).Debug("Projects listed successfully from database")
+
+	return projects, nil
+}
+
+func (r *PostgresProjectRepository) Count(ctx context.Context, filter domain.ProjectParams) (int64, error) {
+	var total int64
+	err := r.applyFilter(r.db.WithContext(ctx).Model(&domain.Project{}), filter).
+		Count(&total).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count projects in database")
+		return 0, err
+	}
 
+	return total, nil
+}
+
+func (r *PostgresProjectRepository) applyFilter(db *gorm.DB, filter domain.ProjectParams) *gorm.DB {
 	if filter.Name != "" {
 		r.logger.WithFields(logrus.Fields{
 			"filter_name": filter.Name,
@@ -159,51 +222,33 @@ func (r *PostgresProjectRepository) List(ctx context.Context, filter domain.Proj
 		db = db.Where("created_at <= ?", *filter.CreatedAtTo)
 	}
 
-	db = db.Where("deleted_at IS NULL")
-
-	if pagination.Sort != "" {
-		r.logger.WithFields(logrus.Fields{
-			"sort": pagination.Sort,
-		}).Debug("Applying sort")
-		db = db.Order(pagination.Sort)
+	if !filter.IncludeArchived {
+		db = db.Where("archived_at IS NULL")
 	}
 
-	if pagination.Limit > 0 {
+	if len(filter.CustomFields) > 0 {
 		r.logger.WithFields(logrus.Fields{
-			"limit": pagination.Limit,
-		}).Debug("Applying limit")
-		db = db.Limit(pagination.Limit)
+			"custom_fields": filter.CustomFields,
+		}).Debug("Applying custom field filters")
+		db = whereCustomFields(db, filter.CustomFields)
 	}
 
-	if pagination.Offset > 0 {
-		r.logger.WithFields(logrus.Fields{
-			"offset": pagination.Offset,
-		}).Debug("Applying offset")
-		db = db.Offset(pagination.Offset)
-	}
-
-	if err := db.Find(&projects).Error; err != nil {
-		r.logger.WithFields(logrus.Fields{
-			"error": err.Error(),
-		}).Error("Failed to list projects from database")
-		return nil, err
-	}
-
-	r.logger.WithFields(logrus.Fields{
-		"count": len(projects),
-	}).Debug("Projects listed successfully from database")
+	db = db.Where("deleted_at IS NULL")
 
-	return projects, nil
+	return db
 }
 
-func (r *PostgresProjectRepository) Update(ctx context.Context, project *domain.Project) error {
+func (r *PostgresProjectRepository) Update(ctx context.Context, project *domain.Project, fields ...string) error {
 	r.logger.WithFields(logrus.Fields{
 		"project_id": project.ID,
 		"name":       project.Name,
 		"status":     project.Status,
 	}).Debug("Updating project in database")
 
-	err := r.db.WithContext(ctx).Model(project).Updates(project).Error
+	// The archived state only changes through SetArchived.
+	err := updateVersioned(r.db.WithContext(ctx), project, project.ID, &project.Version, func(db *gorm.DB) *gorm.DB {
+		return selectFields(db, fields).Omit("archived_at").Updates(project)
+	})
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
@@ -225,7 +270,7 @@ func (r *PostgresProjectRepository) Delete(ctx context.Context, id uuid.UUID) er
 		"project_id": id,
 	}).Debug("Soft deleting project in database")
 
-	err := r.db.WithContext(ctx).Model(&domain.Project{}).Where("id = ?", id).Update("deleted_at", time.Now()).Error
+	err := r.db.WithContext(ctx).Model(&domain.Project{}).Where("id = ?", id).Update("deleted_at", r.clock.Now()).Error
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":      err.Error(),
@@ -241,6 +286,93 @@ func (r *PostgresProjectRepository) Delete(ctx context.Context, id uuid.UUID) er
 	return nil
 }
 
+func (r *PostgresProjectRepository) SetArchived(ctx context.Context, id uuid.UUID, archivedAt *time.Time) error {
+	r.logger.WithFields(logrus.Fields{
+		"project_id":  id,
+		"archived_at": archivedAt,
+	}).Debug("Updating project archived state in database")
+
+	err := r.db.WithContext(ctx).Model(&domain.Project{}).Where("id = ? AND deleted_at IS NULL", id).Updates(map[string]interface{}{
+		"archived_at": archivedAt,
+		"updated_at":  r.clock.Now(),
+	}).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": id,
+		}).Error("Failed to update project archived state in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"project_id": id,
+	}).Debug("Project archived state updated successfully in database")
+
+	return nil
+}
+
+// Progress computes the progress of every given project in one aggregate
+// query over their items. Projects without anything to measure are left out
+// of the result.
+func (r *PostgresProjectRepository) Progress(ctx context.Context, ids []uuid.UUID, mode string) (map[uuid.UUID]float64, error) {
+	r.logger.WithFields(logrus.Fields{
+		"projects": len(ids),
+		"mode":     mode,
+	}).Debug("Computing project progress in database")
+
+	progress := make(map[uuid.UUID]float64, len(ids))
+	if len(ids) == 0 {
+		return progress, nil
+	}
+
+	weight := "1"
+	if mode == domain.ProjectProgressByHours {
+		weight = "COALESCE(estimated_hours, 0)"
+	}
+
+	var rows []struct {
+		ProjectID uuid.UUID
+		Done      float64
+		Total     float64
+	}
+	err := r.db.WithContext(ctx).Model(&domain.ProjectItem{}).
+		Select("project_id, COALESCE(SUM("+weight+") FILTER (WHERE status = ?), 0) AS done, COALESCE(SUM("+weight+"), 0) AS total", domain.ProjectItemStatusCompleted).
+		Where("project_id IN ? AND deleted_at IS NULL AND status <> ?", ids, domain.ProjectItemStatusCancelled).
+		Group("project_id").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to compute project progress in database")
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if row.Total > 0 {
+			progress[row.ProjectID] = math.Round(row.Done/row.Total*10000) / 100
+		}
+	}
+
+	return progress, nil
+}
+
+// ensureProjectWritable fails with domain.ErrProjectArchived when the project
+// is archived. The row is share-locked so an archive running concurrently
+// waits for the caller's transaction.
+func ensureProjectWritable(tx *gorm.DB, projectID uuid.UUID) error {
+	var project domain.Project
+	if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+		Select("id", "archived_at").
+		First(&project, "id = ? AND deleted_at IS NULL", projectID).Error; err != nil {
+		return err
+	}
+
+	if project.ArchivedAt != nil {
+		return domain.ErrProjectArchived
+	}
+	return nil
+}
+
 func (r *PostgresProjectRepository) GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]domain.Project, error) {
 	r.logger.WithFields(logrus.Fields{
 		"owner_id": ownerID,
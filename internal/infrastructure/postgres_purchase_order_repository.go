@@ -0,0 +1,316 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresPurchaseOrderRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+	ids    domain.IDGenerator
+}
+
+func NewPostgresPurchaseOrderRepository(db *gorm.DB) *PostgresPurchaseOrderRepository {
+	return &PostgresPurchaseOrderRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+		ids:    domain.UUIDv7Generator{},
+	}
+}
+
+func (r *PostgresPurchaseOrderRepository) WithClock(clock domain.Clock) *PostgresPurchaseOrderRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresPurchaseOrderRepository) WithIDGenerator(ids domain.IDGenerator) *PostgresPurchaseOrderRepository {
+	r.ids = ids
+	return r
+}
+
+func (r *PostgresPurchaseOrderRepository) Create(ctx context.Context, order *domain.PurchaseOrder) error {
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+		"supplier":          order.Supplier,
+		"lines":             len(order.Lines),
+	}).Debug("Creating purchase order in database")
+
+	err := r.db.WithContext(ctx).Create(order).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": order.ID,
+		}).Error("Failed to create purchase order in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+	}).Debug("Purchase order created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresPurchaseOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error) {
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+	}).Debug("Getting purchase order by ID from database")
+
+	var order domain.PurchaseOrder
+	err := r.db.WithContext(ctx).Preload("Lines").First(&order, "id = ? AND deleted_at IS NULL", id).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+		}).Warn("Purchase order not found in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+		"status":            order.Status,
+	}).Debug("Purchase order retrieved successfully from database")
+
+	return &order, nil
+}
+
+func (r *PostgresPurchaseOrderRepository) List(ctx context.Context, filter domain.PurchaseOrderParams, pagination domain.Pagination) ([]domain.PurchaseOrder, error) {
+	r.logger.WithFields(logrus.Fields{
+		"filter_supplier": filter.Supplier,
+		"filter_status":   filter.Status,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
+		"sort":            pagination.Sort,
+	}).Debug("Listing purchase orders from database with filters")
+
+	var orders []domain.PurchaseOrder
+	db := r.db.WithContext(ctx).Model(&domain.PurchaseOrder{}).Preload("Lines")
+
+	if filter.Supplier != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_supplier": filter.Supplier,
+		}).Debug("Applying supplier filter")
+		db = db.Where("supplier ILIKE ?", "%"+filter.Supplier+"%")
+	}
+
+	if filter.Status != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_status": filter.Status,
+		}).Debug("Applying status filter")
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	db = db.Where("deleted_at IS NULL")
+
+	if pagination.Sort != "" {
+		r.logger.WithFields(logrus.Fields{
+			"sort": pagination.Sort,
+		}).Debug("Applying sort")
+		db = db.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"limit": pagination.Limit,
+		}).Debug("Applying limit")
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"offset": pagination.Offset,
+		}).Debug("Applying offset")
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&orders).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list purchase orders from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(orders),
+	}).Debug("Purchase orders listed successfully from database")
+
+	return orders, nil
+}
+
+func (r *PostgresPurchaseOrderRepository) Update(ctx context.Context, order *domain.PurchaseOrder) error {
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+		"supplier":          order.Supplier,
+		"lines":             len(order.Lines),
+	}).Debug("Updating purchase order in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current domain.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "id = ? AND deleted_at IS NULL", order.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != domain.PurchaseOrderStatusDraft {
+			return domain.ErrPurchaseOrderStatus
+		}
+
+		if err := tx.Model(&domain.PurchaseOrder{}).Where("id = ?", order.ID).Updates(map[string]interface{}{
+			"supplier":     order.Supplier,
+			"warehouse_id": order.WarehouseID,
+			"notes":        order.Notes,
+			"updated_at":   order.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("purchase_order_id = ?", order.ID).Delete(&domain.PurchaseOrderLine{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&order.Lines).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": order.ID,
+		}).Error("Failed to update purchase order in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": order.ID,
+	}).Debug("Purchase order updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresPurchaseOrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+	}).Debug("Soft deleting purchase order in database")
+
+	err := r.db.WithContext(ctx).Model(&domain.PurchaseOrder{}).Where("id = ?", id).Update("deleted_at", r.clock.Now()).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+		}).Error("Failed to delete purchase order from database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+	}).Debug("Purchase order soft deleted successfully in database")
+
+	return nil
+}
+
+func (r *PostgresPurchaseOrderRepository) Submit(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+	}).Debug("Submitting purchase order in database")
+
+	now := r.clock.Now()
+	result := r.db.WithContext(ctx).Model(&domain.PurchaseOrder{}).
+		Where("id = ? AND status = ? AND deleted_at IS NULL", id, domain.PurchaseOrderStatusDraft).
+		Updates(map[string]interface{}{
+			"status":       domain.PurchaseOrderStatusSubmitted,
+			"submitted_at": now,
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":             result.Error.Error(),
+			"purchase_order_id": id,
+		}).Error("Failed to submit purchase order in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		r.logger.WithFields(logrus.Fields{
+			"purchase_order_id": id,
+		}).Warn("Purchase order is not a draft")
+		return domain.ErrPurchaseOrderStatus
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+	}).Debug("Purchase order submitted successfully in database")
+
+	return nil
+}
+
+func (r *PostgresPurchaseOrderRepository) Receive(ctx context.Context, id, actorID uuid.UUID) (*domain.PurchaseOrder, error) {
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+		"actor_id":          actorID,
+	}).Debug("Receiving purchase order in database")
+
+	var order domain.PurchaseOrder
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&order, "id = ? AND deleted_at IS NULL", id).Error; err != nil {
+			return err
+		}
+		if order.Status != domain.PurchaseOrderStatusSubmitted {
+			return domain.ErrPurchaseOrderStatus
+		}
+		if err := tx.Where("purchase_order_id = ?", id).Order("id").Find(&order.Lines).Error; err != nil {
+			return err
+		}
+
+		now := r.clock.Now()
+		for _, line := range order.Lines {
+			adj := &domain.StockAdjustment{
+				ID:          r.ids.NewID(),
+				ProductID:   line.ProductID,
+				WarehouseID: order.WarehouseID,
+				Quantity:    line.Quantity,
+				Reason:      domain.StockReasonPurchase,
+				Note:        "purchase order " + order.ID.String(),
+				ActorID:     actorID,
+				CreatedAt:   now,
+			}
+			if err := applyStockAdjustment(tx, adj, now); err != nil {
+				return err
+			}
+			if err := tx.Model(&domain.Product{}).Where("id = ?", line.ProductID).Updates(map[string]interface{}{
+				"cost_price": line.UnitCost,
+				"updated_at": now,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		order.Status = domain.PurchaseOrderStatusReceived
+		order.ReceivedAt = &now
+		order.ReceivedBy = &actorID
+		order.UpdatedAt = now
+		return tx.Model(&domain.PurchaseOrder{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":      order.Status,
+			"received_at": now,
+			"received_by": actorID,
+			"updated_at":  now,
+		}).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":             err.Error(),
+			"purchase_order_id": id,
+		}).Error("Failed to receive purchase order in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"purchase_order_id": id,
+		"lines":             len(order.Lines),
+	}).Debug("Purchase order received successfully in database")
+
+	return &order, nil
+}
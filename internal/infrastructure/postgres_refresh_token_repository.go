@@ -0,0 +1,89 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresRefreshTokenRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresRefreshTokenRepository(db *gorm.DB) *PostgresRefreshTokenRepository {
+	return &PostgresRefreshTokenRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": token.UserID,
+	}).Debug("Creating refresh token in database")
+
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": token.UserID,
+		}).Error("Failed to create refresh token in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).Take(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrRefreshTokenInvalid
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to get refresh token from database")
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *PostgresRefreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result := r.db.WithContext(ctx).Model(&domain.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    result.Error.Error(),
+			"token_id": id,
+		}).Error("Failed to revoke refresh token in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrRefreshTokenInvalid
+	}
+
+	return nil
+}
+
+func (r *PostgresRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID, at time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&domain.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error.Error(),
+			"user_id": userID,
+		}).Error("Failed to revoke refresh tokens in database")
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
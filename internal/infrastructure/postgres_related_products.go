@@ -0,0 +1,64 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostgresSimilarProducts recommends products in the same category or priced
+// within domain.RelatedPriceBand of the product. Same-category products come
+// first, then products in stock, then the closest prices.
+type PostgresSimilarProducts struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresSimilarProducts(db *gorm.DB) *PostgresSimilarProducts {
+	return &PostgresSimilarProducts{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresSimilarProducts) Related(ctx context.Context, product *domain.Product, limit int) ([]domain.Product, error) {
+	r.logger.WithFields(logrus.Fields{
+		"product_id": product.ID,
+		"category":   product.Category,
+		"limit":      limit,
+	}).Debug("Finding similar products in database")
+
+	price := float64(product.Price)
+	low, high := price*(1-domain.RelatedPriceBand), price*(1+domain.RelatedPriceBand)
+
+	products := []domain.Product{}
+	db := r.db.WithContext(ctx).
+		Where("id <> ? AND deleted_at IS NULL AND archived_at IS NULL", product.ID)
+	if product.Category != "" {
+		db = db.Where("category = ? OR price BETWEEN ? AND ?", product.Category, low, high)
+	} else {
+		db = db.Where("price BETWEEN ? AND ?", low, high)
+	}
+	err := db.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                "category = ? DESC, stock > 0 DESC, abs(price - ?), name",
+		Vars:               []interface{}{product.Category, price},
+		WithoutParentheses: true,
+	}}).Limit(limit).Find(&products).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": product.ID,
+		}).Error("Failed to find similar products in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"product_id": product.ID,
+		"count":      len(products),
+	}).Debug("Similar products found successfully in database")
+
+	return products, nil
+}
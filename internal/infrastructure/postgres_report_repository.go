@@ -0,0 +1,183 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// The SQL expressions behind each report's group_by values. Only these are
+// ever interpolated into the queries.
+var (
+	itemReportGroupColumns = map[string]string{
+		"status":      "project_items.status",
+		"priority":    "project_items.priority",
+		"assigned_to": "project_items.assigned_to::text",
+		"project_id":  "project_items.project_id::text",
+	}
+	stockReportGroupColumns = map[string]string{
+		"category":  "products.category",
+		"warehouse": "warehouses.code",
+	}
+	projectReportGroupColumns = map[string]string{
+		"status":   "projects.status",
+		"owner_id": "projects.owner_id::text",
+	}
+)
+
+type PostgresReportRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresReportRepository(db *gorm.DB) *PostgresReportRepository {
+	return &PostgresReportRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresReportRepository) ItemsReport(ctx context.Context, params domain.ReportParams) ([]domain.ItemsReportRow, error) {
+	r.logger.WithFields(logrus.Fields{
+		"group_by": params.GroupBy,
+		"interval": params.Interval,
+	}).Debug("Computing project items report in database")
+
+	db, err := r.reportScope(ctx, "project_items", itemReportGroupColumns, params,
+		"COUNT(*) AS items, COALESCE(SUM(project_items.estimated_hours), 0) AS estimated_hours, COALESCE(SUM(project_items.actual_hours), 0) AS actual_hours")
+	if err != nil {
+		return nil, err
+	}
+	db = db.Where("project_items.deleted_at IS NULL")
+	if params.ProjectID != nil {
+		db = db.Where("project_items.project_id = ?", *params.ProjectID)
+	}
+	if params.AssignedTo != nil {
+		db = db.Where("project_items.assigned_to = ?", *params.AssignedTo)
+	}
+	if params.Status != "" {
+		db = db.Where("project_items.status = ?", params.Status)
+	}
+	if params.Priority != "" {
+		db = db.Where("project_items.priority = ?", params.Priority)
+	}
+
+	var rows []domain.ItemsReportRow
+	if err := r.scanReport(db, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *PostgresReportRepository) StockReport(ctx context.Context, params domain.ReportParams) ([]domain.StockReportRow, error) {
+	r.logger.WithFields(logrus.Fields{
+		"group_by": params.GroupBy,
+		"interval": params.Interval,
+	}).Debug("Computing stock report in database")
+
+	// Grouped by warehouse the stock comes from the per-warehouse levels
+	// rather than the product totals.
+	sums := "COUNT(*) AS products, COALESCE(SUM(products.stock), 0) AS stock, " +
+		"COALESCE(SUM(products.stock * products.price), 0) AS stock_value, " +
+		"COALESCE(SUM(products.stock * products.cost_price), 0) AS stock_cost"
+	if params.GroupBy == "warehouse" {
+		sums = "COUNT(DISTINCT products.id) AS products, COALESCE(SUM(warehouse_stocks.quantity), 0) AS stock, " +
+			"COALESCE(SUM(warehouse_stocks.quantity * products.price), 0) AS stock_value, " +
+			"COALESCE(SUM(warehouse_stocks.quantity * products.cost_price), 0) AS stock_cost"
+	}
+
+	db, err := r.reportScope(ctx, "products", stockReportGroupColumns, params, sums)
+	if err != nil {
+		return nil, err
+	}
+	if params.GroupBy == "warehouse" {
+		db = db.Joins("JOIN warehouse_stocks ON warehouse_stocks.product_id = products.id").
+			Joins("JOIN warehouses ON warehouses.id = warehouse_stocks.warehouse_id AND warehouses.deleted_at IS NULL")
+	}
+	db = db.Where("products.deleted_at IS NULL")
+	if !params.IncludeArchived {
+		db = db.Where("products.archived_at IS NULL")
+	}
+	if params.Category != "" {
+		db = db.Where("products.category = ?", params.Category)
+	}
+
+	var rows []domain.StockReportRow
+	if err := r.scanReport(db, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *PostgresReportRepository) ProjectsReport(ctx context.Context, params domain.ReportParams) ([]domain.ProjectsReportRow, error) {
+	r.logger.WithFields(logrus.Fields{
+		"group_by": params.GroupBy,
+		"interval": params.Interval,
+	}).Debug("Computing projects budget report in database")
+
+	db, err := r.reportScope(ctx, "projects", projectReportGroupColumns, params,
+		"COUNT(*) AS projects, COALESCE(SUM(projects.budget), 0) AS budget, COALESCE(SUM(spent.amount), 0) AS spent, "+
+			"COALESCE(SUM(projects.budget - COALESCE(spent.amount, 0)), 0) AS remaining")
+	if err != nil {
+		return nil, err
+	}
+	db = db.Joins("LEFT JOIN (SELECT project_id, SUM(amount) AS amount FROM expenses WHERE deleted_at IS NULL GROUP BY project_id) AS spent ON spent.project_id = projects.id").
+		Where("projects.deleted_at IS NULL")
+	if !params.IncludeArchived {
+		db = db.Where("projects.archived_at IS NULL")
+	}
+	if params.OwnerID != nil {
+		db = db.Where("projects.owner_id = ?", *params.OwnerID)
+	}
+	if params.Status != "" {
+		db = db.Where("projects.status = ?", params.Status)
+	}
+
+	var rows []domain.ProjectsReportRow
+	if err := r.scanReport(db, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// reportScope selects the group, the creation date bucket and sums from
+// table, restricted to the requested creation date range.
+func (r *PostgresReportRepository) reportScope(ctx context.Context, table string, groups map[string]string, params domain.ReportParams, sums string) (*gorm.DB, error) {
+	group, ok := groups[params.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("cannot group %s by %q", table, params.GroupBy)
+	}
+
+	createdAt := table + ".created_at"
+	bucket := "NULL::timestamptz"
+	var args []interface{}
+	if params.Interval != "" {
+		bucket = "date_trunc(?, " + createdAt + ")"
+		args = append(args, string(params.Interval))
+	}
+
+	db := r.db.WithContext(ctx).Table(table).
+		Select(group+" AS grp, "+bucket+" AS bucket, "+sums, args...).
+		Group("grp, bucket").
+		Order("bucket, grp NULLS LAST")
+	if params.From != nil {
+		db = db.Where(createdAt+" >= ?", *params.From)
+	}
+	if params.To != nil {
+		db = db.Where(createdAt+" <= ?", *params.To)
+	}
+	return db, nil
+}
+
+func (r *PostgresReportRepository) scanReport(db *gorm.DB, rows interface{}) error {
+	if err := db.Scan(rows).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to compute report in database")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresReportSubscriptionRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+}
+
+func NewPostgresReportSubscriptionRepository(db *gorm.DB) *PostgresReportSubscriptionRepository {
+	return &PostgresReportSubscriptionRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+	}
+}
+
+func (r *PostgresReportSubscriptionRepository) WithClock(clock domain.Clock) *PostgresReportSubscriptionRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresReportSubscriptionRepository) Create(ctx context.Context, subscription *domain.ReportSubscription) error {
+	r.logger.WithFields(logrus.Fields{
+		"subscription_id": subscription.ID,
+		"user_id":         subscription.UserID,
+		"report":          subscription.Report,
+	}).Debug("Creating report subscription in database")
+
+	if err := r.db.WithContext(ctx).Create(subscription).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": subscription.UserID,
+		}).Error("Failed to create report subscription in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"subscription_id": subscription.ID,
+	}).Debug("Report subscription created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresReportSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ReportSubscription, error) {
+	r.logger.WithFields(logrus.Fields{
+		"subscription_id": id,
+	}).Debug("Getting report subscription by ID from database")
+
+	var subscription domain.ReportSubscription
+	err := r.db.WithContext(ctx).First(&subscription, "id = ? AND deleted_at IS NULL", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"subscription_id": id,
+		}).Warn("Report subscription not found in database")
+		return nil, domain.ErrReportSubscriptionNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": id,
+		}).Error("Failed to get report subscription from database")
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (r *PostgresReportSubscriptionRepository) List(ctx context.Context, params domain.ReportSubscriptionParams, pagination domain.Pagination) ([]domain.ReportSubscription, error) {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": params.UserID,
+		"report":  params.Report,
+		"limit":   pagination.Limit,
+		"offset":  pagination.Offset,
+	}).Debug("Listing report subscriptions from database")
+
+	db := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL").
+		Where("user_id = ?", params.UserID)
+	if params.Report != "" {
+		db = db.Where("report = ?", params.Report)
+	}
+	db = db.Order("name, id")
+
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	var subscriptions []domain.ReportSubscription
+	if err := db.Find(&subscriptions).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": params.UserID,
+		}).Error("Failed to list report subscriptions from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(subscriptions),
+	}).Debug("Report subscriptions listed successfully from database")
+
+	return subscriptions, nil
+}
+
+func (r *PostgresReportSubscriptionRepository) Update(ctx context.Context, subscription *domain.ReportSubscription) error {
+	r.logger.WithFields(logrus.Fields{
+		"subscription_id": subscription.ID,
+	}).Debug("Updating report subscription in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.ReportSubscription{}).
+		Where("id = ? AND deleted_at IS NULL", subscription.ID).
+		Updates(map[string]interface{}{
+			"name":        subscription.Name,
+			"format":      subscription.Format,
+			"filters":     subscription.Filters,
+			"schedule":    subscription.Schedule,
+			"recipients":  subscription.Recipients,
+			"active":      subscription.Active,
+			"next_run_at": subscription.NextRunAt,
+			"updated_at":  subscription.UpdatedAt,
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           result.Error.Error(),
+			"subscription_id": subscription.ID,
+		}).Error("Failed to update report subscription in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrReportSubscriptionNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"subscription_id": subscription.ID,
+	}).Debug("Report subscription updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresReportSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"subscription_id": id,
+	}).Debug("Deleting report subscription in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.ReportSubscription{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", r.clock.Now())
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           result.Error.Error(),
+			"subscription_id": id,
+		}).Error("Failed to delete report subscription in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrReportSubscriptionNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"subscription_id": id,
+	}).Debug("Report subscription deleted successfully in database")
+
+	return nil
+}
+
+func (r *PostgresReportSubscriptionRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]domain.ReportSubscription, error) {
+	var subscriptions []domain.ReportSubscription
+	err := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL AND active AND next_run_at <= ?", now).
+		Order("next_run_at, id").
+		Limit(limit).
+		Find(&subscriptions).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list due report subscriptions from database")
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// Claim only moves the subscription on while next_run_at still holds the
+// run being claimed, so each run is delivered by a single instance.
+func (r *PostgresReportSubscriptionRepository) Claim(ctx context.Context, id uuid.UUID, due time.Time, next *time.Time, ranAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.ReportSubscription{}).
+		Where("id = ? AND deleted_at IS NULL AND active AND next_run_at = ?", id, due).
+		Updates(map[string]interface{}{
+			"next_run_at": next,
+			"last_run_at": ranAt,
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           result.Error.Error(),
+			"subscription_id": id,
+		}).Error("Failed to claim report subscription run in database")
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *PostgresReportSubscriptionRepository) CreateDelivery(ctx context.Context, delivery *domain.ReportDelivery) error {
+	if err := r.db.WithContext(ctx).Create(delivery).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": delivery.SubscriptionID,
+		}).Error("Failed to record report delivery in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresReportSubscriptionRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, pagination domain.Pagination) ([]domain.ReportDelivery, error) {
+	r.logger.WithFields(logrus.Fields{
+		"subscription_id": subscriptionID,
+		"limit":           pagination.Limit,
+		"offset":          pagination.Offset,
+	}).Debug("Listing report deliveries from database")
+
+	db := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC, id")
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	var deliveries []domain.ReportDelivery
+	if err := db.Find(&deliveries).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"subscription_id": subscriptionID,
+		}).Error("Failed to list report deliveries from database")
+		return nil, err
+	}
+
+	return deliveries, nil
+}
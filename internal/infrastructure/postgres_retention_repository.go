@@ -0,0 +1,533 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// retentionPurgeTables maps the purgeable entities to their table. Every one
+// of them ages by created_at.
+var retentionPurgeTables = map[domain.RetentionEntity]string{
+	domain.RetentionNotification:    "notifications",
+	domain.RetentionReportDelivery:  "report_deliveries",
+	domain.RetentionProjectExport:   "project_exports",
+	domain.RetentionStockAdjustment: "stock_adjustments",
+}
+
+// anonymizedUserColumns replaces a user's personal data with placeholders and
+// switches the account off. The placeholder email stays unique per account.
+const anonymizedUserColumns = "name = 'Anonymized user', email = 'anonymized-' || id || '@anonymized.invalid', " +
+	"password_hash = '', mfa_secret = '', mfa_enabled = FALSE, active = FALSE, anonymized_at = ?, updated_at = ?"
+
+// retentionInactiveUsers selects the accounts a user retention rule
+// anonymizes: not yet anonymized, not admins, and without a login since the
+// cutoff, counting from creation for accounts that never logged in.
+const retentionInactiveUsers = "anonymized_at IS NULL AND role <> ? AND COALESCE(last_login_at, created_at) < ?"
+
+// retentionArchivable selects the rows an archive rule moves, given the
+// cutoff: stock adjustments by creation date, completed projects and items
+// once they have not changed since. Projects that project-scoped custom
+// fields or chat connectors still point at are left in place.
+var retentionArchivable = map[domain.RetentionEntity]string{
+	domain.RetentionStockAdjustment: "SELECT id FROM stock_adjustments WHERE created_at < ?",
+	domain.RetentionProjectItem:     "SELECT id FROM project_items WHERE status = 'completed' AND deleted_at IS NULL AND updated_at < ?",
+	domain.RetentionProject: "SELECT id FROM projects p WHERE status = 'completed' AND deleted_at IS NULL AND updated_at < ?" +
+		" AND NOT EXISTS (SELECT 1 FROM custom_field_definitions f WHERE f.project_id = p.id)" +
+		" AND NOT EXISTS (SELECT 1 FROM chat_connectors c WHERE c.project_id = p.id)",
+}
+
+// retentionArchiveStatements move one batch of the rows picked by
+// retentionArchivable into archived_records and delete them, in a single
+// statement so a row is never in both places. The parameters are the
+// cutoff, the batch size, the rule and the archive time. Item assignments
+// go with their item through ON DELETE CASCADE; a project's generated
+// exports are dropped rather than archived.
+var retentionArchiveStatements = map[domain.RetentionEntity]string{
+	domain.RetentionStockAdjustment: "WITH picked AS (" + retentionArchivable[domain.RetentionStockAdjustment] + " LIMIT ?), " +
+		"moved AS (DELETE FROM stock_adjustments WHERE id IN (SELECT id FROM picked) RETURNING *) " +
+		"INSERT INTO archived_records (entity, record_id, rule_id, payload, archived_at) " +
+		"SELECT 'stock_adjustment', id, ?, jsonb_build_object('stock_adjustment', to_jsonb(moved)), ? FROM moved",
+	domain.RetentionProjectItem: "WITH picked AS (" + retentionArchivable[domain.RetentionProjectItem] + " LIMIT ?), " +
+		"archived AS (INSERT INTO archived_records (entity, record_id, project_id, rule_id, payload, archived_at) " +
+		"SELECT 'project_item', i.id, i.project_id, ?, jsonb_build_object(" +
+		"'project_item', to_jsonb(i), " +
+		"'assignments', COALESCE((SELECT jsonb_agg(to_jsonb(a)) FROM project_item_assignments a WHERE a.item_id = i.id), '[]')" +
+		"), ? FROM project_items i WHERE i.id IN (SELECT id FROM picked) RETURNING record_id) " +
+		"DELETE FROM project_items WHERE id IN (SELECT record_id FROM archived)",
+	domain.RetentionProject: "WITH picked AS (" + retentionArchivable[domain.RetentionProject] + " LIMIT ?), " +
+		"archived AS (INSERT INTO archived_records (entity, record_id, project_id, rule_id, payload, archived_at) " +
+		"SELECT 'project', p.id, p.id, ?, jsonb_build_object(" +
+		"'project', to_jsonb(p), " +
+		"'project_items', COALESCE((SELECT jsonb_agg(to_jsonb(i)) FROM project_items i WHERE i.project_id = p.id), '[]'), " +
+		"'assignments', COALESCE((SELECT jsonb_agg(to_jsonb(a)) FROM project_item_assignments a JOIN project_items i ON i.id = a.item_id WHERE i.project_id = p.id), '[]'), " +
+		"'expenses', COALESCE((SELECT jsonb_agg(to_jsonb(e)) FROM expenses e WHERE e.project_id = p.id), '[]')" +
+		"), ? FROM projects p WHERE p.id IN (SELECT id FROM picked) RETURNING record_id), " +
+		"dropped_exports AS (DELETE FROM project_exports WHERE project_id IN (SELECT record_id FROM archived)), " +
+		"dropped_expenses AS (DELETE FROM expenses WHERE project_id IN (SELECT record_id FROM archived)) " +
+		"DELETE FROM projects WHERE id IN (SELECT record_id FROM archived)",
+}
+
+// archivedRestores list, per entity, the payload keys to put back and the
+// table each returns to. The first is the record itself, a single row; the
+// others are lists of rows referencing it, so they come after it.
+var archivedRestores = map[domain.RetentionEntity][][2]string{
+	domain.RetentionStockAdjustment: {{"stock_adjustment", "stock_adjustments"}},
+	domain.RetentionProjectItem:     {{"project_item", "project_items"}, {"assignments", "project_item_assignments"}},
+	domain.RetentionProject: {
+		{"project", "projects"},
+		{"project_items", "project_items"},
+		{"assignments", "project_item_assignments"},
+		{"expenses", "expenses"},
+	},
+}
+
+// archivedParents checks that what an archived record references is still
+// there, given its payload.
+var archivedParents = map[domain.RetentionEntity]string{
+	domain.RetentionStockAdjustment: "SELECT EXISTS (SELECT 1 FROM products WHERE id = (?::jsonb #>> '{stock_adjustment,product_id}')::uuid)",
+	domain.RetentionProjectItem:     "SELECT EXISTS (SELECT 1 FROM projects WHERE id = (?::jsonb #>> '{project_item,project_id}')::uuid)",
+}
+
+type PostgresRetentionRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+}
+
+func NewPostgresRetentionRepository(db *gorm.DB) *PostgresRetentionRepository {
+	return &PostgresRetentionRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+	}
+}
+
+func (r *PostgresRetentionRepository) WithClock(clock domain.Clock) *PostgresRetentionRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresRetentionRepository) CreateRule(ctx context.Context, rule *domain.RetentionRule) error {
+	r.logger.WithFields(logrus.Fields{
+		"rule_id": rule.ID,
+		"entity":  rule.Entity,
+	}).Debug("Creating retention rule in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialising creations per entity lets the count report a clash as
+		// ErrRetentionRuleExists before the partial unique index trips.
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "retention:"+string(rule.Entity)).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&domain.RetentionRule{}).
+			Where("entity = ? AND deleted_at IS NULL", rule.Entity).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return domain.ErrRetentionRuleExists
+		}
+
+		return tx.Create(rule).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"entity": rule.Entity,
+		}).Error("Failed to create retention rule in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresRetentionRepository) GetRule(ctx context.Context, id uuid.UUID) (*domain.RetentionRule, error) {
+	var rule domain.RetentionRule
+	err := r.db.WithContext(ctx).First(&rule, "id = ? AND deleted_at IS NULL", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"rule_id": id,
+		}).Warn("Retention rule not found in database")
+		return nil, domain.ErrRetentionRuleNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"rule_id": id,
+		}).Error("Failed to get retention rule from database")
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (r *PostgresRetentionRepository) ListRules(ctx context.Context) ([]domain.RetentionRule, error) {
+	var rules []domain.RetentionRule
+	if err := r.db.WithContext(ctx).Where("deleted_at IS NULL").Order("entity").Find(&rules).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list retention rules from database")
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *PostgresRetentionRepository) UpdateRule(ctx context.Context, rule *domain.RetentionRule) error {
+	r.logger.WithFields(logrus.Fields{
+		"rule_id": rule.ID,
+	}).Debug("Updating retention rule in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.RetentionRule{}).
+		Where("id = ? AND deleted_at IS NULL", rule.ID).
+		Updates(map[string]interface{}{
+			"action":      rule.Action,
+			"retain_days": rule.RetainDays,
+			"enabled":     rule.Enabled,
+			"updated_at":  rule.UpdatedAt,
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error.Error(),
+			"rule_id": rule.ID,
+		}).Error("Failed to update retention rule in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrRetentionRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresRetentionRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&domain.RetentionRule{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", r.clock.Now())
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error.Error(),
+			"rule_id": id,
+		}).Error("Failed to delete retention rule in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrRetentionRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresRetentionRepository) ClaimRule(ctx context.Context, id uuid.UUID, previous *time.Time, ranAt time.Time) (bool, error) {
+	db := r.db.WithContext(ctx).Model(&domain.RetentionRule{}).
+		Where("id = ? AND deleted_at IS NULL AND enabled", id)
+	if previous == nil {
+		db = db.Where("last_run_at IS NULL")
+	} else {
+		db = db.Where("last_run_at = ?", *previous)
+	}
+
+	result := db.Update("last_run_at", ranAt)
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error.Error(),
+			"rule_id": id,
+		}).Error("Failed to claim retention rule run in database")
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *PostgresRetentionRepository) Apply(ctx context.Context, rule *domain.RetentionRule, cutoff time.Time, dryRun bool) (int64, error) {
+	r.logger.WithFields(logrus.Fields{
+		"rule_id": rule.ID,
+		"entity":  rule.Entity,
+		"action":  rule.Action,
+		"cutoff":  cutoff,
+		"dry_run": dryRun,
+	}).Debug("Applying retention rule in database")
+
+	var affected int64
+	var err error
+	switch {
+	case rule.Entity == domain.RetentionUser && rule.Action == domain.RetentionAnonymize:
+		affected, err = r.anonymizeUsers(ctx, cutoff, dryRun)
+	case rule.Action == domain.RetentionPurge && retentionPurgeTables[rule.Entity] != "":
+		affected, err = r.purge(ctx, retentionPurgeTables[rule.Entity], cutoff, dryRun)
+	case rule.Action == domain.RetentionArchive && retentionArchiveStatements[rule.Entity] != "":
+		affected, err = r.archive(ctx, rule, cutoff, dryRun)
+	default:
+		err = fmt.Errorf("cannot %s %s records", rule.Action, rule.Entity)
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"rule_id":  rule.ID,
+			"affected": affected,
+		}).Error("Failed to apply retention rule in database")
+		return affected, err
+	}
+
+	return affected, nil
+}
+
+// purge deletes in batches until a batch comes back short, so each
+// statement only locks a bounded number of rows.
+func (r *PostgresRetentionRepository) purge(ctx context.Context, table string, cutoff time.Time, dryRun bool) (int64, error) {
+	db := r.db.WithContext(ctx)
+	if dryRun {
+		var count int64
+		err := db.Table(table).Where("created_at < ?", cutoff).Count(&count).Error
+		return count, err
+	}
+
+	var total int64
+	for {
+		result := db.Exec("DELETE FROM "+table+" WHERE id IN (SELECT id FROM "+table+" WHERE created_at < ? LIMIT ?)", cutoff, domain.RetentionBatchSize)
+		if result.Error != nil {
+			return total, result.Error
+		}
+		total += result.RowsAffected
+		if result.RowsAffected < domain.RetentionBatchSize {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
+
+// archive moves the rule's records into archived_records in batches, like
+// purge.
+func (r *PostgresRetentionRepository) archive(ctx context.Context, rule *domain.RetentionRule, cutoff time.Time, dryRun bool) (int64, error) {
+	db := r.db.WithContext(ctx)
+	if dryRun {
+		var count int64
+		err := db.Raw("SELECT COUNT(*) FROM ("+retentionArchivable[rule.Entity]+") archivable", cutoff).Scan(&count).Error
+		return count, err
+	}
+
+	var total int64
+	for {
+		result := db.Exec(retentionArchiveStatements[rule.Entity], cutoff, domain.RetentionBatchSize, rule.ID, r.clock.Now())
+		if result.Error != nil {
+			return total, result.Error
+		}
+		total += result.RowsAffected
+		if result.RowsAffected < domain.RetentionBatchSize {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
+
+// anonymizeUsers replaces the personal data of inactive accounts.
+func (r *PostgresRetentionRepository) anonymizeUsers(ctx context.Context, cutoff time.Time, dryRun bool) (int64, error) {
+	db := r.db.WithContext(ctx)
+	if dryRun {
+		var count int64
+		err := db.Model(&domain.User{}).Where(retentionInactiveUsers, domain.RoleAdmin, cutoff).Count(&count).Error
+		return count, err
+	}
+
+	var total int64
+	for {
+		now := r.clock.Now()
+		result := db.Exec("UPDATE users SET "+anonymizedUserColumns+
+			" WHERE id IN (SELECT id FROM users WHERE "+retentionInactiveUsers+" LIMIT ?)",
+			now, now, domain.RoleAdmin, cutoff, domain.RetentionBatchSize)
+		if result.Error != nil {
+			return total, result.Error
+		}
+		total += result.RowsAffected
+		if result.RowsAffected < domain.RetentionBatchSize {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
+
+func (r *PostgresRetentionRepository) CreateRun(ctx context.Context, run *domain.RetentionRun) error {
+	if err := r.db.WithContext(ctx).Create(run).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"rule_id": run.RuleID,
+		}).Error("Failed to record retention run in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresRetentionRepository) ListRuns(ctx context.Context, params domain.RetentionRunParams, pagination domain.Pagination) ([]domain.RetentionRun, error) {
+	db := r.db.WithContext(ctx)
+	if params.RuleID != nil {
+		db = db.Where("rule_id = ?", *params.RuleID)
+	}
+	if params.DryRun != nil {
+		db = db.Where("dry_run = ?", *params.DryRun)
+	}
+	db = db.Order("started_at DESC, id")
+
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	var runs []domain.RetentionRun
+	if err := db.Find(&runs).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list retention runs from database")
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+func (r *PostgresRetentionRepository) Metrics(ctx context.Context) ([]domain.RetentionMetric, error) {
+	var metrics []domain.RetentionMetric
+	err := r.db.WithContext(ctx).Model(&domain.RetentionRun{}).
+		Select("entity, action, COUNT(*) AS runs, COUNT(*) FILTER (WHERE error <> '') AS failed, " +
+			"COALESCE(SUM(affected), 0) AS affected, MAX(started_at) AS last_run_at").
+		Where("NOT dry_run").
+		Group("entity, action").
+		Order("entity, action").
+		Scan(&metrics).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to compute retention metrics in database")
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+func (r *PostgresRetentionRepository) ListArchived(ctx context.Context, params domain.ArchivedRecordParams, pagination domain.Pagination) ([]domain.ArchivedRecord, error) {
+	db := r.db.WithContext(ctx)
+	if params.Entity != "" {
+		db = db.Where("entity = ?", params.Entity)
+	}
+	if params.ProjectID != nil {
+		db = db.Where("project_id = ?", *params.ProjectID)
+	}
+	if params.ArchivedFrom != nil {
+		db = db.Where("archived_at >= ?", *params.ArchivedFrom)
+	}
+	if params.ArchivedTo != nil {
+		db = db.Where("archived_at <= ?", *params.ArchivedTo)
+	}
+	db = db.Order("archived_at DESC, record_id")
+
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	var records []domain.ArchivedRecord
+	if err := db.Find(&records).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"entity": params.Entity,
+		}).Error("Failed to list archived records from database")
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (r *PostgresRetentionRepository) GetArchived(ctx context.Context, entity domain.RetentionEntity, id uuid.UUID) (*domain.ArchivedRecord, error) {
+	return r.getArchived(r.db.WithContext(ctx), entity, id)
+}
+
+func (r *PostgresRetentionRepository) getArchived(db *gorm.DB, entity domain.RetentionEntity, id uuid.UUID) (*domain.ArchivedRecord, error) {
+	var record domain.ArchivedRecord
+	err := db.First(&record, "entity = ? AND record_id = ?", entity, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"entity":    entity,
+			"record_id": id,
+		}).Warn("Archived record not found in database")
+		return nil, domain.ErrArchivedRecordNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"entity":    entity,
+			"record_id": id,
+		}).Error("Failed to get archived record from database")
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// Restore rebuilds the rows from the payload with jsonb_populate_record, so
+// columns added to a table after the record was archived come back empty.
+func (r *PostgresRetentionRepository) Restore(ctx context.Context, entity domain.RetentionEntity, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"entity":    entity,
+		"record_id": id,
+	}).Debug("Restoring archived record in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		record, err := r.getArchived(tx.Clauses(clause.Locking{Strength: "UPDATE"}), entity, id)
+		if err != nil {
+			return err
+		}
+		payload := string(record.Payload)
+
+		if check, ok := archivedParents[entity]; ok {
+			var exists bool
+			if err := tx.Raw(check, payload).Scan(&exists).Error; err != nil {
+				return err
+			}
+			if !exists {
+				return domain.ErrArchivedRecordOrphaned
+			}
+		}
+
+		for i, restore := range archivedRestores[entity] {
+			key, table := restore[0], restore[1]
+			populate := "jsonb_populate_recordset(NULL::" + table + ", ?::jsonb -> ?)"
+			if i == 0 {
+				populate = "jsonb_populate_record(NULL::" + table + ", ?::jsonb -> ?)"
+			}
+			if err := tx.Exec("INSERT INTO "+table+" SELECT * FROM "+populate, payload, key).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&domain.ArchivedRecord{}, "entity = ? AND record_id = ?", entity, id).Error
+	})
+	if err != nil && !errors.Is(err, domain.ErrArchivedRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"entity":    entity,
+			"record_id": id,
+		}).Error("Failed to restore archived record in database")
+		return err
+	}
+
+	return err
+}
@@ -0,0 +1,68 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresRevokedTokenRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresRevokedTokenRepository(db *gorm.DB) *PostgresRevokedTokenRepository {
+	return &PostgresRevokedTokenRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresRevokedTokenRepository) Revoke(ctx context.Context, token *domain.RevokedToken) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":    token.UserID,
+		"expires_at": token.ExpiresAt,
+	}).Debug("Revoking access token in database")
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": token.UserID,
+		}).Error("Failed to revoke access token in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresRevokedTokenRepository) IsRevoked(ctx context.Context, tokenHash string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.RevokedToken{}).
+		Where("token_hash = ?", tokenHash).
+		Count(&count).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to check revoked access token in database")
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *PostgresRevokedTokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&domain.RevokedToken{})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": result.Error.Error(),
+		}).Error("Failed to delete expired revoked tokens from database")
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
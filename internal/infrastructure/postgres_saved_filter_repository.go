@@ -0,0 +1,173 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresSavedFilterRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+}
+
+func NewPostgresSavedFilterRepository(db *gorm.DB) *PostgresSavedFilterRepository {
+	return &PostgresSavedFilterRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+	}
+}
+
+func (r *PostgresSavedFilterRepository) WithClock(clock domain.Clock) *PostgresSavedFilterRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresSavedFilterRepository) Create(ctx context.Context, filter *domain.SavedFilter) error {
+	r.logger.WithFields(logrus.Fields{
+		"saved_filter_id": filter.ID,
+		"user_id":         filter.UserID,
+		"entity":          filter.Entity,
+	}).Debug("Creating saved filter in database")
+
+	if err := r.db.WithContext(ctx).Create(filter).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": filter.UserID,
+		}).Error("Failed to create saved filter in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"saved_filter_id": filter.ID,
+	}).Debug("Saved filter created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresSavedFilterRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.SavedFilter, error) {
+	r.logger.WithFields(logrus.Fields{
+		"saved_filter_id": id,
+	}).Debug("Getting saved filter by ID from database")
+
+	var filter domain.SavedFilter
+	err := r.db.WithContext(ctx).First(&filter, "id = ? AND deleted_at IS NULL", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"saved_filter_id": id,
+		}).Warn("Saved filter not found in database")
+		return nil, domain.ErrSavedFilterNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           err.Error(),
+			"saved_filter_id": id,
+		}).Error("Failed to get saved filter from database")
+		return nil, err
+	}
+
+	return &filter, nil
+}
+
+func (r *PostgresSavedFilterRepository) List(ctx context.Context, params domain.SavedFilterParams, pagination domain.Pagination) ([]domain.SavedFilter, error) {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": params.UserID,
+		"entity":  params.Entity,
+		"limit":   pagination.Limit,
+		"offset":  pagination.Offset,
+	}).Debug("Listing saved filters from database")
+
+	db := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL").
+		Where("user_id = ? OR shared", params.UserID)
+	if params.Entity != "" {
+		db = db.Where("entity = ?", params.Entity)
+	}
+	db = db.Order("entity, name, id")
+
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	var filters []domain.SavedFilter
+	if err := db.Find(&filters).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": params.UserID,
+		}).Error("Failed to list saved filters from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(filters),
+	}).Debug("Saved filters listed successfully from database")
+
+	return filters, nil
+}
+
+func (r *PostgresSavedFilterRepository) Update(ctx context.Context, filter *domain.SavedFilter) error {
+	r.logger.WithFields(logrus.Fields{
+		"saved_filter_id": filter.ID,
+	}).Debug("Updating saved filter in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.SavedFilter{}).
+		Where("id = ? AND deleted_at IS NULL", filter.ID).
+		Updates(map[string]interface{}{
+			"name":       filter.Name,
+			"query":      filter.Query,
+			"sort":       filter.Sort,
+			"shared":     filter.Shared,
+			"updated_at": filter.UpdatedAt,
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           result.Error.Error(),
+			"saved_filter_id": filter.ID,
+		}).Error("Failed to update saved filter in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSavedFilterNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"saved_filter_id": filter.ID,
+	}).Debug("Saved filter updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresSavedFilterRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"saved_filter_id": id,
+	}).Debug("Deleting saved filter in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.SavedFilter{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", r.clock.Now())
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":           result.Error.Error(),
+			"saved_filter_id": id,
+		}).Error("Failed to delete saved filter in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSavedFilterNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"saved_filter_id": id,
+	}).Debug("Saved filter deleted successfully in database")
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresStockAdjustmentRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+}
+
+func NewPostgresStockAdjustmentRepository(db *gorm.DB) *PostgresStockAdjustmentRepository {
+	return &PostgresStockAdjustmentRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+	}
+}
+
+func (r *PostgresStockAdjustmentRepository) WithClock(clock domain.Clock) *PostgresStockAdjustmentRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresStockAdjustmentRepository) Apply(ctx context.Context, adj *domain.StockAdjustment) error {
+	r.logger.WithFields(logrus.Fields{
+		"adjustment_id": adj.ID,
+		"product_id":    adj.ProductID,
+		"warehouse_id":  adj.WarehouseID,
+		"quantity":      adj.Quantity,
+		"reason":        adj.Reason,
+	}).Debug("Applying stock adjustment in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return applyStockAdjustment(tx, adj, r.clock.Now())
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": adj.ProductID,
+			"quantity":   adj.Quantity,
+		}).Error("Failed to apply stock adjustment in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"adjustment_id": adj.ID,
+		"product_id":    adj.ProductID,
+		"stock_before":  adj.StockBefore,
+		"stock_after":   adj.StockAfter,
+	}).Debug("Stock adjustment applied successfully in database")
+
+	return nil
+}
+
+func (r *PostgresStockAdjustmentRepository) ListByProduct(ctx context.Context, productID uuid.UUID, pagination domain.Pagination) ([]domain.StockAdjustment, error) {
+	r.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+		"limit":      pagination.Limit,
+		"offset":     pagination.Offset,
+		"sort":       pagination.Sort,
+	}).Debug("Listing stock adjustments from database")
+
+	var adjustments []domain.StockAdjustment
+	db := r.db.WithContext(ctx).Where("product_id = ?", productID)
+
+	if pagination.Sort != "" {
+		db = db.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&adjustments).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Error("Failed to list stock adjustments from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+		"count":      len(adjustments),
+	}).Debug("Stock adjustments listed successfully from database")
+
+	return adjustments, nil
+}
+
+func (r *PostgresStockAdjustmentRepository) ListMovements(ctx context.Context, productID uuid.UUID, filter domain.StockMovementParams, pagination domain.Pagination) ([]domain.StockMovement, error) {
+	r.logger.WithFields(logrus.Fields{
+		"product_id":          productID,
+		"filter_reason":       filter.Reason,
+		"filter_warehouse_id": filter.WarehouseID,
+		"limit":               pagination.Limit,
+		"offset":              pagination.Offset,
+		"sort":                pagination.Sort,
+	}).Debug("Listing stock movements from database")
+
+	db := r.db.WithContext(ctx)
+	adjustments := db.Model(&domain.StockAdjustment{}).
+		Select("id, product_id, reason, quantity, "+
+			"CASE WHEN quantity < 0 THEN warehouse_id END AS from_warehouse_id, "+
+			"CASE WHEN quantity > 0 THEN warehouse_id END AS to_warehouse_id, "+
+			"stock_before, stock_after, note, actor_id, created_at").
+		Where("product_id = ?", productID)
+	transfers := db.Model(&domain.StockTransfer{}).
+		Select("id, product_id, ?::text AS reason, quantity, from_warehouse_id, to_warehouse_id, "+
+			"NULL::integer AS stock_before, NULL::integer AS stock_after, note, actor_id, created_at", domain.StockReasonTransfer).
+		Where("product_id = ?", productID)
+
+	query := db.Table("(?) AS movements", db.Raw("? UNION ALL ?", adjustments, transfers))
+
+	if filter.Reason != "" {
+		query = query.Where("reason = ?", filter.Reason)
+	}
+
+	if filter.WarehouseID != nil {
+		query = query.Where("from_warehouse_id = ? OR to_warehouse_id = ?", *filter.WarehouseID, *filter.WarehouseID)
+	}
+
+	if pagination.Sort != "" {
+		query = query.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		query = query.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		query = query.Offset(pagination.Offset)
+	}
+
+	var movements []domain.StockMovement
+	if err := query.Find(&movements).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": productID,
+		}).Error("Failed to list stock movements from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"product_id": productID,
+		"count":      len(movements),
+	}).Debug("Stock movements listed successfully from database")
+
+	return movements, nil
+}
+
+// applyStockAdjustment locks the product row, adds adj to the product total
+// and, when adj names a warehouse, to that location's level, then inserts adj
+// with the resulting levels. It runs inside the caller's transaction so other
+// flows, such as receiving a purchase order, can book several entries at once.
+func applyStockAdjustment(tx *gorm.DB, adj *domain.StockAdjustment, now time.Time) error {
+	return bookStockAdjustment(tx, adj, now, false)
+}
+
+// returnStock is applyStockAdjustment for stock coming back from an order,
+// which is taken back even when the product was archived after the sale.
+func returnStock(tx *gorm.DB, adj *domain.StockAdjustment, now time.Time) error {
+	return bookStockAdjustment(tx, adj, now, true)
+}
+
+func bookStockAdjustment(tx *gorm.DB, adj *domain.StockAdjustment, now time.Time, allowArchived bool) error {
+	var product domain.Product
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&product, "id = ? AND deleted_at IS NULL", adj.ProductID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrProductDeleted
+	}
+	if err != nil {
+		return err
+	}
+
+	if product.ArchivedAt != nil && !allowArchived {
+		return domain.ErrProductArchived
+	}
+
+	adj.StockBefore = product.Stock
+	adj.StockAfter = product.Stock + adj.Quantity
+	if adj.StockAfter < 0 {
+		return domain.ErrInsufficientStock
+	}
+
+	if adj.WarehouseID != nil {
+		if err := moveWarehouseStock(tx, *adj.WarehouseID, product.ID, adj.Quantity, now); err != nil {
+			return err
+		}
+	} else if adj.Quantity < 0 {
+		allocated, err := allocatedStock(tx, product.ID)
+		if err != nil {
+			return err
+		}
+		if adj.StockAfter < allocated {
+			return domain.ErrInsufficientWarehouseStock
+		}
+	}
+
+	// The write is relative and guarded so it stays correct even for a
+	// writer that skipped the row lock: it never overwrites a concurrent
+	// change and never takes the stock below zero.
+	result := tx.Model(&domain.Product{}).
+		Where("id = ? AND stock + ? >= 0", product.ID, adj.Quantity).
+		Updates(map[string]interface{}{
+			"stock":      gorm.Expr("stock + ?", adj.Quantity),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInsufficientStock
+	}
+
+	return tx.Create(adj).Error
+}
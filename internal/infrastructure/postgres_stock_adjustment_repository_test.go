@@ -0,0 +1,154 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB connects to the database in TEST_DATABASE_URL and migrates it,
+// skipping the test when the variable is unset. The database is written to,
+// so it must not be one whose data matters.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, RunMigrations(db))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+// createTestProduct inserts a product with stock units and removes it, with
+// its adjustments, when the test ends.
+func createTestProduct(t *testing.T, db *gorm.DB, stock int) *domain.Product {
+	t.Helper()
+
+	product := &domain.Product{
+		ID:    uuid.New(),
+		Name:  "Concurrency test product",
+		Price: 10,
+		Stock: stock,
+		SKU:   domain.SKU("TEST-" + uuid.NewString()[:8]),
+	}
+	require.NoError(t, db.Create(product).Error)
+	t.Cleanup(func() {
+		db.Where("product_id = ?", product.ID).Delete(&domain.StockAdjustment{})
+		db.Delete(&domain.Product{}, "id = ?", product.ID)
+	})
+	return product
+}
+
+// applyConcurrently applies one adjustment per quantity, all at once, and
+// returns the adjustments that were booked. Any error other than running out
+// of stock fails the test.
+func applyConcurrently(t *testing.T, repo *PostgresStockAdjustmentRepository, productID uuid.UUID, quantities []int) []*domain.StockAdjustment {
+	t.Helper()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		applied []*domain.StockAdjustment
+		start   = make(chan struct{})
+	)
+	for _, quantity := range quantities {
+		reason := domain.StockReasonReturn
+		if quantity < 0 {
+			reason = domain.StockReasonSale
+		}
+		adj := &domain.StockAdjustment{
+			ID:        uuid.New(),
+			ProductID: productID,
+			Quantity:  quantity,
+			Reason:    reason,
+			ActorID:   uuid.New(),
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			err := repo.Apply(context.Background(), adj)
+			if errors.Is(err, domain.ErrInsufficientStock) {
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			applied = append(applied, adj)
+			mu.Unlock()
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return applied
+}
+
+func TestApplyStockAdjustmentConcurrentSalesNeverOversell(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPostgresStockAdjustmentRepository(db)
+	product := createTestProduct(t, db, 5)
+
+	quantities := make([]int, 20)
+	for i := range quantities {
+		quantities[i] = -1
+	}
+	applied := applyConcurrently(t, repo, product.ID, quantities)
+
+	assert.Len(t, applied, 5, "only the units in stock can be sold")
+	for _, adj := range applied {
+		assert.GreaterOrEqual(t, adj.StockAfter, 0)
+	}
+
+	var stored domain.Product
+	require.NoError(t, db.First(&stored, "id = ?", product.ID).Error)
+	assert.Equal(t, 0, stored.Stock)
+}
+
+func TestApplyStockAdjustmentConcurrentMixedKeepsEveryChange(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPostgresStockAdjustmentRepository(db)
+	product := createTestProduct(t, db, 3)
+
+	var quantities []int
+	for i := 0; i < 15; i++ {
+		quantities = append(quantities, -2, 1)
+	}
+	applied := applyConcurrently(t, repo, product.ID, quantities)
+
+	want := product.Stock
+	for _, adj := range applied {
+		assert.GreaterOrEqual(t, adj.StockAfter, 0)
+		assert.Equal(t, adj.StockBefore+adj.Quantity, adj.StockAfter)
+		want += adj.Quantity
+	}
+
+	var stored domain.Product
+	require.NoError(t, db.First(&stored, "id = ?", product.ID).Error)
+	assert.Equal(t, want, stored.Stock, "every booked adjustment must reach the product")
+
+	var logged int64
+	require.NoError(t, db.Model(&domain.StockAdjustment{}).Where("product_id = ?", product.ID).Count(&logged).Error)
+	assert.Equal(t, int64(len(applied)), logged)
+}
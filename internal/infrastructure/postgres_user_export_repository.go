@@ -0,0 +1,229 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresUserExportRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresUserExportRepository(db *gorm.DB) *PostgresUserExportRepository {
+	return &PostgresUserExportRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresUserExportRepository) Create(ctx context.Context, export *domain.UserExport) error {
+	r.logger.WithFields(logrus.Fields{
+		"export_id": export.ID,
+		"user_id":   export.UserID,
+	}).Debug("Creating user export in database")
+
+	if err := r.db.WithContext(ctx).Create(export).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"export_id": export.ID,
+			"user_id":   export.UserID,
+		}).Error("Failed to create user export in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresUserExportRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.UserExport, error) {
+	r.logger.WithFields(logrus.Fields{
+		"export_id": id,
+	}).Debug("Getting user export by ID from database")
+
+	var export domain.UserExport
+	err := r.db.WithContext(ctx).First(&export, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"export_id": id,
+		}).Warn("User export not found in database")
+		return nil, domain.ErrUserExportNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"export_id": id,
+		}).Error("Failed to get user export from database")
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+func (r *PostgresUserExportRepository) List(ctx context.Context, userID uuid.UUID, pagination domain.Pagination) ([]domain.UserExport, error) {
+	// The archives themselves are left out of listings.
+	db := r.db.WithContext(ctx).Omit("content").
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id")
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	var exports []domain.UserExport
+	if err := db.Find(&exports).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to list user exports from database")
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+func (r *PostgresUserExportRepository) HasPending(ctx context.Context, userID uuid.UUID, since time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.UserExport{}).
+		Where("user_id = ? AND status = ? AND created_at > ?", userID, domain.UserExportStatusPending, since).
+		Count(&count).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to check pending user exports in database")
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *PostgresUserExportRepository) Complete(ctx context.Context, id uuid.UUID, content []byte, completedAt, expiresAt time.Time) error {
+	return r.finish(ctx, id, map[string]interface{}{
+		"status":       domain.UserExportStatusReady,
+		"content":      content,
+		"size":         len(content),
+		"completed_at": completedAt,
+		"expires_at":   expiresAt,
+	})
+}
+
+func (r *PostgresUserExportRepository) Fail(ctx context.Context, id uuid.UUID, reason string, completedAt time.Time) error {
+	return r.finish(ctx, id, map[string]interface{}{
+		"status":       domain.UserExportStatusFailed,
+		"error":        reason,
+		"completed_at": completedAt,
+	})
+}
+
+func (r *PostgresUserExportRepository) finish(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	r.logger.WithFields(logrus.Fields{
+		"export_id": id,
+		"status":    updates["status"],
+	}).Debug("Finishing user export in database")
+
+	err := r.db.WithContext(ctx).Model(&domain.UserExport{}).
+		Where("id = ? AND status = ?", id, domain.UserExportStatusPending).
+		Updates(updates).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"export_id": id,
+		}).Error("Failed to finish user export in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresUserExportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&domain.UserExport{})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": result.Error.Error(),
+		}).Error("Failed to delete expired user exports in database")
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *PostgresUserExportRepository) CollectUserData(ctx context.Context, userID uuid.UUID) (*domain.UserData, error) {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": userID,
+	}).Debug("Collecting user data from database")
+
+	db := r.db.WithContext(ctx)
+	data := &domain.UserData{}
+
+	if err := db.First(&data.Profile, "id = ?", userID).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": userID,
+		}).Error("Failed to load user profile from database")
+		return nil, err
+	}
+
+	queries := []struct {
+		name string
+		run  func() error
+	}{
+		{"projects", func() error {
+			return db.Where("owner_id = ? AND deleted_at IS NULL", userID).Order("created_at").Find(&data.Projects).Error
+		}},
+		{"project_items", func() error {
+			return db.Where("assigned_to = ? AND deleted_at IS NULL", userID).Order("created_at").Find(&data.ProjectItems).Error
+		}},
+		{"assignments", func() error {
+			return db.Where("user_id = ?", userID).Order("assigned_at").Find(&data.Assignments).Error
+		}},
+		{"expenses", func() error {
+			return db.Where("created_by = ? AND deleted_at IS NULL", userID).Order("date, created_at").Find(&data.Expenses).Error
+		}},
+		{"stock_adjustments", func() error {
+			return db.Where("actor_id = ?", userID).Order("created_at").Find(&data.StockAdjustments).Error
+		}},
+		{"purchase_orders", func() error {
+			return db.Preload("Lines").
+				Where("(created_by = ? OR received_by = ?) AND deleted_at IS NULL", userID, userID).
+				Order("created_at").Find(&data.PurchaseOrders).Error
+		}},
+		{"orders", func() error {
+			return db.Preload("Items").Where("user_id = ? AND deleted_at IS NULL", userID).Order("created_at").Find(&data.Orders).Error
+		}},
+		{"watches", func() error {
+			return db.Where("user_id = ?", userID).Order("created_at").Find(&data.Watches).Error
+		}},
+		{"notifications", func() error {
+			return db.Where("user_id = ?", userID).Order("created_at").Find(&data.Notifications).Error
+		}},
+		{"devices", func() error {
+			return db.Where("user_id = ?", userID).Order("created_at").Find(&data.Devices).Error
+		}},
+		{"saved_filters", func() error {
+			return db.Where("user_id = ? AND deleted_at IS NULL", userID).Order("created_at").Find(&data.SavedFilters).Error
+		}},
+		{"report_subscriptions", func() error {
+			return db.Where("user_id = ? AND deleted_at IS NULL", userID).Order("created_at").Find(&data.ReportSubscriptions).Error
+		}},
+	}
+	for _, query := range queries {
+		if err := query.run(); err != nil {
+			r.logger.WithFields(logrus.Fields{
+				"error":   err.Error(),
+				"user_id": userID,
+				"records": query.name,
+			}).Error("Failed to collect user data from database")
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
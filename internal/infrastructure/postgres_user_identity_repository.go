@@ -0,0 +1,57 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PostgresUserIdentityRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresUserIdentityRepository(db *gorm.DB) *PostgresUserIdentityRepository {
+	return &PostgresUserIdentityRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+func (r *PostgresUserIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":  identity.UserID,
+		"provider": identity.Provider,
+	}).Debug("Creating user identity in database")
+
+	if err := r.db.WithContext(ctx).Create(identity).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"user_id":  identity.UserID,
+			"provider": identity.Provider,
+		}).Error("Failed to create user identity in database")
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresUserIdentityRepository) GetBySubject(ctx context.Context, provider domain.OAuthProvider, subject string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+	err := r.db.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).Take(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrUserIdentityNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"provider": provider,
+		}).Error("Failed to get user identity from database")
+		return nil, err
+	}
+
+	return &identity, nil
+}
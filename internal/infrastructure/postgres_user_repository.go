@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/edumes/golang-api-rest/internal/domain"
@@ -13,15 +14,22 @@ import (
 type PostgresUserRepository struct {
 	db     *gorm.DB
 	logger *logrus.Logger
+	clock  domain.Clock
 }
 
 func NewPostgresUserRepository(db *gorm.DB) *PostgresUserRepository {
 	return &PostgresUserRepository{
 		db:     db,
-		logger: logrus.New(),
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
 	}
 }
 
+func (r *PostgresUserRepository) WithClock(clock domain.Clock) *PostgresUserRepository {
+	r.clock = clock
+	return r
+}
+
 func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User) error {
 	r.logger.WithFields(logrus.Fields{
 		"user_id": user.ID,
@@ -29,7 +37,26 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User)
 		"name":    user.Name,
 	}).Debug("Creating user in database")
 
-	err := r.db.WithContext(ctx).Create(user).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialising sign-ups per email lets the count report a clash as
+		// ErrEmailTaken before the unique index trips.
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "user:"+string(user.Email)).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&domain.User{}).Where("email = ?", user.Email).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return domain.ErrEmailTaken
+		}
+
+		return tx.Create(user).Error
+	})
+	if errors.Is(err, domain.ErrEmailTaken) {
+		return err
+	}
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":   err.Error(),
@@ -70,18 +97,104 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*do
 	return &user, nil
 }
 
+func (r *PostgresUserRepository) Suggest(ctx context.Context, query string, now time.Time, limit int) ([]domain.Suggestion, error) {
+	r.logger.WithFields(logrus.Fields{
+		"query": query,
+		"limit": limit,
+	}).Debug("Suggesting users from database")
+
+	pattern := likeEscaper.Replace(query)
+	suggestions := []domain.Suggestion{}
+	err := r.db.WithContext(ctx).Model(&domain.User{}).
+		Select("id, name AS label").
+		Where("deleted_at IS NULL AND active AND (suspended_until IS NULL OR suspended_until <= ?)", now).
+		Where("name ILIKE ? OR email ILIKE ?", "%"+pattern+"%", pattern+"%").
+		Order(suggestionOrder("name", query)).
+		Limit(limit).
+		Scan(&suggestions).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"query": query,
+		}).Error("Failed to suggest users from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"query": query,
+		"count": len(suggestions),
+	}).Debug("Users suggested successfully from database")
+
+	return suggestions, nil
+}
+
 func (r *PostgresUserRepository) List(ctx context.Context, filter domain.Params, pagination domain.Pagination) ([]domain.User, error) {
 	r.logger.WithFields(logrus.Fields{
 		"filter_name":  filter.Name,
 		"filter_email": filter.Email,
+		"status":       filter.Status,
 		"limit":        pagination.Limit,
 		"offset":       pagination.Offset,
 		"sort":         pagination.Sort,
 	}).Debug("Listing users from database with filters")
 
 	var users []domain.User
-	db := r.db.WithContext(ctx).Model(&domain.User{})
+	db := r.applyFilter(r.db.WithContext(ctx).Model(&domain.User{}), filter)
+
+	if pagination.AfterID != nil {
+		db = db.Where("id > ?", *pagination.AfterID)
+	}
+
+	if pagination.Sort != "" {
+		r.logger.WithFields(logrus.Fields{
+			"sort": pagination.Sort,
+		}).Debug("Applying sort")
+		db = db.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"limit": pagination.Limit,
+		}).Debug("Applying limit")
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"offset": pagination.Offset,
+		}).Debug("Applying offset")
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&users).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list users from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(users),
+	}).Debug("Users listed successfully from database")
 
+	return users, nil
+}
+
+func (r *PostgresUserRepository) Count(ctx context.Context, filter domain.Params) (int64, error) {
+	var total int64
+	err := r.applyFilter(r.db.WithContext(ctx).Model(&domain.User{}), filter).
+		Count(&total).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to count users in database")
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *PostgresUserRepository) applyFilter(db *gorm.DB, filter domain.Params) *gorm.DB {
 	if filter.Name != "" {
 		r.logger.WithFields(logrus.Fields{
 			"filter_name": filter.Name,
@@ -96,6 +209,13 @@ func (r *PostgresUserRepository) List(ctx context.Context, filter domain.Params,
 		db = db.Where("email = ?", filter.Email)
 	}
 
+	if filter.Role != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_role": filter.Role,
+		}).Debug("Applying role filter")
+		db = db.Where("role = ?", filter.Role)
+	}
+
 	if filter.CreatedAtFrom != nil {
 		r.logger.WithFields(logrus.Fields{
 			"created_at_from": filter.CreatedAtFrom,
@@ -110,51 +230,66 @@ func (r *PostgresUserRepository) List(ctx context.Context, filter domain.Params,
 		db = db.Where("created_at <= ?", *filter.CreatedAtTo)
 	}
 
-	db = db.Where("deleted_at IS NULL")
-
-	if pagination.Sort != "" {
+	if filter.LastSeenBefore != nil {
 		r.logger.WithFields(logrus.Fields{
-			"sort": pagination.Sort,
-		}).Debug("Applying sort")
-		db = db.Order(pagination.Sort)
+			"last_seen_before": filter.LastSeenBefore,
+		}).Debug("Applying last_seen_before filter")
+		db = db.Where("COALESCE(last_login_at, created_at) < ?", *filter.LastSeenBefore)
 	}
 
-	if pagination.Limit > 0 {
+	if filter.LastLoginFrom != nil {
 		r.logger.WithFields(logrus.Fields{
-			"limit": pagination.Limit,
-		}).Debug("Applying limit")
-		db = db.Limit(pagination.Limit)
+			"last_login_from": filter.LastLoginFrom,
+		}).Debug("Applying last_login_from filter")
+		db = db.Where("last_login_at >= ?", *filter.LastLoginFrom)
 	}
 
-	if pagination.Offset > 0 {
+	if filter.LastLoginTo != nil {
 		r.logger.WithFields(logrus.Fields{
-			"offset": pagination.Offset,
-		}).Debug("Applying offset")
-		db = db.Offset(pagination.Offset)
+			"last_login_to": filter.LastLoginTo,
+		}).Debug("Applying last_login_to filter")
+		db = db.Where("last_login_at <= ?", *filter.LastLoginTo)
 	}
 
-	if err := db.Find(&users).Error; err != nil {
-		r.logger.WithFields(logrus.Fields{
-			"error": err.Error(),
-		}).Error("Failed to list users from database")
-		return nil, err
+	if filter.ActiveOnly {
+		r.logger.Debug("Excluding deactivated and suspended users")
+		db = db.Where("active = ? AND (suspended_until IS NULL OR suspended_until <= ?)", true, r.clock.Now())
 	}
 
-	r.logger.WithFields(logrus.Fields{
-		"count": len(users),
-	}).Debug("Users listed successfully from database")
+	switch filter.Status {
+	case domain.UserStatusActive:
+		db = db.Where("active = ? AND (suspended_until IS NULL OR suspended_until <= ?)", true, r.clock.Now())
+	case domain.UserStatusSuspended:
+		db = db.Where("active = ? AND suspended_until > ?", true, r.clock.Now())
+	case domain.UserStatusDeactivated:
+		db = db.Where("active = ?", false)
+	case domain.UserStatusDeleted:
+		db = db.Where("deleted_at IS NOT NULL")
+	}
 
-	return users, nil
+	if !filter.IncludeDeleted && filter.Status != domain.UserStatusDeleted {
+		db = db.Where("deleted_at IS NULL")
+	}
+
+	return db
 }
 
-func (r *PostgresUserRepository) Update(ctx context.Context, user *domain.User) error {
+func (r *PostgresUserRepository) Update(ctx context.Context, user *domain.User, fields ...string) error {
 	r.logger.WithFields(logrus.Fields{
 		"user_id": user.ID,
 		"email":   user.Email,
 		"name":    user.Name,
 	}).Debug("Updating user in database")
 
-	err := r.db.WithContext(ctx).Model(user).Updates(user).Error
+	// Account status only changes through SetStatus, login tracking through
+	// RecordLogin, the password through SetPassword, two-factor settings
+	// through SetMFA, lockouts through SetLockedUntil and deletion requests
+	// through RequestDeletion, Restore and Erase.
+	err := updateVersioned(r.db.WithContext(ctx), user, user.ID, &user.Version, func(db *gorm.DB) *gorm.DB {
+		return selectFields(db, fields).
+			Omit("active", "suspended_until", "last_login_at", "login_count", "password_hash", "password_changed_at", "erase_after", "anonymized_at", "mfa_enabled", "mfa_secret", "locked_until").
+			Updates(user)
+	})
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":   err.Error(),
@@ -176,7 +311,7 @@ func (r *PostgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error
 		"user_id": id,
 	}).Debug("Soft deleting user in database")
 
-	err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", id).Update("deleted_at", time.Now()).Error
+	err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", id).Update("deleted_at", r.clock.Now()).Error
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"error":   err.Error(),
@@ -191,3 +326,319 @@ func (r *PostgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error
 
 	return nil
 }
+
+// SetStatus writes both status columns explicitly, since Update skips false
+// and nil values.
+func (r *PostgresUserRepository) SetStatus(ctx context.Context, id uuid.UUID, active bool, suspendedUntil *time.Time) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":         id,
+		"active":          active,
+		"suspended_until": suspendedUntil,
+	}).Debug("Updating user status in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"active":          active,
+			"suspended_until": suspendedUntil,
+			"updated_at":      r.clock.Now(),
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error.Error(),
+			"user_id": id,
+		}).Error("Failed to update user status in database")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithFields(logrus.Fields{
+			"user_id": id,
+		}).Warn("User not found for status update")
+		return gorm.ErrRecordNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"user_id": id,
+		"active":  active,
+	}).Debug("User status updated successfully in database")
+
+	return nil
+}
+
+// SetLockedUntil writes locked_until explicitly, since Update skips nil
+// values.
+func (r *PostgresUserRepository) SetLockedUntil(ctx context.Context, id uuid.UUID, lockedUntil *time.Time) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":      id,
+		"locked_until": lockedUntil,
+	}).Debug("Updating user lockout in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"locked_until": lockedUntil,
+			"updated_at":   r.clock.Now(),
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error.Error(),
+			"user_id": id,
+		}).Error("Failed to update user lockout in database")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithFields(logrus.Fields{
+			"user_id": id,
+		}).Warn("User not found for lockout update")
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresUserRepository) RecordLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Debug("Recording user login in database")
+
+	err := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_login_at": at,
+			"login_count":   gorm.Expr("login_count + 1"),
+		}).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": id,
+		}).Error("Failed to record user login in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Debug("User login recorded successfully in database")
+
+	return nil
+}
+
+func (r *PostgresUserRepository) SetPassword(ctx context.Context, id uuid.UUID, passwordHash string, changedAt time.Time) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Debug("Updating user password in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"password_hash":       passwordHash,
+			"password_changed_at": changedAt,
+			"updated_at":          changedAt,
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error.Error(),
+			"user_id": id,
+		}).Error("Failed to update user password in database")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithFields(logrus.Fields{
+			"user_id": id,
+		}).Warn("User not found for password update")
+		return gorm.ErrRecordNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Debug("User password updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresUserRepository) SetMFA(ctx context.Context, id uuid.UUID, secret string, enabled bool, at time.Time) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":     id,
+		"mfa_enabled": enabled,
+	}).Debug("Updating user MFA in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"mfa_secret":  secret,
+			"mfa_enabled": enabled,
+			"updated_at":  at,
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error.Error(),
+			"user_id": id,
+		}).Error("Failed to update user MFA in database")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		r.logger.WithFields(logrus.Fields{
+			"user_id": id,
+		}).Warn("User not found for MFA update")
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresUserRepository) GetAccount(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"user_id": id,
+		}).Warn("Account not found in database")
+		return nil, domain.ErrAccountNotFound
+	}
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": id,
+		}).Error("Failed to get account from database")
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *PostgresUserRepository) GetDeletedByEmail(ctx context.Context, email string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).
+		First(&user, "email = ? AND deleted_at IS NOT NULL AND anonymized_at IS NULL", email).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"email": email,
+		}).Warn("Deleted account not found in database")
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *PostgresUserRepository) RequestDeletion(ctx context.Context, id uuid.UUID, deletedAt, eraseAfter time.Time) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":     id,
+		"erase_after": eraseAfter,
+	}).Debug("Requesting user deletion in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at":  deletedAt,
+			"erase_after": eraseAfter,
+			"updated_at":  deletedAt,
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error.Error(),
+			"user_id": id,
+		}).Error("Failed to request user deletion in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresUserRepository) Restore(ctx context.Context, id uuid.UUID, now time.Time) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Debug("Restoring deleted user in database")
+
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL AND anonymized_at IS NULL AND erase_after > ?", id, now).
+		Updates(map[string]interface{}{
+			"deleted_at":  nil,
+			"erase_after": nil,
+			"updated_at":  now,
+		})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error.Error(),
+			"user_id": id,
+		}).Error("Failed to restore user in database")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAccountNotRestorable
+	}
+
+	return nil
+}
+
+func (r *PostgresUserRepository) ListErasable(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("deleted_at IS NOT NULL AND anonymized_at IS NULL AND erase_after <= ?", now).
+		Order("erase_after").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list erasable users from database")
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// userPrivateRecords deletes what only the user could see. Records shared
+// with others, like projects, items, assignments and expenses, stay and keep
+// pointing at the anonymized account.
+var userPrivateRecords = []string{
+	"DELETE FROM report_deliveries WHERE subscription_id IN (SELECT id FROM report_subscriptions WHERE user_id = ?)",
+	"DELETE FROM report_subscriptions WHERE user_id = ?",
+	"DELETE FROM saved_filters WHERE user_id = ?",
+	"DELETE FROM watches WHERE user_id = ?",
+	"DELETE FROM notifications WHERE user_id = ?",
+	"DELETE FROM devices WHERE user_id = ?",
+	"DELETE FROM notification_preferences WHERE user_id = ?",
+	"DELETE FROM user_exports WHERE user_id = ?",
+	"DELETE FROM calendar_feeds WHERE user_id = ?",
+	"DELETE FROM user_identities WHERE user_id = ?",
+	"DELETE FROM failed_logins WHERE user_id = ?",
+}
+
+func (r *PostgresUserRepository) Erase(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id": id,
+	}).Debug("Erasing user personal data in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("UPDATE users SET "+anonymizedUserColumns+" WHERE id = ? AND anonymized_at IS NULL", at, at, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		for _, statement := range userPrivateRecords {
+			if err := tx.Exec(statement, id).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"user_id": id,
+		}).Error("Failed to erase user in database")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,332 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresWarehouseRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+	clock  domain.Clock
+}
+
+func NewPostgresWarehouseRepository(db *gorm.DB) *PostgresWarehouseRepository {
+	return &PostgresWarehouseRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+		clock:  domain.SystemClock{},
+	}
+}
+
+func (r *PostgresWarehouseRepository) WithClock(clock domain.Clock) *PostgresWarehouseRepository {
+	r.clock = clock
+	return r
+}
+
+func (r *PostgresWarehouseRepository) Create(ctx context.Context, warehouse *domain.Warehouse) error {
+	r.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+		"code":         warehouse.Code,
+		"name":         warehouse.Name,
+	}).Debug("Creating warehouse in database")
+
+	err := r.db.WithContext(ctx).Create(warehouse).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": warehouse.ID,
+			"code":         warehouse.Code,
+		}).Error("Failed to create warehouse in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+		"code":         warehouse.Code,
+	}).Debug("Warehouse created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresWarehouseRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Warehouse, error) {
+	r.logger.WithFields(logrus.Fields{
+		"warehouse_id": id,
+	}).Debug("Getting warehouse by ID from database")
+
+	var warehouse domain.Warehouse
+	err := r.db.WithContext(ctx).First(&warehouse, "id = ? AND deleted_at IS NULL", id).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+		}).Warn("Warehouse not found in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+		"code":         warehouse.Code,
+	}).Debug("Warehouse retrieved successfully from database")
+
+	return &warehouse, nil
+}
+
+func (r *PostgresWarehouseRepository) GetByCode(ctx context.Context, code string) (*domain.Warehouse, error) {
+	r.logger.WithFields(logrus.Fields{
+		"code": code,
+	}).Debug("Getting warehouse by code from database")
+
+	var warehouse domain.Warehouse
+	err := r.db.WithContext(ctx).First(&warehouse, "code = ? AND deleted_at IS NULL", code).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"code":  code,
+		}).Warn("Warehouse not found by code in database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+		"code":         warehouse.Code,
+	}).Debug("Warehouse retrieved successfully by code from database")
+
+	return &warehouse, nil
+}
+
+func (r *PostgresWarehouseRepository) List(ctx context.Context, filter domain.WarehouseParams, pagination domain.Pagination) ([]domain.Warehouse, error) {
+	r.logger.WithFields(logrus.Fields{
+		"filter_code": filter.Code,
+		"filter_name": filter.Name,
+		"limit":       pagination.Limit,
+		"offset":      pagination.Offset,
+		"sort":        pagination.Sort,
+	}).Debug("Listing warehouses from database with filters")
+
+	var warehouses []domain.Warehouse
+	db := r.db.WithContext(ctx).Model(&domain.Warehouse{})
+
+	if filter.Code != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_code": filter.Code,
+		}).Debug("Applying code filter")
+		db = db.Where("code ILIKE ?", "%"+filter.Code+"%")
+	}
+
+	if filter.Name != "" {
+		r.logger.WithFields(logrus.Fields{
+			"filter_name": filter.Name,
+		}).Debug("Applying name filter")
+		db = db.Where("name ILIKE ?", "%"+filter.Name+"%")
+	}
+
+	db = db.Where("deleted_at IS NULL")
+
+	if pagination.Sort != "" {
+		r.logger.WithFields(logrus.Fields{
+			"sort": pagination.Sort,
+		}).Debug("Applying sort")
+		db = db.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"limit": pagination.Limit,
+		}).Debug("Applying limit")
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		r.logger.WithFields(logrus.Fields{
+			"offset": pagination.Offset,
+		}).Debug("Applying offset")
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&warehouses).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to list warehouses from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"count": len(warehouses),
+	}).Debug("Warehouses listed successfully from database")
+
+	return warehouses, nil
+}
+
+func (r *PostgresWarehouseRepository) Update(ctx context.Context, warehouse *domain.Warehouse) error {
+	r.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+		"code":         warehouse.Code,
+	}).Debug("Updating warehouse in database")
+
+	err := r.db.WithContext(ctx).Model(warehouse).Updates(warehouse).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": warehouse.ID,
+		}).Error("Failed to update warehouse in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouse.ID,
+		"code":         warehouse.Code,
+	}).Debug("Warehouse updated successfully in database")
+
+	return nil
+}
+
+func (r *PostgresWarehouseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"warehouse_id": id,
+	}).Debug("Soft deleting warehouse in database")
+
+	err := r.db.WithContext(ctx).Model(&domain.Warehouse{}).Where("id = ?", id).Update("deleted_at", r.clock.Now()).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": id,
+		}).Error("Failed to soft delete warehouse in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"warehouse_id": id,
+	}).Debug("Warehouse soft deleted successfully in database")
+
+	return nil
+}
+
+func (r *PostgresWarehouseRepository) ListStock(ctx context.Context, warehouseID uuid.UUID, pagination domain.Pagination) ([]domain.WarehouseStock, error) {
+	r.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouseID,
+		"limit":        pagination.Limit,
+		"offset":       pagination.Offset,
+		"sort":         pagination.Sort,
+	}).Debug("Listing warehouse stock from database")
+
+	var stock []domain.WarehouseStock
+	db := r.db.WithContext(ctx).Where("warehouse_id = ? AND quantity <> 0", warehouseID)
+
+	if pagination.Sort != "" {
+		db = db.Order(pagination.Sort)
+	}
+
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&stock).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":        err.Error(),
+			"warehouse_id": warehouseID,
+		}).Error("Failed to list warehouse stock from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"warehouse_id": warehouseID,
+		"count":        len(stock),
+	}).Debug("Warehouse stock listed successfully from database")
+
+	return stock, nil
+}
+
+func (r *PostgresWarehouseRepository) Transfer(ctx context.Context, transfer *domain.StockTransfer) error {
+	r.logger.WithFields(logrus.Fields{
+		"transfer_id":       transfer.ID,
+		"product_id":        transfer.ProductID,
+		"from_warehouse_id": transfer.FromWarehouseID,
+		"to_warehouse_id":   transfer.ToWarehouseID,
+		"quantity":          transfer.Quantity,
+	}).Debug("Transferring stock in database")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var product domain.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&product, "id = ? AND deleted_at IS NULL", transfer.ProductID).Error; err != nil {
+			return err
+		}
+
+		now := r.clock.Now()
+		if transfer.FromWarehouseID == nil {
+			allocated, err := allocatedStock(tx, product.ID)
+			if err != nil {
+				return err
+			}
+			if product.Stock-allocated < transfer.Quantity {
+				return domain.ErrInsufficientWarehouseStock
+			}
+		} else if err := moveWarehouseStock(tx, *transfer.FromWarehouseID, product.ID, -transfer.Quantity, now); err != nil {
+			return err
+		}
+
+		if transfer.ToWarehouseID != nil {
+			if err := moveWarehouseStock(tx, *transfer.ToWarehouseID, product.ID, transfer.Quantity, now); err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(transfer).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"product_id": transfer.ProductID,
+			"quantity":   transfer.Quantity,
+		}).Error("Failed to transfer stock in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"transfer_id": transfer.ID,
+		"product_id":  transfer.ProductID,
+	}).Debug("Stock transferred successfully in database")
+
+	return nil
+}
+
+// allocatedStock sums the quantity of a product held across all warehouses.
+// Callers must hold the product row lock so the sum cannot change underneath.
+func allocatedStock(tx *gorm.DB, productID uuid.UUID) (int, error) {
+	var allocated int
+	err := tx.Model(&domain.WarehouseStock{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("product_id = ?", productID).
+		Scan(&allocated).Error
+	return allocated, err
+}
+
+// moveWarehouseStock adds delta to a product's level at a warehouse, creating
+// the row on first use, and refuses to take the level below zero.
+func moveWarehouseStock(tx *gorm.DB, warehouseID, productID uuid.UUID, delta int, now time.Time) error {
+	var level domain.WarehouseStock
+	err := tx.Raw(
+		"INSERT INTO warehouse_stocks (warehouse_id, product_id, quantity, updated_at) VALUES (?, ?, ?, ?) "+
+			"ON CONFLICT (warehouse_id, product_id) DO UPDATE SET quantity = warehouse_stocks.quantity + EXCLUDED.quantity, updated_at = EXCLUDED.updated_at "+
+			"RETURNING warehouse_id, product_id, quantity, updated_at",
+		warehouseID, productID, delta, now,
+	).Scan(&level).Error
+	if err != nil {
+		return err
+	}
+	if level.Quantity < 0 {
+		return domain.ErrInsufficientWarehouseStock
+	}
+	return nil
+}
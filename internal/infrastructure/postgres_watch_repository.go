@@ -0,0 +1,151 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresWatchRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewPostgresWatchRepository(db *gorm.DB) *PostgresWatchRepository {
+	return &PostgresWatchRepository{
+		db:     db,
+		logger: WithRedaction(logrus.New()),
+	}
+}
+
+// Watch is idempotent: watching something twice keeps the original watch.
+func (r *PostgresWatchRepository) Watch(ctx context.Context, watch *domain.Watch) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":     watch.UserID,
+		"target_type": watch.TargetType,
+		"target_id":   watch.TargetID,
+	}).Debug("Creating watch in database")
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(watch).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"user_id":     watch.UserID,
+			"target_type": watch.TargetType,
+			"target_id":   watch.TargetID,
+		}).Error("Failed to create watch in database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"user_id":     watch.UserID,
+		"target_type": watch.TargetType,
+		"target_id":   watch.TargetID,
+	}).Debug("Watch created successfully in database")
+
+	return nil
+}
+
+func (r *PostgresWatchRepository) Unwatch(ctx context.Context, userID uuid.UUID, targetType string, targetID uuid.UUID) error {
+	r.logger.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"target_type": targetType,
+		"target_id":   targetID,
+	}).Debug("Deleting watch from database")
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).
+		Delete(&domain.Watch{}).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"user_id":     userID,
+			"target_type": targetType,
+			"target_id":   targetID,
+		}).Error("Failed to delete watch from database")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"target_type": targetType,
+		"target_id":   targetID,
+	}).Debug("Watch deleted successfully from database")
+
+	return nil
+}
+
+func (r *PostgresWatchRepository) ListWatchers(ctx context.Context, targetType string, targetID uuid.UUID, pagination domain.Pagination) ([]domain.Watch, error) {
+	r.logger.WithFields(logrus.Fields{
+		"target_type": targetType,
+		"target_id":   targetID,
+		"limit":       pagination.Limit,
+		"offset":      pagination.Offset,
+	}).Debug("Listing watchers from database")
+
+	var watches []domain.Watch
+	db := r.db.WithContext(ctx).Where("target_type = ? AND target_id = ?", targetType, targetID).Order("created_at, user_id")
+
+	if pagination.Limit > 0 {
+		db = db.Limit(pagination.Limit)
+	}
+
+	if pagination.Offset > 0 {
+		db = db.Offset(pagination.Offset)
+	}
+
+	if err := db.Find(&watches).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":       err.Error(),
+			"target_type": targetType,
+			"target_id":   targetID,
+		}).Error("Failed to list watchers from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"target_type": targetType,
+		"target_id":   targetID,
+		"count":       len(watches),
+	}).Debug("Watchers listed successfully from database")
+
+	return watches, nil
+}
+
+// SubscriberIDs returns the distinct users watching the project or, when
+// itemID is set, the given item of that project.
+func (r *PostgresWatchRepository) SubscriberIDs(ctx context.Context, projectID uuid.UUID, itemID *uuid.UUID) ([]uuid.UUID, error) {
+	r.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"item_id":    itemID,
+	}).Debug("Resolving subscribers from database")
+
+	db := r.db.WithContext(ctx).Model(&domain.Watch{}).Distinct("user_id")
+	if itemID != nil {
+		db = db.Where("(target_type = ? AND target_id = ?) OR (target_type = ? AND target_id = ?)",
+			domain.WatchTargetProject, projectID, domain.WatchTargetProjectItem, *itemID)
+	} else {
+		db = db.Where("target_type = ? AND target_id = ?", domain.WatchTargetProject, projectID)
+	}
+
+	var userIDs []uuid.UUID
+	if err := db.Pluck("user_id", &userIDs).Error; err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"project_id": projectID,
+			"item_id":    itemID,
+		}).Error("Failed to resolve subscribers from database")
+		return nil, err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"count":      len(userIDs),
+	}).Debug("Subscribers resolved successfully from database")
+
+	return userIDs, nil
+}
@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"html"
+	"strings"
+)
+
+// productSearchStatements add the full-text search column of products,
+// generated from the name, SKU and description, and its index. They repeat
+// migration 050 for databases set up through AutoMigrate. The configuration
+// must stay domain.ProductSearchConfig.
+var productSearchStatements = []string{
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(sku, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+	) STORED`,
+	"CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING gin (search_vector)",
+}
+
+// ts_headline marks the matched words with these control characters rather
+// than with the final tags, so the text around them can be HTML-escaped
+// afterwards without escaping the tags too.
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+)
+
+var (
+	nameHeadlineOptions        = `HighlightAll=true, StartSel="` + highlightStart + `", StopSel="` + highlightStop + `"`
+	descriptionHeadlineOptions = `MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" … ", StartSel="` + highlightStart + `", StopSel="` + highlightStop + `"`
+)
+
+var highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// highlight turns a ts_headline result into HTML with the matched words in
+// <mark> tags.
+func highlight(headline string) string {
+	return highlightReplacer.Replace(html.EscapeString(headline))
+}
@@ -0,0 +1,122 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// idempotencyKeyPrefix keeps the records apart from other data in the same
+// Redis database.
+const idempotencyKeyPrefix = "idempotency:"
+
+// RedisIdempotencyStore keeps Idempotency-Key records in Redis, shared by
+// every instance. Records expire on their own.
+type RedisIdempotencyStore struct {
+	client *redis.Client
+	logger *logrus.Logger
+}
+
+// NewRedisIdempotencyStore connects to the Redis server at redisURL, as in
+// redis://:password@localhost:6379/0.
+func NewRedisIdempotencyStore(redisURL string) (*RedisIdempotencyStore, error) {
+	options, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	return &RedisIdempotencyStore{
+		client: redis.NewClient(options),
+		logger: WithRedaction(logrus.New()),
+	}, nil
+}
+
+// Ping checks that the server answers.
+func (s *RedisIdempotencyStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+func (s *RedisIdempotencyStore) Close() error {
+	return s.client.Close()
+}
+
+func (s *RedisIdempotencyStore) Claim(ctx context.Context, key, requestHash string, now time.Time) (*domain.IdempotencyRecord, bool, error) {
+	record, err := json.Marshal(&domain.IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(domain.IdempotencyLockTTL),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// SET NX claims the key only when no record holds it. A record that
+	// expires between a failed SET and the GET is claimed on the next try.
+	for attempt := 0; attempt < 2; attempt++ {
+		claimed, err := s.client.SetNX(ctx, idempotencyKeyPrefix+key, record, domain.IdempotencyLockTTL).Result()
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Failed to claim idempotency key in redis")
+			return nil, false, err
+		}
+		if claimed {
+			return nil, true, nil
+		}
+
+		stored, err := s.client.Get(ctx, idempotencyKeyPrefix+key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			s.logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Failed to get idempotency key from redis")
+			return nil, false, err
+		}
+
+		var existing domain.IdempotencyRecord
+		if err := json.Unmarshal(stored, &existing); err != nil {
+			return nil, false, err
+		}
+		return &existing, false, nil
+	}
+
+	return nil, false, domain.ErrIdempotencyKeyInUse
+}
+
+func (s *RedisIdempotencyStore) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	stored, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.SetArgs(ctx, idempotencyKeyPrefix+record.Key, stored, redis.SetArgs{ExpireAt: record.ExpiresAt}).Err()
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":  err.Error(),
+			"status": record.Status,
+		}).Error("Failed to store idempotent response in redis")
+		return err
+	}
+
+	return nil
+}
+
+func (s *RedisIdempotencyStore) Release(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, idempotencyKeyPrefix+key).Err(); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to release idempotency key in redis")
+		return err
+	}
+
+	return nil
+}
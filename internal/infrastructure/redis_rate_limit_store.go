@@ -0,0 +1,96 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// rateLimitKeyPrefix keeps the buckets apart from other data in the same
+// Redis database.
+const rateLimitKeyPrefix = "rate_limit:"
+
+// takeTokenScript refills and takes from a bucket in one step, so instances
+// sharing it cannot both take its last token. Times are in milliseconds
+// from the caller, and a bucket expires once it would be full again.
+var takeTokenScript = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local refill = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+	tokens = burst
+	updated = now
+end
+if now > updated then
+	tokens = math.min(burst, tokens + (now - updated) / refill)
+	updated = now
+end
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) * refill)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", updated)
+redis.call("PEXPIRE", KEYS[1], math.ceil((burst - tokens) * refill) + 1)
+return {allowed, math.floor(tokens), retry}
+`)
+
+// RedisRateLimitStore keeps buckets in Redis, so every instance behind a
+// load balancer counts against the same limit.
+type RedisRateLimitStore struct {
+	client *redis.Client
+	logger *logrus.Logger
+}
+
+// NewRedisRateLimitStore connects to the Redis server at redisURL, as in
+// redis://:password@localhost:6379/0.
+func NewRedisRateLimitStore(redisURL string) (*RedisRateLimitStore, error) {
+	options, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	return &RedisRateLimitStore{
+		client: redis.NewClient(options),
+		logger: WithRedaction(logrus.New()),
+	}, nil
+}
+
+// Ping checks that the server answers.
+func (s *RedisRateLimitStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+func (s *RedisRateLimitStore) Close() error {
+	return s.client.Close()
+}
+
+func (s *RedisRateLimitStore) Take(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitDecision, error) {
+	refill := float64(limit.Refill()) / float64(time.Millisecond)
+	result, err := takeTokenScript.Run(ctx, s.client, []string{rateLimitKeyPrefix + key}, limit.Burst, refill, now.UnixMilli()).Int64Slice()
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to take rate limit token from redis")
+		return domain.RateLimitDecision{}, err
+	}
+
+	return domain.RateLimitDecision{
+		Allowed:    result[0] == 1,
+		Remaining:  int(result[1]),
+		RetryAfter: time.Duration(result[2]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// Prefixes keeping cached responses and resource generations apart from
+// other data in the same Redis database.
+const (
+	responseCacheKeyPrefix        = "response_cache:"
+	responseCacheGenerationPrefix = "response_cache_generation:"
+)
+
+// RedisResponseCache keeps responses in Redis, shared by every instance, so a
+// write served by one instance invalidates what the others cached. Entries
+// expire on their own; how many are kept is up to the server's memory
+// policy. When Redis fails, lookups miss and the request is served as
+// without cache.
+type RedisResponseCache struct {
+	client *redis.Client
+	logger *logrus.Logger
+}
+
+// NewRedisResponseCache connects to the Redis server at redisURL, as in
+// redis://:password@localhost:6379/0.
+func NewRedisResponseCache(redisURL string) (*RedisResponseCache, error) {
+	options, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	return &RedisResponseCache{
+		client: redis.NewClient(options),
+		logger: WithRedaction(logrus.New()),
+	}, nil
+}
+
+// Ping checks that the server answers.
+func (c *RedisResponseCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+func (c *RedisResponseCache) Close() error {
+	return c.client.Close()
+}
+
+func (c *RedisResponseCache) Get(key string) (*domain.CachedResponse, bool) {
+	stored, err := c.client.Get(context.Background(), responseCacheKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false
+	}
+	if err != nil {
+		c.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to get cached response from redis")
+		return nil, false
+	}
+
+	var response domain.CachedResponse
+	if err := json.Unmarshal(stored, &response); err != nil {
+		c.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to decode cached response from redis")
+		return nil, false
+	}
+	return &response, true
+}
+
+func (c *RedisResponseCache) Set(key string, response *domain.CachedResponse, ttl time.Duration) {
+	stored, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+
+	if err := c.client.Set(context.Background(), responseCacheKeyPrefix+key, stored, ttl).Err(); err != nil {
+		c.logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to store cached response in redis")
+	}
+}
+
+func (c *RedisResponseCache) Generation(resource string) uint64 {
+	generation, err := c.client.Get(context.Background(), responseCacheGenerationPrefix+resource).Uint64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		c.logger.WithFields(logrus.Fields{
+			"error":    err.Error(),
+			"resource": resource,
+		}).Error("Failed to get cache generation from redis")
+	}
+	return generation
+}
+
+func (c *RedisResponseCache) Invalidate(resources ...string) {
+	if len(resources) == 0 {
+		return
+	}
+
+	_, err := c.client.Pipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		for _, resource := range resources {
+			pipe.Incr(context.Background(), responseCacheGenerationPrefix+resource)
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"resources": resources,
+		}).Error("Failed to invalidate cached responses in redis")
+	}
+}
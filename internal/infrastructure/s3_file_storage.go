@@ -0,0 +1,292 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3TimeFormat      = "20060102T150405Z"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// S3FileStorage keeps files in a bucket of Amazon S3 or of an S3-compatible
+// service such as MinIO or Cloudflare R2. Requests are signed with AWS
+// Signature Version 4 and download links are presigned URLs, so files are
+// fetched from the storage service directly.
+type S3FileStorage struct {
+	client    *HTTPClient
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	logger    *logrus.Logger
+	clock     domain.Clock
+}
+
+// NewS3FileStorage stores files in bucket. An empty endpoint is Amazon S3
+// in region; pathStyle addresses the bucket as the first path segment
+// instead of a subdomain, which most self-hosted services need.
+func NewS3FileStorage(client *HTTPClient, endpoint, region, bucket, accessKey, secretKey string, pathStyle bool) (*S3FileStorage, error) {
+	if bucket == "" || region == "" || accessKey == "" || secretKey == "" {
+		return nil, errors.New("S3 storage needs a bucket, a region and credentials")
+	}
+	if endpoint == "" {
+		endpoint = "https://s3." + region + ".amazonaws.com"
+	}
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+
+	return &S3FileStorage{
+		client:    client,
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		pathStyle: pathStyle,
+		logger:    WithRedaction(logrus.New()),
+		clock:     domain.SystemClock{},
+	}, nil
+}
+
+func (s *S3FileStorage) WithClock(clock domain.Clock) *S3FileStorage {
+	s.clock = clock
+	return s
+}
+
+func (s *S3FileStorage) Put(ctx context.Context, key, contentType string, content []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, content)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("S3 upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := s3Error(resp); err != nil {
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"key":  key,
+		"size": len(content),
+	}).Debug("File stored in S3")
+
+	return nil
+}
+
+func (s *S3FileStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, nil)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("S3 download failed: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, domain.ErrFileNotFound
+	}
+	if err := s3Error(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3FileStorage) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	s.sign(req, nil)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("S3 delete failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return s3Error(resp)
+}
+
+// SignedURL presigns a GET of the object. S3 refuses presigned URLs valid
+// for more than a week, so expiresAt is capped at MaxSignedURLTTL.
+func (s *S3FileStorage) SignedURL(ctx context.Context, key string, expiresAt time.Time) (string, error) {
+	now := s.clock.Now().UTC()
+	ttl := expiresAt.Sub(now)
+	if ttl <= 0 {
+		return "", errors.New("signed URL expiry must be in the future")
+	}
+	if ttl > domain.MaxSignedURLTTL {
+		ttl = domain.MaxSignedURLTTL
+	}
+
+	u := s.objectURL(key)
+	scope := s.scope(now)
+	query := url.Values{
+		"X-Amz-Algorithm":     {s3Algorithm},
+		"X-Amz-Credential":    {s.accessKey + "/" + scope},
+		"X-Amz-Date":          {now.Format(s3TimeFormat)},
+		"X-Amz-Expires":       {strconv.Itoa(int(ttl / time.Second))},
+		"X-Amz-SignedHeaders": {"host"},
+	}
+	canonical := strings.Join([]string{
+		http.MethodGet,
+		s3EscapePath(u.Path),
+		s3CanonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+	query.Set("X-Amz-Signature", s.signature(now, scope, canonical))
+
+	u.RawQuery = s3CanonicalQuery(query)
+	return u.String(), nil
+}
+
+func (s *S3FileStorage) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	if s.pathStyle {
+		u.Path += "/" + s.bucket + "/" + key
+	} else {
+		u.Host = s.bucket + "." + u.Host
+		u.Path += "/" + key
+	}
+	u.RawPath = s3EscapePath(u.Path)
+	return &u
+}
+
+// sign adds the Signature Version 4 Authorization header to req, whose
+// body is payload.
+func (s *S3FileStorage) sign(req *http.Request, payload []byte) {
+	now := s.clock.Now().UTC()
+	payloadHash := sha256Hex(payload)
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signed := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	headers := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + now.Format(s3TimeFormat) + "\n"
+	canonical := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		s3CanonicalQuery(req.URL.Query()),
+		headers,
+		strings.Join(signed, ";"),
+		payloadHash,
+	}, "\n")
+
+	scope := s.scope(now)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKey, scope, strings.Join(signed, ";"), s.signature(now, scope, canonical)))
+}
+
+func (s *S3FileStorage) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+func (s *S3FileStorage) signature(now time.Time, scope, canonicalRequest string) string {
+	stringToSign := s3Algorithm + "\n" + now.Format(s3TimeFormat) + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// s3Error turns a non-2xx response into an error carrying the start of the
+// service's message.
+func s3Error(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	return fmt.Errorf("S3 request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+}
+
+// s3EscapePath percent-encodes every byte of p except the unreserved
+// characters and the slashes between segments.
+func s3EscapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, s3Escape(key)+"="+s3Escape(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape is the URI encoding of Signature Version 4: only A-Z, a-z, 0-9,
+// '-', '.', '_' and '~' are left as they are.
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,218 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/edumes/golang-api-rest/internal/domain"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// SMTPMailer sends email through an SMTP server, upgrading the connection
+// with STARTTLS whenever the server offers it.
+type SMTPMailer struct {
+	host     string
+	addr     string
+	username string
+	password string
+	from     string
+	logger   *logrus.Logger
+}
+
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		host:     host,
+		addr:     net.JoinHostPort(host, port),
+		username: username,
+		password: password,
+		from:     from,
+		logger:   WithRedaction(logrus.New()),
+	}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, email *domain.Email) error {
+	m.logger.WithFields(logrus.Fields{
+		"recipients":  len(email.To),
+		"subject":     email.Subject,
+		"attachments": len(email.Attachments),
+	}).Debug("Sending email")
+
+	message, err := m.message(email)
+	if err != nil {
+		return err
+	}
+
+	if err := m.deliver(ctx, email.To, message); err != nil {
+		m.logger.WithFields(logrus.Fields{
+			"error":   err.Error(),
+			"subject": email.Subject,
+		}).Error("Failed to send email")
+		return err
+	}
+
+	m.logger.WithFields(logrus.Fields{
+		"recipients": len(email.To),
+		"subject":    email.Subject,
+	}).Info("Email sent successfully")
+
+	return nil
+}
+
+func (m *SMTPMailer) deliver(ctx context.Context, to []string, message []byte) error {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return fmt.Errorf("connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(m.from); err != nil {
+		return fmt.Errorf("smtp sender: %w", err)
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("smtp recipient %s: %w", recipient, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// message builds a multipart/mixed MIME message with the body as its first
+// part and each attachment base64 encoded after it.
+func (m *SMTPMailer) message(email *domain.Email) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	header := []string{
+		"From: " + m.from,
+		"To: " + strings.Join(email.To, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", email.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"Message-ID: <" + uuid.NewString() + "@" + m.host + ">",
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/mixed; boundary=" + writer.Boundary(),
+	}
+	message := bytes.NewBufferString(strings.Join(header, "\r\n") + "\r\n\r\n")
+
+	if err := writeBody(writer, email); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range email.Attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		writeBase64(part, attachment.Data)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	message.Write(buf.Bytes())
+	return message.Bytes(), nil
+}
+
+// writeBody adds the body part: plain text, or a multipart/alternative of the
+// text and HTML versions when the email has both.
+func writeBody(writer *multipart.Writer, email *domain.Email) error {
+	text := textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	}
+	if email.HTMLBody == "" {
+		part, err := writer.CreatePart(text)
+		if err != nil {
+			return err
+		}
+		writeBase64(part, []byte(email.Body))
+		return nil
+	}
+
+	var buf bytes.Buffer
+	alternative := multipart.NewWriter(&buf)
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"multipart/alternative; boundary=" + alternative.Boundary()},
+	})
+	if err != nil {
+		return err
+	}
+	for _, version := range []struct {
+		header textproto.MIMEHeader
+		body   string
+	}{
+		{text, email.Body},
+		{textproto.MIMEHeader{
+			"Content-Type":              {"text/html; charset=utf-8"},
+			"Content-Transfer-Encoding": {"base64"},
+		}, email.HTMLBody},
+	} {
+		w, err := alternative.CreatePart(version.header)
+		if err != nil {
+			return err
+		}
+		writeBase64(w, []byte(version.body))
+	}
+	if err := alternative.Close(); err != nil {
+		return err
+	}
+	_, err = part.Write(buf.Bytes())
+	return err
+}
+
+// writeBase64 encodes data in lines of 76 characters as MIME requires.
+func writeBase64(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	w.Write([]byte(encoded + "\r\n"))
+}
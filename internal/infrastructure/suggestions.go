@@ -0,0 +1,22 @@
+package infrastructure
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// likeEscaper escapes the LIKE wildcards in typed input, so "50%" matches
+// the literal text.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// suggestionOrder ranks typeahead matches on column: exact matches first,
+// then those starting with query, then by how early query appears, and
+// finally shorter labels before longer ones.
+func suggestionOrder(column, query string) clause.OrderBy {
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                "lower(" + column + ") = lower(?) DESC, " + column + " ILIKE ? DESC, strpos(lower(" + column + "), lower(?)), length(" + column + "), " + column,
+		Vars:               []interface{}{query, likeEscaper.Replace(query) + "%", query},
+		WithoutParentheses: true,
+	}}
+}
@@ -0,0 +1,86 @@
+// Package mocks holds mockery-generated testify mocks for the repository
+// interfaces in internal/domain and the service interfaces in internal/api.
+// Regenerate with `make mocks` after changing any of those interfaces.
+package mocks
+
+import (
+	"github.com/edumes/golang-api-rest/internal/api"
+	"github.com/edumes/golang-api-rest/internal/domain"
+)
+
+var (
+	_ domain.UserRepository               = (*UserRepository)(nil)
+	_ domain.ProductRepository            = (*ProductRepository)(nil)
+	_ domain.ProjectRepository            = (*ProjectRepository)(nil)
+	_ domain.ProjectItemRepository        = (*ProjectItemRepository)(nil)
+	_ domain.CouponRepository             = (*CouponRepository)(nil)
+	_ domain.StockAdjustmentRepository    = (*StockAdjustmentRepository)(nil)
+	_ domain.WarehouseRepository          = (*WarehouseRepository)(nil)
+	_ domain.PurchaseOrderRepository      = (*PurchaseOrderRepository)(nil)
+	_ domain.ExpenseRepository            = (*ExpenseRepository)(nil)
+	_ domain.WatchRepository              = (*WatchRepository)(nil)
+	_ domain.NotificationRepository       = (*NotificationRepository)(nil)
+	_ domain.ChangeNotifier               = (*ChangeNotifier)(nil)
+	_ domain.ProjectExportRepository      = (*ProjectExportRepository)(nil)
+	_ domain.CustomFieldRepository        = (*CustomFieldRepository)(nil)
+	_ domain.SavedFilterRepository        = (*SavedFilterRepository)(nil)
+	_ domain.ReportRepository             = (*ReportRepository)(nil)
+	_ domain.DashboardRepository          = (*DashboardRepository)(nil)
+	_ domain.ReportSubscriptionRepository = (*ReportSubscriptionRepository)(nil)
+	_ domain.Mailer                       = (*Mailer)(nil)
+	_ domain.RetentionRepository          = (*RetentionRepository)(nil)
+	_ domain.UserExportRepository         = (*UserExportRepository)(nil)
+	_ domain.PolicyRepository             = (*PolicyRepository)(nil)
+	_ domain.DeviceRepository             = (*DeviceRepository)(nil)
+	_ domain.PushSender                   = (*PushSender)(nil)
+	_ domain.NotificationDispatcher       = (*NotificationDispatcher)(nil)
+	_ domain.ChatConnectorRepository      = (*ChatConnectorRepository)(nil)
+	_ domain.ChatPoster                   = (*ChatPoster)(nil)
+	_ domain.ChatNotifier                 = (*ChatNotifier)(nil)
+	_ domain.CalendarRepository           = (*CalendarRepository)(nil)
+	_ domain.ExchangeRateRepository       = (*ExchangeRateRepository)(nil)
+	_ domain.ExchangeRateFetcher          = (*ExchangeRateFetcher)(nil)
+	_ domain.RefreshTokenRepository       = (*RefreshTokenRepository)(nil)
+	_ domain.RevokedTokenRepository       = (*RevokedTokenRepository)(nil)
+	_ domain.PasswordResetRepository      = (*PasswordResetRepository)(nil)
+	_ domain.UserIdentityRepository       = (*UserIdentityRepository)(nil)
+	_ domain.OAuthClient                  = (*OAuthClient)(nil)
+	_ domain.FailedLoginRepository        = (*FailedLoginRepository)(nil)
+	_ domain.ProductImportRepository      = (*ProductImportRepository)(nil)
+	_ domain.AttachmentRepository         = (*AttachmentRepository)(nil)
+	_ domain.FileStorage                  = (*FileStorage)(nil)
+	_ domain.CommentRepository            = (*CommentRepository)(nil)
+	_ domain.OrderRepository              = (*OrderRepository)(nil)
+	_ domain.CategoryRepository           = (*CategoryRepository)(nil)
+
+	_ api.UserService               = (*UserService)(nil)
+	_ api.TokenService              = (*TokenService)(nil)
+	_ api.ProductService            = (*ProductService)(nil)
+	_ api.ProjectService            = (*ProjectService)(nil)
+	_ api.ProjectItemService        = (*ProjectItemService)(nil)
+	_ api.CouponService             = (*CouponService)(nil)
+	_ api.StockAdjustmentService    = (*StockAdjustmentService)(nil)
+	_ api.WarehouseService          = (*WarehouseService)(nil)
+	_ api.PurchaseOrderService      = (*PurchaseOrderService)(nil)
+	_ api.ExpenseService            = (*ExpenseService)(nil)
+	_ api.WatchService              = (*WatchService)(nil)
+	_ api.ProjectExportService      = (*ProjectExportService)(nil)
+	_ api.CustomFieldService        = (*CustomFieldService)(nil)
+	_ api.SavedFilterService        = (*SavedFilterService)(nil)
+	_ api.ReportService             = (*ReportService)(nil)
+	_ api.DashboardService          = (*DashboardService)(nil)
+	_ api.ReportSubscriptionService = (*ReportSubscriptionService)(nil)
+	_ api.RetentionService          = (*RetentionService)(nil)
+	_ api.UserExportService         = (*UserExportService)(nil)
+	_ api.PolicyService             = (*PolicyService)(nil)
+	_ api.EmailTemplateService      = (*EmailTemplateService)(nil)
+	_ api.PushService               = (*PushService)(nil)
+	_ api.ChatService               = (*ChatService)(nil)
+	_ api.CalendarService           = (*CalendarService)(nil)
+	_ api.ExchangeRateService       = (*ExchangeRateService)(nil)
+	_ api.FaultService              = (*FaultService)(nil)
+	_ api.AttachmentService         = (*AttachmentService)(nil)
+	_ api.CommentService            = (*CommentService)(nil)
+	_ api.OrderService              = (*OrderService)(nil)
+	_ api.CategoryService           = (*CategoryService)(nil)
+)
@@ -0,0 +1,3 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS barcode VARCHAR(13);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_barcode ON products(barcode);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS sku_sequences;
@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS sku_sequences (
+    prefix VARCHAR(100) PRIMARY KEY,
+    last_value BIGINT NOT NULL DEFAULT 0
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS stock_adjustments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL CHECK (quantity <> 0),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('damage', 'recount', 'sale', 'return')),
+    note TEXT,
+    actor_id UUID NOT NULL,
+    stock_before INTEGER NOT NULL,
+    stock_after INTEGER NOT NULL CHECK (stock_after >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_adjustments_product_id ON stock_adjustments(product_id, created_at);
@@ -0,0 +1,4 @@
+ALTER TABLE stock_adjustments DROP COLUMN IF EXISTS warehouse_id;
+DROP TABLE IF EXISTS stock_transfers;
+DROP TABLE IF EXISTS warehouse_stocks;
+DROP TABLE IF EXISTS warehouses;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS warehouses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(32) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    address TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_warehouses_deleted_at ON warehouses(deleted_at);
+
+CREATE TABLE IF NOT EXISTS warehouse_stocks (
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    product_id UUID NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (warehouse_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_warehouse_stocks_product_id ON warehouse_stocks(product_id);
+
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    from_warehouse_id UUID REFERENCES warehouses(id),
+    to_warehouse_id UUID REFERENCES warehouses(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    note TEXT,
+    actor_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_warehouse_id IS NOT NULL OR to_warehouse_id IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_product_id ON stock_transfers(product_id, created_at);
+
+ALTER TABLE stock_adjustments ADD COLUMN IF NOT EXISTS warehouse_id UUID REFERENCES warehouses(id);
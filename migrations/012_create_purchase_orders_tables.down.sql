@@ -0,0 +1,6 @@
+ALTER TABLE stock_adjustments DROP CONSTRAINT IF EXISTS stock_adjustments_reason_check;
+ALTER TABLE stock_adjustments ADD CONSTRAINT stock_adjustments_reason_check
+    CHECK (reason IN ('damage', 'recount', 'sale', 'return'));
+ALTER TABLE products DROP COLUMN IF EXISTS cost_price;
+DROP TABLE IF EXISTS purchase_order_lines;
+DROP TABLE IF EXISTS purchase_orders;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS watches (
+    user_id UUID NOT NULL REFERENCES users(id),
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('project', 'project_item')),
+    target_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, target_type, target_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_watches_target ON watches(target_type, target_id);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    target_type VARCHAR(20) NOT NULL,
+    target_id UUID NOT NULL,
+    project_id UUID NOT NULL,
+    event VARCHAR(20) NOT NULL,
+    message TEXT NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS project_item_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES project_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    unassigned_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_item_assignments_item_id ON project_item_assignments(item_id, assigned_at DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_project_item_assignments_open ON project_item_assignments(item_id) WHERE unassigned_at IS NULL;
+
+INSERT INTO project_item_assignments (item_id, user_id, assigned_at)
+SELECT id, assigned_to, updated_at FROM project_items
+WHERE assigned_to IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_active;
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_until;
+ALTER TABLE users DROP COLUMN IF EXISTS active;
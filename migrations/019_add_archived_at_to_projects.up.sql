@@ -0,0 +1,3 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_projects_archived_at ON projects(archived_at);
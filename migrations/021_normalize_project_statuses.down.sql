@@ -0,0 +1,4 @@
+-- The normalised values are kept; only the constraints are dropped.
+ALTER TABLE project_items DROP CONSTRAINT IF EXISTS chk_project_items_priority;
+ALTER TABLE project_items DROP CONSTRAINT IF EXISTS chk_project_items_status;
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS chk_projects_status;
@@ -0,0 +1,35 @@
+-- Map the free-text values written before status and priority were
+-- enumerated onto the allowed ones. Anything unrecognised falls back to the
+-- default the API would have used.
+UPDATE projects SET status = CASE
+    WHEN lower(btrim(status)) IN ('active', 'open', 'in_progress', 'in progress', 'in-progress', 'started') THEN 'active'
+    WHEN lower(btrim(status)) IN ('on_hold', 'on hold', 'on-hold', 'onhold', 'paused', 'hold') THEN 'on_hold'
+    WHEN lower(btrim(status)) IN ('completed', 'complete', 'done', 'finished', 'closed') THEN 'completed'
+    WHEN lower(btrim(status)) IN ('cancelled', 'canceled', 'abandoned') THEN 'cancelled'
+    ELSE 'active'
+END
+WHERE status NOT IN ('active', 'on_hold', 'completed', 'cancelled');
+
+UPDATE project_items SET status = CASE
+    WHEN lower(btrim(status)) IN ('pending', 'todo', 'to do', 'to-do', 'open', 'new', 'backlog') THEN 'pending'
+    WHEN lower(btrim(status)) IN ('in_progress', 'in progress', 'in-progress', 'inprogress', 'doing', 'started', 'wip') THEN 'in_progress'
+    WHEN lower(btrim(status)) IN ('completed', 'complete', 'done', 'finished', 'closed', 'resolved') THEN 'completed'
+    WHEN lower(btrim(status)) IN ('cancelled', 'canceled', 'abandoned', 'wontfix') THEN 'cancelled'
+    ELSE 'pending'
+END
+WHERE status NOT IN ('pending', 'in_progress', 'completed', 'cancelled');
+
+UPDATE project_items SET priority = CASE
+    WHEN lower(btrim(priority)) IN ('low', 'lowest', 'minor', 'trivial') THEN 'low'
+    WHEN lower(btrim(priority)) IN ('medium', 'med', 'normal', 'moderate') THEN 'medium'
+    WHEN lower(btrim(priority)) IN ('high', 'highest', 'urgent', 'critical', 'major', 'blocker') THEN 'high'
+    ELSE 'medium'
+END
+WHERE priority NOT IN ('low', 'medium', 'high');
+
+ALTER TABLE projects ADD CONSTRAINT chk_projects_status
+    CHECK (status IN ('active', 'on_hold', 'completed', 'cancelled'));
+ALTER TABLE project_items ADD CONSTRAINT chk_project_items_status
+    CHECK (status IN ('pending', 'in_progress', 'completed', 'cancelled'));
+ALTER TABLE project_items ADD CONSTRAINT chk_project_items_priority
+    CHECK (priority IN ('low', 'medium', 'high'));
@@ -0,0 +1,3 @@
+ALTER TABLE project_items DROP COLUMN IF EXISTS custom_fields;
+ALTER TABLE projects DROP COLUMN IF EXISTS custom_fields;
+DROP TABLE IF EXISTS custom_field_definitions;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS custom_field_definitions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity VARCHAR(20) NOT NULL CHECK (entity IN ('project', 'item')),
+    project_id UUID REFERENCES projects(id),
+    key VARCHAR(63) NOT NULL,
+    label VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('text', 'number', 'date', 'select')),
+    options JSONB NOT NULL DEFAULT '[]',
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK (entity = 'item' OR project_id IS NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_custom_field_definitions_entity ON custom_field_definitions(entity, project_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_custom_field_definitions_deleted_at ON custom_field_definitions(deleted_at);
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE project_items ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}';
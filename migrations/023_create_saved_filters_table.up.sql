@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS saved_filters (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    name VARCHAR(255) NOT NULL,
+    entity VARCHAR(30) NOT NULL CHECK (entity IN ('product', 'project', 'project_item', 'user', 'coupon', 'purchase_order')),
+    query JSONB NOT NULL DEFAULT '{}',
+    sort VARCHAR(100) NOT NULL DEFAULT '',
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_filters_user_id ON saved_filters(user_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_saved_filters_shared ON saved_filters(entity) WHERE shared AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_saved_filters_deleted_at ON saved_filters(deleted_at);
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS report_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    name VARCHAR(255) NOT NULL,
+    report VARCHAR(30) NOT NULL CHECK (report IN ('items-by-status', 'stock-by-category', 'projects-budget')),
+    format VARCHAR(10) NOT NULL DEFAULT 'csv' CHECK (format IN ('csv', 'pdf')),
+    filters JSONB NOT NULL DEFAULT '{}',
+    schedule VARCHAR(100) NOT NULL,
+    recipients JSONB NOT NULL DEFAULT '[]',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMP WITH TIME ZONE,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_subscriptions_user_id ON report_subscriptions(user_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_report_subscriptions_next_run_at ON report_subscriptions(next_run_at) WHERE active AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_report_subscriptions_deleted_at ON report_subscriptions(deleted_at);
+
+CREATE TABLE IF NOT EXISTS report_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID NOT NULL REFERENCES report_subscriptions(id),
+    status VARCHAR(10) NOT NULL CHECK (status IN ('sent', 'failed')),
+    format VARCHAR(10) NOT NULL,
+    recipients JSONB NOT NULL DEFAULT '[]',
+    rows INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_deliveries_subscription_id ON report_deliveries(subscription_id, created_at DESC);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS retention_runs;
+DROP TABLE IF EXISTS retention_rules;
+ALTER TABLE users DROP COLUMN IF EXISTS anonymized_at;
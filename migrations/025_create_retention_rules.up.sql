@@ -0,0 +1,34 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS retention_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity VARCHAR(30) NOT NULL CHECK (entity IN ('notification', 'report_delivery', 'project_export', 'stock_adjustment', 'user')),
+    action VARCHAR(20) NOT NULL CHECK (action IN ('purge', 'anonymize')),
+    retain_days INTEGER NOT NULL CHECK (retain_days BETWEEN 1 AND 36500),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_retention_rules_entity ON retention_rules(entity) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_retention_rules_deleted_at ON retention_rules(deleted_at);
+
+CREATE TABLE IF NOT EXISTS retention_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    rule_id UUID NOT NULL REFERENCES retention_rules(id),
+    entity VARCHAR(30) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    cutoff TIMESTAMP WITH TIME ZONE NOT NULL,
+    affected BIGINT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    triggered_by UUID REFERENCES users(id),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_runs_rule_id ON retention_runs(rule_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_retention_runs_started_at ON retention_runs(started_at DESC);
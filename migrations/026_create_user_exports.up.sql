@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_exports (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'ready', 'failed')),
+    error TEXT NOT NULL DEFAULT '',
+    content BYTEA,
+    size INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_exports_user_id ON user_exports(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_exports_expires_at ON user_exports(expires_at) WHERE expires_at IS NOT NULL;
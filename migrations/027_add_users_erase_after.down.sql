@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_erase_after;
+
+ALTER TABLE users DROP COLUMN IF EXISTS erase_after;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS policy_documents (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL CHECK (kind IN ('terms_of_service', 'privacy_policy')),
+    version VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    published_by UUID NOT NULL REFERENCES users(id),
+    published_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_policy_documents_kind_version ON policy_documents(kind, version);
+CREATE INDEX IF NOT EXISTS idx_policy_documents_published_at ON policy_documents(kind, published_at DESC);
+
+CREATE TABLE IF NOT EXISTS policy_acceptances (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    document_id UUID NOT NULL REFERENCES policy_documents(id),
+    kind VARCHAR(50) NOT NULL,
+    version VARCHAR(50) NOT NULL,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    accepted_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_policy_acceptances_user_document ON policy_acceptances(user_id, document_id);
+CREATE INDEX IF NOT EXISTS idx_policy_acceptances_document_id ON policy_acceptances(document_id, accepted_at DESC);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_email_trgm;
+DROP INDEX IF EXISTS idx_users_name_trgm;
+DROP INDEX IF EXISTS idx_products_sku_trgm;
+DROP INDEX IF EXISTS idx_products_name_trgm;
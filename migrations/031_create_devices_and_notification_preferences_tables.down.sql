@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notifications_due_soon;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS devices;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS devices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    platform VARCHAR(10) NOT NULL CHECK (platform IN ('fcm', 'apns')),
+    token TEXT NOT NULL,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_devices_token ON devices(token);
+CREATE INDEX IF NOT EXISTS idx_devices_user_id ON devices(user_id);
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id),
+    push_assigned BOOLEAN NOT NULL DEFAULT TRUE,
+    push_due_soon BOOLEAN NOT NULL DEFAULT TRUE,
+    push_changes BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_due_soon ON notifications(target_id, user_id) WHERE event = 'due_soon';
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS chat_connectors (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    provider VARCHAR(10) NOT NULL CHECK (provider IN ('slack', 'teams')),
+    webhook_url TEXT NOT NULL,
+    events JSONB NOT NULL DEFAULT '[]',
+    project_id UUID REFERENCES projects(id),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_connectors_project_id ON chat_connectors(project_id);
+CREATE INDEX IF NOT EXISTS idx_chat_connectors_deleted_at ON chat_connectors(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_chat_connectors_events ON chat_connectors USING GIN (events) WHERE deleted_at IS NULL AND active;
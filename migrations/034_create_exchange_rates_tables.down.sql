@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS exchange_rate_syncs;
+DROP TABLE IF EXISTS exchange_rates;
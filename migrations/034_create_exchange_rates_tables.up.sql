@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    base CHAR(3) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    rate DOUBLE PRECISION NOT NULL CHECK (rate > 0),
+    provider VARCHAR(20) NOT NULL,
+    effective_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (base, currency)
+);
+
+CREATE TABLE IF NOT EXISTS exchange_rate_syncs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    provider VARCHAR(20) NOT NULL,
+    base CHAR(3) NOT NULL,
+    currencies INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    triggered_by UUID REFERENCES users(id),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_rate_syncs_started_at ON exchange_rate_syncs(started_at);
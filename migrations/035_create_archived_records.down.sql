@@ -0,0 +1,13 @@
+-- Archived rows are not put back; restore the ones to keep before rolling
+-- back. Archive rules are dropped so the narrower constraints hold.
+DROP TABLE IF EXISTS archived_records;
+
+DELETE FROM retention_runs WHERE rule_id IN (SELECT id FROM retention_rules WHERE action = 'archive' OR entity IN ('project', 'project_item'));
+DELETE FROM retention_rules WHERE action = 'archive' OR entity IN ('project', 'project_item');
+
+ALTER TABLE retention_rules DROP CONSTRAINT IF EXISTS retention_rules_action_check;
+ALTER TABLE retention_rules ADD CONSTRAINT retention_rules_action_check
+    CHECK (action IN ('purge', 'anonymize'));
+ALTER TABLE retention_rules DROP CONSTRAINT IF EXISTS retention_rules_entity_check;
+ALTER TABLE retention_rules ADD CONSTRAINT retention_rules_entity_check
+    CHECK (entity IN ('notification', 'report_delivery', 'project_export', 'stock_adjustment', 'user'));
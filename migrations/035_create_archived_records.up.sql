@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS archived_records (
+    entity VARCHAR(30) NOT NULL,
+    record_id UUID NOT NULL,
+    project_id UUID,
+    rule_id UUID REFERENCES retention_rules(id),
+    payload JSONB NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (entity, record_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_archived_records_project_id ON archived_records(project_id);
+CREATE INDEX IF NOT EXISTS idx_archived_records_archived_at ON archived_records(archived_at DESC);
+
+ALTER TABLE retention_rules DROP CONSTRAINT IF EXISTS retention_rules_entity_check;
+ALTER TABLE retention_rules ADD CONSTRAINT retention_rules_entity_check
+    CHECK (entity IN ('notification', 'report_delivery', 'project_export', 'stock_adjustment', 'user', 'project', 'project_item'));
+ALTER TABLE retention_rules DROP CONSTRAINT IF EXISTS retention_rules_action_check;
+ALTER TABLE retention_rules ADD CONSTRAINT retention_rules_action_check
+    CHECK (action IN ('purge', 'anonymize', 'archive'));
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS failed_logins (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    email TEXT NOT NULL DEFAULT '',
+    ip TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    cleared_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_failed_logins_user_id ON failed_logins(user_id);
+CREATE INDEX IF NOT EXISTS idx_failed_logins_ip ON failed_logins(ip);
+CREATE INDEX IF NOT EXISTS idx_failed_logins_created_at ON failed_logins(created_at);
@@ -0,0 +1,4 @@
+ALTER TABLE project_items DROP COLUMN IF EXISTS version;
+ALTER TABLE projects DROP COLUMN IF EXISTS version;
+ALTER TABLE products DROP COLUMN IF EXISTS version;
+ALTER TABLE users DROP COLUMN IF EXISTS version;
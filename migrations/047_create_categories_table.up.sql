@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    parent_id UUID REFERENCES categories(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories(deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_sibling_name ON categories(COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid), LOWER(name)) WHERE deleted_at IS NULL;
+
+-- Every category name already in use becomes a top-level category.
+INSERT INTO categories (name)
+SELECT DISTINCT ON (LOWER(TRIM(category))) TRIM(category)
+FROM products
+WHERE TRIM(COALESCE(category, '')) <> ''
+ORDER BY LOWER(TRIM(category)), TRIM(category);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS category_id UUID REFERENCES categories(id);
+CREATE INDEX IF NOT EXISTS idx_products_category_id ON products(category_id);
+
+UPDATE products p
+SET category_id = c.id, category = c.name
+FROM categories c
+WHERE c.parent_id IS NULL AND c.deleted_at IS NULL AND LOWER(c.name) = LOWER(TRIM(p.category));
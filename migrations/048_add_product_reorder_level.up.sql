@@ -0,0 +1,2 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_level INTEGER CHECK (reorder_level >= 0);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS low_stock_alerted_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY,
+    owner_type VARCHAR(20) NOT NULL,
+    owner_id UUID NOT NULL,
+    key TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    uploaded_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attachments_key ON attachments(key);
+CREATE INDEX IF NOT EXISTS idx_attachments_owner ON attachments(owner_type, owner_id);
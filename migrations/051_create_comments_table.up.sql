@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS comments (
+    id UUID PRIMARY KEY,
+    item_id UUID NOT NULL REFERENCES project_items(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES users(id),
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_item_id ON comments(item_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments(deleted_at);
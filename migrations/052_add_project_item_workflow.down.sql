@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS project_item_transitions;
+
+-- Blocked and in-review items go back to in progress, the closest status
+-- the earlier constraint allows.
+UPDATE project_items SET status = 'in_progress' WHERE status IN ('blocked', 'review');
+
+ALTER TABLE project_items DROP CONSTRAINT IF EXISTS chk_project_items_status;
+ALTER TABLE project_items ADD CONSTRAINT chk_project_items_status
+    CHECK (status IN ('pending', 'in_progress', 'completed', 'cancelled'));
@@ -0,0 +1,14 @@
+ALTER TABLE project_items DROP CONSTRAINT IF EXISTS chk_project_items_status;
+ALTER TABLE project_items ADD CONSTRAINT chk_project_items_status
+    CHECK (status IN ('pending', 'in_progress', 'blocked', 'review', 'completed', 'cancelled'));
+
+CREATE TABLE IF NOT EXISTS project_item_transitions (
+    id UUID PRIMARY KEY,
+    item_id UUID NOT NULL REFERENCES project_items(id) ON DELETE CASCADE,
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    actor_id UUID REFERENCES users(id),
+    transitioned_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_item_transitions_item_id ON project_item_transitions(item_id, transitioned_at DESC);
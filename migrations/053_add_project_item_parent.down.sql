@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_project_items_parent_item_id;
+ALTER TABLE project_items DROP COLUMN IF EXISTS parent_item_id;
@@ -0,0 +1,3 @@
+ALTER TABLE project_items ADD COLUMN IF NOT EXISTS parent_item_id UUID REFERENCES project_items(id);
+
+CREATE INDEX IF NOT EXISTS idx_project_items_parent_item_id ON project_items(parent_item_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_project_items_board;
+ALTER TABLE project_items DROP COLUMN IF EXISTS rank;
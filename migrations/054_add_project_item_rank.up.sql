@@ -0,0 +1,11 @@
+ALTER TABLE project_items ADD COLUMN IF NOT EXISTS rank DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+UPDATE project_items AS p SET rank = o.position * 1024
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id, status ORDER BY created_at, id) AS position
+    FROM project_items
+    WHERE deleted_at IS NULL
+) AS o
+WHERE p.id = o.id;
+
+CREATE INDEX IF NOT EXISTS idx_project_items_board ON project_items(project_id, status, rank) WHERE deleted_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_orders_coupon_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS subtotal;
+ALTER TABLE orders DROP COLUMN IF EXISTS coupon_code;
+ALTER TABLE orders DROP COLUMN IF EXISTS coupon_id;
@@ -0,0 +1,8 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS coupon_id UUID REFERENCES coupons(id);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS coupon_code VARCHAR(64);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS subtotal DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (subtotal >= 0);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (discount >= 0);
+
+UPDATE orders SET subtotal = total;
+
+CREATE INDEX IF NOT EXISTS idx_orders_coupon_id ON orders(coupon_id);
@@ -0,0 +1,3 @@
+ALTER TABLE stock_adjustments DROP CONSTRAINT IF EXISTS stock_adjustments_reason_check;
+ALTER TABLE stock_adjustments ADD CONSTRAINT stock_adjustments_reason_check
+    CHECK (reason IN ('damage', 'recount', 'sale', 'return', 'purchase'));
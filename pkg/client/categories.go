@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type CategoriesService struct {
+	client *Client
+}
+
+func (s *CategoriesService) Create(ctx context.Context, req CategoryRequest) (*Category, error) {
+	var out Category
+	if err := s.client.do(ctx, http.MethodPost, "/v1/categories", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *CategoriesService) Get(ctx context.Context, id uuid.UUID) (*Category, error) {
+	var out Category
+	if err := s.client.do(ctx, http.MethodGet, "/v1/categories/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// List returns a page of categories. Filter on "roots" to walk the tree
+// from the top and on "parent_id" to list the children of a category.
+func (s *CategoriesService) List(ctx context.Context, opts ListOptions) ([]Category, error) {
+	var out []Category
+	if err := s.client.do(ctx, http.MethodGet, "/v1/categories", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *CategoriesService) All(ctx context.Context, opts ListOptions) iter.Seq2[Category, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+func (s *CategoriesService) Update(ctx context.Context, id uuid.UUID, req CategoryRequest) (*Category, error) {
+	var out Category
+	if err := s.client.do(ctx, http.MethodPut, "/v1/categories/"+id.String(), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *CategoriesService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/categories/"+id.String(), nil, nil, nil)
+}
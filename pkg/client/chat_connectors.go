@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ChatConnectorsService manages the Slack and Microsoft Teams connectors
+// events are posted to. Every endpoint is admin only.
+type ChatConnectorsService struct {
+	client *Client
+}
+
+func (s *ChatConnectorsService) Create(ctx context.Context, req ChatConnectorRequest) (*ChatConnector, error) {
+	var out ChatConnector
+	if err := s.client.do(ctx, http.MethodPost, "/v1/admin/chat-connectors", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ChatConnectorsService) Get(ctx context.Context, id uuid.UUID) (*ChatConnector, error) {
+	var out ChatConnector
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/chat-connectors/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// List returns the connectors by name. Filter by "provider" or "project_id".
+func (s *ChatConnectorsService) List(ctx context.Context, opts ListOptions) ([]ChatConnector, error) {
+	var out []ChatConnector
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/chat-connectors", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *ChatConnectorsService) All(ctx context.Context, opts ListOptions) iter.Seq2[ChatConnector, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+// Update replaces everything but the provider, which cannot change and is
+// ignored.
+func (s *ChatConnectorsService) Update(ctx context.Context, id uuid.UUID, req ChatConnectorRequest) (*ChatConnector, error) {
+	var out ChatConnector
+	if err := s.client.do(ctx, http.MethodPut, "/v1/admin/chat-connectors/"+id.String(), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ChatConnectorsService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/admin/chat-connectors/"+id.String(), nil, nil, nil)
+}
+
+// Test posts a test message through the connector. A message the provider
+// rejects is reported in the result rather than as an error.
+func (s *ChatConnectorsService) Test(ctx context.Context, id uuid.UUID) (*ChatTestResult, error) {
+	var out ChatTestResult
+	if err := s.client.do(ctx, http.MethodPost, "/v1/admin/chat-connectors/"+id.String()+"/test", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,509 @@
+// Package client is a typed Go client for the Golang API REST service.
+//
+//	c := client.New("http://localhost:8080")
+//	if _, err := c.Login(ctx, "admin@example.com", "secret"); err != nil {
+//		return err
+//	}
+//	for product, err := range c.Products.All(ctx, client.ListOptions{Limit: 100}) {
+//		...
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 3
+	defaultRetryWait  = 200 * time.Millisecond
+	defaultPageSize   = 100
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	maxRetries int
+	retryWait  time.Duration
+	userAgent  string
+
+	mu           sync.RWMutex
+	token        string
+	refreshToken string
+
+	Users               *UsersService
+	Products            *ProductsService
+	Categories          *CategoriesService
+	Projects            *ProjectsService
+	ProjectItems        *ProjectItemsService
+	Coupons             *CouponsService
+	Warehouses          *WarehousesService
+	PurchaseOrders      *PurchaseOrdersService
+	Orders              *OrdersService
+	Notifications       *NotificationsService
+	CustomFields        *CustomFieldsService
+	SavedFilters        *SavedFiltersService
+	Reports             *ReportsService
+	ReportSubscriptions *ReportSubscriptionsService
+	Retention           *RetentionService
+	Policies            *PoliciesService
+	ChatConnectors      *ChatConnectorsService
+	ExchangeRates       *ExchangeRatesService
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithRetries sets how many times idempotent requests are retried on network
+// errors, 429 and 5xx responses. Waits double after every attempt, and are
+// stretched to the Retry-After the API asks for.
+func WithRetries(maxRetries int, wait time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryWait = wait
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		maxRetries: defaultMaxRetries,
+		retryWait:  defaultRetryWait,
+		userAgent:  "golang-api-rest-client",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Users = &UsersService{client: c}
+	c.Products = &ProductsService{client: c}
+	c.Categories = &CategoriesService{client: c}
+	c.Projects = &ProjectsService{client: c}
+	c.ProjectItems = &ProjectItemsService{client: c}
+	c.Coupons = &CouponsService{client: c}
+	c.Warehouses = &WarehousesService{client: c}
+	c.PurchaseOrders = &PurchaseOrdersService{client: c}
+	c.Orders = &OrdersService{client: c}
+	c.Notifications = &NotificationsService{client: c}
+	c.CustomFields = &CustomFieldsService{client: c}
+	c.SavedFilters = &SavedFiltersService{client: c}
+	c.Reports = &ReportsService{client: c}
+	c.ReportSubscriptions = &ReportSubscriptionsService{client: c}
+	c.Retention = &RetentionService{client: c}
+	c.Policies = &PoliciesService{client: c}
+	c.ChatConnectors = &ChatConnectorsService{client: c}
+	c.ExchangeRates = &ExchangeRatesService{client: c}
+
+	return c
+}
+
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// RefreshToken is the refresh token stored by the last Login,
+// ChangePassword or Refresh, if the server issued one.
+func (c *Client) RefreshToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.refreshToken
+}
+
+// tokenResponse is what the login, password and refresh endpoints return.
+// Accounts with two-factor authentication get a challenge instead of
+// tokens from login.
+type tokenResponse struct {
+	Token          string    `json:"token"`
+	RefreshToken   string    `json:"refresh_token"`
+	MFARequired    bool      `json:"mfa_required"`
+	ChallengeToken string    `json:"challenge_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// MFARequiredError is returned by Login and ChangePassword for accounts
+// with two-factor authentication. Pass ChallengeToken with a code from the
+// authenticator app to VerifyMFA before ExpiresAt.
+type MFARequiredError struct {
+	ChallengeToken string
+	ExpiresAt      time.Time
+}
+
+func (e *MFARequiredError) Error() string {
+	return "client: two-factor code required, call VerifyMFA"
+}
+
+// authenticate posts body to path and stores the tokens returned.
+func (c *Client) authenticate(ctx context.Context, path string, body any) (string, error) {
+	var resp tokenResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, body, &resp); err != nil {
+		return "", err
+	}
+	if resp.MFARequired {
+		return "", &MFARequiredError{ChallengeToken: resp.ChallengeToken, ExpiresAt: resp.ExpiresAt}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = resp.Token
+	c.refreshToken = resp.RefreshToken
+	return resp.Token, nil
+}
+
+// Register creates an account with the user role and uses the tokens issued
+// for it, so no Login is needed afterwards.
+func (c *Client) Register(ctx context.Context, name, email, password string) (string, error) {
+	body := map[string]string{"name": name, "email": email, "password": password}
+	return c.authenticate(ctx, "/v1/auth/register", body)
+}
+
+// Login exchanges credentials for a JWT and uses it for subsequent requests.
+func (c *Client) Login(ctx context.Context, email, password string) (string, error) {
+	body := map[string]string{"email": email, "password": password}
+	return c.authenticate(ctx, "/v1/auth/login", body)
+}
+
+// VerifyMFA finishes a Login that failed with MFARequiredError and uses the
+// token issued.
+func (c *Client) VerifyMFA(ctx context.Context, challengeToken, code string) (string, error) {
+	body := map[string]string{"challenge_token": challengeToken, "code": code}
+	return c.authenticate(ctx, "/v1/auth/mfa/verify", body)
+}
+
+// Refresh trades the stored refresh token for a new access token and a new
+// refresh token, without sending credentials again.
+func (c *Client) Refresh(ctx context.Context) (string, error) {
+	refreshToken := c.RefreshToken()
+	if refreshToken == "" {
+		return "", errors.New("client: no refresh token, call Login first")
+	}
+	body := map[string]string{"refresh_token": refreshToken}
+	return c.authenticate(ctx, "/v1/auth/refresh", body)
+}
+
+// Logout revokes the stored access and refresh tokens and forgets them.
+func (c *Client) Logout(ctx context.Context) error {
+	if c.Token() != "" || c.RefreshToken() != "" {
+		body := map[string]string{"refresh_token": c.RefreshToken()}
+		if err := c.do(ctx, http.MethodPost, "/v1/auth/logout", nil, body, nil); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = ""
+	c.refreshToken = ""
+	return nil
+}
+
+// ChangePassword replaces the account's password and stores the token issued
+// for it. Use it when Login fails with 403 because the password expired.
+func (c *Client) ChangePassword(ctx context.Context, email, currentPassword, newPassword string) (string, error) {
+	body := map[string]string{"email": email, "current_password": currentPassword, "new_password": newPassword}
+	return c.authenticate(ctx, "/v1/auth/password", body)
+}
+
+// ForgotPassword asks for a password reset token to be emailed to the
+// account with email. It succeeds whether or not that account exists.
+func (c *Client) ForgotPassword(ctx context.Context, email string) error {
+	body := map[string]string{"email": email}
+	return c.do(ctx, http.MethodPost, "/v1/auth/forgot-password", nil, body, nil)
+}
+
+// ResetPassword sets a new password with a token from ForgotPassword. Call
+// Login afterwards to get a token.
+func (c *Client) ResetPassword(ctx context.Context, token, newPassword string) error {
+	body := map[string]string{"token": token, "new_password": newPassword}
+	return c.do(ctx, http.MethodPost, "/v1/auth/reset-password", nil, body, nil)
+}
+
+// RestoreAccount undoes the deletion of the account with these credentials
+// while its grace period lasts. Call Login afterwards to get a token.
+func (c *Client) RestoreAccount(ctx context.Context, email, password string) (*User, error) {
+	var out User
+	body := map[string]string{"email": email, "password": password}
+	if err := c.do(ctx, http.MethodPost, "/v1/auth/restore", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Ready reports whether the server answers its readiness probe.
+func (c *Client) Ready(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/health/ready", nil, nil, nil)
+}
+
+type APIError struct {
+	StatusCode int
+	Message    string
+	// Fields lists each invalid request field when the API rejected the
+	// body with 400.
+	Fields []FieldError
+}
+
+type FieldError struct {
+	Field   string   `json:"field"`
+	Message string   `json:"message"`
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("api error: status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("api error: status %d: %s", e.StatusCode, e.Message)
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+type ListOptions struct {
+	Limit  int
+	Offset int
+	Sort   string
+	// Filters are passed through as query parameters, e.g. "category" or
+	// "created_at_from".
+	Filters map[string]string
+	// SavedFilter applies a saved filter; Filters and Sort take precedence
+	// over its values.
+	SavedFilter uuid.UUID
+}
+
+func (o ListOptions) query() url.Values {
+	q := url.Values{}
+	for key, value := range o.Filters {
+		q.Set(key, value)
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+	if o.SavedFilter != uuid.Nil {
+		q.Set("saved_filter", o.SavedFilter.String())
+	}
+	return q
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	retries := 0
+	if method != http.MethodPost && method != http.MethodPatch {
+		retries = c.maxRetries
+	}
+
+	wait := c.retryWait
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, target, payload, "application/json")
+		if err == nil && !retryable(resp.StatusCode) {
+			defer resp.Body.Close()
+			return decodeResponse(resp, out)
+		}
+
+		if attempt >= retries {
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			return decodeResponse(resp, out)
+		}
+		delay := wait
+		if resp != nil {
+			if after := retryAfter(resp); after > delay {
+				delay = after
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		wait *= 2
+	}
+}
+
+// upload posts content as the file field of a multipart form. Uploads are
+// not retried, like any other POST.
+func (c *Client) upload(ctx context.Context, path, field, fileName string, content io.Reader, out interface{}) error {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile(field, fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	resp, err := c.send(ctx, http.MethodPost, c.baseURL+path, buf.Bytes(), w.FormDataContentType())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeResponse(resp, out)
+}
+
+func (c *Client) send(ctx context.Context, method, target string, payload []byte, contentType string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if payload != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if token := c.Token(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// retryAfter reads the Retry-After seconds of a throttled response, or zero
+// when there are none.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+func decodeResponse(resp *http.Response, out interface{}) error {
+	if resp.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		var payload struct {
+			Error  string       `json:"error"`
+			Fields []FieldError `json:"fields"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err == nil {
+			apiErr.Message = payload.Error
+			apiErr.Fields = payload.Fields
+		}
+		return apiErr
+	}
+
+	if raw, ok := out.(*rawBody); ok {
+		raw.StatusCode = resp.StatusCode
+		var err error
+		raw.Data, err = io.ReadAll(resp.Body)
+		return err
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// rawBody receives a successful response undecoded, for endpoints that do not
+// always answer with JSON.
+type rawBody struct {
+	StatusCode int
+	Data       []byte
+}
+
+// paginate walks a list endpoint page by page until a short page is returned.
+func paginate[T any](ctx context.Context, opts ListOptions, list func(ctx context.Context, opts ListOptions) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if opts.Limit <= 0 {
+			opts.Limit = defaultPageSize
+		}
+
+		for {
+			page, err := list(ctx, opts)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, record := range page {
+				if !yield(record, nil) {
+					return
+				}
+			}
+
+			if len(page) < opts.Limit {
+				return
+			}
+			opts.Offset += len(page)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type CouponsService struct {
+	client *Client
+}
+
+type couponCartRequest struct {
+	Code  string     `json:"code,omitempty"`
+	Items []CartLine `json:"items"`
+}
+
+func (s *CouponsService) Create(ctx context.Context, req CreateCouponRequest) (*Coupon, error) {
+	var out Coupon
+	if err := s.client.do(ctx, http.MethodPost, "/v1/coupons", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *CouponsService) Get(ctx context.Context, id uuid.UUID) (*Coupon, error) {
+	var out Coupon
+	if err := s.client.do(ctx, http.MethodGet, "/v1/coupons/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *CouponsService) List(ctx context.Context, opts ListOptions) ([]Coupon, error) {
+	var out []Coupon
+	if err := s.client.do(ctx, http.MethodGet, "/v1/coupons", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *CouponsService) All(ctx context.Context, opts ListOptions) iter.Seq2[Coupon, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+func (s *CouponsService) Update(ctx context.Context, id uuid.UUID, record Coupon) (*Coupon, error) {
+	var out Coupon
+	if err := s.client.do(ctx, http.MethodPut, "/v1/coupons/"+id.String(), nil, record, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *CouponsService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/coupons/"+id.String(), nil, nil, nil)
+}
+
+// Validate prices the cart with the coupon applied without consuming a use.
+func (s *CouponsService) Validate(ctx context.Context, code string, items []CartLine) (*CartQuote, error) {
+	var out CartQuote
+	if err := s.client.do(ctx, http.MethodPost, "/v1/coupons/validate", nil, couponCartRequest{Code: code, Items: items}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *CouponsService) Redeem(ctx context.Context, code string, items []CartLine) (*CartQuote, error) {
+	var out CartQuote
+	if err := s.client.do(ctx, http.MethodPost, "/v1/coupons/redeem", nil, couponCartRequest{Code: code, Items: items}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type CustomFieldsService struct {
+	client *Client
+}
+
+func (s *CustomFieldsService) Create(ctx context.Context, req CreateCustomFieldRequest) (*CustomField, error) {
+	var out CustomField
+	if err := s.client.do(ctx, http.MethodPost, "/v1/custom-fields", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *CustomFieldsService) Get(ctx context.Context, id uuid.UUID) (*CustomField, error) {
+	var out CustomField
+	if err := s.client.do(ctx, http.MethodGet, "/v1/custom-fields/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// List returns the definitions matching opts. Filter by "entity" and by
+// "project_id" to get the fields applying to a project's items, global ones
+// included unless "exact_scope" is "true".
+func (s *CustomFieldsService) List(ctx context.Context, opts ListOptions) ([]CustomField, error) {
+	var out []CustomField
+	if err := s.client.do(ctx, http.MethodGet, "/v1/custom-fields", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *CustomFieldsService) Update(ctx context.Context, id uuid.UUID, req UpdateCustomFieldRequest) (*CustomField, error) {
+	var out CustomField
+	if err := s.client.do(ctx, http.MethodPut, "/v1/custom-fields/"+id.String(), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *CustomFieldsService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/custom-fields/"+id.String(), nil, nil, nil)
+}
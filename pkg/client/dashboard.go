@@ -0,0 +1,15 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Dashboard returns the authenticated user's landing screen summary.
+func (c *Client) Dashboard(ctx context.Context) (*Dashboard, error) {
+	var out Dashboard
+	if err := c.do(ctx, http.MethodGet, "/v1/dashboard", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+)
+
+// ExchangeRatesService reads the exchange rates of the store currency. Every
+// endpoint but List is admin only.
+type ExchangeRatesService struct {
+	client *Client
+}
+
+// List returns how many units of each currency one unit of the store
+// currency buys, as last synced.
+func (s *ExchangeRatesService) List(ctx context.Context) ([]ExchangeRate, error) {
+	var out []ExchangeRate
+	if err := s.client.do(ctx, http.MethodGet, "/v1/exchange-rates", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Refresh syncs the rates from the provider now. A provider failure is
+// reported in the returned sync's Error; a 503 APIError means no provider
+// is configured.
+func (s *ExchangeRatesService) Refresh(ctx context.Context) (*ExchangeRateSync, error) {
+	var out ExchangeRateSync
+	if err := s.client.do(ctx, http.MethodPost, "/v1/admin/exchange-rates/refresh", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Syncs returns the recorded syncs, newest first.
+func (s *ExchangeRatesService) Syncs(ctx context.Context, opts ListOptions) ([]ExchangeRateSync, error) {
+	var out []ExchangeRateSync
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/exchange-rates/syncs", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AllSyncs iterates over every sync, fetching one page at a time.
+func (s *ExchangeRatesService) AllSyncs(ctx context.Context, opts ListOptions) iter.Seq2[ExchangeRateSync, error] {
+	return paginate(ctx, opts, s.Syncs)
+}
+
+// Metrics tells how old the stored rates are and how the recent syncs went.
+func (s *ExchangeRatesService) Metrics(ctx context.Context) (*ExchangeRateMetrics, error) {
+	var out ExchangeRateMetrics
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/exchange-rates/metrics", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
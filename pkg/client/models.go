@@ -0,0 +1,972 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type User struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	Locale         string     `json:"locale"`
+	Active         bool       `json:"active"`
+	SuspendedUntil *time.Time `json:"suspended_until"`
+	LastLoginAt    *time.Time `json:"last_login_at"`
+	LoginCount     int        `json:"login_count"`
+	MFAEnabled     bool       `json:"mfa_enabled"`
+	// LockedUntil is set while failed logins keep the account locked.
+	LockedUntil *time.Time `json:"locked_until"`
+	// PasswordChangedAt is nil for accounts created before it was tracked.
+	PasswordChangedAt *time.Time `json:"password_changed_at"`
+	// AnonymizedAt is set once retention replaced the account's personal data.
+	AnonymizedAt *time.Time `json:"anonymized_at"`
+	// EraseAfter is when a deleted account stops being restorable and its
+	// personal data is erased.
+	EraseAfter *time.Time `json:"erase_after"`
+	Version    int        `json:"version"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+}
+
+type Product struct {
+	ID           uuid.UUID            `json:"id"`
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Price        float64              `json:"price"`
+	CostPrice    *float64             `json:"cost_price"`
+	Stock        int                  `json:"stock"`
+	ReorderLevel *int                 `json:"reorder_level"`
+	CategoryID   *uuid.UUID           `json:"category_id"`
+	Category     string               `json:"category"`
+	SKU          string               `json:"sku"`
+	Barcode      *string              `json:"barcode"`
+	ArchivedAt   *time.Time           `json:"archived_at"`
+	Availability *ProductAvailability `json:"availability,omitempty"`
+	Version      int                  `json:"version"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+	DeletedAt    *time.Time           `json:"deleted_at"`
+}
+
+type Category struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type WarehouseStockLevel struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseCode string    `json:"warehouse_code"`
+	Quantity      int       `json:"quantity"`
+}
+
+type ProductAvailability struct {
+	Total       int                   `json:"total"`
+	Unallocated int                   `json:"unallocated"`
+	Warehouses  []WarehouseStockLevel `json:"warehouses"`
+}
+
+type StockAdjustment struct {
+	ID          uuid.UUID  `json:"id"`
+	ProductID   uuid.UUID  `json:"product_id"`
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	Quantity    int        `json:"quantity"`
+	Reason      string     `json:"reason"`
+	Note        string     `json:"note"`
+	ActorID     uuid.UUID  `json:"actor_id"`
+	StockBefore int        `json:"stock_before"`
+	StockAfter  int        `json:"stock_after"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// StockMovement is an adjustment or, with Reason "transfer", a move between
+// warehouses. StockBefore and StockAfter are nil for transfers.
+type StockMovement struct {
+	ID              uuid.UUID  `json:"id"`
+	ProductID       uuid.UUID  `json:"product_id"`
+	Reason          string     `json:"reason"`
+	Quantity        int        `json:"quantity"`
+	FromWarehouseID *uuid.UUID `json:"from_warehouse_id"`
+	ToWarehouseID   *uuid.UUID `json:"to_warehouse_id"`
+	StockBefore     *int       `json:"stock_before"`
+	StockAfter      *int       `json:"stock_after"`
+	Note            string     `json:"note"`
+	ActorID         uuid.UUID  `json:"actor_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Attachment is an uploaded file, such as a product image. URL downloads it
+// without a token until URLExpiresAt; list the images again for a new one.
+type Attachment struct {
+	ID           uuid.UUID  `json:"id"`
+	OwnerType    string     `json:"owner_type"`
+	OwnerID      uuid.UUID  `json:"owner_id"`
+	FileName     string     `json:"file_name"`
+	ContentType  string     `json:"content_type"`
+	Size         int64      `json:"size"`
+	UploadedBy   uuid.UUID  `json:"uploaded_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	URL          string     `json:"url"`
+	URLExpiresAt *time.Time `json:"url_expires_at"`
+}
+
+type Warehouse struct {
+	ID        uuid.UUID  `json:"id"`
+	Code      string     `json:"code"`
+	Name      string     `json:"name"`
+	Address   string     `json:"address"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type WarehouseStock struct {
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	Quantity    int       `json:"quantity"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type StockTransfer struct {
+	ID              uuid.UUID  `json:"id"`
+	ProductID       uuid.UUID  `json:"product_id"`
+	FromWarehouseID *uuid.UUID `json:"from_warehouse_id"`
+	ToWarehouseID   *uuid.UUID `json:"to_warehouse_id"`
+	Quantity        int        `json:"quantity"`
+	Note            string     `json:"note"`
+	ActorID         uuid.UUID  `json:"actor_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Suggestion is one entry of a typeahead list.
+type Suggestion struct {
+	ID    uuid.UUID `json:"id"`
+	Label string    `json:"label"`
+}
+
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type PriceRangeBucket struct {
+	From  float64  `json:"from"`
+	To    *float64 `json:"to"`
+	Count int64    `json:"count"`
+}
+
+type StockAvailability struct {
+	InStock    int64 `json:"in_stock"`
+	OutOfStock int64 `json:"out_of_stock"`
+}
+
+type ProductFacets struct {
+	Categories []FacetBucket      `json:"categories"`
+	Price      []PriceRangeBucket `json:"price"`
+	Stock      *StockAvailability `json:"stock"`
+}
+
+type ProductFacetsPage struct {
+	Data   []Product      `json:"data"`
+	Meta   PageMeta       `json:"meta"`
+	Facets *ProductFacets `json:"facets"`
+}
+
+// PageMeta places a page in the whole list: Total records match the
+// filters, split into Pages pages of Limit.
+type PageMeta struct {
+	Total  int64 `json:"total"`
+	Limit  int   `json:"limit"`
+	Offset int   `json:"offset"`
+	Pages  int64 `json:"pages"`
+}
+
+// Page is one page of a list endpoint that reports totals.
+// ProductSearchHit is a product found by ProductsService.Search. The
+// highlights are HTML-escaped, with the matched words in <mark> tags.
+type ProductSearchHit struct {
+	Product    Product           `json:"product"`
+	Rank       float64           `json:"rank"`
+	Highlights ProductHighlights `json:"highlights"`
+}
+
+type ProductHighlights struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type Page[T any] struct {
+	Data []T      `json:"data"`
+	Meta PageMeta `json:"meta"`
+}
+
+type Project struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	StartDate   *time.Time `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+	Budget      *float64   `json:"budget"`
+	OwnerID     uuid.UUID  `json:"owner_id"`
+	ArchivedAt  *time.Time `json:"archived_at"`
+	Progress    *float64   `json:"progress"`
+	// CustomFields holds custom field values by key. Leave it nil on
+	// update to keep the stored values.
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Version      int                    `json:"version"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+	DeletedAt    *time.Time             `json:"deleted_at"`
+}
+
+type ProjectExport struct {
+	ID          uuid.UUID  `json:"id"`
+	ProjectID   uuid.UUID  `json:"project_id"`
+	Format      string     `json:"format"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error"`
+	RequestedBy uuid.UUID  `json:"requested_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// UserExport is a zip archive of the caller's data. Status is "pending",
+// "ready" or "failed"; a ready export can be downloaded until ExpiresAt.
+type UserExport struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error"`
+	Size        int        `json:"size"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type CalendarFeed struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+type CalendarFeedToken struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Expense struct {
+	ID          uuid.UUID  `json:"id"`
+	ProjectID   uuid.UUID  `json:"project_id"`
+	Amount      float64    `json:"amount"`
+	Category    string     `json:"category"`
+	Description string     `json:"description"`
+	Date        time.Time  `json:"date"`
+	ReceiptURL  string     `json:"receipt_url"`
+	CreatedBy   uuid.UUID  `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+}
+
+type ExpenseCategoryTotal struct {
+	Category string  `json:"category"`
+	Amount   float64 `json:"amount"`
+	Count    int64   `json:"count"`
+}
+
+type ProjectBudgetStats struct {
+	ProjectID   uuid.UUID              `json:"project_id"`
+	Budget      *float64               `json:"budget"`
+	Spent       float64                `json:"spent"`
+	Remaining   *float64               `json:"remaining"`
+	Consumption *float64               `json:"consumption"`
+	OverBudget  bool                   `json:"over_budget"`
+	Categories  []ExpenseCategoryTotal `json:"categories"`
+	Warnings    []string               `json:"warnings"`
+}
+
+type HoursByStatus struct {
+	Status    string  `json:"status"`
+	Estimated float64 `json:"estimated"`
+	Actual    float64 `json:"actual"`
+	Items     int64   `json:"items"`
+}
+
+type HoursByAssignee struct {
+	AssignedTo *uuid.UUID `json:"assigned_to"`
+	Estimated  float64    `json:"estimated"`
+	Actual     float64    `json:"actual"`
+	Items      int64      `json:"items"`
+}
+
+// HoursByWeek.Week is nil for items without a due date.
+type HoursByWeek struct {
+	Week      *time.Time `json:"week"`
+	Estimated float64    `json:"estimated"`
+	Actual    float64    `json:"actual"`
+	Items     int64      `json:"items"`
+}
+
+type HoursRollup struct {
+	Estimated  float64           `json:"estimated"`
+	Actual     float64           `json:"actual"`
+	Items      int64             `json:"items"`
+	ByStatus   []HoursByStatus   `json:"by_status"`
+	ByAssignee []HoursByAssignee `json:"by_assignee"`
+	ByWeek     []HoursByWeek     `json:"by_week"`
+}
+
+type ProjectItem struct {
+	ID             uuid.UUID  `json:"id"`
+	ProjectID      uuid.UUID  `json:"project_id"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description"`
+	Status         string     `json:"status"`
+	Priority       string     `json:"priority"`
+	EstimatedHours *float64   `json:"estimated_hours"`
+	ActualHours    *float64   `json:"actual_hours"`
+	DueDate        *time.Time `json:"due_date"`
+	AssignedTo     *uuid.UUID `json:"assigned_to"`
+	ParentItemID   *uuid.UUID `json:"parent_item_id"`
+	// CustomFields holds custom field values by key. Leave it nil on
+	// update to keep the stored values.
+	CustomFields map[string]interface{} `json:"custom_fields"`
+	Version      int                    `json:"version"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+	DeletedAt    *time.Time             `json:"deleted_at"`
+	// Rollup is only set by ProjectItemsService.ListByProject.
+	Rollup *ProjectItemRollup `json:"rollup,omitempty"`
+}
+
+// ProjectItemRollup sums the hours of an item and of all its sub-tasks.
+type ProjectItemRollup struct {
+	EstimatedHours float64 `json:"estimated_hours"`
+	ActualHours    float64 `json:"actual_hours"`
+	SubTasks       int     `json:"sub_tasks"`
+}
+
+type ProjectItemAssignment struct {
+	ID           uuid.UUID  `json:"id"`
+	ItemID       uuid.UUID  `json:"item_id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	AssignedAt   time.Time  `json:"assigned_at"`
+	UnassignedAt *time.Time `json:"unassigned_at"`
+}
+
+// ProjectItemTransition is one status change of an item. ActorID is nil for
+// changes made by editing the item.
+type ProjectItemTransition struct {
+	ID             uuid.UUID  `json:"id"`
+	ItemID         uuid.UUID  `json:"item_id"`
+	FromStatus     string     `json:"from_status"`
+	ToStatus       string     `json:"to_status"`
+	ActorID        *uuid.UUID `json:"actor_id"`
+	TransitionedAt time.Time  `json:"transitioned_at"`
+}
+
+type BoardColumn struct {
+	Status string        `json:"status"`
+	Items  []ProjectItem `json:"items"`
+	Total  int64         `json:"total"`
+}
+
+// ProjectBoard is a project's items in one column per status. Limit and
+// Offset are the page every column was read with.
+type ProjectBoard struct {
+	ProjectID uuid.UUID     `json:"project_id"`
+	Limit     int           `json:"limit"`
+	Offset    int           `json:"offset"`
+	Columns   []BoardColumn `json:"columns"`
+}
+
+type Comment struct {
+	ID        uuid.UUID  `json:"id"`
+	ItemID    uuid.UUID  `json:"item_id"`
+	AuthorID  uuid.UUID  `json:"author_id"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type Watch struct {
+	UserID     uuid.UUID `json:"user_id"`
+	TargetType string    `json:"target_type"`
+	TargetID   uuid.UUID `json:"target_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type Notification struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	TargetType string     `json:"target_type"`
+	TargetID   uuid.UUID  `json:"target_id"`
+	ProjectID  uuid.UUID  `json:"project_id"`
+	Event      string     `json:"event"`
+	Message    string     `json:"message"`
+	ReadAt     *time.Time `json:"read_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type Device struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Platform   string    `json:"platform"`
+	Token      string    `json:"token"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type NotificationPreferences struct {
+	UserID       uuid.UUID `json:"user_id"`
+	PushAssigned bool      `json:"push_assigned"`
+	PushDueSoon  bool      `json:"push_due_soon"`
+	PushChanges  bool      `json:"push_changes"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type Coupon struct {
+	ID          uuid.UUID  `json:"id"`
+	Code        string     `json:"code"`
+	Description string     `json:"description"`
+	Type        string     `json:"type"`
+	Value       float64    `json:"value"`
+	Category    string     `json:"category"`
+	ProductID   *uuid.UUID `json:"product_id"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	MaxUses     *int       `json:"max_uses"`
+	UsedCount   int        `json:"used_count"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+}
+
+type PurchaseOrder struct {
+	ID          uuid.UUID           `json:"id"`
+	Supplier    string              `json:"supplier"`
+	Status      string              `json:"status"`
+	WarehouseID *uuid.UUID          `json:"warehouse_id"`
+	Notes       string              `json:"notes"`
+	CreatedBy   uuid.UUID           `json:"created_by"`
+	Lines       []PurchaseOrderLine `json:"lines"`
+	SubmittedAt *time.Time          `json:"submitted_at"`
+	ReceivedAt  *time.Time          `json:"received_at"`
+	ReceivedBy  *uuid.UUID          `json:"received_by"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	DeletedAt   *time.Time          `json:"deleted_at"`
+}
+
+type PurchaseOrderLine struct {
+	ID              uuid.UUID `json:"id,omitempty"`
+	PurchaseOrderID uuid.UUID `json:"purchase_order_id,omitempty"`
+	ProductID       uuid.UUID `json:"product_id"`
+	Quantity        int       `json:"quantity"`
+	UnitCost        float64   `json:"unit_cost"`
+}
+
+type Order struct {
+	ID          uuid.UUID   `json:"id"`
+	UserID      uuid.UUID   `json:"user_id"`
+	Status      string      `json:"status"`
+	WarehouseID *uuid.UUID  `json:"warehouse_id"`
+	Notes       string      `json:"notes"`
+	CouponID    *uuid.UUID  `json:"coupon_id"`
+	CouponCode  string      `json:"coupon_code"`
+	Subtotal    float64     `json:"subtotal"`
+	Discount    float64     `json:"discount"`
+	Total       float64     `json:"total"`
+	Items       []OrderItem `json:"items"`
+	PaidAt      *time.Time  `json:"paid_at"`
+	ShippedAt   *time.Time  `json:"shipped_at"`
+	DeliveredAt *time.Time  `json:"delivered_at"`
+	CancelledAt *time.Time  `json:"cancelled_at"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	DeletedAt   *time.Time  `json:"deleted_at"`
+}
+
+type OrderItem struct {
+	ID        uuid.UUID `json:"id,omitempty"`
+	OrderID   uuid.UUID `json:"order_id,omitempty"`
+	ProductID uuid.UUID `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+	UnitPrice float64   `json:"unit_price,omitempty"`
+}
+
+type CartLine struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+}
+
+type CartQuoteLine struct {
+	ProductID uuid.UUID `json:"product_id"`
+	SKU       string    `json:"sku"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	Quantity  int       `json:"quantity"`
+	UnitPrice float64   `json:"unit_price"`
+	LineTotal float64   `json:"line_total"`
+	Eligible  bool      `json:"eligible"`
+}
+
+type CartQuote struct {
+	CouponCode string          `json:"coupon_code"`
+	Lines      []CartQuoteLine `json:"lines"`
+	Subtotal   float64         `json:"subtotal"`
+	Discount   float64         `json:"discount"`
+	Total      float64         `json:"total"`
+}
+
+type CreateUserRequest struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// MFAEnrollment is a TOTP secret to add to an authenticator app. URI is the
+// otpauth:// link to show as a QR code.
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_url"`
+}
+
+// UpdateProfileRequest is what users may change about themselves. An empty
+// Locale keeps the current one.
+type UpdateProfileRequest struct {
+	Name   string `json:"name"`
+	Locale string `json:"locale,omitempty"`
+}
+
+// CreateProductRequest names the category by CategoryID or by the name of
+// an existing category; CategoryID wins when both are set.
+type CreateProductRequest struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Price       float64    `json:"price"`
+	Stock       int        `json:"stock"`
+	Category    string     `json:"category,omitempty"`
+	CategoryID  *uuid.UUID `json:"category_id,omitempty"`
+	SKU         string     `json:"sku,omitempty"`
+	Barcode     string     `json:"barcode,omitempty"`
+}
+
+// CategoryRequest creates or replaces a category. A nil ParentID makes it
+// top-level.
+type CategoryRequest struct {
+	Name     string     `json:"name"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+type CreateProjectRequest struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+	Budget      *float64   `json:"budget,omitempty"`
+	OwnerID     uuid.UUID  `json:"owner_id"`
+	// CustomFields holds values for the project custom fields by key.
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+type CreateProjectItemRequest struct {
+	ProjectID      uuid.UUID  `json:"project_id"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	Priority       string     `json:"priority,omitempty"`
+	EstimatedHours *float64   `json:"estimated_hours,omitempty"`
+	ActualHours    *float64   `json:"actual_hours,omitempty"`
+	DueDate        *time.Time `json:"due_date,omitempty"`
+	AssignedTo     *uuid.UUID `json:"assigned_to,omitempty"`
+	ParentItemID   *uuid.UUID `json:"parent_item_id,omitempty"`
+	// CustomFields holds values for the item custom fields by key.
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+}
+
+// CustomField is a custom field definition. Entity is "project" or "item"
+// and Type one of "text", "number", "date" or "select".
+type CustomField struct {
+	ID        uuid.UUID  `json:"id"`
+	Entity    string     `json:"entity"`
+	ProjectID *uuid.UUID `json:"project_id"`
+	Key       string     `json:"key"`
+	Label     string     `json:"label"`
+	Type      string     `json:"type"`
+	Options   []string   `json:"options"`
+	Required  bool       `json:"required"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type CreateCustomFieldRequest struct {
+	Entity    string     `json:"entity"`
+	ProjectID *uuid.UUID `json:"project_id,omitempty"`
+	Key       string     `json:"key"`
+	Label     string     `json:"label,omitempty"`
+	Type      string     `json:"type"`
+	Options   []string   `json:"options,omitempty"`
+	Required  bool       `json:"required,omitempty"`
+}
+
+type UpdateCustomFieldRequest struct {
+	Label    string   `json:"label"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+}
+
+type CreateCouponRequest struct {
+	Code        string     `json:"code"`
+	Description string     `json:"description,omitempty"`
+	Type        string     `json:"type"`
+	Value       float64    `json:"value"`
+	Category    string     `json:"category,omitempty"`
+	ProductID   *uuid.UUID `json:"product_id,omitempty"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	MaxUses     *int       `json:"max_uses,omitempty"`
+}
+
+type StockAdjustmentRequest struct {
+	Quantity    int        `json:"quantity"`
+	Reason      string     `json:"reason"`
+	Note        string     `json:"note,omitempty"`
+	WarehouseID *uuid.UUID `json:"warehouse_id,omitempty"`
+}
+
+type CreateWarehouseRequest struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Address string `json:"address,omitempty"`
+}
+
+type StockTransferRequest struct {
+	ProductID       uuid.UUID  `json:"product_id"`
+	FromWarehouseID *uuid.UUID `json:"from_warehouse_id,omitempty"`
+	ToWarehouseID   *uuid.UUID `json:"to_warehouse_id,omitempty"`
+	Quantity        int        `json:"quantity"`
+	Note            string     `json:"note,omitempty"`
+}
+
+type PurchaseOrderRequest struct {
+	Supplier    string              `json:"supplier"`
+	WarehouseID *uuid.UUID          `json:"warehouse_id,omitempty"`
+	Notes       string              `json:"notes,omitempty"`
+	Lines       []PurchaseOrderLine `json:"lines"`
+}
+
+type OrderRequest struct {
+	WarehouseID *uuid.UUID  `json:"warehouse_id,omitempty"`
+	Notes       string      `json:"notes,omitempty"`
+	CouponCode  string      `json:"coupon_code,omitempty"`
+	Items       []OrderItem `json:"items"`
+}
+
+type ExpenseRequest struct {
+	Amount      float64    `json:"amount"`
+	Category    string     `json:"category"`
+	Description string     `json:"description,omitempty"`
+	Date        *time.Time `json:"date,omitempty"`
+	ReceiptURL  string     `json:"receipt_url,omitempty"`
+}
+
+// SavedFilter is a named set of list query parameters and a sort order.
+// Entity is "product", "project", "project_item", "user", "coupon" or
+// "purchase_order".
+type SavedFilter struct {
+	ID        uuid.UUID         `json:"id"`
+	UserID    uuid.UUID         `json:"user_id"`
+	Name      string            `json:"name"`
+	Entity    string            `json:"entity"`
+	Query     map[string]string `json:"query"`
+	Sort      string            `json:"sort"`
+	Shared    bool              `json:"shared"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	DeletedAt *time.Time        `json:"deleted_at"`
+}
+
+type SavedFilterRequest struct {
+	Name   string            `json:"name"`
+	Entity string            `json:"entity,omitempty"`
+	Query  map[string]string `json:"query"`
+	Sort   string            `json:"sort"`
+	Shared bool              `json:"shared"`
+}
+
+// ReportSubscription emails a report on a cron schedule. Report is
+// "items-by-status", "stock-by-category" or "projects-budget" and Format
+// "csv" or "pdf"; Filters takes the report endpoint's query parameters.
+type ReportSubscription struct {
+	ID         uuid.UUID         `json:"id"`
+	UserID     uuid.UUID         `json:"user_id"`
+	Name       string            `json:"name"`
+	Report     string            `json:"report"`
+	Format     string            `json:"format"`
+	Filters    map[string]string `json:"filters"`
+	Schedule   string            `json:"schedule"`
+	Recipients []string          `json:"recipients"`
+	Active     bool              `json:"active"`
+	NextRunAt  *time.Time        `json:"next_run_at"`
+	LastRunAt  *time.Time        `json:"last_run_at"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	DeletedAt  *time.Time        `json:"deleted_at"`
+}
+
+// ReportSubscriptionRequest creates or updates a subscription. A nil Active
+// leaves the subscription active.
+type ReportSubscriptionRequest struct {
+	Name       string            `json:"name"`
+	Report     string            `json:"report,omitempty"`
+	Format     string            `json:"format,omitempty"`
+	Filters    map[string]string `json:"filters,omitempty"`
+	Schedule   string            `json:"schedule"`
+	Recipients []string          `json:"recipients"`
+	Active     *bool             `json:"active,omitempty"`
+}
+
+// ReportDelivery is one run of a subscription; Status is "sent" or "failed".
+type ReportDelivery struct {
+	ID             uuid.UUID `json:"id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	Status         string    `json:"status"`
+	Format         string    `json:"format"`
+	Recipients     []string  `json:"recipients"`
+	Rows           int       `json:"rows"`
+	Error          string    `json:"error"`
+	ScheduledFor   time.Time `json:"scheduled_for"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// RetentionRule purges or anonymizes the records of Entity older than
+// RetainDays. Entity is "notification", "report_delivery", "project_export",
+// "stock_adjustment" or "user"; Action is "purge", or "anonymize" for users.
+type RetentionRule struct {
+	ID         uuid.UUID  `json:"id"`
+	Entity     string     `json:"entity"`
+	Action     string     `json:"action"`
+	RetainDays int        `json:"retain_days"`
+	Enabled    bool       `json:"enabled"`
+	LastRunAt  *time.Time `json:"last_run_at"`
+	CreatedBy  uuid.UUID  `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+}
+
+// RetentionRuleRequest creates or updates a rule. An empty Action uses the
+// one the entity supports and a nil Enabled leaves the rule enabled.
+type RetentionRuleRequest struct {
+	Entity     string `json:"entity,omitempty"`
+	Action     string `json:"action,omitempty"`
+	RetainDays int    `json:"retain_days"`
+	Enabled    *bool  `json:"enabled,omitempty"`
+}
+
+// RetentionRun is one evaluation of a rule. TriggeredBy is nil for scheduled
+// runs and Error is set when the run failed part way.
+type RetentionRun struct {
+	ID          uuid.UUID  `json:"id"`
+	RuleID      uuid.UUID  `json:"rule_id"`
+	Entity      string     `json:"entity"`
+	Action      string     `json:"action"`
+	DryRun      bool       `json:"dry_run"`
+	Cutoff      time.Time  `json:"cutoff"`
+	Affected    int64      `json:"affected"`
+	Error       string     `json:"error"`
+	TriggeredBy *uuid.UUID `json:"triggered_by"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  time.Time  `json:"finished_at"`
+}
+
+// ArchivedRecord is a record an archive rule moved out of its table. Payload
+// holds the row under the entity's name, with the rows archived along with
+// it.
+type ArchivedRecord struct {
+	Entity     string          `json:"entity"`
+	RecordID   uuid.UUID       `json:"record_id"`
+	ProjectID  *uuid.UUID      `json:"project_id"`
+	RuleID     *uuid.UUID      `json:"rule_id"`
+	Payload    json.RawMessage `json:"payload"`
+	ArchivedAt time.Time       `json:"archived_at"`
+}
+
+type RetentionMetric struct {
+	Entity    string     `json:"entity"`
+	Action    string     `json:"action"`
+	Runs      int64      `json:"runs"`
+	Failed    int64      `json:"failed"`
+	Affected  int64      `json:"affected"`
+	LastRunAt *time.Time `json:"last_run_at"`
+}
+
+// ExchangeRate is how many units of Currency one unit of Base, the store
+// currency, buys.
+type ExchangeRate struct {
+	Base        string    `json:"base"`
+	Currency    string    `json:"currency"`
+	Rate        float64   `json:"rate"`
+	Provider    string    `json:"provider"`
+	EffectiveAt time.Time `json:"effective_at"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+// ExchangeRateSync is one attempt to refresh the rates. TriggeredBy is nil
+// for scheduled syncs and Error is empty when it succeeded.
+type ExchangeRateSync struct {
+	ID          uuid.UUID  `json:"id"`
+	Provider    string     `json:"provider"`
+	Base        string     `json:"base"`
+	Currencies  int        `json:"currencies"`
+	Error       string     `json:"error"`
+	TriggeredBy *uuid.UUID `json:"triggered_by"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  time.Time  `json:"finished_at"`
+}
+
+type ExchangeRateMetrics struct {
+	Base                string     `json:"base"`
+	Provider            string     `json:"provider"`
+	Currencies          int64      `json:"currencies"`
+	OldestFetchedAt     *time.Time `json:"oldest_fetched_at"`
+	AgeSeconds          int64      `json:"age_seconds"`
+	Stale               bool       `json:"stale"`
+	LastSyncAt          *time.Time `json:"last_sync_at"`
+	LastSuccessAt       *time.Time `json:"last_success_at"`
+	LastError           string     `json:"last_error"`
+	ConsecutiveFailures int64      `json:"consecutive_failures"`
+}
+
+// ChatConnector posts Events, among "project_created",
+// "project_item_completed" and "product_low_stock", to a Slack or Teams
+// channel. ProjectID limits it to one project's events.
+type ChatConnector struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Provider   string     `json:"provider"`
+	WebhookURL string     `json:"webhook_url"`
+	Events     []string   `json:"events"`
+	ProjectID  *uuid.UUID `json:"project_id"`
+	Active     bool       `json:"active"`
+	CreatedBy  uuid.UUID  `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+}
+
+// ChatConnectorRequest creates or updates a connector. Provider is "slack"
+// or "teams"; a nil Active leaves the connector active.
+type ChatConnectorRequest struct {
+	Name       string     `json:"name"`
+	Provider   string     `json:"provider,omitempty"`
+	WebhookURL string     `json:"webhook_url"`
+	Events     []string   `json:"events"`
+	ProjectID  *uuid.UUID `json:"project_id,omitempty"`
+	Active     *bool      `json:"active,omitempty"`
+}
+
+type ChatTestResult struct {
+	Delivered bool      `json:"delivered"`
+	Error     string    `json:"error"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+// PolicyDocument is one published version of a policy. Kind is
+// "terms_of_service" or "privacy_policy".
+type PolicyDocument struct {
+	ID          uuid.UUID `json:"id"`
+	Kind        string    `json:"kind"`
+	Version     string    `json:"version"`
+	Title       string    `json:"title"`
+	Content     string    `json:"content"`
+	PublishedBy uuid.UUID `json:"published_by"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+type PublishPolicyRequest struct {
+	Kind    string `json:"kind"`
+	Version string `json:"version"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+type PolicyAcceptance struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	DocumentID uuid.UUID `json:"document_id"`
+	Kind       string    `json:"kind"`
+	Version    string    `json:"version"`
+	IPAddress  string    `json:"ip_address"`
+	AcceptedAt time.Time `json:"accepted_at"`
+}
+
+type PolicyStatus struct {
+	Accepted []PolicyAcceptance `json:"accepted"`
+	Pending  []PolicyDocument   `json:"pending"`
+}
+
+// ItemsReportRow, StockReportRow and ProjectsReportRow are report rows.
+// Group is the value of the grouped dimension, nil for records without one,
+// and Bucket the start of the date bucket when an interval was requested.
+type ItemsReportRow struct {
+	Group          *string    `json:"group"`
+	Bucket         *time.Time `json:"bucket,omitempty"`
+	Items          int64      `json:"items"`
+	EstimatedHours float64    `json:"estimated_hours"`
+	ActualHours    float64    `json:"actual_hours"`
+}
+
+type StockReportRow struct {
+	Group      *string    `json:"group"`
+	Bucket     *time.Time `json:"bucket,omitempty"`
+	Products   int64      `json:"products"`
+	Stock      int64      `json:"stock"`
+	StockValue float64    `json:"stock_value"`
+	StockCost  float64    `json:"stock_cost"`
+}
+
+type ProjectsReportRow struct {
+	Group     *string    `json:"group"`
+	Bucket    *time.Time `json:"bucket,omitempty"`
+	Projects  int64      `json:"projects"`
+	Budget    float64    `json:"budget"`
+	Spent     float64    `json:"spent"`
+	Remaining float64    `json:"remaining"`
+}
+
+// Dashboard is the authenticated user's landing screen. AssignedItems lists
+// their open items soonest due first and LowStock the products at or below
+// LowStockThreshold.
+type Dashboard struct {
+	AssignedItems     []ProjectItem        `json:"assigned_items"`
+	Items             DashboardItemCounts  `json:"items"`
+	RecentActivity    []Notification       `json:"recent_activity"`
+	OwnedProjects     []ProjectStatusCount `json:"owned_projects"`
+	LowStock          []Product            `json:"low_stock"`
+	LowStockThreshold int                  `json:"low_stock_threshold"`
+	GeneratedAt       time.Time            `json:"generated_at"`
+}
+
+type DashboardItemCounts struct {
+	Open     int64 `json:"open"`
+	Overdue  int64 `json:"overdue"`
+	DueToday int64 `json:"due_today"`
+}
+
+type ProjectStatusCount struct {
+	Status   string `json:"status"`
+	Projects int64  `json:"projects"`
+}
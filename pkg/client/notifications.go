@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type NotificationsService struct {
+	client *Client
+}
+
+// List returns the authenticated user's notifications, newest first. Set the
+// "unread" filter to "true" to skip notifications already read.
+func (s *NotificationsService) List(ctx context.Context, opts ListOptions) ([]Notification, error) {
+	var out []Notification
+	if err := s.client.do(ctx, http.MethodGet, "/v1/notifications", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *NotificationsService) All(ctx context.Context, opts ListOptions) iter.Seq2[Notification, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+func (s *NotificationsService) MarkRead(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodPost, "/v1/notifications/"+id.String()+"/read", nil, nil, nil)
+}
+
+// RegisterDevice registers a device of the authenticated user for push
+// notifications. Platform is "fcm" or "apns"; registering a known token
+// refreshes it.
+func (s *NotificationsService) RegisterDevice(ctx context.Context, platform, token string) (*Device, error) {
+	var out Device
+	body := map[string]string{"platform": platform, "token": token}
+	if err := s.client.do(ctx, http.MethodPost, "/v1/users/me/devices", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *NotificationsService) Devices(ctx context.Context) ([]Device, error) {
+	var out []Device
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users/me/devices", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *NotificationsService) UnregisterDevice(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/users/me/devices/"+id.String(), nil, nil, nil)
+}
+
+func (s *NotificationsService) Preferences(ctx context.Context) (*NotificationPreferences, error) {
+	var out NotificationPreferences
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users/me/notification-preferences", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdatePreferences replaces every preference of the authenticated user.
+func (s *NotificationsService) UpdatePreferences(ctx context.Context, preferences NotificationPreferences) (*NotificationPreferences, error) {
+	var out NotificationPreferences
+	body := map[string]bool{
+		"push_assigned": preferences.PushAssigned,
+		"push_due_soon": preferences.PushDueSoon,
+		"push_changes":  preferences.PushChanges,
+	}
+	if err := s.client.do(ctx, http.MethodPut, "/v1/users/me/notification-preferences", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
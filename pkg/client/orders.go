@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type OrdersService struct {
+	client *Client
+}
+
+// Create places an order, taking its items out of stock.
+func (s *OrdersService) Create(ctx context.Context, req OrderRequest) (*Order, error) {
+	var out Order
+	if err := s.client.do(ctx, http.MethodPost, "/v1/orders", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *OrdersService) Get(ctx context.Context, id uuid.UUID) (*Order, error) {
+	var out Order
+	if err := s.client.do(ctx, http.MethodGet, "/v1/orders/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *OrdersService) List(ctx context.Context, opts ListOptions) ([]Order, error) {
+	var out []Order
+	if err := s.client.do(ctx, http.MethodGet, "/v1/orders", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *OrdersService) All(ctx context.Context, opts ListOptions) iter.Seq2[Order, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+// Update replaces the notes and items of a pending order. The warehouse is
+// kept.
+func (s *OrdersService) Update(ctx context.Context, id uuid.UUID, req OrderRequest) (*Order, error) {
+	var out Order
+	if err := s.client.do(ctx, http.MethodPut, "/v1/orders/"+id.String(), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *OrdersService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/orders/"+id.String(), nil, nil, nil)
+}
+
+// SetStatus moves an order along its workflow; cancelling puts its items
+// back into stock.
+func (s *OrdersService) SetStatus(ctx context.Context, id uuid.UUID, status string) (*Order, error) {
+	var out Order
+	body := map[string]string{"status": status}
+	if err := s.client.do(ctx, http.MethodPut, "/v1/orders/"+id.String()+"/status", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
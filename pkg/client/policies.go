@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// PoliciesService reads and accepts the terms of service and privacy policy.
+// Publishing and auditing acceptances are admin only.
+type PoliciesService struct {
+	client *Client
+}
+
+// Current returns the latest version of each policy. It works without a
+// token.
+func (s *PoliciesService) Current(ctx context.Context) ([]PolicyDocument, error) {
+	var out []PolicyDocument
+	if err := s.client.do(ctx, http.MethodGet, "/v1/policies", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *PoliciesService) Get(ctx context.Context, id uuid.UUID) (*PolicyDocument, error) {
+	var out PolicyDocument
+	if err := s.client.do(ctx, http.MethodGet, "/v1/policies/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Accept records the caller's acceptance of a document. Only the latest
+// version of a kind can be accepted; older ones fail with a 409 APIError.
+func (s *PoliciesService) Accept(ctx context.Context, id uuid.UUID) (*PolicyAcceptance, error) {
+	var out PolicyAcceptance
+	if err := s.client.do(ctx, http.MethodPost, "/v1/policies/"+id.String()+"/accept", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Status returns the caller's acceptances and the documents still pending.
+// While enforcement is on, other requests fail with a 426 APIError until
+// Pending is empty.
+func (s *PoliciesService) Status(ctx context.Context) (*PolicyStatus, error) {
+	var out PolicyStatus
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users/me/policies", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Publish stores a new version, which users then have to accept (admin
+// only).
+func (s *PoliciesService) Publish(ctx context.Context, req PublishPolicyRequest) (*PolicyDocument, error) {
+	var out PolicyDocument
+	if err := s.client.do(ctx, http.MethodPost, "/v1/admin/policies", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// List returns every published version, newest first (admin only). Filter by
+// "kind".
+func (s *PoliciesService) List(ctx context.Context, opts ListOptions) ([]PolicyDocument, error) {
+	var out []PolicyDocument
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/policies", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Acceptances returns who accepted a document, newest first (admin only).
+func (s *PoliciesService) Acceptances(ctx context.Context, id uuid.UUID, opts ListOptions) ([]PolicyAcceptance, error) {
+	var out []PolicyAcceptance
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/policies/"+id.String()+"/acceptances", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
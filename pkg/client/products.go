@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ProductsService struct {
+	client *Client
+}
+
+func (s *ProductsService) Create(ctx context.Context, req CreateProductRequest) (*Product, error) {
+	var out Product
+	if err := s.client.do(ctx, http.MethodPost, "/v1/products", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProductsService) Get(ctx context.Context, id uuid.UUID) (*Product, error) {
+	var out Product
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProductsService) GetBySKU(ctx context.Context, sku string) (*Product, error) {
+	var out Product
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products/sku/"+url.PathEscape(sku), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProductsService) GetByBarcode(ctx context.Context, barcode string) (*Product, error) {
+	var out Product
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products/barcode/"+url.PathEscape(barcode), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProductsService) List(ctx context.Context, opts ListOptions) ([]Product, error) {
+	page, err := s.Page(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return page.Data, nil
+}
+
+// Page returns one page of the list together with the total count.
+func (s *ProductsService) Page(ctx context.Context, opts ListOptions) (*Page[Product], error) {
+	var out Page[Product]
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListWithFacets returns one page of products together with counts per facet
+// (category, price, stock) for the same filters.
+func (s *ProductsService) ListWithFacets(ctx context.Context, opts ListOptions, facets ...string) (*ProductFacetsPage, error) {
+	query := opts.query()
+	query.Set("facets", strings.Join(facets, ","))
+
+	var out ProductFacetsPage
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Related returns up to limit products recommended next to the product.
+// A limit of zero uses the server default.
+func (s *ProductsService) Related(ctx context.Context, id uuid.UUID, limit int) ([]Product, error) {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var out []Product
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products/"+id.String()+"/related", params, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Suggest returns up to limit products matching query, best matches first.
+// A limit of zero uses the server default.
+func (s *ProductsService) Suggest(ctx context.Context, query string, limit int) ([]Suggestion, error) {
+	params := url.Values{"q": {query}}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var out []Suggestion
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products/suggest", params, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Search runs a full-text search over product names, descriptions and SKUs
+// and returns one page of hits, best ranked first. query is written like a
+// web search: "quoted phrases", or, -excluded words.
+func (s *ProductsService) Search(ctx context.Context, query string, opts ListOptions) (*Page[ProductSearchHit], error) {
+	params := opts.query()
+	params.Set("q", query)
+
+	var out Page[ProductSearchHit]
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products/search", params, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// LowStock returns the products at or below their reorder level, or the
+// server's low stock threshold when they have none.
+func (s *ProductsService) LowStock(ctx context.Context, opts ListOptions) ([]Product, error) {
+	var out []Product
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products/low-stock", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *ProductsService) All(ctx context.Context, opts ListOptions) iter.Seq2[Product, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+func (s *ProductsService) Update(ctx context.Context, id uuid.UUID, record Product) (*Product, error) {
+	var out Product
+	if err := s.client.do(ctx, http.MethodPut, "/v1/products/"+id.String(), nil, record, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Patch changes only the fields in patch, a JSON Merge Patch: fields left
+// out keep their values and nil values clear theirs.
+func (s *ProductsService) Patch(ctx context.Context, id uuid.UUID, patch map[string]interface{}) (*Product, error) {
+	var out Product
+	if err := s.client.do(ctx, http.MethodPatch, "/v1/products/"+id.String(), nil, patch, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProductsService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/products/"+id.String(), nil, nil, nil)
+}
+
+// AdjustStock changes the stock by quantity, which may be negative, and
+// records the change with a reason (damage, recount, sale or return).
+func (s *ProductsService) AdjustStock(ctx context.Context, id uuid.UUID, req StockAdjustmentRequest) (*StockAdjustment, error) {
+	var out StockAdjustment
+	if err := s.client.do(ctx, http.MethodPost, "/v1/products/"+id.String()+"/stock-adjustments", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProductsService) StockAdjustments(ctx context.Context, id uuid.UUID, opts ListOptions) ([]StockAdjustment, error) {
+	var out []StockAdjustment
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products/"+id.String()+"/stock-adjustments", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StockMovements lists the adjustments and warehouse transfers of a product
+// together. Filter on "reason" or "warehouse_id" to narrow it.
+func (s *ProductsService) StockMovements(ctx context.Context, id uuid.UUID, opts ListOptions) ([]StockMovement, error) {
+	var out []StockMovement
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products/"+id.String()+"/stock-movements", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *ProductsService) Archive(ctx context.Context, id uuid.UUID) (*Product, error) {
+	var out Product
+	if err := s.client.do(ctx, http.MethodPost, "/v1/products/"+id.String()+"/archive", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProductsService) Unarchive(ctx context.Context, id uuid.UUID) (*Product, error) {
+	var out Product
+	if err := s.client.do(ctx, http.MethodPost, "/v1/products/"+id.String()+"/unarchive", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UploadImage adds a JPEG, PNG, GIF or WebP image read from content to the
+// product. fileName is only kept for downloads; the type is read from the
+// content.
+func (s *ProductsService) UploadImage(ctx context.Context, id uuid.UUID, fileName string, content io.Reader) (*Attachment, error) {
+	var out Attachment
+	if err := s.client.upload(ctx, "/v1/products/"+id.String()+"/images", "file", fileName, content, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProductsService) Images(ctx context.Context, id uuid.UUID) ([]Attachment, error) {
+	var out []Attachment
+	if err := s.client.do(ctx, http.MethodGet, "/v1/products/"+id.String()+"/images", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *ProductsService) DeleteImage(ctx context.Context, id, imageID uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/products/"+id.String()+"/images/"+imageID.String(), nil, nil, nil)
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type ProjectItemsService struct {
+	client *Client
+}
+
+func (s *ProjectItemsService) Create(ctx context.Context, req CreateProjectItemRequest) (*ProjectItem, error) {
+	var out ProjectItem
+	if err := s.client.do(ctx, http.MethodPost, "/v1/project-items", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectItemsService) Get(ctx context.Context, id uuid.UUID) (*ProjectItem, error) {
+	var out ProjectItem
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-items/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectItemsService) List(ctx context.Context, opts ListOptions) ([]ProjectItem, error) {
+	page, err := s.Page(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return page.Data, nil
+}
+
+// Page returns one page of the list together with the total count.
+func (s *ProjectItemsService) Page(ctx context.Context, opts ListOptions) (*Page[ProjectItem], error) {
+	var out Page[ProjectItem]
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-items", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *ProjectItemsService) All(ctx context.Context, opts ListOptions) iter.Seq2[ProjectItem, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+// Overdue lists open items past their due date. Without a "project_id" filter
+// the server scopes the list to items assigned to the authenticated user.
+func (s *ProjectItemsService) Overdue(ctx context.Context, opts ListOptions) ([]ProjectItem, error) {
+	var out []ProjectItem
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-items/overdue", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Upcoming lists open items due within the next days days, scoped like Overdue.
+func (s *ProjectItemsService) Upcoming(ctx context.Context, days int, opts ListOptions) ([]ProjectItem, error) {
+	query := opts.query()
+	query.Set("days", strconv.Itoa(days))
+
+	var out []ProjectItem
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-items/upcoming", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *ProjectItemsService) ListByProject(ctx context.Context, projectID uuid.UUID) ([]ProjectItem, error) {
+	var out []ProjectItem
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-items/project/"+projectID.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Children lists the direct sub-tasks of an item.
+func (s *ProjectItemsService) Children(ctx context.Context, id uuid.UUID) ([]ProjectItem, error) {
+	var out []ProjectItem
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-items/"+id.String()+"/children", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *ProjectItemsService) Update(ctx context.Context, id uuid.UUID, record ProjectItem) (*ProjectItem, error) {
+	var out ProjectItem
+	if err := s.client.do(ctx, http.MethodPut, "/v1/project-items/"+id.String(), nil, record, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Patch changes only the fields in patch, a JSON Merge Patch: fields left
+// out keep their values and nil values clear theirs.
+func (s *ProjectItemsService) Patch(ctx context.Context, id uuid.UUID, patch map[string]interface{}) (*ProjectItem, error) {
+	var out ProjectItem
+	if err := s.client.do(ctx, http.MethodPatch, "/v1/project-items/"+id.String(), nil, patch, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectItemsService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/project-items/"+id.String(), nil, nil, nil)
+}
+
+func (s *ProjectItemsService) Watch(ctx context.Context, id uuid.UUID) (*Watch, error) {
+	var out Watch
+	if err := s.client.do(ctx, http.MethodPost, "/v1/project-items/"+id.String()+"/watch", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectItemsService) Unwatch(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/project-items/"+id.String()+"/watch", nil, nil, nil)
+}
+
+func (s *ProjectItemsService) Watchers(ctx context.Context, id uuid.UUID, opts ListOptions) ([]Watch, error) {
+	var out []Watch
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-items/"+id.String()+"/watchers", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *ProjectItemsService) Assignments(ctx context.Context, id uuid.UUID) ([]ProjectItemAssignment, error) {
+	var out []ProjectItemAssignment
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-items/"+id.String()+"/assignments", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Transition moves an item along its status workflow, answering 409 when
+// its current status cannot move to status.
+func (s *ProjectItemsService) Transition(ctx context.Context, id uuid.UUID, status string) (*ProjectItem, error) {
+	var out ProjectItem
+	body := map[string]string{"status": status}
+	if err := s.client.do(ctx, http.MethodPost, "/v1/project-items/"+id.String()+"/transition", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectItemsService) Transitions(ctx context.Context, id uuid.UUID) ([]ProjectItemTransition, error) {
+	var out []ProjectItemTransition
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-items/"+id.String()+"/transitions", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddComment posts a comment on the item as the authenticated user.
+func (s *ProjectItemsService) AddComment(ctx context.Context, id uuid.UUID, body string) (*Comment, error) {
+	var out Comment
+	req := map[string]string{"body": body}
+	if err := s.client.do(ctx, http.MethodPost, "/v1/project-items/"+id.String()+"/comments", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Comments returns one page of the item's comments, oldest first unless
+// opts sorts them otherwise.
+func (s *ProjectItemsService) Comments(ctx context.Context, id uuid.UUID, opts ListOptions) (*Page[Comment], error) {
+	var out Page[Comment]
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-items/"+id.String()+"/comments", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteComment deletes a comment of the item. Only its author or an admin
+// may delete it.
+func (s *ProjectItemsService) DeleteComment(ctx context.Context, id, commentID uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/project-items/"+id.String()+"/comments/"+commentID.String(), nil, nil, nil)
+}
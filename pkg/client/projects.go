@@ -0,0 +1,251 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectsService struct {
+	client *Client
+}
+
+func (s *ProjectsService) Create(ctx context.Context, req CreateProjectRequest) (*Project, error) {
+	var out Project
+	if err := s.client.do(ctx, http.MethodPost, "/v1/projects", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectsService) Get(ctx context.Context, id uuid.UUID) (*Project, error) {
+	var out Project
+	if err := s.client.do(ctx, http.MethodGet, "/v1/projects/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectsService) List(ctx context.Context, opts ListOptions) ([]Project, error) {
+	page, err := s.Page(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return page.Data, nil
+}
+
+// Page returns one page of the list together with the total count.
+func (s *ProjectsService) Page(ctx context.Context, opts ListOptions) (*Page[Project], error) {
+	var out Page[Project]
+	if err := s.client.do(ctx, http.MethodGet, "/v1/projects", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *ProjectsService) All(ctx context.Context, opts ListOptions) iter.Seq2[Project, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+func (s *ProjectsService) Update(ctx context.Context, id uuid.UUID, record Project) (*Project, error) {
+	var out Project
+	if err := s.client.do(ctx, http.MethodPut, "/v1/projects/"+id.String(), nil, record, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Patch changes only the fields in patch, a JSON Merge Patch: fields left
+// out keep their values and nil values clear theirs.
+func (s *ProjectsService) Patch(ctx context.Context, id uuid.UUID, patch map[string]interface{}) (*Project, error) {
+	var out Project
+	if err := s.client.do(ctx, http.MethodPatch, "/v1/projects/"+id.String(), nil, patch, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectsService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/projects/"+id.String(), nil, nil, nil)
+}
+
+func (s *ProjectsService) Archive(ctx context.Context, id uuid.UUID) (*Project, error) {
+	var out Project
+	if err := s.client.do(ctx, http.MethodPost, "/v1/projects/"+id.String()+"/archive", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectsService) Unarchive(ctx context.Context, id uuid.UUID) (*Project, error) {
+	var out Project
+	if err := s.client.do(ctx, http.MethodPost, "/v1/projects/"+id.String()+"/unarchive", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectsService) CreateExpense(ctx context.Context, projectID uuid.UUID, req ExpenseRequest) (*Expense, error) {
+	var out Expense
+	if err := s.client.do(ctx, http.MethodPost, "/v1/projects/"+projectID.String()+"/expenses", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectsService) GetExpense(ctx context.Context, projectID, id uuid.UUID) (*Expense, error) {
+	var out Expense
+	if err := s.client.do(ctx, http.MethodGet, "/v1/projects/"+projectID.String()+"/expenses/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectsService) Expenses(ctx context.Context, projectID uuid.UUID, opts ListOptions) ([]Expense, error) {
+	var out []Expense
+	if err := s.client.do(ctx, http.MethodGet, "/v1/projects/"+projectID.String()+"/expenses", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *ProjectsService) UpdateExpense(ctx context.Context, projectID, id uuid.UUID, req ExpenseRequest) (*Expense, error) {
+	var out Expense
+	if err := s.client.do(ctx, http.MethodPut, "/v1/projects/"+projectID.String()+"/expenses/"+id.String(), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectsService) DeleteExpense(ctx context.Context, projectID, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/projects/"+projectID.String()+"/expenses/"+id.String(), nil, nil, nil)
+}
+
+// Stats reports spending against the project budget.
+func (s *ProjectsService) Stats(ctx context.Context, projectID uuid.UUID) (*ProjectBudgetStats, error) {
+	var out ProjectBudgetStats
+	if err := s.client.do(ctx, http.MethodGet, "/v1/projects/"+projectID.String()+"/stats", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Hours sums the estimated and actual hours of the project's items by
+// status, assignee and due week. from and to optionally bound the due date.
+func (s *ProjectsService) Hours(ctx context.Context, projectID uuid.UUID, from, to *time.Time) (*HoursRollup, error) {
+	var out HoursRollup
+	if err := s.client.do(ctx, http.MethodGet, "/v1/projects/"+projectID.String()+"/hours", hoursQuery(from, to), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func hoursQuery(from, to *time.Time) url.Values {
+	query := url.Values{}
+	if from != nil {
+		query.Set("from", from.Format(time.RFC3339))
+	}
+	if to != nil {
+		query.Set("to", to.Format(time.RFC3339))
+	}
+	return query
+}
+
+// Board lays the project's items out in one column per status. opts pages
+// and sorts every column on its own; statuses, when given, picks the
+// columns.
+func (s *ProjectsService) Board(ctx context.Context, projectID uuid.UUID, statuses []string, opts ListOptions) (*ProjectBoard, error) {
+	query := opts.query()
+	for _, status := range statuses {
+		query.Add("status", status)
+	}
+
+	var out ProjectBoard
+	if err := s.client.do(ctx, http.MethodGet, "/v1/projects/"+projectID.String()+"/board", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// MoveOnBoard places an item at position, counted from zero, in the board
+// column of status, or in its own column when status is empty.
+func (s *ProjectsService) MoveOnBoard(ctx context.Context, projectID, itemID uuid.UUID, status string, position int) (*ProjectItem, error) {
+	body := map[string]interface{}{"position": position}
+	if status != "" {
+		body["status"] = status
+	}
+
+	var out ProjectItem
+	if err := s.client.do(ctx, http.MethodPatch, "/v1/projects/"+projectID.String()+"/board/items/"+itemID.String(), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Export renders the project as "json" or "pdf" and returns the file. Large
+// projects are exported in the background: data is then nil and the returned
+// export is polled with ExportStatus and fetched with DownloadExport.
+func (s *ProjectsService) Export(ctx context.Context, id uuid.UUID, format string) ([]byte, *ProjectExport, error) {
+	query := url.Values{}
+	if format != "" {
+		query.Set("format", format)
+	}
+
+	var raw rawBody
+	if err := s.client.do(ctx, http.MethodGet, "/v1/projects/"+id.String()+"/export", query, nil, &raw); err != nil {
+		return nil, nil, err
+	}
+
+	if raw.StatusCode == http.StatusAccepted {
+		var export ProjectExport
+		if err := json.Unmarshal(raw.Data, &export); err != nil {
+			return nil, nil, err
+		}
+		return nil, &export, nil
+	}
+	return raw.Data, nil, nil
+}
+
+func (s *ProjectsService) ExportStatus(ctx context.Context, exportID uuid.UUID) (*ProjectExport, error) {
+	var out ProjectExport
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-exports/"+exportID.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DownloadExport fetches a finished background export. It fails with a 409
+// APIError while the export is still pending.
+func (s *ProjectsService) DownloadExport(ctx context.Context, exportID uuid.UUID) ([]byte, error) {
+	var raw rawBody
+	if err := s.client.do(ctx, http.MethodGet, "/v1/project-exports/"+exportID.String()+"/download", nil, nil, &raw); err != nil {
+		return nil, err
+	}
+	return raw.Data, nil
+}
+
+func (s *ProjectsService) Watch(ctx context.Context, id uuid.UUID) (*Watch, error) {
+	var out Watch
+	if err := s.client.do(ctx, http.MethodPost, "/v1/projects/"+id.String()+"/watch", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ProjectsService) Unwatch(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/projects/"+id.String()+"/watch", nil, nil, nil)
+}
+
+func (s *ProjectsService) Watchers(ctx context.Context, id uuid.UUID, opts ListOptions) ([]Watch, error) {
+	var out []Watch
+	if err := s.client.do(ctx, http.MethodGet, "/v1/projects/"+id.String()+"/watchers", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type PurchaseOrdersService struct {
+	client *Client
+}
+
+func (s *PurchaseOrdersService) Create(ctx context.Context, req PurchaseOrderRequest) (*PurchaseOrder, error) {
+	var out PurchaseOrder
+	if err := s.client.do(ctx, http.MethodPost, "/v1/purchase-orders", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *PurchaseOrdersService) Get(ctx context.Context, id uuid.UUID) (*PurchaseOrder, error) {
+	var out PurchaseOrder
+	if err := s.client.do(ctx, http.MethodGet, "/v1/purchase-orders/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *PurchaseOrdersService) List(ctx context.Context, opts ListOptions) ([]PurchaseOrder, error) {
+	var out []PurchaseOrder
+	if err := s.client.do(ctx, http.MethodGet, "/v1/purchase-orders", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *PurchaseOrdersService) All(ctx context.Context, opts ListOptions) iter.Seq2[PurchaseOrder, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+// Update replaces the header and lines of a draft order.
+func (s *PurchaseOrdersService) Update(ctx context.Context, id uuid.UUID, req PurchaseOrderRequest) (*PurchaseOrder, error) {
+	var out PurchaseOrder
+	if err := s.client.do(ctx, http.MethodPut, "/v1/purchase-orders/"+id.String(), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *PurchaseOrdersService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/purchase-orders/"+id.String(), nil, nil, nil)
+}
+
+func (s *PurchaseOrdersService) Submit(ctx context.Context, id uuid.UUID) (*PurchaseOrder, error) {
+	var out PurchaseOrder
+	if err := s.client.do(ctx, http.MethodPost, "/v1/purchase-orders/"+id.String()+"/submit", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Receive books every line into stock and records the unit costs as the
+// products' cost prices.
+func (s *PurchaseOrdersService) Receive(ctx context.Context, id uuid.UUID) (*PurchaseOrder, error) {
+	var out PurchaseOrder
+	if err := s.client.do(ctx, http.MethodPost, "/v1/purchase-orders/"+id.String()+"/receive", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type ReportSubscriptionsService struct {
+	client *Client
+}
+
+func (s *ReportSubscriptionsService) Create(ctx context.Context, req ReportSubscriptionRequest) (*ReportSubscription, error) {
+	var out ReportSubscription
+	if err := s.client.do(ctx, http.MethodPost, "/v1/report-subscriptions", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ReportSubscriptionsService) Get(ctx context.Context, id uuid.UUID) (*ReportSubscription, error) {
+	var out ReportSubscription
+	if err := s.client.do(ctx, http.MethodGet, "/v1/report-subscriptions/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// List returns the caller's report subscriptions. Filter by "report" to get
+// those of one report.
+func (s *ReportSubscriptionsService) List(ctx context.Context, opts ListOptions) ([]ReportSubscription, error) {
+	var out []ReportSubscription
+	if err := s.client.do(ctx, http.MethodGet, "/v1/report-subscriptions", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *ReportSubscriptionsService) All(ctx context.Context, opts ListOptions) iter.Seq2[ReportSubscription, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+// Update replaces everything but the report, which cannot change and is
+// ignored.
+func (s *ReportSubscriptionsService) Update(ctx context.Context, id uuid.UUID, req ReportSubscriptionRequest) (*ReportSubscription, error) {
+	var out ReportSubscription
+	if err := s.client.do(ctx, http.MethodPut, "/v1/report-subscriptions/"+id.String(), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *ReportSubscriptionsService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/report-subscriptions/"+id.String(), nil, nil, nil)
+}
+
+// Deliveries returns the subscription's delivery history, newest first.
+func (s *ReportSubscriptionsService) Deliveries(ctx context.Context, id uuid.UUID, opts ListOptions) ([]ReportDelivery, error) {
+	var out []ReportDelivery
+	if err := s.client.do(ctx, http.MethodGet, "/v1/report-subscriptions/"+id.String()+"/deliveries", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type ReportsService struct {
+	client *Client
+}
+
+// ReportOptions groups and filters a report. Interval is "day", "week",
+// "month" or "year"; Filters holds the report's own filters such as
+// "status" or "include_archived".
+type ReportOptions struct {
+	GroupBy  string
+	Interval string
+	From     *time.Time
+	To       *time.Time
+	Filters  map[string]string
+}
+
+func (s *ReportsService) ItemsByStatus(ctx context.Context, opts ReportOptions) ([]ItemsReportRow, error) {
+	var out []ItemsReportRow
+	if err := s.client.do(ctx, http.MethodGet, "/v1/reports/items-by-status", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *ReportsService) StockByCategory(ctx context.Context, opts ReportOptions) ([]StockReportRow, error) {
+	var out []StockReportRow
+	if err := s.client.do(ctx, http.MethodGet, "/v1/reports/stock-by-category", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *ReportsService) ProjectsBudget(ctx context.Context, opts ReportOptions) ([]ProjectsReportRow, error) {
+	var out []ProjectsReportRow
+	if err := s.client.do(ctx, http.MethodGet, "/v1/reports/projects-budget", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (o ReportOptions) query() url.Values {
+	q := hoursQuery(o.From, o.To)
+	for key, value := range o.Filters {
+		q.Set(key, value)
+	}
+	if o.GroupBy != "" {
+		q.Set("group_by", o.GroupBy)
+	}
+	if o.Interval != "" {
+		q.Set("interval", o.Interval)
+	}
+	return q
+}
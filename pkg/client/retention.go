@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// RetentionService manages retention rules and their runs. Every endpoint is
+// admin only.
+type RetentionService struct {
+	client *Client
+}
+
+func (s *RetentionService) CreateRule(ctx context.Context, req RetentionRuleRequest) (*RetentionRule, error) {
+	var out RetentionRule
+	if err := s.client.do(ctx, http.MethodPost, "/v1/admin/retention-rules", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *RetentionService) GetRule(ctx context.Context, id uuid.UUID) (*RetentionRule, error) {
+	var out RetentionRule
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/retention-rules/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *RetentionService) ListRules(ctx context.Context) ([]RetentionRule, error) {
+	var out []RetentionRule
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/retention-rules", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateRule replaces the action, period and enabled flag. The entity cannot
+// change and is ignored.
+func (s *RetentionService) UpdateRule(ctx context.Context, id uuid.UUID, req RetentionRuleRequest) (*RetentionRule, error) {
+	var out RetentionRule
+	if err := s.client.do(ctx, http.MethodPut, "/v1/admin/retention-rules/"+id.String(), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *RetentionService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/admin/retention-rules/"+id.String(), nil, nil, nil)
+}
+
+// Run applies every enabled rule now, or only ruleID when it is not nil. A
+// dry run changes nothing and reports how many records each rule would
+// affect.
+func (s *RetentionService) Run(ctx context.Context, ruleID *uuid.UUID, dryRun bool) ([]RetentionRun, error) {
+	query := url.Values{}
+	if ruleID != nil {
+		query.Set("rule_id", ruleID.String())
+	}
+	if dryRun {
+		query.Set("dry_run", strconv.FormatBool(dryRun))
+	}
+
+	var out []RetentionRun
+	if err := s.client.do(ctx, http.MethodPost, "/v1/admin/retention-runs", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Runs returns the recorded runs, newest first. Filter by "rule_id" or
+// "dry_run".
+func (s *RetentionService) Runs(ctx context.Context, opts ListOptions) ([]RetentionRun, error) {
+	var out []RetentionRun
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/retention-runs", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AllRuns iterates over every run matching opts, fetching one page at a time.
+func (s *RetentionService) AllRuns(ctx context.Context, opts ListOptions) iter.Seq2[RetentionRun, error] {
+	return paginate(ctx, opts, s.Runs)
+}
+
+// Metrics totals the runs and affected records per entity, dry runs aside.
+func (s *RetentionService) Metrics(ctx context.Context) ([]RetentionMetric, error) {
+	var out []RetentionMetric
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/retention-runs/metrics", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Archived returns the records archive rules moved out of their tables,
+// most recently archived first. Filter by "entity", "project_id",
+// "archived_from" or "archived_to".
+func (s *RetentionService) Archived(ctx context.Context, opts ListOptions) ([]ArchivedRecord, error) {
+	var out []ArchivedRecord
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/archived-records", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AllArchived iterates over every archived record matching opts, fetching
+// one page at a time.
+func (s *RetentionService) AllArchived(ctx context.Context, opts ListOptions) iter.Seq2[ArchivedRecord, error] {
+	return paginate(ctx, opts, s.Archived)
+}
+
+func (s *RetentionService) GetArchived(ctx context.Context, entity string, id uuid.UUID) (*ArchivedRecord, error) {
+	var out ArchivedRecord
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/archived-records/"+url.PathEscape(entity)+"/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RestoreArchived moves an archived record back into its table.
+func (s *RetentionService) RestoreArchived(ctx context.Context, entity string, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodPost, "/v1/admin/archived-records/"+url.PathEscape(entity)+"/"+id.String()+"/restore", nil, nil, nil)
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type SavedFiltersService struct {
+	client *Client
+}
+
+func (s *SavedFiltersService) Create(ctx context.Context, req SavedFilterRequest) (*SavedFilter, error) {
+	var out SavedFilter
+	if err := s.client.do(ctx, http.MethodPost, "/v1/saved-filters", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *SavedFiltersService) Get(ctx context.Context, id uuid.UUID) (*SavedFilter, error) {
+	var out SavedFilter
+	if err := s.client.do(ctx, http.MethodGet, "/v1/saved-filters/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// List returns the caller's saved filters and the ones shared by others.
+// Filter by "entity" to get those of one list endpoint.
+func (s *SavedFiltersService) List(ctx context.Context, opts ListOptions) ([]SavedFilter, error) {
+	var out []SavedFilter
+	if err := s.client.do(ctx, http.MethodGet, "/v1/saved-filters", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *SavedFiltersService) All(ctx context.Context, opts ListOptions) iter.Seq2[SavedFilter, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+// Update replaces the filter's name, query, sort and sharing. The entity
+// cannot change and is ignored.
+func (s *SavedFiltersService) Update(ctx context.Context, id uuid.UUID, req SavedFilterRequest) (*SavedFilter, error) {
+	var out SavedFilter
+	if err := s.client.do(ctx, http.MethodPut, "/v1/saved-filters/"+id.String(), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *SavedFiltersService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/saved-filters/"+id.String(), nil, nil, nil)
+}
@@ -0,0 +1,305 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UsersService struct {
+	client *Client
+}
+
+func (s *UsersService) Create(ctx context.Context, req CreateUserRequest) (*User, error) {
+	var out User
+	if err := s.client.do(ctx, http.MethodPost, "/v1/users", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *UsersService) Get(ctx context.Context, id uuid.UUID) (*User, error) {
+	var out User
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Suggest returns up to limit users matching query, best matches first.
+// A limit of zero uses the server default.
+func (s *UsersService) Suggest(ctx context.Context, query string, limit int) ([]Suggestion, error) {
+	params := url.Values{"q": {query}}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var out []Suggestion
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users/suggest", params, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *UsersService) List(ctx context.Context, opts ListOptions) ([]User, error) {
+	page, err := s.Page(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return page.Data, nil
+}
+
+// Page returns one page of the list together with the total count.
+func (s *UsersService) Page(ctx context.Context, opts ListOptions) (*Page[User], error) {
+	var out Page[User]
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *UsersService) All(ctx context.Context, opts ListOptions) iter.Seq2[User, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+func (s *UsersService) Update(ctx context.Context, id uuid.UUID, record User) (*User, error) {
+	var out User
+	if err := s.client.do(ctx, http.MethodPut, "/v1/users/"+id.String(), nil, record, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Patch changes only the fields in patch, a JSON Merge Patch: fields left
+// out keep their values and nil values clear theirs.
+func (s *UsersService) Patch(ctx context.Context, id uuid.UUID, patch map[string]interface{}) (*User, error) {
+	var out User
+	if err := s.client.do(ctx, http.MethodPatch, "/v1/users/"+id.String(), nil, patch, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *UsersService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/users/"+id.String(), nil, nil, nil)
+}
+
+// Me returns the caller's account.
+func (s *UsersService) Me(ctx context.Context) (*User, error) {
+	var out User
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users/me", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateProfile changes the caller's name and email locale.
+func (s *UsersService) UpdateProfile(ctx context.Context, req UpdateProfileRequest) (*User, error) {
+	var out User
+	if err := s.client.do(ctx, http.MethodPut, "/v1/users/me", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// EnrollMFA generates a TOTP secret for the caller. Two-factor
+// authentication is on once ConfirmMFA accepts a code from it.
+func (s *UsersService) EnrollMFA(ctx context.Context) (*MFAEnrollment, error) {
+	var out MFAEnrollment
+	if err := s.client.do(ctx, http.MethodPost, "/v1/users/me/mfa/enroll", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConfirmMFA turns two-factor authentication on for the caller.
+func (s *UsersService) ConfirmMFA(ctx context.Context, code string) (*User, error) {
+	var out User
+	body := map[string]string{"code": code}
+	if err := s.client.do(ctx, http.MethodPost, "/v1/users/me/mfa/confirm", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DisableMFA turns two-factor authentication off for the caller.
+func (s *UsersService) DisableMFA(ctx context.Context, code string) (*User, error) {
+	var out User
+	body := map[string]string{"code": code}
+	if err := s.client.do(ctx, http.MethodPost, "/v1/users/me/mfa/disable", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteAccount deletes the caller's account. It stays restorable with
+// Client.RestoreAccount until the returned user's EraseAfter.
+func (s *UsersService) DeleteAccount(ctx context.Context) (*User, error) {
+	var out User
+	if err := s.client.do(ctx, http.MethodDelete, "/v1/users/me", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminDeleteAccount deletes another user's account with the same grace
+// period as DeleteAccount (admin only).
+func (s *UsersService) AdminDeleteAccount(ctx context.Context, id uuid.UUID) (*User, error) {
+	var out User
+	if err := s.client.do(ctx, http.MethodDelete, "/v1/admin/users/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RestoreAccount undoes an account deletion during its grace period (admin
+// only).
+func (s *UsersService) RestoreAccount(ctx context.Context, id uuid.UUID) (*User, error) {
+	var out User
+	if err := s.client.do(ctx, http.MethodPost, "/v1/admin/users/"+id.String()+"/restore", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AdminList lists users through the admin endpoint, which accepts the
+// filters "role", "status", "include_deleted", "created_from", "created_to",
+// "last_login_from" and "last_login_to" (admin only).
+func (s *UsersService) AdminList(ctx context.Context, opts ListOptions) ([]User, error) {
+	page, err := s.AdminPage(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return page.Data, nil
+}
+
+// AdminPage is AdminList with the total count of matching users.
+func (s *UsersService) AdminPage(ctx context.Context, opts ListOptions) (*Page[User], error) {
+	var out Page[User]
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/users", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Deactivate switches the account off (admin only). A non-nil suspendedUntil
+// suspends it until then instead.
+func (s *UsersService) Deactivate(ctx context.Context, id uuid.UUID, suspendedUntil *time.Time) (*User, error) {
+	body := map[string]*time.Time{"suspended_until": suspendedUntil}
+
+	var out User
+	if err := s.client.do(ctx, http.MethodPost, "/v1/admin/users/"+id.String()+"/deactivate", nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *UsersService) Reactivate(ctx context.Context, id uuid.UUID) (*User, error) {
+	var out User
+	if err := s.client.do(ctx, http.MethodPost, "/v1/admin/users/"+id.String()+"/reactivate", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Unlock ends the lockout failed logins put on the account (admin only).
+func (s *UsersService) Unlock(ctx context.Context, id uuid.UUID) (*User, error) {
+	var out User
+	if err := s.client.do(ctx, http.MethodPost, "/v1/admin/users/"+id.String()+"/unlock", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Stale lists accounts nobody has logged into for at least days days (admin
+// only). Zero days uses the server default of 90.
+func (s *UsersService) Stale(ctx context.Context, days int, opts ListOptions) ([]User, error) {
+	query := opts.query()
+	if days > 0 {
+		query.Set("days", strconv.Itoa(days))
+	}
+
+	var out []User
+	if err := s.client.do(ctx, http.MethodGet, "/v1/admin/users/stale", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Hours sums the estimated and actual hours of the items assigned to the user
+// by status and due week. from and to optionally bound the due date.
+func (s *UsersService) Hours(ctx context.Context, id uuid.UUID, from, to *time.Time) (*HoursRollup, error) {
+	var out HoursRollup
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users/"+id.String()+"/hours", hoursQuery(from, to), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RequestExport starts assembling an archive of everything stored about the
+// caller. The returned export is polled with Export and fetched with
+// DownloadExport; a 409 APIError means one is already in progress.
+func (s *UsersService) RequestExport(ctx context.Context) (*UserExport, error) {
+	var out UserExport
+	if err := s.client.do(ctx, http.MethodPost, "/v1/users/me/export", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Exports returns the caller's data exports, newest first.
+func (s *UsersService) Exports(ctx context.Context, opts ListOptions) ([]UserExport, error) {
+	var out []UserExport
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users/me/exports", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *UsersService) Export(ctx context.Context, id uuid.UUID) (*UserExport, error) {
+	var out UserExport
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users/me/exports/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DownloadExport fetches a finished data export as a zip archive. It fails
+// with a 409 APIError while the export is pending and a 410 once it expired.
+func (s *UsersService) DownloadExport(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	var raw rawBody
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users/me/exports/"+id.String()+"/download", nil, nil, &raw); err != nil {
+		return nil, err
+	}
+	return raw.Data, nil
+}
+
+// CreateCalendarFeed issues the URL calendar apps subscribe to for the
+// caller's due dates and project milestones. Calling it again issues a new
+// URL and revokes the previous one.
+func (s *UsersService) CreateCalendarFeed(ctx context.Context) (*CalendarFeedToken, error) {
+	var out CalendarFeedToken
+	if err := s.client.do(ctx, http.MethodPost, "/v1/users/me/calendar-feed", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *UsersService) CalendarFeed(ctx context.Context) (*CalendarFeed, error) {
+	var out CalendarFeed
+	if err := s.client.do(ctx, http.MethodGet, "/v1/users/me/calendar-feed", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteCalendarFeed stops the caller's calendar feed URL from working.
+func (s *UsersService) DeleteCalendarFeed(ctx context.Context) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/users/me/calendar-feed", nil, nil, nil)
+}
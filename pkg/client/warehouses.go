@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type WarehousesService struct {
+	client *Client
+}
+
+func (s *WarehousesService) Create(ctx context.Context, req CreateWarehouseRequest) (*Warehouse, error) {
+	var out Warehouse
+	if err := s.client.do(ctx, http.MethodPost, "/v1/warehouses", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *WarehousesService) Get(ctx context.Context, id uuid.UUID) (*Warehouse, error) {
+	var out Warehouse
+	if err := s.client.do(ctx, http.MethodGet, "/v1/warehouses/"+id.String(), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *WarehousesService) List(ctx context.Context, opts ListOptions) ([]Warehouse, error) {
+	var out []Warehouse
+	if err := s.client.do(ctx, http.MethodGet, "/v1/warehouses", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// All iterates over every record matching opts, fetching one page at a time.
+func (s *WarehousesService) All(ctx context.Context, opts ListOptions) iter.Seq2[Warehouse, error] {
+	return paginate(ctx, opts, s.List)
+}
+
+func (s *WarehousesService) Update(ctx context.Context, id uuid.UUID, record Warehouse) (*Warehouse, error) {
+	var out Warehouse
+	if err := s.client.do(ctx, http.MethodPut, "/v1/warehouses/"+id.String(), nil, record, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *WarehousesService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.client.do(ctx, http.MethodDelete, "/v1/warehouses/"+id.String(), nil, nil, nil)
+}
+
+func (s *WarehousesService) Stock(ctx context.Context, id uuid.UUID, opts ListOptions) ([]WarehouseStock, error) {
+	var out []WarehouseStock
+	if err := s.client.do(ctx, http.MethodGet, "/v1/warehouses/"+id.String()+"/stock", opts.query(), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Transfer moves stock between locations. Leave FromWarehouseID nil to
+// allocate unassigned stock, or ToWarehouseID nil to release it.
+func (s *WarehousesService) Transfer(ctx context.Context, req StockTransferRequest) (*StockTransfer, error) {
+	var out StockTransfer
+	if err := s.client.do(ctx, http.MethodPost, "/v1/stock-transfers", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
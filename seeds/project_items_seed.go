@@ -8,7 +8,7 @@ import (
 	"github.com/google/uuid"
 )
 
-func SeedProjectItems(repo domain.ProjectItemRepository, projectRepo domain.ProjectRepository) error {
+func SeedProjectItems(repo domain.ProjectItemRepository, projectRepo domain.ProjectRepository, ids domain.IDGenerator) error {
 	ctx := context.Background()
 
 	projects, err := projectRepo.List(ctx, domain.ProjectParams{}, domain.Pagination{Limit: 10})
@@ -24,7 +24,7 @@ func SeedProjectItems(repo domain.ProjectItemRepository, projectRepo domain.Proj
 
 	items := []domain.ProjectItem{
 		{
-			ID:             uuid.New(),
+			ID:             ids.NewID(),
 			ProjectID:      projectID,
 			Name:           "Database Design",
 			Description:    "Design and implement the database schema",
@@ -38,7 +38,7 @@ func SeedProjectItems(repo domain.ProjectItemRepository, projectRepo domain.Proj
 			UpdatedAt:      time.Now(),
 		},
 		{
-			ID:             uuid.New(),
+			ID:             ids.NewID(),
 			ProjectID:      projectID,
 			Name:           "User Authentication",
 			Description:    "Implement user registration and login system",
@@ -52,7 +52,7 @@ func SeedProjectItems(repo domain.ProjectItemRepository, projectRepo domain.Proj
 			UpdatedAt:      time.Now(),
 		},
 		{
-			ID:             uuid.New(),
+			ID:             ids.NewID(),
 			ProjectID:      projectID,
 			Name:           "Payment Integration",
 			Description:    "Integrate payment gateway (Stripe/PayPal)",
@@ -66,7 +66,7 @@ func SeedProjectItems(repo domain.ProjectItemRepository, projectRepo domain.Proj
 			UpdatedAt:      time.Now(),
 		},
 		{
-			ID:             uuid.New(),
+			ID:             ids.NewID(),
 			ProjectID:      projectID,
 			Name:           "Frontend Development",
 			Description:    "Build responsive user interface",
@@ -80,7 +80,7 @@ func SeedProjectItems(repo domain.ProjectItemRepository, projectRepo domain.Proj
 			UpdatedAt:      time.Now(),
 		},
 		{
-			ID:             uuid.New(),
+			ID:             ids.NewID(),
 			ProjectID:      projectID,
 			Name:           "Testing & QA",
 			Description:    "Comprehensive testing and quality assurance",
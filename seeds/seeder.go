@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/edumes/golang-api-rest/internal/domain"
 	"github.com/edumes/golang-api-rest/internal/infrastructure"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -12,19 +13,26 @@ import (
 type Seeder struct {
 	db     *gorm.DB
 	logger *logrus.Logger
+	ids    domain.IDGenerator
 }
 
 func NewSeeder(db *gorm.DB) *Seeder {
 	return &Seeder{
 		db:     db,
-		logger: logrus.New(),
+		logger: infrastructure.WithRedaction(logrus.New()),
+		ids:    domain.UUIDv7Generator{},
 	}
 }
 
+func (s *Seeder) WithIDGenerator(ids domain.IDGenerator) *Seeder {
+	s.ids = ids
+	return s
+}
+
 func (s *Seeder) RunAll(ctx context.Context) error {
 	s.logger.Info("Starting all seeds...")
 
-	userSeed := NewUserSeed(s.db)
+	userSeed := NewUserSeed(s.db).WithIDGenerator(s.ids)
 	if err := userSeed.Run(ctx); err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
@@ -33,7 +41,7 @@ func (s *Seeder) RunAll(ctx context.Context) error {
 	}
 
 	projectRepo := infrastructure.NewPostgresProjectRepository(s.db)
-	if err := SeedProjects(projectRepo); err != nil {
+	if err := SeedProjects(projectRepo, s.ids); err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Error("Failed to run project seeds")
@@ -41,7 +49,7 @@ func (s *Seeder) RunAll(ctx context.Context) error {
 	}
 
 	projectItemRepo := infrastructure.NewPostgresProjectItemRepository(s.db)
-	if err := SeedProjectItems(projectItemRepo, projectRepo); err != nil {
+	if err := SeedProjectItems(projectItemRepo, projectRepo, s.ids); err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Error("Failed to run project item seeds")
@@ -55,7 +63,7 @@ func (s *Seeder) RunAll(ctx context.Context) error {
 func (s *Seeder) RunUsers(ctx context.Context) error {
 	s.logger.Info("Starting user seeds...")
 
-	userSeed := NewUserSeed(s.db)
+	userSeed := NewUserSeed(s.db).WithIDGenerator(s.ids)
 	if err := userSeed.Run(ctx); err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
@@ -71,7 +79,7 @@ func (s *Seeder) RunProjects(ctx context.Context) error {
 	s.logger.Info("Starting project seeds...")
 
 	projectRepo := infrastructure.NewPostgresProjectRepository(s.db)
-	if err := SeedProjects(projectRepo); err != nil {
+	if err := SeedProjects(projectRepo, s.ids); err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Error("Failed to run project seeds")
@@ -87,7 +95,7 @@ func (s *Seeder) RunProjectItems(ctx context.Context) error {
 
 	projectRepo := infrastructure.NewPostgresProjectRepository(s.db)
 	projectItemRepo := infrastructure.NewPostgresProjectItemRepository(s.db)
-	if err := SeedProjectItems(projectItemRepo, projectRepo); err != nil {
+	if err := SeedProjectItems(projectItemRepo, projectRepo, s.ids); err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Error("Failed to run project item seeds")
@@ -6,7 +6,6 @@ import (
 
 	"github.com/edumes/golang-api-rest/internal/domain"
 	"github.com/edumes/golang-api-rest/internal/infrastructure"
-	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -15,56 +14,68 @@ import (
 type UserSeed struct {
 	db     *gorm.DB
 	logger *logrus.Logger
+	ids    domain.IDGenerator
 }
 
 func NewUserSeed(db *gorm.DB) *UserSeed {
 	return &UserSeed{
 		db:     db,
-		logger: logrus.New(),
+		logger: infrastructure.WithRedaction(logrus.New()),
+		ids:    domain.UUIDv7Generator{},
 	}
 }
 
+func (s *UserSeed) WithIDGenerator(ids domain.IDGenerator) *UserSeed {
+	s.ids = ids
+	return s
+}
+
 func (s *UserSeed) Run(ctx context.Context) error {
 	s.logger.Info("Starting user seeds...")
 
 	users := []domain.User{
 		{
-			ID:           uuid.New(),
+			ID:           s.ids.NewID(),
 			Name:         "Admin User",
 			Email:        "admin@example.com",
 			PasswordHash: s.hashPassword("admin123"),
+			Role:         domain.RoleAdmin,
 			CreatedAt:    time.Now(),
 			UpdatedAt:    time.Now(),
 		},
 		{
-			ID:           uuid.New(),
+			ID:           s.ids.NewID(),
 			Name:         "John Doe",
 			Email:        "john.doe@example.com",
 			PasswordHash: s.hashPassword("password123"),
+			Role:         domain.RoleUser,
 			CreatedAt:    time.Now(),
 			UpdatedAt:    time.Now(),
 		},
 		{
-			ID:           uuid.New(),
+			ID:           s.ids.NewID(),
 			Name:         "Jane Smith",
 			Email:        "jane.smith@example.com",
 			PasswordHash: s.hashPassword("password123"),
+			Role:         domain.RoleUser,
 			CreatedAt:    time.Now(),
 			UpdatedAt:    time.Now(),
 		},
 		{
-			ID:           uuid.New(),
+			ID:           s.ids.NewID(),
 			Name:         "Bob Johnson",
 			Email:        "bob.johnson@example.com",
 			PasswordHash: s.hashPassword("password123"),
+			Role:         domain.RoleUser,
 			CreatedAt:    time.Now(),
 			UpdatedAt:    time.Now(),
 		},
 		{
-			ID:           uuid.New(),
+			ID:           s.ids.NewID(),
 			Name:         "Alice Brown",
 			Email:        "alice.brown@example.com",
 			PasswordHash: s.hashPassword("password123"),
+			Role:         domain.RoleUser,
 			CreatedAt:    time.Now(),
 			UpdatedAt:    time.Now(),
 		},